@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GetAccountLimits retrieves the limits of the account: the maximum number
+// of databases, tables and volumes, the volume storage and the API rate
+// limits, each with its current usage.
+//
+// Provisioning tools can check the limits with CheckCapacity before they
+// start, instead of failing with ErrQuotaExceeded halfway through.
+//
+// Example:
+//
+//	limits, err := client.GetAccountLimits(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	if err := limits.CheckCapacity(&sdk.CapacityRequest{Tables: len(specs)}); err != nil {
+//		return err // matches sdk.ErrQuotaExceeded
+//	}
+func (c *RawClient) GetAccountLimits(ctx context.Context, opts ...CallOption) (*AccountLimitsResponse, error) {
+	var resp AccountLimitsResponse
+	if err := c.getJSON(ctx, "/account/limits", &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Unlimited reports whether the resource has no limit.
+func (l ResourceLimit) Unlimited() bool {
+	return l.Max <= 0
+}
+
+// Remaining returns how much of the resource is left, or math.MaxInt64 if
+// it is unlimited.
+func (l ResourceLimit) Remaining() int64 {
+	if l.Unlimited() {
+		return math.MaxInt64
+	}
+	return max(l.Max-l.Used, 0)
+}
+
+// CapacityRequest is the amount of each resource an operation is about to
+// use, as checked by CheckCapacity.
+type CapacityRequest struct {
+	Databases int
+	Tables    int
+	Volumes   int
+	// StorageBytes is the size of the files to upload to volumes.
+	StorageBytes int64
+}
+
+// CheckCapacity checks that the account has enough of each resource left
+// for req. The error lists every resource that would be exceeded and
+// matches ErrQuotaExceeded.
+func (l *AccountLimitsResponse) CheckCapacity(req *CapacityRequest) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	var exceeded []string
+	check := func(name string, limit ResourceLimit, need int64) {
+		if need > 0 && need > limit.Remaining() {
+			exceeded = append(exceeded, fmt.Sprintf("%s: need %d, %d of %d left", name, need, limit.Remaining(), limit.Max))
+		}
+	}
+	check("databases", l.Databases, int64(req.Databases))
+	check("tables", l.Tables, int64(req.Tables))
+	check("volumes", l.Volumes, int64(req.Volumes))
+	check("volume storage", l.VolumeStorage, req.StorageBytes)
+	if len(exceeded) > 0 {
+		return &classifiedError{
+			msg:   "sdk: account limits exceeded: " + strings.Join(exceeded, "; "),
+			class: ErrQuotaExceeded,
+		}
+	}
+	return nil
+}
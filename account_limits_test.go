@@ -0,0 +1,37 @@
+package sdk
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountLimits(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/account/limits": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			writeEnvelope(w, AccountLimitsResponse{
+				Databases:     ResourceLimit{Max: 10, Used: 9},
+				Tables:        ResourceLimit{Max: 100, Used: 40},
+				VolumeStorage: ResourceLimit{Max: 1 << 30, Used: 1 << 30},
+				RateLimits:    []APIRateLimit{{Scope: "default", Limit: 100, WindowSeconds: 60, Remaining: 99}},
+			})
+		},
+	})
+
+	limits, err := client.GetAccountLimits(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(60), limits.Tables.Remaining())
+	require.True(t, limits.Volumes.Unlimited())
+	require.Equal(t, int64(math.MaxInt64), limits.Volumes.Remaining())
+	require.Len(t, limits.RateLimits, 1)
+
+	require.NoError(t, limits.CheckCapacity(&CapacityRequest{Databases: 1, Tables: 60, Volumes: 500}))
+	err = limits.CheckCapacity(&CapacityRequest{Databases: 2, Tables: 10, StorageBytes: 1})
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	require.EqualError(t, err, "sdk: account limits exceeded: databases: need 2, 1 of 10 left; volume storage: need 1, 0 of 1073741824 left")
+}
@@ -0,0 +1,325 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Event and step names used by the data analysis stream.
+const (
+	AnalysisEventClassification = "classification"
+	AnalysisEventComplete       = "complete"
+	AnalysisEventError          = "error"
+	AnalysisEventDecomposition  = "decomposition"
+	AnalysisEventStepStart      = "step_start"
+	AnalysisEventStepComplete   = "step_complete"
+	AnalysisStepInit            = "init"
+	AnalysisStepSQLGenerated    = "sql_generated"
+	AnalysisSourceNL2SQL        = "nl2sql"
+	AnalysisSourceRAG           = "rag"
+)
+
+// AnalysisEvent is a decoded data analysis stream event. Use a type switch
+// to handle the concrete types:
+//
+//   - *InitEvent
+//   - *ClassificationEvent
+//   - *SQLGenerationEvent
+//   - *NL2SQLStepEvent
+//   - *AttributionDecompositionEvent
+//   - *AttributionStepEvent
+//   - *CompleteEvent
+//   - *ErrorEvent
+//   - *UnknownEvent for events the SDK does not recognize
+type AnalysisEvent interface {
+	// Raw returns the event as read from the stream.
+	Raw() *DataAnalysisStreamEvent
+}
+
+type analysisEventBase struct {
+	raw *DataAnalysisStreamEvent
+}
+
+// Raw returns the event as read from the stream.
+func (b analysisEventBase) Raw() *DataAnalysisStreamEvent { return b.raw }
+
+// InitEvent is the first event of an analysis. Its RequestID can be passed
+// to CancelAnalyze.
+type InitEvent struct {
+	analysisEventBase
+	InitEventData
+}
+
+// ClassificationEvent reports how the question was classified, for example
+// as a query or an attribution question.
+type ClassificationEvent struct {
+	analysisEventBase
+	QuestionType
+}
+
+// SQLGenerationEvent carries the SQL generated for the question.
+type SQLGenerationEvent struct {
+	analysisEventBase
+	StepName string
+	SQL      string
+}
+
+// NL2SQLStepEvent reports progress of an NL2SQL step other than SQL
+// generation. Data holds the step-specific payload.
+type NL2SQLStepEvent struct {
+	analysisEventBase
+	StepType string
+	StepName string
+	Data     map[string]interface{}
+}
+
+// AttributionDecompositionEvent reports how an attribution question was
+// split into sub-questions, one per analysis step.
+type AttributionDecompositionEvent struct {
+	analysisEventBase
+	Metric       string                   `json:"metric"`
+	SubQuestions []AttributionSubQuestion `json:"sub_questions"`
+}
+
+// AttributionSubQuestion is a step of an attribution analysis.
+type AttributionSubQuestion struct {
+	Step      int    `json:"step"`
+	Question  string `json:"question"`
+	Dimension string `json:"dimension,omitempty"`
+}
+
+// AttributionStepEvent reports the start or the completion of a step of an
+// attribution analysis. Result is set on completion when the step produced
+// one.
+type AttributionStepEvent struct {
+	analysisEventBase
+	// Phase is AnalysisEventStepStart or AnalysisEventStepComplete.
+	Phase    string             `json:"-"`
+	Step     int                `json:"step"`
+	Question string             `json:"question,omitempty"`
+	Result   *AttributionResult `json:"result,omitempty"`
+}
+
+// AttributionResult explains the change of a metric by the factors that
+// drove it.
+type AttributionResult struct {
+	Metric   string  `json:"metric"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+	Change   float64 `json:"change"`
+	// ChangePercent is Change relative to Baseline, in percent.
+	ChangePercent float64 `json:"change_percent"`
+	// Drivers lists the factors of the change, largest contribution first.
+	Drivers    []AttributionDriver  `json:"drivers"`
+	Breakdowns []DimensionBreakdown `json:"breakdowns"`
+	Conclusion string               `json:"conclusion,omitempty"`
+}
+
+// AttributionDriver is a factor that contributed to a metric change.
+type AttributionDriver struct {
+	Factor    string `json:"factor"`
+	Dimension string `json:"dimension,omitempty"`
+	Value     string `json:"value,omitempty"`
+	// Contribution is the part of the change due to the factor, in the unit
+	// of the metric.
+	Contribution float64 `json:"contribution"`
+	// ContributionPercent is the share of the total change due to the
+	// factor, in percent. Shares of opposite sign offset each other.
+	ContributionPercent float64 `json:"contribution_percent"`
+}
+
+// DimensionBreakdown splits a metric change along the values of a
+// dimension.
+type DimensionBreakdown struct {
+	Dimension string                   `json:"dimension"`
+	Items     []DimensionBreakdownItem `json:"items"`
+}
+
+// DimensionBreakdownItem is the metric change for one value of a
+// dimension.
+type DimensionBreakdownItem struct {
+	Value               string  `json:"value"`
+	Baseline            float64 `json:"baseline"`
+	Current             float64 `json:"current"`
+	Change              float64 `json:"change"`
+	ContributionPercent float64 `json:"contribution_percent"`
+}
+
+// CompleteEvent marks the end of the analysis. Data holds the final
+// payload, if the server sent one. Attribution is decoded from its
+// "attribution" object, sent for attribution questions.
+type CompleteEvent struct {
+	analysisEventBase
+	Data        map[string]interface{}
+	Attribution *AttributionResult
+}
+
+// ErrorEvent reports an error raised by the analysis.
+type ErrorEvent struct {
+	analysisEventBase
+	Code    string
+	Message string
+}
+
+// Error implements the error interface so that the event can be returned
+// as an error directly.
+func (e *ErrorEvent) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("data analysis error %s: %s", e.Code, e.Message)
+	}
+	return "data analysis error: " + e.Message
+}
+
+// UnknownEvent is an event without a typed representation. Inspect Raw for
+// its content.
+type UnknownEvent struct {
+	analysisEventBase
+}
+
+// Decode converts e into its typed representation.
+//
+// Example:
+//
+//	typed, err := event.Decode()
+//	if err != nil {
+//		return err
+//	}
+//	if sqlEvent, ok := typed.(*sdk.SQLGenerationEvent); ok {
+//		fmt.Println(sqlEvent.SQL)
+//	}
+func (e *DataAnalysisStreamEvent) Decode() (AnalysisEvent, error) {
+	base := analysisEventBase{raw: e}
+	switch {
+	case e.StepType == AnalysisStepInit:
+		init := e.GetInitEventData()
+		if init == nil {
+			return nil, fmt.Errorf("decode init event: missing request_id")
+		}
+		return &InitEvent{analysisEventBase: base, InitEventData: *init}, nil
+
+	case e.Type == AnalysisEventClassification:
+		ev := &ClassificationEvent{analysisEventBase: base}
+		if err := e.decodeData(&ev.QuestionType); err != nil {
+			return nil, fmt.Errorf("decode classification event: %w", err)
+		}
+		return ev, nil
+
+	case e.Type == AnalysisEventComplete:
+		ev := &CompleteEvent{analysisEventBase: base, Data: e.Data}
+		if e.Data["attribution"] != nil {
+			var payload struct {
+				Attribution *AttributionResult `json:"attribution"`
+			}
+			if err := e.decodeData(&payload); err != nil {
+				return nil, fmt.Errorf("decode complete event: %w", err)
+			}
+			ev.Attribution = payload.Attribution
+		}
+		return ev, nil
+
+	case e.Type == AnalysisEventDecomposition:
+		ev := &AttributionDecompositionEvent{analysisEventBase: base}
+		if err := e.decodeData(ev); err != nil {
+			return nil, fmt.Errorf("decode decomposition event: %w", err)
+		}
+		return ev, nil
+
+	case e.Type == AnalysisEventStepStart || e.Type == AnalysisEventStepComplete:
+		ev := &AttributionStepEvent{analysisEventBase: base, Phase: e.Type}
+		if err := e.decodeData(ev); err != nil {
+			return nil, fmt.Errorf("decode %s event: %w", e.Type, err)
+		}
+		return ev, nil
+
+	case e.Type == AnalysisEventError:
+		var payload struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Msg     string `json:"msg"`
+			Error   string `json:"error"`
+		}
+		// Error details may be nested in "data" or sent at the top level.
+		_ = e.decodeData(&payload)
+		if payload.Message == "" && payload.Msg == "" && payload.Error == "" {
+			_ = json.Unmarshal(e.RawData, &payload)
+		}
+		ev := &ErrorEvent{analysisEventBase: base, Code: payload.Code, Message: payload.Message}
+		if ev.Message == "" {
+			ev.Message = payload.Msg
+		}
+		if ev.Message == "" {
+			ev.Message = payload.Error
+		}
+		if ev.Message == "" {
+			ev.Message = string(e.RawData)
+		}
+		return ev, nil
+
+	case e.StepType == AnalysisStepSQLGenerated:
+		ev := &SQLGenerationEvent{analysisEventBase: base, StepName: e.StepName}
+		var payload struct {
+			SQL string `json:"sql"`
+		}
+		if err := e.decodeData(&payload); err != nil {
+			return nil, fmt.Errorf("decode sql_generated event: %w", err)
+		}
+		ev.SQL = payload.SQL
+		return ev, nil
+
+	case e.Source == AnalysisSourceNL2SQL || e.StepType != "":
+		return &NL2SQLStepEvent{
+			analysisEventBase: base,
+			StepType:          e.StepType,
+			StepName:          e.StepName,
+			Data:              e.Data,
+		}, nil
+	}
+	return &UnknownEvent{analysisEventBase: base}, nil
+}
+
+// decodeData unmarshals the "data" object of the event into v, or the
+// whole event when it has no "data" object.
+func (e *DataAnalysisStreamEvent) decodeData(v interface{}) error {
+	if e.Data == nil {
+		return json.Unmarshal(e.RawData, v)
+	}
+	payload, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
+
+// DecodeNext reads the next event from the stream and converts it into its
+// typed representation.
+//
+// Returns io.EOF when the stream is complete.
+//
+// Example:
+//
+//	for {
+//		event, err := stream.DecodeNext()
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		switch ev := event.(type) {
+//		case *sdk.ClassificationEvent:
+//			fmt.Println("question type:", ev.Type)
+//		case *sdk.SQLGenerationEvent:
+//			fmt.Println("sql:", ev.SQL)
+//		case *sdk.ErrorEvent:
+//			return ev
+//		case *sdk.CompleteEvent:
+//			return nil
+//		}
+//	}
+func (s *DataAnalysisStream) DecodeNext() (AnalysisEvent, error) {
+	event, err := s.ReadEvent()
+	if err != nil {
+		return nil, err
+	}
+	return event.Decode()
+}
@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataAnalysisStream_DecodeNext(t *testing.T) {
+	t.Parallel()
+
+	sseData := "data: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-1\",\"session_title\":\"Salaries\"}}\n\n" +
+		"event: classification\ndata: {\"type\":\"classification\",\"data\":{\"type\":\"query\",\"confidence\":0.9,\"reason\":\"asks for a value\"}}\n\n" +
+		"data: {\"source\":\"nl2sql\",\"step_type\":\"table_selected\",\"step_name\":\"Select tables\",\"data\":{\"tables\":[\"emp\"]}}\n\n" +
+		"data: {\"source\":\"nl2sql\",\"step_type\":\"sql_generated\",\"step_name\":\"Generate SQL\",\"data\":{\"sql\":\"SELECT AVG(salary) FROM emp\"}}\n\n" +
+		"data: {\"source\":\"rag\",\"chunks\":[]}\n\n" +
+		"event: error\ndata: {\"code\":\"ErrTimeout\",\"message\":\"query timed out\"}\n\n" +
+		"event: complete\ndata: {\"type\":\"complete\",\"data\":{\"rows\":1}}\n\n"
+
+	stream := &DataAnalysisStream{
+		Body:       io.NopCloser(strings.NewReader(sseData)),
+		Header:     make(http.Header),
+		StatusCode: 200,
+	}
+	defer stream.Close()
+
+	var events []AnalysisEvent
+	for {
+		event, err := stream.DecodeNext()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.NotNil(t, event.Raw())
+		events = append(events, event)
+	}
+	require.Len(t, events, 7)
+
+	initEvent, ok := events[0].(*InitEvent)
+	require.True(t, ok)
+	require.Equal(t, "req-1", initEvent.RequestID)
+	require.Equal(t, "Salaries", initEvent.SessionTitle)
+
+	classification, ok := events[1].(*ClassificationEvent)
+	require.True(t, ok)
+	require.Equal(t, "query", classification.Type)
+	require.InDelta(t, 0.9, classification.Confidence, 1e-9)
+
+	step, ok := events[2].(*NL2SQLStepEvent)
+	require.True(t, ok)
+	require.Equal(t, "table_selected", step.StepType)
+	require.Equal(t, []interface{}{"emp"}, step.Data["tables"])
+
+	sqlEvent, ok := events[3].(*SQLGenerationEvent)
+	require.True(t, ok)
+	require.Equal(t, "SELECT AVG(salary) FROM emp", sqlEvent.SQL)
+	require.Equal(t, "Generate SQL", sqlEvent.StepName)
+
+	_, ok = events[4].(*UnknownEvent)
+	require.True(t, ok)
+
+	errEvent, ok := events[5].(*ErrorEvent)
+	require.True(t, ok)
+	require.Equal(t, "ErrTimeout", errEvent.Code)
+	require.Equal(t, "query timed out", errEvent.Message)
+	require.EqualError(t, errEvent, "data analysis error ErrTimeout: query timed out")
+
+	complete, ok := events[6].(*CompleteEvent)
+	require.True(t, ok)
+	require.EqualValues(t, 1, complete.Data["rows"])
+}
+
+func TestDataAnalysisStreamEvent_Decode_ErrorWithoutJSON(t *testing.T) {
+	t.Parallel()
+
+	event := &DataAnalysisStreamEvent{Type: "error", RawData: []byte("internal failure")}
+	decoded, err := event.Decode()
+	require.NoError(t, err)
+	errEvent, ok := decoded.(*ErrorEvent)
+	require.True(t, ok)
+	require.Equal(t, "internal failure", errEvent.Message)
+}
+
+func TestDataAnalysisStream_DecodeAttribution(t *testing.T) {
+	t.Parallel()
+
+	sseData := "event: decomposition\ndata: {\"data\":{\"metric\":\"revenue\",\"sub_questions\":[{\"step\":1,\"question\":\"Which regions declined?\",\"dimension\":\"region\"}]}}\n\n" +
+		"event: step_start\ndata: {\"data\":{\"step\":1,\"question\":\"Which regions declined?\"}}\n\n" +
+		"event: step_complete\ndata: {\"data\":{\"step\":1,\"result\":{\"metric\":\"revenue\",\"breakdowns\":[{\"dimension\":\"region\",\"items\":[{\"value\":\"north\",\"change\":-80,\"contribution_percent\":80}]}]}}}\n\n" +
+		"event: complete\ndata: {\"data\":{\"attribution\":{\"metric\":\"revenue\",\"baseline\":1000,\"current\":900,\"change\":-100,\"change_percent\":-10," +
+		"\"drivers\":[{\"factor\":\"north region\",\"dimension\":\"region\",\"value\":\"north\",\"contribution\":-80,\"contribution_percent\":80}],\"conclusion\":\"north declined\"}}}\n\n"
+
+	stream := &DataAnalysisStream{
+		Body:       io.NopCloser(strings.NewReader(sseData)),
+		Header:     make(http.Header),
+		StatusCode: 200,
+	}
+	defer stream.Close()
+
+	event, err := stream.DecodeNext()
+	require.NoError(t, err)
+	decomposition, ok := event.(*AttributionDecompositionEvent)
+	require.True(t, ok)
+	require.Equal(t, "revenue", decomposition.Metric)
+	require.Equal(t, []AttributionSubQuestion{{Step: 1, Question: "Which regions declined?", Dimension: "region"}}, decomposition.SubQuestions)
+
+	event, err = stream.DecodeNext()
+	require.NoError(t, err)
+	start, ok := event.(*AttributionStepEvent)
+	require.True(t, ok)
+	require.Equal(t, AnalysisEventStepStart, start.Phase)
+	require.Nil(t, start.Result)
+
+	event, err = stream.DecodeNext()
+	require.NoError(t, err)
+	complete, ok := event.(*AttributionStepEvent)
+	require.True(t, ok)
+	require.Equal(t, AnalysisEventStepComplete, complete.Phase)
+	require.Equal(t, "north", complete.Result.Breakdowns[0].Items[0].Value)
+	require.Equal(t, 80.0, complete.Result.Breakdowns[0].Items[0].ContributionPercent)
+
+	event, err = stream.DecodeNext()
+	require.NoError(t, err)
+	done, ok := event.(*CompleteEvent)
+	require.True(t, ok)
+	require.Equal(t, -10.0, done.Attribution.ChangePercent)
+	require.Equal(t, "north region", done.Attribution.Drivers[0].Factor)
+	require.Equal(t, -80.0, done.Attribution.Drivers[0].Contribution)
+}
@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// defaultCorrectionKnowledgeType is the knowledge type of the entries
+// created by AcceptAnalysisCorrection.
+const defaultCorrectionKnowledgeType = "sql_example"
+
+// AnalysisCorrectionOptions configures AcceptAnalysisCorrection.
+type AnalysisCorrectionOptions struct {
+	// Comment is sent with the feedback.
+	Comment string
+	// KnowledgeType is the type of the knowledge entry. Defaults to
+	// "sql_example".
+	KnowledgeType string
+	// AssociateTables lists the tables the corrected SQL reads.
+	AssociateTables []string
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// AnalysisCorrectionResult is the outcome of AcceptAnalysisCorrection.
+type AnalysisCorrectionResult struct {
+	Feedback *AnalysisFeedbackResponse
+	// Question is the question of the analysis, used as the knowledge key.
+	Question    string
+	KnowledgeID Nl2SqlKnowledgeID
+}
+
+// AcceptAnalysisCorrection records an accepted correction of a data analysis:
+// it submits negative feedback with the corrected SQL and adds the question
+// and the SQL as an NL2SQL knowledge entry, so that later questions alike
+// are answered with it.
+//
+// The question is read from the analysis with GetAnalysisResult.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - requestID: the analysis that answered wrongly (required)
+//   - correctedSQL: the SQL that answers the question (required)
+//   - opts: optional settings; nil uses the defaults
+//
+// Returns:
+//   - *AnalysisCorrectionResult: the feedback and the knowledge entry; it is
+//     also returned with an error once the feedback has been submitted
+//   - error: any error that occurred
+//
+// Example:
+//
+//	res, err := sdkClient.AcceptAnalysisCorrection(ctx, requestID,
+//		"SELECT SUM(amount) FROM orders WHERE status = 'paid'",
+//		&sdk.AnalysisCorrectionOptions{AssociateTables: []string{"orders"}})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("knowledge %d added for %q\n", res.KnowledgeID, res.Question)
+func (c *SDKClient) AcceptAnalysisCorrection(ctx context.Context, requestID, correctedSQL string, opts *AnalysisCorrectionOptions) (*AnalysisCorrectionResult, error) {
+	if strings.TrimSpace(correctedSQL) == "" {
+		return nil, fmt.Errorf("corrected_sql is required")
+	}
+	var cfg AnalysisCorrectionOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.KnowledgeType == "" {
+		cfg.KnowledgeType = defaultCorrectionKnowledgeType
+	}
+
+	analysis, err := c.raw.GetAnalysisResult(ctx, requestID, cfg.CallOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("get analysis %s: %w", requestID, err)
+	}
+	if strings.TrimSpace(analysis.Question) == "" {
+		return nil, fmt.Errorf("analysis %s has no question", requestID)
+	}
+
+	feedback, err := c.raw.SubmitAnalysisFeedback(ctx, requestID, AnalysisRatingNegative, correctedSQL, cfg.Comment, cfg.CallOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("submit feedback: %w", err)
+	}
+	result := &AnalysisCorrectionResult{Feedback: feedback, Question: analysis.Question}
+
+	created, err := c.raw.CreateKnowledge(ctx, &NL2SQLKnowledgeCreateRequest{
+		Type:            cfg.KnowledgeType,
+		Key:             analysis.Question,
+		Value:           []string{correctedSQL},
+		AssociateTables: cfg.AssociateTables,
+	}, cfg.CallOptions...)
+	if err != nil {
+		return result, fmt.Errorf("create knowledge: %w", err)
+	}
+	result.KnowledgeID = created.ID
+	return result, nil
+}
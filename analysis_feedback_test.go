@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitAnalysisFeedback(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/feedback": func(w http.ResponseWriter, r *http.Request) {
+			var req AnalysisFeedbackRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, AnalysisFeedbackRequest{RequestID: "r1", Rating: AnalysisRatingPositive, Comment: "spot on"}, req)
+			writeEnvelope(w, AnalysisFeedbackResponse{FeedbackID: "f1", RequestID: "r1"})
+		},
+	})
+	ctx := context.Background()
+
+	resp, err := client.SubmitAnalysisFeedback(ctx, "r1", AnalysisRatingPositive, "", "spot on")
+	require.NoError(t, err)
+	require.Equal(t, "f1", resp.FeedbackID)
+
+	_, err = client.SubmitAnalysisFeedback(ctx, "", AnalysisRatingPositive, "", "")
+	require.Error(t, err)
+	_, err = client.SubmitAnalysisFeedback(ctx, "r1", "meh", "", "")
+	require.Error(t, err)
+}
+
+func TestAcceptAnalysisCorrection(t *testing.T) {
+	t.Parallel()
+	const sql = "SELECT SUM(amount) FROM orders WHERE status = 'paid'"
+	var knowledge NL2SQLKnowledgeCreateRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/result": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, AnalysisResultResponse{RequestID: "r1", Question: "What is the paid revenue?"})
+		},
+		"/byoa/api/v1/data_asking/feedback": func(w http.ResponseWriter, r *http.Request) {
+			var req AnalysisFeedbackRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, AnalysisRatingNegative, req.Rating)
+			require.Equal(t, sql, req.CorrectedSQL)
+			writeEnvelope(w, AnalysisFeedbackResponse{FeedbackID: "f1", RequestID: "r1"})
+		},
+		"/catalog/nl2sql_knowledge/create": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&knowledge))
+			writeEnvelope(w, NL2SQLKnowledgeCreateResponse{ID: 12})
+		},
+	}))
+
+	res, err := client.AcceptAnalysisCorrection(context.Background(), "r1", sql, &AnalysisCorrectionOptions{
+		AssociateTables: []string{"orders"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, Nl2SqlKnowledgeID(12), res.KnowledgeID)
+	require.Equal(t, "f1", res.Feedback.FeedbackID)
+	require.Equal(t, "sql_example", knowledge.Type)
+	require.Equal(t, "What is the paid revenue?", knowledge.Key)
+	require.Equal(t, []string{sql}, knowledge.Value)
+	require.Equal(t, []string{"orders"}, knowledge.AssociateTables)
+
+	_, err = client.AcceptAnalysisCorrection(context.Background(), "r1", " ", nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DataAnalysisResult is the consolidated outcome of a data analysis, as
+// returned by AnalyzeData.
+type DataAnalysisResult struct {
+	RequestID    string
+	SessionTitle string
+	// Classification is how the question was classified, if reported.
+	Classification *QuestionType
+	// SQL lists the generated statements in the order they were reported.
+	SQL []string
+	// Results holds the result sets reported by NL2SQL steps. Values are
+	// converted to strings; JSON null becomes "NULL".
+	Results []NL2SQLResult
+	// Attribution holds the events of the attribution flow, for attribution
+	// questions.
+	Attribution []*DataAnalysisStreamEvent
+	// AttributionResult is the outcome of the attribution flow: the one sent
+	// with the complete event, or else the result of the last step.
+	AttributionResult *AttributionResult
+	// Complete is the payload of the complete event.
+	Complete map[string]interface{}
+	// Events holds every event received, in order.
+	Events []AnalysisEvent
+}
+
+// AnalyzeData runs a data analysis and waits for it to complete, for callers
+// that do not need incremental events.
+//
+// The stream returned by AnalyzeDataStream is consumed until the complete
+// event arrives and the events are folded into a DataAnalysisResult.
+//
+// Parameters:
+//   - ctx: context for the request; cancelling it aborts the analysis read
+//   - req: the analysis request (required)
+//
+// Returns:
+//   - *DataAnalysisResult: the consolidated result
+//   - error: any error that occurred. When the analysis reports an error
+//     event, the error is the *ErrorEvent and the partial result is returned
+//     along with it. A stream that ends before the complete event yields an
+//     error wrapping io.ErrUnexpectedEOF.
+//
+// Example:
+//
+//	result, err := sdkClient.AnalyzeData(ctx, &sdk.DataAnalysisRequest{
+//		Question: "What is the average salary?",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, sql := range result.SQL {
+//		fmt.Println(sql)
+//	}
+func (c *SDKClient) AnalyzeData(ctx context.Context, req *DataAnalysisRequest, opts ...CallOption) (*DataAnalysisResult, error) {
+	stream, err := c.raw.AnalyzeDataStream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	result := &DataAnalysisResult{}
+	for {
+		event, err := stream.DecodeNext()
+		if errors.Is(err, io.EOF) {
+			return result, fmt.Errorf("data analysis stream ended before completion: %w", io.ErrUnexpectedEOF)
+		}
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return result, ctxErr
+			}
+			return result, err
+		}
+		result.Events = append(result.Events, event)
+
+		switch ev := event.(type) {
+		case *InitEvent:
+			result.RequestID = ev.RequestID
+			result.SessionTitle = ev.SessionTitle
+		case *ClassificationEvent:
+			qt := ev.QuestionType
+			result.Classification = &qt
+		case *SQLGenerationEvent:
+			if ev.SQL != "" {
+				result.SQL = append(result.SQL, ev.SQL)
+			}
+		case *NL2SQLStepEvent:
+			if rs, ok := analysisResultSet(ev.Data); ok {
+				result.Results = append(result.Results, *rs)
+			}
+		case *AttributionDecompositionEvent:
+			result.Attribution = append(result.Attribution, ev.Raw())
+		case *AttributionStepEvent:
+			result.Attribution = append(result.Attribution, ev.Raw())
+			if ev.Result != nil {
+				result.AttributionResult = ev.Result
+			}
+		case *ErrorEvent:
+			return result, ev
+		case *CompleteEvent:
+			result.Complete = ev.Data
+			if ev.Attribution != nil {
+				result.AttributionResult = ev.Attribution
+			}
+			return result, nil
+		}
+	}
+}
+
+// analysisResultSet extracts a result set from step data carrying
+// "columns" and "rows".
+func analysisResultSet(data map[string]interface{}) (*NL2SQLResult, bool) {
+	if data == nil || data["columns"] == nil || data["rows"] == nil {
+		return nil, false
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, false
+	}
+	var raw struct {
+		Columns []string            `json:"columns"`
+		Rows    [][]json.RawMessage `json:"rows"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, false
+	}
+	rs := &NL2SQLResult{Columns: raw.Columns, Rows: make([]NL2SQLRow, len(raw.Rows))}
+	for i, row := range raw.Rows {
+		values := make(NL2SQLRow, len(row))
+		for j, v := range row {
+			values[j] = jsonValueString(v)
+		}
+		rs.Rows[i] = values
+	}
+	return rs, true
+}
+
+// jsonValueString renders a JSON value the way the SQL API reports values:
+// strings unquoted, null as "NULL" and anything else as JSON text.
+func jsonValueString(v json.RawMessage) string {
+	text := strings.TrimSpace(string(v))
+	if text == "null" {
+		return "NULL"
+	}
+	var s string
+	if json.Unmarshal(v, &s) == nil {
+		return s
+	}
+	return text
+}
@@ -0,0 +1,92 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newAnalyzeMockClient(t *testing.T, sse string) *SDKClient {
+	t.Helper()
+	return NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/analyze": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "text/event-stream", r.Header.Get(headerAccept))
+			w.Header().Set(headerContentType, "text/event-stream")
+			fmt.Fprint(w, sse)
+		},
+	}))
+}
+
+func TestAnalyzeData(t *testing.T) {
+	t.Parallel()
+
+	client := newAnalyzeMockClient(t,
+		"data: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-1\",\"session_title\":\"Salaries\"}}\n\n"+
+			"event: classification\ndata: {\"data\":{\"type\":\"query\",\"confidence\":0.8}}\n\n"+
+			"data: {\"source\":\"nl2sql\",\"step_type\":\"sql_generated\",\"data\":{\"sql\":\"SELECT AVG(salary) FROM emp\"}}\n\n"+
+			"data: {\"source\":\"nl2sql\",\"step_type\":\"sql_executed\",\"data\":{\"columns\":[\"avg\",\"note\"],\"rows\":[[1234.5,null],[\"x\",\"y\"]]}}\n\n"+
+			"event: step_start\ndata: {\"data\":{\"step\":1}}\n\n"+
+			"event: complete\ndata: {\"data\":{\"summary\":\"done\"}}\n\n"+
+			"event: classification\ndata: {\"data\":{\"type\":\"ignored\"}}\n\n")
+
+	result, err := client.AnalyzeData(context.Background(), &DataAnalysisRequest{Question: "average salary?"})
+	require.NoError(t, err)
+	require.Equal(t, "req-1", result.RequestID)
+	require.Equal(t, "Salaries", result.SessionTitle)
+	require.NotNil(t, result.Classification)
+	require.Equal(t, "query", result.Classification.Type)
+	require.Equal(t, []string{"SELECT AVG(salary) FROM emp"}, result.SQL)
+	require.Len(t, result.Results, 1)
+	require.Equal(t, []string{"avg", "note"}, result.Results[0].Columns)
+	require.Equal(t, []NL2SQLRow{{"1234.5", "NULL"}, {"x", "y"}}, result.Results[0].Rows)
+	require.Len(t, result.Attribution, 1)
+	require.Equal(t, "step_start", result.Attribution[0].Type)
+	require.Nil(t, result.AttributionResult)
+	require.Equal(t, "done", result.Complete["summary"])
+	require.Len(t, result.Events, 6)
+}
+
+func TestAnalyzeData_Attribution(t *testing.T) {
+	t.Parallel()
+
+	client := newAnalyzeMockClient(t,
+		"event: classification\ndata: {\"data\":{\"type\":\"attribution\"}}\n\n"+
+			"event: decomposition\ndata: {\"data\":{\"metric\":\"revenue\"}}\n\n"+
+			"event: step_complete\ndata: {\"data\":{\"step\":1,\"result\":{\"metric\":\"revenue\",\"change\":-100}}}\n\n"+
+			"event: complete\ndata: {\"data\":{\"summary\":\"done\"}}\n\n")
+
+	result, err := client.AnalyzeData(context.Background(), &DataAnalysisRequest{Question: "why did revenue drop?"})
+	require.NoError(t, err)
+	require.Len(t, result.Attribution, 2)
+	require.NotNil(t, result.AttributionResult)
+	require.Equal(t, -100.0, result.AttributionResult.Change)
+}
+
+func TestAnalyzeData_ErrorEvent(t *testing.T) {
+	t.Parallel()
+
+	client := newAnalyzeMockClient(t,
+		"data: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-2\"}}\n\n"+
+			"event: error\ndata: {\"message\":\"no data source\"}\n\n")
+
+	result, err := client.AnalyzeData(context.Background(), &DataAnalysisRequest{Question: "q"})
+	var errEvent *ErrorEvent
+	require.ErrorAs(t, err, &errEvent)
+	require.Equal(t, "no data source", errEvent.Message)
+	require.Equal(t, "req-2", result.RequestID)
+}
+
+func TestAnalyzeData_StreamEndsEarly(t *testing.T) {
+	t.Parallel()
+
+	client := newAnalyzeMockClient(t, "event: classification\ndata: {\"data\":{\"type\":\"query\"}}\n\n")
+
+	_, err := client.AnalyzeData(context.Background(), &DataAnalysisRequest{Question: "q"})
+	require.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+}
@@ -0,0 +1,294 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// RawClientAPI is the set of operations exposed by RawClient.
+//
+// Code that depends on RawClientAPI instead of *RawClient can be exercised
+// in unit tests with the in-memory fake from the sdkmock package.
+type RawClientAPI interface {
+	// Catalog
+	CreateCatalog(ctx context.Context, req *CatalogCreateRequest, opts ...CallOption) (*CatalogCreateResponse, error)
+	DeleteCatalog(ctx context.Context, req *CatalogDeleteRequest, opts ...CallOption) (*CatalogDeleteResponse, error)
+	UpdateCatalog(ctx context.Context, req *CatalogUpdateRequest, opts ...CallOption) (*CatalogUpdateResponse, error)
+	GetCatalog(ctx context.Context, req *CatalogInfoRequest, opts ...CallOption) (*CatalogInfoResponse, error)
+	ListCatalogs(ctx context.Context, opts ...CallOption) (*CatalogListResponse, error)
+	GetCatalogTree(ctx context.Context, opts ...CallOption) (*CatalogTreeResponse, error)
+	GetCatalogRefList(ctx context.Context, req *CatalogRefListRequest, opts ...CallOption) (*CatalogRefListResponse, error)
+	DownloadTableData(ctx context.Context, req *TableDownloadDataRequest, opts ...CallOption) (*FileStream, error)
+
+	// Database
+	CreateDatabase(ctx context.Context, req *DatabaseCreateRequest, opts ...CallOption) (*DatabaseCreateResponse, error)
+	DeleteDatabase(ctx context.Context, req *DatabaseDeleteRequest, opts ...CallOption) (*DatabaseDeleteResponse, error)
+	UpdateDatabase(ctx context.Context, req *DatabaseUpdateRequest, opts ...CallOption) (*DatabaseUpdateResponse, error)
+	GetDatabase(ctx context.Context, req *DatabaseInfoRequest, opts ...CallOption) (*DatabaseInfoResponse, error)
+	ListDatabases(ctx context.Context, req *DatabaseListRequest, opts ...CallOption) (*DatabaseListResponse, error)
+	GetDatabaseChildren(ctx context.Context, req *DatabaseChildrenRequest, opts ...CallOption) (*DatabaseChildrenResponseData, error)
+	GetDatabaseRefList(ctx context.Context, req *DatabaseRefListRequest, opts ...CallOption) (*DatabaseRefListResponse, error)
+
+	// Table
+	CreateTable(ctx context.Context, req *TableCreateRequest, opts ...CallOption) (*TableCreateResponse, error)
+	GetTable(ctx context.Context, req *TableInfoRequest, opts ...CallOption) (*TableInfoResponse, error)
+	GetMultiTable(ctx context.Context, req *MultiTableInfoRequest, opts ...CallOption) (*MultiTableInfoResponse, error)
+	GetTableOverview(ctx context.Context, opts ...CallOption) ([]TableOverview, error)
+	CheckTableExists(ctx context.Context, req *TableExistRequest, opts ...CallOption) (bool, error)
+	PreviewTable(ctx context.Context, req *TablePreviewRequest, opts ...CallOption) (*TablePreviewResponse, error)
+	GetTableData(ctx context.Context, req *GetTableDataRequest, opts ...CallOption) (*GetTableDataResponse, error)
+	LoadTable(ctx context.Context, req *TableLoadRequest, opts ...CallOption) (*TableLoadResponse, error)
+	InsertTableRows(ctx context.Context, tableID TableID, rows []map[string]any, opts ...CallOption) (*TableInsertRowsResponse, error)
+	GetTableDownloadLink(ctx context.Context, req *TableDownloadRequest, opts ...CallOption) (*TableDownloadResponse, error)
+	AlterTable(ctx context.Context, req *TableAlterRequest, opts ...CallOption) (*TableAlterResponse, error)
+	TruncateTable(ctx context.Context, req *TableTruncateRequest, opts ...CallOption) (*TableTruncateResponse, error)
+	RenameTable(ctx context.Context, req *TableRenameRequest, opts ...CallOption) (*TableRenameResponse, error)
+	DeleteTable(ctx context.Context, req *TableDeleteRequest, opts ...CallOption) (*TableDeleteResponse, error)
+	GetTableFullPath(ctx context.Context, req *TableFullPathRequest, opts ...CallOption) (*TableFullPathResponse, error)
+	GetTableRefList(ctx context.Context, req *TableRefListRequest, opts ...CallOption) (*TableRefListResponse, error)
+
+	// Volume
+	CreateVolume(ctx context.Context, req *VolumeCreateRequest, opts ...CallOption) (*VolumeCreateResponse, error)
+	DeleteVolume(ctx context.Context, req *VolumeDeleteRequest, opts ...CallOption) (*VolumeDeleteResponse, error)
+	UpdateVolume(ctx context.Context, req *VolumeUpdateRequest, opts ...CallOption) (*VolumeUpdateResponse, error)
+	GetVolume(ctx context.Context, req *VolumeInfoRequest, opts ...CallOption) (*VolumeInfoResponse, error)
+	GetVolumeRefList(ctx context.Context, req *VolumeRefListRequest, opts ...CallOption) (*VolumeRefListResponse, error)
+	GetVolumeFullPath(ctx context.Context, req *VolumeFullPathRequest, opts ...CallOption) (*VolumeFullPathResponse, error)
+	AddVolumeWorkflowRef(ctx context.Context, req *VolumeAddRefWorkflowRequest, opts ...CallOption) (*VolumeAddRefWorkflowResponse, error)
+	RemoveVolumeWorkflowRef(ctx context.Context, req *VolumeRemoveRefWorkflowRequest, opts ...CallOption) (*VolumeRemoveRefWorkflowResponse, error)
+
+	// Dataset
+	CreateDataset(ctx context.Context, req *DatasetCreateRequest, opts ...CallOption) (*DatasetCreateResponse, error)
+	DeleteDataset(ctx context.Context, req *DatasetDeleteRequest, opts ...CallOption) (*DatasetDeleteResponse, error)
+	UpdateDataset(ctx context.Context, req *DatasetUpdateRequest, opts ...CallOption) (*DatasetUpdateResponse, error)
+	GetDataset(ctx context.Context, req *DatasetInfoRequest, opts ...CallOption) (*DatasetInfoResponse, error)
+	ListDatasets(ctx context.Context, req *DatasetListRequest, opts ...CallOption) (*DatasetListResponse, error)
+
+	// Folder
+	CreateFolder(ctx context.Context, req *FolderCreateRequest, opts ...CallOption) (*FolderCreateResponse, error)
+	UpdateFolder(ctx context.Context, req *FolderUpdateRequest, opts ...CallOption) (*FolderUpdateResponse, error)
+	DeleteFolder(ctx context.Context, req *FolderDeleteRequest, opts ...CallOption) (*FolderDeleteResponse, error)
+	CleanFolder(ctx context.Context, req *FolderCleanRequest, opts ...CallOption) (*FolderCleanResponse, error)
+	GetFolderRefList(ctx context.Context, req *FolderRefListRequest, opts ...CallOption) (*FolderRefListResponse, error)
+
+	// File
+	CreateFile(ctx context.Context, req *FileCreateRequest, opts ...CallOption) (*FileCreateResponse, error)
+	UpdateFile(ctx context.Context, req *FileUpdateRequest, opts ...CallOption) (*FileUpdateResponse, error)
+	DeleteFile(ctx context.Context, req *FileDeleteRequest, opts ...CallOption) (*FileDeleteResponse, error)
+	DeleteFileRef(ctx context.Context, req *FileDeleteRefRequest, opts ...CallOption) (*FileDeleteRefResponse, error)
+	MoveFile(ctx context.Context, req *FileMoveRequest, opts ...CallOption) (*FileMoveResponse, error)
+	CopyFile(ctx context.Context, req *FileCopyRequest, opts ...CallOption) (*FileCopyResponse, error)
+	SetFileTags(ctx context.Context, req *FileTagsSetRequest, opts ...CallOption) (*FileTagsSetResponse, error)
+	GetFileTags(ctx context.Context, req *FileTagsGetRequest, opts ...CallOption) (*FileTagsGetResponse, error)
+	GetFile(ctx context.Context, req *FileInfoRequest, opts ...CallOption) (*FileInfoResponse, error)
+	ListFiles(ctx context.Context, req *FileListRequest, opts ...CallOption) (*FileListResponse, error)
+	ListFolderTree(ctx context.Context, volumeID VolumeID, folderID FileID, depth int, opts ...CallOption) (*FileTreeResponse, error)
+	UploadFile(ctx context.Context, req *FileUploadRequest, opts ...CallOption) (*FileUploadResponse, error)
+	GetFileDownloadLink(ctx context.Context, req *FileDownloadRequest, opts ...CallOption) (*FileDownloadResponse, error)
+	DownloadFileStream(ctx context.Context, fileID FileID, volumeID VolumeID, opts ...CallOption) (*FileStream, error)
+	GetFilePreviewLink(ctx context.Context, req *FilePreviewLinkRequest, opts ...CallOption) (*FilePreviewLinkResponse, error)
+	GetFilePreviewStream(ctx context.Context, req *FilePreviewStreamRequest, opts ...CallOption) (*FilePreviewLinkResponse, error)
+
+	// Trash
+	MoveToTrash(ctx context.Context, req *TrashMoveRequest, opts ...CallOption) (*TrashMoveResponse, error)
+	ListTrash(ctx context.Context, req *TrashListRequest, opts ...CallOption) (*TrashListResponse, error)
+	RestoreFromTrash(ctx context.Context, req *TrashRestoreRequest, opts ...CallOption) (*TrashRestoreResponse, error)
+	PurgeTrash(ctx context.Context, req *TrashPurgeRequest, opts ...CallOption) (*TrashPurgeResponse, error)
+
+	// Connector
+	UploadLocalFiles(ctx context.Context, files []FileUploadItem, meta []FileMeta, opts ...CallOption) (*LocalFileUploadResponse, error)
+	UploadLocalFile(ctx context.Context, fileReader io.Reader, fileName string, meta []FileMeta, opts ...CallOption) (*LocalFileUploadResponse, error)
+	UploadLocalFileFromPath(ctx context.Context, filePath string, meta []FileMeta, opts ...CallOption) (*LocalFileUploadResponse, error)
+	FilePreview(ctx context.Context, req *FilePreviewRequest, opts ...CallOption) (*FilePreviewResponse, error)
+	UploadConnectorFile(ctx context.Context, req *UploadFileRequest, opts ...CallOption) (*UploadFileResponse, error)
+	DownloadConnectorFile(ctx context.Context, req *ConnectorFileDownloadRequest, opts ...CallOption) (*ConnectorFileDownloadResponse, error)
+	DeleteConnectorFile(ctx context.Context, req *ConnectorFileDeleteRequest, opts ...CallOption) (*ConnectorFileDeleteResponse, error)
+	ListConnectorFiles(ctx context.Context, connectorID uint64, pathPrefix string, recursive bool, opts ...CallOption) (*ConnectorFileListResponse, error)
+
+	// Task
+	GetTask(ctx context.Context, req *TaskInfoRequest, opts ...CallOption) (*TaskInfoResponse, error)
+	CreateLoadTask(ctx context.Context, req *LoadTaskCreateRequest, opts ...CallOption) (*LoadTaskCreateResponse, error)
+	GetLoadTask(ctx context.Context, taskID TaskID, opts ...CallOption) (*TaskInfoResponse, error)
+	ListLoadTasks(ctx context.Context, req *LoadTaskListRequest, opts ...CallOption) (*LoadTaskListResponse, error)
+	CancelLoadTask(ctx context.Context, req *LoadTaskCancelRequest, opts ...CallOption) (*LoadTaskCancelResponse, error)
+	DeleteLoadTask(ctx context.Context, req *LoadTaskDeleteRequest, opts ...CallOption) (*LoadTaskDeleteResponse, error)
+
+	// Auth
+	Login(ctx context.Context, req *AuthLoginRequest, opts ...CallOption) (*AuthLoginResponse, error)
+	ExchangeAPIKey(ctx context.Context, req *AuthAPIKeyExchangeRequest, opts ...CallOption) (*AuthAPIKeyExchangeResponse, error)
+
+	// User
+	CreateUser(ctx context.Context, req *UserCreateRequest, opts ...CallOption) (*UserCreateResponse, error)
+	DeleteUser(ctx context.Context, req *UserDeleteUserRequest, opts ...CallOption) (*UserDeleteUserResponse, error)
+	GetUserDetail(ctx context.Context, req *UserDetailInfoRequest, opts ...CallOption) (*UserDetailInfoResponse, error)
+	ListUsers(ctx context.Context, req *UserListRequest, opts ...CallOption) (*UserListResponse, error)
+	GetUserByName(ctx context.Context, name string, opts ...CallOption) (*UserResponse, error)
+	UpdateUserPassword(ctx context.Context, req *UserUpdatePasswordRequest, opts ...CallOption) (*UserUpdatePasswordResponse, error)
+	UpdateUserInfo(ctx context.Context, req *UserUpdateInfoRequest, opts ...CallOption) (*UserUpdateInfoResponse, error)
+	UpdateUserRoles(ctx context.Context, req *UserUpdateRoleListRequest, opts ...CallOption) (*UserUpdateRoleListResponse, error)
+	UpdateUserStatus(ctx context.Context, req *UserUpdateStatusRequest, opts ...CallOption) (*UserUpdateStatusResponse, error)
+	GetMyAPIKey(ctx context.Context, opts ...CallOption) (*UserApiKeyResponse, error)
+	RefreshMyAPIKey(ctx context.Context, opts ...CallOption) (*UserApiKeyRefreshResonse, error)
+	GetMyInfo(ctx context.Context, opts ...CallOption) (*UserMeInfoResponse, error)
+	UpdateMyInfo(ctx context.Context, req *UserMeUpdateInfoRequest, opts ...CallOption) (*UserMeUpdateInfoResponse, error)
+	UpdateMyPassword(ctx context.Context, req *UserMeUpdatePasswordRequest, opts ...CallOption) (*UserMeUpdatePasswordResponse, error)
+
+	// Role
+	CreateRole(ctx context.Context, req *RoleCreateRequest, opts ...CallOption) (*RoleCreateResponse, error)
+	DeleteRole(ctx context.Context, req *RoleDeleteRequest, opts ...CallOption) (*RoleDeleteResponse, error)
+	GetRole(ctx context.Context, req *RoleInfoRequest, opts ...CallOption) (*RoleInfoResponse, error)
+	ListRoles(ctx context.Context, req *RoleListRequest, opts ...CallOption) (*RoleListResponse, error)
+	GetRoleByName(ctx context.Context, name string, opts ...CallOption) (*RoleInfoResponse, error)
+	ListRolesByCategoryAndObject(ctx context.Context, req *RoleListByCategoryAndObjectRequest, opts ...CallOption) (*RoleListByCategoryAndObjectResponse, error)
+	UpdateRoleCodeList(ctx context.Context, req *RoleUpdateCodeListRequest, opts ...CallOption) (*RoleUpdateCodeListResponse, error)
+	UpdateRoleInfo(ctx context.Context, req *RoleUpdateInfoRequest, opts ...CallOption) (*RoleUpdateInfoResponse, error)
+	UpdateRolesByObject(ctx context.Context, req *RoleUpdateRolesByObjectRequest, opts ...CallOption) (*RoleUpdateRolesByObjectResponse, error)
+	UpdateRoleStatus(ctx context.Context, req *RoleUpdateStatusRequest, opts ...CallOption) (*RoleUpdateStatusResponse, error)
+
+	// Privilege
+	ListObjectsByCategory(ctx context.Context, req *PrivListObjByCategoryRequest, opts ...CallOption) (*PrivListObjByCategoryResponse, error)
+	GetAuthorizedObjects(ctx context.Context, req *PrivGetAuthorizedObjectsRequest, opts ...CallOption) (*PrivGetAuthorizedObjectsResponse, error)
+	CheckPrivs(ctx context.Context, privs []CheckPriv, opts ...CallOption) ([]CheckPrivResult, error)
+
+	// Log
+	ListUserLogs(ctx context.Context, req *LogLogListRequest, opts ...CallOption) (*LogLogListResponse, error)
+	ListRoleLogs(ctx context.Context, req *LogLogListRequest, opts ...CallOption) (*LogLogListResponse, error)
+
+	// NL2SQL
+	RunNL2SQL(ctx context.Context, req *NL2SQLRunSQLRequest, opts ...CallOption) (*NL2SQLRunSQLResponse, error)
+
+	// NL2SQL knowledge
+	CreateKnowledge(ctx context.Context, req *NL2SQLKnowledgeCreateRequest, opts ...CallOption) (*NL2SQLKnowledgeCreateResponse, error)
+	UpdateKnowledge(ctx context.Context, req *NL2SQLKnowledgeUpdateRequest, opts ...CallOption) (*NL2SQLKnowledgeUpdateResponse, error)
+	DeleteKnowledge(ctx context.Context, req *NL2SQLKnowledgeDeleteRequest, opts ...CallOption) (*NL2SQLKnowledgeDeleteResponse, error)
+	GetKnowledge(ctx context.Context, req *NL2SQLKnowledgeGetRequest, opts ...CallOption) (*NL2SQLKnowledgeGetResponse, error)
+	ListKnowledge(ctx context.Context, req *NL2SQLKnowledgeListRequest, opts ...CallOption) (*NL2SQLKnowledgeListResponse, error)
+	SearchKnowledge(ctx context.Context, req *NL2SQLKnowledgeSearchRequest, opts ...CallOption) (*NL2SQLKnowledgeSearchResponse, error)
+
+	// GenAI
+	CreateGenAIPipeline(ctx context.Context, req *GenAICreatePipelineRequest, files []PipelineFile, opts ...CallOption) (*GenAICreatePipelineResponse, error)
+	GetGenAIJob(ctx context.Context, jobID string, opts ...CallOption) (*GenAIGetJobDetailResponse, error)
+	DownloadGenAIResult(ctx context.Context, fileID string, opts ...CallOption) (*FileStream, error)
+	ListGenAIWorkflowNodes(ctx context.Context, opts ...CallOption) (*GenAIWorkflowNodeListResponse, error)
+	CreateWorkflow(ctx context.Context, req *WorkflowMetadata, opts ...CallOption) (*WorkflowCreateResponse, error)
+	GetWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowResponse, error)
+	UpdateWorkflow(ctx context.Context, workflowID string, req *WorkflowMetadata, opts ...CallOption) (*WorkflowResponse, error)
+	DeleteWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowDeleteResponse, error)
+	ListWorkflows(ctx context.Context, req *WorkflowListRequest, opts ...CallOption) (*WorkflowListResponse, error)
+	RunWorkflow(ctx context.Context, workflowID string, req *WorkflowRunRequest, opts ...CallOption) (*WorkflowRunResponse, error)
+	StopWorkflowJob(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowJobStopResponse, error)
+	RerunWorkflowJobFiles(ctx context.Context, jobID string, req *WorkflowJobRerunRequest, opts ...CallOption) (*WorkflowRunResponse, error)
+	StreamWorkflowJobEvents(ctx context.Context, workflowID, sourceFileID string, opts ...CallOption) (*SSEStream[*WorkflowJobEvent], error)
+	ListWorkflowJobs(ctx context.Context, req *WorkflowJobListRequest, opts ...CallOption) (*WorkflowJobListResponse, error)
+
+	// Account
+	GetAccountLimits(ctx context.Context, opts ...CallOption) (*AccountLimitsResponse, error)
+
+	// Health
+	HealthCheck(ctx context.Context, opts ...CallOption) (*HealthStatus, error)
+	WaitUntilReady(ctx context.Context, timeout time.Duration, opts ...CallOption) (*HealthStatus, error)
+
+	// Generic calls
+	Do(ctx context.Context, method, path string, reqBody, respBody any, opts ...CallOption) error
+	Batch(ctx context.Context, calls []BatchCall, opts ...CallOption) ([]BatchResult, error)
+
+	// LLM proxy
+	CreateLLMSession(ctx context.Context, req *LLMSessionCreateRequest, opts ...CallOption) (*LLMSession, error)
+	ListLLMSessions(ctx context.Context, req *LLMSessionListRequest, opts ...CallOption) (*LLMSessionListResponse, error)
+	GetLLMSession(ctx context.Context, sessionID int64, opts ...CallOption) (*LLMSession, error)
+	UpdateLLMSession(ctx context.Context, sessionID int64, req *LLMSessionUpdateRequest, opts ...CallOption) (*LLMSession, error)
+	DeleteLLMSession(ctx context.Context, sessionID int64, opts ...CallOption) (*LLMSessionDeleteResponse, error)
+	ListLLMSessionMessages(ctx context.Context, sessionID int64, req *LLMSessionMessagesListRequest, opts ...CallOption) ([]LLMChatMessage, error)
+	GetLLMSessionLatestCompletedMessage(ctx context.Context, sessionID int64, opts ...CallOption) (*LLMLatestCompletedMessageResponse, error)
+	GetLLMSessionLatestMessage(ctx context.Context, sessionID int64, opts ...CallOption) (*LLMLatestCompletedMessageResponse, error)
+	ModifyLLMSessionMessageResponse(ctx context.Context, sessionID int64, messageID int64, modifiedResponse string, opts ...CallOption) (*LLMModifySessionMessageResponseResponse, error)
+	AppendLLMSessionMessageModifiedResponse(ctx context.Context, sessionID int64, messageID int64, appendContent string, opts ...CallOption) (*LLMAppendSessionMessageModifiedResponseResponse, error)
+	CreateLLMChatMessage(ctx context.Context, req *LLMChatMessageCreateRequest, opts ...CallOption) (*LLMChatMessage, error)
+	CreateLLMChatMessagesBatch(ctx context.Context, req *LLMChatMessageBatchCreateRequest, opts ...CallOption) (*LLMChatMessageBatchCreateResponse, error)
+	GetLLMChatMessage(ctx context.Context, messageID int64, opts ...CallOption) (*LLMChatMessage, error)
+	UpdateLLMChatMessage(ctx context.Context, messageID int64, req *LLMChatMessageUpdateRequest, opts ...CallOption) (*LLMChatMessage, error)
+	DeleteLLMChatMessage(ctx context.Context, messageID int64, opts ...CallOption) (*LLMChatMessageDeleteResponse, error)
+	UpdateLLMChatMessageTags(ctx context.Context, messageID int64, req *LLMChatMessageTagsUpdateRequest, opts ...CallOption) (*LLMChatMessage, error)
+	DeleteLLMChatMessageTag(ctx context.Context, messageID int64, source, name string, opts ...CallOption) (*LLMChatMessageTagDeleteResponse, error)
+	GetLLMUsageStats(ctx context.Context, filter *LLMUsageStatsFilter, opts ...CallOption) (*LLMUsageStatsResponse, error)
+	CreateLLMCompletion(ctx context.Context, req *LLMCompletionRequest, opts ...CallOption) (*LLMCompletionResponse, error)
+	CreateLLMCompletionStream(ctx context.Context, req *LLMCompletionRequest, opts ...CallOption) (*LLMCompletionStream, error)
+
+	// Data asking
+	AnalyzeDataStream(ctx context.Context, req *DataAnalysisRequest, opts ...CallOption) (*DataAnalysisStream, error)
+	CancelAnalyze(ctx context.Context, req *CancelAnalyzeRequest, opts ...CallOption) (*CancelAnalyzeResponse, error)
+	ListAnalysisSessions(ctx context.Context, req *AnalysisSessionListRequest, opts ...CallOption) (*AnalysisSessionListResponse, error)
+	GetAnalysisResult(ctx context.Context, requestID string, opts ...CallOption) (*AnalysisResultResponse, error)
+	SubmitAnalysisFeedback(ctx context.Context, requestID string, rating AnalysisRating, correctedSQL, comment string, opts ...CallOption) (*AnalysisFeedbackResponse, error)
+	SearchFilePassages(ctx context.Context, req *PassageSearchRequest, opts ...CallOption) (*PassageSearchResponse, error)
+}
+
+// SDKClientAPI is the set of high-level operations exposed by SDKClient.
+type SDKClientAPI interface {
+	CreateTableRole(ctx context.Context, roleName string, comment string, tablePrivs []TablePrivInfo) (roleID RoleID, created bool, err error)
+	UpdateTableRole(ctx context.Context, roleID RoleID, comment string, tablePrivs []TablePrivInfo, globalPrivs []string) error
+	CreateDatabaseRole(ctx context.Context, roleName string, comment string, dbPrivs []DatabasePrivInfo) (roleID RoleID, created bool, err error)
+	UpdateDatabaseRole(ctx context.Context, roleID RoleID, comment string, dbPrivs []DatabasePrivInfo, globalPrivs []string) error
+	GrantVolumeAccess(ctx context.Context, roleID RoleID, volumeID VolumeID, access VolumeAccess, opts ...CallOption) error
+	RevokeVolumeAccess(ctx context.Context, roleID RoleID, volumeID VolumeID, access VolumeAccess, opts ...CallOption) error
+	EnsureUser(ctx context.Context, spec *UserSpec) (*EnsureUserResult, error)
+	CreateTables(ctx context.Context, databaseID DatabaseID, specs []TableCreateSpec, opts *CreateTablesOptions) ([]TableCreateResult, error)
+	InsertRows(ctx context.Context, tableID TableID, rows []map[string]any, opts *InsertRowsOptions) (int64, error)
+	ImportLocalFileToTable(ctx context.Context, tableConfig *TableConfig) (*UploadFileResponse, error)
+	ImportCSVToTable(ctx context.Context, filePath string, databaseID DatabaseID, tableName string, opts *CSVImportOptions) (*UploadFileResponse, error)
+	ImportExcelToTables(ctx context.Context, filePath string, databaseID DatabaseID, opts *ExcelImportOptions) ([]ExcelSheetImportResult, error)
+	ImportLocalFileToVolume(ctx context.Context, filePath string, volumeID VolumeID, meta FileMeta, dedup *DedupConfig, opts ...CallOption) (*UploadFileResponse, error)
+	ImportLocalFilesToVolume(ctx context.Context, filePaths []string, volumeID VolumeID, metas []FileMeta, dedup *DedupConfig, opts ...CallOption) (*UploadFileResponse, error)
+	ImportDirectoryToVolume(ctx context.Context, localDir string, volumeID VolumeID, opts *DirectoryImportOptions) (*DirectoryImportResult, error)
+	ExportVolumeToDirectory(ctx context.Context, volumeID VolumeID, localDir string, opts *DirectoryExportOptions) (*DirectoryExportResult, error)
+	ImportZipToVolume(ctx context.Context, zipPath string, volumeID VolumeID, opts *ZipImportOptions) (*ZipImportResult, error)
+	MoveFolder(ctx context.Context, folderID FileID, dstVolumeID VolumeID, dstParentID FileID, opts *MoveFolderOptions) (*MoveFolderResult, error)
+	DeleteFilesWhere(ctx context.Context, volumeID VolumeID, predicate *FilePredicate, opts *DeleteFilesOptions) (*DeleteFilesResult, error)
+	DeleteDatabaseCascade(ctx context.Context, databaseID DatabaseID, opts *CascadeDeleteOptions) (*CascadeDeleteResult, error)
+	NewUnitOfWork() *UnitOfWork
+	RunUnitOfWork(ctx context.Context, fn func(ctx context.Context, uow *UnitOfWork) error) error
+	VerifyFileIntegrity(ctx context.Context, fileID FileID, localPath string, opts ...CallOption) (*FileIntegrityResult, error)
+	GetVolumeUsage(ctx context.Context, volumeID VolumeID, opts ...CallOption) (*VolumeUsage, error)
+	GetDatabaseUsage(ctx context.Context, databaseID DatabaseID, opts ...CallOption) (*DatabaseUsage, error)
+	GetCatalogUsage(ctx context.Context, catalogID CatalogID, opts ...CallOption) (*CatalogUsage, error)
+	ExportTableToFile(ctx context.Context, tableID TableID, path string, format TableExportFormat, opts ...CallOption) (*TableExportResult, error)
+	CopyTable(ctx context.Context, srcTableID TableID, dstDatabaseID DatabaseID, newName string, opts *CopyTableOptions) (*CopyTableResult, error)
+	SnapshotCatalog(ctx context.Context, catalogID CatalogID, opts *CatalogSnapshotOptions) (*CatalogSnapshot, error)
+	ExportMetadata(ctx context.Context, scope *MetadataScope, w io.Writer) error
+	ImportMetadata(ctx context.Context, r io.Reader, opts *MetadataImportOptions) (*MetadataImportResult, error)
+	RunSQL(ctx context.Context, statement string, opts ...CallOption) (*NL2SQLRunSQLResponse, error)
+	RunSQLArgs(ctx context.Context, statement string, args []any, opts ...CallOption) (*NL2SQLRunSQLResponse, error)
+	QueryRows(ctx context.Context, statement string, dest any, args ...any) error
+	RunSQLCursor(ctx context.Context, statement string, opts *SQLCursorOptions, args ...any) (*SQLCursor, error)
+	CreateDocumentProcessingWorkflow(ctx context.Context, workflowName string, sourceVolumeID VolumeID, targetVolumeID VolumeID, opts ...CallOption) (workflowID string, err error)
+	GetWorkflowJob(ctx context.Context, workflowID string, sourceFileID string, opts ...CallOption) (*WorkflowJob, error)
+	WaitForTask(ctx context.Context, taskID TaskID, pollInterval time.Duration) (*TaskInfoResponse, TaskStatus, error)
+	RunLoadTaskAndWait(ctx context.Context, req *LoadTaskCreateRequest, pollInterval time.Duration, opts ...CallOption) (*TaskInfoResponse, error)
+	StartLoadTask(ctx context.Context, req *LoadTaskCreateRequest, pollInterval time.Duration, opts ...CallOption) (*TaskJob, error)
+	NewTaskJob(taskID TaskID, pollInterval time.Duration, opts ...CallOption) *TaskJob
+	NewGenAIJob(jobID string, pollInterval time.Duration, opts ...CallOption) *GenAIJob
+	WaitForWorkflowJob(ctx context.Context, workflowID string, sourceFileID string, pollInterval time.Duration, waitForStatuses []WorkflowJobStatus) (*WorkflowJob, error)
+	RerunFailedWorkflowFiles(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowRunResponse, error)
+	PageWorkflowJobs(ctx context.Context, req *WorkflowJobListRequest, opts ...CallOption) *WorkflowJobPager
+	SemanticSearch(ctx context.Context, source SearchSource, query string, topK int, opts ...CallOption) ([]FilePassage, error)
+	StreamAndRecord(ctx context.Context, sessionID int64, prompt *LLMChatMessageCreateRequest, streamFn LLMStreamFunc, opts ...CallOption) (*LLMChatMessage, error)
+	GetConversationContext(ctx context.Context, sessionID int64, opts *ConversationContextOptions) ([]LLMCompletionMessage, error)
+	ProcessDocument(ctx context.Context, localPath string, steps []GenAIWorkflowStep, opts *ProcessDocumentOptions) (*ProcessDocumentResult, error)
+	WatchWorkflowJob(ctx context.Context, workflowID, sourceFileID string, opts ...CallOption) (*WorkflowJobWatcher, error)
+	AnalyzeData(ctx context.Context, req *DataAnalysisRequest, opts ...CallOption) (*DataAnalysisResult, error)
+	AcceptAnalysisCorrection(ctx context.Context, requestID, correctedSQL string, opts *AnalysisCorrectionOptions) (*AnalysisCorrectionResult, error)
+	AskSQL(ctx context.Context, question string, scope *SQLScope, opts *AskSQLOptions) (*AskSQLResult, error)
+	ResolvePath(ctx context.Context, path string, opts ...CallOption) (*ResolvedPath, error)
+	ResolveTablePath(ctx context.Context, path string, opts ...CallOption) (TableID, error)
+	ResolveVolumePath(ctx context.Context, path string, opts ...CallOption) (VolumeID, error)
+	FindFilesByName(ctx context.Context, fileName string, volumeID VolumeID, opts ...CallOption) (*FileListResponse, error)
+	CanUser(ctx context.Context, userID UserID, privCode PrivCode, objectID PrivObjectID, opts ...CallOption) (*PermissionCheck, error)
+	ListAuditLogs(ctx context.Context, query *AuditLogQuery, opts ...CallOption) (*AuditLogPager, error)
+	ExportAuditLogs(ctx context.Context, w io.Writer, format AuditLogExportFormat, query *AuditLogQuery, opts ...CallOption) (int, error)
+}
+
+var (
+	_ RawClientAPI = (*RawClient)(nil)
+	_ SDKClientAPI = (*SDKClient)(nil)
+)
@@ -0,0 +1,154 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSQLNotConfirmed is returned by AskSQL when the Confirm callback declines
+// to run the generated statement.
+var ErrSQLNotConfirmed = errors.New("sdk: generated SQL was not confirmed")
+
+// SQLScope restricts the tables a natural-language question is answered
+// from.
+type SQLScope struct {
+	// Database is the database to query (required).
+	Database string
+	// Tables limits the question to these tables; empty means every table
+	// of Database.
+	Tables []string
+}
+
+// AskSQLOptions configures AskSQL.
+type AskSQLOptions struct {
+	// Confirm, when set, is called with the generated SQL before it is
+	// executed. Returning false stops AskSQL with ErrSQLNotConfirmed, and
+	// returning an error stops it with that error; in both cases the result
+	// still carries the SQL.
+	Confirm func(ctx context.Context, sql string) (bool, error)
+	// SessionID continues an existing analysis session, if set.
+	SessionID *string
+	// CallOptions are applied to every request.
+	CallOptions []CallOption
+}
+
+// AskSQLResult is the outcome of AskSQL.
+type AskSQLResult struct {
+	Question string
+	// SQL is the statement generated for the question.
+	SQL string
+	// Executed reports whether SQL was run.
+	Executed bool
+	// Result is the first result set of the statement, when it was run.
+	Result *NL2SQLResult
+	// Analysis holds the events of the NL2SQL analysis.
+	Analysis *DataAnalysisResult
+}
+
+// Scan converts the rows of the result into dest. See NL2SQLResult.Scan.
+func (r *AskSQLResult) Scan(dest any) error {
+	if r.Result == nil {
+		return (&NL2SQLResult{}).Scan(dest)
+	}
+	return r.Result.Scan(dest)
+}
+
+// AskSQL answers a natural-language question with SQL.
+//
+// The question is sent to the NL2SQL analysis pipeline restricted to scope,
+// the generated statement is taken from the analysis and then executed with
+// RunSQL. When several statements are generated, the last one is used.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - question: the question to answer (required)
+//   - scope: the database and tables to query (required)
+//   - opts: optional settings, may be nil
+//
+// Returns:
+//   - *AskSQLResult: the generated SQL and, once executed, its result
+//   - error: any error that occurred; ErrSQLNotConfirmed when Confirm
+//     declined the statement
+//
+// Example:
+//
+//	res, err := sdkClient.AskSQL(ctx, "What is the average salary per department?",
+//		&sdk.SQLScope{Database: "hr", Tables: []string{"employees"}},
+//		&sdk.AskSQLOptions{
+//			Confirm: func(ctx context.Context, sql string) (bool, error) {
+//				fmt.Println("About to run:", sql)
+//				return true, nil
+//			},
+//		})
+//	if err != nil {
+//		return err
+//	}
+//	var rows []struct {
+//		Department string  `db:"department"`
+//		Average    float64 `db:"avg_salary"`
+//	}
+//	err = res.Scan(&rows)
+func (c *SDKClient) AskSQL(ctx context.Context, question string, scope *SQLScope, opts *AskSQLOptions) (*AskSQLResult, error) {
+	if strings.TrimSpace(question) == "" {
+		return nil, fmt.Errorf("question is required")
+	}
+	if scope == nil || strings.TrimSpace(scope.Database) == "" {
+		return nil, fmt.Errorf("scope database is required")
+	}
+	if opts == nil {
+		opts = &AskSQLOptions{}
+	}
+
+	source := AnalysisSourceNL2SQL
+	tables := &DataAskingTableConfig{Type: "all", DbName: scope.Database}
+	if len(scope.Tables) > 0 {
+		tables.Type = "specified"
+		tables.TableList = scope.Tables
+	}
+	analysis, err := c.AnalyzeData(ctx, &DataAnalysisRequest{
+		Question:  question,
+		Source:    &source,
+		SessionID: opts.SessionID,
+		Config: &DataAnalysisConfig{
+			DataSource: &DataSource{Type: "specified", Tables: tables},
+		},
+	}, opts.CallOptions...)
+	result := &AskSQLResult{Question: question, Analysis: analysis}
+	if err != nil {
+		return result, fmt.Errorf("generate sql: %w", err)
+	}
+	if len(analysis.SQL) == 0 {
+		return result, fmt.Errorf("no SQL was generated for the question")
+	}
+	result.SQL = analysis.SQL[len(analysis.SQL)-1]
+
+	if opts.Confirm != nil {
+		ok, err := opts.Confirm(ctx, result.SQL)
+		if err != nil {
+			return result, err
+		}
+		if !ok {
+			return result, ErrSQLNotConfirmed
+		}
+	}
+
+	req := &NL2SQLRunSQLRequest{
+		Operation: RunSQL,
+		Statement: result.SQL,
+		DbNames:   []string{scope.Database},
+	}
+	if len(scope.Tables) > 0 {
+		req.TableNames = []DbAndTablesInfo{{DbName: scope.Database, TableNames: scope.Tables}}
+	}
+	resp, err := c.raw.RunNL2SQL(ctx, req, opts.CallOptions...)
+	if err != nil {
+		return result, fmt.Errorf("run generated sql: %w", err)
+	}
+	result.Executed = true
+	if len(resp.Results) > 0 {
+		result.Result = &resp.Results[0]
+	}
+	return result, nil
+}
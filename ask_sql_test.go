@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newAskSQLMockClient(t *testing.T, runCalls *int32) *SDKClient {
+	t.Helper()
+	return NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/analyze": func(w http.ResponseWriter, r *http.Request) {
+			var req DataAnalysisRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "average salary?", req.Question)
+			require.Equal(t, "nl2sql", *req.Source)
+			require.Equal(t, "specified", req.Config.DataSource.Tables.Type)
+			require.Equal(t, "hr", req.Config.DataSource.Tables.DbName)
+			require.Equal(t, []string{"emp"}, req.Config.DataSource.Tables.TableList)
+
+			w.Header().Set(headerContentType, "text/event-stream")
+			fmt.Fprint(w, "data: {\"source\":\"nl2sql\",\"step_type\":\"sql_generated\",\"data\":{\"sql\":\"SELECT 1\"}}\n\n")
+			fmt.Fprint(w, "data: {\"source\":\"nl2sql\",\"step_type\":\"sql_generated\",\"data\":{\"sql\":\"SELECT dept, AVG(salary) AS avg_salary FROM emp GROUP BY dept\"}}\n\n")
+			fmt.Fprint(w, "event: complete\ndata: {}\n\n")
+		},
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(runCalls, 1)
+			var req NL2SQLRunSQLRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, RunSQL, req.Operation)
+			require.Equal(t, "SELECT dept, AVG(salary) AS avg_salary FROM emp GROUP BY dept", req.Statement)
+			require.Equal(t, []string{"hr"}, req.DbNames)
+			require.Equal(t, []DbAndTablesInfo{{DbName: "hr", TableNames: []string{"emp"}}}, req.TableNames)
+			writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{{
+				Columns: []string{"dept", "avg_salary"},
+				Rows:    []NL2SQLRow{{"eng", "120.5"}, {"ops", "99"}},
+			}}})
+		},
+	}))
+}
+
+func TestAskSQL(t *testing.T) {
+	t.Parallel()
+
+	var runCalls int32
+	client := newAskSQLMockClient(t, &runCalls)
+	var confirmed string
+	res, err := client.AskSQL(context.Background(), "average salary?",
+		&SQLScope{Database: "hr", Tables: []string{"emp"}},
+		&AskSQLOptions{Confirm: func(ctx context.Context, sql string) (bool, error) {
+			confirmed = sql
+			return true, nil
+		}})
+	require.NoError(t, err)
+	require.True(t, res.Executed)
+	require.Equal(t, res.SQL, confirmed)
+	require.Len(t, res.Analysis.SQL, 2)
+
+	var rows []struct {
+		Dept    string  `db:"dept"`
+		Average float64 `db:"avg_salary"`
+	}
+	require.NoError(t, res.Scan(&rows))
+	require.Len(t, rows, 2)
+	require.Equal(t, "eng", rows[0].Dept)
+	require.InDelta(t, 120.5, rows[0].Average, 1e-9)
+	require.Equal(t, int32(1), atomic.LoadInt32(&runCalls))
+}
+
+func TestAskSQL_NotConfirmed(t *testing.T) {
+	t.Parallel()
+
+	var runCalls int32
+	client := newAskSQLMockClient(t, &runCalls)
+	res, err := client.AskSQL(context.Background(), "average salary?",
+		&SQLScope{Database: "hr", Tables: []string{"emp"}},
+		&AskSQLOptions{Confirm: func(ctx context.Context, sql string) (bool, error) {
+			return false, nil
+		}})
+	require.ErrorIs(t, err, ErrSQLNotConfirmed)
+	require.False(t, res.Executed)
+	require.NotEmpty(t, res.SQL)
+	require.Zero(t, atomic.LoadInt32(&runCalls))
+}
+
+func TestAskSQL_Validation(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(&RawClient{})
+
+	_, err := client.AskSQL(context.Background(), " ", &SQLScope{Database: "hr"}, nil)
+	require.Error(t, err)
+	_, err = client.AskSQL(context.Background(), "q", nil, nil)
+	require.Error(t, err)
+}
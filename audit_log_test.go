@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// auditLogHandler serves total role log entries, one per hour from
+// 2024-05-01 00:00:00, and records the requests it receives.
+func auditLogHandler(t *testing.T, total int, reqs *[]LogLogListRequest) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req LogLogListRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*reqs = append(*reqs, req)
+		resp := LogLogListResponse{Total: total, List: []LogLogResponse{}}
+		start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		for i := (req.Page - 1) * req.PageSize; i < total && i < req.Page*req.PageSize; i++ {
+			resp.List = append(resp.List, LogLogResponse{
+				LogActionType: "update",
+				UserName:      "admin",
+				RoleName:      fmt.Sprintf("r%d", i),
+				CreatedAt:     start.Add(time.Duration(i) * time.Hour).Format("2006-01-02 15:04:05"),
+				Status:        "success",
+				Description:   "changed, \"quoted\"",
+			})
+		}
+		writeEnvelope(w, resp)
+	}
+}
+
+func TestListAuditLogs(t *testing.T) {
+	t.Parallel()
+	var reqs []LogLogListRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/log/role": auditLogHandler(t, 5, &reqs),
+	}))
+
+	pager, err := client.ListAuditLogs(context.Background(), &AuditLogQuery{
+		Kind:        AuditLogKindRole,
+		UserIDs:     []UserID{1, 2},
+		ActionTypes: []string{"update"},
+		// The server ignores the range here; the pager enforces it.
+		Since:    time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC),
+		Until:    time.Date(2024, 5, 1, 4, 0, 0, 0, time.UTC),
+		PageSize: 2,
+	})
+	require.NoError(t, err)
+	var names []string
+	for pager.Next() {
+		names = append(names, pager.Entry().RoleName)
+	}
+	require.NoError(t, pager.Err())
+	require.Equal(t, []string{"r1", "r2", "r3"}, names)
+	require.Nil(t, pager.Entry())
+
+	require.Len(t, reqs, 3)
+	require.Equal(t, 3, reqs[2].Page)
+	require.Equal(t, "created_at", reqs[0].OrderBy)
+	require.Equal(t, []CommonFilter{
+		{Name: "user_id", Values: []string{"1", "2"}},
+		{Name: "type", Values: []string{"update"}},
+		{Name: "created_at", Values: []string{"2024-05-01 01:00:00", "2024-05-01 04:00:00"}},
+	}, reqs[0].Filters)
+}
+
+func TestListAuditLogsInvalidQuery(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, nil))
+	ctx := context.Background()
+
+	_, err := client.ListAuditLogs(ctx, &AuditLogQuery{Kind: "table"})
+	require.ErrorContains(t, err, "unsupported audit log kind")
+	now := time.Now()
+	_, err = client.ListAuditLogs(ctx, &AuditLogQuery{Since: now, Until: now})
+	require.ErrorContains(t, err, "time range is empty")
+}
+
+func TestExportAuditLogs(t *testing.T) {
+	t.Parallel()
+	var reqs []LogLogListRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/log/user": auditLogHandler(t, 3, &reqs),
+	}))
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	n, err := client.ExportAuditLogs(ctx, &buf, AuditLogExportFormatCSV, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Equal(t, "created_at,type,user_name,role_name,status,description", lines[0])
+	require.Equal(t, `2024-05-01 00:00:00,update,admin,r0,success,"changed, ""quoted"""`, lines[1])
+
+	buf.Reset()
+	n, err = client.ExportAuditLogs(ctx, &buf, AuditLogExportFormatJSONL, &AuditLogQuery{PageSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	var entry LogLogResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &entry))
+	require.Equal(t, "r2", entry.RoleName)
+
+	_, err = client.ExportAuditLogs(ctx, &buf, "xml", nil)
+	require.ErrorContains(t, err, "unsupported export format")
+}
+
+func TestExportAuditLogsError(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/log/user": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelopeError(w, "ErrPermissionDenied", "no access")
+		},
+	}))
+
+	var buf bytes.Buffer
+	n, err := client.ExportAuditLogs(context.Background(), &buf, AuditLogExportFormatCSV, nil)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.Zero(t, n)
+	require.Equal(t, "created_at,type,user_name,role_name,status,description\n", buf.String())
+}
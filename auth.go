@@ -0,0 +1,177 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sessionRefreshMargin is how long before its expiry a session token is
+// replaced, so that a request does not race the expiry.
+const sessionRefreshMargin = 30 * time.Second
+
+// NewRawClientWithPassword creates a client that authenticates with a user
+// name and password instead of an API key.
+//
+// The client logs in immediately and sends the session token with every
+// request. The token is renewed by logging in again shortly before it
+// expires, and after the server rejects it with 401 Unauthorized; the
+// rejected request itself is not retried. With WithAPIKeyExchange the
+// session is instead exchanged for an API key once, and the client behaves
+// like one created with NewRawClient.
+//
+// The initial login is bounded by the HTTP timeout of the client.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClientWithPassword("https://api.example.com", "alice", password)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	catalogs, err := client.ListCatalogs(ctx)
+func NewRawClientWithPassword(baseURL, userName, password string, opts ...ClientOption) (*RawClient, error) {
+	c, cfg, err := newRawClient(baseURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	userName = strings.TrimSpace(userName)
+	if userName == "" || password == "" {
+		return nil, ErrCredentialsRequired
+	}
+	c.session = &session{
+		login: func(ctx context.Context) (*AuthLoginResponse, error) {
+			return c.Login(ctx, &AuthLoginRequest{UserName: userName, Password: password})
+		},
+	}
+
+	ctx := context.Background()
+	if _, err := c.session.token(ctx); err != nil {
+		return nil, err
+	}
+	if cfg.exchangeAPIKey {
+		resp, err := c.ExchangeAPIKey(ctx, &AuthAPIKeyExchangeRequest{})
+		if err != nil {
+			return nil, err
+		}
+		if resp.APIKey == "" {
+			return nil, fmt.Errorf("exchange api key: no api key returned")
+		}
+		c.apiKey = resp.APIKey
+		c.session = nil
+	}
+	return c, nil
+}
+
+// WithAPIKeyExchange makes NewRawClientWithPassword exchange the session
+// for an API key after logging in, so that the client does not depend on
+// the session lifetime. It has no effect on NewRawClient.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClientWithPassword(baseURL, "alice", password,
+//		sdk.WithAPIKeyExchange())
+func WithAPIKeyExchange() ClientOption {
+	return func(o *clientOptions) {
+		o.exchangeAPIKey = true
+	}
+}
+
+// Login authenticates with a user name and password and returns a session
+// token.
+//
+// The request is sent without the credentials of the client. Most callers
+// should use NewRawClientWithPassword, which logs in and renews the token
+// automatically.
+//
+// Example:
+//
+//	resp, err := client.Login(ctx, &sdk.AuthLoginRequest{
+//		UserName: "alice",
+//		Password: password,
+//	})
+func (c *RawClient) Login(ctx context.Context, req *AuthLoginRequest, opts ...CallOption) (*AuthLoginResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	opts = append(opts, func(co *callOptions) {
+		co.skipSession = true
+	})
+	var resp AuthLoginResponse
+	if err := c.postJSON(ctx, "/auth/login", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Token == "" {
+		return nil, fmt.Errorf("no session token returned")
+	}
+	return &resp, nil
+}
+
+// ExchangeAPIKey creates an API key for the authenticated user.
+//
+// It is typically called on a client created with NewRawClientWithPassword
+// to obtain a key for long-running services; see also WithAPIKeyExchange.
+//
+// Example:
+//
+//	resp, err := client.ExchangeAPIKey(ctx, &sdk.AuthAPIKeyExchangeRequest{})
+//	if err != nil {
+//		return err
+//	}
+//	keyClient, err := sdk.NewRawClient(baseURL, resp.APIKey)
+func (c *RawClient) ExchangeAPIKey(ctx context.Context, req *AuthAPIKeyExchangeRequest, opts ...CallOption) (*AuthAPIKeyExchangeResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp AuthAPIKeyExchangeResponse
+	if err := c.postJSON(ctx, "/auth/api_key/exchange", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// session holds the token of a password-authenticated client. Concurrent
+// requests share one login.
+type session struct {
+	login func(ctx context.Context) (*AuthLoginResponse, error)
+
+	mu        sync.Mutex
+	current   string
+	expiresAt time.Time // Zero if the token has no known expiry
+}
+
+// token returns a valid session token, logging in if needed.
+func (s *session) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != "" && (s.expiresAt.IsZero() || time.Now().Add(sessionRefreshMargin).Before(s.expiresAt)) {
+		return s.current, nil
+	}
+	resp, err := s.login(ctx)
+	if err != nil {
+		return "", fmt.Errorf("login: %w", err)
+	}
+	s.current = resp.Token
+	s.expiresAt = time.Time{}
+	if resp.ExpiresIn > 0 {
+		s.expiresAt = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	return s.current, nil
+}
+
+// invalidate discards token if it is still the current one, so that the
+// next request logs in again.
+func (s *session) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == token {
+		s.current = ""
+	}
+}
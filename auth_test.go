@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRawClientWithPassword(t *testing.T) {
+	t.Parallel()
+	var logins atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("Authorization"))
+		var req AuthLoginRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "alice", req.UserName)
+		require.Equal(t, "secret", req.Password)
+		n := logins.Add(1)
+		writeEnvelope(w, AuthLoginResponse{Token: "token-" + string(rune('0'+n)), ExpiresIn: 3600})
+	})
+	mux.HandleFunc("/catalog/list", func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get(headerAPIKey))
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			// The first token is rejected, as if it had been revoked.
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.Equal(t, "Bearer token-2", r.Header.Get("Authorization"))
+		writeEnvelope(w, CatalogListResponse{})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewRawClientWithPassword(server.URL, "alice", "secret")
+	require.NoError(t, err)
+	require.Equal(t, int32(1), logins.Load())
+
+	_, err = client.ListCatalogs(context.Background())
+	require.ErrorIs(t, err, ErrUnauthenticated)
+
+	_, err = client.ListCatalogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(2), logins.Load())
+}
+
+func TestNewRawClientWithPassword_RefreshesExpiredToken(t *testing.T) {
+	t.Parallel()
+	var logins atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		logins.Add(1)
+		// Expires within the refresh margin, so every request logs in again.
+		writeEnvelope(w, AuthLoginResponse{Token: "token", ExpiresIn: 1})
+	})
+	mux.HandleFunc("/catalog/list", func(w http.ResponseWriter, r *http.Request) {
+		writeEnvelope(w, CatalogListResponse{})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewRawClientWithPassword(server.URL, "alice", "secret")
+	require.NoError(t, err)
+	_, err = client.ListCatalogs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(2), logins.Load())
+}
+
+func TestNewRawClientWithPassword_APIKeyExchange(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		writeEnvelope(w, AuthLoginResponse{Token: "token"})
+	})
+	mux.HandleFunc("/auth/api_key/exchange", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		writeEnvelope(w, AuthAPIKeyExchangeResponse{APIKey: "key-1"})
+	})
+	mux.HandleFunc("/catalog/list", func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("Authorization"))
+		require.Equal(t, "key-1", r.Header.Get(headerAPIKey))
+		writeEnvelope(w, CatalogListResponse{})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewRawClientWithPassword(server.URL, "alice", "secret", WithAPIKeyExchange())
+	require.NoError(t, err)
+	_, err = client.ListCatalogs(context.Background())
+	require.NoError(t, err)
+}
+
+func TestNewRawClientWithPassword_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := NewRawClientWithPassword("", "alice", "secret")
+	require.ErrorIs(t, err, ErrBaseURLRequired)
+	_, err = NewRawClientWithPassword("http://localhost", "alice", "")
+	require.ErrorIs(t, err, ErrCredentialsRequired)
+
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/auth/login": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelopeError(w, "ErrUnauthorized", "wrong password")
+		},
+	})
+	_, err = NewRawClientWithPassword(client.baseURL, "alice", "wrong")
+	require.ErrorContains(t, err, "wrong password")
+}
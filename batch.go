@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// defaultBatchConcurrency is the number of calls of a Batch in flight.
+const defaultBatchConcurrency = 8
+
+// BatchCall is one JSON API call of a Batch.
+type BatchCall struct {
+	// Method is http.MethodGet or http.MethodPost. Defaults to POST, the
+	// method of most catalog APIs.
+	Method string
+	// Path is the API path, for example "/catalog/table/info".
+	Path string
+	// Body is encoded as the JSON request body. It is ignored for GET.
+	Body any
+	// Result, if set, receives the data of the response, as the typed
+	// methods do; for example a *TableInfoResponse.
+	Result any
+	// CallOptions apply to this call only, after those given to Batch.
+	CallOptions []CallOption
+}
+
+// BatchResult is the outcome of one BatchCall.
+type BatchResult struct {
+	// Err is nil when the call succeeded and its Result was decoded.
+	Err error
+}
+
+// Batch executes several JSON API calls and returns their results in the
+// order of calls, so that a page issuing tens of reads waits for the
+// slowest one rather than for their sum.
+//
+// The service has no batch endpoint, so the calls are sent concurrently,
+// at most WithBatchConcurrency at a time (8 by default). A failing call
+// does not stop the others. Calls not yet sent when ctx is done fail with
+// the context error.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - calls: the calls to execute
+//   - opts: call options applied to every call
+//
+// Returns:
+//   - []BatchResult: one result per call, in order
+//   - error: nil if every call succeeded; otherwise an error summarizing
+//     the failures, wrapping the first one
+//
+// Example:
+//
+//	var table sdk.TableInfoResponse
+//	var volume sdk.VolumeInfoResponse
+//	results, err := client.Batch(ctx, []sdk.BatchCall{
+//		{Path: "/catalog/table/info", Body: &sdk.TableInfoRequest{TableID: 1}, Result: &table},
+//		{Path: "/catalog/volume/info", Body: &sdk.VolumeInfoRequest{VolumeID: "v1"}, Result: &volume},
+//	})
+//	if err != nil {
+//		for i, r := range results {
+//			if r.Err != nil {
+//				log.Printf("call %d: %v", i, r.Err)
+//			}
+//		}
+//	}
+func (c *RawClient) Batch(ctx context.Context, calls []BatchCall, opts ...CallOption) ([]BatchResult, error) {
+	for i, call := range calls {
+		if call.Path == "" {
+			return nil, invalidArgument(fmt.Sprintf("calls[%d].path", i), fmt.Sprintf("call %d: path is required", i))
+		}
+		switch call.Method {
+		case "", http.MethodGet, http.MethodPost:
+		default:
+			return nil, invalidArgument(fmt.Sprintf("calls[%d].method", i), fmt.Sprintf("call %d: unsupported method %s", i, call.Method))
+		}
+	}
+	concurrency := newCallOptions(opts...).batchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	results := make([]BatchResult, len(calls))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			callOpts := append(append([]CallOption(nil), opts...), call.CallOptions...)
+			if call.Method == http.MethodGet {
+				results[i].Err = c.getJSON(ctx, call.Path, call.Result, callOpts...)
+			} else {
+				results[i].Err = c.postJSON(ctx, call.Path, call.Body, call.Result, callOpts...)
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	var first error
+	for i, r := range results {
+		if r.Err != nil {
+			if first == nil {
+				first = fmt.Errorf("call %d (%s): %w", i, calls[i].Path, r.Err)
+			}
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d calls failed, first error: %w", failed, len(calls), first)
+	}
+	return results, nil
+}
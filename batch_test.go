@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+	var inFlight, peak atomic.Int32
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/info": func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			var req TableInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.TableID == 3 {
+				writeEnvelopeError(w, "ErrNotFound", "table not found")
+				return
+			}
+			writeEnvelope(w, TableInfoResponse{Name: req.TableName})
+		},
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			writeEnvelope(w, TaskInfoResponse{Status: TaskStatusRunning})
+		},
+	})
+
+	tables := make([]TableInfoResponse, 5)
+	var calls []BatchCall
+	for i := range tables {
+		calls = append(calls, BatchCall{
+			Path:   "/catalog/table/info",
+			Body:   &TableInfoRequest{TableID: TableID(i + 1), TableName: "t"},
+			Result: &tables[i],
+		})
+	}
+	var task TaskInfoResponse
+	calls = append(calls, BatchCall{Method: http.MethodGet, Path: "/task/get", Result: &task})
+
+	results, err := client.Batch(context.Background(), calls, WithBatchConcurrency(2))
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Len(t, results, 6)
+	for i, r := range results {
+		if i == 2 {
+			require.Error(t, r.Err)
+			continue
+		}
+		require.NoError(t, r.Err)
+	}
+	require.Equal(t, "t", tables[4].Name)
+	require.Equal(t, TaskStatusRunning, task.Status)
+	require.LessOrEqual(t, peak.Load(), int32(2))
+
+	_, err = client.Batch(context.Background(), []BatchCall{{Method: http.MethodDelete, Path: "/x"}})
+	require.Error(t, err)
+}
@@ -5,8 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"slices"
 )
 
 // CreateCatalog creates a new catalog.
@@ -27,6 +27,13 @@ func (c *RawClient) CreateCatalog(ctx context.Context, req *CatalogCreateRequest
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := withNormalizedName(c, ResourceCatalog, req, func(r *CatalogCreateRequest) *string { return &r.CatalogName })
+	if err != nil {
+		return nil, err
+	}
 	var resp CatalogCreateResponse
 	if err := c.postJSON(ctx, "/catalog/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -47,10 +54,14 @@ func (c *RawClient) DeleteCatalog(ctx context.Context, req *CatalogDeleteRequest
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp CatalogDeleteResponse
 	if err := c.postJSON(ctx, "/catalog/delete", req, &resp, opts...); err != nil {
 		return nil, err
 	}
+	c.forgetResolved(ResolvedPath{CatalogID: req.CatalogID})
 	return &resp, nil
 }
 
@@ -69,10 +80,14 @@ func (c *RawClient) UpdateCatalog(ctx context.Context, req *CatalogUpdateRequest
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp CatalogUpdateResponse
 	if err := c.postJSON(ctx, "/catalog/update", req, &resp, opts...); err != nil {
 		return nil, err
 	}
+	c.forgetResolved(ResolvedPath{CatalogID: req.CatalogID})
 	return &resp, nil
 }
 
@@ -93,6 +108,9 @@ func (c *RawClient) GetCatalog(ctx context.Context, req *CatalogInfoRequest, opt
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp CatalogInfoResponse
 	if err := c.postJSON(ctx, "/catalog/info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -124,6 +142,10 @@ func (c *RawClient) ListCatalogs(ctx context.Context, opts ...CallOption) (*Cata
 // GetCatalogTree retrieves the hierarchical tree structure of catalogs, databases, tables, and volumes.
 //
 // The tree structure shows the complete organizational hierarchy of all resources.
+// On large tenants, WithTreeDepth and WithTreeNodeTypes return part of the
+// tree; the limits are also applied to the response, so that servers that
+// ignore them give the same result. When the server returns the tree in
+// parts, ContinuationToken is set: see WithTreeContinuation.
 //
 // Example:
 //
@@ -136,13 +158,41 @@ func (c *RawClient) ListCatalogs(ctx context.Context, opts ...CallOption) (*Cata
 //		fmt.Printf("Type: %s, Name: %s\n", node.Type, node.Name)
 //	}
 func (c *RawClient) GetCatalogTree(ctx context.Context, opts ...CallOption) (*CatalogTreeResponse, error) {
+	callOpts := newCallOptions(opts...)
+	req := catalogTreeRequest{
+		MaxDepth:          callOpts.treeDepth,
+		Types:             callOpts.treeTypes,
+		ContinuationToken: callOpts.treeToken,
+	}
 	var resp CatalogTreeResponse
-	if err := c.postJSON(ctx, "/catalog/tree", struct{}{}, &resp, opts...); err != nil {
+	if err := c.postJSON(ctx, "/catalog/tree", req, &resp, opts...); err != nil {
 		return nil, err
 	}
+	resp.Tree = pruneCatalogTree(resp.Tree, 1, req.MaxDepth, req.Types)
 	return &resp, nil
 }
 
+// pruneCatalogTree drops the children of the nodes at level maxDepth, and
+// the children of databases whose type is not in types.
+func pruneCatalogTree(nodes []*TreeNode, level, maxDepth int, types []string) []*TreeNode {
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		if maxDepth > 0 && level >= maxDepth {
+			node.NodeList = nil
+			continue
+		}
+		if node.Typ == NodeTypeDatabase && len(types) > 0 {
+			node.NodeList = slices.DeleteFunc(node.NodeList, func(child *TreeNode) bool {
+				return child == nil || !slices.Contains(types, child.Typ)
+			})
+		}
+		node.NodeList = pruneCatalogTree(node.NodeList, level+1, maxDepth, types)
+	}
+	return nodes
+}
+
 // GetCatalogRefList retrieves the list of references to the specified catalog.
 //
 // Returns a list of volume references associated with the catalog.
@@ -156,6 +206,9 @@ func (c *RawClient) GetCatalogRefList(ctx context.Context, req *CatalogRefListRe
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp CatalogRefListResponse
 	if err := c.postJSON(ctx, "/catalog/ref_list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -163,10 +216,11 @@ func (c *RawClient) GetCatalogRefList(ctx context.Context, req *CatalogRefListRe
 	return &resp, nil
 }
 
-// DownloadTableData downloads table data as a CSV file stream.
+// DownloadTableData downloads table data as a file stream.
 //
 // Returns a FileStream that must be closed by the caller. The stream contains
-// the CSV content that can be read directly.
+// the table data in req.Format (CSV by default), compressed as requested by
+// req.Compression.
 //
 // This method uses a client with no timeout to allow downloading large files.
 // The download can still be cancelled using the provided context.
@@ -190,6 +244,9 @@ func (c *RawClient) DownloadTableData(ctx context.Context, req *TableDownloadDat
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	callOpts := newCallOptions(opts...)
 
 	var reader *bytes.Reader
@@ -199,35 +256,13 @@ func (c *RawClient) DownloadTableData(ctx context.Context, req *TableDownloadDat
 	}
 	reader = bytes.NewReader(payload)
 
-	// Build the request
-	httpReq, err := c.buildRequest(ctx, http.MethodPost, "/catalog/table/download_data", reader, callOpts)
-	if err != nil {
-		return nil, err
-	}
-	httpReq.Header.Set(headerContentType, mimeJSON)
-
-	// Create a client with no timeout for downloading large files
-	// The download can still be cancelled via context
-	downloadClient := &http.Client{
-		Timeout: 0, // No timeout - allows downloading large files
-	}
-
-	// Execute the request
-	resp, err := downloadClient.Do(httpReq)
+	// Download without the client timeout so large files can be fetched;
+	// the download can still be cancelled via context.
+	resp, err := c.doStream(ctx, http.MethodPost, "/catalog/table/download_data", reader, callOpts, func(r *http.Request) {
+		r.Header.Set(headerContentType, mimeJSON)
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	// Check for HTTP errors
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		data, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
-	}
-
-	return &FileStream{
-		Body:       resp.Body,
-		Header:     resp.Header.Clone(),
-		StatusCode: resp.StatusCode,
-	}, nil
+	return newFileStream(resp, callOpts), nil
 }
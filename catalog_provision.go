@@ -0,0 +1,218 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// EnsureCatalog returns the ID of the catalog named name, creating it with
+// comment if it does not exist. An existing catalog is left unchanged.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - name: the name of the catalog (required)
+//   - comment: the description of a created catalog
+//
+// Returns:
+//   - CatalogID: the ID of the catalog (existing or newly created)
+//   - bool: true if the catalog was newly created
+//   - error: any error that occurred
+//
+// Example:
+//
+//	catalogID, created, err := sdkClient.EnsureCatalog(ctx, "sales", "Sales data")
+//	if err != nil {
+//		return err
+//	}
+//	if created {
+//		fmt.Printf("Created catalog %d\n", catalogID)
+//	}
+func (c *SDKClient) EnsureCatalog(ctx context.Context, name, comment string, opts ...CallOption) (CatalogID, bool, error) {
+	if name == "" {
+		return 0, false, fmt.Errorf("catalog name is required")
+	}
+	name, err := c.raw.NormalizeName(ResourceCatalog, name)
+	if err != nil {
+		return 0, false, err
+	}
+	find := func() (CatalogID, bool, error) {
+		resp, err := c.raw.ListCatalogs(ctx, opts...)
+		if err != nil {
+			return 0, false, err
+		}
+		for _, cat := range resp.List {
+			if cat.CatalogName == name {
+				return cat.CatalogID, true, nil
+			}
+		}
+		return 0, false, nil
+	}
+	create := func() (CatalogID, error) {
+		resp, err := c.raw.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: name, Comment: comment}, opts...)
+		if err != nil {
+			return 0, err
+		}
+		return resp.CatalogID, nil
+	}
+	return findOrCreate("catalog", name, find, create)
+}
+
+// EnsureDatabase returns the ID of the database named name in the catalog
+// catalogID, creating it with comment if it does not exist. An existing
+// database is left unchanged.
+//
+// Example:
+//
+//	dbID, _, err := sdkClient.EnsureDatabase(ctx, catalogID, "orders", "")
+//	if err != nil {
+//		return err
+//	}
+func (c *SDKClient) EnsureDatabase(ctx context.Context, catalogID CatalogID, name, comment string, opts ...CallOption) (DatabaseID, bool, error) {
+	if name == "" {
+		return 0, false, fmt.Errorf("database name is required")
+	}
+	name, err := c.raw.NormalizeName(ResourceDatabase, name)
+	if err != nil {
+		return 0, false, err
+	}
+	find := func() (DatabaseID, bool, error) {
+		resp, err := c.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: catalogID}, opts...)
+		if err != nil {
+			return 0, false, err
+		}
+		for _, db := range resp.List {
+			if db.DatabaseName == name {
+				return db.DatabaseID, true, nil
+			}
+		}
+		return 0, false, nil
+	}
+	create := func() (DatabaseID, error) {
+		resp, err := c.raw.CreateDatabase(ctx, &DatabaseCreateRequest{DatabaseName: name, Comment: comment, CatalogID: catalogID}, opts...)
+		if err != nil {
+			return 0, err
+		}
+		return resp.DatabaseID, nil
+	}
+	return findOrCreate("database", name, find, create)
+}
+
+// EnsureVolume returns the ID of the volume named name in the database
+// databaseID, creating it with comment if it does not exist. An existing
+// volume is left unchanged.
+//
+// Example:
+//
+//	volumeID, _, err := sdkClient.EnsureVolume(ctx, dbID, "raw-files", "Uploaded documents")
+//	if err != nil {
+//		return err
+//	}
+func (c *SDKClient) EnsureVolume(ctx context.Context, databaseID DatabaseID, name, comment string, opts ...CallOption) (VolumeID, bool, error) {
+	if name == "" {
+		return "", false, fmt.Errorf("volume name is required")
+	}
+	name, err := c.raw.NormalizeName(ResourceVolume, name)
+	if err != nil {
+		return "", false, err
+	}
+	find := func() (VolumeID, bool, error) {
+		child, err := c.findDatabaseChild(ctx, databaseID, name, NodeTypeVolume, opts)
+		if err != nil || child == nil {
+			return "", false, err
+		}
+		return VolumeID(child.ID), true, nil
+	}
+	create := func() (VolumeID, error) {
+		resp, err := c.raw.CreateVolume(ctx, &VolumeCreateRequest{Name: name, DatabaseID: databaseID, Comment: comment}, opts...)
+		if err != nil {
+			return "", err
+		}
+		return resp.VolumeID, nil
+	}
+	return findOrCreate("volume", name, find, create)
+}
+
+// EnsureTable returns the ID of the table named name in the database
+// databaseID, creating it with columns and comment if it does not exist.
+// The columns of an existing table are not compared with columns.
+//
+// Example:
+//
+//	tableID, created, err := sdkClient.EnsureTable(ctx, dbID, "customers", []sdk.Column{
+//		{Name: "id", Type: "int", IsPk: true},
+//		{Name: "name", Type: "varchar(255)"},
+//	}, "Customer master data")
+//	if err != nil {
+//		return err
+//	}
+func (c *SDKClient) EnsureTable(ctx context.Context, databaseID DatabaseID, name string, columns []Column, comment string, opts ...CallOption) (TableID, bool, error) {
+	if name == "" {
+		return 0, false, fmt.Errorf("table name is required")
+	}
+	name, err := c.raw.NormalizeName(ResourceTable, name)
+	if err != nil {
+		return 0, false, err
+	}
+	find := func() (TableID, bool, error) {
+		return c.findTable(ctx, databaseID, name, opts)
+	}
+	create := func() (TableID, error) {
+		resp, err := c.raw.CreateTable(ctx, &TableCreateRequest{DatabaseID: databaseID, Name: name, Columns: columns, Comment: comment}, opts...)
+		if err != nil {
+			return 0, err
+		}
+		return resp.TableID, nil
+	}
+	return findOrCreate("table", name, find, create)
+}
+
+// findTable returns the ID of the table named name in the database, and
+// false if there is none.
+func (c *SDKClient) findTable(ctx context.Context, databaseID DatabaseID, name string, opts []CallOption) (TableID, bool, error) {
+	child, err := c.findDatabaseChild(ctx, databaseID, name, NodeTypeTable, opts)
+	if err != nil || child == nil {
+		return 0, false, err
+	}
+	id, err := strconv.ParseInt(child.ID, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("sdk: invalid table id %q: %w", child.ID, err)
+	}
+	return TableID(id), true, nil
+}
+
+// findDatabaseChild returns the child of type typ named name of the
+// database, or nil if there is none.
+func (c *SDKClient) findDatabaseChild(ctx context.Context, databaseID DatabaseID, name, typ string, opts []CallOption) (*DatabaseChildrenResponse, error) {
+	resp, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID, Types: []string{typ}}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.List {
+		if resp.List[i].Name == name && resp.List[i].Typ == typ {
+			return &resp.List[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// findOrCreate returns the ID found by find, or the ID returned by create
+// if find finds nothing. If create fails because the object was created
+// concurrently, the object is looked up again.
+func findOrCreate[ID any](kind, name string, find func() (ID, bool, error), create func() (ID, error)) (ID, bool, error) {
+	var zero ID
+	if id, ok, err := find(); err != nil || ok {
+		return id, false, err
+	}
+	id, err := create()
+	if err == nil {
+		return id, true, nil
+	}
+	if errors.Is(err, ErrConflict) {
+		if id, ok, findErr := find(); findErr == nil && ok {
+			return id, false, nil
+		}
+	}
+	return zero, false, fmt.Errorf("failed to create %s %q: %w", kind, name, err)
+}
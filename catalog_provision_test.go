@@ -0,0 +1,78 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureCatalogAndDatabase(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var createdDB DatabaseCreateRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, CatalogListResponse{List: []CatalogResponse{{CatalogID: 1, CatalogName: "sales"}}})
+		},
+		"/catalog/create": func(w http.ResponseWriter, r *http.Request) {
+			t.Error("existing catalog must not be created")
+		},
+		"/catalog/database/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseListResponse{List: []DatabaseResponse{{DatabaseID: 2, DatabaseName: "orders_archive"}}})
+		},
+		"/catalog/database/create": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&createdDB))
+			writeEnvelope(w, DatabaseCreateResponse{DatabaseID: 3})
+		},
+	}))
+
+	catalogID, created, err := client.EnsureCatalog(ctx, "sales", "")
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, CatalogID(1), catalogID)
+
+	dbID, created, err := client.EnsureDatabase(ctx, catalogID, "orders", "order data")
+	require.NoError(t, err)
+	require.True(t, created)
+	require.Equal(t, DatabaseID(3), dbID)
+	require.Equal(t, DatabaseCreateRequest{DatabaseName: "orders", Comment: "order data", CatalogID: 1}, createdDB)
+
+	_, _, err = client.EnsureCatalog(ctx, "", "")
+	require.Error(t, err)
+}
+
+func TestEnsureTableAndVolume(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	volumeCreated := false
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			list := []DatabaseChildrenResponse{
+				{ID: "10", Name: "customers", Typ: NodeTypeTable},
+				{ID: "vol-1", Name: "customers", Typ: NodeTypeVolume},
+			}
+			if volumeCreated {
+				list = append(list, DatabaseChildrenResponse{ID: "vol-2", Name: "docs", Typ: NodeTypeVolume})
+			}
+			writeEnvelope(w, DatabaseChildrenResponseData{List: list})
+		},
+		"/catalog/volume/create": func(w http.ResponseWriter, r *http.Request) {
+			// Another client created the volume since the lookup.
+			volumeCreated = true
+			writeEnvelopeError(w, "ErrAlreadyExists", "volume docs already exists")
+		},
+	}))
+
+	tableID, created, err := client.EnsureTable(ctx, 2, "customers", []Column{{Name: "id", Type: "int"}}, "")
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, TableID(10), tableID)
+
+	volumeID, created, err := client.EnsureVolume(ctx, 2, "docs", "")
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, VolumeID("vol-2"), volumeID)
+}
@@ -0,0 +1,270 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultSnapshotConcurrency is the number of requests SnapshotCatalog runs
+// at the same time when no concurrency is given.
+const defaultSnapshotConcurrency = 4
+
+// CatalogSnapshotOptions configures SnapshotCatalog.
+type CatalogSnapshotOptions struct {
+	// Concurrency is the maximum number of requests in flight. Defaults to 4.
+	Concurrency int
+	// SkipFiles leaves the file listings of volumes out of the snapshot.
+	// Volume sizes are then the ones reported by the database listing.
+	SkipFiles bool
+	// OnDatabase, if set, is called with each database as soon as its
+	// inventory is complete, so that large catalogs can be streamed to a
+	// report while the walk continues. Calls are serialized.
+	OnDatabase func(*DatabaseSnapshot)
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// CatalogSnapshot is the inventory of a catalog at a point in time.
+type CatalogSnapshot struct {
+	CatalogID   CatalogID
+	CatalogName string
+	TakenAt     time.Time
+	// Databases are in the order of the database listing.
+	Databases []*DatabaseSnapshot
+}
+
+// DatabaseSnapshot is the inventory of one database.
+type DatabaseSnapshot struct {
+	DatabaseID   DatabaseID
+	DatabaseName string
+	Tables       []*TableSnapshot
+	Volumes      []*VolumeSnapshot
+}
+
+// TableSnapshot describes one table of a snapshot.
+type TableSnapshot struct {
+	TableID   TableID
+	TableName string
+	Rows      int64
+	Size      int64
+}
+
+// VolumeSnapshot describes one volume of a snapshot.
+type VolumeSnapshot struct {
+	VolumeID   VolumeID
+	VolumeName string
+	// Size is the total size of the files of the volume.
+	Size int64
+	// Files are the files of the volume; empty with SkipFiles.
+	Files []VolumeSnapshotFile
+}
+
+// VolumeSnapshotFile is a file of a volume snapshot.
+type VolumeSnapshotFile struct {
+	FileID FileID
+	// Path is the slash-separated path of the file inside the volume.
+	Path string
+	Size int64
+}
+
+// Totals returns the number of tables, volumes and files of the snapshot,
+// the total row count of its tables and the total size of its tables and
+// volumes.
+func (s *CatalogSnapshot) Totals() (tables, volumes, files int, rows, size int64) {
+	for _, db := range s.Databases {
+		for _, t := range db.Tables {
+			tables++
+			rows += t.Rows
+			size += t.Size
+		}
+		for _, v := range db.Volumes {
+			volumes++
+			files += len(v.Files)
+			size += v.Size
+		}
+	}
+	return tables, volumes, files, rows, size
+}
+
+// SnapshotCatalog walks a catalog and returns the inventory of its
+// databases, tables, volumes and files, with table row counts and sizes.
+//
+// Databases, tables and volumes are inspected concurrently, with at most
+// opts.Concurrency requests in flight. Set opts.OnDatabase to receive each
+// database as soon as it is complete. The walk stops at the first error.
+//
+// Parameters:
+//   - ctx: context for the requests; cancelling it aborts the walk
+//   - catalogID: the catalog to inventory (required)
+//   - opts: optional concurrency and streaming settings; nil uses the defaults
+//
+// Returns:
+//   - *CatalogSnapshot: the inventory of the catalog
+//   - error: any error that occurred
+//
+// Example:
+//
+//	snap, err := sdkClient.SnapshotCatalog(ctx, catalogID, &sdk.CatalogSnapshotOptions{Concurrency: 8})
+//	if err != nil {
+//		return err
+//	}
+//	tables, volumes, files, rows, size := snap.Totals()
+//	fmt.Printf("%d tables (%d rows), %d volumes, %d files, %d bytes\n", tables, rows, volumes, files, size)
+func (c *SDKClient) SnapshotCatalog(ctx context.Context, catalogID CatalogID, opts *CatalogSnapshotOptions) (*CatalogSnapshot, error) {
+	if catalogID == 0 {
+		return nil, fmt.Errorf("catalog_id is required")
+	}
+	var cfg CatalogSnapshotOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultSnapshotConcurrency
+	}
+
+	catalog, err := c.raw.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: catalogID}, cfg.CallOptions...)
+	if err != nil {
+		return nil, err
+	}
+	dbs, err := c.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: catalogID}, cfg.CallOptions...)
+	if err != nil {
+		return nil, err
+	}
+	snap := &CatalogSnapshot{CatalogID: catalogID, CatalogName: catalog.CatalogName, TakenAt: time.Now()}
+	for _, db := range dbs.List {
+		snap.Databases = append(snap.Databases, &DatabaseSnapshot{DatabaseID: db.DatabaseID, DatabaseName: db.DatabaseName})
+	}
+
+	w := &snapshotWalker{client: c, cfg: cfg, sem: make(chan struct{}, cfg.Concurrency)}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	defer w.cancel()
+	var wg sync.WaitGroup
+	for _, db := range snap.Databases {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.walkDatabase(db)
+		}()
+	}
+	wg.Wait()
+	if w.err != nil {
+		return nil, w.err
+	}
+	return snap, nil
+}
+
+// snapshotWalker holds the state shared by the goroutines of a
+// SnapshotCatalog call.
+type snapshotWalker struct {
+	client *SDKClient
+	cfg    CatalogSnapshotOptions
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// do runs fn once a request slot is free. It records the first error and
+// cancels the rest of the walk.
+func (w *snapshotWalker) do(fn func(ctx context.Context) error) bool {
+	select {
+	case w.sem <- struct{}{}:
+	case <-w.ctx.Done():
+		w.fail(w.ctx.Err())
+		return false
+	}
+	defer func() { <-w.sem }()
+	if err := fn(w.ctx); err != nil {
+		w.fail(err)
+		return false
+	}
+	return true
+}
+
+func (w *snapshotWalker) fail(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err == nil {
+		w.err = err
+		w.cancel()
+	}
+}
+
+func (w *snapshotWalker) walkDatabase(db *DatabaseSnapshot) {
+	opts := w.cfg.CallOptions
+	var children *DatabaseChildrenResponseData
+	if !w.do(func(ctx context.Context) (err error) {
+		children, err = w.client.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: db.DatabaseID}, opts...)
+		if err != nil {
+			return fmt.Errorf("list database %q: %w", db.DatabaseName, err)
+		}
+		return nil
+	}) {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, child := range children.List {
+		switch child.Typ {
+		case NodeTypeTable:
+			id, err := strconv.ParseInt(child.ID, 10, 64)
+			if err != nil {
+				w.fail(fmt.Errorf("sdk: invalid table id %q: %w", child.ID, err))
+				return
+			}
+			t := &TableSnapshot{TableID: TableID(id), TableName: child.Name, Size: child.Size}
+			db.Tables = append(db.Tables, t)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.do(func(ctx context.Context) error {
+					info, err := w.client.raw.GetTable(ctx, &TableInfoRequest{TableID: t.TableID}, opts...)
+					if err != nil {
+						return fmt.Errorf("get table %q: %w", t.TableName, err)
+					}
+					t.Rows = info.Lines
+					if info.Size > 0 {
+						t.Size = info.Size
+					}
+					return nil
+				})
+			}()
+		case NodeTypeVolume:
+			v := &VolumeSnapshot{VolumeID: VolumeID(child.ID), VolumeName: child.Name, Size: child.Size}
+			db.Volumes = append(db.Volumes, v)
+			if w.cfg.SkipFiles {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w.do(func(ctx context.Context) error {
+					tree := &DirectoryExportResult{}
+					if err := w.client.listVolumeTree(ctx, v.VolumeID, "", "", tree, opts); err != nil {
+						return fmt.Errorf("list volume %q: %w", v.VolumeName, err)
+					}
+					v.Size = 0
+					for _, f := range tree.Files {
+						v.Files = append(v.Files, VolumeSnapshotFile{FileID: f.FileID, Path: f.Path, Size: f.Size})
+						v.Size += f.Size
+					}
+					return nil
+				})
+			}()
+		}
+	}
+	wg.Wait()
+
+	if w.cfg.OnDatabase != nil {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.err == nil {
+			w.cfg.OnDatabase(db)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func snapshotHandlers(t *testing.T) map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"/catalog/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, CatalogInfoResponse{CatalogID: 1, CatalogName: "main"})
+		},
+		"/catalog/database/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseListResponse{List: []DatabaseResponse{
+				{DatabaseID: 10, DatabaseName: "sales"},
+				{DatabaseID: 20, DatabaseName: "docs"},
+			}})
+		},
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			var req DatabaseChildrenRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.DatabaseID == 10 {
+				writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+					{ID: "100", Name: "orders", Typ: NodeTypeTable, Size: 1},
+					{ID: "101", Name: "customers", Typ: NodeTypeTable},
+				}})
+				return
+			}
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+				{ID: "v1", Name: "pdfs", Typ: NodeTypeVolume, Size: 999},
+			}})
+		},
+		"/catalog/table/info": func(w http.ResponseWriter, r *http.Request) {
+			var req TableInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			writeEnvelope(w, TableInfoResponse{Lines: int64(req.TableID), Size: int64(req.TableID) * 10})
+		},
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Filters[1].Values[0] == "" {
+				writeEnvelope(w, FileListResponse{Total: 2, List: []VolumeChildrenResponse{
+					{ID: "f1", Name: "a.pdf", FileType: "pdf", Size: 5},
+					{ID: "d1", Name: "sub", FileType: "folder"},
+				}})
+				return
+			}
+			writeEnvelope(w, FileListResponse{Total: 1, List: []VolumeChildrenResponse{
+				{ID: "f2", Name: "b.pdf", FileType: "pdf", Size: 7},
+			}})
+		},
+	}
+}
+
+func TestSnapshotCatalog(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, snapshotHandlers(t)))
+
+	var mu sync.Mutex
+	var streamed []string
+	snap, err := client.SnapshotCatalog(context.Background(), 1, &CatalogSnapshotOptions{
+		Concurrency: 2,
+		OnDatabase: func(db *DatabaseSnapshot) {
+			mu.Lock()
+			defer mu.Unlock()
+			streamed = append(streamed, db.DatabaseName)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "main", snap.CatalogName)
+	require.ElementsMatch(t, []string{"sales", "docs"}, streamed)
+
+	require.Len(t, snap.Databases, 2)
+	require.Equal(t, []*TableSnapshot{
+		{TableID: 100, TableName: "orders", Rows: 100, Size: 1000},
+		{TableID: 101, TableName: "customers", Rows: 101, Size: 1010},
+	}, snap.Databases[0].Tables)
+	require.Equal(t, []*VolumeSnapshot{{
+		VolumeID: "v1", VolumeName: "pdfs", Size: 12,
+		Files: []VolumeSnapshotFile{{FileID: "f1", Path: "a.pdf", Size: 5}, {FileID: "f2", Path: "sub/b.pdf", Size: 7}},
+	}}, snap.Databases[1].Volumes)
+
+	tables, volumes, files, rows, size := snap.Totals()
+	require.Equal(t, []int64{2, 1, 2, 201, 2022}, []int64{int64(tables), int64(volumes), int64(files), rows, size})
+
+	snap, err = client.SnapshotCatalog(context.Background(), 1, &CatalogSnapshotOptions{SkipFiles: true})
+	require.NoError(t, err)
+	require.Equal(t, []*VolumeSnapshot{{VolumeID: "v1", VolumeName: "pdfs", Size: 999}}, snap.Databases[1].Volumes)
+}
+
+func TestSnapshotCatalogError(t *testing.T) {
+	t.Parallel()
+	handlers := snapshotHandlers(t)
+	handlers["/catalog/table/info"] = func(w http.ResponseWriter, r *http.Request) {
+		writeEnvelopeError(w, "ErrNotFound", "table not found")
+	}
+	client := NewSDKClient(newMockClient(t, handlers))
+
+	called := false
+	_, err := client.SnapshotCatalog(context.Background(), 1, &CatalogSnapshotOptions{
+		OnDatabase: func(db *DatabaseSnapshot) {
+			if db.DatabaseName == "sales" {
+				called = true
+			}
+		},
+	})
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorContains(t, err, "get table")
+	require.False(t, called)
+
+	_, err = client.SnapshotCatalog(context.Background(), 0, nil)
+	require.ErrorContains(t, err, "catalog_id is required")
+}
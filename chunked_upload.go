@@ -0,0 +1,364 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultChunkSize          = 8 << 20 // 8 MiB
+	defaultChunkParallelism   = 4
+	defaultChunkPartRetries   = 3
+	defaultChunkRetryInterval = 500 * time.Millisecond
+)
+
+// ChunkedUploadOptions configures the resumable chunked upload mode enabled
+// by WithChunkedUpload.
+//
+// In chunked mode each file is split into parts that are uploaded
+// independently (and in parallel) to an upload session on the server. A
+// failed part is retried without resending the rest of the file, and an
+// interrupted upload can be resumed later from its resume token.
+type ChunkedUploadOptions struct {
+	// ChunkSize is the size of each part in bytes. Defaults to 8 MiB.
+	ChunkSize int64
+	// Parallelism is the number of parts uploaded concurrently. Defaults to 4.
+	Parallelism int
+	// MaxPartRetries is how many times a failed part is retried before the
+	// upload is given up. Defaults to 3; a negative value disables retries.
+	MaxPartRetries int
+	// ResumeTokens maps a file name to the resume token of an earlier,
+	// interrupted upload of that file. Parts already stored by the server
+	// are skipped.
+	ResumeTokens map[string]string
+	// OnUploadStarted, if set, is called with the resume token as soon as the
+	// server has opened an upload session for a file. Persist the token to be
+	// able to resume after the process itself is interrupted.
+	OnUploadStarted func(fileName, resumeToken string)
+}
+
+func (o ChunkedUploadOptions) withDefaults() ChunkedUploadOptions {
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = defaultChunkSize
+	}
+	if o.Parallelism <= 0 {
+		o.Parallelism = defaultChunkParallelism
+	}
+	if o.MaxPartRetries < 0 {
+		o.MaxPartRetries = 0
+	} else if o.MaxPartRetries == 0 {
+		o.MaxPartRetries = defaultChunkPartRetries
+	}
+	return o
+}
+
+// ResumableUploadError is returned when a chunked upload fails after the
+// upload session was opened. ResumeToken can be passed back through
+// ChunkedUploadOptions.ResumeTokens to continue the upload.
+type ResumableUploadError struct {
+	FileName    string
+	ResumeToken string
+	Err         error
+}
+
+func (e *ResumableUploadError) Error() string {
+	return fmt.Sprintf("sdk: chunked upload of %s interrupted (resume token %s): %v", e.FileName, e.ResumeToken, e.Err)
+}
+
+func (e *ResumableUploadError) Unwrap() error {
+	return e.Err
+}
+
+type chunkUploadInitRequest struct {
+	VolumeID  VolumeID `json:"volume_id"`
+	FileName  string   `json:"file_name"`
+	FileSize  int64    `json:"file_size"`
+	ChunkSize int64    `json:"chunk_size"`
+}
+
+type chunkUploadInitResponse struct {
+	UploadID  string `json:"upload_id"`
+	ChunkSize int64  `json:"chunk_size"`
+}
+
+type chunkUploadStatusRequest struct {
+	UploadID string `json:"upload_id"`
+}
+
+type chunkUploadStatusResponse struct {
+	UploadID      string `json:"upload_id"`
+	FileSize      int64  `json:"file_size"`
+	ChunkSize     int64  `json:"chunk_size"`
+	UploadedParts []int  `json:"uploaded_parts"`
+}
+
+type chunkUploadCompleteRequest struct {
+	VolumeID           VolumeID        `json:"volume_id"`
+	UploadIDs          []string        `json:"upload_ids"`
+	Meta               []FileMeta      `json:"meta,omitempty"`
+	FileTypes          []int32         `json:"file_types,omitempty"`
+	PathRegex          string          `json:"path_regex,omitempty"`
+	UnzipKeepStructure bool            `json:"unzip_keep_structure,omitempty"`
+	DedupConfig        *DedupConfig    `json:"dedup,omitempty"`
+	TableConfig        *TableConfig    `json:"table_config,omitempty"`
+	Sheets             []SheetSelector `json:"sheets,omitempty"`
+}
+
+// uploadConnectorFileChunked implements UploadConnectorFile in chunked mode:
+// every file is uploaded part by part into its own upload session and the
+// sessions are then committed together with the request options.
+func (c *RawClient) uploadConnectorFileChunked(ctx context.Context, req *UploadFileRequest, callOpts callOptions, opts []CallOption) (*UploadFileResponse, error) {
+	chunkOpts := callOpts.chunkedUpload.withDefaults()
+
+	var progress *uploadProgress
+	if callOpts.uploadProgress != nil {
+		sizes := make([]int64, len(req.Files))
+		for i, item := range req.Files {
+			// Files of unknown size fail in uploadFileInChunks.
+			_, sizes[i], _ = readerAtWithSize(item.File)
+		}
+		progress = newUploadProgress(callOpts.uploadProgress, sizes)
+	}
+
+	uploadIDs := make([]string, 0, len(req.Files))
+	for i, item := range req.Files {
+		uploadID, err := c.uploadFileInChunks(ctx, req.VolumeID, item, chunkOpts, progress, i, opts)
+		if err != nil {
+			return nil, err
+		}
+		uploadIDs = append(uploadIDs, uploadID)
+	}
+
+	complete := &chunkUploadCompleteRequest{
+		VolumeID:           req.VolumeID,
+		UploadIDs:          uploadIDs,
+		Meta:               req.Meta,
+		FileTypes:          req.FileTypes,
+		PathRegex:          req.PathRegex,
+		UnzipKeepStructure: req.UnzipKeepStructure,
+		DedupConfig:        req.DedupConfig,
+		TableConfig:        req.TableConfig,
+		Sheets:             req.Sheets,
+	}
+	var resp UploadFileResponse
+	if err := c.postJSON(ctx, "/connectors/upload/chunk/complete", complete, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// uploadFileInChunks uploads one file and returns its upload session ID.
+// The parts sent are reported to slot index of progress.
+func (c *RawClient) uploadFileInChunks(ctx context.Context, volumeID VolumeID, item FileUploadItem, chunkOpts ChunkedUploadOptions, progress *uploadProgress, index int, opts []CallOption) (string, error) {
+	readerAt, size, err := readerAtWithSize(item.File)
+	if err != nil {
+		return "", fmt.Errorf("chunked upload of %s: %w", item.FileName, err)
+	}
+
+	uploadID := chunkOpts.ResumeTokens[item.FileName]
+	chunkSize := chunkOpts.ChunkSize
+	done := make(map[int]bool)
+	if uploadID != "" {
+		var status chunkUploadStatusResponse
+		if err := c.postJSON(ctx, "/connectors/upload/chunk/status", &chunkUploadStatusRequest{UploadID: uploadID}, &status, opts...); err != nil {
+			return "", fmt.Errorf("resume chunked upload of %s: %w", item.FileName, err)
+		}
+		if status.FileSize != 0 && status.FileSize != size {
+			return "", fmt.Errorf("resume chunked upload of %s: file size changed from %d to %d", item.FileName, status.FileSize, size)
+		}
+		if status.ChunkSize > 0 {
+			chunkSize = status.ChunkSize
+		}
+		for _, part := range status.UploadedParts {
+			done[part] = true
+		}
+	} else {
+		var initResp chunkUploadInitResponse
+		initReq := &chunkUploadInitRequest{
+			VolumeID:  volumeID,
+			FileName:  item.FileName,
+			FileSize:  size,
+			ChunkSize: chunkSize,
+		}
+		if err := c.postJSON(ctx, "/connectors/upload/chunk/init", initReq, &initResp, opts...); err != nil {
+			return "", fmt.Errorf("start chunked upload of %s: %w", item.FileName, err)
+		}
+		if initResp.UploadID == "" {
+			return "", fmt.Errorf("start chunked upload of %s: server returned no upload_id", item.FileName)
+		}
+		uploadID = initResp.UploadID
+		if initResp.ChunkSize > 0 {
+			chunkSize = initResp.ChunkSize
+		}
+	}
+	if chunkOpts.OnUploadStarted != nil {
+		chunkOpts.OnUploadStarted(item.FileName, uploadID)
+	}
+
+	partCount := int((size + chunkSize - 1) / chunkSize)
+	if partCount == 0 {
+		partCount = 1 // empty files still need one (empty) part
+	}
+	var pending []int
+	for part := 1; part <= partCount; part++ {
+		if !done[part] {
+			pending = append(pending, part)
+		} else {
+			progress.add(index, partLength(part, size, chunkSize))
+		}
+	}
+
+	onPart := func(part int) { progress.add(index, partLength(part, size, chunkSize)) }
+	if err := c.uploadParts(ctx, uploadID, readerAt, size, chunkSize, pending, chunkOpts, onPart, opts); err != nil {
+		return "", &ResumableUploadError{FileName: item.FileName, ResumeToken: uploadID, Err: err}
+	}
+	return uploadID, nil
+}
+
+// uploadParts uploads the given part numbers using a bounded worker pool and
+// stops at the first part that fails after all retries. onPart is called
+// after each part is uploaded.
+func (c *RawClient) uploadParts(ctx context.Context, uploadID string, readerAt io.ReaderAt, size, chunkSize int64, parts []int, chunkOpts ChunkedUploadOptions, onPart func(part int), opts []CallOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	work := make(chan int)
+	for i := 0; i < chunkOpts.Parallelism && i < len(parts); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for part := range work {
+				offset := int64(part-1) * chunkSize
+				section := io.NewSectionReader(readerAt, offset, partLength(part, size, chunkSize))
+				if err := c.uploadPartWithRetry(ctx, uploadID, part, section, chunkOpts.MaxPartRetries, opts); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				onPart(part)
+			}
+		}()
+	}
+
+	sort.Ints(parts)
+feed:
+	for _, part := range parts {
+		select {
+		case work <- part:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+func (c *RawClient) uploadPartWithRetry(ctx context.Context, uploadID string, part int, section *io.SectionReader, retries int, opts []CallOption) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * defaultChunkRetryInterval):
+			}
+			if _, seekErr := section.Seek(0, io.SeekStart); seekErr != nil {
+				return seekErr
+			}
+		}
+		err = c.uploadPart(ctx, uploadID, part, section, opts)
+		if err == nil {
+			return nil
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) || ctx.Err() != nil {
+			// The server rejected the part or the upload was cancelled;
+			// retrying will not help.
+			return fmt.Errorf("upload part %d: %w", part, err)
+		}
+	}
+	return fmt.Errorf("upload part %d after %d attempts: %w", part, retries+1, err)
+}
+
+func (c *RawClient) uploadPart(ctx context.Context, uploadID string, part int, data io.Reader, opts []CallOption) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writer.WriteField("upload_id", uploadID); err != nil {
+		return fmt.Errorf("write upload_id field: %w", err)
+	}
+	if err := writer.WriteField("part_number", strconv.Itoa(part)); err != nil {
+		return fmt.Errorf("write part_number field: %w", err)
+	}
+	chunkField, err := writer.CreateFormFile("chunk", fmt.Sprintf("part-%d", part))
+	if err != nil {
+		return fmt.Errorf("create chunk field: %w", err)
+	}
+	if _, err := io.Copy(chunkField, contextReader{ctx, data}); err != nil {
+		return fmt.Errorf("copy chunk: %w", err)
+	}
+	contentType := writer.FormDataContentType()
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close multipart writer: %w", err)
+	}
+
+	resp, err := c.doStream(ctx, http.MethodPost, "/connectors/upload/chunk/part", body, newCallOptions(opts...), func(r *http.Request) {
+		r.Header.Set(headerContentType, contentType)
+		r.Header.Set(headerAccept, mimeJSON)
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return decodeEnvelope(resp, nil)
+}
+
+// readerAtWithSize returns random access to r and its total size. Chunked
+// uploads need both to upload parts in parallel and to skip parts on resume.
+func readerAtWithSize(r io.Reader) (io.ReaderAt, int64, error) {
+	readerAt, ok := r.(io.ReaderAt)
+	if !ok {
+		return nil, 0, fmt.Errorf("file must implement io.ReaderAt (for example *os.File or *bytes.Reader)")
+	}
+	switch v := r.(type) {
+	case interface{ Stat() (os.FileInfo, error) }:
+		info, err := v.Stat()
+		if err != nil {
+			return nil, 0, fmt.Errorf("stat file: %w", err)
+		}
+		return readerAt, info.Size(), nil
+	case interface{ Size() int64 }:
+		return readerAt, v.Size(), nil
+	}
+	return nil, 0, fmt.Errorf("cannot determine file size")
+}
+
+// partLength returns the size of a part of a file split into chunks.
+func partLength(part int, size, chunkSize int64) int64 {
+	offset := int64(part-1) * chunkSize
+	if offset+chunkSize > size {
+		return size - offset
+	}
+	return chunkSize
+}
@@ -0,0 +1,171 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChunkServer stores uploaded parts in memory. failPart makes the given
+// part fail with a connection reset the first failures times it is sent.
+type fakeChunkServer struct {
+	mu       sync.Mutex
+	parts    map[string]map[int]string
+	attempts map[int]int
+	failPart int
+	failures int
+	complete *chunkUploadCompleteRequest
+}
+
+func (s *fakeChunkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeData := func(data interface{}) {
+		payload, _ := json.Marshal(data)
+		_, _ = w.Write([]byte(`{"code":"OK","data":` + string(payload) + `}`))
+	}
+	switch r.URL.Path {
+	case "/connectors/upload/chunk/init":
+		var req chunkUploadInitRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		id := "upload-" + strconv.Itoa(len(s.parts)+1)
+		s.parts[id] = make(map[int]string)
+		writeData(chunkUploadInitResponse{UploadID: id, ChunkSize: req.ChunkSize})
+	case "/connectors/upload/chunk/status":
+		var req chunkUploadStatusRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		status := chunkUploadStatusResponse{UploadID: req.UploadID}
+		for part := range s.parts[req.UploadID] {
+			status.UploadedParts = append(status.UploadedParts, part)
+		}
+		writeData(status)
+	case "/connectors/upload/chunk/part":
+		part, _ := strconv.Atoi(r.FormValue("part_number"))
+		s.attempts[part]++
+		if part == s.failPart && s.attempts[part] <= s.failures {
+			hj, _ := w.(http.Hijacker)
+			conn, _, _ := hj.Hijack()
+			conn.Close()
+			return
+		}
+		file, _, err := r.FormFile("chunk")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		s.parts[r.FormValue("upload_id")][part] = string(data)
+		writeData(struct{}{})
+	case "/connectors/upload/chunk/complete":
+		s.complete = &chunkUploadCompleteRequest{}
+		_ = json.NewDecoder(r.Body).Decode(s.complete)
+		writeData(UploadFileResponse{Success: true, TaskId: 7})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *fakeChunkServer) attemptsFor(part int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts[part]
+}
+
+func (s *fakeChunkServer) partCount(uploadID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.parts[uploadID])
+}
+
+func (s *fakeChunkServer) assembled(uploadID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var b strings.Builder
+	for part := 1; part <= len(s.parts[uploadID]); part++ {
+		b.WriteString(s.parts[uploadID][part])
+	}
+	return b.String()
+}
+
+func newFakeChunkServer(t *testing.T) (*fakeChunkServer, *RawClient) {
+	fake := &fakeChunkServer{parts: make(map[string]map[int]string), attempts: make(map[int]int)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := NewRawClient(server.URL, "key")
+	require.NoError(t, err)
+	return fake, client
+}
+
+func TestUploadConnectorFileChunked(t *testing.T) {
+	fake, client := newFakeChunkServer(t)
+	fake.failPart, fake.failures = 2, 1
+	content := strings.Repeat("0123456789", 10)
+
+	var started []string
+	resp, err := client.UploadConnectorFile(context.Background(), &UploadFileRequest{
+		VolumeID: "v1",
+		Files:    []FileUploadItem{{File: strings.NewReader(content), FileName: "a.txt"}},
+		Meta:     []FileMeta{{Filename: "a.txt", Path: "a.txt"}},
+	}, WithChunkedUpload(ChunkedUploadOptions{
+		ChunkSize:       16,
+		Parallelism:     3,
+		OnUploadStarted: func(name, token string) { started = append(started, name+"="+token) },
+	}))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), resp.TaskId)
+	require.Equal(t, []string{"a.txt=upload-1"}, started)
+	require.Equal(t, content, fake.assembled("upload-1"))
+	require.Equal(t, 2, fake.attemptsFor(2))
+	require.Equal(t, []string{"upload-1"}, fake.complete.UploadIDs)
+	require.Equal(t, VolumeID("v1"), fake.complete.VolumeID)
+}
+
+func TestUploadConnectorFileChunkedResume(t *testing.T) {
+	fake, client := newFakeChunkServer(t)
+	fake.failPart, fake.failures = 3, 100
+	content := strings.Repeat("abcdefgh", 8)
+	req := &UploadFileRequest{
+		VolumeID: "v1",
+		Files:    []FileUploadItem{{File: strings.NewReader(content), FileName: "b.txt"}},
+	}
+
+	_, err := client.UploadConnectorFile(context.Background(), req,
+		WithChunkedUpload(ChunkedUploadOptions{ChunkSize: 16, Parallelism: 1, MaxPartRetries: -1}))
+	var resumable *ResumableUploadError
+	require.True(t, errors.As(err, &resumable), "got %v", err)
+	require.Equal(t, "b.txt", resumable.FileName)
+	require.Equal(t, "upload-1", resumable.ResumeToken)
+	require.Equal(t, 2, fake.partCount("upload-1"))
+
+	fake.mu.Lock()
+	fake.failures = 0
+	fake.mu.Unlock()
+	before := fake.attemptsFor(1)
+	req.Files[0].File = strings.NewReader(content)
+	_, err = client.UploadConnectorFile(context.Background(), req, WithChunkedUpload(ChunkedUploadOptions{
+		ChunkSize:    16,
+		ResumeTokens: map[string]string{"b.txt": resumable.ResumeToken},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, before, fake.attemptsFor(1), "already uploaded parts must be skipped")
+	require.Equal(t, content, fake.assembled("upload-1"))
+}
+
+func TestUploadConnectorFileChunkedRequiresReaderAt(t *testing.T) {
+	_, client := newFakeChunkServer(t)
+	_, err := client.UploadConnectorFile(context.Background(), &UploadFileRequest{
+		VolumeID: "v1",
+		Files:    []FileUploadItem{{File: io.MultiReader(strings.NewReader("x")), FileName: "c.txt"}},
+	}, WithChunkedUpload(ChunkedUploadOptions{}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "io.ReaderAt")
+}
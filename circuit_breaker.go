@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBreakerFailureRate = 0.5
+	defaultBreakerMinRequests = 10
+	defaultBreakerWindow      = 30 * time.Second
+	defaultBreakerCoolDown    = 30 * time.Second
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets requests through and counts their failures.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fails requests with ErrCircuitOpen without sending them.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets one probe request through after the cool-down;
+	// its outcome closes or reopens the circuit.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreakerConfig configures WithCircuitBreaker. Zero fields use the
+// defaults.
+type CircuitBreakerConfig struct {
+	// FailureRate is the share of failed requests, between 0 and 1, that
+	// opens the circuit. Defaults to 0.5.
+	FailureRate float64
+	// MinRequests is the number of requests in a window below which the
+	// circuit stays closed whatever the failure rate. Defaults to 10.
+	MinRequests int
+	// Window is the period over which failures are counted. Defaults to 30
+	// seconds.
+	Window time.Duration
+	// CoolDown is how long the circuit stays open before a probe request is
+	// let through. Defaults to 30 seconds.
+	CoolDown time.Duration
+	// OnStateChange, if set, is called on every state change. It is called
+	// synchronously and must not send requests through the client.
+	OnStateChange func(from, to CircuitState)
+}
+
+// circuitBreaker tracks the failures of the requests of a client over fixed
+// windows.
+type circuitBreaker struct {
+	cfg CircuitBreakerConfig
+	now func() time.Time
+
+	mu          sync.Mutex
+	state       CircuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probing     bool
+}
+
+func newCircuitBreaker(cfg CircuitBreakerConfig) *circuitBreaker {
+	if cfg.FailureRate <= 0 || cfg.FailureRate > 1 {
+		cfg.FailureRate = defaultBreakerFailureRate
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = defaultBreakerMinRequests
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultBreakerWindow
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = defaultBreakerCoolDown
+	}
+	return &circuitBreaker{cfg: cfg, now: time.Now, state: CircuitClosed}
+}
+
+// allow reports whether a request may be sent, and whether it is the probe
+// of a half-open circuit.
+func (b *circuitBreaker) allow() (ok, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.CoolDown {
+			return false, false
+		}
+		b.setState(CircuitHalfOpen)
+		fallthrough
+	case CircuitHalfOpen:
+		if b.probing {
+			return false, false
+		}
+		b.probing = true
+		return true, true
+	}
+	return true, false
+}
+
+// record counts the outcome of a request let through by allow.
+func (b *circuitBreaker) record(probe, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if probe {
+		b.probing = false
+		if failed {
+			b.openedAt = now
+			b.setState(CircuitOpen)
+		} else {
+			b.reset(now)
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+	if b.state != CircuitClosed {
+		return
+	}
+	if now.Sub(b.windowStart) >= b.cfg.Window {
+		b.reset(now)
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+	if b.requests >= b.cfg.MinRequests && float64(b.failures) >= b.cfg.FailureRate*float64(b.requests) {
+		b.openedAt = now
+		b.setState(CircuitOpen)
+	}
+}
+
+func (b *circuitBreaker) reset(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+// setState must be called with b.mu held.
+func (b *circuitBreaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+	from := b.state
+	b.state = state
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, state)
+	}
+}
+
+// wrap guards next with the breaker. Transport errors and 5xx responses
+// count as failures; requests abandoned by their caller are not counted.
+func (b *circuitBreaker) wrap(next RequestHandler) RequestHandler {
+	return func(req *http.Request) (*http.Response, error) {
+		ok, probe := b.allow()
+		if !ok {
+			return nil, ErrCircuitOpen
+		}
+		resp, err := next(req)
+		if err != nil && req.Context().Err() != nil {
+			if probe {
+				b.mu.Lock()
+				b.probing = false
+				b.mu.Unlock()
+			}
+			return resp, err
+		}
+		b.record(probe, err != nil || resp.StatusCode >= http.StatusInternalServerError)
+		return resp, err
+	}
+}
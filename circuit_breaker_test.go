@@ -0,0 +1,79 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	var down atomic.Bool
+	down.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		writeEnvelope(w, CatalogInfoResponse{CatalogID: 1})
+	}))
+	t.Cleanup(server.Close)
+
+	var transitions []CircuitState
+	client, err := NewRawClient(server.URL, "test-key", WithCircuitBreaker(&CircuitBreakerConfig{
+		MinRequests:   4,
+		CoolDown:      time.Minute,
+		OnStateChange: func(from, to CircuitState) { transitions = append(transitions, to) },
+	}))
+	require.NoError(t, err)
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+	get := func() error {
+		_, err := client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1})
+		return err
+	}
+
+	for range 4 {
+		require.Error(t, get())
+	}
+	err = get()
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.True(t, errors.Is(err, ErrUnavailable))
+	require.Equal(t, int32(4), calls.Load())
+
+	// A failed probe keeps the circuit open.
+	now = now.Add(time.Minute)
+	require.Error(t, get())
+	require.ErrorIs(t, get(), ErrCircuitOpen)
+	require.Equal(t, int32(5), calls.Load())
+
+	// A successful probe closes it.
+	now = now.Add(time.Minute)
+	down.Store(false)
+	require.NoError(t, get())
+	require.NoError(t, get())
+	require.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed}, transitions)
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	t.Parallel()
+	b := newCircuitBreaker(CircuitBreakerConfig{MinRequests: 2})
+	handler := b.wrap(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNotFound}, nil
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for range 5 {
+		_, err := handler(req)
+		require.NoError(t, err)
+	}
+	require.Equal(t, CircuitClosed, b.state)
+}
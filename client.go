@@ -27,9 +27,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 const (
@@ -39,6 +41,9 @@ const (
 	headerContentType = "Content-Type"
 	headerAccept      = "Accept"
 
+	headerAuthorization = "Authorization"
+	bearerPrefix        = "Bearer "
+
 	mimeJSON = "application/json"
 )
 
@@ -50,61 +55,126 @@ type RawClient struct {
 	userAgent       string
 	defaultHeaders  http.Header
 	llmProxyBaseURL string // Optional: direct LLM Proxy base URL for direct connection
+	interceptors    []Interceptor
+	logger          *slog.Logger
+	logRedactor     BodyRedactor
+	namer           Namer
+	credentials     CredentialsProvider // Set for clients created with NewRawClientWithCredentials
+	session         *session            // Set for clients created with NewRawClientWithPassword
+
+	longRequestTimeout time.Duration  // Overall timeout of streams, uploads and downloads (0 means none)
+	flights            *flightGroup   // Set when identical concurrent reads are deduplicated
+	resolver           *resolverCache // Set when path resolutions are cached
+	breaker            *circuitBreaker
+	signer             *requestSigner
 }
 
 // NewRawClient creates a new client using the provided baseURL and apiKey.
 // opts can be used to customize the underlying HTTP client behaviour.
+//
+// apiKey may be empty when requests are signed with WithSigningCredentials.
 func NewRawClient(baseURL, apiKey string, opts ...ClientOption) (*RawClient, error) {
-	trimmedBase := strings.TrimSpace(baseURL)
-	if trimmedBase == "" {
-		return nil, ErrBaseURLRequired
-	}
 	trimmedKey := strings.TrimSpace(apiKey)
-	if trimmedKey == "" {
+	c, cfg, err := newRawClient(baseURL, opts)
+	if trimmedKey == "" && cfg.signing == nil {
+		if strings.TrimSpace(baseURL) == "" {
+			return nil, ErrBaseURLRequired
+		}
 		return nil, ErrAPIKeyRequired
 	}
-
-	parsed, err := url.Parse(trimmedBase)
 	if err != nil {
-		return nil, fmt.Errorf("invalid baseURL: %w", err)
-	}
-	if parsed.Scheme == "" || parsed.Host == "" {
-		return nil, fmt.Errorf("baseURL must include scheme and host")
+		return nil, err
 	}
-	parsed.RawQuery = ""
-	parsed.Fragment = ""
-	normalized := strings.TrimRight(parsed.String(), "/")
+	c.apiKey = trimmedKey
+	return c, nil
+}
 
+// newRawClient validates baseURL and applies opts. The returned client has
+// no credentials yet. The options are returned even if baseURL is invalid.
+func newRawClient(baseURL string, opts []ClientOption) (*RawClient, clientOptions, error) {
 	cfg := clientOptions{
 		userAgent:      defaultUserAgent,
 		defaultHeaders: make(http.Header),
+
+		longRequestTimeout: defaultLongRequestTimeout,
 	}
 	for _, opt := range opts {
 		if opt != nil {
 			opt(&cfg)
 		}
 	}
+
+	trimmedBase := strings.TrimSpace(baseURL)
+	if trimmedBase == "" {
+		return nil, cfg, ErrBaseURLRequired
+	}
+
+	parsed, err := url.Parse(trimmedBase)
+	if err != nil {
+		return nil, cfg, fmt.Errorf("invalid baseURL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, cfg, fmt.Errorf("baseURL must include scheme and host")
+	}
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	normalized := strings.TrimRight(parsed.String(), "/")
+
 	httpClient := cfg.httpClient
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
 	}
+	if cfg.transport != nil {
+		base, ok := httpClient.Transport.(*http.Transport)
+		if !ok && httpClient.Transport != nil {
+			return nil, cfg, fmt.Errorf("transport config requires an *http.Transport, got %T", httpClient.Transport)
+		}
+		transport, err := newTransport(base, cfg.transport)
+		if err != nil {
+			return nil, cfg, err
+		}
+		copied := *httpClient
+		copied.Transport = transport
+		httpClient = &copied
+	}
 	if cfg.defaultHeaders == nil {
 		cfg.defaultHeaders = make(http.Header)
 	}
 
-	return &RawClient{
+	c := &RawClient{
 		baseURL:         normalized,
-		apiKey:          trimmedKey,
 		httpClient:      httpClient,
 		userAgent:       cfg.userAgent,
 		defaultHeaders:  cloneHeader(cfg.defaultHeaders),
 		llmProxyBaseURL: cfg.llmProxyBaseURL,
-	}, nil
+		interceptors:    append([]Interceptor(nil), cfg.interceptors...),
+		logger:          cfg.logger,
+		logRedactor:     cfg.logRedactor,
+		namer:           cfg.namer,
+
+		longRequestTimeout: cfg.longRequestTimeout,
+	}
+	if cfg.deduplicateReads {
+		c.flights = newFlightGroup()
+	}
+	if cfg.resolverCacheTTL > 0 {
+		c.resolver = newResolverCache(cfg.resolverCacheTTL)
+	}
+	if cfg.circuitBreaker != nil {
+		c.breaker = newCircuitBreaker(*cfg.circuitBreaker)
+	}
+	if cfg.signing != nil {
+		c.signer = &requestSigner{creds: *cfg.signing, now: time.Now}
+	}
+	return c, cfg, nil
 }
 
 // WithSpecialUser creates a new RawClient with the same configuration but a different API key.
 // The cloned client shares the same HTTP client instance but has its own API key.
 // Panics if the client is nil or if the API key is empty.
+//
+// To make individual calls on behalf of another user, passing
+// WithAPIKeyOverride to those calls avoids creating a client per user.
 func (c *RawClient) WithSpecialUser(apiKey string) *RawClient {
 	if c == nil {
 		panic("cannot clone nil client")
@@ -114,14 +184,58 @@ func (c *RawClient) WithSpecialUser(apiKey string) *RawClient {
 		panic("API key is required")
 	}
 
-	return &RawClient{
+	clone := &RawClient{
 		baseURL:         c.baseURL,
 		apiKey:          trimmedKey,
 		httpClient:      c.httpClient, // Share the same HTTP client (thread-safe)
 		userAgent:       c.userAgent,
 		defaultHeaders:  cloneHeader(c.defaultHeaders),
 		llmProxyBaseURL: c.llmProxyBaseURL,
+		interceptors:    c.interceptors,
+		logger:          c.logger,
+		logRedactor:     c.logRedactor,
+		namer:           c.namer,
+
+		longRequestTimeout: c.longRequestTimeout,
+		breaker:            c.breaker,
+		signer:             c.signer,
+	}
+	if c.flights != nil {
+		// Responses depend on the API key, so they are not shared with c.
+		clone.flights = newFlightGroup()
+	}
+	if c.resolver != nil {
+		// Another user may not see the same objects.
+		clone.resolver = newResolverCache(c.resolver.ttl)
 	}
+	return clone
+}
+
+// Do calls a JSON API endpoint that has no typed method yet, with the same
+// authentication, headers, interceptors and error handling as the typed
+// methods.
+//
+// reqBody, if not nil, is encoded as the JSON request body. The response
+// must use the standard {code, msg, data} envelope: a non-OK code is
+// returned as an *APIError, a non-2xx status as an *HTTPError, and the data
+// field is decoded into respBody unless it is nil. A reqBody implementing
+// Validator is validated before it is sent.
+//
+// Example:
+//
+//	var resp struct {
+//		Items []string `json:"items"`
+//	}
+//	err := client.Do(ctx, http.MethodPost, "/catalog/some/new_endpoint",
+//		map[string]any{"id": 1}, &resp)
+func (c *RawClient) Do(ctx context.Context, method, path string, reqBody, respBody any, opts ...CallOption) error {
+	if strings.TrimSpace(method) == "" {
+		return invalidArgument("method", "method is required")
+	}
+	if err := validate(reqBody); err != nil {
+		return err
+	}
+	return c.doJSON(ctx, strings.ToUpper(method), path, reqBody, respBody, opts...)
 }
 
 // postJSON issues a JSON request and decodes the enveloped response payload.
@@ -140,26 +254,47 @@ func (c *RawClient) doJSON(ctx context.Context, method, path string, body interf
 	}
 	callOpts := newCallOptions(opts...)
 
-	var reader io.Reader
+	var (
+		payload []byte
+		reader  io.Reader
+	)
 	if body != nil {
-		payload, err := json.Marshal(body)
+		var err error
+		payload, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("marshal request body: %w", err)
 		}
 		reader = bytes.NewReader(payload)
 	}
 
-	resp, err := c.doRaw(ctx, method, path, reader, callOpts, func(req *http.Request) {
+	prepare := func(req *http.Request) {
 		req.Header.Set(headerAccept, mimeJSON)
 		if body != nil {
 			req.Header.Set(headerContentType, mimeJSON)
 		}
-	})
+	}
+	var (
+		resp *http.Response
+		err  error
+	)
+	if c.flights != nil && !callOpts.noDedup && isReadOnlyRequest(method, path) {
+		resp, err = c.doShared(ctx, method, path, payload, callOpts, prepare)
+	} else {
+		resp, err = c.doRaw(ctx, method, path, reader, callOpts, prepare)
+	}
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	if callOpts.decoder != nil {
+		return callOpts.decoder(resp, respBody)
+	}
+	return decodeEnvelope(resp, respBody)
+}
 
+// decodeEnvelope decodes the standard {code, msg, data} envelope from resp
+// and unmarshals the data field into respBody.
+func decodeEnvelope(resp *http.Response, respBody interface{}) error {
 	var envelope apiEnvelope
 	decoder := json.NewDecoder(resp.Body)
 	if err := decoder.Decode(&envelope); err != nil {
@@ -190,6 +325,16 @@ func (c *RawClient) doJSON(ctx context.Context, method, path string, body interf
 }
 
 func (c *RawClient) doRaw(ctx context.Context, method, path string, body io.Reader, opts callOptions, prepare func(*http.Request)) (*http.Response, error) {
+	return c.doRawWithClient(ctx, c.httpClient, method, path, body, opts, prepare)
+}
+
+// doStream is like doRaw but sends the request with the long request timeout
+// instead of the client timeout, for uploads, downloads and streams.
+func (c *RawClient) doStream(ctx context.Context, method, path string, body io.Reader, opts callOptions, prepare func(*http.Request)) (*http.Response, error) {
+	return c.doRawWithClient(ctx, c.streamHTTPClient(), method, path, body, opts, prepare)
+}
+
+func (c *RawClient) doRawWithClient(ctx context.Context, client *http.Client, method, path string, body io.Reader, opts callOptions, prepare func(*http.Request)) (*http.Response, error) {
 	req, err := c.buildRequest(ctx, method, path, body, opts)
 	if err != nil {
 		return nil, err
@@ -198,7 +343,7 @@ func (c *RawClient) doRaw(ctx context.Context, method, path string, body io.Read
 		prepare(req)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(client, req, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -231,16 +376,124 @@ func (c *RawClient) buildRequest(ctx context.Context, method, path string, body
 		return nil, err
 	}
 
-	req.Header.Set(headerAPIKey, c.apiKey)
+	c.applyHeaders(req, opts)
+	return req, nil
+}
+
+// applyHeaders sets the authentication, user agent, default, context
+// metadata and per-call headers shared by every request the client sends.
+func (c *RawClient) applyHeaders(req *http.Request, opts callOptions) {
+	switch {
+	case opts.apiKeyOverride != "":
+		req.Header.Set(headerAPIKey, opts.apiKeyOverride)
+	case c.apiKey != "":
+		req.Header.Set(headerAPIKey, c.apiKey)
+	}
 	if c.userAgent != "" {
 		req.Header.Set(headerUserAgent, c.userAgent)
 	}
 	mergeHeaders(req.Header, c.defaultHeaders, false)
+	mergeHeaders(req.Header, http.Header(metadataFromContext(req.Context())), true)
 	if opts.requestID != "" {
 		req.Header.Set(headerRequestID, opts.requestID)
 	}
 	mergeHeaders(req.Header, opts.headers, true)
-	return req, nil
+}
+
+// send executes req with the given http.Client after passing it through the
+// registered interceptors. Every request issued by the client goes through
+// send, including multipart uploads, streams and LLM proxy calls.
+//
+// send also attaches the credentials that are resolved per request: the API
+// key of a CredentialsProvider, or the session token of a client
+// authenticated with a password, logging in first when there is no valid
+// token.
+func (c *RawClient) send(client *http.Client, req *http.Request, opts callOptions) (*http.Response, error) {
+	if opts.callTimeout > 0 {
+		return c.sendWithTimeout(client, req, opts)
+	}
+	throttleRequest(req, opts)
+	do := client.Do
+	if c.signer != nil {
+		do = c.signer.wrap(do)
+	}
+	final := c.logRequests(do, opts)
+	if c.breaker != nil {
+		final = c.breaker.wrap(final)
+	}
+	handler := chainInterceptors(c.interceptors, final)
+	if opts.apiKeyOverride != "" {
+		return handler(req)
+	}
+	if c.credentials != nil && req.Header.Get(headerAPIKey) == "" {
+		key, err := c.credentials.APIKey(req.Context())
+		if err != nil {
+			return nil, fmt.Errorf("get api key: %w", err)
+		}
+		if key == "" {
+			return nil, ErrAPIKeyRequired
+		}
+		req.Header.Set(headerAPIKey, key)
+	}
+	if c.session == nil || opts.skipSession || req.Header.Get(headerAuthorization) != "" {
+		return handler(req)
+	}
+	token, err := c.session.token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(headerAuthorization, bearerPrefix+token)
+	resp, err := handler(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		c.session.invalidate(token)
+	}
+	return resp, err
+}
+
+// sendWithTimeout sends req under the deadline of WithCallTimeout instead of
+// the timeout of client. The deadline is released when the response body is
+// closed.
+func (c *RawClient) sendWithTimeout(client *http.Client, req *http.Request, opts callOptions) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), opts.callTimeout)
+	unbounded := *client
+	unbounded.Timeout = 0
+	callOpts := opts
+	callOpts.callTimeout = 0
+	resp, err := c.send(&unbounded, req.WithContext(ctx), callOpts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a request context when the response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// streamHTTPClient returns an http.Client for streaming responses, uploads and
+// downloads. It shares the transport of the configured client but uses the
+// long request timeout instead of the client timeout.
+func (c *RawClient) streamHTTPClient() *http.Client {
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: c.httpClient.CheckRedirect,
+		Jar:           c.httpClient.Jar,
+		Timeout:       c.longRequestTimeout,
+	}
 }
 
 func ensureLeadingSlash(p string) string {
@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newSlowServer serves a catalog after delay, and an event stream that
+// sends one event, waits for delay and sends a second one.
+func newSlowServer(t *testing.T, delay time.Duration) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/catalog/info", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		writeEnvelope(w, CatalogInfoResponse{CatalogID: 1})
+	})
+	mux.HandleFunc("/byoa/api/v1/data_asking/analyze", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerContentType, "text/event-stream")
+		fmt.Fprint(w, "data: {\"type\":\"classification\"}\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		fmt.Fprint(w, "data: {\"type\":\"complete\"}\n\n")
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	t.Parallel()
+	url := newSlowServer(t, 200*time.Millisecond)
+	client, err := NewRawClient(url, "test-key", WithHTTPTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// The client timeout applies without the option.
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1})
+	require.Error(t, err)
+
+	// The call timeout replaces it, in both directions.
+	resp, err := client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1}, WithCallTimeout(5*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, CatalogID(1), resp.CatalogID)
+
+	fast, err := NewRawClient(url, "test-key")
+	require.NoError(t, err)
+	_, err = fast.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1}, WithCallTimeout(20*time.Millisecond))
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+}
+
+func TestLongRequestTimeout(t *testing.T) {
+	t.Parallel()
+	url := newSlowServer(t, 200*time.Millisecond)
+	readAll := func(client *RawClient) error {
+		stream, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "q"})
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+		for {
+			if _, err := stream.ReadEvent(); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+
+	// Streams outlive the client timeout.
+	client, err := NewRawClient(url, "test-key", WithHTTPTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+	require.NoError(t, readAll(client))
+
+	// They are bounded by the long request timeout instead.
+	client, err = NewRawClient(url, "test-key", WithLongRequestTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+	require.Error(t, readAll(client))
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/new/endpoint": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPut, r.Method)
+			require.Equal(t, "test-key", r.Header.Get(headerAPIKey))
+			var req map[string]int
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req["id"] == 0 {
+				writeEnvelopeError(w, "ErrNotFound", "no such object")
+				return
+			}
+			writeEnvelope(w, map[string]any{"items": []string{"a", "b"}})
+		},
+	})
+	ctx := context.Background()
+
+	var resp struct {
+		Items []string `json:"items"`
+	}
+	require.NoError(t, client.Do(ctx, "put", "/catalog/new/endpoint", map[string]int{"id": 1}, &resp))
+	require.Equal(t, []string{"a", "b"}, resp.Items)
+
+	err := client.Do(ctx, http.MethodPut, "/catalog/new/endpoint", map[string]int{}, nil)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Error(t, client.Do(ctx, "", "/catalog/new/endpoint", nil, nil))
+}
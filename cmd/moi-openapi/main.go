@@ -0,0 +1,47 @@
+// Command moi-openapi writes the OpenAPI 3 document of the JSON APIs covered
+// by the SDK, generated from its endpoint table and models.
+//
+// Usage:
+//
+//	go run ./cmd/moi-openapi -o openapi.json
+//
+// It is run by go generate from the root of the module.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+	"github.com/matrixorigin/moi-go-sdk/openapi"
+)
+
+func main() {
+	out := flag.String("o", "", "output file (default: standard output)")
+	title := flag.String("title", "MOI API", "title of the document")
+	version := flag.String("version", "1.0.0", "version of the API")
+	flag.Parse()
+
+	doc := openapi.Generate(sdk.Endpoints(), openapi.Info{
+		Title:       *title,
+		Description: "Generated from the MOI Go SDK. Do not edit.",
+		Version:     *version,
+	})
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "moi-openapi:", err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*out, data, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "moi-openapi:", err)
+		os.Exit(1)
+	}
+}
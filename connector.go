@@ -1,7 +1,6 @@
 package sdk
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -17,6 +16,10 @@ import (
 type FileMeta struct {
 	Filename string `json:"filename"`
 	Path     string `json:"path"`
+	// Hash is the hex-encoded MD5 of the content, used by DedupByMD5 and
+	// kept as the file's hash. The SDKClient volume upload helpers compute
+	// it when it is empty.
+	Hash string `json:"hash,omitempty"`
 }
 
 // LocalFileUploadRequest represents a request to upload local files.
@@ -45,9 +48,10 @@ type UploadFileRequest struct {
 	Files []FileUploadItem
 	// Meta is the file metadata array (optional)
 	Meta []FileMeta
-	// FileTypes is the list of allowed file types (optional)
+	// FileTypes is the list of allowed file types (optional); build it with
+	// FileTypeCodes
 	FileTypes []int32
-	// PathRegex is the path regex filter (optional)
+	// PathRegex is the path regex filter (optional); see ValidatePathRegex
 	PathRegex string
 	// UnzipKeepStructure indicates whether to keep directory structure when unzipping (optional)
 	UnzipKeepStructure bool
@@ -55,6 +59,25 @@ type UploadFileRequest struct {
 	DedupConfig *DedupConfig
 	// TableConfig is the table configuration (optional)
 	TableConfig *TableConfig
+	// Sheets limits the import of Excel files to these worksheets (optional)
+	Sheets []SheetSelector
+}
+
+// SheetSelector selects a worksheet of an Excel file, either by name or by
+// its zero-based position. Name takes precedence when both are set.
+type SheetSelector struct {
+	Name  string `json:"name,omitempty"`
+	Index *int   `json:"index,omitempty"`
+}
+
+// SheetByName selects the worksheet with the given name.
+func SheetByName(name string) SheetSelector {
+	return SheetSelector{Name: name}
+}
+
+// SheetByIndex selects the worksheet at the given zero-based position.
+func SheetByIndex(index int) SheetSelector {
+	return SheetSelector{Index: &index}
 }
 
 // ConflictPolicy represents the conflict resolution policy when importing data.
@@ -119,6 +142,9 @@ type TableConfig struct {
 	ExistedTable []FileAndTableColumnMapping `json:"existed_table,omitempty"`
 	// ExistedTableOpts denotes the choice when import data into the existed table
 	ExistedTableOpts ExistedTableOptions `json:"existed_table_opts,omitempty"`
+	// Sheet is the worksheet the table is imported from, for Excel files.
+	// The first worksheet is used when it is nil.
+	Sheet *SheetSelector `json:"sheet,omitempty"`
 }
 
 // CreateTableConfig represents the table creation configuration.
@@ -149,6 +175,11 @@ type UploadFileResponse struct {
 	Success bool                `json:"success"`
 	Results []*FileUploadResult `json:"results"`
 	TaskId  int64               `json:"task_id"`
+	// Batches holds the response of every request when
+	// ImportLocalFilesToVolume split its upload into several; the other
+	// fields then merge them, with FileID and TaskId taken from the first
+	// batch.
+	Batches []*UploadFileResponse `json:"-"`
 }
 
 // FileUploadResult represents a single file upload result.
@@ -176,6 +207,9 @@ type FilePreviewRequest struct {
 	Csv *ConnectorCsvConfig `json:"csv"`
 	// FileType is the file type (0 = auto detect, or specific file type)
 	FileType int32 `json:"file_type,omitempty"`
+	// Sheet is the worksheet to preview, for Excel files (optional, the
+	// first worksheet by default)
+	Sheet *SheetSelector `json:"sheet,omitempty"`
 }
 
 // ConnectorCsvConfig represents CSV parsing configuration for connector file preview.
@@ -196,6 +230,8 @@ type FilePreviewResponse struct {
 	Rows []*PreviewRow `json:"rows"`
 	// FileType is the file type
 	FileType int32 `json:"file_type"`
+	// SheetNames lists the worksheets of an Excel file, in order
+	SheetNames []string `json:"sheet_names,omitempty"`
 }
 
 // PreviewRow represents a single row in file preview.
@@ -237,6 +273,34 @@ type ConnectorFileDeleteResponse struct {
 	Success bool `json:"success"`
 }
 
+// ConnectorFileListRequest represents a request to list the files of a
+// connector.
+type ConnectorFileListRequest struct {
+	ConnectorId uint64 `json:"connector_id"`
+	// PathPrefix limits the listing to entries under this path (optional)
+	PathPrefix string `json:"path_prefix"`
+	// Recursive lists the entries of sub-folders too
+	Recursive bool `json:"recursive"`
+}
+
+// ConnectorFileEntry is a file or folder reported by ListConnectorFiles.
+type ConnectorFileEntry struct {
+	Name string `json:"name"`
+	// Uri identifies the entry; pass it as FilePreviewRequest.Uri
+	Uri   string `json:"uri"`
+	IsDir bool   `json:"is_dir"`
+	// Size is the file size in bytes, 0 for folders
+	Size int64 `json:"size"`
+	// ModifiedAt is the last modification time reported by the source
+	ModifiedAt string `json:"modified_at"`
+}
+
+// ConnectorFileListResponse represents a response from listing connector
+// files.
+type ConnectorFileListResponse struct {
+	Entries []ConnectorFileEntry `json:"entries"`
+}
+
 // UploadLocalFiles uploads local files to connector.
 // files is a map of form field name to file reader and filename.
 // meta is the file metadata array in JSON format.
@@ -265,15 +329,13 @@ type ConnectorFileDeleteResponse struct {
 //	}
 //	fmt.Printf("Uploaded files: %v\n", resp.ConnFileIds)
 func (c *RawClient) UploadLocalFiles(ctx context.Context, files []FileUploadItem, meta []FileMeta, opts ...CallOption) (*LocalFileUploadResponse, error) {
-	if len(files) == 0 {
-		return nil, fmt.Errorf("at least one file is required")
-	}
-	if len(meta) == 0 {
-		return nil, fmt.Errorf("meta is required")
+	if err := (&LocalFileUploadRequest{Files: files, Meta: meta}).Validate(); err != nil {
+		return nil, err
 	}
 
-	// Create multipart form data
-	body := &bytes.Buffer{}
+	// Create multipart form data; file contents are streamed from their
+	// readers when the request is sent
+	body := &multipartBody{}
 	writer := multipart.NewWriter(body)
 
 	// Add meta field
@@ -291,11 +353,11 @@ func (c *RawClient) UploadLocalFiles(ctx context.Context, files []FileUploadItem
 
 	// Add files
 	for _, item := range files {
-		fileField, err := writer.CreateFormFile("file", item.FileName)
-		if err != nil {
+		// The content of a part goes straight to the underlying writer
+		if _, err := writer.CreateFormFile("file", item.FileName); err != nil {
 			return nil, fmt.Errorf("create file field for %s: %w", item.FileName, err)
 		}
-		if _, err := io.Copy(fileField, item.File); err != nil {
+		if err := body.addFile(ctx, item.FileName, item.File); err != nil {
 			return nil, fmt.Errorf("copy file %s: %w", item.FileName, err)
 		}
 	}
@@ -319,25 +381,22 @@ func (c *RawClient) UploadLocalFiles(ctx context.Context, files []FileUploadItem
 		fullURL = fullURL + delimiter + callOpts.query.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, body)
+	// The files are streamed from disk: GetBody lets retries send them again,
+	// but signing must not read them just to hash them.
+	req, err := http.NewRequestWithContext(withUnsignedPayload(ctx), http.MethodPost, fullURL, body.Reader())
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
+	req.ContentLength = body.Len()
+	req.GetBody = func() (io.ReadCloser, error) { return body.Reader(), nil }
 
 	// Set headers
 	req.Header.Set("Content-Type", contentType)
-	req.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		req.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		req.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(req.Header, callOpts.headers, true)
+	c.applyHeaders(req, callOpts)
+	trackUploadProgress(req, callOpts)
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(c.streamHTTPClient(), req, callOpts)
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
@@ -452,18 +511,8 @@ func (c *RawClient) FilePreview(ctx context.Context, req *FilePreviewRequest, op
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-
-	// Validate request parameters
-	if req.ConnectorId > 0 {
-		// Connector file preview: need uri or conn_file_id
-		if strings.TrimSpace(req.Uri) == "" && strings.TrimSpace(req.ConnFileId) == "" {
-			return nil, fmt.Errorf("file preview needs uri or conn_file_id when connector_id is provided")
-		}
-	} else {
-		// Local upload file preview: need conn_file_id
-		if strings.TrimSpace(req.ConnFileId) == "" {
-			return nil, fmt.Errorf("file preview needs conn_file_id for local upload file")
-		}
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
 	// Make request
@@ -483,18 +532,10 @@ func (c *RawClient) FilePreview(ctx context.Context, req *FilePreviewRequest, op
 	// Set headers
 	httpReq.Header.Set(headerContentType, mimeJSON)
 	httpReq.Header.Set(headerAccept, mimeJSON)
-	httpReq.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		httpReq.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(httpReq.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		httpReq.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(httpReq.Header, callOpts.headers, true)
+	c.applyHeaders(httpReq, callOpts)
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send(c.httpClient, httpReq, callOpts)
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
@@ -576,16 +617,18 @@ func (c *RawClient) UploadConnectorFile(ctx context.Context, req *UploadFileRequ
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	if req.VolumeID == "" {
-		return nil, fmt.Errorf("volume_id is required")
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
-	// Allow empty Files if TableConfig.ConnFileIDs is provided (files already uploaded)
-	if len(req.Files) == 0 && (req.TableConfig == nil || len(req.TableConfig.ConnFileIDs) == 0) {
-		return nil, fmt.Errorf("at least one file is required, or TableConfig.ConnFileIDs must be provided")
+
+	callOpts := newCallOptions(opts...)
+	if callOpts.chunkedUpload != nil && len(req.Files) > 0 {
+		return c.uploadConnectorFileChunked(ctx, req, callOpts, opts)
 	}
 
-	// Create multipart form data
-	body := &bytes.Buffer{}
+	// Create multipart form data; file contents are streamed from their
+	// readers when the request is sent
+	body := &multipartBody{}
 	writer := multipart.NewWriter(body)
 
 	// Add VolumeID field (required)
@@ -664,6 +707,21 @@ func (c *RawClient) UploadConnectorFile(ctx context.Context, req *UploadFileRequ
 		}
 	}
 
+	// Add sheets field (optional)
+	if len(req.Sheets) > 0 {
+		sheetsJSON, err := json.Marshal(req.Sheets)
+		if err != nil {
+			return nil, fmt.Errorf("marshal sheets: %w", err)
+		}
+		sheetsField, err := writer.CreateFormField("sheets")
+		if err != nil {
+			return nil, fmt.Errorf("create sheets field: %w", err)
+		}
+		if _, err := sheetsField.Write(sheetsJSON); err != nil {
+			return nil, fmt.Errorf("write sheets field: %w", err)
+		}
+	}
+
 	// Add table_config field (optional)
 	if req.TableConfig != nil {
 		tableConfigJSON, err := json.Marshal(req.TableConfig)
@@ -681,11 +739,11 @@ func (c *RawClient) UploadConnectorFile(ctx context.Context, req *UploadFileRequ
 
 	// Add files (required, unless TableConfig.ConnFileIDs is provided)
 	for _, item := range req.Files {
-		fileField, err := writer.CreateFormFile("file", item.FileName)
-		if err != nil {
+		// The content of a part goes straight to the underlying writer
+		if _, err := writer.CreateFormFile("file", item.FileName); err != nil {
 			return nil, fmt.Errorf("create file field for %s: %w", item.FileName, err)
 		}
-		if _, err := io.Copy(fileField, item.File); err != nil {
+		if err := body.addFile(ctx, item.FileName, item.File); err != nil {
 			return nil, fmt.Errorf("copy file %s: %w", item.FileName, err)
 		}
 	}
@@ -699,7 +757,6 @@ func (c *RawClient) UploadConnectorFile(ctx context.Context, req *UploadFileRequ
 	}
 
 	// Make request
-	callOpts := newCallOptions(opts...)
 	fullURL := c.baseURL + ensureLeadingSlash("/connectors/upload")
 	if len(callOpts.query) > 0 {
 		delimiter := "?"
@@ -709,25 +766,21 @@ func (c *RawClient) UploadConnectorFile(ctx context.Context, req *UploadFileRequ
 		fullURL = fullURL + delimiter + callOpts.query.Encode()
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, body)
+	// As in UploadLocalFiles, GetBody is for retries only.
+	httpReq, err := http.NewRequestWithContext(withUnsignedPayload(ctx), http.MethodPost, fullURL, body.Reader())
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
+	httpReq.ContentLength = body.Len()
+	httpReq.GetBody = func() (io.ReadCloser, error) { return body.Reader(), nil }
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", contentType)
-	httpReq.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		httpReq.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(httpReq.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		httpReq.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(httpReq.Header, callOpts.headers, true)
+	c.applyHeaders(httpReq, callOpts)
+	trackUploadProgress(httpReq, callOpts)
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.send(c.streamHTTPClient(), httpReq, callOpts)
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
@@ -778,8 +831,8 @@ func (c *RawClient) DownloadConnectorFile(ctx context.Context, req *ConnectorFil
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	if strings.TrimSpace(req.ConnFileId) == "" {
-		return nil, fmt.Errorf("conn_file_id is required")
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
 	var resp ConnectorFileDownloadResponse
@@ -800,8 +853,8 @@ func (c *RawClient) DeleteConnectorFile(ctx context.Context, req *ConnectorFileD
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	if strings.TrimSpace(req.ConnFileId) == "" {
-		return nil, fmt.Errorf("conn_file_id is required")
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
 	var resp ConnectorFileDeleteResponse
@@ -810,3 +863,36 @@ func (c *RawClient) DeleteConnectorFile(ctx context.Context, req *ConnectorFileD
 	}
 	return &resp, nil
 }
+
+// ListConnectorFiles lists the files and folders a connector can read.
+//
+// Use it to discover the URIs to pass to FilePreview and import tasks. With
+// recursive set, the entries of all sub-folders under pathPrefix are
+// included; otherwise only the direct children are returned.
+//
+// Example:
+//
+//	resp, err := client.ListConnectorFiles(ctx, 12, "/exports/2024", false)
+//	if err != nil {
+//		return err
+//	}
+//	for _, entry := range resp.Entries {
+//		if !entry.IsDir {
+//			fmt.Printf("%s (%d bytes, modified %s)\n", entry.Uri, entry.Size, entry.ModifiedAt)
+//		}
+//	}
+func (c *RawClient) ListConnectorFiles(ctx context.Context, connectorID uint64, pathPrefix string, recursive bool, opts ...CallOption) (*ConnectorFileListResponse, error) {
+	req := &ConnectorFileListRequest{
+		ConnectorId: connectorID,
+		PathPrefix:  pathPrefix,
+		Recursive:   recursive,
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp ConnectorFileListResponse
+	if err := c.postJSON(ctx, "/connectors/file/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -677,6 +677,33 @@ func TestDeleteConnectorFileValidation(t *testing.T) {
 	require.Contains(t, err.Error(), "conn_file_id is required")
 }
 
+func TestListConnectorFiles(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req ConnectorFileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, uint64(12), req.ConnectorId)
+			require.Equal(t, "/exports", req.PathPrefix)
+			require.True(t, req.Recursive)
+			writeEnvelope(w, ConnectorFileListResponse{Entries: []ConnectorFileEntry{
+				{Name: "2024", Uri: "s3://bucket/exports/2024", IsDir: true},
+				{Name: "a.csv", Uri: "s3://bucket/exports/2024/a.csv", Size: 42, ModifiedAt: "2024-05-01T10:00:00Z"},
+			}})
+		},
+	})
+
+	resp, err := client.ListConnectorFiles(context.Background(), 12, "/exports", true)
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+	require.True(t, resp.Entries[0].IsDir)
+	require.Equal(t, int64(42), resp.Entries[1].Size)
+	require.Equal(t, "s3://bucket/exports/2024/a.csv", resp.Entries[1].Uri)
+
+	_, err = client.ListConnectorFiles(context.Background(), 0, "", false)
+	require.ErrorContains(t, err, "connector_id is required")
+}
+
 func TestDownloadConnectorFileLiveFlow(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewRawClient(testBaseURL, testAPIKey)
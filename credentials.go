@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialsProvider supplies the API key of a client created with
+// NewRawClientWithCredentials.
+//
+// APIKey is called for every request, so a provider can rotate the key at
+// runtime without rebuilding the client. Implementations must be safe for
+// concurrent use and should cache keys that are expensive to obtain.
+type CredentialsProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// CredentialsFunc adapts a function to a CredentialsProvider.
+//
+// Example:
+//
+//	provider := sdk.CredentialsFunc(func(ctx context.Context) (string, error) {
+//		return vault.Read(ctx, "moi/api-key")
+//	})
+type CredentialsFunc func(ctx context.Context) (string, error)
+
+// APIKey calls f(ctx).
+func (f CredentialsFunc) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticCredentials returns a provider that always supplies apiKey.
+func StaticCredentials(apiKey string) CredentialsProvider {
+	key := strings.TrimSpace(apiKey)
+	return CredentialsFunc(func(context.Context) (string, error) {
+		return key, nil
+	})
+}
+
+// EnvCredentials returns a provider that reads the API key from the
+// environment variable name on every request.
+func EnvCredentials(name string) CredentialsProvider {
+	return CredentialsFunc(func(context.Context) (string, error) {
+		key := strings.TrimSpace(os.Getenv(name))
+		if key == "" {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return key, nil
+	})
+}
+
+// FileCredentials returns a provider that reads the API key from the file
+// at path, ignoring surrounding whitespace. The file is read again when its
+// modification time or size changes, so a key rotated by rewriting the file
+// (for example a mounted Kubernetes secret) takes effect on the next
+// request.
+func FileCredentials(path string) CredentialsProvider {
+	return &fileCredentials{path: path}
+}
+
+type fileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	modTime time.Time
+	size    int64
+}
+
+func (f *fileCredentials) APIKey(context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.key != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.key, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("credentials file %s is empty", f.path)
+	}
+	f.key, f.modTime, f.size = key, info.ModTime(), info.Size()
+	return key, nil
+}
+
+// NewRawClientWithCredentials creates a client that obtains its API key from
+// provider for every request.
+//
+// Unlike NewRawClient, the key can change while the client is in use, so a
+// long-lived client picks up rotated keys without being rebuilt. Clients
+// derived with WithSpecialUser use their own static key.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClientWithCredentials(baseURL,
+//		sdk.FileCredentials("/var/run/secrets/moi/api-key"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func NewRawClientWithCredentials(baseURL string, provider CredentialsProvider, opts ...ClientOption) (*RawClient, error) {
+	if provider == nil {
+		if strings.TrimSpace(baseURL) == "" {
+			return nil, ErrBaseURLRequired
+		}
+		return nil, ErrAPIKeyRequired
+	}
+	c, _, err := newRawClient(baseURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.credentials = provider
+	return c, nil
+}
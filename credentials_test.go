@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRawClientWithCredentials_RotatesKey(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get(headerAPIKey))
+		mu.Unlock()
+		writeEnvelope(w, CatalogListResponse{})
+	}))
+	t.Cleanup(server.Close)
+
+	key := "key-1"
+	client, err := NewRawClientWithCredentials(server.URL, CredentialsFunc(func(context.Context) (string, error) {
+		return key, nil
+	}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = client.ListCatalogs(ctx)
+	require.NoError(t, err)
+	key = "key-2"
+	_, err = client.ListCatalogs(ctx)
+	require.NoError(t, err)
+	_, err = client.ListCatalogs(ctx, WithHeader(headerAPIKey, "explicit"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"key-1", "key-2", "explicit"}, seen)
+}
+
+func TestNewRawClientWithCredentials_ProviderError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent")
+	}))
+	t.Cleanup(server.Close)
+
+	boom := errors.New("vault unavailable")
+	client, err := NewRawClientWithCredentials(server.URL, CredentialsFunc(func(context.Context) (string, error) {
+		return "", boom
+	}))
+	require.NoError(t, err)
+	_, err = client.ListCatalogs(context.Background())
+	require.ErrorIs(t, err, boom)
+
+	_, err = NewRawClientWithCredentials(server.URL, nil)
+	require.ErrorIs(t, err, ErrAPIKeyRequired)
+}
+
+func TestFileCredentials(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(t, os.WriteFile(path, []byte("key-1\n"), 0o600))
+	provider := FileCredentials(path)
+
+	key, err := provider.APIKey(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key-1", key)
+
+	require.NoError(t, os.WriteFile(path, []byte("key-22\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	key, err = provider.APIKey(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key-22", key)
+
+	require.NoError(t, os.Remove(path))
+	_, err = provider.APIKey(context.Background())
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("MOI_TEST_API_KEY", " key-env ")
+	key, err := EnvCredentials("MOI_TEST_API_KEY").APIKey(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key-env", key)
+
+	_, err = EnvCredentials("MOI_TEST_API_KEY_UNSET").APIKey(context.Background())
+	require.ErrorContains(t, err, "MOI_TEST_API_KEY_UNSET")
+}
+
+func TestWithAPIKeyOverride(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/login" {
+			writeEnvelope(w, AuthLoginResponse{Token: "token"})
+			return
+		}
+		mu.Lock()
+		seen = append(seen, r.Header.Get(headerAPIKey)+"|"+r.Header.Get(headerAuthorization))
+		mu.Unlock()
+		writeEnvelope(w, CatalogListResponse{})
+	}))
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+
+	static, err := NewRawClient(server.URL, "shared-key")
+	require.NoError(t, err)
+	_, err = static.ListCatalogs(ctx, WithAPIKeyOverride("user-key"))
+	require.NoError(t, err)
+	_, err = static.ListCatalogs(ctx)
+	require.NoError(t, err)
+
+	provided, err := NewRawClientWithCredentials(server.URL, CredentialsFunc(func(context.Context) (string, error) {
+		t.Error("provider should not be called")
+		return "", nil
+	}))
+	require.NoError(t, err)
+	_, err = provided.ListCatalogs(ctx, WithAPIKeyOverride("user-key"))
+	require.NoError(t, err)
+
+	session, err := NewRawClientWithPassword(server.URL, "alice", "secret")
+	require.NoError(t, err)
+	_, err = session.ListCatalogs(ctx, WithAPIKeyOverride("user-key"))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"user-key|", "shared-key|", "user-key|", "user-key|"}, seen)
+}
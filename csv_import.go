@@ -0,0 +1,183 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CSVImportOptions configures ImportCSVToTable.
+type CSVImportOptions struct {
+	// NoHeader indicates that the first row of the file holds data rather
+	// than column names. Columns are then named col_1, col_2, ...
+	NoHeader bool
+	// CSV sets the separator and quoting of CSV files; nil uses the server
+	// defaults. It is ignored for other file types.
+	CSV *ConnectorCsvConfig
+	// Description is the comment of the created table.
+	Description string
+	// PrimaryKey lists the columns that form the primary key of the table.
+	PrimaryKey []string
+	// ColumnTypes overrides the inferred data type of the named columns,
+	// e.g. {"zip": "varchar"}.
+	ColumnTypes map[string]string
+	// Conflict is the policy applied to rows that conflict with existing
+	// ones.
+	Conflict ConflictPolicy
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// ImportCSVToTable imports a local CSV or Parquet file into a new table in
+// one call.
+//
+// The file is uploaded with UploadLocalFileFromPath and previewed with
+// FilePreview. The columns of the table are derived from the preview with
+// InferTableConfig, then an import task that creates the table is submitted.
+// The steps run in a UnitOfWork: if one fails, the uploaded file and, if the
+// failed submission created it, the table are deleted again. An error
+// matching ErrConflict is returned if the database already has a table
+// named tableName.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - filePath: path of the local file (required)
+//   - databaseID: the database to create the table in (required)
+//   - tableName: name of the new table (required)
+//   - opts: optional settings; nil uses the defaults
+//
+// Returns:
+//   - *UploadFileResponse: the response of the import, including its task ID
+//   - error: any error that occurred
+//
+// Example:
+//
+//	resp, err := sdkClient.ImportCSVToTable(ctx, "/data/orders.csv", dbID, "orders", &sdk.CSVImportOptions{
+//		PrimaryKey: []string{"order_id"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	_, status, err := sdkClient.WaitForTask(ctx, sdk.TaskID(resp.TaskId), time.Second)
+func (c *SDKClient) ImportCSVToTable(ctx context.Context, filePath string, databaseID DatabaseID, tableName string, opts *CSVImportOptions) (*UploadFileResponse, error) {
+	if strings.TrimSpace(filePath) == "" {
+		return nil, fmt.Errorf("file_path is required")
+	}
+	if databaseID == 0 {
+		return nil, fmt.Errorf("database_id is required")
+	}
+	if strings.TrimSpace(tableName) == "" {
+		return nil, fmt.Errorf("table_name is required")
+	}
+	if opts == nil {
+		opts = &CSVImportOptions{}
+	}
+
+	var resp *UploadFileResponse
+	err := c.RunUnitOfWork(ctx, func(ctx context.Context, uow *UnitOfWork) error {
+		// The rollback deletes the table by name, so it must not exist yet.
+		if _, exists, err := c.findTable(ctx, databaseID, tableName, opts.CallOptions); err != nil {
+			return fmt.Errorf("look up table %q: %w", tableName, err)
+		} else if exists {
+			return &classifiedError{msg: fmt.Sprintf("sdk: table %q already exists in database %d", tableName, databaseID), class: ErrConflict}
+		}
+
+		uploadResp, err := c.raw.UploadLocalFileFromPath(ctx, filePath, []FileMeta{
+			{Filename: filepath.Base(filePath), Path: "/"},
+		}, opts.CallOptions...)
+		if err != nil {
+			return fmt.Errorf("upload file: %w", err)
+		}
+		if len(uploadResp.ConnFileIds) == 0 {
+			return fmt.Errorf("upload file: no conn_file_id returned")
+		}
+		connFileID := uploadResp.ConnFileIds[0]
+		// The file is of no use without a table; don't leave it behind.
+		uow.OnRollback(fmt.Sprintf("delete uploaded file %q", connFileID), func(ctx context.Context) error {
+			_, err := c.raw.DeleteConnectorFile(ctx, &ConnectorFileDeleteRequest{ConnFileId: connFileID}, opts.CallOptions...)
+			return err
+		})
+
+		tableConfig, err := c.newTableConfig(ctx, newTableImport{
+			connFileID: connFileID,
+			databaseID: databaseID,
+			csv:        opts.CSV,
+			conflict:   opts.Conflict,
+			infer: InferTableOptions{
+				TableName:   tableName,
+				Description: opts.Description,
+				NoHeader:    opts.NoHeader,
+				ColumnTypes: opts.ColumnTypes,
+				PrimaryKey:  opts.PrimaryKey,
+			},
+		}, opts.CallOptions)
+		if err != nil {
+			return err
+		}
+
+		// The import creates the table, which a failed request may still
+		// have done.
+		uow.OnRollback(fmt.Sprintf("delete table %q", tableName), func(ctx context.Context) error {
+			tableID, exists, err := c.findTable(ctx, databaseID, tableName, opts.CallOptions)
+			if err != nil || !exists {
+				return err
+			}
+			_, err = c.raw.DeleteTable(ctx, &TableDeleteRequest{TableID: tableID}, opts.CallOptions...)
+			return err
+		})
+		resp, err = c.importLocalFileToTable(ctx, tableConfig, opts.CallOptions...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// newTableImport describes the import of an uploaded file into a new table.
+type newTableImport struct {
+	connFileID string
+	databaseID DatabaseID
+	sheet      *SheetSelector
+	csv        *ConnectorCsvConfig
+	conflict   ConflictPolicy
+	infer      InferTableOptions
+}
+
+// newTableConfig previews an uploaded file and builds the configuration
+// that creates a table from it.
+func (c *SDKClient) newTableConfig(ctx context.Context, imp newTableImport, opts []CallOption) (*TableConfig, error) {
+	previewReq := &FilePreviewRequest{
+		ConnFileId:    imp.connFileID,
+		IsColumnName:  !imp.infer.NoHeader,
+		ColumnNameRow: 1,
+		RowStart:      2,
+		Csv:           imp.csv,
+		Sheet:         imp.sheet,
+	}
+	if imp.infer.NoHeader {
+		previewReq.ColumnNameRow = 0
+		previewReq.RowStart = 1
+	}
+	preview, err := c.raw.FilePreview(ctx, previewReq, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("preview file: %w", err)
+	}
+	createTable, err := InferTableConfig(preview, &imp.infer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TableConfig{
+		CreateTable:   createTable,
+		IsColumnName:  previewReq.IsColumnName,
+		ColumnNameRow: int(previewReq.ColumnNameRow),
+		RowStart:      int(previewReq.RowStart),
+		Conflict:      imp.conflict,
+		ConnFileIDs:   []string{imp.connFileID},
+		NewTable:      true,
+		DatabaseID:    imp.databaseID,
+		Sheet:         imp.sheet,
+	}, nil
+}
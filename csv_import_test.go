@@ -0,0 +1,157 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCSVToTable(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "orders.csv")
+	require.NoError(t, os.WriteFile(path, []byte("order_id,amount,note\n1,9.5,first\n2,12,\n"), 0o644))
+
+	var tableConfig TableConfig
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{}})
+		},
+		"/connectors/file/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			require.Equal(t, "orders.csv", r.MultipartForm.File["file"][0].Filename)
+			writeEnvelope(w, LocalFileUploadResponse{ConnFileIds: []string{"cf-1"}})
+		},
+		"/connectors/file/preview": func(w http.ResponseWriter, r *http.Request) {
+			var req FilePreviewRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "cf-1", req.ConnFileId)
+			require.True(t, req.IsColumnName)
+			require.Equal(t, int32(2), req.RowStart)
+			writeEnvelope(w, FilePreviewResponse{ConnFileId: "cf-1", Rows: []*PreviewRow{
+				{Number: 1, ColumnName: "order_id", ColumnValues: []string{"1", "2"}},
+				{Number: 2, ColumnName: "amount", ColumnValues: []string{"9.5", "12"}},
+				{Number: 3, ColumnName: "note", ColumnValues: []string{"first", ""}},
+			}})
+		},
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("table_config")), &tableConfig))
+			writeEnvelope(w, UploadFileResponse{TaskId: 42})
+		},
+	})
+
+	resp, err := NewSDKClient(raw).ImportCSVToTable(context.Background(), path, 3, "orders", &CSVImportOptions{
+		PrimaryKey: []string{"order_id"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(42), resp.TaskId)
+
+	require.True(t, tableConfig.NewTable)
+	require.Equal(t, DatabaseID(3), tableConfig.DatabaseID)
+	require.Equal(t, []string{"cf-1"}, tableConfig.ConnFileIDs)
+	require.Equal(t, "orders", tableConfig.CreateTable.Name)
+	cols := tableConfig.CreateTable.TableColumn
+	require.Len(t, cols, 3)
+	require.Equal(t, "order_id", cols[0].ColumnName)
+	require.Equal(t, "int", cols[0].DataType)
+	require.True(t, cols[0].IsKey)
+	require.Equal(t, "double", cols[1].DataType)
+	require.Equal(t, "varchar", cols[2].DataType)
+	require.Equal(t, 3, cols[2].ColNumInFile)
+}
+
+func TestImportCSVToTable_DeletesFileOnFailure(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "orders.csv")
+	require.NoError(t, os.WriteFile(path, []byte("id\n1\n"), 0o644))
+
+	deleted := ""
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{}})
+		},
+		"/connectors/file/upload": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, LocalFileUploadResponse{ConnFileIds: []string{"cf-1"}})
+		},
+		"/connectors/file/preview": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, FilePreviewResponse{Rows: []*PreviewRow{{ColumnName: "id", ColumnValues: []string{"1"}}}})
+		},
+		"/connectors/file/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req ConnectorFileDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			deleted = req.ConnFileId
+			writeEnvelope(w, ConnectorFileDeleteResponse{Success: true})
+		},
+	})
+
+	_, err := NewSDKClient(raw).ImportCSVToTable(context.Background(), path, 3, "orders", &CSVImportOptions{
+		PrimaryKey: []string{"missing"},
+	})
+	require.ErrorContains(t, err, "missing")
+	require.Equal(t, "cf-1", deleted)
+}
+
+func TestImportCSVToTable_RollsBackFailedImport(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "orders.csv")
+	require.NoError(t, os.WriteFile(path, []byte("id\n1\n"), 0o644))
+
+	var calls []string
+	submitted := false
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			list := []DatabaseChildrenResponse{{ID: "7", Name: "customers", Typ: NodeTypeTable}}
+			if submitted {
+				list = append(list, DatabaseChildrenResponse{ID: "8", Name: "orders", Typ: NodeTypeTable})
+			}
+			writeEnvelope(w, DatabaseChildrenResponseData{List: list})
+		},
+		"/connectors/file/upload": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, LocalFileUploadResponse{ConnFileIds: []string{"cf-1"}})
+		},
+		"/connectors/file/preview": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, FilePreviewResponse{Rows: []*PreviewRow{{ColumnName: "id", ColumnValues: []string{"1"}}}})
+		},
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			// The table is created, but the task is not.
+			submitted = true
+			writeEnvelopeError(w, "ErrInternal", "task queue unavailable")
+		},
+		"/catalog/table/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req TableDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			calls = append(calls, fmt.Sprintf("delete table %d", req.TableID))
+			writeEnvelope(w, TableDeleteResponse{})
+		},
+		"/connectors/file/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req ConnectorFileDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			calls = append(calls, "delete file "+req.ConnFileId)
+			writeEnvelope(w, ConnectorFileDeleteResponse{Success: true})
+		},
+	})
+
+	resp, err := NewSDKClient(raw).ImportCSVToTable(context.Background(), path, 3, "orders", nil)
+	require.ErrorContains(t, err, "task queue unavailable")
+	require.Nil(t, resp)
+	require.Equal(t, []string{"delete table 8", "delete file cf-1"}, calls)
+}
+
+func TestImportCSVToTable_ExistingTable(t *testing.T) {
+	t.Parallel()
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{{ID: "8", Name: "orders", Typ: NodeTypeTable}}})
+		},
+	})
+
+	// Nothing is uploaded, and the existing table is left alone.
+	_, err := NewSDKClient(raw).ImportCSVToTable(context.Background(), "orders.csv", 3, "orders", nil)
+	require.ErrorIs(t, err, ErrConflict)
+}
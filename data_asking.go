@@ -1,13 +1,12 @@
 package sdk
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -38,76 +37,6 @@ import (
 //		}
 //		fmt.Printf("Event type: %s\n", event.Type)
 //	}
-//
-// timeoutReader wraps an io.ReadCloser and provides timeout control that resets on each successful read.
-// The timeout is applied to the interval between reads, not the total read time.
-type timeoutReader struct {
-	reader    io.ReadCloser
-	timeout   time.Duration
-	readMutex chan struct{} // Serializes read operations
-}
-
-func newTimeoutReader(reader io.ReadCloser, timeout time.Duration) *timeoutReader {
-	return &timeoutReader{
-		reader:    reader,
-		timeout:   timeout,
-		readMutex: make(chan struct{}, 1),
-	}
-}
-
-func (r *timeoutReader) Read(p []byte) (n int, err error) {
-	// Serialize reads to ensure timeout is properly reset
-	r.readMutex <- struct{}{}
-	defer func() { <-r.readMutex }()
-
-	if r.timeout <= 0 {
-		// No timeout, read directly
-		return r.reader.Read(p)
-	}
-
-	// Create a context with timeout for this read operation
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
-	defer cancel()
-
-	// Use a channel to receive the read result
-	type result struct {
-		n   int
-		err error
-	}
-	resultCh := make(chan result, 1)
-
-	// Perform the read in a goroutine
-	// Note: The read operation itself is thread-safe, as io.ReadCloser implementations
-	// should handle concurrent reads appropriately, or we serialize them via readMutex
-	go func() {
-		// Create a local buffer to avoid potential race conditions
-		// We'll read into a buffer and then copy to p
-		buf := make([]byte, len(p))
-		n, err := r.reader.Read(buf)
-		if n > 0 {
-			copy(p, buf[:n])
-		}
-		resultCh <- result{n: n, err: err}
-	}()
-
-	// Wait for either the read to complete or the timeout
-	select {
-	case res := <-resultCh:
-		// Read completed successfully - timeout is effectively reset for the next read
-		return res.n, res.err
-	case <-ctx.Done():
-		// Timeout - no data received within the timeout period
-		return 0, fmt.Errorf("read timeout: no data received within %v", r.timeout)
-	}
-}
-
-func (r *timeoutReader) Close() error {
-	if r.reader != nil {
-		return r.reader.Close()
-	}
-	return nil
-}
-
 type DataAnalysisStream struct {
 	// Body is the response body that must be closed by the caller
 	Body io.ReadCloser
@@ -115,13 +44,15 @@ type DataAnalysisStream struct {
 	Header http.Header
 	// StatusCode is the HTTP status code
 	StatusCode int
-	reader     *bufio.Reader
 	// initialBufferSize is the initial buffer size for the reader (0 means use default)
 	// The buffer will dynamically grow as needed to handle large lines
 	initialBufferSize int
 	// readTimeout is the timeout between messages in streaming responses
 	// This timeout is reset each time data is successfully read
 	readTimeout time.Duration
+	// sse parses the events of Body; it is created on first read unless
+	// auto-reconnect is enabled
+	sse *SSEStream[*DataAnalysisStreamEvent]
 }
 
 // Close releases the underlying HTTP response body.
@@ -129,6 +60,9 @@ func (s *DataAnalysisStream) Close() error {
 	if s == nil || s.Body == nil {
 		return nil
 	}
+	if s.sse != nil {
+		return s.sse.Close()
+	}
 	return s.Body.Close()
 }
 
@@ -148,118 +82,31 @@ func (s *DataAnalysisStream) Close() error {
 //		}
 //		// Process event
 //	}
-//
-// readLine reads a line from the reader, dynamically growing the buffer as needed.
-// This allows handling lines of arbitrary length without token size limits.
-// The read timeout is reset each time data is successfully read.
-func (s *DataAnalysisStream) readLine() (string, error) {
-	if s.reader == nil {
-		bufferSize := s.initialBufferSize
-		if bufferSize == 0 {
-			bufferSize = 4096 // Default: 4KB initial buffer
-		}
-		// Wrap the body with a timeout reader if timeout is configured
-		body := s.Body
-		if s.readTimeout > 0 {
-			body = newTimeoutReader(s.Body, s.readTimeout)
-		}
-		s.reader = bufio.NewReaderSize(body, bufferSize)
-	}
-
-	var line []byte
-	var isPrefix bool
-	var err error
-
-	// ReadLine may return a partial line if it's too long for the buffer.
-	// We need to keep reading until we get the complete line.
-	// The timeout is automatically reset on each successful read by the timeoutReader.
-	for {
-		var part []byte
-		part, isPrefix, err = s.reader.ReadLine()
-		if err != nil {
-			// Check if error is due to read timeout
-			if strings.Contains(err.Error(), "read timeout") {
-				return "", err
-			}
-			if err == io.EOF && len(line) > 0 {
-				// EOF but we have data, return it
-				return string(line), nil
-			}
-			return "", err
-		}
-
-		// Data successfully read - timeout is automatically reset by timeoutReader
-		line = append(line, part...)
-		if !isPrefix {
-			// Complete line read
-			break
-		}
-		// Line was too long, continue reading
+func (s *DataAnalysisStream) ReadEvent() (*DataAnalysisStreamEvent, error) {
+	if s.sse == nil {
+		s.sse = NewSSEStream(context.Background(), &http.Response{
+			Body:       s.Body,
+			Header:     s.Header,
+			StatusCode: s.StatusCode,
+		}, decodeDataAnalysisEvent, &SSEOptions{
+			InitialBufferSize: s.initialBufferSize,
+			ReadTimeout:       s.readTimeout,
+		})
 	}
-
-	return string(line), nil
+	return s.sse.Next()
 }
 
-func (s *DataAnalysisStream) ReadEvent() (*DataAnalysisStreamEvent, error) {
+// decodeDataAnalysisEvent parses the JSON data of an analysis event. Data
+// that is not valid JSON is returned in RawData only. The SSE event name,
+// when present, takes precedence over the "type" field of the data.
+func decodeDataAnalysisEvent(raw *SSEEvent) (*DataAnalysisStreamEvent, error) {
 	var event DataAnalysisStreamEvent
-	var dataLines []string
-	var eventType string
-
-	for {
-		line, err := s.readLine()
-		if err != nil {
-			if err == io.EOF {
-				// Handle last event if any
-				if len(dataLines) > 0 {
-					dataStr := strings.Join(dataLines, "\n")
-					event.RawData = []byte(dataStr)
-					if err := json.Unmarshal([]byte(dataStr), &event); err != nil {
-						// If JSON parsing fails, return raw data
-						if eventType != "" {
-							event.Type = eventType
-						}
-						return &event, nil
-					}
-					if eventType != "" {
-						event.Type = eventType
-					}
-					return &event, nil
-				}
-				return nil, io.EOF
-			}
-			return nil, fmt.Errorf("read stream: %w", err)
-		}
-
-		if line == "" {
-			// Empty line indicates end of event
-			if len(dataLines) > 0 {
-				// Parse the accumulated data
-				dataStr := strings.Join(dataLines, "\n")
-				event.RawData = []byte(dataStr)
-				if err := json.Unmarshal([]byte(dataStr), &event); err != nil {
-					// If JSON parsing fails, return raw data
-					if eventType != "" {
-						event.Type = eventType
-					}
-					return &event, nil
-				}
-				if eventType != "" {
-					event.Type = eventType
-				}
-				return &event, nil
-			}
-			continue
-		}
-
-		// Parse SSE format: "field: value"
-		if strings.HasPrefix(line, "data: ") {
-			data := strings.TrimPrefix(line, "data: ")
-			dataLines = append(dataLines, data)
-		} else if strings.HasPrefix(line, "event: ") {
-			eventType = strings.TrimPrefix(line, "event: ")
-		}
-		// Ignore other SSE fields (id, retry, etc.)
+	_ = json.Unmarshal(raw.Data, &event)
+	event.RawData = raw.Data
+	if raw.Event != "" {
+		event.Type = raw.Event
 	}
+	return &event, nil
 }
 
 // AnalyzeDataStream performs data analysis and returns a streaming response.
@@ -279,6 +126,10 @@ func (s *DataAnalysisStream) ReadEvent() (*DataAnalysisStreamEvent, error) {
 //   - complete: Analysis complete
 //   - error: Error information
 //
+// With WithStreamAutoReconnect, a connection dropped mid-analysis is
+// reopened with the Last-Event-ID of the last event received, and the
+// stream continues where it left off.
+//
 // Example:
 //
 //	stream, err := client.AnalyzeDataStream(ctx, &sdk.DataAnalysisRequest{
@@ -310,8 +161,8 @@ func (c *RawClient) AnalyzeDataStream(ctx context.Context, req *DataAnalysisRequ
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	if strings.TrimSpace(req.Question) == "" {
-		return nil, fmt.Errorf("question cannot be empty")
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
 	callOpts := newCallOptions(opts...)
@@ -321,78 +172,160 @@ func (c *RawClient) AnalyzeDataStream(ctx context.Context, req *DataAnalysisRequ
 	if err != nil {
 		return nil, fmt.Errorf("marshal request body: %w", err)
 	}
-	reader := bytes.NewReader(payload)
-
-	// Build request
-	path := "/byoa/api/v1/data_asking/analyze"
-	fullURL := c.baseURL + ensureLeadingSlash(path)
-	if len(callOpts.query) > 0 {
-		delimiter := "?"
-		if strings.Contains(fullURL, "?") {
-			delimiter = "&"
-		}
-		fullURL = fullURL + delimiter + callOpts.query.Encode()
-	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, reader)
+	const analyzePath = "/byoa/api/v1/data_asking/analyze"
+	resp, err := c.openEventStream(ctx, http.MethodPost, analyzePath, payload, callOpts, "")
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, err
 	}
 
-	// Set headers
-	httpReq.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		httpReq.Header.Set(headerUserAgent, c.userAgent)
+	stream := &DataAnalysisStream{
+		Body:              resp.Body,
+		Header:            resp.Header.Clone(),
+		StatusCode:        resp.StatusCode,
+		initialBufferSize: callOpts.streamBufferSize,
+		readTimeout:       callOpts.streamReadTimeout,
 	}
-	mergeHeaders(httpReq.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		httpReq.Header.Set(headerRequestID, callOpts.requestID)
+	if callOpts.streamReconnects > 0 {
+		stream.sse = NewSSEStream(ctx, resp, decodeDataAnalysisEvent, callOpts.sseOptions(
+			func(ctx context.Context, lastEventID string) (*http.Response, error) {
+				return c.openEventStream(ctx, http.MethodPost, analyzePath, payload, callOpts, lastEventID)
+			}))
 	}
-	mergeHeaders(httpReq.Header, callOpts.headers, true)
-	httpReq.Header.Set(headerContentType, mimeJSON)
-	httpReq.Header.Set(headerAccept, "text/event-stream")
+	return stream, nil
+}
 
-	// Create a client with no timeout for streaming responses
-	// The stream can still be cancelled via context
-	// This prevents timeout errors while reading long-running streams
-	streamClient := &http.Client{
-		Timeout:   0,                      // No timeout - allows reading long-running streams
-		Transport: c.httpClient.Transport, // Reuse the transport from the original client
+// SearchFilePassages runs a vector similarity search over the files indexed for
+// RAG, the same knowledge AnalyzeDataStream draws on with source "rag".
+//
+// The query is embedded server-side and compared to the indexed passages of
+// the volumes, datasets or files given in the request.
+//
+// Example:
+//
+//	resp, err := client.SearchFilePassages(ctx, &sdk.PassageSearchRequest{
+//		Query:     "refund policy for damaged goods",
+//		TopK:      5,
+//		VolumeIDs: []sdk.VolumeID{"volume-123"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, p := range resp.Passages {
+//		fmt.Printf("%.3f %s: %s\n", p.Score, p.FileName, p.Content)
+//	}
+func (c *RawClient) SearchFilePassages(ctx context.Context, req *PassageSearchRequest, opts ...CallOption) (*PassageSearchResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
 	}
-	if streamClient.Transport == nil {
-		// If original client has no custom transport, use default
-		streamClient.Transport = http.DefaultTransport
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
-
-	// Execute request
-	resp, err := streamClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+	var resp PassageSearchResponse
+	if err := c.postJSON(ctx, "/byoa/api/v1/data_asking/search", req, &resp, opts...); err != nil {
+		return nil, err
 	}
+	return &resp, nil
+}
 
-	// Check for HTTP errors
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		data, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
+// ListAnalysisSessions lists the data analysis sessions of the current user,
+// most recently updated first, so that a UI can restore its history.
+//
+// This method sends a GET request to /byoa/api/v1/data_asking/sessions. Use
+// GetAnalysisResult with the request IDs of a session to load its analyses.
+//
+// Example:
+//
+//	resp, err := client.ListAnalysisSessions(ctx, &sdk.AnalysisSessionListRequest{
+//		PageSize: 50,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, s := range resp.List {
+//		fmt.Printf("%s: %d analyses\n", s.SessionName, len(s.RequestIDs))
+//	}
+func (c *RawClient) ListAnalysisSessions(ctx context.Context, req *AnalysisSessionListRequest, opts ...CallOption) (*AnalysisSessionListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Source != "" {
+		opts = append(opts, WithQueryParam("source", req.Source))
+	}
+	if req.Keyword != "" {
+		opts = append(opts, WithQueryParam("keyword", req.Keyword))
+	}
+	if req.Page > 0 {
+		opts = append(opts, WithQueryParam("page", strconv.Itoa(req.Page)))
+	}
+	if req.PageSize > 0 {
+		opts = append(opts, WithQueryParam("page_size", strconv.Itoa(req.PageSize)))
 	}
+	var resp AnalysisSessionListResponse
+	if err := c.getJSON(ctx, "/byoa/api/v1/data_asking/sessions", &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
 
-	// Check content type
-	contentType := resp.Header.Get("Content-Type")
-	if !strings.Contains(contentType, "text/event-stream") && !strings.Contains(contentType, "text/plain") {
-		// Not a streaming response, try to parse as error
-		data, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		return nil, fmt.Errorf("unexpected content type: %s, body: %s", contentType, string(data))
+// GetAnalysisResult retrieves a previous data analysis by its request ID, the
+// one reported by the init event of AnalyzeDataStream.
+//
+// This method sends a GET request to /byoa/api/v1/data_asking/result. The
+// events are those the analysis streamed, so they can be replayed to
+// restore its display.
+//
+// Example:
+//
+//	result, err := client.GetAnalysisResult(ctx, "request-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%s (%s): %d events\n", result.Question, result.Status, len(result.Events))
+func (c *RawClient) GetAnalysisResult(ctx context.Context, requestID string, opts ...CallOption) (*AnalysisResultResponse, error) {
+	if strings.TrimSpace(requestID) == "" {
+		return nil, invalidArgument("request_id", "request_id cannot be empty")
 	}
+	opts = append(opts, WithQueryParam("request_id", requestID))
+	var resp AnalysisResultResponse
+	if err := c.getJSON(ctx, "/byoa/api/v1/data_asking/result", &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
 
-	return &DataAnalysisStream{
-		Body:              resp.Body,
-		Header:            resp.Header.Clone(),
-		StatusCode:        resp.StatusCode,
-		initialBufferSize: callOpts.streamBufferSize,
-		readTimeout:       callOpts.streamReadTimeout,
-	}, nil
+// SubmitAnalysisFeedback reports whether a data analysis answered its question
+// correctly, optionally with the SQL it should have generated. Feedback feeds
+// the review of NL2SQL knowledge.
+//
+// This method sends a POST request to /byoa/api/v1/data_asking/feedback.
+//
+// Example:
+//
+//	resp, err := client.SubmitAnalysisFeedback(ctx, "request-123", sdk.AnalysisRatingNegative,
+//		"SELECT SUM(amount) FROM orders WHERE status = 'paid'", "refunds must be excluded")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Feedback ID: %s\n", resp.FeedbackID)
+func (c *RawClient) SubmitAnalysisFeedback(ctx context.Context, requestID string, rating AnalysisRating, correctedSQL, comment string, opts ...CallOption) (*AnalysisFeedbackResponse, error) {
+	req := &AnalysisFeedbackRequest{
+		RequestID:    requestID,
+		Rating:       rating,
+		CorrectedSQL: correctedSQL,
+		Comment:      comment,
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp AnalysisFeedbackResponse
+	if err := c.postJSON(ctx, "/byoa/api/v1/data_asking/feedback", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
 // CancelAnalyze cancels an ongoing data analysis request.
@@ -416,8 +349,8 @@ func (c *RawClient) CancelAnalyze(ctx context.Context, req *CancelAnalyzeRequest
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	if strings.TrimSpace(req.RequestID) == "" {
-		return nil, fmt.Errorf("request_id cannot be empty")
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
 	// Add request_id as query parameter
@@ -1347,3 +1347,56 @@ func TestDataAnalysisConfig_RecommendedQuestions_DefaultTimeout(t *testing.T) {
 	require.NoError(t, json.Unmarshal(jsonData, &result))
 	require.Equal(t, 2.0, result["recommended_questions_timeout_seconds"])
 }
+
+func TestListAnalysisSessions(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/sessions": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, "revenue", r.URL.Query().Get("keyword"))
+			require.Equal(t, "2", r.URL.Query().Get("page"))
+			require.Empty(t, r.URL.Query().Get("source"))
+			writeEnvelope(w, AnalysisSessionListResponse{Total: 21, List: []AnalysisSession{
+				{SessionID: "s1", SessionName: "revenue 2024", RequestIDs: []string{"r1", "r2"}},
+			}})
+		},
+	})
+
+	resp, err := client.ListAnalysisSessions(context.Background(), &AnalysisSessionListRequest{Keyword: "revenue", Page: 2})
+	require.NoError(t, err)
+	require.Equal(t, 21, resp.Total)
+	require.Equal(t, []string{"r1", "r2"}, resp.List[0].RequestIDs)
+
+	_, err = client.ListAnalysisSessions(context.Background(), nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+}
+
+func TestGetAnalysisResult(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/result": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "r1", r.URL.Query().Get("request_id"))
+			writeEnvelope(w, map[string]any{
+				"request_id":    "r1",
+				"session_id":    "s1",
+				"question":      "Why did revenue drop?",
+				"status":        "completed",
+				"question_type": map[string]any{"type": "attribution", "confidence": 0.9},
+				"events": []map[string]any{
+					{"step_type": "init"},
+					{"type": "complete"},
+				},
+			})
+		},
+	})
+
+	result, err := client.GetAnalysisResult(context.Background(), "r1")
+	require.NoError(t, err)
+	require.Equal(t, "completed", result.Status)
+	require.Equal(t, "attribution", result.Classification.Type)
+	require.Len(t, result.Events, 2)
+	require.Equal(t, "complete", result.Events[1].Type)
+
+	_, err = client.GetAnalysisResult(context.Background(), " ")
+	require.Error(t, err)
+}
@@ -23,6 +23,13 @@ func (c *RawClient) CreateDatabase(ctx context.Context, req *DatabaseCreateReque
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := withNormalizedName(c, ResourceDatabase, req, func(r *DatabaseCreateRequest) *string { return &r.DatabaseName })
+	if err != nil {
+		return nil, err
+	}
 	var resp DatabaseCreateResponse
 	if err := c.postJSON(ctx, "/catalog/database/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -43,16 +50,23 @@ func (c *RawClient) DeleteDatabase(ctx context.Context, req *DatabaseDeleteReque
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp DatabaseDeleteResponse
 	if err := c.postJSON(ctx, "/catalog/database/delete", req, &resp, opts...); err != nil {
 		return nil, err
 	}
+	c.forgetResolved(ResolvedPath{DatabaseID: req.DatabaseID})
 	return &resp, nil
 }
 
 // UpdateDatabase updates database information.
 //
-// You can update the database comment. The database name cannot be changed.
+// You can update the database comment, and rename the database by setting
+// DatabaseName. The comment is replaced even when empty;
+// SDKClient.RenameDatabase keeps it and checks that the new name is free
+// first.
 //
 // Example:
 //
@@ -64,10 +78,14 @@ func (c *RawClient) UpdateDatabase(ctx context.Context, req *DatabaseUpdateReque
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp DatabaseUpdateResponse
 	if err := c.postJSON(ctx, "/catalog/database/update", req, &resp, opts...); err != nil {
 		return nil, err
 	}
+	c.forgetResolved(ResolvedPath{DatabaseID: req.DatabaseID})
 	return &resp, nil
 }
 
@@ -88,6 +106,9 @@ func (c *RawClient) GetDatabase(ctx context.Context, req *DatabaseInfoRequest, o
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp DatabaseInfoResponse
 	if err := c.postJSON(ctx, "/catalog/database/info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -114,6 +135,9 @@ func (c *RawClient) ListDatabases(ctx context.Context, req *DatabaseListRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp DatabaseListResponse
 	if err := c.postJSON(ctx, "/catalog/database/list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -123,21 +147,27 @@ func (c *RawClient) ListDatabases(ctx context.Context, req *DatabaseListRequest,
 
 // GetDatabaseChildren retrieves the children of the specified database (tables and volumes).
 //
-// Returns both tables and volumes that belong to the database.
+// Returns both tables and volumes that belong to the database, or only
+// those of req.Types. Setting req.PageSize returns one page of children;
+// SDKClient.PageDatabaseChildren iterates over all of them.
 //
 // Example:
 //
 //	resp, err := client.GetDatabaseChildren(ctx, &sdk.DatabaseChildrenRequest{
 //		DatabaseID: 456,
+//		Types:      []string{sdk.NodeTypeVolume},
 //	})
 //	if err != nil {
 //		return err
 //	}
-//	fmt.Printf("Tables: %d, Volumes: %d\n", len(resp.Tables), len(resp.Volumes))
+//	fmt.Printf("Volumes: %d\n", len(resp.List))
 func (c *RawClient) GetDatabaseChildren(ctx context.Context, req *DatabaseChildrenRequest, opts ...CallOption) (*DatabaseChildrenResponseData, error) {
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp DatabaseChildrenResponseData
 	if err := c.postJSON(ctx, "/catalog/database/children", req, &resp, opts...); err != nil {
 		return nil, err
@@ -158,6 +188,9 @@ func (c *RawClient) GetDatabaseRefList(ctx context.Context, req *DatabaseRefList
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp DatabaseRefListResponse
 	if err := c.postJSON(ctx, "/catalog/database/ref_list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -0,0 +1,244 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// CascadeDeleteOptions configures DeleteDatabaseCascade.
+type CascadeDeleteOptions struct {
+	// Force deletes the database even when it, its tables or its volumes
+	// are referenced, for example by a workflow. Without it such a database
+	// is left untouched.
+	Force bool
+	// DryRun reports what would be deleted without deleting anything.
+	DryRun bool
+	// OnProgress, if set, is called after each object is deleted.
+	OnProgress func(CascadeDeleteProgress)
+	// Progress, if set, receives an event as each object is deleted, or
+	// fails to be.
+	Progress ProgressReporter
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// CascadeObject is an object deleted by DeleteDatabaseCascade.
+type CascadeObject struct {
+	// Type is NodeTypeDatabase, NodeTypeTable, NodeTypeVolume,
+	// TrashObjectFolder or TrashObjectFile.
+	Type string
+	ID   string
+	// Name is the name of the object; for files and folders it is the
+	// slash-separated path below the volume root.
+	Name string
+	// VolumeID is the volume of a file or folder.
+	VolumeID VolumeID
+}
+
+// CascadeReference is a reference to an object of the database being
+// deleted.
+type CascadeReference struct {
+	Object  CascadeObject
+	RefType string
+	RefID   string
+}
+
+// CascadeDeleteProgress reports the progress of DeleteDatabaseCascade.
+type CascadeDeleteProgress struct {
+	// Done objects out of Total have been deleted; Object is the last one.
+	Done   int
+	Total  int
+	Object CascadeObject
+}
+
+// CascadeDeleteResult reports a DeleteDatabaseCascade call.
+type CascadeDeleteResult struct {
+	// Planned holds every object of the database in deletion order:
+	// children before their parents and the database last.
+	Planned []CascadeObject
+	// References holds the references found to the planned objects.
+	References []CascadeReference
+	// Removed holds the objects actually deleted, in deletion order. It is
+	// empty in a dry run.
+	Removed []CascadeObject
+}
+
+// DeleteDatabaseCascade deletes a database with everything it contains.
+//
+// The tables and volumes of the database, and the files and folders of the
+// volumes, are listed first, and the references to the database, its
+// tables and its volumes are collected. If any is found the call fails
+// with an error matching ErrConflict unless opts.Force is set. The objects
+// are then deleted bottom-up: the content of each volume, deepest first,
+// then the volume, then the tables and finally the database. Deletion
+// stops at the first error, leaving the remaining objects in place.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - databaseID: the database to delete (required)
+//   - opts: optional settings; nil refuses referenced databases
+//
+// Returns:
+//   - *CascadeDeleteResult: the planned objects, references and removed
+//     objects; returned together with the error when deletion fails
+//   - error: any error that occurred
+//
+// Example:
+//
+//	res, err := sdkClient.DeleteDatabaseCascade(ctx, databaseID, &sdk.CascadeDeleteOptions{
+//		OnProgress: func(p sdk.CascadeDeleteProgress) {
+//			fmt.Printf("%d/%d deleted %s %s\n", p.Done, p.Total, p.Object.Type, p.Object.Name)
+//		},
+//	})
+//	if errors.Is(err, sdk.ErrConflict) {
+//		for _, ref := range res.References {
+//			fmt.Printf("%s %q is used by %s %s\n", ref.Object.Type, ref.Object.Name, ref.RefType, ref.RefID)
+//		}
+//	}
+func (c *SDKClient) DeleteDatabaseCascade(ctx context.Context, databaseID DatabaseID, opts *CascadeDeleteOptions) (*CascadeDeleteResult, error) {
+	if databaseID == 0 {
+		return nil, fmt.Errorf("database_id is required")
+	}
+	var cfg CascadeDeleteOptions
+	if opts != nil {
+		cfg = *opts
+	}
+
+	info, err := c.raw.GetDatabase(ctx, &DatabaseInfoRequest{DatabaseID: databaseID}, cfg.CallOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("get database %d: %w", databaseID, err)
+	}
+	result := &CascadeDeleteResult{}
+	if err := c.planDatabaseCascade(ctx, databaseID, info.DatabaseName, result, cfg.CallOptions); err != nil {
+		return nil, err
+	}
+	if len(result.References) > 0 && !cfg.Force {
+		ref := result.References[0]
+		return result, &classifiedError{
+			msg: fmt.Sprintf("sdk: database %d has %d references, first %s %q is referenced by %s %s",
+				databaseID, len(result.References), ref.Object.Type, ref.Object.Name, ref.RefType, ref.RefID),
+			class: ErrConflict,
+		}
+	}
+	if cfg.DryRun {
+		return result, nil
+	}
+
+	progress := NewProgressTracker(cfg.Progress, OperationCascadeDelete)
+	progress.Start(int64(len(result.Planned)), 0)
+	defer progress.Finish()
+	for _, obj := range result.Planned {
+		item := obj.Type + " " + strconv.Quote(obj.Name)
+		progress.ItemStarted(item, 0)
+		if err := c.deleteCascadeObject(ctx, obj, cfg.CallOptions); err != nil {
+			progress.ItemFailed(item, 0, err)
+			return result, fmt.Errorf("delete %s %q: %w", obj.Type, obj.Name, err)
+		}
+		progress.ItemFinished(item, 0)
+		result.Removed = append(result.Removed, obj)
+		if cfg.OnProgress != nil {
+			cfg.OnProgress(CascadeDeleteProgress{Done: len(result.Removed), Total: len(result.Planned), Object: obj})
+		}
+	}
+	return result, nil
+}
+
+// planDatabaseCascade fills result with the objects of the database in
+// deletion order and with their references.
+func (c *SDKClient) planDatabaseCascade(ctx context.Context, databaseID DatabaseID, name string, result *CascadeDeleteResult, opts []CallOption) error {
+	var tables []CascadeObject
+	pager := c.PageDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID}, opts...)
+	for pager.Next() {
+		child := pager.Child()
+		switch child.Typ {
+		case NodeTypeTable:
+			tables = append(tables, CascadeObject{Type: NodeTypeTable, ID: child.ID, Name: child.Name})
+		case NodeTypeVolume:
+			if err := c.planVolumeCascade(ctx, *child, result, opts); err != nil {
+				return err
+			}
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return fmt.Errorf("list children of database %d: %w", databaseID, err)
+	}
+
+	for _, table := range tables {
+		id, err := strconv.ParseInt(table.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("sdk: invalid table id %q: %w", table.ID, err)
+		}
+		refs, err := c.raw.GetTableRefList(ctx, &TableRefListRequest{TableID: TableID(id)}, opts...)
+		if err != nil {
+			return fmt.Errorf("list references of table %q: %w", table.Name, err)
+		}
+		for _, ref := range refs.List {
+			result.References = append(result.References, CascadeReference{Object: table, RefType: ref.RefType, RefID: ref.RefID})
+		}
+	}
+	result.Planned = append(result.Planned, tables...)
+
+	database := CascadeObject{Type: NodeTypeDatabase, ID: strconv.FormatInt(int64(databaseID), 10), Name: name}
+	refs, err := c.raw.GetDatabaseRefList(ctx, &DatabaseRefListRequest{DatabaseID: databaseID}, opts...)
+	if err != nil {
+		return fmt.Errorf("list references of database %d: %w", databaseID, err)
+	}
+	for _, ref := range refs.List {
+		result.References = append(result.References, CascadeReference{Object: database, RefType: ref.RefType, RefID: ref.RefID})
+	}
+	result.Planned = append(result.Planned, database)
+	return nil
+}
+
+func (c *SDKClient) planVolumeCascade(ctx context.Context, child DatabaseChildrenResponse, result *CascadeDeleteResult, opts []CallOption) error {
+	volumeID := VolumeID(child.ID)
+	volume := CascadeObject{Type: NodeTypeVolume, ID: child.ID, Name: child.Name}
+	refs, err := c.raw.GetVolumeRefList(ctx, &VolumeRefListRequest{VolumeID: volumeID}, opts...)
+	if err != nil {
+		return fmt.Errorf("list references of volume %q: %w", child.Name, err)
+	}
+	for _, ref := range refs.List {
+		result.References = append(result.References, CascadeReference{Object: volume, RefType: ref.RefType, RefID: ref.RefID})
+	}
+
+	var entries []folderTreeEntry
+	if err := c.listFolderTree(ctx, volumeID, "", "", &entries, opts); err != nil {
+		return fmt.Errorf("list volume %q: %w", child.Name, err)
+	}
+	// Folders are listed before their content, so the reverse order
+	// deletes every file and subfolder before its folder.
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		typ := TrashObjectFile
+		if e.folder {
+			typ = TrashObjectFolder
+		}
+		result.Planned = append(result.Planned, CascadeObject{Type: typ, ID: string(e.id), Name: e.path, VolumeID: volumeID})
+	}
+	result.Planned = append(result.Planned, volume)
+	return nil
+}
+
+func (c *SDKClient) deleteCascadeObject(ctx context.Context, obj CascadeObject, opts []CallOption) error {
+	var err error
+	switch obj.Type {
+	case TrashObjectFile:
+		_, err = c.raw.DeleteFile(ctx, &FileDeleteRequest{FileID: FileID(obj.ID)}, opts...)
+	case TrashObjectFolder:
+		_, err = c.raw.DeleteFolder(ctx, &FolderDeleteRequest{FolderID: FileID(obj.ID)}, opts...)
+	case NodeTypeVolume:
+		_, err = c.raw.DeleteVolume(ctx, &VolumeDeleteRequest{VolumeID: VolumeID(obj.ID)}, opts...)
+	case NodeTypeTable:
+		var id int64
+		if id, err = strconv.ParseInt(obj.ID, 10, 64); err == nil {
+			_, err = c.raw.DeleteTable(ctx, &TableDeleteRequest{TableID: TableID(id)}, opts...)
+		}
+	case NodeTypeDatabase:
+		var id int64
+		if id, err = strconv.ParseInt(obj.ID, 10, 64); err == nil {
+			_, err = c.raw.DeleteDatabase(ctx, &DatabaseDeleteRequest{DatabaseID: DatabaseID(id)}, opts...)
+		}
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cascadeRoutes serves database 7 with a table and a volume holding a file
+// and a folder with one file. The table is referenced by a workflow.
+func cascadeRoutes(t *testing.T, deleted *[]string) map[string]http.HandlerFunc {
+	record := func(kind string, id any) {
+		b, _ := json.Marshal(id)
+		*deleted = append(*deleted, kind+":"+string(b))
+	}
+	return map[string]http.HandlerFunc{
+		"/catalog/database/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseInfoResponse{DatabaseID: 7, DatabaseName: "sales"})
+		},
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+				{ID: "10", Name: "orders", Typ: NodeTypeTable},
+				{ID: "vol-1", Name: "docs", Typ: NodeTypeVolume},
+			}})
+		},
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			switch req.Filters[1].Values[0] {
+			case "":
+				writeEnvelope(w, FileListResponse{Total: 2, List: []VolumeChildrenResponse{
+					{ID: "f1", Name: "2024", FileType: "folder"},
+					{ID: "a", Name: "readme.md"},
+				}})
+			case "f1":
+				writeEnvelope(w, FileListResponse{Total: 1, List: []VolumeChildrenResponse{{ID: "b", Name: "q1.pdf"}}})
+			}
+		},
+		"/catalog/table/ref_list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TableRefListResponse{List: []*TableRefResp{{TableID: 10, RefType: "workflow", RefID: "wf-1"}}})
+		},
+		"/catalog/volume/ref_list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, VolumeRefListResponse{})
+		},
+		"/catalog/database/ref_list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseRefListResponse{})
+		},
+		"/catalog/file/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req FileDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			record("file", req.FileID)
+			writeEnvelope(w, FileDeleteResponse{FileID: req.FileID})
+		},
+		"/catalog/folder/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req FolderDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			record("folder", req.FolderID)
+			writeEnvelope(w, FolderDeleteResponse{})
+		},
+		"/catalog/volume/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req VolumeDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			record("volume", req.VolumeID)
+			writeEnvelope(w, VolumeDeleteResponse{VolumeID: req.VolumeID})
+		},
+		"/catalog/table/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req TableDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			record("table", req.TableID)
+			writeEnvelope(w, TableDeleteResponse{})
+		},
+		"/catalog/database/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req DatabaseDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			record("database", req.DatabaseID)
+			writeEnvelope(w, DatabaseDeleteResponse{DatabaseID: req.DatabaseID})
+		},
+	}
+}
+
+func TestDeleteDatabaseCascade(t *testing.T) {
+	t.Parallel()
+	var deleted []string
+	client := NewSDKClient(newMockClient(t, cascadeRoutes(t, &deleted)))
+	ctx := context.Background()
+
+	res, err := client.DeleteDatabaseCascade(ctx, 7, nil)
+	require.ErrorIs(t, err, ErrConflict)
+	require.Equal(t, []CascadeReference{{
+		Object:  CascadeObject{Type: NodeTypeTable, ID: "10", Name: "orders"},
+		RefType: "workflow", RefID: "wf-1",
+	}}, res.References)
+	require.Empty(t, deleted)
+
+	res, err = client.DeleteDatabaseCascade(ctx, 7, &CascadeDeleteOptions{Force: true, DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, res.Planned, 6)
+	require.Empty(t, res.Removed)
+	require.Empty(t, deleted)
+
+	var (
+		progress []CascadeDeleteProgress
+		events   []ProgressEvent
+	)
+	res, err = client.DeleteDatabaseCascade(ctx, 7, &CascadeDeleteOptions{
+		Force:      true,
+		OnProgress: func(p CascadeDeleteProgress) { progress = append(progress, p) },
+		Progress:   ProgressReporterFunc(func(e ProgressEvent) { events = append(events, e) }),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`file:"a"`, `file:"b"`, `folder:"f1"`, `volume:"vol-1"`, `table:10`, `database:7`,
+	}, deleted)
+	require.Equal(t, res.Planned, res.Removed)
+	require.Equal(t, "2024/q1.pdf", res.Removed[1].Name)
+	require.Len(t, progress, 6)
+	require.Equal(t, CascadeDeleteProgress{Done: 6, Total: 6, Object: res.Removed[5]}, progress[5])
+
+	require.Len(t, events, 14)
+	require.Equal(t, ProgressStarted, events[0].Type)
+	require.Equal(t, ProgressItemStarted, events[1].Type)
+	require.Equal(t, ProgressItemFinished, events[12].Type)
+	require.Equal(t, `database "sales"`, events[12].Item)
+	require.Equal(t, OperationCascadeDelete, events[12].Operation)
+	require.Equal(t, ProgressFinished, events[13].Type)
+	require.Equal(t, int64(6), events[13].ItemsDone)
+	require.Equal(t, int64(6), events[13].ItemsTotal)
+}
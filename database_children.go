@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"context"
+	"slices"
+)
+
+// defaultDatabaseChildrenPageSize is the number of children fetched per
+// request when the request gives no page size.
+const defaultDatabaseChildrenPageSize = 100
+
+// DatabaseChildrenPager iterates over the tables and volumes of a database
+// page by page. Create one with PageDatabaseChildren.
+type DatabaseChildrenPager struct {
+	client *SDKClient
+	ctx    context.Context
+	req    DatabaseChildrenRequest
+	opts   []CallOption
+
+	batch   []DatabaseChildrenResponse
+	pos     int
+	firstID string
+	done    bool
+	err     error
+}
+
+// PageDatabaseChildren opens a pager over the children of a database.
+//
+// req.Types is sent to the server and also checked on the returned
+// children, so that servers without type filtering give the same result.
+// req.Page is the first page read, and req.PageSize the number of children
+// per request, 100 if unset. No request is made until the first call to
+// Next.
+//
+// Example:
+//
+//	pager := sdkClient.PageDatabaseChildren(ctx, &sdk.DatabaseChildrenRequest{
+//		DatabaseID: dbID,
+//		Types:      []string{sdk.NodeTypeTable},
+//	})
+//	for pager.Next() {
+//		fmt.Println(pager.Child().Name)
+//	}
+//	if err := pager.Err(); err != nil {
+//		return err
+//	}
+func (c *SDKClient) PageDatabaseChildren(ctx context.Context, req *DatabaseChildrenRequest, opts ...CallOption) *DatabaseChildrenPager {
+	var r DatabaseChildrenRequest
+	if req != nil {
+		r = *req
+	}
+	if r.Page <= 0 {
+		r.Page = 1
+	}
+	if r.PageSize <= 0 {
+		r.PageSize = defaultDatabaseChildrenPageSize
+	}
+	// The first fetch moves to r.Page.
+	r.Page--
+	return &DatabaseChildrenPager{client: c, ctx: ctx, req: r, opts: opts}
+}
+
+// Next advances the pager to the next child, fetching the next page when
+// the current one is exhausted. It returns false when there are no more
+// children or an error occurred; check Err to tell them apart.
+func (p *DatabaseChildrenPager) Next() bool {
+	for p.err == nil {
+		p.pos++
+		for p.pos < len(p.batch) {
+			if len(p.req.Types) == 0 || slices.Contains(p.req.Types, p.batch[p.pos].Typ) {
+				return true
+			}
+			p.pos++
+		}
+		if p.done {
+			return false
+		}
+		if err := p.fetch(); err != nil {
+			p.err = err
+			return false
+		}
+		p.pos = -1
+	}
+	return false
+}
+
+func (p *DatabaseChildrenPager) fetch() error {
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	p.req.Page++
+	resp, err := p.client.raw.GetDatabaseChildren(p.ctx, &p.req, p.opts...)
+	if err != nil {
+		return err
+	}
+	p.batch = resp.List
+	// A server without pagination returns every child on each page: stop
+	// after the first page when it is larger than requested, and drop a
+	// page that starts over.
+	if len(p.batch) > 0 {
+		if p.firstID == "" {
+			p.firstID = p.batch[0].Typ + "/" + p.batch[0].ID
+		} else if p.firstID == p.batch[0].Typ+"/"+p.batch[0].ID {
+			p.batch = nil
+		}
+	}
+	if len(p.batch) != p.req.PageSize || (resp.Total > 0 && p.req.Page*p.req.PageSize >= resp.Total) {
+		p.done = true
+	}
+	return nil
+}
+
+// Child returns the current child. It is valid only after Next returned
+// true.
+func (p *DatabaseChildrenPager) Child() *DatabaseChildrenResponse {
+	if p.pos < 0 || p.pos >= len(p.batch) {
+		return nil
+	}
+	return &p.batch[p.pos]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *DatabaseChildrenPager) Err() error {
+	return p.err
+}
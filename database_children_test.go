@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageDatabaseChildren(t *testing.T) {
+	t.Parallel()
+	var all []DatabaseChildrenResponse
+	for i := 1; i <= 5; i++ {
+		all = append(all, DatabaseChildrenResponse{ID: strconv.Itoa(i), Name: "t" + strconv.Itoa(i), Typ: NodeTypeTable})
+	}
+	var pages []int
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			var req DatabaseChildrenRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, []string{NodeTypeTable}, req.Types)
+			pages = append(pages, req.Page)
+			start := (req.Page - 1) * req.PageSize
+			end := min(start+req.PageSize, len(all))
+			writeEnvelope(w, DatabaseChildrenResponseData{List: all[start:end], Total: len(all)})
+		},
+	}))
+
+	pager := client.PageDatabaseChildren(context.Background(), &DatabaseChildrenRequest{
+		DatabaseID: 1, Types: []string{NodeTypeTable}, PageSize: 2,
+	})
+	var names []string
+	for pager.Next() {
+		names = append(names, pager.Child().Name)
+	}
+	require.NoError(t, pager.Err())
+	require.Equal(t, []string{"t1", "t2", "t3", "t4", "t5"}, names)
+	require.Equal(t, []int{1, 2, 3}, pages)
+}
+
+func TestPageDatabaseChildrenUnpaginatedServer(t *testing.T) {
+	t.Parallel()
+	requests := 0
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			// The server ignores the types and the page.
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+				{ID: "1", Name: "orders", Typ: NodeTypeTable},
+				{ID: "vol-1", Name: "docs", Typ: NodeTypeVolume},
+			}})
+		},
+	}))
+
+	pager := client.PageDatabaseChildren(context.Background(), &DatabaseChildrenRequest{
+		DatabaseID: 1, Types: []string{NodeTypeVolume}, PageSize: 2,
+	})
+	var names []string
+	for pager.Next() {
+		names = append(names, pager.Child().Name)
+	}
+	require.NoError(t, pager.Err())
+	require.Equal(t, []string{"docs"}, names)
+	require.Equal(t, 2, requests)
+}
@@ -0,0 +1,110 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// DatabasePrivInfo represents database or catalog privilege information for
+// role creation. Exactly one of DatabaseID and CatalogID must be set.
+type DatabasePrivInfo struct {
+	// DatabaseID is the database the privileges apply to
+	DatabaseID DatabaseID
+	// CatalogID is the catalog the privileges apply to
+	CatalogID CatalogID
+	// PrivCodes are the privilege codes on the object, for example
+	// PrivCode_UpdateDatabase (DB3) for a database or PrivCode_QueryDatabase
+	// (DB2) for a catalog. They must be valid for the object type according
+	// to ObjTypeToPrivIDMap.
+	PrivCodes []PrivCode
+}
+
+// CreateDatabaseRole creates a role for database and catalog privileges, or
+// returns the existing role if it already exists.
+//
+// It behaves like CreateTableRole: if a role named roleName exists, its ID
+// is returned with created=false and the role is left unchanged.
+//
+// Example:
+//
+//	roleID, created, err := sdkClient.CreateDatabaseRole(ctx, "db-admin", "Manages sales", []sdk.DatabasePrivInfo{
+//		{DatabaseID: 123, PrivCodes: []sdk.PrivCode{sdk.PrivCode_UpdateDatabase, sdk.PrivCode_CreateTable}},
+//		{CatalogID: 1, PrivCodes: []sdk.PrivCode{sdk.PrivCode_QueryDatabase}},
+//	})
+//
+// Returns:
+//   - roleID: the ID of the role (existing or newly created)
+//   - created: true if the role was newly created, false if it already existed
+//   - error: any error that occurred
+func (c *SDKClient) CreateDatabaseRole(ctx context.Context, roleName string, comment string, dbPrivs []DatabasePrivInfo) (roleID RoleID, created bool, err error) {
+	if roleName == "" {
+		return 0, false, fmt.Errorf("role name is required")
+	}
+	objPrivList, err := databasePrivsToObjPrivs(dbPrivs)
+	if err != nil {
+		return 0, false, err
+	}
+	return c.findOrCreateRole(ctx, roleName, comment, objPrivList)
+}
+
+// UpdateDatabaseRole replaces the object-level privileges of a role with
+// database and catalog privileges.
+//
+// The comment and globalPrivs parameters have the semantics of
+// UpdateTableRole: an empty comment and nil globalPrivs keep the current
+// values, and an empty globalPrivs removes all global privileges.
+//
+// Example:
+//
+//	err := sdkClient.UpdateDatabaseRole(ctx, roleID, "", []sdk.DatabasePrivInfo{
+//		{DatabaseID: 123, PrivCodes: []sdk.PrivCode{sdk.PrivCode_ShowTables}},
+//	}, nil)
+func (c *SDKClient) UpdateDatabaseRole(ctx context.Context, roleID RoleID, comment string, dbPrivs []DatabasePrivInfo, globalPrivs []string) error {
+	if roleID == 0 {
+		return fmt.Errorf("role_id is required")
+	}
+	objPrivList, err := databasePrivsToObjPrivs(dbPrivs)
+	if err != nil {
+		return err
+	}
+	return c.updateObjRole(ctx, roleID, comment, objPrivList, globalPrivs)
+}
+
+// databasePrivsToObjPrivs converts database privilege info to object
+// privileges, checking each code against the object type.
+func databasePrivsToObjPrivs(dbPrivs []DatabasePrivInfo) ([]ObjPrivResponse, error) {
+	objPrivList := make([]ObjPrivResponse, 0, len(dbPrivs))
+	for _, dbPriv := range dbPrivs {
+		var objType ObjType
+		var objID int64
+		switch {
+		case dbPriv.DatabaseID != 0 && dbPriv.CatalogID != 0:
+			return nil, fmt.Errorf("database %d: set either database_id or catalog_id", dbPriv.DatabaseID)
+		case dbPriv.DatabaseID != 0:
+			objType, objID = ObjTypeDatabase, int64(dbPriv.DatabaseID)
+		case dbPriv.CatalogID != 0:
+			objType, objID = ObjTypeCatalog, int64(dbPriv.CatalogID)
+		default:
+			return nil, fmt.Errorf("database_id or catalog_id is required")
+		}
+		if len(dbPriv.PrivCodes) == 0 {
+			continue
+		}
+
+		authorityCodeList := make([]*AuthorityCodeAndRule, 0, len(dbPriv.PrivCodes))
+		for _, privCode := range dbPriv.PrivCodes {
+			privID, ok := PrivCodeToPrivIDMap[privCode]
+			if !ok || !slices.Contains(ObjTypeToPrivIDMap[objType], privID) {
+				return nil, fmt.Errorf("privilege %s does not apply to %s %d", privCode, objType, objID)
+			}
+			authorityCodeList = append(authorityCodeList, &AuthorityCodeAndRule{Code: privCode.String()})
+		}
+		objPrivList = append(objPrivList, ObjPrivResponse{
+			ObjID:             IntToPrivObjectID(objID).String(),
+			ObjType:           objType.String(),
+			AuthorityCodeList: authorityCodeList,
+		})
+	}
+	return objPrivList, nil
+}
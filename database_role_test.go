@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDatabaseRole(t *testing.T) {
+	t.Parallel()
+	var created RoleCreateRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/role/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, RoleListResponse{Total: 1, List: []RoleInfoResponse{{RoleID: 5, RoleName: "existing"}}})
+		},
+		"/role/create": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			writeEnvelope(w, RoleCreateResponse{RoleID: 9})
+		},
+	}))
+	ctx := context.Background()
+
+	roleID, isNew, err := client.CreateDatabaseRole(ctx, "db-admin", "manages sales", []DatabasePrivInfo{
+		{DatabaseID: 123, PrivCodes: []PrivCode{PrivCode_UpdateDatabase, PrivCode_CreateTable}},
+		{CatalogID: 1, PrivCodes: []PrivCode{PrivCode_QueryDatabase}},
+	})
+	require.NoError(t, err)
+	require.True(t, isNew)
+	require.Equal(t, RoleID(9), roleID)
+	require.Equal(t, "db-admin", created.RoleName)
+	require.Len(t, created.ObjPrivList, 2)
+	require.Equal(t, "database", created.ObjPrivList[0].ObjType)
+	require.Equal(t, "123", created.ObjPrivList[0].ObjID)
+	require.Equal(t, "DB3", created.ObjPrivList[0].AuthorityCodeList[0].Code)
+	require.Equal(t, "catalog", created.ObjPrivList[1].ObjType)
+
+	roleID, isNew, err = client.CreateDatabaseRole(ctx, "existing", "", nil)
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.Equal(t, RoleID(5), roleID)
+}
+
+func TestCreateDatabaseRole_InvalidPrivileges(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(&RawClient{})
+	ctx := context.Background()
+
+	_, _, err := client.CreateDatabaseRole(ctx, "r", "", []DatabasePrivInfo{
+		{DatabaseID: 123, PrivCodes: []PrivCode{PrivCode_TableSelect}},
+	})
+	require.ErrorContains(t, err, "does not apply to database 123")
+
+	_, _, err = client.CreateDatabaseRole(ctx, "r", "", []DatabasePrivInfo{
+		{DatabaseID: 1, CatalogID: 1, PrivCodes: []PrivCode{PrivCode_UpdateDatabase}},
+	})
+	require.ErrorContains(t, err, "either")
+
+	_, _, err = client.CreateDatabaseRole(ctx, "", "", nil)
+	require.ErrorContains(t, err, "role name is required")
+}
+
+func TestUpdateDatabaseRole(t *testing.T) {
+	t.Parallel()
+	var updated RoleUpdateInfoRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/role/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, RoleInfoResponse{RoleID: 5, Comment: "kept", AuthorityList: []*PrivResponse{{PrivCode: "U2"}}})
+		},
+		"/role/update_info": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+			writeEnvelope(w, RoleUpdateInfoResponse{RoleID: 5})
+		},
+	}))
+
+	err := client.UpdateDatabaseRole(context.Background(), 5, "", []DatabasePrivInfo{
+		{DatabaseID: 123, PrivCodes: []PrivCode{PrivCode_ShowTables}},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "kept", updated.Comment)
+	require.Equal(t, []string{"U2"}, updated.PrivList)
+	require.Len(t, updated.ObjPrivList, 1)
+	require.Equal(t, PrivCode_ShowTables.String(), updated.ObjPrivList[0].AuthorityCodeList[0].Code)
+}
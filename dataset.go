@@ -0,0 +1,164 @@
+package sdk
+
+import (
+	"context"
+)
+
+// CreateDataset creates a new dataset.
+//
+// A dataset groups tables and volumes so that they can be shared and
+// authorized together; see DatasetObjPriv.
+//
+// Example:
+//
+//	resp, err := client.CreateDataset(ctx, &sdk.DatasetCreateRequest{
+//		Name:        "sales",
+//		Description: "Sales tables and reports",
+//		TableIDs:    []sdk.TableID{101, 102},
+//		VolumeIDs:   []sdk.VolumeID{"volume-id-123"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Created dataset ID: %d\n", resp.DatasetID)
+func (c *RawClient) CreateDataset(ctx context.Context, req *DatasetCreateRequest, opts ...CallOption) (*DatasetCreateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp DatasetCreateResponse
+	if err := c.postJSON(ctx, "/catalog/dataset/create", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteDataset deletes the specified dataset.
+//
+// The tables and volumes of the dataset are not deleted.
+//
+// Example:
+//
+//	resp, err := client.DeleteDataset(ctx, &sdk.DatasetDeleteRequest{
+//		DatasetID: 7,
+//	})
+func (c *RawClient) DeleteDataset(ctx context.Context, req *DatasetDeleteRequest, opts ...CallOption) (*DatasetDeleteResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp DatasetDeleteResponse
+	if err := c.postJSON(ctx, "/catalog/dataset/delete", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateDataset updates dataset information.
+//
+// The name and description are replaced. Non-nil TableIDs and VolumeIDs
+// replace the members of the dataset.
+//
+// Example:
+//
+//	resp, err := client.UpdateDataset(ctx, &sdk.DatasetUpdateRequest{
+//		DatasetID:   7,
+//		Name:        "sales",
+//		Description: "Sales tables",
+//		TableIDs:    []sdk.TableID{101, 102, 103},
+//	})
+func (c *RawClient) UpdateDataset(ctx context.Context, req *DatasetUpdateRequest, opts ...CallOption) (*DatasetUpdateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp DatasetUpdateResponse
+	if err := c.postJSON(ctx, "/catalog/dataset/update", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDataset retrieves detailed information about the specified dataset.
+//
+// The response includes the dataset name, description and members.
+//
+// Example:
+//
+//	resp, err := client.GetDataset(ctx, &sdk.DatasetInfoRequest{
+//		DatasetID: 7,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Dataset: %s (%d tables)\n", resp.Name, len(resp.TableIDs))
+func (c *RawClient) GetDataset(ctx context.Context, req *DatasetInfoRequest, opts ...CallOption) (*DatasetInfoResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp DatasetInfoResponse
+	if err := c.postJSON(ctx, "/catalog/dataset/info", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListDatasets lists the datasets visible to the caller.
+//
+// Example:
+//
+//	resp, err := client.ListDatasets(ctx, &sdk.DatasetListRequest{
+//		CommonCondition: sdk.CommonCondition{Page: 1, PageSize: 20},
+//		Keyword:         "sales",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, ds := range resp.List {
+//		fmt.Printf("%d: %s\n", ds.DatasetID, ds.Name)
+//	}
+func (c *RawClient) ListDatasets(ctx context.Context, req *DatasetListRequest, opts ...CallOption) (*DatasetListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp DatasetListResponse
+	if err := c.postJSON(ctx, "/catalog/dataset/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DatasetObjPriv returns the object privileges on a dataset, for use in
+// RoleCreateRequest.ObjPrivList and RoleUpdateInfoRequest.ObjPrivList.
+//
+// Example:
+//
+//	_, err := client.CreateRole(ctx, &sdk.RoleCreateRequest{
+//		RoleName: "sales-reader",
+//		ObjPrivList: []sdk.ObjPrivResponse{
+//			sdk.DatasetObjPriv(7, sdk.PrivCode_GetDataSet),
+//		},
+//	})
+func DatasetObjPriv(datasetID DatasetID, codes ...PrivCode) ObjPrivResponse {
+	authorityCodes := make([]*AuthorityCodeAndRule, 0, len(codes))
+	for _, code := range codes {
+		authorityCodes = append(authorityCodes, &AuthorityCodeAndRule{Code: code.String()})
+	}
+	return ObjPrivResponse{
+		ObjID:             IntToPrivObjectID(int64(datasetID)).String(),
+		ObjType:           ObjTypeDataSet.String(),
+		AuthorityCodeList: authorityCodes,
+	}
+}
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatasetCRUD(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/dataset/create": func(w http.ResponseWriter, r *http.Request) {
+			var req DatasetCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "sales", req.Name)
+			require.Equal(t, []TableID{101, 102}, req.TableIDs)
+			require.Equal(t, []VolumeID{"v-1"}, req.VolumeIDs)
+			writeEnvelope(w, DatasetCreateResponse{DatasetID: 7})
+		},
+		"/catalog/dataset/info": func(w http.ResponseWriter, r *http.Request) {
+			var req DatasetInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, DatasetID(7), req.DatasetID)
+			writeEnvelope(w, DatasetInfoResponse{DatasetID: 7, Name: "sales", TableIDs: []TableID{101, 102}})
+		},
+		"/catalog/dataset/update": func(w http.ResponseWriter, r *http.Request) {
+			var req DatasetUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "sales-2024", req.Name)
+			writeEnvelope(w, DatasetUpdateResponse{DatasetID: req.DatasetID})
+		},
+		"/catalog/dataset/list": func(w http.ResponseWriter, r *http.Request) {
+			var req DatasetListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "sal", req.Keyword)
+			writeEnvelope(w, DatasetListResponse{Total: 1, List: []*DatasetInfoResponse{{DatasetID: 7, Name: "sales"}}})
+		},
+		"/catalog/dataset/delete": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatasetDeleteResponse{DatasetID: 7})
+		},
+	})
+	ctx := context.Background()
+
+	created, err := client.CreateDataset(ctx, &DatasetCreateRequest{
+		Name:      "sales",
+		TableIDs:  []TableID{101, 102},
+		VolumeIDs: []VolumeID{"v-1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, DatasetID(7), created.DatasetID)
+
+	info, err := client.GetDataset(ctx, &DatasetInfoRequest{DatasetID: 7})
+	require.NoError(t, err)
+	require.Equal(t, "sales", info.Name)
+	require.Equal(t, []TableID{101, 102}, info.TableIDs)
+
+	updated, err := client.UpdateDataset(ctx, &DatasetUpdateRequest{DatasetID: 7, Name: "sales-2024"})
+	require.NoError(t, err)
+	require.Equal(t, DatasetID(7), updated.DatasetID)
+
+	list, err := client.ListDatasets(ctx, &DatasetListRequest{Keyword: "sal"})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Total)
+	require.Equal(t, "sales", list.List[0].Name)
+
+	_, err = client.DeleteDataset(ctx, &DatasetDeleteRequest{DatasetID: 7})
+	require.NoError(t, err)
+}
+
+func TestDatasetNilRequest(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, nil)
+	ctx := context.Background()
+
+	_, err := client.CreateDataset(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.DeleteDataset(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.UpdateDataset(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.GetDataset(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.ListDatasets(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+}
+
+func TestDatasetObjPriv(t *testing.T) {
+	t.Parallel()
+	priv := DatasetObjPriv(7, PrivCode_GetDataSet, PrivCode_UpdateDataSet)
+	require.Equal(t, "7", priv.ObjID)
+	require.Equal(t, "dataset", priv.ObjType)
+	require.Len(t, priv.AuthorityCodeList, 2)
+	require.Equal(t, "DS5", priv.AuthorityCodeList[0].Code)
+	require.Equal(t, "DS3", priv.AuthorityCodeList[1].Code)
+
+	require.Contains(t, ObjTypeToPrivIDMap[ObjTypeDataSet], PrivID_GetDataSet)
+}
@@ -0,0 +1,194 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// readOnlyEndpoints are the POST endpoints that only read, and whose
+// identical concurrent calls can share one response. They are listed one by
+// one so that a new endpoint is never shared by accident.
+var readOnlyEndpoints = map[string]bool{
+	"/catalog/info":     true,
+	"/catalog/list":     true,
+	"/catalog/tree":     true,
+	"/catalog/ref_list": true,
+
+	"/catalog/database/info":     true,
+	"/catalog/database/list":     true,
+	"/catalog/database/children": true,
+	"/catalog/database/ref_list": true,
+
+	"/catalog/table/info":       true,
+	"/catalog/table/multi_info": true,
+	"/catalog/table/exist":      true,
+	"/catalog/table/full_path":  true,
+	"/catalog/table/overview":   true,
+	"/catalog/table/ref_list":   true,
+
+	"/catalog/volume/info":      true,
+	"/catalog/volume/full_path": true,
+	"/catalog/volume/ref_list":  true,
+
+	"/catalog/dataset/info": true,
+	"/catalog/dataset/list": true,
+
+	"/catalog/file/info":     true,
+	"/catalog/file/list":     true,
+	"/catalog/file/tree":     true,
+	"/catalog/file/get_tags": true,
+
+	"/catalog/folder/ref_list": true,
+
+	"/catalog/trash/list": true,
+
+	"/catalog/nl2sql_knowledge/get":  true,
+	"/catalog/nl2sql_knowledge/list": true,
+
+	"/connectors/file/list": true,
+
+	"/role/info": true,
+	"/role/list": true,
+
+	"/user/detail_info": true,
+	"/user/list":        true,
+	"/user/me/info":     true,
+
+	"/task/load/list": true,
+}
+
+// isReadOnlyRequest reports whether a request only reads, so that identical
+// concurrent requests can be collapsed into one.
+func isReadOnlyRequest(method, p string) bool {
+	switch method {
+	case http.MethodGet:
+		return true
+	case http.MethodPost:
+		return readOnlyEndpoints[ensureLeadingSlash(p)]
+	}
+	return false
+}
+
+// flightGroup collapses identical concurrent requests into one backend call.
+// It is the singleflight pattern, sharing the raw response body so that each
+// caller decodes its own copy.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	resp *http.Response // Without body
+	data []byte
+	err  error
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// do calls fn, unless a call with the same key is in flight, in which case
+// it waits for that call and returns its result. A caller that waits gives
+// up when its own context is done, and calls fn itself when the shared call
+// failed only because the context of the caller that made it was done.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		if isContextError(call.err) && ctx.Err() == nil {
+			return fn()
+		}
+		return call.resp, call.data, call.err
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.resp, call.data, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.resp, call.data, call.err
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// flightKey identifies a request by everything that can change its response:
+// endpoint, query, body, the per-call credentials and headers, and the
+// metadata of its context.
+func flightKey(ctx context.Context, method, path string, body []byte, opts callOptions) string {
+	var b strings.Builder
+	b.WriteString(method)
+	b.WriteByte(' ')
+	b.WriteString(path)
+	b.WriteByte('?')
+	b.WriteString(opts.query.Encode())
+	b.WriteByte('\n')
+	b.WriteString(opts.apiKeyOverride)
+	b.WriteByte('\n')
+	b.WriteString(opts.requestID)
+	b.WriteByte('\n')
+	writeHeaderKey(&b, http.Header(metadataFromContext(ctx)))
+	b.WriteByte('\n')
+	writeHeaderKey(&b, opts.headers)
+	b.Write(body)
+	return b.String()
+}
+
+func writeHeaderKey(b *strings.Builder, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.Join(h[name], ","))
+		b.WriteByte('\n')
+	}
+}
+
+// doShared sends a read-only request through the flight group of the client
+// and returns a response whose body is a private copy of the shared one.
+func (c *RawClient) doShared(ctx context.Context, method, path string, payload []byte, opts callOptions, prepare func(*http.Request)) (*http.Response, error) {
+	key := flightKey(ctx, method, path, payload, opts)
+	shared, data, err := c.flights.do(ctx, key, func() (*http.Response, []byte, error) {
+		var body io.Reader
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+		resp, err := c.doRaw(ctx, method, path, body, opts, prepare)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		shared := *resp
+		shared.Body = nil
+		return &shared, data, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := *shared
+	resp.Header = shared.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return &resp, nil
+}
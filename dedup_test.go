@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestDeduplication(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		writeEnvelope(w, TableInfoResponse{Name: "orders"})
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewRawClient(server.URL, "test-key", WithRequestDeduplication())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	const n = 8
+	results := make([]*TableInfoResponse, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.GetTable(ctx, &TableInfoRequest{TableID: 1})
+			require.NoError(t, err)
+			results[i] = resp
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), calls.Load())
+	for _, resp := range results {
+		require.Equal(t, "orders", resp.Name)
+	}
+	require.NotSame(t, results[0], results[1])
+
+	// Sequential and opted-out calls reach the backend.
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1})
+	require.NoError(t, err)
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1}, WithoutDeduplication())
+	require.NoError(t, err)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestFlightGroupLeaderCanceled(t *testing.T) {
+	t.Parallel()
+	g := newFlightGroup()
+	started := make(chan struct{})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, _, _ = g.do(leaderCtx, "k", func() (*http.Response, []byte, error) {
+			close(started)
+			<-leaderCtx.Done()
+			return nil, nil, leaderCtx.Err()
+		})
+	}()
+	<-started
+
+	done := make(chan []byte)
+	go func() {
+		_, data, err := g.do(context.Background(), "k", func() (*http.Response, []byte, error) {
+			return &http.Response{StatusCode: http.StatusOK}, []byte("own"), nil
+		})
+		require.NoError(t, err)
+		done <- data
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.Equal(t, []byte("own"), <-done)
+}
+
+func TestIsReadOnlyRequest(t *testing.T) {
+	t.Parallel()
+	require.True(t, isReadOnlyRequest(http.MethodGet, "/task/get"))
+	require.True(t, isReadOnlyRequest(http.MethodPost, "/catalog/table/info"))
+	require.True(t, isReadOnlyRequest(http.MethodPost, "/catalog/file/list"))
+	require.False(t, isReadOnlyRequest(http.MethodPost, "/catalog/table/create"))
+	require.False(t, isReadOnlyRequest(http.MethodDelete, "/catalog/info"))
+	require.True(t, isReadOnlyRequest(http.MethodPost, "/catalog/nl2sql_knowledge/get"))
+	require.True(t, isReadOnlyRequest(http.MethodPost, "catalog/table/info"))
+	// Only the listed endpoints are shared, not any path ending like them.
+	require.False(t, isReadOnlyRequest(http.MethodPost, "/catalog/token/get"))
+	require.False(t, isReadOnlyRequest(http.MethodPost, "/v2/catalog/list"))
+}
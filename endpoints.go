@@ -0,0 +1,216 @@
+package sdk
+
+import (
+	"net/http"
+	"reflect"
+)
+
+//go:generate go run ./cmd/moi-openapi -o openapi.json
+
+// Endpoint describes a JSON API of the service: its route and the Go types
+// of its request body and of the data field of its response envelope. The
+// endpoint table is what the OpenAPI spec of the SDK is generated from, so
+// that other languages and contract tests share the types of models.go.
+type Endpoint struct {
+	// Name is the name of the RawClient method calling the endpoint.
+	Name string
+	// Tag groups endpoints by resource, such as "Table".
+	Tag    string
+	Method string
+	// Path is the route, with path parameters in braces, such as
+	// "/v1/genai/jobs/{job_id}".
+	Path string
+	// Request is the type of the JSON request body, nil if none is sent.
+	Request reflect.Type
+	// Query lists the query parameters of GET endpoints.
+	Query []string
+	// Response is the type of the data field of the response envelope.
+	Response reflect.Type
+	// Via names the endpoints a method without a route of its own is built
+	// on, such as ListUsers for GetUserByName. Method and Path are then
+	// empty, Request and Response are the types the method takes and
+	// returns, and the entry is left out of the OpenAPI spec.
+	Via []string
+}
+
+var endpoints = []Endpoint{
+	// Account
+	{Name: "GetAccountLimits", Tag: "Account", Method: http.MethodGet, Path: "/account/limits", Response: reflect.TypeFor[AccountLimitsResponse]()},
+
+	// Auth
+	{Name: "Login", Tag: "Auth", Method: http.MethodPost, Path: "/auth/login", Request: reflect.TypeFor[AuthLoginRequest](), Response: reflect.TypeFor[AuthLoginResponse]()},
+	{Name: "ExchangeAPIKey", Tag: "Auth", Method: http.MethodPost, Path: "/auth/api_key/exchange", Request: reflect.TypeFor[AuthAPIKeyExchangeRequest](), Response: reflect.TypeFor[AuthAPIKeyExchangeResponse]()},
+
+	// Catalog
+	{Name: "CreateCatalog", Tag: "Catalog", Method: http.MethodPost, Path: "/catalog/create", Request: reflect.TypeFor[CatalogCreateRequest](), Response: reflect.TypeFor[CatalogCreateResponse]()},
+	{Name: "DeleteCatalog", Tag: "Catalog", Method: http.MethodPost, Path: "/catalog/delete", Request: reflect.TypeFor[CatalogDeleteRequest](), Response: reflect.TypeFor[CatalogDeleteResponse]()},
+	{Name: "UpdateCatalog", Tag: "Catalog", Method: http.MethodPost, Path: "/catalog/update", Request: reflect.TypeFor[CatalogUpdateRequest](), Response: reflect.TypeFor[CatalogUpdateResponse]()},
+	{Name: "GetCatalog", Tag: "Catalog", Method: http.MethodPost, Path: "/catalog/info", Request: reflect.TypeFor[CatalogInfoRequest](), Response: reflect.TypeFor[CatalogInfoResponse]()},
+	{Name: "ListCatalogs", Tag: "Catalog", Method: http.MethodPost, Path: "/catalog/list", Response: reflect.TypeFor[CatalogListResponse]()},
+	{Name: "GetCatalogTree", Tag: "Catalog", Method: http.MethodPost, Path: "/catalog/tree", Request: reflect.TypeFor[catalogTreeRequest](), Response: reflect.TypeFor[CatalogTreeResponse]()},
+	{Name: "GetCatalogRefList", Tag: "Catalog", Method: http.MethodPost, Path: "/catalog/ref_list", Request: reflect.TypeFor[CatalogRefListRequest](), Response: reflect.TypeFor[CatalogRefListResponse]()},
+
+	// Connector
+	{Name: "DownloadConnectorFile", Tag: "Connector", Method: http.MethodPost, Path: "/connectors/file/download", Request: reflect.TypeFor[ConnectorFileDownloadRequest](), Response: reflect.TypeFor[ConnectorFileDownloadResponse]()},
+	{Name: "DeleteConnectorFile", Tag: "Connector", Method: http.MethodPost, Path: "/connectors/file/delete", Request: reflect.TypeFor[ConnectorFileDeleteRequest](), Response: reflect.TypeFor[ConnectorFileDeleteResponse]()},
+	{Name: "ListConnectorFiles", Tag: "Connector", Method: http.MethodPost, Path: "/connectors/file/list", Request: reflect.TypeFor[ConnectorFileListRequest](), Response: reflect.TypeFor[ConnectorFileListResponse]()},
+
+	// Data asking
+	{Name: "SearchFilePassages", Tag: "Data asking", Method: http.MethodPost, Path: "/byoa/api/v1/data_asking/search", Request: reflect.TypeFor[PassageSearchRequest](), Response: reflect.TypeFor[PassageSearchResponse]()},
+	{Name: "ListAnalysisSessions", Tag: "Data asking", Method: http.MethodGet, Path: "/byoa/api/v1/data_asking/sessions", Query: []string{"source", "keyword", "page", "page_size"}, Response: reflect.TypeFor[AnalysisSessionListResponse]()},
+	{Name: "GetAnalysisResult", Tag: "Data asking", Method: http.MethodGet, Path: "/byoa/api/v1/data_asking/result", Query: []string{"request_id"}, Response: reflect.TypeFor[AnalysisResultResponse]()},
+	{Name: "SubmitAnalysisFeedback", Tag: "Data asking", Method: http.MethodPost, Path: "/byoa/api/v1/data_asking/feedback", Request: reflect.TypeFor[AnalysisFeedbackRequest](), Response: reflect.TypeFor[AnalysisFeedbackResponse]()},
+	{Name: "CancelAnalyze", Tag: "Data asking", Method: http.MethodPost, Path: "/byoa/api/v1/data_asking/cancel", Request: reflect.TypeFor[CancelAnalyzeRequest](), Response: reflect.TypeFor[CancelAnalyzeResponse]()},
+
+	// Database
+	{Name: "CreateDatabase", Tag: "Database", Method: http.MethodPost, Path: "/catalog/database/create", Request: reflect.TypeFor[DatabaseCreateRequest](), Response: reflect.TypeFor[DatabaseCreateResponse]()},
+	{Name: "DeleteDatabase", Tag: "Database", Method: http.MethodPost, Path: "/catalog/database/delete", Request: reflect.TypeFor[DatabaseDeleteRequest](), Response: reflect.TypeFor[DatabaseDeleteResponse]()},
+	{Name: "UpdateDatabase", Tag: "Database", Method: http.MethodPost, Path: "/catalog/database/update", Request: reflect.TypeFor[DatabaseUpdateRequest](), Response: reflect.TypeFor[DatabaseUpdateResponse]()},
+	{Name: "GetDatabase", Tag: "Database", Method: http.MethodPost, Path: "/catalog/database/info", Request: reflect.TypeFor[DatabaseInfoRequest](), Response: reflect.TypeFor[DatabaseInfoResponse]()},
+	{Name: "ListDatabases", Tag: "Database", Method: http.MethodPost, Path: "/catalog/database/list", Request: reflect.TypeFor[DatabaseListRequest](), Response: reflect.TypeFor[DatabaseListResponse]()},
+	{Name: "GetDatabaseChildren", Tag: "Database", Method: http.MethodPost, Path: "/catalog/database/children", Request: reflect.TypeFor[DatabaseChildrenRequest](), Response: reflect.TypeFor[DatabaseChildrenResponseData]()},
+	{Name: "GetDatabaseRefList", Tag: "Database", Method: http.MethodPost, Path: "/catalog/database/ref_list", Request: reflect.TypeFor[DatabaseRefListRequest](), Response: reflect.TypeFor[DatabaseRefListResponse]()},
+
+	// Dataset
+	{Name: "CreateDataset", Tag: "Dataset", Method: http.MethodPost, Path: "/catalog/dataset/create", Request: reflect.TypeFor[DatasetCreateRequest](), Response: reflect.TypeFor[DatasetCreateResponse]()},
+	{Name: "DeleteDataset", Tag: "Dataset", Method: http.MethodPost, Path: "/catalog/dataset/delete", Request: reflect.TypeFor[DatasetDeleteRequest](), Response: reflect.TypeFor[DatasetDeleteResponse]()},
+	{Name: "UpdateDataset", Tag: "Dataset", Method: http.MethodPost, Path: "/catalog/dataset/update", Request: reflect.TypeFor[DatasetUpdateRequest](), Response: reflect.TypeFor[DatasetUpdateResponse]()},
+	{Name: "GetDataset", Tag: "Dataset", Method: http.MethodPost, Path: "/catalog/dataset/info", Request: reflect.TypeFor[DatasetInfoRequest](), Response: reflect.TypeFor[DatasetInfoResponse]()},
+	{Name: "ListDatasets", Tag: "Dataset", Method: http.MethodPost, Path: "/catalog/dataset/list", Request: reflect.TypeFor[DatasetListRequest](), Response: reflect.TypeFor[DatasetListResponse]()},
+
+	// File
+	{Name: "CreateFile", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/create", Request: reflect.TypeFor[FileCreateRequest](), Response: reflect.TypeFor[FileCreateResponse]()},
+	{Name: "UpdateFile", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/update", Request: reflect.TypeFor[FileUpdateRequest](), Response: reflect.TypeFor[FileUpdateResponse]()},
+	{Name: "DeleteFile", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/delete", Request: reflect.TypeFor[FileDeleteRequest](), Response: reflect.TypeFor[FileDeleteResponse]()},
+	{Name: "DeleteFileRef", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/delete_ref", Request: reflect.TypeFor[FileDeleteRefRequest](), Response: reflect.TypeFor[FileDeleteRefResponse]()},
+	{Name: "MoveFile", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/move", Request: reflect.TypeFor[FileMoveRequest](), Response: reflect.TypeFor[FileMoveResponse]()},
+	{Name: "CopyFile", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/copy", Request: reflect.TypeFor[FileCopyRequest](), Response: reflect.TypeFor[FileCopyResponse]()},
+	{Name: "SetFileTags", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/set_tags", Request: reflect.TypeFor[FileTagsSetRequest](), Response: reflect.TypeFor[FileTagsSetResponse]()},
+	{Name: "GetFileTags", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/get_tags", Request: reflect.TypeFor[FileTagsGetRequest](), Response: reflect.TypeFor[FileTagsGetResponse]()},
+	{Name: "GetFile", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/info", Request: reflect.TypeFor[FileInfoRequest](), Response: reflect.TypeFor[FileInfoResponse]()},
+	{Name: "ListFiles", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/list", Request: reflect.TypeFor[FileListRequest](), Response: reflect.TypeFor[FileListResponse]()},
+	{Name: "ListFolderTree", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/tree", Request: reflect.TypeFor[FileTreeRequest](), Response: reflect.TypeFor[FileTreeResponse]()},
+	{Name: "UploadFile", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/upload", Request: reflect.TypeFor[FileUploadRequest](), Response: reflect.TypeFor[FileUploadResponse]()},
+	{Name: "GetFileDownloadLink", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/download", Request: reflect.TypeFor[FileDownloadRequest](), Response: reflect.TypeFor[FileDownloadResponse]()},
+	{Name: "GetFilePreviewLink", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/preview_link", Request: reflect.TypeFor[FilePreviewLinkRequest](), Response: reflect.TypeFor[FilePreviewLinkResponse]()},
+	{Name: "GetFilePreviewStream", Tag: "File", Method: http.MethodPost, Path: "/catalog/file/preview_stream", Request: reflect.TypeFor[FilePreviewStreamRequest](), Response: reflect.TypeFor[FilePreviewLinkResponse]()},
+	{Name: "FilePreview", Tag: "File", Method: http.MethodPost, Path: "/connectors/file/preview", Request: reflect.TypeFor[FilePreviewRequest](), Response: reflect.TypeFor[FilePreviewResponse]()},
+
+	// Folder
+	{Name: "CreateFolder", Tag: "Folder", Method: http.MethodPost, Path: "/catalog/folder/create", Request: reflect.TypeFor[FolderCreateRequest](), Response: reflect.TypeFor[FolderCreateResponse]()},
+	{Name: "UpdateFolder", Tag: "Folder", Method: http.MethodPost, Path: "/catalog/folder/update", Request: reflect.TypeFor[FolderUpdateRequest](), Response: reflect.TypeFor[FolderUpdateResponse]()},
+	{Name: "DeleteFolder", Tag: "Folder", Method: http.MethodPost, Path: "/catalog/folder/delete", Request: reflect.TypeFor[FolderDeleteRequest](), Response: reflect.TypeFor[FolderDeleteResponse]()},
+	{Name: "CleanFolder", Tag: "Folder", Method: http.MethodPost, Path: "/catalog/folder/clean", Request: reflect.TypeFor[FolderCleanRequest](), Response: reflect.TypeFor[FolderCleanResponse]()},
+	{Name: "GetFolderRefList", Tag: "Folder", Method: http.MethodPost, Path: "/catalog/folder/ref_list", Request: reflect.TypeFor[FolderRefListRequest](), Response: reflect.TypeFor[FolderRefListResponse]()},
+
+	// GenAI
+	{Name: "ListGenAIWorkflowNodes", Tag: "GenAI", Method: http.MethodGet, Path: "/v1/genai/nodes", Response: reflect.TypeFor[GenAIWorkflowNodeListResponse]()},
+	{Name: "CreateWorkflow", Tag: "GenAI", Method: http.MethodPost, Path: "/v1/genai/workflow", Request: reflect.TypeFor[WorkflowMetadata](), Response: reflect.TypeFor[WorkflowCreateResponse]()},
+	{Name: "ListWorkflows", Tag: "GenAI", Method: http.MethodGet, Path: "/v1/genai/workflow", Query: []string{"name", "page", "page_size"}, Response: reflect.TypeFor[WorkflowListResponse]()},
+	{Name: "GetWorkflow", Tag: "GenAI", Method: http.MethodGet, Path: "/v1/genai/workflow/{workflow_id}", Response: reflect.TypeFor[WorkflowResponse]()},
+	{Name: "UpdateWorkflow", Tag: "GenAI", Method: http.MethodPut, Path: "/v1/genai/workflow/{workflow_id}", Request: reflect.TypeFor[WorkflowMetadata](), Response: reflect.TypeFor[WorkflowResponse]()},
+	{Name: "DeleteWorkflow", Tag: "GenAI", Method: http.MethodDelete, Path: "/v1/genai/workflow/{workflow_id}", Response: reflect.TypeFor[WorkflowDeleteResponse]()},
+	{Name: "RunWorkflow", Tag: "GenAI", Method: http.MethodPost, Path: "/v1/genai/workflow/{workflow_id}/run", Request: reflect.TypeFor[WorkflowRunRequest](), Response: reflect.TypeFor[WorkflowRunResponse]()},
+	{Name: "GetGenAIJob", Tag: "GenAI", Method: http.MethodGet, Path: "/v1/genai/jobs/{job_id}", Response: reflect.TypeFor[GenAIGetJobDetailResponse]()},
+	{Name: "StopWorkflowJob", Tag: "GenAI", Method: http.MethodPost, Path: "/v1/genai/jobs/{job_id}/stop", Response: reflect.TypeFor[WorkflowJobStopResponse]()},
+	{Name: "RerunWorkflowJobFiles", Tag: "GenAI", Method: http.MethodPost, Path: "/v1/genai/jobs/{job_id}/rerun", Request: reflect.TypeFor[WorkflowJobRerunRequest](), Response: reflect.TypeFor[WorkflowRunResponse]()},
+	{Name: "ListWorkflowJobs", Tag: "GenAI", Method: http.MethodGet, Path: "/byoa/api/v1/workflow_job", Query: []string{"workflow_id", "source_file_id", "status", "start_time_after", "start_time_before", "page", "page_size"}, Response: reflect.TypeFor[workflowJobListRaw]()},
+
+	// Log
+	{Name: "ListUserLogs", Tag: "Log", Method: http.MethodPost, Path: "/log/user", Request: reflect.TypeFor[LogLogListRequest](), Response: reflect.TypeFor[LogLogListResponse]()},
+	{Name: "ListRoleLogs", Tag: "Log", Method: http.MethodPost, Path: "/log/role", Request: reflect.TypeFor[LogLogListRequest](), Response: reflect.TypeFor[LogLogListResponse]()},
+
+	// NL2SQL
+	{Name: "RunNL2SQL", Tag: "NL2SQL", Method: http.MethodPost, Path: "/catalog/nl2sql/run_sql", Request: reflect.TypeFor[NL2SQLRunSQLRequest](), Response: reflect.TypeFor[NL2SQLRunSQLResponse]()},
+
+	// NL2SQL knowledge
+	{Name: "CreateKnowledge", Tag: "NL2SQL knowledge", Method: http.MethodPost, Path: "/catalog/nl2sql_knowledge/create", Request: reflect.TypeFor[NL2SQLKnowledgeCreateRequest](), Response: reflect.TypeFor[NL2SQLKnowledgeCreateResponse]()},
+	{Name: "UpdateKnowledge", Tag: "NL2SQL knowledge", Method: http.MethodPost, Path: "/catalog/nl2sql_knowledge/update", Request: reflect.TypeFor[NL2SQLKnowledgeUpdateRequest](), Response: reflect.TypeFor[NL2SQLKnowledgeUpdateResponse]()},
+	{Name: "DeleteKnowledge", Tag: "NL2SQL knowledge", Method: http.MethodPost, Path: "/catalog/nl2sql_knowledge/delete", Request: reflect.TypeFor[NL2SQLKnowledgeDeleteRequest](), Response: reflect.TypeFor[NL2SQLKnowledgeDeleteResponse]()},
+	{Name: "GetKnowledge", Tag: "NL2SQL knowledge", Method: http.MethodPost, Path: "/catalog/nl2sql_knowledge/get", Request: reflect.TypeFor[NL2SQLKnowledgeGetRequest](), Response: reflect.TypeFor[NL2SQLKnowledgeGetResponse]()},
+	{Name: "ListKnowledge", Tag: "NL2SQL knowledge", Method: http.MethodPost, Path: "/catalog/nl2sql_knowledge/list", Request: reflect.TypeFor[NL2SQLKnowledgeListRequest](), Response: reflect.TypeFor[NL2SQLKnowledgeListResponse]()},
+	{Name: "SearchKnowledge", Tag: "NL2SQL knowledge", Method: http.MethodPost, Path: "/catalog/nl2sql_knowledge/search", Request: reflect.TypeFor[NL2SQLKnowledgeSearchRequest](), Response: reflect.TypeFor[NL2SQLKnowledgeSearchResponse]()},
+
+	// Privilege
+	{Name: "ListObjectsByCategory", Tag: "Privilege", Method: http.MethodPost, Path: "/rbac/priv/list_obj_by_category", Request: reflect.TypeFor[PrivListObjByCategoryRequest](), Response: reflect.TypeFor[PrivListObjByCategoryResponse]()},
+	{Name: "GetAuthorizedObjects", Tag: "Privilege", Method: http.MethodPost, Path: "/rbac/priv/get_authorized_objects", Request: reflect.TypeFor[PrivGetAuthorizedObjectsRequest](), Response: reflect.TypeFor[PrivGetAuthorizedObjectsResponse]()},
+	{Name: "CheckPrivs", Tag: "Privilege", Method: http.MethodPost, Path: "/rbac/priv/check", Request: reflect.TypeFor[PrivCheckRequest](), Response: reflect.TypeFor[PrivCheckResponse]()},
+
+	// Role
+	{Name: "CreateRole", Tag: "Role", Method: http.MethodPost, Path: "/role/create", Request: reflect.TypeFor[RoleCreateRequest](), Response: reflect.TypeFor[RoleCreateResponse]()},
+	{Name: "DeleteRole", Tag: "Role", Method: http.MethodPost, Path: "/role/delete", Request: reflect.TypeFor[RoleDeleteRequest](), Response: reflect.TypeFor[RoleDeleteResponse]()},
+	{Name: "GetRole", Tag: "Role", Method: http.MethodPost, Path: "/role/info", Request: reflect.TypeFor[RoleInfoRequest](), Response: reflect.TypeFor[RoleInfoResponse]()},
+	{Name: "ListRoles", Tag: "Role", Method: http.MethodPost, Path: "/role/list", Request: reflect.TypeFor[RoleListRequest](), Response: reflect.TypeFor[RoleListResponse]()},
+	{Name: "ListRolesByCategoryAndObject", Tag: "Role", Method: http.MethodPost, Path: "/role/list_by_category_and_obj", Request: reflect.TypeFor[RoleListByCategoryAndObjectRequest](), Response: reflect.TypeFor[RoleListByCategoryAndObjectResponse]()},
+	{Name: "UpdateRoleCodeList", Tag: "Role", Method: http.MethodPost, Path: "/role/update_code_list", Request: reflect.TypeFor[RoleUpdateCodeListRequest](), Response: reflect.TypeFor[RoleUpdateCodeListResponse]()},
+	{Name: "UpdateRoleInfo", Tag: "Role", Method: http.MethodPost, Path: "/role/update_info", Request: reflect.TypeFor[RoleUpdateInfoRequest](), Response: reflect.TypeFor[RoleUpdateInfoResponse]()},
+	{Name: "UpdateRolesByObject", Tag: "Role", Method: http.MethodPost, Path: "/role/update_roles_by_obj", Request: reflect.TypeFor[RoleUpdateRolesByObjectRequest](), Response: reflect.TypeFor[RoleUpdateRolesByObjectResponse]()},
+	{Name: "UpdateRoleStatus", Tag: "Role", Method: http.MethodPost, Path: "/role/update_status", Request: reflect.TypeFor[RoleUpdateStatusRequest](), Response: reflect.TypeFor[RoleUpdateStatusResponse]()},
+	{Name: "GetRoleByName", Tag: "Role", Response: reflect.TypeFor[RoleInfoResponse](), Via: []string{"ListRoles"}},
+
+	// Table
+	{Name: "CreateTable", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/create", Request: reflect.TypeFor[TableCreateRequest](), Response: reflect.TypeFor[TableCreateResponse]()},
+	{Name: "GetTable", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/info", Request: reflect.TypeFor[TableInfoRequest](), Response: reflect.TypeFor[TableInfoResponse]()},
+	{Name: "GetMultiTable", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/multi_info", Request: reflect.TypeFor[MultiTableInfoRequest](), Response: reflect.TypeFor[MultiTableInfoResponse]()},
+	{Name: "GetTableOverview", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/overview", Response: reflect.TypeFor[[]TableOverview]()},
+	{Name: "CheckTableExists", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/exist", Request: reflect.TypeFor[TableExistRequest](), Response: reflect.TypeFor[bool]()},
+	{Name: "GetTableData", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/data", Request: reflect.TypeFor[GetTableDataRequest](), Response: reflect.TypeFor[GetTableDataResponse]()},
+	{Name: "LoadTable", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/load", Request: reflect.TypeFor[TableLoadRequest](), Response: reflect.TypeFor[TableLoadResponse]()},
+	{Name: "InsertTableRows", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/insert", Request: reflect.TypeFor[TableInsertRowsRequest](), Response: reflect.TypeFor[TableInsertRowsResponse]()},
+	{Name: "GetTableDownloadLink", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/download", Request: reflect.TypeFor[TableDownloadRequest](), Response: reflect.TypeFor[TableDownloadResponse]()},
+	{Name: "TruncateTable", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/truncate", Request: reflect.TypeFor[TableTruncateRequest](), Response: reflect.TypeFor[TableTruncateResponse]()},
+	{Name: "AlterTable", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/alter", Request: reflect.TypeFor[TableAlterRequest](), Response: reflect.TypeFor[TableAlterResponse]()},
+	{Name: "RenameTable", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/rename", Request: reflect.TypeFor[TableRenameRequest](), Response: reflect.TypeFor[TableRenameResponse]()},
+	{Name: "DeleteTable", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/delete", Request: reflect.TypeFor[TableDeleteRequest](), Response: reflect.TypeFor[TableDeleteResponse]()},
+	{Name: "GetTableFullPath", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/full_path", Request: reflect.TypeFor[TableFullPathRequest](), Response: reflect.TypeFor[TableFullPathResponse]()},
+	{Name: "GetTableRefList", Tag: "Table", Method: http.MethodPost, Path: "/catalog/table/ref_list", Request: reflect.TypeFor[TableRefListRequest](), Response: reflect.TypeFor[TableRefListResponse]()},
+	{Name: "PreviewTable", Tag: "Table", Request: reflect.TypeFor[TablePreviewRequest](), Response: reflect.TypeFor[TablePreviewResponse](), Via: []string{"GetTableData", "GetTable", "GetTableFullPath", "RunNL2SQL"}},
+
+	// Task
+	{Name: "GetTask", Tag: "Task", Method: http.MethodGet, Path: "/task/get", Query: []string{"task_id"}, Response: reflect.TypeFor[TaskInfoResponse]()},
+	{Name: "CreateLoadTask", Tag: "Task", Method: http.MethodPost, Path: "/task/load/create", Request: reflect.TypeFor[LoadTaskCreateRequest](), Response: reflect.TypeFor[LoadTaskCreateResponse]()},
+	{Name: "GetLoadTask", Tag: "Task", Method: http.MethodGet, Path: "/task/load/get", Query: []string{"task_id"}, Response: reflect.TypeFor[TaskInfoResponse]()},
+	{Name: "ListLoadTasks", Tag: "Task", Method: http.MethodPost, Path: "/task/load/list", Request: reflect.TypeFor[LoadTaskListRequest](), Response: reflect.TypeFor[LoadTaskListResponse]()},
+	{Name: "CancelLoadTask", Tag: "Task", Method: http.MethodPost, Path: "/task/load/cancel", Request: reflect.TypeFor[LoadTaskCancelRequest](), Response: reflect.TypeFor[LoadTaskCancelResponse]()},
+	{Name: "DeleteLoadTask", Tag: "Task", Method: http.MethodPost, Path: "/task/load/delete", Request: reflect.TypeFor[LoadTaskDeleteRequest](), Response: reflect.TypeFor[LoadTaskDeleteResponse]()},
+
+	// Trash
+	{Name: "MoveToTrash", Tag: "Trash", Method: http.MethodPost, Path: "/catalog/trash/move", Request: reflect.TypeFor[TrashMoveRequest](), Response: reflect.TypeFor[TrashMoveResponse]()},
+	{Name: "ListTrash", Tag: "Trash", Method: http.MethodPost, Path: "/catalog/trash/list", Request: reflect.TypeFor[TrashListRequest](), Response: reflect.TypeFor[TrashListResponse]()},
+	{Name: "RestoreFromTrash", Tag: "Trash", Method: http.MethodPost, Path: "/catalog/trash/restore", Request: reflect.TypeFor[TrashRestoreRequest](), Response: reflect.TypeFor[TrashRestoreResponse]()},
+	{Name: "PurgeTrash", Tag: "Trash", Method: http.MethodPost, Path: "/catalog/trash/purge", Request: reflect.TypeFor[TrashPurgeRequest](), Response: reflect.TypeFor[TrashPurgeResponse]()},
+
+	// User
+	{Name: "CreateUser", Tag: "User", Method: http.MethodPost, Path: "/user/create", Request: reflect.TypeFor[UserCreateRequest](), Response: reflect.TypeFor[UserCreateResponse]()},
+	{Name: "DeleteUser", Tag: "User", Method: http.MethodPost, Path: "/user/delete", Request: reflect.TypeFor[UserDeleteUserRequest](), Response: reflect.TypeFor[UserDeleteUserResponse]()},
+	{Name: "GetUserDetail", Tag: "User", Method: http.MethodPost, Path: "/user/detail_info", Request: reflect.TypeFor[UserDetailInfoRequest](), Response: reflect.TypeFor[UserDetailInfoResponse]()},
+	{Name: "ListUsers", Tag: "User", Method: http.MethodPost, Path: "/user/list", Request: reflect.TypeFor[UserListRequest](), Response: reflect.TypeFor[UserListResponse]()},
+	{Name: "UpdateUserPassword", Tag: "User", Method: http.MethodPost, Path: "/user/update_password", Request: reflect.TypeFor[UserUpdatePasswordRequest](), Response: reflect.TypeFor[UserUpdatePasswordResponse]()},
+	{Name: "UpdateUserInfo", Tag: "User", Method: http.MethodPost, Path: "/user/update_info", Request: reflect.TypeFor[UserUpdateInfoRequest](), Response: reflect.TypeFor[UserUpdateInfoResponse]()},
+	{Name: "UpdateUserRoles", Tag: "User", Method: http.MethodPost, Path: "/user/update_role_list", Request: reflect.TypeFor[UserUpdateRoleListRequest](), Response: reflect.TypeFor[UserUpdateRoleListResponse]()},
+	{Name: "UpdateUserStatus", Tag: "User", Method: http.MethodPost, Path: "/user/update_status", Request: reflect.TypeFor[UserUpdateStatusRequest](), Response: reflect.TypeFor[UserUpdateStatusResponse]()},
+	{Name: "GetMyAPIKey", Tag: "User", Method: http.MethodPost, Path: "/user/me/api-key", Response: reflect.TypeFor[UserApiKeyResponse]()},
+	{Name: "RefreshMyAPIKey", Tag: "User", Method: http.MethodPost, Path: "/user/me/api-key/refresh", Response: reflect.TypeFor[UserApiKeyRefreshResonse]()},
+	{Name: "GetMyInfo", Tag: "User", Method: http.MethodPost, Path: "/user/me/info", Response: reflect.TypeFor[UserMeInfoResponse]()},
+	{Name: "UpdateMyInfo", Tag: "User", Method: http.MethodPost, Path: "/user/me/update_info", Request: reflect.TypeFor[UserMeUpdateInfoRequest](), Response: reflect.TypeFor[UserMeUpdateInfoResponse]()},
+	{Name: "UpdateMyPassword", Tag: "User", Method: http.MethodPost, Path: "/user/me/update_password", Request: reflect.TypeFor[UserMeUpdatePasswordRequest](), Response: reflect.TypeFor[UserMeUpdatePasswordResponse]()},
+	{Name: "GetUserByName", Tag: "User", Response: reflect.TypeFor[UserResponse](), Via: []string{"ListUsers"}},
+
+	// Volume
+	{Name: "CreateVolume", Tag: "Volume", Method: http.MethodPost, Path: "/catalog/volume/create", Request: reflect.TypeFor[VolumeCreateRequest](), Response: reflect.TypeFor[VolumeCreateResponse]()},
+	{Name: "DeleteVolume", Tag: "Volume", Method: http.MethodPost, Path: "/catalog/volume/delete", Request: reflect.TypeFor[VolumeDeleteRequest](), Response: reflect.TypeFor[VolumeDeleteResponse]()},
+	{Name: "UpdateVolume", Tag: "Volume", Method: http.MethodPost, Path: "/catalog/volume/update", Request: reflect.TypeFor[VolumeUpdateRequest](), Response: reflect.TypeFor[VolumeUpdateResponse]()},
+	{Name: "GetVolume", Tag: "Volume", Method: http.MethodPost, Path: "/catalog/volume/info", Request: reflect.TypeFor[VolumeInfoRequest](), Response: reflect.TypeFor[VolumeInfoResponse]()},
+	{Name: "GetVolumeRefList", Tag: "Volume", Method: http.MethodPost, Path: "/catalog/volume/ref_list", Request: reflect.TypeFor[VolumeRefListRequest](), Response: reflect.TypeFor[VolumeRefListResponse]()},
+	{Name: "GetVolumeFullPath", Tag: "Volume", Method: http.MethodPost, Path: "/catalog/volume/full_path", Request: reflect.TypeFor[VolumeFullPathRequest](), Response: reflect.TypeFor[VolumeFullPathResponse]()},
+	{Name: "AddVolumeWorkflowRef", Tag: "Volume", Method: http.MethodPost, Path: "/catalog/volume/add_ref_workflow", Request: reflect.TypeFor[VolumeAddRefWorkflowRequest](), Response: reflect.TypeFor[VolumeAddRefWorkflowResponse]()},
+	{Name: "RemoveVolumeWorkflowRef", Tag: "Volume", Method: http.MethodPost, Path: "/catalog/volume/remove_ref_workflow", Request: reflect.TypeFor[VolumeRemoveRefWorkflowRequest](), Response: reflect.TypeFor[VolumeRemoveRefWorkflowResponse]()},
+}
+
+// Endpoints returns the JSON APIs covered by the typed methods of
+// RawClient, grouped by tag, together with the methods built on them.
+// Streaming, upload, download, health check and LLM Proxy APIs are not
+// included.
+func Endpoints() []Endpoint {
+	return append([]Endpoint(nil), endpoints...)
+}
@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// endpointExclusions lists the RawClientAPI methods deliberately left out of
+// the endpoint table, with the reason.
+var endpointExclusions = map[string]string{
+	"Do":             "generic request",
+	"Batch":          "runs other methods",
+	"HealthCheck":    "health check, not enveloped",
+	"WaitUntilReady": "health check, not enveloped",
+
+	"AnalyzeDataStream":         "streaming",
+	"CreateLLMCompletionStream": "streaming",
+	"StreamWorkflowJobEvents":   "streaming",
+
+	"UploadConnectorFile":     "upload",
+	"UploadLocalFile":         "upload",
+	"UploadLocalFileFromPath": "upload",
+	"UploadLocalFiles":        "upload",
+	"CreateGenAIPipeline":     "upload",
+
+	"DownloadFileStream":  "download",
+	"DownloadGenAIResult": "download",
+	"DownloadTableData":   "download",
+
+	"AppendLLMSessionMessageModifiedResponse": "LLM Proxy",
+	"CreateLLMChatMessage":                    "LLM Proxy",
+	"CreateLLMChatMessagesBatch":              "LLM Proxy",
+	"CreateLLMCompletion":                     "LLM Proxy",
+	"CreateLLMSession":                        "LLM Proxy",
+	"DeleteLLMChatMessage":                    "LLM Proxy",
+	"DeleteLLMChatMessageTag":                 "LLM Proxy",
+	"DeleteLLMSession":                        "LLM Proxy",
+	"GetLLMChatMessage":                       "LLM Proxy",
+	"GetLLMSession":                           "LLM Proxy",
+	"GetLLMSessionLatestCompletedMessage":     "LLM Proxy",
+	"GetLLMSessionLatestMessage":              "LLM Proxy",
+	"GetLLMUsageStats":                        "LLM Proxy",
+	"ListLLMSessionMessages":                  "LLM Proxy",
+	"ListLLMSessions":                         "LLM Proxy",
+	"ModifyLLMSessionMessageResponse":         "LLM Proxy",
+	"UpdateLLMChatMessage":                    "LLM Proxy",
+	"UpdateLLMChatMessageTags":                "LLM Proxy",
+	"UpdateLLMSession":                        "LLM Proxy",
+}
+
+// TestEndpointsCoverRawClientAPI fails when a method is added to
+// RawClientAPI without an entry in the endpoint table or an exclusion.
+func TestEndpointsCoverRawClientAPI(t *testing.T) {
+	t.Parallel()
+	api := reflect.TypeFor[RawClientAPI]()
+	entries := make(map[string]Endpoint)
+	for _, e := range Endpoints() {
+		_, dup := entries[e.Name]
+		require.False(t, dup, "%s is in the endpoint table twice", e.Name)
+		entries[e.Name] = e
+		_, ok := api.MethodByName(e.Name)
+		require.True(t, ok, "%s is in the endpoint table but not in RawClientAPI", e.Name)
+	}
+
+	for i := 0; i < api.NumMethod(); i++ {
+		name := api.Method(i).Name
+		_, listed := entries[name]
+		_, excluded := endpointExclusions[name]
+		require.False(t, listed && excluded, "%s is both in the endpoint table and excluded", name)
+		require.True(t, listed || excluded, "%s is neither in the endpoint table nor excluded", name)
+	}
+	for name := range endpointExclusions {
+		_, ok := api.MethodByName(name)
+		require.True(t, ok, "excluded method %s is not in RawClientAPI", name)
+	}
+
+	for _, e := range entries {
+		if len(e.Via) == 0 {
+			require.NotEmpty(t, e.Method, e.Name)
+			require.NotEmpty(t, e.Path, e.Name)
+			continue
+		}
+		require.Empty(t, e.Path, e.Name)
+		for _, via := range e.Via {
+			require.Contains(t, entries, via, "%s is built on %s", e.Name, via)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package sdk
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
 var (
@@ -17,11 +19,77 @@ var (
 	// The apiKey parameter must be a non-empty string used for authentication.
 	ErrAPIKeyRequired = errors.New("sdk: apiKey is required")
 
+	// ErrCredentialsRequired indicates that NewRawClientWithPassword was
+	// called without a user name or password.
+	ErrCredentialsRequired = errors.New("sdk: user name and password are required")
+
 	// ErrNilRequest indicates that a required request payload was nil.
 	//
 	// All API methods require a non-nil request parameter. If you need to pass
 	// an empty request, use an empty struct literal (e.g., &CatalogListRequest{}).
 	ErrNilRequest = errors.New("sdk: request payload cannot be nil")
+
+	// ErrPathNotFound indicates that a catalog path passed to ResolvePath or
+	// FindByPath did not match any object. It matches ErrNotFound.
+	ErrPathNotFound error = &classifiedError{msg: "sdk: path not found", class: ErrNotFound}
+
+	// ErrAmbiguousPath indicates that a catalog path matched both a table and
+	// a volume. Use ResolveTablePath or ResolveVolumePath to pick one.
+	ErrAmbiguousPath = errors.New("sdk: path is ambiguous")
+
+	// ErrChecksumMismatch indicates that downloaded data did not match the
+	// checksum announced by the server.
+	ErrChecksumMismatch = errors.New("sdk: checksum mismatch")
+
+	// ErrHashUnavailable indicates that the server has no content hash for
+	// a file to check it against.
+	ErrHashUnavailable = errors.New("sdk: file has no recorded hash")
+
+	// ErrCircuitOpen indicates that the request was not sent because the
+	// circuit breaker configured with WithCircuitBreaker is open after too
+	// many failures. It matches ErrUnavailable.
+	ErrCircuitOpen error = &classifiedError{msg: "sdk: circuit breaker is open", class: ErrUnavailable}
+)
+
+// Sentinel errors for the classes of failures reported by the service.
+//
+// *APIError and *HTTPError values match these with errors.Is, based on the
+// error code, message and HTTP status, so callers can branch on the kind of
+// failure without inspecting backend messages:
+//
+//	_, err := client.CreateCatalog(ctx, req)
+//	switch {
+//	case errors.Is(err, sdk.ErrConflict):
+//		// the catalog already exists
+//	case errors.Is(err, sdk.ErrPermissionDenied):
+//		// the API key lacks the privilege
+//	}
+var (
+	// ErrNotFound indicates that the requested resource does not exist.
+	ErrNotFound = errors.New("sdk: not found")
+
+	// ErrConflict indicates that the resource already exists or the request
+	// conflicts with the current state of the resource.
+	ErrConflict = errors.New("sdk: conflict")
+
+	// ErrPermissionDenied indicates that the caller is authenticated but not
+	// allowed to perform the operation.
+	ErrPermissionDenied = errors.New("sdk: permission denied")
+
+	// ErrUnauthenticated indicates that the API key is missing, invalid or
+	// expired.
+	ErrUnauthenticated = errors.New("sdk: unauthenticated")
+
+	// ErrInvalidArgument indicates that the service rejected the request
+	// parameters.
+	ErrInvalidArgument = errors.New("sdk: invalid argument")
+
+	// ErrQuotaExceeded indicates that a quota or rate limit was exceeded.
+	ErrQuotaExceeded = errors.New("sdk: quota exceeded")
+
+	// ErrUnavailable indicates that the service is temporarily unable to
+	// handle the request; retrying later may succeed.
+	ErrUnavailable = errors.New("sdk: service unavailable")
 )
 
 // APIError captures an application-level error returned by the catalog service envelope.
@@ -59,6 +127,12 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("catalog service error: code=%s msg=%s request_id=%s status=%d", e.Code, e.Message, e.RequestID, e.HTTPStatus)
 }
 
+// Is reports whether the error belongs to the class of target, one of the
+// sentinel errors such as ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	return e != nil && classifyError(e.Code, e.Message, e.HTTPStatus) == target
+}
+
 // HTTPError represents a non-2xx HTTP response that occurred before the SDK could parse the envelope.
 //
 // HTTPError represents network-level errors or server errors that occur before
@@ -90,3 +164,157 @@ func (e *HTTPError) Error() string {
 	}
 	return fmt.Sprintf("http error: status=%d body=%s", e.StatusCode, string(e.Body))
 }
+
+// Is reports whether the HTTP status belongs to the class of target, one of
+// the sentinel errors such as ErrUnavailable.
+func (e *HTTPError) Is(target error) bool {
+	return e != nil && classifyStatus(e.StatusCode) == target
+}
+
+// MapAPIError returns the sentinel error that describes err, such as
+// ErrNotFound or ErrConflict, or nil if err is nil or does not belong to a
+// known class. It looks through wrapped errors for an *APIError or
+// *HTTPError.
+//
+// Example:
+//
+//	switch sdk.MapAPIError(err) {
+//	case sdk.ErrNotFound:
+//		return nil
+//	case sdk.ErrUnavailable, sdk.ErrQuotaExceeded:
+//		return retryLater(err)
+//	}
+func MapAPIError(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr != nil {
+		return classifyError(apiErr.Code, apiErr.Message, apiErr.HTTPStatus)
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr != nil {
+		return classifyStatus(httpErr.StatusCode)
+	}
+	for _, class := range errorClasses {
+		if errors.Is(err, class) {
+			return class
+		}
+	}
+	return nil
+}
+
+var errorClasses = []error{
+	ErrNotFound, ErrConflict, ErrPermissionDenied, ErrUnauthenticated,
+	ErrInvalidArgument, ErrQuotaExceeded, ErrUnavailable,
+}
+
+// errorCodeClasses maps normalized error codes (lower case, without an "err"
+// prefix and without separators) to their class.
+var errorCodeClasses = map[string]error{
+	"notfound":           ErrNotFound,
+	"notexist":           ErrNotFound,
+	"notexists":          ErrNotFound,
+	"nosuchobject":       ErrNotFound,
+	"alreadyexists":      ErrConflict,
+	"alreadyexist":       ErrConflict,
+	"exists":             ErrConflict,
+	"duplicate":          ErrConflict,
+	"conflict":           ErrConflict,
+	"permissiondenied":   ErrPermissionDenied,
+	"forbidden":          ErrPermissionDenied,
+	"noprivilege":        ErrPermissionDenied,
+	"nopermission":       ErrPermissionDenied,
+	"accessdenied":       ErrPermissionDenied,
+	"unauthorized":       ErrUnauthenticated,
+	"unauthenticated":    ErrUnauthenticated,
+	"invalidapikey":      ErrUnauthenticated,
+	"invalidtoken":       ErrUnauthenticated,
+	"tokenexpired":       ErrUnauthenticated,
+	"invalidparam":       ErrInvalidArgument,
+	"invalidparams":      ErrInvalidArgument,
+	"invalidargument":    ErrInvalidArgument,
+	"invalidrequest":     ErrInvalidArgument,
+	"badrequest":         ErrInvalidArgument,
+	"quotaexceeded":      ErrQuotaExceeded,
+	"limitexceeded":      ErrQuotaExceeded,
+	"exceedlimit":        ErrQuotaExceeded,
+	"ratelimited":        ErrQuotaExceeded,
+	"toomanyrequests":    ErrQuotaExceeded,
+	"unavailable":        ErrUnavailable,
+	"serviceunavailable": ErrUnavailable,
+	"timeout":            ErrUnavailable,
+}
+
+// errorMessageClasses are message fragments used when the code is not
+// recognized. They are checked in order.
+var errorMessageClasses = []struct {
+	fragment string
+	class    error
+}{
+	{"already exist", ErrConflict},
+	{"duplicate", ErrConflict},
+	{"not found", ErrNotFound},
+	{"not exist", ErrNotFound},
+	{"permission denied", ErrPermissionDenied},
+	{"no privilege", ErrPermissionDenied},
+	{"quota", ErrQuotaExceeded},
+	{"rate limit", ErrQuotaExceeded},
+}
+
+// classifyError maps a service error to a sentinel using the code first,
+// then the message and finally the HTTP status.
+// isPermanentError reports whether err is a failure that repeating the same
+// request cannot fix, such as a missing resource, a denied permission or a
+// rejected argument.
+func isPermanentError(err error) bool {
+	for _, class := range []error{ErrNotFound, ErrPermissionDenied, ErrUnauthenticated, ErrInvalidArgument, ErrNilRequest} {
+		if errors.Is(err, class) {
+			return true
+		}
+	}
+	return false
+}
+
+func classifyError(code, message string, status int) error {
+	normalized := strings.ToLower(code)
+	normalized = strings.NewReplacer("_", "", "-", "", " ", "", ".", "").Replace(normalized)
+	normalized = strings.TrimPrefix(normalized, "err")
+	if class, ok := errorCodeClasses[normalized]; ok {
+		return class
+	}
+	lowerMsg := strings.ToLower(message)
+	for _, m := range errorMessageClasses {
+		if strings.Contains(lowerMsg, m.fragment) {
+			return m.class
+		}
+	}
+	return classifyStatus(status)
+}
+
+func classifyStatus(status int) error {
+	switch status {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusForbidden:
+		return ErrPermissionDenied
+	case http.StatusUnauthorized:
+		return ErrUnauthenticated
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrInvalidArgument
+	case http.StatusTooManyRequests:
+		return ErrQuotaExceeded
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrUnavailable
+	}
+	return nil
+}
+
+// classifiedError is a sentinel that also matches a broader error class.
+type classifiedError struct {
+	msg   string
+	class error
+}
+
+func (e *classifiedError) Error() string { return e.msg }
+
+func (e *classifiedError) Unwrap() error { return e.class }
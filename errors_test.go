@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrorClasses(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		err  error
+		want error
+	}{
+		{&APIError{Code: "ErrNotFound"}, ErrNotFound},
+		{&APIError{Code: "ERR_ALREADY_EXISTS"}, ErrConflict},
+		{&APIError{Code: "ErrInvalidParam"}, ErrInvalidArgument},
+		{&APIError{Code: "PermissionDenied"}, ErrPermissionDenied},
+		{&APIError{Code: "ErrQuotaExceeded"}, ErrQuotaExceeded},
+		{&APIError{Code: "ErrInternal", Message: "role r1 already exists"}, ErrConflict},
+		{&APIError{Code: "ErrInternal", Message: "table does not exist"}, ErrNotFound},
+		{&APIError{Code: "ErrInternal", HTTPStatus: http.StatusUnauthorized}, ErrUnauthenticated},
+		{&HTTPError{StatusCode: http.StatusForbidden}, ErrPermissionDenied},
+		{&HTTPError{StatusCode: http.StatusTooManyRequests}, ErrQuotaExceeded},
+		{&HTTPError{StatusCode: http.StatusServiceUnavailable}, ErrUnavailable},
+		{fmt.Errorf("wrapped: %w", &APIError{Code: "ErrNotFound"}), ErrNotFound},
+		{fmt.Errorf("%w: x", ErrPathNotFound), ErrNotFound},
+	}
+	for _, tc := range cases {
+		require.True(t, errors.Is(tc.err, tc.want), "%v should be %v", tc.err, tc.want)
+		require.Equal(t, tc.want, MapAPIError(tc.err), "%v", tc.err)
+	}
+
+	require.Nil(t, MapAPIError(nil))
+	require.Nil(t, MapAPIError(&APIError{Code: "ErrInternal", HTTPStatus: 200}))
+	require.Nil(t, MapAPIError(&HTTPError{StatusCode: http.StatusInternalServerError}))
+	require.False(t, errors.Is(&APIError{Code: "ErrNotFound"}, ErrConflict))
+}
+
+func TestAPIErrorClassFromResponse(t *testing.T) {
+	t.Parallel()
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelopeError(w, "ErrNotFound", "catalog 9 not found")
+		},
+	})
+	_, err := raw.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 9})
+	require.ErrorIs(t, err, ErrNotFound)
+}
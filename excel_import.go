@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ExcelImportOptions configures ImportExcelToTables.
+type ExcelImportOptions struct {
+	// Sheets lists the worksheets to import, by name. Empty imports every
+	// worksheet of the file.
+	Sheets []string
+	// TableNames maps a worksheet name to the name of its table. Worksheets
+	// not listed get a table of the same name.
+	TableNames map[string]string
+	// NoHeader indicates that the worksheets have no header row.
+	NoHeader bool
+	// AutoPrimaryKey marks a suggested key column of each worksheet as the
+	// primary key; see SuggestPrimaryKey.
+	AutoPrimaryKey bool
+	// Conflict is the policy applied to rows that conflict with existing
+	// ones.
+	Conflict ConflictPolicy
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// ExcelSheetImportResult is the outcome of importing one worksheet in
+// ImportExcelToTables.
+type ExcelSheetImportResult struct {
+	Sheet     string
+	TableName string
+	// TaskID is the ID of the import task, when it was submitted.
+	TaskID int64
+	// Err is the error that prevented the import, or nil.
+	Err error
+}
+
+// ImportExcelToTables imports every worksheet of a local Excel file into a
+// table of its own.
+//
+// The file is uploaded once. Each worksheet is then previewed separately,
+// its columns are inferred with InferTableConfig and an import task that
+// creates its table is submitted. A failing worksheet does not stop the
+// others.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - filePath: path of the local .xlsx file (required)
+//   - databaseID: the database to create the tables in (required)
+//   - opts: optional settings; nil imports every worksheet
+//
+// Returns:
+//   - []ExcelSheetImportResult: the outcome of each worksheet, in order
+//   - error: nil if every worksheet was submitted, otherwise the joined
+//     errors of the worksheets that failed
+//
+// Example:
+//
+//	results, err := sdkClient.ImportExcelToTables(ctx, "/data/report.xlsx", dbID, &sdk.ExcelImportOptions{
+//		Sheets:     []string{"Orders", "Customers"},
+//		TableNames: map[string]string{"Orders": "orders", "Customers": "customers"},
+//	})
+//	for _, r := range results {
+//		fmt.Printf("%s -> %s: task %d, err %v\n", r.Sheet, r.TableName, r.TaskID, r.Err)
+//	}
+func (c *SDKClient) ImportExcelToTables(ctx context.Context, filePath string, databaseID DatabaseID, opts *ExcelImportOptions) ([]ExcelSheetImportResult, error) {
+	if strings.TrimSpace(filePath) == "" {
+		return nil, fmt.Errorf("file_path is required")
+	}
+	if databaseID == 0 {
+		return nil, fmt.Errorf("database_id is required")
+	}
+	if opts == nil {
+		opts = &ExcelImportOptions{}
+	}
+
+	uploadResp, err := c.raw.UploadLocalFileFromPath(ctx, filePath, []FileMeta{
+		{Filename: filepath.Base(filePath), Path: "/"},
+	}, opts.CallOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("upload file: %w", err)
+	}
+	if len(uploadResp.ConnFileIds) == 0 {
+		return nil, fmt.Errorf("upload file: no conn_file_id returned")
+	}
+	connFileID := uploadResp.ConnFileIds[0]
+
+	sheets := opts.Sheets
+	if len(sheets) == 0 {
+		preview, err := c.raw.FilePreview(ctx, &FilePreviewRequest{ConnFileId: connFileID}, opts.CallOptions...)
+		if err == nil && len(preview.SheetNames) == 0 {
+			err = fmt.Errorf("file has no worksheets")
+		}
+		if err != nil {
+			_, _ = c.raw.DeleteConnectorFile(context.WithoutCancel(ctx), &ConnectorFileDeleteRequest{ConnFileId: connFileID}, opts.CallOptions...)
+			return nil, fmt.Errorf("list worksheets: %w", err)
+		}
+		sheets = preview.SheetNames
+	}
+
+	results := make([]ExcelSheetImportResult, len(sheets))
+	var errs []error
+	for i, sheet := range sheets {
+		tableName := opts.TableNames[sheet]
+		if tableName == "" {
+			tableName = strings.TrimSpace(sheet)
+		}
+		results[i] = ExcelSheetImportResult{Sheet: sheet, TableName: tableName}
+
+		selector := SheetByName(sheet)
+		tableConfig, err := c.newTableConfig(ctx, newTableImport{
+			connFileID: connFileID,
+			databaseID: databaseID,
+			sheet:      &selector,
+			conflict:   opts.Conflict,
+			infer: InferTableOptions{
+				TableName:      tableName,
+				NoHeader:       opts.NoHeader,
+				AutoPrimaryKey: opts.AutoPrimaryKey,
+			},
+		}, opts.CallOptions)
+		if err == nil {
+			var resp *UploadFileResponse
+			resp, err = c.importLocalFileToTable(ctx, tableConfig, opts.CallOptions...)
+			if err == nil {
+				results[i].TaskID = resp.TaskId
+			}
+		}
+		if err != nil {
+			results[i].Err = err
+			errs = append(errs, fmt.Errorf("sheet %q: %w", sheet, err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
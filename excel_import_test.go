@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportExcelToTables(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "report.xlsx")
+	require.NoError(t, os.WriteFile(path, []byte("xlsx"), 0o644))
+
+	var (
+		mu      sync.Mutex
+		configs []TableConfig
+	)
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/file/upload": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, LocalFileUploadResponse{ConnFileIds: []string{"cf-1"}})
+		},
+		"/connectors/file/preview": func(w http.ResponseWriter, r *http.Request) {
+			var req FilePreviewRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Sheet == nil {
+				writeEnvelope(w, FilePreviewResponse{SheetNames: []string{"Orders", "Broken"}})
+				return
+			}
+			if req.Sheet.Name == "Broken" {
+				writeEnvelope(w, FilePreviewResponse{})
+				return
+			}
+			writeEnvelope(w, FilePreviewResponse{
+				SheetNames: []string{"Orders", "Broken"},
+				Rows:       []*PreviewRow{{ColumnName: "id", ColumnValues: []string{"1", "2"}}},
+			})
+		},
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			var cfg TableConfig
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("table_config")), &cfg))
+			mu.Lock()
+			configs = append(configs, cfg)
+			mu.Unlock()
+			writeEnvelope(w, UploadFileResponse{TaskId: 7})
+		},
+	})
+
+	results, err := NewSDKClient(raw).ImportExcelToTables(context.Background(), path, 3, &ExcelImportOptions{
+		TableNames:     map[string]string{"Orders": "orders"},
+		AutoPrimaryKey: true,
+	})
+	require.ErrorContains(t, err, `sheet "Broken"`)
+	require.Len(t, results, 2)
+	require.Equal(t, "orders", results[0].TableName)
+	require.Equal(t, int64(7), results[0].TaskID)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "Broken", results[1].TableName)
+	require.Error(t, results[1].Err)
+
+	require.Len(t, configs, 1)
+	require.Equal(t, "Orders", configs[0].Sheet.Name)
+	require.Equal(t, "orders", configs[0].CreateTable.Name)
+	require.True(t, configs[0].CreateTable.TableColumn[0].IsKey)
+}
+
+func TestUploadConnectorFile_Sheets(t *testing.T) {
+	t.Parallel()
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			var sheets []SheetSelector
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("sheets")), &sheets))
+			require.Len(t, sheets, 2)
+			require.Equal(t, "Orders", sheets[0].Name)
+			require.Equal(t, 2, *sheets[1].Index)
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	})
+
+	resp, err := raw.UploadConnectorFile(context.Background(), &UploadFileRequest{
+		VolumeID: "v1",
+		Files:    []FileUploadItem{{File: strings.NewReader("xlsx"), FileName: "report.xlsx"}},
+		Sheets:   []SheetSelector{SheetByName("Orders"), SheetByIndex(2)},
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+}
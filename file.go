@@ -1,7 +1,11 @@
 package sdk
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 )
 
 // CreateFile creates a new file in the specified volume.
@@ -25,6 +29,9 @@ func (c *RawClient) CreateFile(ctx context.Context, req *FileCreateRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FileCreateResponse
 	if err := c.postJSON(ctx, "/catalog/file/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -46,6 +53,9 @@ func (c *RawClient) UpdateFile(ctx context.Context, req *FileUpdateRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FileUpdateResponse
 	if err := c.postJSON(ctx, "/catalog/file/update", req, &resp, opts...); err != nil {
 		return nil, err
@@ -66,6 +76,9 @@ func (c *RawClient) DeleteFile(ctx context.Context, req *FileDeleteRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FileDeleteResponse
 	if err := c.postJSON(ctx, "/catalog/file/delete", req, &resp, opts...); err != nil {
 		return nil, err
@@ -86,6 +99,9 @@ func (c *RawClient) DeleteFileRef(ctx context.Context, req *FileDeleteRefRequest
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FileDeleteRefResponse
 	if err := c.postJSON(ctx, "/catalog/file/delete_ref", req, &resp, opts...); err != nil {
 		return nil, err
@@ -93,6 +109,111 @@ func (c *RawClient) DeleteFileRef(ctx context.Context, req *FileDeleteRefRequest
 	return &resp, nil
 }
 
+// MoveFile moves a file into another folder, possibly of another volume.
+//
+// The file keeps its ID and content; only its location, and its name if one
+// is given, change.
+//
+// Example:
+//
+//	resp, err := client.MoveFile(ctx, &sdk.FileMoveRequest{
+//		FileID:   "file-id-123",
+//		VolumeID: "volume-id-456", // optional, empty for the same volume
+//		ParentID: "folder-id-789", // optional, empty for the root
+//	})
+func (c *RawClient) MoveFile(ctx context.Context, req *FileMoveRequest, opts ...CallOption) (*FileMoveResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp FileMoveResponse
+	if err := c.postJSON(ctx, "/catalog/file/move", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CopyFile copies a file into another folder, possibly of another volume.
+//
+// The content is copied on the server side, so nothing is downloaded. The
+// copy gets a new file ID.
+//
+// Example:
+//
+//	resp, err := client.CopyFile(ctx, &sdk.FileCopyRequest{
+//		FileID:   "file-id-123",
+//		VolumeID: "volume-id-456",
+//		Name:     "report-copy.pdf",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Copy ID: %s\n", resp.FileID)
+func (c *RawClient) CopyFile(ctx context.Context, req *FileCopyRequest, opts ...CallOption) (*FileCopyResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp FileCopyResponse
+	if err := c.postJSON(ctx, "/catalog/file/copy", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetFileTags attaches key/value tags to a file.
+//
+// Tags are merged into the tags the file already has, and a key given an
+// empty value is removed, unless Replace is set. Tagged files can be found
+// with the TagFilters of ListFiles.
+//
+// Example:
+//
+//	resp, err := client.SetFileTags(ctx, &sdk.FileTagsSetRequest{
+//		FileID: "file-id-123",
+//		Tags:   map[string]string{"dataset": "train", "retention": "90d"},
+//	})
+func (c *RawClient) SetFileTags(ctx context.Context, req *FileTagsSetRequest, opts ...CallOption) (*FileTagsSetResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp FileTagsSetResponse
+	if err := c.postJSON(ctx, "/catalog/file/set_tags", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetFileTags returns the tags attached to a file.
+//
+// Example:
+//
+//	resp, err := client.GetFileTags(ctx, &sdk.FileTagsGetRequest{FileID: "file-id-123"})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("dataset: %s\n", resp.Tags["dataset"])
+func (c *RawClient) GetFileTags(ctx context.Context, req *FileTagsGetRequest, opts ...CallOption) (*FileTagsGetResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp FileTagsGetResponse
+	if err := c.postJSON(ctx, "/catalog/file/get_tags", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetFile retrieves detailed information about the specified file.
 //
 // The response includes file name, size, type, and metadata.
@@ -110,6 +231,9 @@ func (c *RawClient) GetFile(ctx context.Context, req *FileInfoRequest, opts ...C
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FileInfoResponse
 	if err := c.postJSON(ctx, "/catalog/file/info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -145,6 +269,9 @@ func (c *RawClient) ListFiles(ctx context.Context, req *FileListRequest, opts ..
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FileListResponse
 	if err := c.postJSON(ctx, "/catalog/file/list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -152,6 +279,35 @@ func (c *RawClient) ListFiles(ctx context.Context, req *FileListRequest, opts ..
 	return &resp, nil
 }
 
+// ListFolderTree returns the folders and files below a folder as a tree, in
+// a single request instead of one ListFiles call per folder.
+//
+// An empty folderID starts from the volume root. depth limits the number of
+// levels returned, 1 meaning the direct children only; 0 returns the whole
+// tree. Folders below the limit have HasChildren set but no Children, and
+// can be expanded with another call.
+//
+// Example:
+//
+//	resp, err := client.ListFolderTree(ctx, "volume-id-123", "", 2)
+//	if err != nil {
+//		return err
+//	}
+//	for _, node := range resp.Nodes {
+//		fmt.Printf("%s (%d children)\n", node.Name, len(node.Children))
+//	}
+func (c *RawClient) ListFolderTree(ctx context.Context, volumeID VolumeID, folderID FileID, depth int, opts ...CallOption) (*FileTreeResponse, error) {
+	req := &FileTreeRequest{VolumeID: volumeID, FolderID: folderID, Depth: depth}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp FileTreeResponse
+	if err := c.postJSON(ctx, "/catalog/file/tree", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // UploadFile uploads a file to the catalog service.
 //
 // This is a simple file upload endpoint. For advanced features like table import,
@@ -167,6 +323,9 @@ func (c *RawClient) UploadFile(ctx context.Context, req *FileUploadRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FileUploadResponse
 	if err := c.postJSON(ctx, "/catalog/file/upload", req, &resp, opts...); err != nil {
 		return nil, err
@@ -191,6 +350,9 @@ func (c *RawClient) GetFileDownloadLink(ctx context.Context, req *FileDownloadRe
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FileDownloadResponse
 	if err := c.postJSON(ctx, "/catalog/file/download", req, &resp, opts...); err != nil {
 		return nil, err
@@ -198,6 +360,45 @@ func (c *RawClient) GetFileDownloadLink(ctx context.Context, req *FileDownloadRe
 	return &resp, nil
 }
 
+// DownloadFileStream downloads the content of a volume file as a stream.
+//
+// Unlike GetFileDownloadLink, the content is streamed through the SDK so no
+// presigned URL or separate HTTP client is needed. The request is sent
+// without the client timeout so large files can be downloaded; it can still
+// be cancelled via ctx. Use WithProgress to observe the bytes transferred.
+// The returned FileStream must be closed by the caller.
+//
+// Example:
+//
+//	stream, err := client.DownloadFileStream(ctx, "file-id-123", "volume-id-456",
+//		sdk.WithProgress(func(transferred, total int64) {
+//			log.Printf("downloaded %d/%d bytes", transferred, total)
+//		}))
+//	if err != nil {
+//		return err
+//	}
+//	defer stream.Close()
+//
+//	written, err := stream.WriteToFile("/tmp/report.pdf")
+func (c *RawClient) DownloadFileStream(ctx context.Context, fileID FileID, volumeID VolumeID, opts ...CallOption) (*FileStream, error) {
+	req := &FileDownloadRequest{FileID: fileID, VolumeID: volumeID}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	callOpts := newCallOptions(opts...)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request body: %w", err)
+	}
+	resp, err := c.doStream(ctx, http.MethodPost, "/catalog/file/download_stream", bytes.NewReader(payload), callOpts, func(r *http.Request) {
+		r.Header.Set(headerContentType, mimeJSON)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newFileStream(resp, callOpts), nil
+}
+
 // GetFilePreviewLink retrieves a signed preview link for the file.
 //
 // The link can be used to preview the file in a browser or application.
@@ -215,6 +416,9 @@ func (c *RawClient) GetFilePreviewLink(ctx context.Context, req *FilePreviewLink
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FilePreviewLinkResponse
 	if err := c.postJSON(ctx, "/catalog/file/preview_link", req, &resp, opts...); err != nil {
 		return nil, err
@@ -239,6 +443,9 @@ func (c *RawClient) GetFilePreviewStream(ctx context.Context, req *FilePreviewSt
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FilePreviewLinkResponse
 	if err := c.postJSON(ctx, "/catalog/file/preview_stream", req, &resp, opts...); err != nil {
 		return nil, err
@@ -0,0 +1,233 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDeleteFilesConcurrency is the number of deletions DeleteFilesWhere
+// runs at the same time when no concurrency is given.
+const defaultDeleteFilesConcurrency = 4
+
+// FilePredicate selects the files deleted by DeleteFilesWhere. A file
+// matches when it satisfies every condition that is set; at least one must
+// be set.
+type FilePredicate struct {
+	// Names are path.Match patterns such as "*.tmp". A pattern containing a
+	// slash is matched against the path of the file below the volume root,
+	// any other against its name.
+	Names []string
+	// Extensions are file extensions such as ".log" or "log", matched
+	// case-insensitively.
+	Extensions []string
+	// OlderThan matches files last updated longer ago than this. Files
+	// whose update time cannot be parsed do not match.
+	OlderThan time.Duration
+	// MinSize and MaxSize bound the file size in bytes. A MaxSize of 0
+	// means no upper bound.
+	MinSize int64
+	MaxSize int64
+	// Tags matches files carrying every one of these tags with the given
+	// value.
+	Tags map[string]string
+	// Match, if set, is an extra condition on the file and its path.
+	Match func(path string, file VolumeChildrenResponse) bool
+}
+
+func (p *FilePredicate) empty() bool {
+	return len(p.Names) == 0 && len(p.Extensions) == 0 && p.OlderThan <= 0 &&
+		p.MinSize <= 0 && p.MaxSize <= 0 && len(p.Tags) == 0 && p.Match == nil
+}
+
+func (p *FilePredicate) matches(filePath string, file VolumeChildrenResponse, now time.Time) bool {
+	if len(p.Names) > 0 && !matchAnyName(p.Names, filePath, file.Name) {
+		return false
+	}
+	if len(p.Extensions) > 0 {
+		ext := fileExtension(file)
+		found := false
+		for _, want := range p.Extensions {
+			if strings.EqualFold(strings.TrimPrefix(want, "."), ext) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if p.OlderThan > 0 {
+		updated, ok := parseServerTime(file.UpdatedAt, time.Local)
+		if !ok {
+			updated, ok = parseServerTime(file.CreatedAt, time.Local)
+		}
+		if !ok || now.Sub(updated) <= p.OlderThan {
+			return false
+		}
+	}
+	if file.Size < p.MinSize || (p.MaxSize > 0 && file.Size > p.MaxSize) {
+		return false
+	}
+	for key, want := range p.Tags {
+		if got, ok := file.Tags[key]; !ok || got != want {
+			return false
+		}
+	}
+	return p.Match == nil || p.Match(filePath, file)
+}
+
+func matchAnyName(patterns []string, filePath, name string) bool {
+	for _, pattern := range patterns {
+		subject := name
+		if strings.Contains(pattern, "/") {
+			subject = filePath
+		}
+		if ok, _ := path.Match(pattern, subject); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteFilesOptions configures DeleteFilesWhere.
+type DeleteFilesOptions struct {
+	// FolderID limits the search to a folder and its subfolders; empty
+	// searches the whole volume.
+	FolderID FileID
+	// DryRun reports the matching files without deleting them.
+	DryRun bool
+	// Concurrency is the maximum number of deletions in flight. Defaults
+	// to 4.
+	Concurrency int
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// DeletedFile is the outcome for one matching file.
+type DeletedFile struct {
+	FileID FileID
+	// Path is the slash-separated path of the file below the searched folder.
+	Path string
+	Size int64
+	// Deleted is true once the file has been deleted. It stays false in a
+	// dry run.
+	Deleted bool
+	Err     error
+}
+
+// DeleteFilesResult reports a DeleteFilesWhere call.
+type DeleteFilesResult struct {
+	// Scanned is the number of files examined.
+	Scanned int
+	// Files holds every matching file, in listing order.
+	Files []DeletedFile
+}
+
+// Failed returns the matching files that could not be deleted.
+func (r *DeleteFilesResult) Failed() []DeletedFile {
+	var failed []DeletedFile
+	for _, f := range r.Files {
+		if f.Err != nil {
+			failed = append(failed, f)
+		}
+	}
+	return failed
+}
+
+// DeleteFilesWhere deletes the files of a volume that match a predicate.
+//
+// The volume, or opts.FolderID, is listed page by page and folder by
+// folder; folders themselves are never deleted. Every file matching the
+// predicate is then deleted by a bounded pool of workers. With DryRun set
+// nothing is deleted, which lets callers review the report first. A
+// predicate with no condition set is rejected rather than emptying the
+// volume.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - volumeID: the volume to clean up (required)
+//   - predicate: the files to delete (required)
+//   - opts: optional settings; nil deletes from the whole volume
+//
+// Returns:
+//   - *DeleteFilesResult: the outcome for every matching file
+//   - error: a listing error, or an error summarizing failed deletions
+//
+// Example:
+//
+//	res, err := sdkClient.DeleteFilesWhere(ctx, volumeID, &sdk.FilePredicate{
+//		Extensions: []string{".tmp", ".log"},
+//		OlderThan:  30 * 24 * time.Hour,
+//	}, &sdk.DeleteFilesOptions{DryRun: true})
+//	if err != nil {
+//		return err
+//	}
+//	for _, f := range res.Files {
+//		fmt.Printf("would delete %s (%d bytes)\n", f.Path, f.Size)
+//	}
+func (c *SDKClient) DeleteFilesWhere(ctx context.Context, volumeID VolumeID, predicate *FilePredicate, opts *DeleteFilesOptions) (*DeleteFilesResult, error) {
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	if predicate == nil || predicate.empty() {
+		return nil, fmt.Errorf("predicate must set at least one condition")
+	}
+	var cfg DeleteFilesOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultDeleteFilesConcurrency
+	}
+
+	var entries []folderTreeEntry
+	if err := c.listFolderTree(ctx, volumeID, cfg.FolderID, "", &entries, cfg.CallOptions); err != nil {
+		return nil, err
+	}
+	result := &DeleteFilesResult{}
+	now := time.Now()
+	for _, e := range entries {
+		if e.folder {
+			continue
+		}
+		result.Scanned++
+		if predicate.matches(e.path, e.item, now) {
+			result.Files = append(result.Files, DeletedFile{FileID: e.id, Path: e.path, Size: e.item.Size})
+		}
+	}
+	if cfg.DryRun {
+		return result, nil
+	}
+
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i := range result.Files {
+		f := &result.Files[i]
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			f.Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, err := c.raw.DeleteFile(ctx, &FileDeleteRequest{FileID: f.FileID}, cfg.CallOptions...); err != nil {
+				f.Err = err
+				return
+			}
+			f.Deleted = true
+		}()
+	}
+	wg.Wait()
+
+	if failed := result.Failed(); len(failed) > 0 {
+		return result, fmt.Errorf("%d of %d files failed to delete, first error: %w", len(failed), len(result.Files), failed[0].Err)
+	}
+	return result, nil
+}
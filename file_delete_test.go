@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func deleteFilesRoutes(t *testing.T, deleted *[]string) map[string]http.HandlerFunc {
+	old := time.Now().Add(-48 * time.Hour).Format("2006-01-02 15:04:05")
+	recent := time.Now().Format("2006-01-02 15:04:05")
+	var mu sync.Mutex
+	return map[string]http.HandlerFunc{
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			switch req.Filters[1].Values[0] {
+			case "":
+				writeEnvelope(w, FileListResponse{Total: 4, List: []VolumeChildrenResponse{
+					{ID: "logs", Name: "logs", FileType: "folder"},
+					{ID: "a", Name: "report.pdf", Size: 500, UpdatedAt: old},
+					{ID: "b", Name: "scratch.TMP", Size: 10, UpdatedAt: old},
+					{ID: "c", Name: "new.tmp", Size: 10, UpdatedAt: recent, Tags: map[string]string{"retention": "1d"}},
+				}})
+			case "logs":
+				writeEnvelope(w, FileListResponse{Total: 1, List: []VolumeChildrenResponse{
+					{ID: "d", Name: "app.log", Size: 2000, UpdatedAt: old},
+				}})
+			}
+		},
+		"/catalog/file/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req FileDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.FileID == "d" {
+				writeEnvelopeError(w, "ErrPermissionDenied", "read-only")
+				return
+			}
+			mu.Lock()
+			*deleted = append(*deleted, string(req.FileID))
+			mu.Unlock()
+			writeEnvelope(w, FileDeleteResponse{FileID: req.FileID})
+		},
+	}
+}
+
+func TestDeleteFilesWhere(t *testing.T) {
+	t.Parallel()
+	var deleted []string
+	client := NewSDKClient(newMockClient(t, deleteFilesRoutes(t, &deleted)))
+
+	res, err := client.DeleteFilesWhere(context.Background(), "v1", &FilePredicate{
+		Extensions: []string{"tmp"},
+		OlderThan:  24 * time.Hour,
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 4, res.Scanned)
+	require.Equal(t, []DeletedFile{{FileID: "b", Path: "scratch.TMP", Size: 10, Deleted: true}}, res.Files)
+	require.Equal(t, []string{"b"}, deleted)
+}
+
+func TestDeleteFilesWhereDryRunAndFailures(t *testing.T) {
+	t.Parallel()
+	var deleted []string
+	client := NewSDKClient(newMockClient(t, deleteFilesRoutes(t, &deleted)))
+
+	pred := &FilePredicate{Names: []string{"logs/*", "*.pdf"}, MinSize: 100}
+	res, err := client.DeleteFilesWhere(context.Background(), "v1", pred, &DeleteFilesOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, res.Files, 2)
+	require.Empty(t, deleted)
+
+	res, err = client.DeleteFilesWhere(context.Background(), "v1", pred, &DeleteFilesOptions{Concurrency: 2})
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.Len(t, res.Failed(), 1)
+	require.Equal(t, "logs/app.log", res.Failed()[0].Path)
+	sort.Strings(deleted)
+	require.Equal(t, []string{"a"}, deleted)
+}
+
+func TestDeleteFilesWhereTags(t *testing.T) {
+	t.Parallel()
+	var deleted []string
+	client := NewSDKClient(newMockClient(t, deleteFilesRoutes(t, &deleted)))
+
+	res, err := client.DeleteFilesWhere(context.Background(), "v1", &FilePredicate{Tags: map[string]string{"retention": "1d"}}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Files, 1)
+	require.Equal(t, []string{"c"}, deleted)
+}
+
+func TestDeleteFilesWhereRequiresCondition(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, nil))
+	_, err := client.DeleteFilesWhere(context.Background(), "v1", &FilePredicate{}, nil)
+	require.ErrorContains(t, err, "at least one condition")
+	_, err = client.DeleteFilesWhere(context.Background(), "", &FilePredicate{MinSize: 1}, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+)
+
+// Hash algorithms reported in FileIntegrityResult.Algorithm.
+const (
+	HashMD5    = "md5"
+	HashSHA256 = "sha256"
+)
+
+// FileIntegrityResult describes a VerifyFileIntegrity check.
+type FileIntegrityResult struct {
+	FileID FileID
+	// Algorithm is the hash algorithm of the remote hash, HashMD5 or
+	// HashSHA256.
+	Algorithm  string
+	RemoteHash string
+	LocalHash  string
+	RemoteSize int64
+	LocalSize  int64
+	// Match is true when the hashes are equal and so are the sizes, if the
+	// server reported one.
+	Match bool
+}
+
+// VerifyFileIntegrity checks that a local file has the same content as a
+// file of a volume, without downloading the remote file.
+//
+// The hash recorded by the server is read with GetFile; its length tells
+// the algorithm, 32 hex characters for MD5 and 64 for SHA-256, and an
+// optional "md5:" or "sha256:" prefix is accepted. The local file is then
+// hashed with the same algorithm. A file whose hash was not recorded yields
+// ErrHashUnavailable; a difference yields an error wrapping
+// ErrChecksumMismatch, together with the result.
+//
+// Parameters:
+//   - ctx: context for the request
+//   - fileID: the remote file (required)
+//   - localPath: the local copy to check (required)
+//
+// Returns:
+//   - *FileIntegrityResult: both hashes and sizes
+//   - error: nil when the contents match
+//
+// Example:
+//
+//	res, err := sdkClient.VerifyFileIntegrity(ctx, fileID, "/data/report.pdf")
+//	if errors.Is(err, sdk.ErrChecksumMismatch) {
+//		log.Printf("report.pdf differs: local %s, remote %s", res.LocalHash, res.RemoteHash)
+//	}
+func (c *SDKClient) VerifyFileIntegrity(ctx context.Context, fileID FileID, localPath string, opts ...CallOption) (*FileIntegrityResult, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file_id is required")
+	}
+	if strings.TrimSpace(localPath) == "" {
+		return nil, fmt.Errorf("local_path is required")
+	}
+	info, err := c.raw.GetFile(ctx, &FileInfoRequest{FileID: fileID}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result := &FileIntegrityResult{FileID: fileID, RemoteSize: info.Size}
+	result.Algorithm, result.RemoteHash = splitFileHash(info.Hash)
+	if result.Algorithm == "" {
+		return nil, fmt.Errorf("%w: file %s", ErrHashUnavailable, fileID)
+	}
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var h hash.Hash = md5.New()
+	if result.Algorithm == HashSHA256 {
+		h = sha256.New()
+	}
+	if result.LocalSize, err = io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("hash %s: %w", localPath, err)
+	}
+	result.LocalHash = hex.EncodeToString(h.Sum(nil))
+
+	result.Match = result.LocalHash == result.RemoteHash && (result.RemoteSize == 0 || result.LocalSize == result.RemoteSize)
+	if !result.Match {
+		return result, fmt.Errorf("%w: %s has %s %s (%d bytes), file %s has %s (%d bytes)", ErrChecksumMismatch,
+			localPath, result.Algorithm, result.LocalHash, result.LocalSize, fileID, result.RemoteHash, result.RemoteSize)
+	}
+	return result, nil
+}
+
+// splitFileHash returns the algorithm and the lower-case hex digest of a
+// hash recorded by the server, or empty strings if it is not recognized.
+// The algorithm is the "md5:" or "sha256:" prefix when there is one, which
+// the digest length must then match, and otherwise follows from the length.
+func splitFileHash(s string) (algorithm, digest string) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if prefix, rest, ok := strings.Cut(s, ":"); ok {
+		algorithm, s = prefix, rest
+	}
+	if _, err := hex.DecodeString(s); err != nil {
+		return "", ""
+	}
+	byLength := ""
+	switch len(s) {
+	case md5.Size * 2:
+		byLength = HashMD5
+	case sha256.Size * 2:
+		byLength = HashSHA256
+	}
+	if byLength == "" || (algorithm != "" && algorithm != byLength) {
+		return "", ""
+	}
+	return byLength, s
+}
@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyFileIntegrity(t *testing.T) {
+	t.Parallel()
+	content := []byte("quarterly numbers\n")
+	md5Sum := md5.Sum(content)
+	shaSum := sha256.Sum256(content)
+	remote := map[FileID]FileInfoResponse{
+		"md5":    {ID: "md5", Size: int64(len(content)), Hash: hex.EncodeToString(md5Sum[:])},
+		"sha":    {ID: "sha", Hash: "SHA256:" + hex.EncodeToString(shaSum[:])},
+		"other":  {ID: "other", Size: int64(len(content)), Hash: "00000000000000000000000000000000"},
+		"nohash": {ID: "nohash", Size: int64(len(content))},
+	}
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/file/info": func(w http.ResponseWriter, r *http.Request) {
+			var req FileInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			writeEnvelope(w, remote[req.FileID])
+		},
+	}))
+	localPath := filepath.Join(t.TempDir(), "q.txt")
+	require.NoError(t, os.WriteFile(localPath, content, 0o644))
+	ctx := context.Background()
+
+	res, err := client.VerifyFileIntegrity(ctx, "md5", localPath)
+	require.NoError(t, err)
+	require.True(t, res.Match)
+	require.Equal(t, HashMD5, res.Algorithm)
+
+	res, err = client.VerifyFileIntegrity(ctx, "sha", localPath)
+	require.NoError(t, err)
+	require.Equal(t, HashSHA256, res.Algorithm)
+	require.Equal(t, hex.EncodeToString(shaSum[:]), res.LocalHash)
+
+	res, err = client.VerifyFileIntegrity(ctx, "other", localPath)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.False(t, res.Match)
+
+	_, err = client.VerifyFileIntegrity(ctx, "nohash", localPath)
+	require.ErrorIs(t, err, ErrHashUnavailable)
+}
+
+func TestImportLocalFileToVolumeSendsHash(t *testing.T) {
+	t.Parallel()
+	content := []byte("a,b\n1,2\n")
+	sum := md5.Sum(content)
+	var metas [][]FileMeta
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			var meta []FileMeta
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("meta")), &meta))
+			metas = append(metas, meta)
+			writeEnvelope(w, UploadFileResponse{})
+		},
+	}))
+	dir := t.TempDir()
+	localPath := filepath.Join(dir, "data.csv")
+	require.NoError(t, os.WriteFile(localPath, content, 0o644))
+	ctx := context.Background()
+
+	_, err := client.ImportLocalFileToVolume(ctx, localPath, "v1", FileMeta{Filename: "data.csv", Path: "data.csv"}, NewDedupConfigSkipByMD5())
+	require.NoError(t, err)
+	_, err = client.ImportLocalFileToVolume(ctx, localPath, "v1", FileMeta{Filename: "data.csv", Path: "data.csv", Hash: "given"}, nil)
+	require.NoError(t, err)
+	_, err = client.ImportLocalFilesToVolume(ctx, []string{localPath}, "v1", nil, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, hex.EncodeToString(sum[:]), metas[0][0].Hash)
+	require.Equal(t, "given", metas[1][0].Hash)
+	require.Equal(t, FileMeta{Filename: "data.csv", Path: "data.csv", Hash: hex.EncodeToString(sum[:])}, metas[2][0])
+}
+
+func TestSplitFileHash(t *testing.T) {
+	t.Parallel()
+	md5Hex := strings.Repeat("ab", md5.Size)
+	shaHex := strings.Repeat("cd", sha256.Size)
+	for _, tc := range []struct {
+		in, algorithm, digest string
+	}{
+		{md5Hex, HashMD5, md5Hex},
+		{shaHex, HashSHA256, shaHex},
+		{"MD5:" + strings.ToUpper(md5Hex), HashMD5, md5Hex},
+		{"sha256:" + shaHex, HashSHA256, shaHex},
+		// A prefix that does not match the digest length is rejected, not
+		// verified with the algorithm the length suggests.
+		{"md5:" + shaHex, "", ""},
+		{"sha256:" + md5Hex, "", ""},
+		{"sha1:" + md5Hex, "", ""},
+		{"xyz", "", ""},
+	} {
+		algorithm, digest := splitFileHash(tc.in)
+		require.Equal(t, tc.algorithm, algorithm, tc.in)
+		require.Equal(t, tc.digest, digest, tc.in)
+	}
+}
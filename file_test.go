@@ -2,6 +2,12 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -94,6 +100,10 @@ func TestFileNilRequestErrors(t *testing.T) {
 		{"Update", func() error { _, err := client.UpdateFile(ctx, nil); return err }},
 		{"Delete", func() error { _, err := client.DeleteFile(ctx, nil); return err }},
 		{"DeleteRef", func() error { _, err := client.DeleteFileRef(ctx, nil); return err }},
+		{"Move", func() error { _, err := client.MoveFile(ctx, nil); return err }},
+		{"Copy", func() error { _, err := client.CopyFile(ctx, nil); return err }},
+		{"SetTags", func() error { _, err := client.SetFileTags(ctx, nil); return err }},
+		{"GetTags", func() error { _, err := client.GetFileTags(ctx, nil); return err }},
 		{"Info", func() error { _, err := client.GetFile(ctx, nil); return err }},
 		{"List", func() error { _, err := client.ListFiles(ctx, nil); return err }},
 		{"Upload", func() error { _, err := client.UploadFile(ctx, nil); return err }},
@@ -910,3 +920,125 @@ func TestFilePreviewStreamFormat(t *testing.T) {
 	require.Contains(t, previewStreamResp.Url, "Signature=")
 	t.Logf("Preview Stream URL format verified: %s", previewStreamResp.Url)
 }
+
+func TestDownloadFileStreamReportsProgress(t *testing.T) {
+	t.Parallel()
+	content := strings.Repeat("x", 10000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/catalog/file/download_stream", r.URL.Path)
+		var req FileDownloadRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, FileID("f1"), req.FileID)
+		require.Equal(t, VolumeID("v1"), req.VolumeID)
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	client, err := NewRawClient(server.URL, "key")
+	require.NoError(t, err)
+
+	var lastTransferred, lastTotal int64
+	stream, err := client.DownloadFileStream(context.Background(), "f1", "v1",
+		WithProgress(func(transferred, total int64) {
+			lastTransferred, lastTotal = transferred, total
+		}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream.Body)
+	require.NoError(t, err)
+	require.Equal(t, content, string(data))
+	require.Equal(t, int64(len(content)), lastTransferred)
+	require.Equal(t, int64(len(content)), lastTotal)
+}
+
+func TestDownloadFileStreamRequiresFileID(t *testing.T) {
+	t.Parallel()
+	_, err := (&RawClient{}).DownloadFileStream(context.Background(), "", "v1")
+	require.Error(t, err)
+}
+
+func TestFileTags(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tags := map[string]string{"dataset": "eval"}
+	var listReq FileListRequest
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/file/set_tags": func(w http.ResponseWriter, r *http.Request) {
+			var req FileTagsSetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, FileID("f1"), req.FileID)
+			require.False(t, req.Replace)
+			for k, v := range req.Tags {
+				if v == "" {
+					delete(tags, k)
+				} else {
+					tags[k] = v
+				}
+			}
+			writeEnvelope(w, FileTagsSetResponse{FileID: req.FileID, Tags: tags})
+		},
+		"/catalog/file/get_tags": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, FileTagsGetResponse{FileID: "f1", Tags: tags})
+		},
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&listReq))
+			writeEnvelope(w, FileListResponse{Total: 1, List: []VolumeChildrenResponse{{ID: "f1", Name: "a.csv", Tags: tags}}})
+		},
+	})
+
+	setResp, err := client.SetFileTags(ctx, &FileTagsSetRequest{FileID: "f1", Tags: map[string]string{"dataset": "", "split": "train"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"split": "train"}, setResp.Tags)
+
+	getResp, err := client.GetFileTags(ctx, &FileTagsGetRequest{FileID: "f1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"split": "train"}, getResp.Tags)
+
+	list, err := client.ListFiles(ctx, &FileListRequest{TagFilters: []FileTagFilter{{Key: "split", Values: []string{"train", "eval"}}, {Key: "owner"}}})
+	require.NoError(t, err)
+	require.Equal(t, "train", list.List[0].Tags["split"])
+	require.Equal(t, []FileTagFilter{{Key: "split", Values: []string{"train", "eval"}}, {Key: "owner"}}, listReq.TagFilters)
+
+	_, err = client.SetFileTags(ctx, &FileTagsSetRequest{FileID: "f1", Tags: map[string]string{" ": "x"}})
+	require.Error(t, err)
+	_, err = client.SetFileTags(ctx, &FileTagsSetRequest{Tags: tags})
+	require.Error(t, err)
+}
+
+func TestListFolderTree(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var got FileTreeRequest
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/file/tree": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			_, _ = w.Write([]byte(`{"code":"OK","data":{"nodes":[
+				{"id":"d1","name":"docs","file_type":"folder","has_children":true,"children":[
+					{"id":"d2","name":"old","file_type":"folder","has_children":true},
+					{"id":"f1","name":"a.pdf","file_type":"pdf","size":42}
+				]},
+				{"id":"f2","name":"b.txt","file_type":"txt"}
+			]}}`))
+		},
+	})
+
+	resp, err := client.ListFolderTree(ctx, "v1", "root", 2)
+	require.NoError(t, err)
+	require.Equal(t, FileTreeRequest{VolumeID: "v1", FolderID: "root", Depth: 2}, got)
+	require.Len(t, resp.Nodes, 2)
+	docs := resp.Nodes[0]
+	require.Equal(t, "docs", docs.Name)
+	require.True(t, docs.HasChildren)
+	require.Len(t, docs.Children, 2)
+	require.True(t, docs.Children[0].HasChildren)
+	require.Empty(t, docs.Children[0].Children)
+	require.Equal(t, int64(42), docs.Children[1].Size)
+	require.False(t, resp.Nodes[1].HasChildren)
+
+	_, err = client.ListFolderTree(ctx, "", "", 0)
+	require.Error(t, err)
+	_, err = client.ListFolderTree(ctx, "v1", "", -1)
+	require.Error(t, err)
+}
@@ -0,0 +1,138 @@
+package sdk
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// fileTypeExtensions maps lower-case file extensions to their FileType.
+var fileTypeExtensions = map[string]FileType{
+	".txt":      FileTypeTXT,
+	".pdf":      FileTypePDF,
+	".ppt":      FileTypePPT,
+	".doc":      FileTypeDOC,
+	".md":       FileTypeMarkdown,
+	".markdown": FileTypeMarkdown,
+	".csv":      FileTypeCSV,
+	".parquet":  FileTypeParquet,
+	".sql":      FileTypeSQLFiles,
+	".docx":     FileTypeDOCX,
+	".pptx":     FileTypePPTX,
+	".wav":      FileTypeWAV,
+	".mp3":      FileTypeMP3,
+	".aac":      FileTypeAAC,
+	".flac":     FileTypeFLAC,
+	".mp4":      FileTypeMP4,
+	".mov":      FileTypeMOV,
+	".mkv":      FileTypeMKV,
+	".png":      FileTypePNG,
+	".jpg":      FileTypeJPG,
+	".jpeg":     FileTypeJPEG,
+	".bmp":      FileTypeBMP,
+	".xls":      FileTypeXLS,
+	".xlsx":     FileTypeXLSX,
+	".htm":      FileTypeHTM,
+	".html":     FileTypeHTML,
+	".eml":      FileTypeEML,
+	".msg":      FileTypeMSG,
+	".p7s":      FileTypeP7S,
+	".dwg":      FileTypeDWG,
+	".dxf":      FileTypeDXF,
+	".fas":      FileTypeFAS,
+}
+
+// fileTypeNames holds the name of every FileType constant.
+var fileTypeNames = map[FileType]string{
+	FileTypeUnknown:  "unknown",
+	FileTypeTXT:      "txt",
+	FileTypePDF:      "pdf",
+	FileTypeIMAGE:    "image",
+	FileTypePPT:      "ppt",
+	FileTypeDOC:      "doc",
+	FileTypeMarkdown: "markdown",
+	FileTypeCSV:      "csv",
+	FileTypeParquet:  "parquet",
+	FileTypeSQLFiles: "sql",
+	FileTypeDir:      "dir",
+	FileTypeDOCX:     "docx",
+	FileTypePPTX:     "pptx",
+	FileTypeWAV:      "wav",
+	FileTypeMP3:      "mp3",
+	FileTypeAAC:      "aac",
+	FileTypeFLAC:     "flac",
+	FileTypeMP4:      "mp4",
+	FileTypeMOV:      "mov",
+	FileTypeMKV:      "mkv",
+	FileTypePNG:      "png",
+	FileTypeJPG:      "jpg",
+	FileTypeJPEG:     "jpeg",
+	FileTypeBMP:      "bmp",
+	FileTypeXLS:      "xls",
+	FileTypeXLSX:     "xlsx",
+	FileTypeHTM:      "htm",
+	FileTypeHTML:     "html",
+	FileTypeEML:      "eml",
+	FileTypeMSG:      "msg",
+	FileTypeP7S:      "p7s",
+	FileTypeDWG:      "dwg",
+	FileTypeDXF:      "dxf",
+	FileTypeFAS:      "fas",
+}
+
+// String returns the name of the file type, such as "pdf", or its code
+// for types the SDK does not know.
+func (t FileType) String() string {
+	if name, ok := fileTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("FileType(%d)", int(t))
+}
+
+// DetectFileType returns the file type of filename from its extension,
+// ignoring case, or FileTypeUnknown if the extension is not recognized.
+//
+// Example:
+//
+//	sdk.DetectFileType("reports/2024/q1.PDF") // sdk.FileTypePDF
+func DetectFileType(filename string) FileType {
+	return fileTypeExtensions[strings.ToLower(path.Ext(filename))]
+}
+
+// FileTypeCodes converts file types to the codes taken by the FileTypes
+// fields of requests, such as UploadFileRequest.FileTypes.
+//
+// Example:
+//
+//	req.FileTypes = sdk.FileTypeCodes(sdk.FileTypePDF, sdk.FileTypeDOCX)
+func FileTypeCodes(types ...FileType) []int32 {
+	if len(types) == 0 {
+		return nil
+	}
+	codes := make([]int32, len(types))
+	for i, t := range types {
+		codes[i] = int32(t)
+	}
+	return codes
+}
+
+// ValidatePathRegex checks that expr, a PathRegex filter, compiles as a
+// regular expression. An empty expr, which filters nothing, is valid. The
+// error is a *ValidationError.
+func ValidatePathRegex(expr string) error {
+	var errs fieldErrors
+	errs.checkPathRegex("path_regex", expr)
+	return errs.err()
+}
+
+// checkPathRegex records an error for field unless expr is empty or
+// compiles.
+func (f *fieldErrors) checkPathRegex(field, expr string) {
+	if expr == "" {
+		return
+	}
+	if _, err := regexp.Compile(expr); err != nil {
+		f.check(false, field, fmt.Sprintf("invalid %s: %v", field, err))
+	}
+}
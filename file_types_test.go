@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFileType(t *testing.T) {
+	t.Parallel()
+	for name, want := range map[string]FileType{
+		"report.pdf":          FileTypePDF,
+		"reports/2024/Q1.PDF": FileTypePDF,
+		"notes.md":            FileTypeMarkdown,
+		"sheet.xlsx":          FileTypeXLSX,
+		"photo.jpeg":          FileTypeJPEG,
+		"archive.tar.gz":      FileTypeUnknown,
+		"README":              FileTypeUnknown,
+	} {
+		require.Equal(t, want, DetectFileType(name), name)
+	}
+}
+
+func TestFileTypeString(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "pdf", FileTypePDF.String())
+	require.Equal(t, "image", FileTypeIMAGE.String())
+	require.Equal(t, "FileType(99)", FileType(99).String())
+	for ext, ft := range fileTypeExtensions {
+		_, ok := fileTypeNames[ft]
+		require.True(t, ok, ext)
+	}
+}
+
+func TestFileTypeCodes(t *testing.T) {
+	t.Parallel()
+	require.Nil(t, FileTypeCodes())
+	require.Equal(t, []int32{2, 11}, FileTypeCodes(FileTypePDF, FileTypeDOCX))
+}
+
+func TestValidatePathRegex(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, ValidatePathRegex(""))
+	require.NoError(t, ValidatePathRegex(`^docs/.*\.pdf$`))
+
+	err := ValidatePathRegex(`docs/(`)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	require.ErrorContains(t, err, "invalid path_regex")
+
+	_, err = NewSDKClient(newMockClient(t, nil)).Raw().UploadConnectorFile(context.Background(), &UploadFileRequest{
+		VolumeID:  "v1",
+		Files:     []FileUploadItem{{FileName: "a.txt"}},
+		PathRegex: "[",
+	})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	err = (&LoadTaskCreateRequest{
+		Source: LoadTaskSourceConfig{ConnectorID: 1, URIs: []string{"/"}, PathRegex: "*"},
+		Target: LoadTaskTargetConfig{VolumeID: "v1"},
+	}).Validate()
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, "source.path_regex", verr.Fields[0].Field)
+}
@@ -24,6 +24,13 @@ func (c *RawClient) CreateFolder(ctx context.Context, req *FolderCreateRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := withNormalizedName(c, ResourceFolder, req, func(r *FolderCreateRequest) *string { return &r.Name })
+	if err != nil {
+		return nil, err
+	}
 	var resp FolderCreateResponse
 	if err := c.postJSON(ctx, "/catalog/folder/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -45,6 +52,9 @@ func (c *RawClient) UpdateFolder(ctx context.Context, req *FolderUpdateRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FolderUpdateResponse
 	if err := c.postJSON(ctx, "/catalog/folder/update", req, &resp, opts...); err != nil {
 		return nil, err
@@ -65,6 +75,9 @@ func (c *RawClient) DeleteFolder(ctx context.Context, req *FolderDeleteRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FolderDeleteResponse
 	if err := c.postJSON(ctx, "/catalog/folder/delete", req, &resp, opts...); err != nil {
 		return nil, err
@@ -85,6 +98,9 @@ func (c *RawClient) CleanFolder(ctx context.Context, req *FolderCleanRequest, op
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FolderCleanResponse
 	if err := c.postJSON(ctx, "/catalog/folder/clean", req, &resp, opts...); err != nil {
 		return nil, err
@@ -105,6 +121,9 @@ func (c *RawClient) GetFolderRefList(ctx context.Context, req *FolderRefListRequ
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp FolderRefListResponse
 	if err := c.postJSON(ctx, "/catalog/folder/ref_list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -0,0 +1,200 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+// MoveFolderOptions configures MoveFolder.
+type MoveFolderOptions struct {
+	// Name is the name of the folder at its destination; empty keeps the
+	// current name.
+	Name string
+	// KeepSource copies the files instead of moving them and leaves the
+	// source folder in place.
+	KeepSource bool
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// MovedFile is one file handled by MoveFolder.
+type MovedFile struct {
+	// Path is the slash-separated path of the file below the moved folder.
+	Path     string
+	SourceID FileID
+	// FileID is the ID of the file at its destination. It equals SourceID
+	// for a move and is a new ID for a copy.
+	FileID FileID
+}
+
+// MoveFolderResult describes a MoveFolder call. On error it holds what was
+// done before the failure.
+type MoveFolderResult struct {
+	// FolderID is the ID of the folder at its destination.
+	FolderID FileID
+	// Folders maps the slash-separated path of every folder below the moved
+	// one to its ID at the destination.
+	Folders map[string]FileID
+	Files   []MovedFile
+}
+
+// folderTreeEntry is a file or folder found by listFolderTree.
+type folderTreeEntry struct {
+	id     FileID
+	path   string
+	folder bool
+	item   VolumeChildrenResponse
+}
+
+// MoveFolder moves a folder with all its content into another folder,
+// possibly of another volume.
+//
+// The folder structure is recreated at the destination, parents before
+// children, reusing folders that already exist there. Files are then moved
+// with RawClient.MoveFile, or copied with RawClient.CopyFile when
+// KeepSource is set, so no content passes through the client. The source
+// folder is deleted only once everything has been moved; if a step fails,
+// the source keeps whatever was not moved yet and the call can be repeated.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - folderID: the folder to move (required)
+//   - dstVolumeID: the destination volume; empty keeps the folder's volume
+//   - dstParentID: the destination parent folder; empty means the volume root
+//   - opts: optional settings; nil moves the folder under its current name
+//
+// Returns:
+//   - *MoveFolderResult: the destination folders and the files handled
+//   - error: any error that occurred
+//
+// Example:
+//
+//	res, err := sdkClient.MoveFolder(ctx, "folder-2023", "archive-volume", "", &sdk.MoveFolderOptions{
+//		Name: "reports-2023",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("moved %d files into folder %s\n", len(res.Files), res.FolderID)
+func (c *SDKClient) MoveFolder(ctx context.Context, folderID FileID, dstVolumeID VolumeID, dstParentID FileID, opts *MoveFolderOptions) (*MoveFolderResult, error) {
+	if folderID == "" {
+		return nil, fmt.Errorf("folder_id is required")
+	}
+	var cfg MoveFolderOptions
+	if opts != nil {
+		cfg = *opts
+	}
+
+	info, err := c.raw.GetFile(ctx, &FileInfoRequest{FileID: folderID}, cfg.CallOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("get folder %s: %w", folderID, err)
+	}
+	if !isFolderType(info.FileType) {
+		return nil, fmt.Errorf("%s is a %s, not a folder", folderID, info.FileType)
+	}
+	srcVolumeID := VolumeID(info.VolumeID)
+	if dstVolumeID == "" {
+		dstVolumeID = srcVolumeID
+	}
+	if cfg.Name == "" {
+		cfg.Name = info.Name
+	}
+
+	var entries []folderTreeEntry
+	if err := c.listFolderTree(ctx, srcVolumeID, folderID, "", &entries, cfg.CallOptions); err != nil {
+		return nil, err
+	}
+	if dstVolumeID == srcVolumeID {
+		if dstParentID == folderID {
+			return nil, fmt.Errorf("cannot move folder %s into itself", folderID)
+		}
+		for _, e := range entries {
+			if e.folder && e.id == dstParentID {
+				return nil, fmt.Errorf("cannot move folder %s into its subfolder %q", folderID, e.path)
+			}
+		}
+	}
+
+	root, err := c.ensureFolder(ctx, dstVolumeID, dstParentID, cfg.Name, cfg.CallOptions)
+	if err != nil {
+		return nil, fmt.Errorf("create folder %q: %w", cfg.Name, err)
+	}
+	if root == folderID {
+		return nil, fmt.Errorf("folder %s is already at its destination", folderID)
+	}
+	result := &MoveFolderResult{FolderID: root, Folders: map[string]FileID{}}
+	parentOf := func(p string) FileID {
+		if dir := path.Dir(p); dir != "." {
+			return result.Folders[dir]
+		}
+		return root
+	}
+
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		parentID := parentOf(e.path)
+		if e.folder {
+			id, err := c.ensureFolder(ctx, dstVolumeID, parentID, path.Base(e.path), cfg.CallOptions)
+			if err != nil {
+				return result, fmt.Errorf("create folder %q: %w", e.path, err)
+			}
+			result.Folders[e.path] = id
+			continue
+		}
+		moved := MovedFile{Path: e.path, SourceID: e.id}
+		if cfg.KeepSource {
+			resp, err := c.raw.CopyFile(ctx, &FileCopyRequest{FileID: e.id, VolumeID: dstVolumeID, ParentID: parentID}, cfg.CallOptions...)
+			if err != nil {
+				return result, fmt.Errorf("copy %q: %w", e.path, err)
+			}
+			moved.FileID = resp.FileID
+		} else {
+			resp, err := c.raw.MoveFile(ctx, &FileMoveRequest{FileID: e.id, VolumeID: dstVolumeID, ParentID: parentID}, cfg.CallOptions...)
+			if err != nil {
+				return result, fmt.Errorf("move %q: %w", e.path, err)
+			}
+			moved.FileID = resp.FileID
+		}
+		result.Files = append(result.Files, moved)
+	}
+
+	if !cfg.KeepSource {
+		if _, err := c.raw.DeleteFolder(ctx, &FolderDeleteRequest{FolderID: folderID}, cfg.CallOptions...); err != nil {
+			return result, fmt.Errorf("delete source folder %s: %w", folderID, err)
+		}
+	}
+	return result, nil
+}
+
+// listFolderTree appends the entries below parentID to entries, every
+// folder before its content.
+func (c *SDKClient) listFolderTree(ctx context.Context, volumeID VolumeID, parentID FileID, prefix string, entries *[]folderTreeEntry, opts []CallOption) error {
+	for page := 1; ; page++ {
+		list, err := c.raw.ListFiles(ctx, &FileListRequest{CommonCondition: CommonCondition{
+			Page:     page,
+			PageSize: volumeListPageSize,
+			Filters: []CommonFilter{
+				{Name: "volume_id", Values: []string{string(volumeID)}},
+				{Name: "parent_id", Values: []string{string(parentID)}},
+			},
+		}}, opts...)
+		if err != nil {
+			return fmt.Errorf("list folder %q: %w", prefix, err)
+		}
+		for _, item := range list.List {
+			e := folderTreeEntry{id: FileID(item.ID), path: path.Join(prefix, item.Name), folder: isFolderType(item.FileType), item: item}
+			*entries = append(*entries, e)
+			if e.folder {
+				if err := c.listFolderTree(ctx, volumeID, e.id, e.path, entries, opts); err != nil {
+					return err
+				}
+			}
+		}
+		if len(list.List) < volumeListPageSize || page*volumeListPageSize >= list.Total {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// folderMoveRoutes serves a source folder "f1" in volume "v1" holding
+// a.txt and a subfolder "sub" with b.txt.
+func folderMoveRoutes(t *testing.T, calls *[]string) map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"/catalog/file/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, FileInfoResponse{ID: "f1", Name: "reports", FileType: "folder", VolumeID: "v1"})
+		},
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "v1", req.Filters[0].Values[0])
+			switch req.Filters[1].Values[0] {
+			case "f1":
+				writeEnvelope(w, FileListResponse{Total: 2, List: []VolumeChildrenResponse{
+					{ID: "sub", Name: "q1", FileType: "folder"},
+					{ID: "a", Name: "a.txt", FileType: "txt"},
+				}})
+			case "sub":
+				writeEnvelope(w, FileListResponse{Total: 1, List: []VolumeChildrenResponse{{ID: "b", Name: "b.txt", FileType: "txt"}}})
+			default:
+				writeEnvelope(w, FileListResponse{})
+			}
+		},
+		"/catalog/folder/create": func(w http.ResponseWriter, r *http.Request) {
+			var req FolderCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*calls = append(*calls, "mkdir "+string(req.VolumeID)+"/"+string(req.ParentID)+"/"+req.Name)
+			writeEnvelope(w, FolderCreateResponse{FolderID: FileID("new-" + req.Name)})
+		},
+		"/catalog/file/move": func(w http.ResponseWriter, r *http.Request) {
+			var req FileMoveRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*calls = append(*calls, "move "+string(req.FileID)+" "+string(req.VolumeID)+"/"+string(req.ParentID))
+			writeEnvelope(w, FileMoveResponse{FileID: req.FileID})
+		},
+		"/catalog/file/copy": func(w http.ResponseWriter, r *http.Request) {
+			var req FileCopyRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*calls = append(*calls, "copy "+string(req.FileID)+" "+string(req.VolumeID)+"/"+string(req.ParentID))
+			writeEnvelope(w, FileCopyResponse{FileID: "copy-" + req.FileID})
+		},
+		"/catalog/folder/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req FolderDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*calls = append(*calls, "rmdir "+string(req.FolderID))
+			writeEnvelope(w, FolderDeleteResponse{FolderID: req.FolderID})
+		},
+	}
+}
+
+func TestMoveFolderAcrossVolumes(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	client := NewSDKClient(newMockClient(t, folderMoveRoutes(t, &calls)))
+
+	res, err := client.MoveFolder(context.Background(), "f1", "v2", "", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"mkdir v2//reports",
+		"mkdir v2/new-reports/q1",
+		"move b v2/new-q1",
+		"move a v2/new-reports",
+		"rmdir f1",
+	}, calls)
+	require.Equal(t, FileID("new-reports"), res.FolderID)
+	require.Equal(t, map[string]FileID{"q1": "new-q1"}, res.Folders)
+	require.Equal(t, []MovedFile{{Path: "q1/b.txt", SourceID: "b", FileID: "b"}, {Path: "a.txt", SourceID: "a", FileID: "a"}}, res.Files)
+}
+
+func TestMoveFolderKeepSource(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	client := NewSDKClient(newMockClient(t, folderMoveRoutes(t, &calls)))
+
+	res, err := client.MoveFolder(context.Background(), "f1", "", "dest", &MoveFolderOptions{Name: "copy", KeepSource: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"mkdir v1/dest/copy",
+		"mkdir v1/new-copy/q1",
+		"copy b v1/new-q1",
+		"copy a v1/new-copy",
+	}, calls)
+	require.Equal(t, FileID("copy-a"), res.Files[1].FileID)
+}
+
+func TestMoveFolderIntoItself(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	client := NewSDKClient(newMockClient(t, folderMoveRoutes(t, &calls)))
+
+	_, err := client.MoveFolder(context.Background(), "f1", "", "sub", nil)
+	require.ErrorContains(t, err, "subfolder")
+	_, err = client.MoveFolder(context.Background(), "f1", "v1", "f1", nil)
+	require.ErrorContains(t, err, "into itself")
+	require.Empty(t, calls)
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // PipelineFile represents a single file to be uploaded when creating a GenAI pipeline.
@@ -43,6 +44,9 @@ func (c *RawClient) CreateGenAIPipeline(ctx context.Context, req *GenAICreatePip
 		if req == nil {
 			return nil, ErrNilRequest
 		}
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
 		var resp GenAICreatePipelineResponse
 		if err := c.postJSON(ctx, "/v1/genai/pipeline", req, &resp, opts...); err != nil {
 			return nil, err
@@ -53,6 +57,9 @@ func (c *RawClient) CreateGenAIPipeline(ctx context.Context, req *GenAICreatePip
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 
 	pr, pw := io.Pipe()
 	writer := multipart.NewWriter(pw)
@@ -102,7 +109,7 @@ func (c *RawClient) CreateGenAIPipeline(ctx context.Context, req *GenAICreatePip
 	}()
 
 	callOpts := newCallOptions(opts...)
-	resp, err := c.doRaw(ctx, http.MethodPost, "/v1/genai/pipeline", pr, callOpts, func(r *http.Request) {
+	resp, err := c.doStream(ctx, http.MethodPost, "/v1/genai/pipeline", pr, callOpts, func(r *http.Request) {
 		r.Header.Set(headerContentType, contentType)
 		r.Header.Set(headerAccept, mimeJSON)
 	})
@@ -147,7 +154,7 @@ func (c *RawClient) CreateGenAIPipeline(ctx context.Context, req *GenAICreatePip
 //	fmt.Printf("Job Status: %s\n", resp.Status)
 func (c *RawClient) GetGenAIJob(ctx context.Context, jobID string, opts ...CallOption) (*GenAIGetJobDetailResponse, error) {
 	if strings.TrimSpace(jobID) == "" {
-		return nil, fmt.Errorf("jobID cannot be empty")
+		return nil, invalidArgument("job_id", "jobID cannot be empty")
 	}
 	var resp GenAIGetJobDetailResponse
 	path := fmt.Sprintf("/v1/genai/jobs/%s", url.PathEscape(jobID))
@@ -177,7 +184,7 @@ func (c *RawClient) GetGenAIJob(ctx context.Context, jobID string, opts ...CallO
 //	fmt.Printf("Downloaded %d bytes\n", len(data))
 func (c *RawClient) DownloadGenAIResult(ctx context.Context, fileID string, opts ...CallOption) (*FileStream, error) {
 	if strings.TrimSpace(fileID) == "" {
-		return nil, fmt.Errorf("fileID cannot be empty")
+		return nil, invalidArgument("file_id", "fileID cannot be empty")
 	}
 	callOpts := newCallOptions(opts...)
 	path := fmt.Sprintf("/v1/genai/results/file/%s", url.PathEscape(fileID))
@@ -185,11 +192,31 @@ func (c *RawClient) DownloadGenAIResult(ctx context.Context, fileID string, opts
 	if err != nil {
 		return nil, err
 	}
-	return &FileStream{
-		Body:       resp.Body,
-		Header:     resp.Header.Clone(),
-		StatusCode: resp.StatusCode,
-	}, nil
+	return newFileStream(resp, callOpts), nil
+}
+
+// ListGenAIWorkflowNodes returns the node types that can be used in workflow
+// steps, with the components and parameters each one accepts.
+//
+// The result can fill in defaults with GenAIWorkflowNode.DefaultParameters
+// and check hand-written parameters with ValidateParameters before a
+// workflow is created.
+//
+// Example:
+//
+//	resp, err := client.ListGenAIWorkflowNodes(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	for _, node := range resp.Nodes {
+//		fmt.Printf("%s: %s\n", node.Type, node.Description)
+//	}
+func (c *RawClient) ListGenAIWorkflowNodes(ctx context.Context, opts ...CallOption) (*GenAIWorkflowNodeListResponse, error) {
+	var resp GenAIWorkflowNodeListResponse
+	if err := c.getJSON(ctx, "/v1/genai/nodes", &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
 // CreateWorkflow creates a new workflow.
@@ -237,8 +264,110 @@ func (c *RawClient) CreateWorkflow(ctx context.Context, req *WorkflowMetadata, o
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	// Ensure required fields are initialized to avoid serializing them as null
-	// The server requires these fields to be present even if empty
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	normalizeWorkflowMetadata(req)
+	var resp WorkflowCreateResponse
+	if err := c.postJSON(ctx, "/v1/genai/workflow", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RunWorkflow triggers a run of a workflow on demand, independently of its
+// ProcessMode schedule.
+//
+// A nil request, or one without FileIDs, processes the whole source volume.
+//
+// Example:
+//
+//	run, err := client.RunWorkflow(ctx, "workflow-123", &sdk.WorkflowRunRequest{
+//		FileIDs: []string{"file-1", "file-2"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Started job: %s\n", run.JobID)
+func (c *RawClient) RunWorkflow(ctx context.Context, workflowID string, req *WorkflowRunRequest, opts ...CallOption) (*WorkflowRunResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, invalidArgument("workflow_id", "workflowID cannot be empty")
+	}
+	if req == nil {
+		req = &WorkflowRunRequest{}
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp WorkflowRunResponse
+	path := fmt.Sprintf("/v1/genai/workflow/%s/run", url.PathEscape(workflowID))
+	if err := c.postJSON(ctx, path, req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StopWorkflowJob stops a running workflow job. Files that have already been
+// processed keep their results.
+//
+// Example:
+//
+//	resp, err := client.StopWorkflowJob(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Job %s is %s\n", resp.JobID, resp.Status)
+func (c *RawClient) StopWorkflowJob(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowJobStopResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, invalidArgument("job_id", "jobID cannot be empty")
+	}
+	var resp WorkflowJobStopResponse
+	path := fmt.Sprintf("/v1/genai/jobs/%s/stop", url.PathEscape(jobID))
+	if err := c.postJSON(ctx, path, struct{}{}, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.JobID == "" {
+		resp.JobID = jobID
+	}
+	return &resp, nil
+}
+
+// RerunWorkflowJobFiles processes the given files of a workflow job again.
+//
+// Use SDKClient.RerunFailedWorkflowFiles to re-run every failed file of a job.
+//
+// Example:
+//
+//	detail, err := client.GetGenAIJob(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	var fileIDs []string
+//	for _, f := range detail.FailedFiles() {
+//		fileIDs = append(fileIDs, f.FileID)
+//	}
+//	run, err := client.RerunWorkflowJobFiles(ctx, "job-123", &sdk.WorkflowJobRerunRequest{FileIDs: fileIDs})
+func (c *RawClient) RerunWorkflowJobFiles(ctx context.Context, jobID string, req *WorkflowJobRerunRequest, opts ...CallOption) (*WorkflowRunResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, invalidArgument("job_id", "jobID cannot be empty")
+	}
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp WorkflowRunResponse
+	path := fmt.Sprintf("/v1/genai/jobs/%s/rerun", url.PathEscape(jobID))
+	if err := c.postJSON(ctx, path, req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// normalizeWorkflowMetadata initializes the fields the server requires to be
+// present, so that they are not serialized as null.
+func normalizeWorkflowMetadata(req *WorkflowMetadata) {
 	if req.SourceVolumeNames == nil {
 		req.SourceVolumeNames = []string{}
 	}
@@ -263,18 +392,139 @@ func (c *RawClient) CreateWorkflow(ctx context.Context, req *WorkflowMetadata, o
 			}
 		}
 	}
-	var resp WorkflowCreateResponse
-	if err := c.postJSON(ctx, "/v1/genai/workflow", req, &resp, opts...); err != nil {
+}
+
+// GetWorkflow retrieves a workflow by ID.
+//
+// Example:
+//
+//	wf, err := client.GetWorkflow(ctx, "workflow-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Workflow %s targets volume %s\n", wf.Name, wf.TargetVolumeID)
+func (c *RawClient) GetWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, invalidArgument("workflow_id", "workflowID cannot be empty")
+	}
+	var resp WorkflowResponse
+	path := fmt.Sprintf("/v1/genai/workflow/%s", url.PathEscape(workflowID))
+	if err := c.getJSON(ctx, path, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
+// UpdateWorkflow replaces the metadata and definition of a workflow.
+//
+// The request has the same shape as for CreateWorkflow and replaces the stored
+// workflow as a whole, so fields left empty are cleared.
+//
+// Example:
+//
+//	wf, err := client.GetWorkflow(ctx, workflowID)
+//	if err != nil {
+//		return err
+//	}
+//	updated, err := client.UpdateWorkflow(ctx, workflowID, &sdk.WorkflowMetadata{
+//		Name:            wf.Name + "-v2",
+//		SourceVolumeIDs: []string{"vol-123"},
+//		TargetVolumeID:  wf.TargetVolumeID,
+//		Workflow:        definition,
+//	})
+func (c *RawClient) UpdateWorkflow(ctx context.Context, workflowID string, req *WorkflowMetadata, opts ...CallOption) (*WorkflowResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, invalidArgument("workflow_id", "workflowID cannot be empty")
+	}
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	normalizeWorkflowMetadata(req)
+	var resp WorkflowResponse
+	path := fmt.Sprintf("/v1/genai/workflow/%s", url.PathEscape(workflowID))
+	if err := c.doJSON(ctx, http.MethodPut, path, req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteWorkflow deletes a workflow.
+//
+// Example:
+//
+//	_, err := client.DeleteWorkflow(ctx, "workflow-123")
+//	if err != nil {
+//		return err
+//	}
+func (c *RawClient) DeleteWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowDeleteResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, invalidArgument("workflow_id", "workflowID cannot be empty")
+	}
+	var resp WorkflowDeleteResponse
+	path := fmt.Sprintf("/v1/genai/workflow/%s", url.PathEscape(workflowID))
+	if err := c.doJSON(ctx, http.MethodDelete, path, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.ID == "" {
+		resp.ID = workflowID
+	}
+	return &resp, nil
+}
+
+// ListWorkflows lists workflows with optional name filtering and pagination.
+//
+// Example:
+//
+//	resp, err := client.ListWorkflows(ctx, &sdk.WorkflowListRequest{
+//		Name:     "docs",
+//		Page:     1,
+//		PageSize: 20,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, wf := range resp.Workflows {
+//		fmt.Printf("Workflow: %s (%s)\n", wf.Name, wf.ID)
+//	}
+func (c *RawClient) ListWorkflows(ctx context.Context, req *WorkflowListRequest, opts ...CallOption) (*WorkflowListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if req.Name != "" {
+		query.Set("name", req.Name)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	path := "/v1/genai/workflow"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	var resp WorkflowListResponse
+	if err := c.getJSON(ctx, path, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Workflows == nil {
+		resp.Workflows = []WorkflowResponse{}
+	}
+	return &resp, nil
+}
+
 // ListWorkflowJobs lists workflow jobs with optional filtering and pagination.
 //
 // This method calls the workflow-be API endpoint /byoa/api/v1/workflow_job to retrieve
-// a list of workflow jobs. The request supports filtering by workflow ID, source file ID, and status,
-// as well as pagination.
+// a list of workflow jobs. The request supports filtering by workflow ID, source files, statuses
+// and start time, as well as pagination. SDKClient.PageWorkflowJobs walks all the pages.
 //
 // Parameters:
 //   - req: the list request with optional filters and pagination parameters
@@ -301,6 +551,9 @@ func (c *RawClient) ListWorkflowJobs(ctx context.Context, req *WorkflowJobListRe
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Build query parameters
 	query := url.Values{}
@@ -310,9 +563,21 @@ func (c *RawClient) ListWorkflowJobs(ctx context.Context, req *WorkflowJobListRe
 	if req.SourceFileID != "" {
 		query.Set("source_file_id", req.SourceFileID)
 	}
+	for _, id := range req.SourceFileIDs {
+		query.Add("source_file_id", id)
+	}
 	if req.Status != "" {
 		query.Set("status", req.Status)
 	}
+	for _, status := range req.Statuses {
+		query.Add("status", status.String())
+	}
+	if !req.StartedAfter.IsZero() {
+		query.Set("start_time_after", req.StartedAfter.Format(time.RFC3339))
+	}
+	if !req.StartedBefore.IsZero() {
+		query.Set("start_time_before", req.StartedBefore.Format(time.RFC3339))
+	}
 	if req.Page > 0 {
 		query.Set("page", strconv.Itoa(req.Page))
 	}
@@ -321,12 +586,7 @@ func (c *RawClient) ListWorkflowJobs(ctx context.Context, req *WorkflowJobListRe
 	}
 
 	// Use raw response structure to match API format
-	type rawResponse struct {
-		Jobs  []workflowJobRaw `json:"jobs"`
-		Total int              `json:"total"`
-	}
-
-	rawResp := rawResponse{
+	rawResp := workflowJobListRaw{
 		Jobs:  []workflowJobRaw{},
 		Total: 0,
 	}
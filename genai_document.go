@@ -0,0 +1,166 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProcessDocumentOptions configures ProcessDocument.
+type ProcessDocumentOptions struct {
+	// PollInterval is the time between job status checks. Defaults to 2
+	// seconds.
+	PollInterval time.Duration
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// ProcessDocumentResult is the outcome of ProcessDocument.
+type ProcessDocumentResult struct {
+	JobID string
+	// Status is the terminal status of the job.
+	Status TaskStatus
+	// File is the job entry of the processed file.
+	File GenAIWorkflowJobFileResponse
+	// Output is the content of the result file.
+	Output []byte
+}
+
+// ProcessDocument runs a local document through GenAI pipeline steps and
+// returns the result.
+//
+// The file is uploaded together with the pipeline creation, the job is then
+// polled with GetGenAIJob until it reaches a terminal state, and the result
+// of the file is read with DownloadGenAIResult. Transient errors while
+// polling are retried; bound the whole call with ctx.
+//
+// Parameters:
+//   - ctx: context controlling how long to wait
+//   - localPath: the document to process (required)
+//   - steps: the pipeline steps (required)
+//   - opts: optional settings; nil uses the defaults
+//
+// Returns:
+//   - *ProcessDocumentResult: the job and the output; it is also returned
+//     with an error once the job has been created
+//   - error: any error that occurred, including a job that did not succeed
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+//	defer cancel()
+//	res, err := sdkClient.ProcessDocument(ctx, "/data/manual.pdf", []sdk.GenAIWorkflowStep{
+//		{Node: "DocumentParseNode"},
+//		{Node: "ChunkNode"},
+//	}, nil)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%s\n", res.Output)
+func (c *SDKClient) ProcessDocument(ctx context.Context, localPath string, steps []GenAIWorkflowStep, opts *ProcessDocumentOptions) (*ProcessDocumentResult, error) {
+	if strings.TrimSpace(localPath) == "" {
+		return nil, fmt.Errorf("local_path is required")
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("at least one step is required")
+	}
+	var cfg ProcessDocumentOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("open file %s: %w", localPath, err)
+	}
+	defer file.Close()
+	name := filepath.Base(localPath)
+	created, err := c.raw.CreateGenAIPipeline(ctx, &GenAICreatePipelineRequest{
+		FileNames: []string{name},
+		Steps:     steps,
+	}, []PipelineFile{{FileName: name, Reader: file}}, cfg.CallOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("create pipeline: %w", err)
+	}
+	if created.JobID == "" {
+		return nil, fmt.Errorf("create pipeline: server returned no job_id")
+	}
+
+	result := &ProcessDocumentResult{JobID: created.JobID}
+	detail, err := c.waitForGenAIJob(ctx, created.JobID, cfg.PollInterval, cfg.CallOptions)
+	if err != nil {
+		return result, err
+	}
+	result.Status = detail.JobStatus()
+	if len(detail.Files) == 0 {
+		return result, fmt.Errorf("job %s reported no files", created.JobID)
+	}
+	result.File = detail.Files[0]
+	for _, f := range detail.Files {
+		if f.FileName == name {
+			result.File = f
+			break
+		}
+	}
+	if result.Status != TaskStatusSucceeded {
+		if result.File.ErrorMessage != "" {
+			return result, fmt.Errorf("job %s %s: %s", created.JobID, result.Status, result.File.ErrorMessage)
+		}
+		return result, fmt.Errorf("job %s %s", created.JobID, result.Status)
+	}
+
+	stream, err := c.raw.DownloadGenAIResult(ctx, result.File.FileID, cfg.CallOptions...)
+	if err != nil {
+		return result, fmt.Errorf("download result of job %s: %w", created.JobID, err)
+	}
+	defer stream.Close()
+	if result.Output, err = io.ReadAll(stream.Body); err != nil {
+		return result, fmt.Errorf("read result of job %s: %w", created.JobID, err)
+	}
+	return result, nil
+}
+
+// waitForGenAIJob polls a GenAI job until it reaches a terminal state,
+// retrying transient errors like WaitForTask does.
+func (c *SDKClient) waitForGenAIJob(ctx context.Context, jobID string, pollInterval time.Duration, opts []CallOption) (*GenAIGetJobDetailResponse, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var (
+		last    *GenAIGetJobDetailResponse
+		lastErr error
+	)
+	for {
+		detail, err := c.raw.GetGenAIJob(ctx, jobID, opts...)
+		if err == nil {
+			last, lastErr = detail, nil
+			if detail.JobStatus().IsTerminal() {
+				return detail, nil
+			}
+		} else if isPermanentError(err) {
+			return nil, fmt.Errorf("get job %s: %w", jobID, err)
+		} else {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("job %s did not finish (last error: %v): %w", jobID, lastErr, ctx.Err())
+			}
+			status := TaskStatusUnknown
+			if last != nil {
+				status = last.JobStatus()
+			}
+			return nil, fmt.Errorf("job %s did not finish, last status %s: %w", jobID, status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
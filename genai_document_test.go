@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessDocument(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "manual.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o644))
+
+	var polls atomic.Int32
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/v1/genai/pipeline": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			var req GenAICreatePipelineRequest
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("payload")), &req))
+			require.Equal(t, []string{"manual.txt"}, req.FileNames)
+			require.Equal(t, "ChunkNode", req.Steps[0].Node)
+			f, header, err := r.FormFile("files")
+			require.NoError(t, err)
+			data, _ := io.ReadAll(f)
+			require.Equal(t, "manual.txt", header.Filename)
+			require.Equal(t, "hello", string(data))
+			writeEnvelope(w, GenAICreatePipelineResponse{JobID: "job-1"})
+		},
+		"/v1/genai/jobs/job-1": func(w http.ResponseWriter, r *http.Request) {
+			if polls.Add(1) < 3 {
+				writeEnvelope(w, GenAIGetJobDetailResponse{Status: TaskStatusRunning.String()})
+				return
+			}
+			writeEnvelope(w, GenAIGetJobDetailResponse{Status: TaskStatusSucceeded.String(), Files: []GenAIWorkflowJobFileResponse{
+				{FileID: "res-1", FileName: "manual.txt", FileStatus: TaskStatusSucceeded},
+			}})
+		},
+		"/v1/genai/results/file/res-1": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"chunks":["hello"]}`))
+		},
+	}))
+
+	res, err := client.ProcessDocument(context.Background(), path, []GenAIWorkflowStep{{Node: "ChunkNode"}},
+		&ProcessDocumentOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	require.Equal(t, "job-1", res.JobID)
+	require.Equal(t, TaskStatusSucceeded, res.Status)
+	require.Equal(t, "res-1", res.File.FileID)
+	require.Equal(t, `{"chunks":["hello"]}`, string(res.Output))
+	require.Equal(t, int32(3), polls.Load())
+}
+
+func TestProcessDocumentFailed(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "bad.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("%PDF"), 0o644))
+
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/v1/genai/pipeline": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, GenAICreatePipelineResponse{JobID: "job-2"})
+		},
+		"/v1/genai/jobs/job-2": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, GenAIGetJobDetailResponse{Status: TaskStatusFailed.String(), Files: []GenAIWorkflowJobFileResponse{
+				{FileID: "res-2", FileName: "bad.pdf", FileStatus: TaskStatusFailed, ErrorMessage: "corrupt file"},
+			}})
+		},
+	}))
+
+	res, err := client.ProcessDocument(context.Background(), path, []GenAIWorkflowStep{{Node: "DocumentParseNode"}}, nil)
+	require.ErrorContains(t, err, "corrupt file")
+	require.Equal(t, TaskStatusFailed, res.Status)
+	require.Nil(t, res.Output)
+
+	_, err = client.ProcessDocument(context.Background(), path, nil, nil)
+	require.Error(t, err)
+}
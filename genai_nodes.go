@@ -0,0 +1,183 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// Parameter types reported in GenAINodeParameter.Type.
+const (
+	GenAIParamString  = "string"
+	GenAIParamInteger = "integer"
+	GenAIParamNumber  = "number"
+	GenAIParamBoolean = "boolean"
+	GenAIParamArray   = "array"
+	GenAIParamObject  = "object"
+)
+
+// Node returns the node type named typ, or nil if there is none.
+func (r *GenAIWorkflowNodeListResponse) Node(typ string) *GenAIWorkflowNode {
+	for i := range r.Nodes {
+		if r.Nodes[i].Type == typ {
+			return &r.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// ValidateStep checks that step uses a known node type and that its
+// parameters are valid for it, as ValidateParameters does.
+func (r *GenAIWorkflowNodeListResponse) ValidateStep(step GenAIWorkflowStep) error {
+	node := r.Node(step.Node)
+	if node == nil {
+		return fmt.Errorf("unknown node type %q", step.Node)
+	}
+	return node.ValidateParameters(step.Parameters)
+}
+
+// ValidateWorkflow checks every node of a workflow definition as
+// ValidateStep does and reports all the problems found.
+func (r *GenAIWorkflowNodeListResponse) ValidateWorkflow(wf *CatalogWorkflow) error {
+	if wf == nil {
+		return fmt.Errorf("workflow is required")
+	}
+	var errs []error
+	for _, n := range wf.Nodes {
+		if err := r.ValidateStep(GenAIWorkflowStep{Node: n.Type, Parameters: n.InitParameters}); err != nil {
+			errs = append(errs, fmt.Errorf("node %s: %w", n.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DefaultParameters returns the parameters of the node that have a default
+// value, keyed by component and parameter name, ready to be adjusted and
+// used as GenAIWorkflowStep.Parameters.
+func (n *GenAIWorkflowNode) DefaultParameters() map[string]map[string]any {
+	params := make(map[string]map[string]any)
+	for _, comp := range n.Components {
+		for _, p := range comp.Parameters {
+			if p.Default == nil {
+				continue
+			}
+			if params[comp.Name] == nil {
+				params[comp.Name] = make(map[string]any)
+			}
+			params[comp.Name][p.Name] = p.Default
+		}
+	}
+	return params
+}
+
+// ValidateParameters checks step parameters against the node: every
+// component and parameter must be known, values must have the declared type
+// and be one of the accepted values if those are listed, and required
+// parameters of the components present must be set. All the problems found
+// are reported.
+func (n *GenAIWorkflowNode) ValidateParameters(params map[string]map[string]any) error {
+	var errs []error
+	for _, compName := range slices.Sorted(maps.Keys(params)) {
+		comp := n.component(compName)
+		if comp == nil {
+			errs = append(errs, fmt.Errorf("node %s has no component %q", n.Type, compName))
+			continue
+		}
+		values := params[compName]
+		for _, name := range slices.Sorted(maps.Keys(values)) {
+			p := comp.parameter(name)
+			if p == nil {
+				errs = append(errs, fmt.Errorf("component %s has no parameter %q", compName, name))
+				continue
+			}
+			if err := p.check(values[name]); err != nil {
+				errs = append(errs, fmt.Errorf("parameter %s.%s: %w", compName, name, err))
+			}
+		}
+		for _, p := range comp.Parameters {
+			if _, ok := values[p.Name]; p.Required && !ok {
+				errs = append(errs, fmt.Errorf("parameter %s.%s is required", compName, p.Name))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *GenAIWorkflowNode) component(name string) *GenAINodeComponent {
+	for i := range n.Components {
+		if n.Components[i].Name == name {
+			return &n.Components[i]
+		}
+	}
+	return nil
+}
+
+func (c *GenAINodeComponent) parameter(name string) *GenAINodeParameter {
+	for i := range c.Parameters {
+		if c.Parameters[i].Name == name {
+			return &c.Parameters[i]
+		}
+	}
+	return nil
+}
+
+func (p *GenAINodeParameter) check(v any) error {
+	if !matchesParamType(p.Type, v) {
+		return fmt.Errorf("expected %s, got %T", p.Type, v)
+	}
+	if len(p.Enum) == 0 {
+		return nil
+	}
+	for _, allowed := range p.Enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(v) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v is not one of %v", v, p.Enum)
+}
+
+// matchesParamType reports whether v, as built in Go or decoded from JSON,
+// has the parameter type typ. Unknown types accept any value.
+func matchesParamType(typ string, v any) bool {
+	switch typ {
+	case GenAIParamString:
+		_, ok := v.(string)
+		return ok
+	case GenAIParamBoolean:
+		_, ok := v.(bool)
+		return ok
+	case GenAIParamInteger:
+		switch n := v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return true
+		case float64:
+			return n == math.Trunc(n)
+		case float32:
+			return float64(n) == math.Trunc(float64(n))
+		case json.Number:
+			_, err := n.Int64()
+			return err == nil
+		}
+		return false
+	case GenAIParamNumber:
+		switch n := v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			return true
+		case json.Number:
+			_, err := n.Float64()
+			return err == nil
+		}
+		return false
+	case GenAIParamArray:
+		kind := reflect.ValueOf(v).Kind()
+		return kind == reflect.Slice || kind == reflect.Array
+	case GenAIParamObject:
+		rv := reflect.ValueOf(v)
+		return rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String
+	}
+	return true
+}
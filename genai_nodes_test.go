@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGenAIWorkflowNodes(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/v1/genai/nodes": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			_, _ = w.Write([]byte(`{"code":"OK","data":{"nodes":[
+				{"type":"ChunkNode","description":"Splits documents","components":[
+					{"name":"DocumentSplitter","parameters":[
+						{"name":"enable_level_based_split","type":"boolean","default":true},
+						{"name":"chunk_size","type":"integer","default":512},
+						{"name":"mode","type":"string","required":true,"enum":["token","sentence"]},
+						{"name":"separators","type":"array"}
+					]}
+				]},
+				{"type":"WriteNode","components":[]}
+			]}}`))
+		},
+	})
+
+	resp, err := client.ListGenAIWorkflowNodes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resp.Nodes, 2)
+	chunk := resp.Node("ChunkNode")
+	require.NotNil(t, chunk)
+	require.Nil(t, resp.Node("OCRNode"))
+
+	params := chunk.DefaultParameters()
+	require.Equal(t, map[string]map[string]any{
+		"DocumentSplitter": {"enable_level_based_split": true, "chunk_size": float64(512)},
+	}, params)
+	require.ErrorContains(t, chunk.ValidateParameters(params), "DocumentSplitter.mode is required")
+
+	params["DocumentSplitter"]["mode"] = "sentence"
+	params["DocumentSplitter"]["separators"] = []string{"\n\n"}
+	require.NoError(t, resp.ValidateStep(GenAIWorkflowStep{Node: "ChunkNode", Parameters: params}))
+
+	params["DocumentSplitter"]["chunk_size"] = 1.5
+	params["DocumentSplitter"]["mode"] = "word"
+	params["Other"] = map[string]any{}
+	err = chunk.ValidateParameters(params)
+	require.ErrorContains(t, err, "DocumentSplitter.chunk_size: expected integer")
+	require.ErrorContains(t, err, "word is not one of")
+	require.ErrorContains(t, err, `no component "Other"`)
+
+	err = resp.ValidateWorkflow(&CatalogWorkflow{Nodes: []CatalogWorkflowNode{
+		{ID: "WriteNode_1", Type: "WriteNode", InitParameters: map[string]map[string]any{}},
+		{ID: "OCR_2", Type: "OCRNode"},
+	}})
+	require.ErrorContains(t, err, `node OCR_2: unknown node type "OCRNode"`)
+	require.NotContains(t, err.Error(), "WriteNode_1")
+}
@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -877,3 +879,104 @@ func TestListWorkflowJobs_WithCombinedFilters(t *testing.T) {
 		t.Logf("No jobs found, skipping combined filter test")
 	}
 }
+
+func TestGetWorkflow_EmptyID(t *testing.T) {
+	t.Parallel()
+	client := &RawClient{}
+	resp, err := client.GetWorkflow(context.Background(), "  ")
+	require.Error(t, err)
+	require.Nil(t, resp)
+	require.Contains(t, err.Error(), "workflowID cannot be empty")
+}
+
+func TestWorkflowCRUD_Mock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var updated WorkflowMetadata
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/v1/genai/workflow/wf-1": func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				writeEnvelope(w, WorkflowResponse{ID: "wf-1", Name: "docs", TargetVolumeID: "vol-2"})
+			case http.MethodPut:
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+				writeEnvelope(w, WorkflowResponse{ID: "wf-1", Name: updated.Name, TargetVolumeID: updated.TargetVolumeID})
+			case http.MethodDelete:
+				writeEnvelope(w, WorkflowDeleteResponse{ID: "wf-1"})
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		},
+		"/v1/genai/workflow": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, "docs", r.URL.Query().Get("name"))
+			require.Equal(t, "2", r.URL.Query().Get("page"))
+			require.Equal(t, "10", r.URL.Query().Get("page_size"))
+			writeEnvelope(w, WorkflowListResponse{
+				Workflows: []WorkflowResponse{{ID: "wf-1", Name: "docs"}},
+				Total:     11,
+			})
+		},
+	})
+
+	wf, err := client.GetWorkflow(ctx, "wf-1")
+	require.NoError(t, err)
+	require.Equal(t, "docs", wf.Name)
+	require.Equal(t, "vol-2", wf.TargetVolumeID)
+
+	wf, err = client.UpdateWorkflow(ctx, "wf-1", &WorkflowMetadata{
+		Name:           "docs-v2",
+		TargetVolumeID: "vol-3",
+		Workflow: &CatalogWorkflow{
+			Nodes: []CatalogWorkflowNode{{ID: "n1", Type: "RootNode"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "docs-v2", wf.Name)
+	require.Equal(t, "vol-3", wf.TargetVolumeID)
+	require.NotNil(t, updated.SourceVolumeIDs)
+	require.NotNil(t, updated.ProcessMode)
+	require.NotNil(t, updated.Workflow.Nodes[0].InitParameters)
+
+	list, err := client.ListWorkflows(ctx, &WorkflowListRequest{Name: "docs", Page: 2, PageSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 11, list.Total)
+	require.Len(t, list.Workflows, 1)
+
+	del, err := client.DeleteWorkflow(ctx, "wf-1")
+	require.NoError(t, err)
+	require.Equal(t, "wf-1", del.ID)
+}
+
+func TestWorkflowRunControl_Mock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/v1/genai/workflow/wf-1/run": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			var req WorkflowRunRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, []string{"f1"}, req.FileIDs)
+			writeEnvelope(w, WorkflowRunResponse{JobID: "job-1", FileIDs: req.FileIDs})
+		},
+		"/v1/genai/jobs/job-1/stop": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			writeEnvelope(w, map[string]string{"status": "stopped"})
+		},
+	})
+
+	run, err := client.RunWorkflow(ctx, "wf-1", &WorkflowRunRequest{FileIDs: []string{"f1"}})
+	require.NoError(t, err)
+	require.Equal(t, "job-1", run.JobID)
+
+	stop, err := client.StopWorkflowJob(ctx, "job-1")
+	require.NoError(t, err)
+	require.Equal(t, "job-1", stop.JobID)
+	require.Equal(t, "stopped", stop.Status)
+
+	_, err = client.RerunWorkflowJobFiles(ctx, "job-1", &WorkflowJobRerunRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "file_ids is required")
+}
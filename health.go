@@ -3,18 +3,78 @@ package sdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Readiness polling starts fast and backs off, so that a service that is
+	// already up is detected at once without hammering one that is not.
+	minReadinessPollInterval = 100 * time.Millisecond
+	maxReadinessPollInterval = 2 * time.Second
 )
 
 // HealthStatus mirrors the response from /healthz endpoint.
 type HealthStatus struct {
 	Status string `json:"status"` // Status is typically "ok" when the service is healthy
+	// Components reports the health of the dependencies of the service,
+	// such as the database or the object store, when the service lists them.
+	Components map[string]ComponentHealth `json:"components,omitempty"`
+}
+
+// ComponentHealth is the health of one dependency of the service.
+type ComponentHealth struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Healthy reports whether the service and all its listed components are ok.
+func (s *HealthStatus) Healthy() bool {
+	if s == nil || !isHealthyStatus(s.Status) {
+		return false
+	}
+	for _, component := range s.Components {
+		if !isHealthyStatus(component.Status) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnhealthyComponents returns the sorted names of the components that are
+// not ok.
+func (s *HealthStatus) UnhealthyComponents() []string {
+	if s == nil {
+		return nil
+	}
+	var names []string
+	for name, component := range s.Components {
+		if !isHealthyStatus(component.Status) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isHealthyStatus(status string) bool {
+	switch strings.ToLower(status) {
+	case "ok", "healthy", "up":
+		return true
+	}
+	return false
 }
 
 // HealthCheck queries the /healthz endpoint to check service health.
 //
 // This is useful for monitoring and health checks. Returns the health status
-// of the catalog service.
+// of the catalog service. When the service answers with an error status and
+// a health report, such as 503 with a failing component, both the report and
+// the *HTTPError are returned.
 //
 // Example:
 //
@@ -29,6 +89,13 @@ func (c *RawClient) HealthCheck(ctx context.Context, opts ...CallOption) (*Healt
 	callOpts := newCallOptions(opts...)
 	resp, err := c.doRaw(ctx, http.MethodGet, "/healthz", nil, callOpts, nil)
 	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) {
+			var status HealthStatus
+			if json.Unmarshal(httpErr.Body, &status) == nil && status.Status != "" {
+				return &status, err
+			}
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -39,3 +106,68 @@ func (c *RawClient) HealthCheck(ctx context.Context, opts ...CallOption) (*Healt
 	}
 	return &status, nil
 }
+
+// WaitUntilReady polls the /healthz endpoint until the service and all its
+// components are healthy, so that a service can gate its startup on the
+// backend being reachable.
+//
+// Errors while polling, such as connection refusals during a deployment, do
+// not stop the wait. A timeout of zero or less waits as long as ctx allows.
+//
+// Parameters:
+//   - ctx: context controlling the wait
+//   - timeout: the maximum time to wait
+//   - opts: optional call options applied to each health check
+//
+// Returns:
+//   - *HealthStatus: the last health report received, if any
+//   - error: nil once ready; otherwise an error wrapping the context error
+//     and describing the last failure
+//
+// Example:
+//
+//	if _, err := client.WaitUntilReady(ctx, time.Minute); err != nil {
+//		log.Fatalf("backend not ready: %v", err)
+//	}
+func (c *RawClient) WaitUntilReady(ctx context.Context, timeout time.Duration, opts ...CallOption) (*HealthStatus, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var (
+		last    *HealthStatus
+		lastErr error
+	)
+	interval := minReadinessPollInterval
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			switch {
+			case lastErr != nil:
+				return last, fmt.Errorf("service not ready (last error: %v): %w", lastErr, ctx.Err())
+			case last != nil:
+				return last, fmt.Errorf("service not ready, status %s, unhealthy components %v: %w", last.Status, last.UnhealthyComponents(), ctx.Err())
+			}
+			return nil, fmt.Errorf("service not ready: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		status, err := c.HealthCheck(ctx, opts...)
+		if err != nil && ctx.Err() != nil {
+			continue
+		}
+		if status != nil {
+			last = status
+		}
+		lastErr = err
+		if err == nil && status.Healthy() {
+			return status, nil
+		}
+		timer.Reset(interval)
+		interval = min(interval*2, maxReadinessPollInterval)
+	}
+}
@@ -0,0 +1,64 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheckComponents(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/healthz": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerContentType, mimeJSON)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"degraded","components":{"db":{"status":"ok"},"s3":{"status":"down","message":"timeout"}}}`))
+		},
+	})
+
+	status, err := client.HealthCheck(context.Background())
+	var httpErr *HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	require.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	require.False(t, status.Healthy())
+	require.Equal(t, []string{"s3"}, status.UnhealthyComponents())
+	require.Equal(t, "timeout", status.Components["s3"].Message)
+}
+
+func TestWaitUntilReady(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/healthz": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerContentType, mimeJSON)
+			if calls.Add(1) < 3 {
+				_, _ = w.Write([]byte(`{"status":"ok","components":{"db":{"status":"starting"}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"ok","components":{"db":{"status":"ok"}}}`))
+		},
+	})
+
+	status, err := client.WaitUntilReady(context.Background(), 5*time.Second)
+	require.NoError(t, err)
+	require.True(t, status.Healthy())
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestWaitUntilReadyTimeout(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/healthz": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	})
+
+	_, err := client.WaitUntilReady(context.Background(), 300*time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "502")
+}
@@ -0,0 +1,46 @@
+package sdk
+
+import "net/http"
+
+// RequestHandler sends an HTTP request and returns the raw response.
+//
+// It is the "next" step handed to an Interceptor; calling it continues the
+// chain and eventually performs the network round trip.
+type RequestHandler func(req *http.Request) (*http.Response, error)
+
+// Interceptor observes or modifies an outgoing request and its response.
+//
+// An interceptor receives the fully prepared *http.Request (headers, query
+// and body already set, context available via req.Context()) and must call
+// next to continue the chain, unless it wants to short-circuit the request
+// with its own response or error. The response returned by next is the raw
+// HTTP response: status checks and envelope decoding happen after the
+// interceptor chain returns.
+//
+// Interceptors apply to every request issued by the client, including
+// multipart uploads, streaming responses, downloads and LLM proxy calls.
+//
+// Example:
+//
+//	tracing := func(req *http.Request, next sdk.RequestHandler) (*http.Response, error) {
+//		req.Header.Set("traceparent", traceParentFrom(req.Context()))
+//		start := time.Now()
+//		resp, err := next(req)
+//		log.Printf("%s %s took %s", req.Method, req.URL.Path, time.Since(start))
+//		return resp, err
+//	}
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithInterceptor(tracing))
+type Interceptor func(req *http.Request, next RequestHandler) (*http.Response, error)
+
+// chainInterceptors wraps final with interceptors so that the first
+// interceptor is the outermost one.
+func chainInterceptors(interceptors []Interceptor, final RequestHandler) RequestHandler {
+	handler := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		ic, next := interceptors[i], handler
+		handler = func(req *http.Request) (*http.Response, error) {
+			return ic(req, next)
+		}
+	}
+	return handler
+}
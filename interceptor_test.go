@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterceptorsSeeEveryRequestPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "injected", r.Header.Get("X-Injected"))
+		w.Header().Set(headerContentType, mimeJSON)
+		if strings.HasPrefix(r.URL.Path, "/llm-proxy/") {
+			_, _ = w.Write([]byte(`{"id":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":"OK","data":{}}`))
+	}))
+	defer server.Close()
+
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	record := func(req *http.Request, next RequestHandler) (*http.Response, error) {
+		req.Header.Set("X-Injected", "injected")
+		resp, err := next(req)
+		if err == nil {
+			mu.Lock()
+			paths = append(paths, req.URL.Path)
+			mu.Unlock()
+		}
+		return resp, err
+	}
+
+	client, err := NewRawClient(server.URL, "key", WithInterceptor(record))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.ListCatalogs(ctx)
+	require.NoError(t, err)
+	_, err = client.UploadLocalFile(ctx, strings.NewReader("a,b\n"), "a.csv", []FileMeta{{Filename: "a.csv", Path: "/"}})
+	require.NoError(t, err)
+	_, err = client.GetLLMSession(ctx, 1)
+	require.NoError(t, err)
+	stream, err := client.DownloadTableData(ctx, &TableDownloadDataRequest{ID: 1})
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+
+	require.Equal(t, []string{
+		"/catalog/list",
+		"/connectors/file/upload",
+		"/llm-proxy/api/sessions/1",
+		"/catalog/table/download_data",
+	}, paths)
+}
+
+func TestInterceptorOrderAndShortCircuit(t *testing.T) {
+	var order []string
+	outer := func(req *http.Request, next RequestHandler) (*http.Response, error) {
+		order = append(order, "outer-before")
+		resp, err := next(req)
+		order = append(order, "outer-after")
+		return resp, err
+	}
+	inner := func(req *http.Request, next RequestHandler) (*http.Response, error) {
+		order = append(order, "inner")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	}
+	final := func(req *http.Request) (*http.Response, error) {
+		t.Fatal("final handler must not be called")
+		return nil, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+	_, err = chainInterceptors([]Interceptor{outer, inner}, final)(req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"outer-before", "inner", "outer-after"}, order)
+}
+
+func TestWithSpecialUserKeepsInterceptors(t *testing.T) {
+	noop := func(req *http.Request, next RequestHandler) (*http.Response, error) { return next(req) }
+	client, err := NewRawClient("http://example.com", "key", WithInterceptor(noop), WithInterceptor(nil))
+	require.NoError(t, err)
+	require.Len(t, client.interceptors, 1)
+	require.Len(t, client.WithSpecialUser("other").interceptors, 1)
+}
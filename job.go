@@ -0,0 +1,275 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultJobPollInterval is the interval between polls of Job.Wait when
+// none is given.
+const defaultJobPollInterval = 2 * time.Second
+
+// Job is a handle on a long-running server-side operation, such as a task
+// or a workflow job, giving every kind of operation the same way to be
+// followed and cancelled. R is the type of its result.
+//
+// The state of every job is reported as a TaskStatus. Poll makes one
+// request and Wait polls until the job reaches a terminal state; both keep
+// the last result received, which Result returns.
+type Job[R any] interface {
+	// ID returns the server-side ID of the job.
+	ID() string
+	// Poll fetches the current state of the job once.
+	Poll(ctx context.Context) (TaskStatus, error)
+	// Wait polls the job until it reaches a terminal state or ctx is done.
+	// A job that does not succeed yields an error.
+	Wait(ctx context.Context) (R, error)
+	// Cancel asks the server to stop the job. Jobs that cannot be
+	// cancelled return an error matching errors.ErrUnsupported.
+	Cancel(ctx context.Context) error
+	// Result returns the last result received, or the zero R before the
+	// first successful poll.
+	Result() R
+}
+
+var (
+	_ Job[*TaskInfoResponse]          = (*TaskJob)(nil)
+	_ Job[*GenAIGetJobDetailResponse] = (*GenAIJob)(nil)
+)
+
+// JobError is returned by Job.Wait when a job ends without succeeding.
+type JobError struct {
+	// Kind is the kind of job, such as "task" or "workflow job".
+	Kind   string
+	ID     string
+	Status TaskStatus
+}
+
+func (e *JobError) Error() string {
+	return fmt.Sprintf("sdk: %s %s ended as %s", e.Kind, e.ID, e.Status)
+}
+
+// waitForJob calls poll until it reports a terminal status. Transient
+// errors do not stop the polling; the last one is reported if ctx is done
+// first. Errors that polling again cannot fix, such as ErrNotFound or
+// ErrPermissionDenied, are returned at once.
+func waitForJob(ctx context.Context, kind, id string, interval time.Duration, poll func(context.Context) (TaskStatus, error)) (TaskStatus, error) {
+	if interval <= 0 {
+		interval = defaultJobPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	status := TaskStatusUnknown
+	var lastErr error
+	for {
+		s, err := poll(ctx)
+		if err == nil {
+			status, lastErr = s, nil
+			if s.IsTerminal() {
+				return s, nil
+			}
+		} else if isPermanentError(err) {
+			return status, fmt.Errorf("poll %s %s: %w", kind, id, err)
+		} else {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return status, fmt.Errorf("%s %s did not reach a terminal state (last error: %v): %w", kind, id, lastErr, ctx.Err())
+			}
+			return status, fmt.Errorf("%s %s did not reach a terminal state, last status %s: %w", kind, id, status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// TaskJob is a Job for a task, such as a load task. Create one with
+// NewTaskJob, or with StartLoadTask for a new load task.
+type TaskJob struct {
+	client   *SDKClient
+	id       TaskID
+	load     bool
+	interval time.Duration
+	opts     []CallOption
+
+	mu   sync.Mutex
+	last *TaskInfoResponse
+}
+
+// NewTaskJob returns a handle on the existing task taskID. Wait polls it
+// every pollInterval (2 seconds if <= 0); opts apply to every request.
+//
+// Example:
+//
+//	job := sdkClient.NewTaskJob(taskID, 5*time.Second)
+//	task, err := job.Wait(ctx)
+func (c *SDKClient) NewTaskJob(taskID TaskID, pollInterval time.Duration, opts ...CallOption) *TaskJob {
+	return &TaskJob{client: c, id: taskID, interval: pollInterval, opts: opts}
+}
+
+// StartLoadTask creates a load task and returns a handle on it, without
+// waiting for it to finish as RunLoadTaskAndWait does. Wait returns a
+// *LoadTaskError if the task does not succeed or some files fail to load.
+//
+// Example:
+//
+//	job, err := sdkClient.StartLoadTask(ctx, req, 5*time.Second)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println("started task", job.ID())
+//	task, err := job.Wait(ctx)
+func (c *SDKClient) StartLoadTask(ctx context.Context, req *LoadTaskCreateRequest, pollInterval time.Duration, opts ...CallOption) (*TaskJob, error) {
+	created, err := c.raw.CreateLoadTask(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TaskJob{client: c, id: created.TaskID, load: true, interval: pollInterval, opts: opts}, nil
+}
+
+// ID returns the task ID.
+func (j *TaskJob) ID() string { return strconv.FormatInt(int64(j.id), 10) }
+
+// TaskID returns the task ID.
+func (j *TaskJob) TaskID() TaskID { return j.id }
+
+// Poll fetches the task once.
+func (j *TaskJob) Poll(ctx context.Context) (TaskStatus, error) {
+	var task *TaskInfoResponse
+	var err error
+	if j.load {
+		task, err = j.client.raw.GetLoadTask(ctx, j.id, j.opts...)
+	} else {
+		task, err = j.client.raw.GetTask(ctx, &TaskInfoRequest{TaskID: j.id}, j.opts...)
+	}
+	if err != nil {
+		return TaskStatusUnknown, err
+	}
+	j.mu.Lock()
+	j.last = task
+	j.mu.Unlock()
+	return task.Status, nil
+}
+
+// Wait polls the task until it finishes. A task that does not succeed
+// yields a *JobError, or a *LoadTaskError for a load task started with
+// StartLoadTask, which also fails when some files were not loaded.
+func (j *TaskJob) Wait(ctx context.Context) (*TaskInfoResponse, error) {
+	if j.id == 0 {
+		return nil, invalidArgument("task_id", "task_id is required")
+	}
+	status, err := waitForJob(ctx, "task", j.ID(), j.interval, j.Poll)
+	task := j.Result()
+	if err != nil {
+		return task, err
+	}
+	if j.load {
+		failures := loadFailures(task)
+		if status != TaskStatusSucceeded || len(failures) > 0 {
+			return task, &LoadTaskError{TaskID: j.id, Status: status, Failures: failures}
+		}
+		return task, nil
+	}
+	if status != TaskStatusSucceeded {
+		return task, &JobError{Kind: "task", ID: j.ID(), Status: status}
+	}
+	return task, nil
+}
+
+// Cancel cancels a load task started with StartLoadTask. Other tasks have
+// no cancel endpoint, so for handles made by NewTaskJob it returns an error
+// matching errors.ErrUnsupported without sending a request.
+func (j *TaskJob) Cancel(ctx context.Context) error {
+	if !j.load {
+		return fmt.Errorf("cancel task %s: %w", j.ID(), errors.ErrUnsupported)
+	}
+	_, err := j.client.raw.CancelLoadTask(ctx, &LoadTaskCancelRequest{TaskID: j.id}, j.opts...)
+	return err
+}
+
+// Result returns the task as last polled.
+func (j *TaskJob) Result() *TaskInfoResponse {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.last
+}
+
+// GenAIJob is a Job for a workflow job, as started by RunWorkflow. Its
+// result is the job detail with the status of each file. Create one with
+// NewGenAIJob.
+type GenAIJob struct {
+	client   *SDKClient
+	id       string
+	interval time.Duration
+	opts     []CallOption
+
+	mu   sync.Mutex
+	last *GenAIGetJobDetailResponse
+}
+
+// NewGenAIJob returns a handle on the workflow job jobID. Wait polls it
+// every pollInterval (2 seconds if <= 0); opts apply to every request.
+//
+// Example:
+//
+//	run, err := client.RunWorkflow(ctx, workflowID, nil)
+//	if err != nil {
+//		return err
+//	}
+//	detail, err := sdkClient.NewGenAIJob(run.JobID, 5*time.Second).Wait(ctx)
+func (c *SDKClient) NewGenAIJob(jobID string, pollInterval time.Duration, opts ...CallOption) *GenAIJob {
+	return &GenAIJob{client: c, id: jobID, interval: pollInterval, opts: opts}
+}
+
+// ID returns the workflow job ID.
+func (j *GenAIJob) ID() string { return j.id }
+
+// Poll fetches the job detail once.
+func (j *GenAIJob) Poll(ctx context.Context) (TaskStatus, error) {
+	detail, err := j.client.raw.GetGenAIJob(ctx, j.id, j.opts...)
+	if err != nil {
+		return TaskStatusUnknown, err
+	}
+	j.mu.Lock()
+	j.last = detail
+	j.mu.Unlock()
+	return detail.JobStatus(), nil
+}
+
+// Wait polls the job until it finishes. A job that does not succeed
+// yields a *JobError; the detail lists the failed files.
+func (j *GenAIJob) Wait(ctx context.Context) (*GenAIGetJobDetailResponse, error) {
+	if strings.TrimSpace(j.id) == "" {
+		return nil, invalidArgument("job_id", "job_id is required")
+	}
+	status, err := waitForJob(ctx, "workflow job", j.id, j.interval, j.Poll)
+	detail := j.Result()
+	if err != nil {
+		return detail, err
+	}
+	if status != TaskStatusSucceeded {
+		return detail, &JobError{Kind: "workflow job", ID: j.id, Status: status}
+	}
+	return detail, nil
+}
+
+// Cancel stops the workflow job.
+func (j *GenAIJob) Cancel(ctx context.Context) error {
+	_, err := j.client.raw.StopWorkflowJob(ctx, j.id, j.opts...)
+	return err
+}
+
+// Result returns the job detail as last polled.
+func (j *GenAIJob) Result() *GenAIGetJobDetailResponse {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.last
+}
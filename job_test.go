@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskJob(t *testing.T) {
+	t.Parallel()
+	var polls atomic.Int32
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "12", r.URL.Query().Get("task_id"))
+			status := TaskStatusRunning
+			if polls.Add(1) >= 3 {
+				status = TaskStatusSucceeded
+			}
+			writeEnvelope(w, TaskInfoResponse{ID: "12", Status: status})
+		},
+	}))
+	var job Job[*TaskInfoResponse] = client.NewTaskJob(12, time.Millisecond)
+	require.Equal(t, "12", job.ID())
+	require.Nil(t, job.Result())
+
+	status, err := job.Poll(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, TaskStatusRunning, status)
+	require.Equal(t, TaskStatusRunning, job.Result().Status)
+
+	task, err := job.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, TaskStatusSucceeded, task.Status)
+	require.Same(t, task, job.Result())
+	require.EqualValues(t, 3, polls.Load())
+}
+
+func TestGenAIJob(t *testing.T) {
+	t.Parallel()
+	var polls, stops atomic.Int32
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/v1/genai/jobs/job-1": func(w http.ResponseWriter, r *http.Request) {
+			status := TaskStatusRunning
+			if polls.Add(1) >= 2 {
+				status = TaskStatusFailed
+			}
+			writeEnvelope(w, GenAIGetJobDetailResponse{Status: status.String(), Files: []GenAIWorkflowJobFileResponse{
+				{FileID: "f1", FileStatus: status},
+			}})
+		},
+		"/v1/genai/jobs/job-1/stop": func(w http.ResponseWriter, r *http.Request) {
+			stops.Add(1)
+			writeEnvelope(w, WorkflowJobStopResponse{JobID: "job-1", Status: "stopped"})
+		},
+	}))
+	job := client.NewGenAIJob("job-1", time.Millisecond)
+
+	detail, err := job.Wait(context.Background())
+	var jobErr *JobError
+	require.ErrorAs(t, err, &jobErr)
+	require.Equal(t, &JobError{Kind: "workflow job", ID: "job-1", Status: TaskStatusFailed}, jobErr)
+	require.Len(t, detail.FailedFiles(), 1)
+
+	require.NoError(t, job.Cancel(context.Background()))
+	require.EqualValues(t, 1, stops.Load())
+}
+
+func TestJobWaitTimeout(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TaskInfoResponse{ID: "12", Status: TaskStatusPending})
+		},
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	task, err := client.NewTaskJob(12, time.Millisecond).Wait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, TaskStatusPending, task.Status)
+}
+
+func TestJobWaitStopsOnPermanentError(t *testing.T) {
+	t.Parallel()
+	var polls atomic.Int32
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			if polls.Add(1) == 1 {
+				writeEnvelopeError(w, "ErrInternal", "try again")
+				return
+			}
+			writeEnvelopeError(w, "ErrNotFound", "task 12 not found")
+		},
+	}))
+
+	// Without a deadline, Wait must not keep polling a task that is gone.
+	_, err := client.NewTaskJob(12, time.Millisecond).Wait(context.Background())
+	require.ErrorIs(t, err, ErrNotFound)
+	require.EqualValues(t, 2, polls.Load())
+}
+
+func TestTaskJobCancel(t *testing.T) {
+	t.Parallel()
+	var cancels atomic.Int32
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/task/load/create": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, LoadTaskCreateResponse{TaskID: 9})
+		},
+		"/task/load/cancel": func(w http.ResponseWriter, r *http.Request) {
+			cancels.Add(1)
+			writeEnvelope(w, LoadTaskCancelResponse{})
+		},
+	}))
+	ctx := context.Background()
+
+	err := client.NewTaskJob(12, time.Millisecond).Cancel(ctx)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+	require.Zero(t, cancels.Load())
+
+	job, err := client.StartLoadTask(ctx, &LoadTaskCreateRequest{
+		Name:   "orders",
+		Source: LoadTaskSourceConfig{ConnectorID: 12, URIs: []string{"s3://bucket/orders.csv"}},
+		Target: LoadTaskTargetConfig{Table: &TableConfig{DatabaseID: 123, TableID: 456}},
+	}, time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, job.Cancel(ctx))
+	require.EqualValues(t, 1, cancels.Load())
+}
@@ -0,0 +1,185 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// LazyTree is a catalog tree whose nodes are loaded when they are
+// expanded, instead of all at once as GetCatalogTree does. Create one with
+// NewLazyTree.
+//
+// Expanded nodes keep their children in NodeList, so each node is loaded
+// at most once; Refresh forgets them. A LazyTree is safe for concurrent
+// use.
+type LazyTree struct {
+	client *SDKClient
+	opts   []CallOption
+	types  []string
+
+	mu       sync.Mutex
+	roots    []*TreeNode
+	rootsOK  bool
+	expanded map[*TreeNode]bool
+}
+
+// NewLazyTree returns a catalog tree that loads the catalogs on the first
+// call to Roots and the children of a node when it is expanded. opts apply
+// to every request; WithTreeNodeTypes limits the children of databases.
+//
+// Example:
+//
+//	tree := sdkClient.NewLazyTree(sdk.WithTreeNodeTypes(sdk.NodeTypeTable))
+//	catalogs, err := tree.Roots(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	for _, catalog := range catalogs {
+//		databases, err := tree.Expand(ctx, catalog)
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Printf("%s: %d databases\n", catalog.Name, len(databases))
+//	}
+func (c *SDKClient) NewLazyTree(opts ...CallOption) *LazyTree {
+	return &LazyTree{
+		client:   c,
+		opts:     opts,
+		types:    newCallOptions(opts...).treeTypes,
+		expanded: make(map[*TreeNode]bool),
+	}
+}
+
+// Roots returns the catalogs, loading them on the first call.
+func (t *LazyTree) Roots(ctx context.Context) ([]*TreeNode, error) {
+	t.mu.Lock()
+	if t.rootsOK {
+		roots := t.roots
+		t.mu.Unlock()
+		return roots, nil
+	}
+	t.mu.Unlock()
+
+	var roots []*TreeNode
+	opts := append(t.opts[:len(t.opts):len(t.opts)], WithTreeDepth(1))
+	for token := ""; ; {
+		resp, err := t.client.raw.GetCatalogTree(ctx, append(opts, WithTreeContinuation(token))...)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, resp.Tree...)
+		if resp.ContinuationToken == "" || resp.ContinuationToken == token {
+			break
+		}
+		token = resp.ContinuationToken
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.rootsOK {
+		t.roots, t.rootsOK = roots, true
+	}
+	return t.roots, nil
+}
+
+// Expand returns the children of node, loading them on the first call: the
+// databases of a catalog, or the tables and volumes of a database. Tables
+// and volumes have no children.
+func (t *LazyTree) Expand(ctx context.Context, node *TreeNode) ([]*TreeNode, error) {
+	if node == nil {
+		return nil, ErrNilRequest
+	}
+	t.mu.Lock()
+	if t.expanded[node] {
+		children := node.NodeList
+		t.mu.Unlock()
+		return children, nil
+	}
+	t.mu.Unlock()
+
+	var children []*TreeNode
+	var err error
+	switch node.Typ {
+	case NodeTypeCatalog:
+		children, err = t.loadDatabases(ctx, node)
+	case NodeTypeDatabase:
+		children, err = t.loadDatabaseChildren(ctx, node)
+	case NodeTypeTable, NodeTypeVolume:
+	default:
+		err = fmt.Errorf("sdk: cannot expand a node of type %q", node.Typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.expanded[node] {
+		node.NodeList = children
+		t.expanded[node] = true
+	}
+	return node.NodeList, nil
+}
+
+// Expanded reports whether the children of node have been loaded.
+func (t *LazyTree) Expanded(node *TreeNode) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.expanded[node]
+}
+
+// Refresh forgets the loaded nodes, so that the next calls load them
+// again.
+func (t *LazyTree) Refresh() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.roots, t.rootsOK = nil, false
+	t.expanded = make(map[*TreeNode]bool)
+}
+
+func (t *LazyTree) loadDatabases(ctx context.Context, node *TreeNode) ([]*TreeNode, error) {
+	id, err := strconv.ParseInt(node.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("sdk: invalid catalog id %q: %w", node.ID, err)
+	}
+	resp, err := t.client.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: CatalogID(id)}, t.opts...)
+	if err != nil {
+		return nil, err
+	}
+	children := make([]*TreeNode, 0, len(resp.List))
+	for _, db := range resp.List {
+		children = append(children, &TreeNode{
+			Typ:         NodeTypeDatabase,
+			ID:          strconv.FormatInt(int64(db.DatabaseID), 10),
+			Name:        db.DatabaseName,
+			Description: db.Comment,
+			Reserved:    db.Reserved,
+		})
+	}
+	return children, nil
+}
+
+func (t *LazyTree) loadDatabaseChildren(ctx context.Context, node *TreeNode) ([]*TreeNode, error) {
+	id, err := strconv.ParseInt(node.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("sdk: invalid database id %q: %w", node.ID, err)
+	}
+	pager := t.client.PageDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: DatabaseID(id), Types: t.types}, t.opts...)
+	var children []*TreeNode
+	for pager.Next() {
+		child := pager.Child()
+		children = append(children, &TreeNode{
+			Typ:         child.Typ,
+			ID:          child.ID,
+			Name:        child.Name,
+			Description: child.Comment,
+			Reserved:    child.Reserved,
+		})
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	return children, nil
+}
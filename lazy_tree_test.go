@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fullCatalogTree is the tree of a server that ignores the tree limits.
+func fullCatalogTree() CatalogTreeResponse {
+	return CatalogTreeResponse{Tree: []*TreeNode{{
+		Typ: NodeTypeCatalog, ID: "1", Name: "sales",
+		NodeList: []*TreeNode{{
+			Typ: NodeTypeDatabase, ID: "2", Name: "orders",
+			NodeList: []*TreeNode{
+				{Typ: NodeTypeTable, ID: "3", Name: "items"},
+				{Typ: NodeTypeVolume, ID: "vol-4", Name: "docs"},
+			},
+		}},
+	}}}
+}
+
+func TestGetCatalogTreeLimits(t *testing.T) {
+	t.Parallel()
+	var reqs []catalogTreeRequest
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/tree": func(w http.ResponseWriter, r *http.Request) {
+			var req catalogTreeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			reqs = append(reqs, req)
+			writeEnvelope(w, fullCatalogTree())
+		},
+	})
+	ctx := context.Background()
+
+	tree, err := client.GetCatalogTree(ctx, WithTreeDepth(2), WithTreeContinuation("abc"))
+	require.NoError(t, err)
+	require.Len(t, tree.Tree[0].NodeList, 1)
+	require.Nil(t, tree.Tree[0].NodeList[0].NodeList)
+
+	tree, err = client.GetCatalogTree(ctx, WithTreeNodeTypes(NodeTypeVolume))
+	require.NoError(t, err)
+	db := tree.Tree[0].NodeList[0]
+	require.Len(t, db.NodeList, 1)
+	require.Equal(t, "docs", db.NodeList[0].Name)
+
+	tree, err = client.GetCatalogTree(ctx)
+	require.NoError(t, err)
+	require.Len(t, tree.Tree[0].NodeList[0].NodeList, 2)
+
+	require.Equal(t, []catalogTreeRequest{
+		{MaxDepth: 2, ContinuationToken: "abc"},
+		{Types: []string{NodeTypeVolume}},
+		{},
+	}, reqs)
+}
+
+func TestLazyTree(t *testing.T) {
+	t.Parallel()
+	var childrenCalls atomic.Int32
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/tree": func(w http.ResponseWriter, r *http.Request) {
+			var req catalogTreeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, 1, req.MaxDepth)
+			resp := fullCatalogTree()
+			if req.ContinuationToken == "" {
+				resp.ContinuationToken = "next"
+			} else {
+				resp.Tree[0].ID, resp.Tree[0].Name = "5", "hr"
+			}
+			writeEnvelope(w, resp)
+		},
+		"/catalog/database/list": func(w http.ResponseWriter, r *http.Request) {
+			var req DatabaseListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, CatalogID(1), req.CatalogID)
+			writeEnvelope(w, DatabaseListResponse{List: []DatabaseResponse{{DatabaseID: 2, DatabaseName: "orders"}}})
+		},
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			childrenCalls.Add(1)
+			writeEnvelope(w, DatabaseChildrenResponseData{List: fullCatalogTreeChildren()})
+		},
+	}))
+	ctx := context.Background()
+	tree := client.NewLazyTree(WithTreeNodeTypes(NodeTypeTable))
+
+	roots, err := tree.Roots(ctx)
+	require.NoError(t, err)
+	require.Len(t, roots, 2)
+	require.Equal(t, "hr", roots[1].Name)
+	require.Nil(t, roots[0].NodeList)
+	require.False(t, tree.Expanded(roots[0]))
+
+	databases, err := tree.Expand(ctx, roots[0])
+	require.NoError(t, err)
+	require.Len(t, databases, 1)
+	require.Equal(t, NodeTypeDatabase, databases[0].Typ)
+	require.True(t, tree.Expanded(roots[0]))
+
+	tables, err := tree.Expand(ctx, databases[0])
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	require.Equal(t, "items", tables[0].Name)
+	_, err = tree.Expand(ctx, databases[0])
+	require.NoError(t, err)
+	require.EqualValues(t, 1, childrenCalls.Load())
+
+	leaves, err := tree.Expand(ctx, tables[0])
+	require.NoError(t, err)
+	require.Empty(t, leaves)
+}
+
+func fullCatalogTreeChildren() []DatabaseChildrenResponse {
+	return []DatabaseChildrenResponse{
+		{ID: "3", Name: "items", Typ: NodeTypeTable},
+		{ID: "vol-4", Name: "docs", Typ: NodeTypeVolume},
+	}
+}
@@ -0,0 +1,295 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// llmCompletionPath is the OpenAI-compatible chat completion endpoint of
+// LLM Proxy.
+const llmCompletionPath = "/v1/chat/completions"
+
+// CreateLLMCompletion sends an OpenAI-compatible chat completion request
+// through LLM Proxy.
+//
+// When req.SessionID is set, the last message of the request and the first
+// reply are recorded as an LLMChatMessage of that session, returned in
+// Recorded. A failed completion is recorded with the failed status. If the
+// completion succeeds but cannot be recorded, the response is returned
+// together with the error.
+//
+// Example:
+//
+//	resp, err := client.CreateLLMCompletion(ctx, &sdk.LLMCompletionRequest{
+//		Model: "gpt-4",
+//		Messages: []sdk.LLMCompletionMessage{
+//			{Role: sdk.LLMMessageRoleUser, Content: "Hello!"},
+//		},
+//		SessionID: &sessionID,
+//		UserID:    "user123",
+//		Source:    "my-app",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(resp.Choices[0].Message.Content)
+func (c *RawClient) CreateLLMCompletion(ctx context.Context, req *LLMCompletionRequest, opts ...CallOption) (*LLMCompletionResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	body := *req
+	body.Stream = false
+
+	var resp LLMCompletionResponse
+	if err := c.doLLMJSON(ctx, http.MethodPost, llmCompletionPath, &body, &resp, opts...); err != nil {
+		if req.SessionID != nil {
+			_, _ = c.recordLLMCompletion(ctx, req, req.Model, "", LLMMessageStatusFailed, opts)
+		}
+		return nil, err
+	}
+	if req.SessionID == nil {
+		return &resp, nil
+	}
+	var reply string
+	if len(resp.Choices) > 0 {
+		reply = resp.Choices[0].Message.Content
+	}
+	model := resp.Model
+	if model == "" {
+		model = req.Model
+	}
+	recorded, err := c.recordLLMCompletion(ctx, req, model, reply, LLMMessageStatusSuccess, opts)
+	if err != nil {
+		return &resp, fmt.Errorf("record completion: %w", err)
+	}
+	resp.Recorded = recorded
+	return &resp, nil
+}
+
+// LLMCompletionStream reads the chunks of a streamed chat completion.
+//
+// When the request chose a session, the exchange is recorded once the
+// stream ends: with the success status when the server completes it, the
+// failed status on a read error, or the aborted status when it is closed
+// early.
+type LLMCompletionStream struct {
+	// Header contains the HTTP response headers
+	Header http.Header
+	// StatusCode is the HTTP status code
+	StatusCode int
+
+	sse    *SSEStream[*LLMCompletionChunk]
+	client *RawClient
+	ctx    context.Context
+	req    *LLMCompletionRequest
+	opts   []CallOption
+
+	mu        sync.Mutex
+	model     string
+	content   strings.Builder
+	done      bool
+	recorded  *LLMChatMessage
+	recordErr error
+}
+
+// CreateLLMCompletionStream sends a chat completion request through LLM
+// Proxy and streams the reply as it is generated. The stream must be closed
+// by the caller.
+//
+// The session recording works as with CreateLLMCompletion; the recorded
+// message holds the whole reply and is available from Recorded once the
+// stream has ended.
+//
+// Example:
+//
+//	stream, err := client.CreateLLMCompletionStream(ctx, &sdk.LLMCompletionRequest{
+//		Model: "gpt-4",
+//		Messages: []sdk.LLMCompletionMessage{
+//			{Role: sdk.LLMMessageRoleUser, Content: "Tell me a story"},
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer stream.Close()
+//	for {
+//		chunk, err := stream.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		if len(chunk.Choices) > 0 {
+//			fmt.Print(chunk.Choices[0].Delta.Content)
+//		}
+//	}
+func (c *RawClient) CreateLLMCompletionStream(ctx context.Context, req *LLMCompletionRequest, opts ...CallOption) (*LLMCompletionStream, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	body := *req
+	body.Stream = true
+	payload, err := json.Marshal(&body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request body: %w", err)
+	}
+
+	callOpts := newCallOptions(opts...)
+	httpReq, err := c.newLLMRequest(ctx, http.MethodPost, llmCompletionPath, bytes.NewReader(payload), callOpts)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set(headerContentType, mimeJSON)
+	httpReq.Header.Set(headerAccept, "text/event-stream")
+
+	resp, err := c.send(c.streamHTTPClient(), httpReq, callOpts)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		err := llmResponseError(resp.StatusCode, data)
+		if req.SessionID != nil {
+			_, _ = c.recordLLMCompletion(ctx, req, req.Model, "", LLMMessageStatusFailed, opts)
+		}
+		return nil, err
+	}
+
+	return &LLMCompletionStream{
+		Header:     resp.Header,
+		StatusCode: resp.StatusCode,
+		sse:        NewSSEStream(ctx, resp, decodeLLMCompletionChunk, nil),
+		client:     c,
+		ctx:        ctx,
+		req:        &body,
+		opts:       opts,
+		model:      req.Model,
+	}, nil
+}
+
+// Next returns the next chunk of the reply. It returns io.EOF once the
+// completion is done.
+func (s *LLMCompletionStream) Next() (*LLMCompletionChunk, error) {
+	chunk, err := s.sse.Next()
+	if err == io.EOF {
+		s.finish(LLMMessageStatusSuccess)
+		return nil, io.EOF
+	}
+	if err != nil {
+		s.finish(LLMMessageStatusFailed)
+		return nil, err
+	}
+	s.mu.Lock()
+	if chunk.Model != "" {
+		s.model = chunk.Model
+	}
+	if len(chunk.Choices) > 0 {
+		s.content.WriteString(chunk.Choices[0].Delta.Content)
+	}
+	s.mu.Unlock()
+	return chunk, nil
+}
+
+// Content returns the reply received so far.
+func (s *LLMCompletionStream) Content() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.content.String()
+}
+
+// Recorded returns the message recorded in the session and the error of
+// the recording, if any. Both are nil until the stream has ended or when no
+// session was chosen.
+func (s *LLMCompletionStream) Recorded() (*LLMChatMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recorded, s.recordErr
+}
+
+// Close releases the underlying HTTP response body, recording the exchange
+// as aborted if the completion had not ended.
+func (s *LLMCompletionStream) Close() error {
+	if s == nil {
+		return nil
+	}
+	err := s.sse.Close()
+	s.finish(LLMMessageStatusAborted)
+	return err
+}
+
+// finish records the exchange with the given status the first time the
+// stream ends.
+func (s *LLMCompletionStream) finish(status LLMMessageStatus) {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return
+	}
+	s.done = true
+	model, reply := s.model, s.content.String()
+	s.mu.Unlock()
+	if s.req.SessionID == nil {
+		return
+	}
+
+	recorded, err := s.client.recordLLMCompletion(s.ctx, s.req, model, reply, status, s.opts)
+	s.mu.Lock()
+	s.recorded, s.recordErr = recorded, err
+	s.mu.Unlock()
+}
+
+// decodeLLMCompletionChunk parses a chunk event, ending the stream on the
+// "[DONE]" marker.
+func decodeLLMCompletionChunk(event *SSEEvent) (*LLMCompletionChunk, error) {
+	data := bytes.TrimSpace(event.Data)
+	if len(data) == 0 {
+		return nil, ErrSkipEvent
+	}
+	if string(data) == "[DONE]" {
+		return nil, io.EOF
+	}
+	var chunk LLMCompletionChunk
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return nil, fmt.Errorf("decode completion chunk: %w", err)
+	}
+	return &chunk, nil
+}
+
+// recordLLMCompletion stores the last message of req and the reply as a
+// chat message of the session of req.
+func (c *RawClient) recordLLMCompletion(ctx context.Context, req *LLMCompletionRequest, model, reply string, status LLMMessageStatus, opts []CallOption) (*LLMChatMessage, error) {
+	last := req.Messages[len(req.Messages)-1]
+	role := last.Role
+	if role == "" {
+		role = LLMMessageRoleUser
+	}
+	msg, err := c.CreateLLMChatMessage(ctx, &LLMChatMessageCreateRequest{
+		UserID:    req.UserID,
+		SessionID: req.SessionID,
+		Source:    req.Source,
+		Role:      role,
+		Content:   last.Content,
+		Model:     model,
+		Status:    status,
+		Response:  reply,
+		Tags:      req.Tags,
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("session %d: %w", *req.SessionID, err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,217 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// completionRecorder collects the chat messages recorded by the mock server.
+type completionRecorder struct {
+	mu   sync.Mutex
+	msgs []LLMChatMessageCreateRequest
+}
+
+func (r *completionRecorder) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var msg LLMChatMessageCreateRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&msg))
+		r.mu.Lock()
+		r.msgs = append(r.msgs, msg)
+		r.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(LLMChatMessage{ID: 7, Role: msg.Role, Content: msg.Content, Response: msg.Response, Status: msg.Status})
+	}
+}
+
+func (r *completionRecorder) messages() []LLMChatMessageCreateRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]LLMChatMessageCreateRequest(nil), r.msgs...)
+}
+
+func TestCreateLLMCompletion(t *testing.T) {
+	t.Parallel()
+	rec := &completionRecorder{}
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/llm-proxy/v1/chat/completions": func(w http.ResponseWriter, r *http.Request) {
+			raw, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NotContains(t, string(raw), "session")
+			require.NotContains(t, string(raw), "stream")
+			var req LLMCompletionRequest
+			require.NoError(t, json.Unmarshal(raw, &req))
+			require.Equal(t, "gpt-4", req.Model)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(LLMCompletionResponse{
+				ID:      "c1",
+				Model:   "gpt-4-0613",
+				Choices: []LLMCompletionChoice{{Message: LLMCompletionMessage{Role: LLMMessageRoleAssistant, Content: "Hi there"}, FinishReason: "stop"}},
+				Usage:   &LLMCompletionUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+			})
+		},
+		"/llm-proxy/api/chat-messages": rec.handler(t),
+	})
+
+	req := &LLMCompletionRequest{
+		Model: "gpt-4",
+		Messages: []LLMCompletionMessage{
+			{Role: LLMMessageRoleSystem, Content: "Be brief"},
+			{Role: LLMMessageRoleUser, Content: "Hello"},
+		},
+	}
+	resp, err := client.CreateLLMCompletion(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "Hi there", resp.Choices[0].Message.Content)
+	require.Equal(t, 5, resp.Usage.TotalTokens)
+	require.Nil(t, resp.Recorded)
+	require.Empty(t, rec.messages())
+
+	sessionID := int64(42)
+	req.SessionID = &sessionID
+	req.UserID = "u1"
+	req.Source = "app"
+	resp, err = client.CreateLLMCompletion(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), resp.Recorded.ID)
+	msgs := rec.messages()
+	require.Len(t, msgs, 1)
+	require.Equal(t, sessionID, *msgs[0].SessionID)
+	require.Equal(t, LLMMessageRoleUser, msgs[0].Role)
+	require.Equal(t, "Hello", msgs[0].Content)
+	require.Equal(t, "Hi there", msgs[0].Response)
+	require.Equal(t, "gpt-4-0613", msgs[0].Model)
+	require.Equal(t, LLMMessageStatusSuccess, msgs[0].Status)
+}
+
+func TestCreateLLMCompletion_Validation(t *testing.T) {
+	t.Parallel()
+	client := &RawClient{}
+	ctx := context.Background()
+
+	_, err := client.CreateLLMCompletion(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.CreateLLMCompletion(ctx, &LLMCompletionRequest{Model: "gpt-4"})
+	require.Error(t, err)
+	sessionID := int64(1)
+	_, err = client.CreateLLMCompletionStream(ctx, &LLMCompletionRequest{
+		Model:     "gpt-4",
+		Messages:  []LLMCompletionMessage{{Content: "hi"}},
+		SessionID: &sessionID,
+	})
+	require.ErrorContains(t, err, "user_id")
+}
+
+func TestCreateLLMCompletion_FailureRecorded(t *testing.T) {
+	t.Parallel()
+	rec := &completionRecorder{}
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/llm-proxy/v1/chat/completions": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"rate limited","type":"rate_limit","code":"429"}}`))
+		},
+		"/llm-proxy/api/chat-messages": rec.handler(t),
+	})
+
+	sessionID := int64(42)
+	_, err := client.CreateLLMCompletion(context.Background(), &LLMCompletionRequest{
+		Model:     "gpt-4",
+		Messages:  []LLMCompletionMessage{{Role: LLMMessageRoleUser, Content: "Hello"}},
+		SessionID: &sessionID,
+		UserID:    "u1",
+		Source:    "app",
+	})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, "rate limited", apiErr.Message)
+	msgs := rec.messages()
+	require.Len(t, msgs, 1)
+	require.Equal(t, LLMMessageStatusFailed, msgs[0].Status)
+}
+
+func TestCreateLLMCompletionStream(t *testing.T) {
+	t.Parallel()
+	rec := &completionRecorder{}
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/llm-proxy/v1/chat/completions": func(w http.ResponseWriter, r *http.Request) {
+			var req LLMCompletionRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.True(t, req.Stream)
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, part := range []string{"Once", " upon", " a time"} {
+				fmt.Fprintf(w, "data: {\"model\":\"gpt-4\",\"choices\":[{\"delta\":{\"content\":%q}}]}\n\n", part)
+			}
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		},
+		"/llm-proxy/api/chat-messages": rec.handler(t),
+	})
+
+	sessionID := int64(42)
+	stream, err := client.CreateLLMCompletionStream(context.Background(), &LLMCompletionRequest{
+		Model:     "gpt-4",
+		Messages:  []LLMCompletionMessage{{Role: LLMMessageRoleUser, Content: "Tell me a story"}},
+		SessionID: &sessionID,
+		UserID:    "u1",
+		Source:    "app",
+	})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	var chunks int
+	for {
+		_, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		chunks++
+	}
+	require.Equal(t, 3, chunks)
+	require.Equal(t, "Once upon a time", stream.Content())
+
+	recorded, err := stream.Recorded()
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	require.NoError(t, stream.Close())
+	msgs := rec.messages()
+	require.Len(t, msgs, 1)
+	require.Equal(t, "Once upon a time", msgs[0].Response)
+	require.Equal(t, LLMMessageStatusSuccess, msgs[0].Status)
+}
+
+func TestCreateLLMCompletionStream_ClosedEarly(t *testing.T) {
+	t.Parallel()
+	rec := &completionRecorder{}
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/llm-proxy/v1/chat/completions": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Once\"}}]}\n\n")
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\" upon\"}}]}\n\n")
+		},
+		"/llm-proxy/api/chat-messages": rec.handler(t),
+	})
+
+	sessionID := int64(42)
+	stream, err := client.CreateLLMCompletionStream(context.Background(), &LLMCompletionRequest{
+		Model:     "gpt-4",
+		Messages:  []LLMCompletionMessage{{Role: LLMMessageRoleUser, Content: "Tell me a story"}},
+		SessionID: &sessionID,
+		UserID:    "u1",
+		Source:    "app",
+	})
+	require.NoError(t, err)
+	_, err = stream.Next()
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+
+	msgs := rec.messages()
+	require.Len(t, msgs, 1)
+	require.Equal(t, "Once", msgs[0].Response)
+	require.Equal(t, LLMMessageStatusAborted, msgs[0].Status)
+}
@@ -0,0 +1,194 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// llmSessionMessagePageSize is the number of messages listed per request,
+// the maximum accepted by LLM Proxy.
+const llmSessionMessagePageSize = 100
+
+// ConversationSummarizer condenses the older messages of a conversation into
+// a short text that stands in for them in the prompt context.
+type ConversationSummarizer func(ctx context.Context, messages []LLMCompletionMessage) (string, error)
+
+// ConversationContextOptions configures GetConversationContext.
+type ConversationContextOptions struct {
+	// MaxMessages bounds the number of recorded messages kept, each giving
+	// the prompt and its reply. Zero means no limit.
+	MaxMessages int
+	// MaxTokens bounds the tokens of the messages kept, as counted by
+	// CountTokens. The summary is not counted. Zero means no limit.
+	MaxTokens int
+	// Summarizer, when set, is given the messages left out of the window and
+	// its summary is placed first in the context as a system message.
+	Summarizer ConversationSummarizer
+	// CountTokens counts the tokens of a text. Defaults to an estimate of
+	// one token per four characters.
+	CountTokens func(text string) int
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// GetConversationContext builds the prompt context of a session from its
+// recorded messages, ready to be used as LLMCompletionRequest.Messages.
+//
+// Only messages with the success status are used. The most recent ones are
+// kept, oldest first, within the MaxMessages and MaxTokens limits; the
+// older ones are dropped, or summarized when a Summarizer is set. Each
+// message gives its content under its role, followed by its reply, the
+// modified one if any, as an assistant message.
+//
+// The message list of LLM Proxy does not include contents, so every message
+// kept or summarized is fetched with its own request.
+//
+// Parameters:
+//   - ctx: context for the requests, also passed to the summarizer
+//   - sessionID: the session to read (required)
+//   - opts: optional settings; nil keeps every message
+//
+// Returns:
+//   - []LLMCompletionMessage: the prompt context, oldest first
+//   - error: any error that occurred
+//
+// Example:
+//
+//	history, err := sdkClient.GetConversationContext(ctx, sessionID, &sdk.ConversationContextOptions{
+//		MaxTokens:  4000,
+//		Summarizer: summarize,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	resp, err := sdkClient.Raw().CreateLLMCompletion(ctx, &sdk.LLMCompletionRequest{
+//		Model:    "gpt-4",
+//		Messages: append(history, sdk.LLMCompletionMessage{Role: sdk.LLMMessageRoleUser, Content: question}),
+//	})
+func (c *SDKClient) GetConversationContext(ctx context.Context, sessionID int64, opts *ConversationContextOptions) ([]LLMCompletionMessage, error) {
+	if sessionID <= 0 {
+		return nil, fmt.Errorf("session_id is required")
+	}
+	var cfg ConversationContextOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.CountTokens == nil {
+		cfg.CountTokens = estimateTokens
+	}
+
+	records, err := c.listSessionMessages(ctx, sessionID, cfg.CallOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk back from the newest message until a limit is reached.
+	var (
+		window [][]LLMCompletionMessage
+		tokens int
+		split  = len(records)
+	)
+	for split > 0 {
+		if cfg.MaxMessages > 0 && len(window) >= cfg.MaxMessages {
+			break
+		}
+		msgs, err := c.conversationMessages(ctx, records[split-1].ID, cfg.CallOptions)
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		for _, m := range msgs {
+			n += cfg.CountTokens(m.Content)
+		}
+		if cfg.MaxTokens > 0 && tokens+n > cfg.MaxTokens {
+			break
+		}
+		tokens += n
+		window = append(window, msgs)
+		split--
+	}
+
+	var result []LLMCompletionMessage
+	if cfg.Summarizer != nil && split > 0 {
+		var older []LLMCompletionMessage
+		for _, r := range records[:split] {
+			msgs, err := c.conversationMessages(ctx, r.ID, cfg.CallOptions)
+			if err != nil {
+				return nil, err
+			}
+			older = append(older, msgs...)
+		}
+		summary, err := cfg.Summarizer(ctx, older)
+		if err != nil {
+			return nil, fmt.Errorf("summarize %d messages: %w", split, err)
+		}
+		if summary != "" {
+			result = append(result, LLMCompletionMessage{Role: LLMMessageRoleSystem, Content: summary})
+		}
+	}
+	for i := len(window) - 1; i >= 0; i-- {
+		result = append(result, window[i]...)
+	}
+	return result, nil
+}
+
+// listSessionMessages lists every successful message of a session, oldest
+// first.
+func (c *SDKClient) listSessionMessages(ctx context.Context, sessionID int64, opts []CallOption) ([]LLMChatMessage, error) {
+	var (
+		all   []LLMChatMessage
+		after *int64
+	)
+	limit := llmSessionMessagePageSize
+	for {
+		page, err := c.raw.ListLLMSessionMessages(ctx, sessionID, &LLMSessionMessagesListRequest{
+			Status: LLMMessageStatusSuccess,
+			After:  after,
+			Limit:  &limit,
+		}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("list messages of session %d: %w", sessionID, err)
+		}
+		all = append(all, page...)
+		if len(page) < limit {
+			break
+		}
+		last := page[len(page)-1].ID
+		after = &last
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all, nil
+}
+
+// conversationMessages fetches a recorded message and returns it as prompt
+// messages: its content and, if any, its reply.
+func (c *SDKClient) conversationMessages(ctx context.Context, messageID int64, opts []CallOption) ([]LLMCompletionMessage, error) {
+	msg, err := c.raw.GetLLMChatMessage(ctx, messageID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get message %d: %w", messageID, err)
+	}
+	var msgs []LLMCompletionMessage
+	if msg.Content != "" {
+		role := msg.Role
+		if role == "" {
+			role = LLMMessageRoleUser
+		}
+		msgs = append(msgs, LLMCompletionMessage{Role: role, Content: msg.Content})
+	}
+	reply := msg.ModifiedResponse
+	if reply == "" {
+		reply = msg.Response
+	}
+	if reply != "" {
+		msgs = append(msgs, LLMCompletionMessage{Role: LLMMessageRoleAssistant, Content: reply})
+	}
+	return msgs, nil
+}
+
+// estimateTokens approximates the token count of text at one token per
+// four characters.
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// conversationRoutes serves session 3 with messages 1 to n, message i having
+// content "q<i>" and response "a<i>"; message 2 has a modified response.
+func conversationRoutes(t *testing.T, n int) map[string]http.HandlerFunc {
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set(headerContentType, mimeJSON)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	return map[string]http.HandlerFunc{
+		"/llm-proxy/api/sessions/3/messages": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "success", r.URL.Query().Get("status"))
+			after, _ := strconv.ParseInt(r.URL.Query().Get("after"), 10, 64)
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			var page []LLMChatMessage
+			for id := after + 1; id <= int64(n) && len(page) < limit; id++ {
+				page = append(page, LLMChatMessage{ID: id})
+			}
+			writeJSON(w, page)
+		},
+		"/llm-proxy/api/chat-messages/": func(w http.ResponseWriter, r *http.Request) {
+			id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/llm-proxy/api/chat-messages/"), 10, 64)
+			require.NoError(t, err)
+			msg := LLMChatMessage{ID: id, Role: LLMMessageRoleUser, Content: fmt.Sprintf("q%d", id), Response: fmt.Sprintf("a%d", id)}
+			if id == 2 {
+				msg.ModifiedResponse = "edited"
+			}
+			writeJSON(w, msg)
+		},
+	}
+}
+
+func TestGetConversationContext(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, conversationRoutes(t, 5)))
+	ctx := context.Background()
+
+	all, err := client.GetConversationContext(ctx, 3, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 10)
+	require.Equal(t, LLMCompletionMessage{Role: LLMMessageRoleUser, Content: "q1"}, all[0])
+	require.Equal(t, LLMCompletionMessage{Role: LLMMessageRoleAssistant, Content: "edited"}, all[3])
+	require.Equal(t, "a5", all[9].Content)
+
+	recent, err := client.GetConversationContext(ctx, 3, &ConversationContextOptions{MaxMessages: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"q4", "a4", "q5", "a5"}, messageContents(recent))
+
+	// Each message weighs two tokens with this counter.
+	byTokens, err := client.GetConversationContext(ctx, 3, &ConversationContextOptions{
+		MaxTokens:   5,
+		CountTokens: func(string) int { return 1 },
+		Summarizer: func(ctx context.Context, older []LLMCompletionMessage) (string, error) {
+			return fmt.Sprintf("summary of %s", strings.Join(messageContents(older), " ")), nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"summary of q1 a1 q2 edited q3 a3", "q4", "a4", "q5", "a5"}, messageContents(byTokens))
+	require.Equal(t, LLMMessageRoleSystem, byTokens[0].Role)
+
+	_, err = client.GetConversationContext(ctx, 0, nil)
+	require.Error(t, err)
+}
+
+func messageContents(msgs []LLMCompletionMessage) []string {
+	out := make([]string, len(msgs))
+	for i, m := range msgs {
+		out[i] = m.Content
+	}
+	return out
+}
@@ -29,6 +29,44 @@ func (c *RawClient) doLLMJSON(ctx context.Context, method, path string, body int
 		reader = bytes.NewReader(payload)
 	}
 
+	req, err := c.newLLMRequest(ctx, method, path, reader, callOpts)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(headerAccept, mimeJSON)
+	if body != nil {
+		req.Header.Set(headerContentType, mimeJSON)
+	}
+
+	// Execute request
+	resp, err := c.send(c.httpClient, req, callOpts)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return llmResponseError(resp.StatusCode, data)
+	}
+
+	// Parse successful response
+	decode := callOpts.decoder
+	if decode == nil {
+		decode = JSONDecoder
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return decode(resp, respBody)
+}
+
+// newLLMRequest builds a request to the LLM Proxy API, either directly or
+// through the MOI SDK gateway, with the client headers applied.
+func (c *RawClient) newLLMRequest(ctx context.Context, method, path string, body io.Reader, callOpts callOptions) (*http.Request, error) {
 	// Determine base URL and path
 	var baseURL string
 	var fullPath string
@@ -53,67 +91,32 @@ func (c *RawClient) doLLMJSON(ctx context.Context, method, path string, body int
 		fullURL = fullURL + delimiter + callOpts.query.Encode()
 	}
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, reader)
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		req.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		req.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(req.Header, callOpts.headers, true)
-	req.Header.Set(headerAccept, mimeJSON)
-	if body != nil {
-		req.Header.Set(headerContentType, mimeJSON)
-	}
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	data, err := io.ReadAll(resp.Body)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
-		return fmt.Errorf("read response body: %w", err)
-	}
-
-	// Check for error response format
-	if resp.StatusCode >= http.StatusBadRequest {
-		var errResp struct {
-			Error struct {
-				Message string `json:"message"`
-				Type    string `json:"type"`
-				Code    string `json:"code"`
-			} `json:"error"`
-		}
-		if err := json.Unmarshal(data, &errResp); err == nil && errResp.Error.Message != "" {
-			return &APIError{
-				Code:       errResp.Error.Code,
-				Message:    errResp.Error.Message,
-				HTTPStatus: resp.StatusCode,
-			}
-		}
-		// If not in error format, return HTTP error
-		return &HTTPError{StatusCode: resp.StatusCode, Body: data}
+		return nil, fmt.Errorf("create request: %w", err)
 	}
+	c.applyHeaders(req, callOpts)
+	return req, nil
+}
 
-	// Parse successful response
-	if respBody != nil && len(data) > 0 && string(data) != "null" {
-		if err := json.Unmarshal(data, respBody); err != nil {
-			return fmt.Errorf("decode response: %w", err)
+// llmResponseError converts an LLM Proxy error response into an APIError,
+// or an HTTPError when the body is not in the ErrorResponse format.
+func llmResponseError(statusCode int, data []byte) error {
+	var errResp struct {
+		Error struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+			Code    string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &errResp); err == nil && errResp.Error.Message != "" {
+		return &APIError{
+			Code:       errResp.Error.Code,
+			Message:    errResp.Error.Message,
+			HTTPStatus: statusCode,
 		}
 	}
-	return nil
+	return &HTTPError{StatusCode: statusCode, Body: data}
 }
 
 // ============ Session Management APIs ============
@@ -136,6 +139,9 @@ func (c *RawClient) CreateLLMSession(ctx context.Context, req *LLMSessionCreateR
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp LLMSession
 	if err := c.doLLMJSON(ctx, http.MethodPost, "/api/sessions", req, &resp, opts...); err != nil {
 		return nil, err
@@ -163,6 +169,9 @@ func (c *RawClient) ListLLMSessions(ctx context.Context, req *LLMSessionListRequ
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Build query parameters
 	query := url.Values{}
@@ -230,6 +239,9 @@ func (c *RawClient) UpdateLLMSession(ctx context.Context, sessionID int64, req *
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp LLMSession
 	path := fmt.Sprintf("/api/sessions/%d", sessionID)
 	if err := c.doLLMJSON(ctx, http.MethodPut, path, req, &resp, opts...); err != nil {
@@ -278,6 +290,9 @@ func (c *RawClient) ListLLMSessionMessages(ctx context.Context, sessionID int64,
 	if req == nil {
 		req = &LLMSessionMessagesListRequest{}
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Build query parameters
 	query := url.Values{}
@@ -399,20 +414,12 @@ func (c *RawClient) ModifyLLMSessionMessageResponse(ctx context.Context, session
 	}
 
 	// Set headers
-	req.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		req.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		req.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(req.Header, callOpts.headers, true)
+	c.applyHeaders(req, callOpts)
 	req.Header.Set(headerAccept, mimeJSON)
 	req.Header.Set(headerContentType, "text/plain")
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(c.httpClient, req, callOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -503,20 +510,12 @@ func (c *RawClient) AppendLLMSessionMessageModifiedResponse(ctx context.Context,
 	}
 
 	// Set headers
-	req.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		req.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		req.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(req.Header, callOpts.headers, true)
+	c.applyHeaders(req, callOpts)
 	req.Header.Set(headerAccept, mimeJSON)
 	req.Header.Set(headerContentType, "text/plain")
 
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(c.httpClient, req, callOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -581,6 +580,9 @@ func (c *RawClient) CreateLLMChatMessage(ctx context.Context, req *LLMChatMessag
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp LLMChatMessage
 	if err := c.doLLMJSON(ctx, http.MethodPost, "/api/chat-messages", req, &resp, opts...); err != nil {
 		return nil, err
@@ -588,6 +590,43 @@ func (c *RawClient) CreateLLMChatMessage(ctx context.Context, req *LLMChatMessag
 	return &resp, nil
 }
 
+// CreateLLMChatMessagesBatch creates many chat messages in one request, such as
+// when importing historical conversations.
+//
+// Messages are validated and stored one by one: a rejected message does not
+// prevent the others from being created. Check the result of each message in
+// the response.
+//
+// Example:
+//
+//	resp, err := client.CreateLLMChatMessagesBatch(ctx, &sdk.LLMChatMessageBatchCreateRequest{
+//		Messages: []sdk.LLMChatMessageCreateRequest{
+//			{UserID: "user123", Source: "my-app", Role: sdk.LLMMessageRoleUser, Content: "Hi", Model: "gpt-4"},
+//			{UserID: "user123", Source: "my-app", Role: sdk.LLMMessageRoleUser, Content: "Bye", Model: "gpt-4"},
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, r := range resp.Results {
+//		if r.Error != "" {
+//			fmt.Printf("message %d: %s\n", r.Index, r.Error)
+//		}
+//	}
+func (c *RawClient) CreateLLMChatMessagesBatch(ctx context.Context, req *LLMChatMessageBatchCreateRequest, opts ...CallOption) (*LLMChatMessageBatchCreateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp LLMChatMessageBatchCreateResponse
+	if err := c.doLLMJSON(ctx, http.MethodPost, "/api/chat-messages/batch", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetLLMChatMessage retrieves a single chat message by ID.
 //
 // Example:
@@ -622,6 +661,9 @@ func (c *RawClient) UpdateLLMChatMessage(ctx context.Context, messageID int64, r
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp LLMChatMessage
 	path := fmt.Sprintf("/api/chat-messages/%d", messageID)
 	if err := c.doLLMJSON(ctx, http.MethodPut, path, req, &resp, opts...); err != nil {
@@ -662,6 +704,9 @@ func (c *RawClient) UpdateLLMChatMessageTags(ctx context.Context, messageID int6
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp LLMChatMessage
 	path := fmt.Sprintf("/api/chat-messages/%d/tags", messageID)
 	if err := c.doLLMJSON(ctx, http.MethodPut, path, req, &resp, opts...); err != nil {
@@ -688,6 +733,72 @@ func (c *RawClient) DeleteLLMChatMessageTag(ctx context.Context, messageID int64
 	return &resp, nil
 }
 
+// ============ Usage Statistics APIs ============
+
+// GetLLMUsageStats returns message counts, token usage, model distribution and
+// error rates of the recorded chat messages, optionally grouped by source, user,
+// model and time bucket. Token figures only cover messages that recorded them.
+//
+// Example:
+//
+//	stats, err := client.GetLLMUsageStats(ctx, &sdk.LLMUsageStatsFilter{
+//		StartTime: time.Now().AddDate(0, -1, 0).Unix(),
+//		GroupBy:   []sdk.LLMUsageGroupBy{sdk.LLMUsageGroupBySource},
+//		Bucket:    sdk.LLMUsageBucketDay,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, g := range stats.Groups {
+//		fmt.Printf("%s %d: %d tokens\n", g.Source, g.BucketStart, g.TotalTokens)
+//	}
+func (c *RawClient) GetLLMUsageStats(ctx context.Context, filter *LLMUsageStatsFilter, opts ...CallOption) (*LLMUsageStatsResponse, error) {
+	if filter == nil {
+		return nil, ErrNilRequest
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Build query parameters
+	query := url.Values{}
+	if filter.UserID != "" {
+		query.Set("user_id", filter.UserID)
+	}
+	if filter.Source != "" {
+		query.Set("source", filter.Source)
+	}
+	if filter.Model != "" {
+		query.Set("model", filter.Model)
+	}
+	if filter.StartTime > 0 {
+		query.Set("start_time", strconv.FormatInt(filter.StartTime, 10))
+	}
+	if filter.EndTime > 0 {
+		query.Set("end_time", strconv.FormatInt(filter.EndTime, 10))
+	}
+	if len(filter.GroupBy) > 0 {
+		groupBy := make([]string, len(filter.GroupBy))
+		for i, g := range filter.GroupBy {
+			groupBy[i] = string(g)
+		}
+		query.Set("group_by", strings.Join(groupBy, ","))
+	}
+	if filter.Bucket != "" {
+		query.Set("bucket", string(filter.Bucket))
+	}
+
+	var resp LLMUsageStatsResponse
+	path := "/api/usage/stats"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	if err := c.doLLMJSON(ctx, http.MethodGet, path, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // Helper functions for pointer creation
 // These are used in tests and example code to create pointer values for optional fields.
 func stringPtr(s string) *string {
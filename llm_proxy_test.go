@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -69,6 +71,91 @@ func TestUpdateLLMChatMessageTags_NilRequest(t *testing.T) {
 	require.ErrorIs(t, err, ErrNilRequest)
 }
 
+func TestCreateLLMChatMessagesBatch_NilRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	resp, err := client.CreateLLMChatMessagesBatch(ctx, nil)
+	require.Nil(t, resp)
+	require.ErrorIs(t, err, ErrNilRequest)
+
+	_, err = client.CreateLLMChatMessagesBatch(ctx, &LLMChatMessageBatchCreateRequest{})
+	require.Error(t, err)
+}
+
+func TestCreateLLMChatMessagesBatch(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/llm-proxy/api/chat-messages/batch": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			var req LLMChatMessageBatchCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Len(t, req.Messages, 2)
+			w.Header().Set(headerContentType, mimeJSON)
+			_ = json.NewEncoder(w).Encode(LLMChatMessageBatchCreateResponse{
+				Results: []LLMChatMessageBatchResult{
+					{Index: 0, Message: &LLMChatMessage{ID: 1, Content: req.Messages[0].Content}},
+					{Index: 1, Error: "model is required"},
+				},
+				SuccessCount: 1,
+				FailureCount: 1,
+			})
+		},
+	})
+
+	resp, err := client.CreateLLMChatMessagesBatch(context.Background(), &LLMChatMessageBatchCreateRequest{
+		Messages: []LLMChatMessageCreateRequest{
+			{UserID: "u1", Source: "app", Role: LLMMessageRoleUser, Content: "Hi", Model: "gpt-4"},
+			{UserID: "u1", Source: "app", Role: LLMMessageRoleUser, Content: "Bye"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.SuccessCount)
+	require.Equal(t, "Hi", resp.Results[0].Message.Content)
+	require.Nil(t, resp.Results[1].Message)
+	require.Equal(t, "model is required", resp.Results[1].Error)
+}
+
+func TestGetLLMUsageStats(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/llm-proxy/api/usage/stats": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			q := r.URL.Query()
+			require.Equal(t, "my-app", q.Get("source"))
+			require.Equal(t, "1700000000", q.Get("start_time"))
+			require.Equal(t, "source,model", q.Get("group_by"))
+			require.Equal(t, "day", q.Get("bucket"))
+			require.Empty(t, q.Get("user_id"))
+			w.Header().Set(headerContentType, mimeJSON)
+			_, _ = w.Write([]byte(`{
+				"total": {"message_count": 10, "failed_count": 2, "error_rate": 0.2, "total_tokens": 900, "models": {"gpt-4": 10}},
+				"groups": [{"source": "my-app", "model": "gpt-4", "bucket_start": 1700006400, "message_count": 10, "total_tokens": 900}]
+			}`))
+		},
+	})
+
+	resp, err := client.GetLLMUsageStats(context.Background(), &LLMUsageStatsFilter{
+		Source:    "my-app",
+		StartTime: 1700000000,
+		GroupBy:   []LLMUsageGroupBy{LLMUsageGroupBySource, LLMUsageGroupByModel},
+		Bucket:    LLMUsageBucketDay,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), resp.Total.MessageCount)
+	require.InDelta(t, 0.2, resp.Total.ErrorRate, 1e-9)
+	require.Equal(t, map[string]int64{"gpt-4": 10}, resp.Total.Models)
+	require.Len(t, resp.Groups, 1)
+	require.Equal(t, "gpt-4", resp.Groups[0].Model)
+	require.Equal(t, int64(900), resp.Groups[0].TotalTokens)
+
+	_, err = client.GetLLMUsageStats(context.Background(), nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.GetLLMUsageStats(context.Background(), &LLMUsageStatsFilter{StartTime: 20, EndTime: 10})
+	require.Error(t, err)
+}
+
 // ============ Live Flow Tests (using real backend) ============
 
 // TestLLMSessionLiveFlow tests the complete session management flow with a real backend.
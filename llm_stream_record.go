@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// LLMStreamFunc produces a streamed LLM reply, passing each piece of text to
+// emit as it arrives. An error returned by emit should be returned as is.
+type LLMStreamFunc func(ctx context.Context, emit func(delta string) error) error
+
+// StreamAndRecord records a streamed LLM reply as a chat message of a
+// session while it is generated.
+//
+// The message is created from prompt with the retry status before streamFn
+// runs, every delta emitted by streamFn is appended to its response, and
+// the status is then set to success, to aborted if ctx was cancelled, or to
+// failed otherwise. The user ID and source of prompt default to those of
+// the session.
+//
+// Parameters:
+//   - ctx: context for the requests, also passed to streamFn
+//   - sessionID: the session to record the message in (required)
+//   - prompt: the message to create; Content and Model are required, and
+//     SessionID, Status and Response are set by the call
+//   - streamFn: the function producing the reply (required)
+//
+// Returns:
+//   - *LLMChatMessage: the recorded message in its final state; it is also
+//     returned with an error once the message has been created
+//   - error: the error of streamFn, or of the recording
+//
+// Example:
+//
+//	msg, err := sdkClient.StreamAndRecord(ctx, sessionID, &sdk.LLMChatMessageCreateRequest{
+//		Role:    sdk.LLMMessageRoleUser,
+//		Content: question,
+//		Model:   "gpt-4",
+//	}, func(ctx context.Context, emit func(string) error) error {
+//		for token := range tokens {
+//			if err := emit(token); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("recorded message %d\n", msg.ID)
+func (c *SDKClient) StreamAndRecord(ctx context.Context, sessionID int64, prompt *LLMChatMessageCreateRequest, streamFn LLMStreamFunc, opts ...CallOption) (*LLMChatMessage, error) {
+	if sessionID <= 0 {
+		return nil, fmt.Errorf("session_id is required")
+	}
+	if prompt == nil {
+		return nil, ErrNilRequest
+	}
+	if streamFn == nil {
+		return nil, fmt.Errorf("stream function is required")
+	}
+
+	req := *prompt
+	req.SessionID = &sessionID
+	req.Status = LLMMessageStatusRetry
+	req.Response = ""
+	if req.Role == "" {
+		req.Role = LLMMessageRoleUser
+	}
+	if req.UserID == "" || req.Source == "" {
+		session, err := c.raw.GetLLMSession(ctx, sessionID, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("get session %d: %w", sessionID, err)
+		}
+		if req.UserID == "" {
+			req.UserID = session.UserID
+		}
+		if req.Source == "" {
+			req.Source = session.Source
+		}
+	}
+	msg, err := c.raw.CreateLLMChatMessage(ctx, &req, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create message: %w", err)
+	}
+
+	streamErr := streamFn(ctx, func(delta string) error {
+		if delta == "" {
+			return nil
+		}
+		if _, err := c.raw.UpdateLLMChatMessage(ctx, msg.ID, &LLMChatMessageUpdateRequest{Response: &delta}, opts...); err != nil {
+			return fmt.Errorf("append to message %d: %w", msg.ID, err)
+		}
+		return nil
+	})
+
+	status := LLMMessageStatusSuccess
+	switch {
+	case streamErr == nil:
+	case ctx.Err() != nil || errors.Is(streamErr, context.Canceled):
+		status = LLMMessageStatusAborted
+	default:
+		status = LLMMessageStatusFailed
+	}
+	// The final status is written even when ctx was cancelled.
+	final, err := c.raw.UpdateLLMChatMessage(context.WithoutCancel(ctx), msg.ID, &LLMChatMessageUpdateRequest{Status: &status}, opts...)
+	if err != nil {
+		return msg, errors.Join(streamErr, fmt.Errorf("set status of message %d: %w", msg.ID, err))
+	}
+	return final, streamErr
+}
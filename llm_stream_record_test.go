@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMessageStore mimics the chat message endpoints of LLM Proxy: updates
+// of the response are concatenated to the stored one.
+type fakeMessageStore struct {
+	mu      sync.Mutex
+	msg     LLMChatMessage
+	updates int
+}
+
+func (s *fakeMessageStore) routes(t *testing.T) map[string]http.HandlerFunc {
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set(headerContentType, mimeJSON)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	return map[string]http.HandlerFunc{
+		"/llm-proxy/api/sessions/5": func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, LLMSession{ID: 5, UserID: "u1", Source: "app"})
+		},
+		"/llm-proxy/api/chat-messages": func(w http.ResponseWriter, r *http.Request) {
+			var req LLMChatMessageCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.msg = LLMChatMessage{ID: 9, UserID: req.UserID, SessionID: req.SessionID, Source: req.Source, Role: req.Role, Content: req.Content, Model: req.Model, Status: req.Status}
+			writeJSON(w, s.msg)
+		},
+		"/llm-proxy/api/chat-messages/9": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPut, r.Method)
+			var req LLMChatMessageUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.updates++
+			if req.Response != nil {
+				s.msg.Response += *req.Response
+			}
+			if req.Status != nil {
+				s.msg.Status = *req.Status
+			}
+			writeJSON(w, s.msg)
+		},
+	}
+}
+
+func TestStreamAndRecord(t *testing.T) {
+	t.Parallel()
+	store := &fakeMessageStore{}
+	client := NewSDKClient(newMockClient(t, store.routes(t)))
+
+	var statusWhileStreaming LLMMessageStatus
+	msg, err := client.StreamAndRecord(context.Background(), 5, &LLMChatMessageCreateRequest{
+		Content: "Tell me a story",
+		Model:   "gpt-4",
+	}, func(ctx context.Context, emit func(string) error) error {
+		store.mu.Lock()
+		statusWhileStreaming = store.msg.Status
+		store.mu.Unlock()
+		for _, delta := range []string{"Once", "", " upon", " a time"} {
+			if err := emit(delta); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, LLMMessageStatusRetry, statusWhileStreaming)
+	require.Equal(t, LLMMessageStatusSuccess, msg.Status)
+	require.Equal(t, "Once upon a time", msg.Response)
+	require.Equal(t, "u1", msg.UserID)
+	require.Equal(t, "app", msg.Source)
+	require.Equal(t, LLMMessageRoleUser, msg.Role)
+	require.Equal(t, 4, store.updates)
+}
+
+func TestStreamAndRecord_Failure(t *testing.T) {
+	t.Parallel()
+	store := &fakeMessageStore{}
+	client := NewSDKClient(newMockClient(t, store.routes(t)))
+	streamErr := errors.New("upstream closed")
+
+	msg, err := client.StreamAndRecord(context.Background(), 5, &LLMChatMessageCreateRequest{
+		UserID:  "u2",
+		Source:  "other",
+		Content: "hi",
+		Model:   "gpt-4",
+	}, func(ctx context.Context, emit func(string) error) error {
+		if err := emit("partial"); err != nil {
+			return err
+		}
+		return streamErr
+	})
+	require.ErrorIs(t, err, streamErr)
+	require.Equal(t, LLMMessageStatusFailed, msg.Status)
+	require.Equal(t, "partial", msg.Response)
+	require.Equal(t, "u2", msg.UserID)
+}
+
+func TestStreamAndRecord_Aborted(t *testing.T) {
+	t.Parallel()
+	store := &fakeMessageStore{}
+	client := NewSDKClient(newMockClient(t, store.routes(t)))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	msg, err := client.StreamAndRecord(ctx, 5, &LLMChatMessageCreateRequest{
+		Content: "hi",
+		Model:   "gpt-4",
+	}, func(ctx context.Context, emit func(string) error) error {
+		if err := emit("partial"); err != nil {
+			return err
+		}
+		cancel()
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, LLMMessageStatusAborted, msg.Status)
+
+	_, err = client.StreamAndRecord(context.Background(), 0, &LLMChatMessageCreateRequest{}, nil)
+	require.Error(t, err)
+}
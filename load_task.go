@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LoadFileFailure is a file that a load task could not load.
+type LoadFileFailure struct {
+	// File is the source file, when the task reported it.
+	File   string
+	Reason string
+}
+
+// LoadTaskError is returned by RunLoadTaskAndWait when a load task did not
+// succeed or some of its files failed to load.
+type LoadTaskError struct {
+	TaskID   TaskID
+	Status   TaskStatus
+	Failures []LoadFileFailure
+}
+
+func (e *LoadTaskError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "sdk: load task %d ended as %s", e.TaskID, e.Status)
+	if len(e.Failures) > 0 {
+		fmt.Fprintf(&b, " with %d failed files", len(e.Failures))
+		for _, f := range e.Failures {
+			if f.File != "" {
+				fmt.Fprintf(&b, "; %s: %s", f.File, f.Reason)
+			} else {
+				fmt.Fprintf(&b, "; %s", f.Reason)
+			}
+		}
+	}
+	return b.String()
+}
+
+// RunLoadTaskAndWait creates a load task and waits until it finishes.
+//
+// The task is polled every pollInterval (2 seconds if <= 0) until it reaches
+// a terminal state; bound the wait with ctx. A task that does not succeed,
+// or that succeeds with files whose load result carries a reason, yields a
+// *LoadTaskError listing the failed files.
+//
+// Parameters:
+//   - ctx: context controlling the requests and how long to wait
+//   - req: the load task to create (required)
+//   - pollInterval: the interval between polls
+//
+// Returns:
+//   - *TaskInfoResponse: the last state of the task, also on error when known
+//   - error: any error that occurred
+//
+// Example:
+//
+//	task, err := sdkClient.RunLoadTaskAndWait(ctx, &sdk.LoadTaskCreateRequest{
+//		Name:   "daily-orders",
+//		Source: sdk.LoadTaskSourceConfig{ConnectorID: 12, URIs: []string{"s3://bucket/orders/"}},
+//		Target: sdk.LoadTaskTargetConfig{Table: &sdk.TableConfig{DatabaseID: 123, TableID: 456}},
+//	}, 5*time.Second)
+//	var loadErr *sdk.LoadTaskError
+//	if errors.As(err, &loadErr) {
+//		for _, f := range loadErr.Failures {
+//			log.Printf("%s: %s", f.File, f.Reason)
+//		}
+//	}
+func (c *SDKClient) RunLoadTaskAndWait(ctx context.Context, req *LoadTaskCreateRequest, pollInterval time.Duration, opts ...CallOption) (*TaskInfoResponse, error) {
+	job, err := c.StartLoadTask(ctx, req, pollInterval, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return job.Wait(ctx)
+}
+
+// loadFailures collects the load results that report a failure. Results
+// are matched to SourceFiles by position.
+func loadFailures(task *TaskInfoResponse) []LoadFileFailure {
+	var failures []LoadFileFailure
+	for i, r := range task.LoadResults {
+		if r == nil || r.Reason == "" {
+			continue
+		}
+		f := LoadFileFailure{Reason: r.Reason}
+		if i < len(task.SourceFiles) {
+			f.File = strings.Join(task.SourceFiles[i], "/")
+		}
+		failures = append(failures, f)
+	}
+	return failures
+}
@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTaskLifecycle(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/task/load/create": func(w http.ResponseWriter, r *http.Request) {
+			var req LoadTaskCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "orders", req.Name)
+			require.Equal(t, uint64(12), req.Source.ConnectorID)
+			require.Equal(t, TableID(456), req.Target.Table.TableID)
+			writeEnvelope(w, LoadTaskCreateResponse{TaskID: 77})
+		},
+		"/task/load/get": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "77", r.URL.Query().Get("task_id"))
+			writeEnvelope(w, TaskInfoResponse{ID: "77", Status: TaskStatusRunning})
+		},
+		"/task/load/list": func(w http.ResponseWriter, r *http.Request) {
+			var req LoadTaskListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "running", req.Status)
+			writeEnvelope(w, LoadTaskListResponse{Tasks: []TaskInfoResponse{{ID: "77"}}, Total: 1})
+		},
+		"/task/load/cancel": func(w http.ResponseWriter, r *http.Request) {
+			var req LoadTaskCancelRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, TaskID(77), req.TaskID)
+			writeEnvelope(w, LoadTaskCancelResponse{})
+		},
+		"/task/load/delete": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, LoadTaskDeleteResponse{})
+		},
+	})
+
+	created, err := client.CreateLoadTask(ctx, &LoadTaskCreateRequest{
+		Name:   "orders",
+		Source: LoadTaskSourceConfig{ConnectorID: 12, URIs: []string{"s3://bucket/orders.csv"}},
+		Target: LoadTaskTargetConfig{Table: &TableConfig{DatabaseID: 123, TableID: 456}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TaskID(77), created.TaskID)
+
+	task, err := client.GetLoadTask(ctx, 77)
+	require.NoError(t, err)
+	require.Equal(t, TaskStatusRunning, task.Status)
+
+	list, err := client.ListLoadTasks(ctx, &LoadTaskListRequest{Status: "running"})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Total)
+
+	_, err = client.CancelLoadTask(ctx, &LoadTaskCancelRequest{TaskID: 77})
+	require.NoError(t, err)
+	_, err = client.DeleteLoadTask(ctx, &LoadTaskDeleteRequest{TaskID: 77})
+	require.NoError(t, err)
+}
+
+func TestCreateLoadTask_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	_, err := client.CreateLoadTask(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.CreateLoadTask(ctx, &LoadTaskCreateRequest{Target: LoadTaskTargetConfig{VolumeID: "v"}})
+	require.ErrorContains(t, err, "uris or conn_file_ids")
+	_, err = client.CreateLoadTask(ctx, &LoadTaskCreateRequest{
+		Source: LoadTaskSourceConfig{URIs: []string{"s3://a"}},
+		Target: LoadTaskTargetConfig{VolumeID: "v"},
+	})
+	require.ErrorContains(t, err, "connector_id")
+	_, err = client.CreateLoadTask(ctx, &LoadTaskCreateRequest{
+		Source: LoadTaskSourceConfig{ConnFileIDs: []string{"cf"}},
+		Target: LoadTaskTargetConfig{VolumeID: "v", Table: &TableConfig{}},
+	})
+	require.ErrorContains(t, err, "exactly one")
+	_, err = client.GetLoadTask(ctx, 0)
+	require.Error(t, err)
+	_, err = client.CancelLoadTask(ctx, &LoadTaskCancelRequest{})
+	require.Error(t, err)
+	_, err = client.DeleteLoadTask(ctx, &LoadTaskDeleteRequest{})
+	require.Error(t, err)
+}
+
+func TestRunLoadTaskAndWait(t *testing.T) {
+	t.Parallel()
+	var polls int32
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/task/load/create": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, LoadTaskCreateResponse{TaskID: 5})
+		},
+		"/task/load/get": func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&polls, 1) < 2 {
+				writeEnvelope(w, TaskInfoResponse{ID: "5", Status: TaskStatusRunning})
+				return
+			}
+			writeEnvelope(w, TaskInfoResponse{
+				ID:          "5",
+				Status:      TaskStatusSucceeded,
+				SourceFiles: [][]string{{"orders", "a.csv"}, {"orders", "b.csv"}},
+				LoadResults: []*LoadResult{{Lines: 10}, {Reason: "bad date in line 3"}},
+			})
+		},
+	})
+
+	task, err := NewSDKClient(raw).RunLoadTaskAndWait(context.Background(), &LoadTaskCreateRequest{
+		Source: LoadTaskSourceConfig{ConnFileIDs: []string{"cf"}},
+		Target: LoadTaskTargetConfig{VolumeID: "v"},
+	}, 10*time.Millisecond)
+	require.NotNil(t, task)
+	var loadErr *LoadTaskError
+	require.True(t, errors.As(err, &loadErr))
+	require.Equal(t, TaskID(5), loadErr.TaskID)
+	require.Equal(t, TaskStatusSucceeded, loadErr.Status)
+	require.Equal(t, []LoadFileFailure{{File: "orders/b.csv", Reason: "bad date in line 3"}}, loadErr.Failures)
+	require.Contains(t, err.Error(), "orders/b.csv: bad date in line 3")
+}
@@ -33,6 +33,9 @@ func (c *RawClient) ListUserLogs(ctx context.Context, req *LogLogListRequest, op
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp LogLogListResponse
 	if err := c.postJSON(ctx, "/log/user", req, &resp, opts...); err != nil {
 		return nil, err
@@ -69,6 +72,9 @@ func (c *RawClient) ListRoleLogs(ctx context.Context, req *LogLogListRequest, op
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp LogLogListResponse
 	if err := c.postJSON(ctx, "/log/role", req, &resp, opts...); err != nil {
 		return nil, err
@@ -0,0 +1,233 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxLoggedBodySize caps how much of a body is written to the log.
+	maxLoggedBodySize = 16 << 10
+	// maxCapturedResponseSize is the largest response body buffered for
+	// logging by WithDebugLogging.
+	maxCapturedResponseSize = 1 << 20
+)
+
+const redactedValue = "[REDACTED]"
+
+// BodyRedactor scrubs sensitive data from a body before it is logged.
+//
+// contentType is the media type of the body without parameters, for example
+// "application/json". The returned slice is logged in place of body.
+type BodyRedactor func(contentType string, body []byte) []byte
+
+// sensitiveLogFields are the JSON field names masked by the default redactor.
+var sensitiveLogFields = map[string]bool{
+	"password":      true,
+	"old_password":  true,
+	"new_password":  true,
+	"api_key":       true,
+	"apikey":        true,
+	"key":           true,
+	"moi-key":       true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"secret":        true,
+	"authorization": true,
+}
+
+// defaultBodyRedactor masks sensitiveLogFields in JSON bodies and leaves
+// other bodies unchanged.
+func defaultBodyRedactor(contentType string, body []byte) []byte {
+	if !strings.Contains(contentType, "json") {
+		return body
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	redacted, err := json.Marshal(redactJSONValue(v))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+func redactJSONValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if sensitiveLogFields[strings.ToLower(k)] {
+				t[k] = redactedValue
+				continue
+			}
+			t[k] = redactJSONValue(val)
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = redactJSONValue(t[i])
+		}
+	}
+	return v
+}
+
+// logRequests wraps next so that the request is logged once it completes.
+// It returns next unchanged when logging is disabled for this call.
+func (c *RawClient) logRequests(next RequestHandler, opts callOptions) RequestHandler {
+	logger := c.logger
+	if logger == nil {
+		if !opts.debugLogging {
+			return next
+		}
+		logger = slog.Default()
+	}
+	redactor := c.logRedactor
+	if redactor == nil {
+		redactor = defaultBodyRedactor
+	}
+
+	return func(req *http.Request) (*http.Response, error) {
+		var reqBody string
+		if opts.debugLogging {
+			reqBody = captureRequestBody(req, redactor)
+		}
+
+		start := time.Now()
+		resp, err := next(req)
+		latency := time.Since(start)
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.Redacted()),
+			slog.Duration("latency", latency),
+		}
+		requestID := req.Header.Get(headerRequestID)
+		level := slog.LevelDebug
+		if err != nil {
+			level = slog.LevelWarn
+			attrs = append(attrs, slog.String("error", err.Error()))
+		} else {
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			if id := resp.Header.Get(headerRequestID); id != "" {
+				requestID = id
+			}
+			if resp.StatusCode >= http.StatusBadRequest {
+				level = slog.LevelWarn
+			}
+		}
+		if requestID != "" {
+			attrs = append(attrs, slog.String("request_id", requestID))
+		}
+		if opts.debugLogging {
+			if level < slog.LevelInfo {
+				level = slog.LevelInfo
+			}
+			attrs = append(attrs, slog.String("request_body", reqBody))
+			if resp != nil {
+				attrs = append(attrs, slog.String("response_body", captureResponseBody(resp, redactor)))
+			}
+		}
+
+		logger.LogAttrs(req.Context(), level, "sdk request", attrs...)
+		return resp, err
+	}
+}
+
+// captureRequestBody returns the redacted request body without consuming
+// it. Bodies that cannot be replayed or are not text are summarized.
+func captureRequestBody(req *http.Request, redactor BodyRedactor) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	contentType := mediaType(req.Header.Get(headerContentType))
+	if !isLoggableMediaType(contentType) || req.GetBody == nil {
+		return summarizeBody(contentType, req.ContentLength)
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return summarizeBody(contentType, req.ContentLength)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	return formatLoggedBody(contentType, data, redactor)
+}
+
+// captureResponseBody reads a JSON or text response body so that it can be
+// logged and replaces resp.Body with an equivalent reader. Event streams are
+// not read, and bodies larger than maxCapturedResponseSize are summarized
+// without buffering them.
+func captureResponseBody(resp *http.Response, redactor BodyRedactor) string {
+	contentType := mediaType(resp.Header.Get(headerContentType))
+	if resp.Body == nil || !isLoggableMediaType(contentType) || resp.ContentLength > maxCapturedResponseSize {
+		return summarizeBody(contentType, resp.ContentLength)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCapturedResponseSize+1))
+	if err != nil || len(data) > maxCapturedResponseSize {
+		// Hand the caller the bytes already read followed by the rest of the
+		// original body (or the read error).
+		var rest io.Reader = resp.Body
+		if err != nil {
+			rest = errReader{err}
+		}
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), rest), resp.Body}
+		return summarizeBody(contentType, resp.ContentLength)
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return formatLoggedBody(contentType, data, redactor)
+}
+
+// formatLoggedBody redacts the complete body before truncating it, so that
+// the redactor always sees a well-formed document.
+func formatLoggedBody(contentType string, data []byte, redactor BodyRedactor) string {
+	out := redactor(contentType, data)
+	if len(out) > maxLoggedBodySize {
+		return string(out[:maxLoggedBodySize]) + "...(truncated)"
+	}
+	return string(out)
+}
+
+func summarizeBody(contentType string, size int64) string {
+	if contentType == "" {
+		contentType = "unknown"
+	}
+	if size < 0 {
+		return "<" + contentType + " body omitted>"
+	}
+	return "<" + contentType + " body omitted, " + strconv.FormatInt(size, 10) + " bytes>"
+}
+
+func isLoggableMediaType(contentType string) bool {
+	switch {
+	case contentType == "text/event-stream":
+		return false
+	case strings.HasPrefix(contentType, "text/"),
+		strings.Contains(contentType, "json"),
+		contentType == "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
+
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mt
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
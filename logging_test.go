@@ -0,0 +1,116 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newLoggingTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) (*RawClient, *bytes.Buffer) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewRawClient(server.URL, "secret-key", append([]ClientOption{WithLogger(logger)}, opts...)...)
+	require.NoError(t, err)
+	return client, &buf
+}
+
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestWithLogger_LogsEveryRequest(t *testing.T) {
+	t.Parallel()
+	client, buf := newLoggingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/catalog/info" {
+			writeEnvelopeError(w, "ErrNotFound", "missing")
+			return
+		}
+		w.Header().Set(headerRequestID, "srv-1")
+		writeEnvelope(w, CatalogCreateResponse{CatalogID: 7})
+	})
+	ctx := context.Background()
+
+	_, err := client.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: "c"})
+	require.NoError(t, err)
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 7}, WithRequestID("cli-2"))
+	require.Error(t, err)
+
+	require.NotContains(t, buf.String(), "secret-key")
+	records := decodeLogRecords(t, buf)
+	require.Len(t, records, 2)
+
+	require.Equal(t, "DEBUG", records[0]["level"])
+	require.Equal(t, http.MethodPost, records[0]["method"])
+	require.Contains(t, records[0]["url"], "/catalog/create")
+	require.Equal(t, float64(200), records[0]["status"])
+	require.Equal(t, "srv-1", records[0]["request_id"])
+	require.Contains(t, records[0], "latency")
+	require.NotContains(t, records[0], "request_body")
+
+	require.Equal(t, "cli-2", records[1]["request_id"])
+}
+
+func TestWithDebugLogging_CapturesRedactedBodies(t *testing.T) {
+	t.Parallel()
+	client, buf := newLoggingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeEnvelope(w, map[string]interface{}{"api_key": "returned-key", "name": "bob"})
+	})
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	err := client.postJSON(context.Background(), "/user/me/api-key", map[string]string{"password": "hunter2", "user": "bob"}, &out, WithDebugLogging())
+	require.NoError(t, err)
+	require.Equal(t, "bob", out.Name, "response must still be readable after logging")
+
+	require.NotContains(t, buf.String(), "hunter2")
+	require.NotContains(t, buf.String(), "returned-key")
+	records := decodeLogRecords(t, buf)
+	require.Len(t, records, 1)
+	require.Equal(t, "INFO", records[0]["level"])
+	require.Contains(t, records[0]["request_body"], `"user":"bob"`)
+	require.Contains(t, records[0]["request_body"], redactedValue)
+	require.Contains(t, records[0]["response_body"], redactedValue)
+}
+
+func TestWithDebugLogging_CustomRedactorAndStreams(t *testing.T) {
+	t.Parallel()
+	client, buf := newLoggingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerContentType, "text/event-stream")
+		_, _ = w.Write([]byte("data: hello\n\n"))
+	}, WithLogRedactor(func(contentType string, body []byte) []byte {
+		return bytes.ReplaceAll(body, []byte("bob"), []byte("***"))
+	}))
+
+	req, err := client.buildRequest(context.Background(), http.MethodPost, "/stream", strings.NewReader(`{"user":"bob"}`), newCallOptions())
+	require.NoError(t, err)
+	req.Header.Set(headerContentType, mimeJSON)
+	resp, err := client.send(client.streamHTTPClient(), req, newCallOptions(WithDebugLogging()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	records := decodeLogRecords(t, buf)
+	require.Len(t, records, 1)
+	require.Equal(t, `{"user":"***"}`, records[0]["request_body"])
+	require.Contains(t, records[0]["response_body"], "<text/event-stream body omitted")
+}
@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MappingBuilder builds the column mapping used to import a file into an
+// existing table (TableConfig.ExistedTable).
+//
+// Table columns can be matched to file columns by name with AutoMatch and
+// set explicitly with Map, MapIndex, Default and Null; explicit settings
+// replace earlier ones for the same table column. Build checks the result.
+// Errors found while building are reported by Build.
+//
+// Example:
+//
+//	mapping, err := sdk.NewMappingBuilder(tableInfo.Columns, preview.Rows).
+//		AutoMatch().
+//		Map("customer_name", "Customer").
+//		Default("source", "import").
+//		Build()
+//	if err != nil {
+//		return err
+//	}
+//	tableConfig.ExistedTable = mapping
+type MappingBuilder struct {
+	tableColumns []Column
+	fileColumns  []string
+	mappings     map[string]FileAndTableColumnMapping
+	errs         []string
+}
+
+// NewMappingBuilder returns a builder for mapping the columns of a file
+// preview onto tableColumns. Each preview row describes one file column.
+func NewMappingBuilder(tableColumns []Column, previewRows []*PreviewRow) *MappingBuilder {
+	b := &MappingBuilder{
+		tableColumns: tableColumns,
+		fileColumns:  make([]string, len(previewRows)),
+		mappings:     make(map[string]FileAndTableColumnMapping),
+	}
+	for i, row := range previewRows {
+		if row != nil {
+			b.fileColumns[i] = strings.TrimSpace(row.ColumnName)
+		}
+	}
+	return b
+}
+
+// AutoMatch maps every table column that is not mapped yet to the file
+// column with the same name. Names are compared case-insensitively and
+// ignoring spaces, underscores and hyphens.
+func (b *MappingBuilder) AutoMatch() *MappingBuilder {
+	byName := make(map[string]int, len(b.fileColumns))
+	for i, name := range b.fileColumns {
+		key := normalizeColumnName(name)
+		if _, dup := byName[key]; key != "" && !dup {
+			byName[key] = i
+		}
+	}
+	for _, col := range b.tableColumns {
+		if _, ok := b.mappings[col.Name]; ok {
+			continue
+		}
+		if i, ok := byName[normalizeColumnName(col.Name)]; ok {
+			b.setFileColumn(col.Name, i)
+		}
+	}
+	return b
+}
+
+// Map maps tableColumn to the file column named fileColumn.
+func (b *MappingBuilder) Map(tableColumn, fileColumn string) *MappingBuilder {
+	for i, name := range b.fileColumns {
+		if name == fileColumn {
+			return b.setFileColumn(tableColumn, i)
+		}
+	}
+	b.errs = append(b.errs, fmt.Sprintf("file column %q does not exist", fileColumn))
+	return b
+}
+
+// MapIndex maps tableColumn to the file column at position colNum,
+// counting from 1.
+func (b *MappingBuilder) MapIndex(tableColumn string, colNum int) *MappingBuilder {
+	if colNum < 1 || colNum > len(b.fileColumns) {
+		b.errs = append(b.errs, fmt.Sprintf("file column %d is out of range [1, %d]", colNum, len(b.fileColumns)))
+		return b
+	}
+	return b.setFileColumn(tableColumn, colNum-1)
+}
+
+// Default fills tableColumn with value instead of a file column.
+func (b *MappingBuilder) Default(tableColumn, value string) *MappingBuilder {
+	return b.set(FileAndTableColumnMapping{TableColumn: tableColumn, Column: value})
+}
+
+// Null fills tableColumn with NULL.
+func (b *MappingBuilder) Null(tableColumn string) *MappingBuilder {
+	return b.set(FileAndTableColumnMapping{TableColumn: tableColumn, Column: "NULL"})
+}
+
+// Unmap removes the mapping of tableColumn, so that the table default
+// applies.
+func (b *MappingBuilder) Unmap(tableColumn string) *MappingBuilder {
+	delete(b.mappings, tableColumn)
+	return b
+}
+
+func (b *MappingBuilder) setFileColumn(tableColumn string, i int) *MappingBuilder {
+	name := b.fileColumns[i]
+	if name == "" {
+		name = fmt.Sprintf("col_%d", i+1)
+	}
+	return b.set(FileAndTableColumnMapping{TableColumn: tableColumn, Column: name, ColNumInFile: int32(i + 1)})
+}
+
+func (b *MappingBuilder) set(m FileAndTableColumnMapping) *MappingBuilder {
+	if b.tableColumn(m.TableColumn) == nil {
+		b.errs = append(b.errs, fmt.Sprintf("table column %q does not exist", m.TableColumn))
+		return b
+	}
+	b.mappings[m.TableColumn] = m
+	return b
+}
+
+func (b *MappingBuilder) tableColumn(name string) *Column {
+	for i := range b.tableColumns {
+		if b.tableColumns[i].Name == name {
+			return &b.tableColumns[i]
+		}
+	}
+	return nil
+}
+
+// Build returns the mapping in table column order.
+//
+// It fails if an earlier call referred to a column that does not exist, or
+// if a primary key or NOT NULL column without a default is left unmapped or
+// mapped to NULL.
+func (b *MappingBuilder) Build() ([]FileAndTableColumnMapping, error) {
+	errs := append([]string(nil), b.errs...)
+	result := make([]FileAndTableColumnMapping, 0, len(b.mappings))
+	for _, col := range b.tableColumns {
+		m, ok := b.mappings[col.Name]
+		required := (col.IsPk || col.NotNull) && col.Default == ""
+		switch {
+		case ok && required && m.ColNumInFile == 0 && strings.EqualFold(m.Column, "NULL"):
+			errs = append(errs, fmt.Sprintf("column %q cannot be NULL", col.Name))
+		case !ok && required:
+			errs = append(errs, fmt.Sprintf("column %q is not nullable and is not mapped", col.Name))
+		}
+		if ok {
+			result = append(result, m)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid column mapping: %s", strings.Join(errs, "; "))
+	}
+	return result, nil
+}
+
+// normalizeColumnName folds a column name for AutoMatch.
+func normalizeColumnName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMappingBuilder(t *testing.T) {
+	t.Parallel()
+	table := []Column{
+		{Name: "id", Type: "int", IsPk: true},
+		{Name: "customer_name", Type: "varchar", NotNull: true},
+		{Name: "amount", Type: "double"},
+		{Name: "source", Type: "varchar", NotNull: true},
+		{Name: "note", Type: "varchar"},
+		{Name: "created_at", Type: "datetime", NotNull: true, Default: "CURRENT_TIMESTAMP"},
+	}
+	preview := []*PreviewRow{
+		{ColumnName: "ID"},
+		{ColumnName: "Customer"},
+		{ColumnName: "Amount"},
+		{ColumnName: ""},
+	}
+
+	mapping, err := NewMappingBuilder(table, preview).
+		AutoMatch().
+		Map("customer_name", "Customer").
+		MapIndex("note", 4).
+		Default("source", "import").
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, []FileAndTableColumnMapping{
+		{TableColumn: "id", Column: "ID", ColNumInFile: 1},
+		{TableColumn: "customer_name", Column: "Customer", ColNumInFile: 2},
+		{TableColumn: "amount", Column: "Amount", ColNumInFile: 3},
+		{TableColumn: "source", Column: "import"},
+		{TableColumn: "note", Column: "col_4", ColNumInFile: 4},
+	}, mapping)
+}
+
+func TestMappingBuilder_Validation(t *testing.T) {
+	t.Parallel()
+	table := []Column{
+		{Name: "id", Type: "int", IsPk: true},
+		{Name: "name", Type: "varchar", NotNull: true},
+		{Name: "note", Type: "varchar"},
+	}
+	preview := []*PreviewRow{{ColumnName: "id"}, {ColumnName: "note"}}
+
+	_, err := NewMappingBuilder(table, preview).AutoMatch().Build()
+	require.ErrorContains(t, err, `column "name" is not nullable`)
+
+	_, err = NewMappingBuilder(table, preview).AutoMatch().Null("name").Build()
+	require.ErrorContains(t, err, `column "name" cannot be NULL`)
+
+	_, err = NewMappingBuilder(table, preview).
+		AutoMatch().
+		Map("name", "missing").
+		Map("missing", "id").
+		MapIndex("name", 5).
+		Build()
+	require.ErrorContains(t, err, `file column "missing" does not exist`)
+	require.ErrorContains(t, err, `table column "missing" does not exist`)
+	require.ErrorContains(t, err, "file column 5 is out of range")
+
+	mapping, err := NewMappingBuilder(table, preview).AutoMatch().Default("name", "unknown").Unmap("note").Build()
+	require.NoError(t, err)
+	require.Len(t, mapping, 2)
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// Metadata is a set of headers carried by a context, such as the tenant,
+// the locale or the trace of a request being served.
+type Metadata http.Header
+
+type metadataKey struct{}
+
+// NewContextWithMetadata returns a copy of ctx carrying md. Every request
+// the RawClient sends with the returned context, or a context derived from
+// it, includes the headers of md, so that they are set once where the
+// request enters the application instead of at every call site.
+//
+// When ctx already carries metadata, md is merged into it, its values
+// replacing those of the same header. Context metadata overrides the
+// headers of WithDefaultHeaders and is overridden by WithHeader,
+// WithHeaders and WithRequestID.
+//
+// Example:
+//
+//	ctx = sdk.NewContextWithMetadata(ctx, sdk.Metadata{
+//		"X-Tenant-ID":     {tenantID},
+//		"Accept-Language": {"zh-CN"},
+//		"Traceparent":     {traceparent},
+//	})
+//	resp, err := client.GetTable(ctx, req)
+func NewContextWithMetadata(ctx context.Context, md Metadata) context.Context {
+	if len(md) == 0 {
+		return ctx
+	}
+	merged := make(http.Header)
+	mergeHeaders(merged, http.Header(metadataFromContext(ctx)), false)
+	mergeHeaders(merged, http.Header(md), true)
+	return context.WithValue(ctx, metadataKey{}, Metadata(merged))
+}
+
+// MetadataFromContext returns a copy of the metadata carried by ctx, or nil
+// if there is none.
+func MetadataFromContext(ctx context.Context) Metadata {
+	md := metadataFromContext(ctx)
+	if md == nil {
+		return nil
+	}
+	return Metadata(http.Header(md).Clone())
+}
+
+func metadataFromContext(ctx context.Context) Metadata {
+	if ctx == nil {
+		return nil
+	}
+	md, _ := ctx.Value(metadataKey{}).(Metadata)
+	return md
+}
@@ -0,0 +1,591 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetadataBundleVersion is the version of the bundles written by
+// ExportMetadata. ImportMetadata reads bundles up to this version.
+const MetadataBundleVersion = 1
+
+// metadataListPageSize is the page size used to list roles and knowledge.
+const metadataListPageSize = 100
+
+// MetadataBundle is the serialized form of exported metadata. IDs are those
+// of the source environment; ImportMetadata maps them to the IDs of the
+// objects it creates.
+type MetadataBundle struct {
+	Version    int                 `json:"version"`
+	ExportedAt time.Time           `json:"exported_at"`
+	Catalogs   []MetadataCatalog   `json:"catalogs"`
+	Roles      []MetadataRole      `json:"roles,omitempty"`
+	Knowledge  []MetadataKnowledge `json:"knowledge,omitempty"`
+}
+
+// MetadataCatalog is a catalog of a metadata bundle.
+type MetadataCatalog struct {
+	ID        CatalogID          `json:"id"`
+	Name      string             `json:"name"`
+	Comment   string             `json:"comment,omitempty"`
+	Databases []MetadataDatabase `json:"databases"`
+}
+
+// MetadataDatabase is a database of a metadata bundle.
+type MetadataDatabase struct {
+	ID      DatabaseID       `json:"id"`
+	Name    string           `json:"name"`
+	Comment string           `json:"comment,omitempty"`
+	Tables  []MetadataTable  `json:"tables"`
+	Volumes []MetadataVolume `json:"volumes"`
+}
+
+// MetadataTable is the schema of a table of a metadata bundle.
+type MetadataTable struct {
+	ID      TableID  `json:"id"`
+	Name    string   `json:"name"`
+	Comment string   `json:"comment,omitempty"`
+	Columns []Column `json:"columns"`
+}
+
+// MetadataVolume is a volume of a metadata bundle. File contents are not
+// part of the bundle, only the folder hierarchy.
+type MetadataVolume struct {
+	ID      VolumeID `json:"id"`
+	Name    string   `json:"name"`
+	Comment string   `json:"comment,omitempty"`
+	// Folders are the slash-separated folder paths, parents first.
+	Folders []string `json:"folders,omitempty"`
+}
+
+// MetadataRole is a role of a metadata bundle.
+type MetadataRole struct {
+	Name       string            `json:"name"`
+	Comment    string            `json:"comment,omitempty"`
+	Privileges []string          `json:"privileges,omitempty"`
+	ObjPrivs   []ObjPrivResponse `json:"obj_privileges,omitempty"`
+}
+
+// MetadataKnowledge is an NL2SQL knowledge entry of a metadata bundle.
+type MetadataKnowledge struct {
+	Type  string   `json:"type"`
+	Key   string   `json:"key"`
+	Value []string `json:"value"`
+}
+
+// MetadataScope selects what ExportMetadata exports.
+type MetadataScope struct {
+	// CatalogIDs are the catalogs to export; all catalogs if empty.
+	CatalogIDs []CatalogID
+	// Roles exports the roles that are not reserved.
+	Roles bool
+	// Knowledge exports the NL2SQL knowledge entries.
+	Knowledge bool
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// ExportMetadata writes the metadata selected by scope to w as a JSON
+// MetadataBundle: catalogs, databases, table schemas, volumes with their
+// folders and, if requested, roles and NL2SQL knowledge. Table data and
+// file contents are not exported. A nil scope exports every catalog.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - scope: what to export
+//   - w: the destination of the bundle (required)
+//
+// Returns:
+//   - error: any error that occurred
+//
+// Example:
+//
+//	f, err := os.Create("metadata.json")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	err = sdkClient.ExportMetadata(ctx, &sdk.MetadataScope{
+//		CatalogIDs: []sdk.CatalogID{catalogID},
+//		Roles:      true,
+//		Knowledge:  true,
+//	}, f)
+func (c *SDKClient) ExportMetadata(ctx context.Context, scope *MetadataScope, w io.Writer) error {
+	if w == nil {
+		return fmt.Errorf("writer is required")
+	}
+	var s MetadataScope
+	if scope != nil {
+		s = *scope
+	}
+	opts := s.CallOptions
+
+	bundle := &MetadataBundle{Version: MetadataBundleVersion, ExportedAt: time.Now().UTC()}
+	catalogs, err := c.raw.ListCatalogs(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	for _, cat := range catalogs.List {
+		if len(s.CatalogIDs) > 0 && !slices.Contains(s.CatalogIDs, cat.CatalogID) {
+			continue
+		}
+		mc, err := c.exportCatalog(ctx, cat, opts)
+		if err != nil {
+			return err
+		}
+		bundle.Catalogs = append(bundle.Catalogs, *mc)
+	}
+	if s.Roles {
+		if bundle.Roles, err = c.exportRoles(ctx, opts); err != nil {
+			return err
+		}
+	}
+	if s.Knowledge {
+		if bundle.Knowledge, err = c.exportKnowledge(ctx, opts); err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(bundle)
+}
+
+func (c *SDKClient) exportCatalog(ctx context.Context, cat CatalogResponse, opts []CallOption) (*MetadataCatalog, error) {
+	mc := &MetadataCatalog{ID: cat.CatalogID, Name: cat.CatalogName, Comment: cat.Comment}
+	dbs, err := c.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: cat.CatalogID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list databases of catalog %q: %w", cat.CatalogName, err)
+	}
+	for _, db := range dbs.List {
+		md := MetadataDatabase{ID: db.DatabaseID, Name: db.DatabaseName, Comment: db.Comment}
+		children, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: db.DatabaseID}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("list database %q: %w", db.DatabaseName, err)
+		}
+		for _, child := range children.List {
+			switch child.Typ {
+			case NodeTypeTable:
+				id, err := strconv.ParseInt(child.ID, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("sdk: invalid table id %q: %w", child.ID, err)
+				}
+				info, err := c.raw.GetTable(ctx, &TableInfoRequest{TableID: TableID(id)}, opts...)
+				if err != nil {
+					return nil, fmt.Errorf("get table %q: %w", child.Name, err)
+				}
+				md.Tables = append(md.Tables, MetadataTable{ID: TableID(id), Name: child.Name, Comment: info.Comment, Columns: info.Columns})
+			case NodeTypeVolume:
+				tree := &DirectoryExportResult{}
+				if err := c.listVolumeTree(ctx, VolumeID(child.ID), "", "", tree, opts); err != nil {
+					return nil, fmt.Errorf("list volume %q: %w", child.Name, err)
+				}
+				md.Volumes = append(md.Volumes, MetadataVolume{ID: VolumeID(child.ID), Name: child.Name, Comment: child.Comment, Folders: tree.Folders})
+			}
+		}
+		mc.Databases = append(mc.Databases, md)
+	}
+	return mc, nil
+}
+
+func (c *SDKClient) exportRoles(ctx context.Context, opts []CallOption) ([]MetadataRole, error) {
+	var roles []MetadataRole
+	for page := 1; ; page++ {
+		resp, err := c.raw.ListRoles(ctx, &RoleListRequest{
+			CommonCondition: CommonCondition{Page: page, PageSize: metadataListPageSize},
+		}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("list roles: %w", err)
+		}
+		for _, r := range resp.List {
+			if r.Reserved {
+				continue
+			}
+			role, err := c.raw.GetRole(ctx, &RoleInfoRequest{RoleID: r.RoleID}, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("get role %q: %w", r.RoleName, err)
+			}
+			mr := MetadataRole{Name: role.RoleName, Comment: role.Comment}
+			for _, p := range role.AuthorityList {
+				if p != nil {
+					mr.Privileges = append(mr.Privileges, p.PrivCode)
+				}
+			}
+			for _, p := range role.ObjAuthorityList {
+				if p != nil {
+					mr.ObjPrivs = append(mr.ObjPrivs, *p)
+				}
+			}
+			roles = append(roles, mr)
+		}
+		if len(resp.List) < metadataListPageSize || page*metadataListPageSize >= resp.Total {
+			return roles, nil
+		}
+	}
+}
+
+func (c *SDKClient) exportKnowledge(ctx context.Context, opts []CallOption) ([]MetadataKnowledge, error) {
+	var entries []MetadataKnowledge
+	for page := 1; ; page++ {
+		resp, err := c.raw.ListKnowledge(ctx, &NL2SQLKnowledgeListRequest{PageNumber: page, PageSize: metadataListPageSize}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("list knowledge: %w", err)
+		}
+		for _, k := range resp.List {
+			if k != nil {
+				entries = append(entries, MetadataKnowledge{Type: k.Type, Key: k.Key, Value: k.Value})
+			}
+		}
+		if len(resp.List) < metadataListPageSize || int64(page*metadataListPageSize) >= resp.Total {
+			return entries, nil
+		}
+	}
+}
+
+// MetadataImportOptions configures ImportMetadata.
+type MetadataImportOptions struct {
+	// UpdateRoles replaces the privileges of roles that already exist with
+	// those of the bundle. By default existing roles are left unchanged.
+	UpdateRoles bool
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// MetadataIDMap maps the IDs of a bundle to the IDs of the objects they were
+// imported as.
+type MetadataIDMap struct {
+	Catalogs  map[CatalogID]CatalogID
+	Databases map[DatabaseID]DatabaseID
+	Tables    map[TableID]TableID
+	Volumes   map[VolumeID]VolumeID
+}
+
+// MetadataImportResult reports what ImportMetadata did.
+type MetadataImportResult struct {
+	IDs MetadataIDMap
+	// Created and Existing hold the slash-separated paths of the objects
+	// created and of those found already present, such as
+	// "catalog/main/sales/orders" or "role/analyst".
+	Created  []string
+	Existing []string
+	// DroppedGrants describes the object privileges of roles that were not
+	// imported because their object is not part of the bundle.
+	DroppedGrants []string
+}
+
+// ImportMetadata recreates the metadata of a bundle written by
+// ExportMetadata, typically on another environment.
+//
+// Objects are matched by name: a catalog, database, table, volume, folder,
+// role or knowledge entry that already exists is reused rather than
+// created again, so an import can be repeated. Existing table schemas are
+// not altered. The object privileges of roles are rewritten to the IDs of
+// the imported objects; those on objects outside the bundle are dropped
+// and reported in the result.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - r: the bundle to import (required)
+//   - opts: optional settings; nil uses the defaults
+//
+// Returns:
+//   - *MetadataImportResult: the ID mapping and the objects created
+//   - error: any error that occurred; the result describes the work done
+//     before it
+//
+// Example:
+//
+//	f, err := os.Open("metadata.json")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	res, err := sdkClient.ImportMetadata(ctx, f, nil)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("created %d objects\n", len(res.Created))
+func (c *SDKClient) ImportMetadata(ctx context.Context, r io.Reader, opts *MetadataImportOptions) (*MetadataImportResult, error) {
+	if r == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+	var bundle MetadataBundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("decode metadata bundle: %w", err)
+	}
+	if bundle.Version < 1 || bundle.Version > MetadataBundleVersion {
+		return nil, fmt.Errorf("unsupported metadata bundle version %d", bundle.Version)
+	}
+	var cfg MetadataImportOptions
+	if opts != nil {
+		cfg = *opts
+	}
+
+	im := &metadataImporter{client: c, opts: cfg.CallOptions, result: &MetadataImportResult{IDs: MetadataIDMap{
+		Catalogs:  map[CatalogID]CatalogID{},
+		Databases: map[DatabaseID]DatabaseID{},
+		Tables:    map[TableID]TableID{},
+		Volumes:   map[VolumeID]VolumeID{},
+	}}}
+	if err := im.importCatalogs(ctx, bundle.Catalogs); err != nil {
+		return im.result, err
+	}
+	if err := im.importRoles(ctx, bundle.Roles, cfg.UpdateRoles); err != nil {
+		return im.result, err
+	}
+	if err := im.importKnowledge(ctx, bundle.Knowledge); err != nil {
+		return im.result, err
+	}
+	return im.result, nil
+}
+
+// metadataImporter holds the state of an ImportMetadata call.
+type metadataImporter struct {
+	client *SDKClient
+	opts   []CallOption
+	result *MetadataImportResult
+}
+
+func (im *metadataImporter) record(created bool, parts ...string) {
+	p := path.Join(parts...)
+	if created {
+		im.result.Created = append(im.result.Created, p)
+	} else {
+		im.result.Existing = append(im.result.Existing, p)
+	}
+}
+
+func (im *metadataImporter) importCatalogs(ctx context.Context, catalogs []MetadataCatalog) error {
+	raw := im.client.raw
+	existing, err := raw.ListCatalogs(ctx, im.opts...)
+	if err != nil {
+		return err
+	}
+	for _, mc := range catalogs {
+		var catalogID CatalogID
+		for _, cat := range existing.List {
+			if cat.CatalogName == mc.Name {
+				catalogID = cat.CatalogID
+				break
+			}
+		}
+		created := catalogID == 0
+		if created {
+			resp, err := raw.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: mc.Name, Comment: mc.Comment}, im.opts...)
+			if err != nil {
+				return fmt.Errorf("create catalog %q: %w", mc.Name, err)
+			}
+			catalogID = resp.CatalogID
+		}
+		im.record(created, "catalog", mc.Name)
+		im.result.IDs.Catalogs[mc.ID] = catalogID
+
+		dbs, err := raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: catalogID}, im.opts...)
+		if err != nil {
+			return fmt.Errorf("list databases of catalog %q: %w", mc.Name, err)
+		}
+		for _, md := range mc.Databases {
+			var databaseID DatabaseID
+			for _, db := range dbs.List {
+				if db.DatabaseName == md.Name {
+					databaseID = db.DatabaseID
+					break
+				}
+			}
+			if err := im.importDatabase(ctx, mc.Name, catalogID, databaseID, md); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (im *metadataImporter) importDatabase(ctx context.Context, catalogName string, catalogID CatalogID, databaseID DatabaseID, md MetadataDatabase) error {
+	raw := im.client.raw
+	created := databaseID == 0
+	if created {
+		resp, err := raw.CreateDatabase(ctx, &DatabaseCreateRequest{DatabaseName: md.Name, Comment: md.Comment, CatalogID: catalogID}, im.opts...)
+		if err != nil {
+			return fmt.Errorf("create database %q: %w", md.Name, err)
+		}
+		databaseID = resp.DatabaseID
+	}
+	im.record(created, "catalog", catalogName, md.Name)
+	im.result.IDs.Databases[md.ID] = databaseID
+
+	children := map[string]DatabaseChildrenResponse{}
+	if !created {
+		resp, err := raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID}, im.opts...)
+		if err != nil {
+			return fmt.Errorf("list database %q: %w", md.Name, err)
+		}
+		for _, child := range resp.List {
+			children[child.Typ+"/"+child.Name] = child
+		}
+	}
+
+	for _, mt := range md.Tables {
+		var tableID TableID
+		child, found := children[NodeTypeTable+"/"+mt.Name]
+		if found {
+			id, err := strconv.ParseInt(child.ID, 10, 64)
+			if err != nil {
+				return fmt.Errorf("sdk: invalid table id %q: %w", child.ID, err)
+			}
+			tableID = TableID(id)
+		} else {
+			resp, err := raw.CreateTable(ctx, &TableCreateRequest{DatabaseID: databaseID, Name: mt.Name, Columns: mt.Columns, Comment: mt.Comment}, im.opts...)
+			if err != nil {
+				return fmt.Errorf("create table %q: %w", mt.Name, err)
+			}
+			tableID = resp.TableID
+		}
+		im.record(!found, "catalog", catalogName, md.Name, mt.Name)
+		im.result.IDs.Tables[mt.ID] = tableID
+	}
+
+	for _, mv := range md.Volumes {
+		var volumeID VolumeID
+		child, found := children[NodeTypeVolume+"/"+mv.Name]
+		if found {
+			volumeID = VolumeID(child.ID)
+		} else {
+			resp, err := raw.CreateVolume(ctx, &VolumeCreateRequest{Name: mv.Name, DatabaseID: databaseID, Comment: mv.Comment}, im.opts...)
+			if err != nil {
+				return fmt.Errorf("create volume %q: %w", mv.Name, err)
+			}
+			volumeID = resp.VolumeID
+		}
+		im.record(!found, "catalog", catalogName, md.Name, mv.Name)
+		im.result.IDs.Volumes[mv.ID] = volumeID
+
+		folders := map[string]FileID{"": ""}
+		for _, dir := range mv.Folders {
+			parent, name := path.Split(strings.Trim(dir, "/"))
+			parentID, ok := folders[strings.TrimSuffix(parent, "/")]
+			if !ok || name == "" {
+				return fmt.Errorf("volume %q: folder %q is listed before its parent", mv.Name, dir)
+			}
+			folderID, err := im.client.ensureFolder(ctx, volumeID, parentID, name, im.opts)
+			if err != nil {
+				return fmt.Errorf("create folder %q in volume %q: %w", dir, mv.Name, err)
+			}
+			folders[strings.Trim(dir, "/")] = folderID
+		}
+	}
+	return nil
+}
+
+func (im *metadataImporter) importRoles(ctx context.Context, roles []MetadataRole, update bool) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	raw := im.client.raw
+	existing := map[string]RoleID{}
+	for page := 1; ; page++ {
+		resp, err := raw.ListRoles(ctx, &RoleListRequest{
+			CommonCondition: CommonCondition{Page: page, PageSize: metadataListPageSize},
+		}, im.opts...)
+		if err != nil {
+			return fmt.Errorf("list roles: %w", err)
+		}
+		for _, r := range resp.List {
+			existing[r.RoleName] = r.RoleID
+		}
+		if len(resp.List) < metadataListPageSize || page*metadataListPageSize >= resp.Total {
+			break
+		}
+	}
+
+	for _, mr := range roles {
+		roleID, found := existing[mr.Name]
+		if found && !update {
+			im.record(false, "role", mr.Name)
+			continue
+		}
+		objPrivs := im.remapObjPrivs(mr)
+		if found {
+			if _, err := raw.UpdateRoleInfo(ctx, &RoleUpdateInfoRequest{RoleID: roleID, PrivList: mr.Privileges, ObjPrivList: objPrivs, Comment: mr.Comment}, im.opts...); err != nil {
+				return fmt.Errorf("update role %q: %w", mr.Name, err)
+			}
+		} else if _, err := raw.CreateRole(ctx, &RoleCreateRequest{RoleName: mr.Name, PrivList: mr.Privileges, ObjPrivList: objPrivs, Comment: mr.Comment}, im.opts...); err != nil {
+			return fmt.Errorf("create role %q: %w", mr.Name, err)
+		}
+		im.record(!found, "role", mr.Name)
+	}
+	return nil
+}
+
+// remapObjPrivs rewrites the object privileges of a role to the IDs of the
+// imported objects, dropping those whose object was not imported.
+func (im *metadataImporter) remapObjPrivs(mr MetadataRole) []ObjPrivResponse {
+	ids := im.result.IDs
+	objPrivs := make([]ObjPrivResponse, 0, len(mr.ObjPrivs))
+	for _, p := range mr.ObjPrivs {
+		newID, ok := "", false
+		switch p.ObjType {
+		case ObjTypeCatalog.String():
+			newID, ok = remapIntID(ids.Catalogs, p.ObjID)
+		case ObjTypeDatabase.String():
+			newID, ok = remapIntID(ids.Databases, p.ObjID)
+		case ObjTypeTable.String():
+			newID, ok = remapIntID(ids.Tables, p.ObjID)
+		case ObjTypeVolume.String():
+			var id VolumeID
+			id, ok = ids.Volumes[VolumeID(p.ObjID)]
+			newID = string(id)
+		}
+		if !ok {
+			im.result.DroppedGrants = append(im.result.DroppedGrants, fmt.Sprintf("role %q: %s %s", mr.Name, p.ObjType, p.ObjID))
+			continue
+		}
+		p.ObjID = newID
+		objPrivs = append(objPrivs, p)
+	}
+	return objPrivs
+}
+
+func remapIntID[T ~int64](ids map[T]T, objID string) (string, bool) {
+	old, err := strconv.ParseInt(objID, 10, 64)
+	if err != nil {
+		return "", false
+	}
+	id, ok := ids[T(old)]
+	if !ok {
+		return "", false
+	}
+	return IntToPrivObjectID(int64(id)).String(), true
+}
+
+func (im *metadataImporter) importKnowledge(ctx context.Context, entries []MetadataKnowledge) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	existing, err := im.client.exportKnowledge(ctx, im.opts)
+	if err != nil {
+		return err
+	}
+	present := map[string]bool{}
+	for _, k := range existing {
+		present[k.Type+"/"+k.Key] = true
+	}
+	for _, k := range entries {
+		if present[k.Type+"/"+k.Key] {
+			im.record(false, "knowledge", k.Type, k.Key)
+			continue
+		}
+		if _, err := im.client.raw.CreateKnowledge(ctx, &NL2SQLKnowledgeCreateRequest{Type: k.Type, Key: k.Key, Value: k.Value}, im.opts...); err != nil {
+			return fmt.Errorf("create knowledge %q: %w", k.Key, err)
+		}
+		present[k.Type+"/"+k.Key] = true
+		im.record(true, "knowledge", k.Type, k.Key)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func metadataSourceHandlers(t *testing.T) map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"/catalog/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, CatalogListResponse{List: []CatalogResponse{
+				{CatalogID: 1, CatalogName: "main", Comment: "primary"},
+				{CatalogID: 2, CatalogName: "scratch"},
+			}})
+		},
+		"/catalog/database/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseListResponse{List: []DatabaseResponse{{DatabaseID: 10, DatabaseName: "sales"}}})
+		},
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+				{ID: "100", Name: "orders", Typ: NodeTypeTable},
+				{ID: "v1", Name: "docs", Typ: NodeTypeVolume, Comment: "contracts"},
+			}})
+		},
+		"/catalog/table/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TableInfoResponse{Name: "orders", Comment: "all orders", Columns: []Column{{Name: "id", Type: "int", IsPk: true}}})
+		},
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			switch req.Filters[1].Values[0] {
+			case "":
+				writeEnvelope(w, FileListResponse{Total: 2, List: []VolumeChildrenResponse{
+					{ID: "d1", Name: "2024", FileType: "folder"},
+					{ID: "f1", Name: "a.pdf", FileType: "pdf"},
+				}})
+			case "d1":
+				writeEnvelope(w, FileListResponse{Total: 1, List: []VolumeChildrenResponse{{ID: "d2", Name: "q1", FileType: "folder"}}})
+			default:
+				writeEnvelope(w, FileListResponse{})
+			}
+		},
+		"/role/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, RoleListResponse{Total: 2, List: []RoleInfoResponse{
+				{RoleID: 1, RoleName: "admin", Reserved: true},
+				{RoleID: 7, RoleName: "analyst"},
+			}})
+		},
+		"/role/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, RoleInfoResponse{
+				RoleID: 7, RoleName: "analyst", Comment: "reads sales",
+				AuthorityList: []*PrivResponse{{PrivCode: "U2"}},
+				ObjAuthorityList: []*ObjPrivResponse{
+					{ObjID: "100", ObjType: "table", AuthorityCodeList: []*AuthorityCodeAndRule{{Code: "DT8"}}},
+					{ObjID: "999", ObjType: "table", AuthorityCodeList: []*AuthorityCodeAndRule{{Code: "DT8"}}},
+					{ObjID: "v1", ObjType: "volume", AuthorityCodeList: []*AuthorityCodeAndRule{{Code: "DV5"}}},
+				},
+			})
+		},
+		"/catalog/nl2sql_knowledge/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, NL2SQLKnowledgeListResponse{Total: 2, List: []*Nl2SqlKnowledgeResponse{
+				{Type: "glossary", Key: "gmv", Value: []string{"gross merchandise value"}},
+				{Type: "glossary", Key: "aov", Value: []string{"average order value"}},
+			}})
+		},
+	}
+}
+
+func TestExportMetadata(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, metadataSourceHandlers(t)))
+
+	var buf bytes.Buffer
+	require.NoError(t, client.ExportMetadata(context.Background(), &MetadataScope{
+		CatalogIDs: []CatalogID{1}, Roles: true, Knowledge: true,
+	}, &buf))
+
+	var bundle MetadataBundle
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &bundle))
+	require.Equal(t, MetadataBundleVersion, bundle.Version)
+	require.Equal(t, []MetadataCatalog{{
+		ID: 1, Name: "main", Comment: "primary",
+		Databases: []MetadataDatabase{{
+			ID: 10, Name: "sales",
+			Tables:  []MetadataTable{{ID: 100, Name: "orders", Comment: "all orders", Columns: []Column{{Name: "id", Type: "int", IsPk: true}}}},
+			Volumes: []MetadataVolume{{ID: "v1", Name: "docs", Comment: "contracts", Folders: []string{"2024", "2024/q1"}}},
+		}},
+	}}, bundle.Catalogs)
+	require.Len(t, bundle.Roles, 1)
+	require.Equal(t, "analyst", bundle.Roles[0].Name)
+	require.Equal(t, []string{"U2"}, bundle.Roles[0].Privileges)
+	require.Len(t, bundle.Knowledge, 2)
+}
+
+func TestImportMetadata(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	source := NewSDKClient(newMockClient(t, metadataSourceHandlers(t)))
+	require.NoError(t, source.ExportMetadata(context.Background(), &MetadataScope{
+		CatalogIDs: []CatalogID{1}, Roles: true, Knowledge: true,
+	}, &buf))
+
+	var (
+		tables    []TableCreateRequest
+		folders   []FolderCreateRequest
+		roles     []RoleCreateRequest
+		knowledge []NL2SQLKnowledgeCreateRequest
+	)
+	decode := func(r *http.Request, v any) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(v))
+	}
+	target := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, CatalogListResponse{List: []CatalogResponse{{CatalogID: 50, CatalogName: "main"}}})
+		},
+		"/catalog/database/list": func(w http.ResponseWriter, r *http.Request) {
+			var req DatabaseListRequest
+			decode(r, &req)
+			require.Equal(t, CatalogID(50), req.CatalogID)
+			writeEnvelope(w, DatabaseListResponse{})
+		},
+		"/catalog/database/create": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseCreateResponse{DatabaseID: 60})
+		},
+		"/catalog/table/create": func(w http.ResponseWriter, r *http.Request) {
+			var req TableCreateRequest
+			decode(r, &req)
+			tables = append(tables, req)
+			writeEnvelope(w, TableCreateResponse{TableID: 600})
+		},
+		"/catalog/volume/create": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, VolumeCreateResponse{VolumeID: "v9"})
+		},
+		"/catalog/folder/create": func(w http.ResponseWriter, r *http.Request) {
+			var req FolderCreateRequest
+			decode(r, &req)
+			folders = append(folders, req)
+			writeEnvelope(w, FolderCreateResponse{FolderID: FileID("f-" + req.Name)})
+		},
+		"/role/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, RoleListResponse{Total: 1, List: []RoleInfoResponse{{RoleID: 1, RoleName: "admin", Reserved: true}}})
+		},
+		"/role/create": func(w http.ResponseWriter, r *http.Request) {
+			var req RoleCreateRequest
+			decode(r, &req)
+			roles = append(roles, req)
+			writeEnvelope(w, RoleCreateResponse{RoleID: 70})
+		},
+		"/catalog/nl2sql_knowledge/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, NL2SQLKnowledgeListResponse{Total: 1, List: []*Nl2SqlKnowledgeResponse{{Type: "glossary", Key: "gmv"}}})
+		},
+		"/catalog/nl2sql_knowledge/create": func(w http.ResponseWriter, r *http.Request) {
+			var req NL2SQLKnowledgeCreateRequest
+			decode(r, &req)
+			knowledge = append(knowledge, req)
+			writeEnvelope(w, NL2SQLKnowledgeCreateResponse{ID: 1})
+		},
+	}))
+
+	res, err := target.ImportMetadata(context.Background(), &buf, nil)
+	require.NoError(t, err)
+	require.Equal(t, MetadataIDMap{
+		Catalogs:  map[CatalogID]CatalogID{1: 50},
+		Databases: map[DatabaseID]DatabaseID{10: 60},
+		Tables:    map[TableID]TableID{100: 600},
+		Volumes:   map[VolumeID]VolumeID{"v1": "v9"},
+	}, res.IDs)
+	require.Equal(t, []string{
+		"catalog/main/sales", "catalog/main/sales/orders", "catalog/main/sales/docs",
+		"role/analyst", "knowledge/glossary/aov",
+	}, res.Created)
+	require.Equal(t, []string{"catalog/main", "knowledge/glossary/gmv"}, res.Existing)
+
+	require.Equal(t, []TableCreateRequest{{DatabaseID: 60, Name: "orders", Comment: "all orders", Columns: []Column{{Name: "id", Type: "int", IsPk: true}}}}, tables)
+	require.Equal(t, []FolderCreateRequest{
+		{Name: "2024", VolumeID: "v9"},
+		{Name: "q1", VolumeID: "v9", ParentID: "f-2024"},
+	}, folders)
+	require.Len(t, roles, 1)
+	require.Equal(t, []ObjPrivResponse{
+		{ObjID: "600", ObjType: "table", AuthorityCodeList: []*AuthorityCodeAndRule{{Code: "DT8"}}},
+		{ObjID: "v9", ObjType: "volume", AuthorityCodeList: []*AuthorityCodeAndRule{{Code: "DV5"}}},
+	}, roles[0].ObjPrivList)
+	require.Equal(t, []string{`role "analyst": table 999`}, res.DroppedGrants)
+	require.Equal(t, []NL2SQLKnowledgeCreateRequest{{Type: "glossary", Key: "aov", Value: []string{"average order value"}}}, knowledge)
+}
+
+func TestImportMetadataRejectsUnknownVersion(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, nil))
+
+	_, err := client.ImportMetadata(context.Background(), strings.NewReader(`{"version": 99}`), nil)
+	require.ErrorContains(t, err, "unsupported metadata bundle version 99")
+	_, err = client.ImportMetadata(context.Background(), strings.NewReader(`not json`), nil)
+	require.ErrorContains(t, err, "decode metadata bundle")
+}
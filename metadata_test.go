@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContextWithMetadata(t *testing.T) {
+	t.Parallel()
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		writeEnvelope(w, TableInfoResponse{})
+	}))
+	t.Cleanup(server.Close)
+	defaults := http.Header{}
+	defaults.Set("X-Tenant-ID", "default")
+	defaults.Set("X-Default", "kept")
+	client, err := NewRawClient(server.URL, "test-key", WithDefaultHeaders(defaults))
+	require.NoError(t, err)
+
+	ctx := NewContextWithMetadata(context.Background(), Metadata{
+		"x-tenant-id":     {"t1"},
+		"Accept-Language": {"zh-CN"},
+	})
+	ctx = NewContextWithMetadata(ctx, Metadata{"Traceparent": {"00-abc-def-01"}})
+
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1})
+	require.NoError(t, err)
+	h := <-headers
+	require.Equal(t, []string{"t1"}, h.Values("X-Tenant-ID"))
+	require.Equal(t, "zh-CN", h.Get("Accept-Language"))
+	require.Equal(t, "00-abc-def-01", h.Get("Traceparent"))
+	require.Equal(t, "kept", h.Get("X-Default"))
+
+	// Per-call headers take precedence over the context.
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1}, WithHeader("X-Tenant-ID", "t2"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"t2"}, (<-headers).Values("X-Tenant-ID"))
+}
+
+func TestMetadataFromContext(t *testing.T) {
+	t.Parallel()
+	require.Nil(t, MetadataFromContext(context.Background()))
+
+	ctx := NewContextWithMetadata(context.Background(), Metadata{"X-Tenant-ID": {"t1"}})
+	md := MetadataFromContext(ctx)
+	require.Equal(t, "t1", http.Header(md).Get("X-Tenant-ID"))
+
+	// The copy does not alias the metadata of the context.
+	http.Header(md).Set("X-Tenant-ID", "changed")
+	require.Equal(t, "t1", http.Header(MetadataFromContext(ctx)).Get("X-Tenant-ID"))
+
+	ctx = NewContextWithMetadata(ctx, Metadata{"X-Tenant-ID": {"t2"}})
+	require.Equal(t, []string{"t2"}, http.Header(MetadataFromContext(ctx)).Values("X-Tenant-ID"))
+}
+
+func TestFlightKeyMetadata(t *testing.T) {
+	t.Parallel()
+	opts := newCallOptions()
+	a := NewContextWithMetadata(context.Background(), Metadata{"X-Tenant-ID": {"a"}})
+	b := NewContextWithMetadata(context.Background(), Metadata{"X-Tenant-ID": {"b"}})
+	require.NotEqual(t,
+		flightKey(a, http.MethodPost, "/catalog/table/info", nil, opts),
+		flightKey(b, http.MethodPost, "/catalog/table/info", nil, opts))
+}
@@ -0,0 +1,99 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Checkpoint stores the progress of a migration so that an interrupted one
+// can resume where it stopped. Implementations must be safe for concurrent
+// use.
+type Checkpoint interface {
+	// Get returns the value stored under key, if any.
+	Get(key string) (value string, ok bool, err error)
+	// Set stores value under key.
+	Set(key, value string) error
+}
+
+// FileCheckpoint is a Checkpoint kept in a JSON file. Every Set rewrites the
+// file atomically, so the file stays valid if the process is killed.
+type FileCheckpoint struct {
+	path string
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// NewFileCheckpoint opens the checkpoint stored at path, or starts an empty
+// one if the file does not exist.
+func NewFileCheckpoint(path string) (*FileCheckpoint, error) {
+	cp := &FileCheckpoint{path: path, values: map[string]string{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &cp.values); err != nil {
+		return nil, fmt.Errorf("read checkpoint %s: %w", path, err)
+	}
+	return cp, nil
+}
+
+// Get implements Checkpoint.
+func (cp *FileCheckpoint) Get(key string) (string, bool, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	v, ok := cp.values[key]
+	return v, ok, nil
+}
+
+// Set implements Checkpoint.
+func (cp *FileCheckpoint) Set(key, value string) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.values[key] = value
+	data, err := json.MarshalIndent(cp.values, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(cp.path), "."+filepath.Base(cp.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), cp.path)
+}
+
+// memoryCheckpoint is the Checkpoint of a Migrator that has none. It lets
+// the copies of one Migrator see each other's work.
+type memoryCheckpoint struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (cp *memoryCheckpoint) Get(key string) (string, bool, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	v, ok := cp.values[key]
+	return v, ok, nil
+}
+
+func (cp *memoryCheckpoint) Set(key, value string) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.values[key] = value
+	return nil
+}
@@ -0,0 +1,260 @@
+// Package migrate copies tables, volumes and roles from one MOI environment
+// to another, for example to promote a staging setup to production.
+//
+// A Migrator reads from its Source client and writes through its Target
+// client. Copies report their progress to an optional callback or
+// sdk.ProgressReporter and record what they have done in an optional
+// Checkpoint; running the same copy again with the same checkpoint skips
+// the work already done:
+//
+//	cp, err := migrate.NewFileCheckpoint("promote.checkpoint.json")
+//	if err != nil {
+//		return err
+//	}
+//	m := &migrate.Migrator{Source: staging, Target: prod, Checkpoint: cp}
+//	tableID, err := m.CopyTable(ctx, stagingTableID, prodDatabaseID, nil)
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// defaultBatchSize is the number of rows inserted per request when no batch
+// size is given.
+const defaultBatchSize = 1000
+
+// Object kinds reported in Progress.Kind.
+const (
+	KindTable  = "table"
+	KindVolume = "volume"
+	KindRole   = "role"
+)
+
+// Progress describes the state of a copy.
+type Progress struct {
+	// Kind is the kind of object being copied, one of the Kind constants.
+	Kind string
+	// Name is the name of the source object.
+	Name string
+	// Item is the file being copied, for volumes.
+	Item string
+	// Done and Total count rows for tables and files for volumes. Total is
+	// 0 when it is not known.
+	Done  int64
+	Total int64
+}
+
+// Migrator copies objects from Source to Target.
+//
+// The IDs of the tables and volumes it copies are remembered, also across
+// runs through the Checkpoint, so that CopyRole can grant the copied role
+// the same privileges on the copies.
+type Migrator struct {
+	Source *sdk.SDKClient
+	Target *sdk.SDKClient
+	// OnProgress, if set, is called as copies advance.
+	OnProgress func(Progress)
+	// Reporter, if set, receives the progress of copies as typed events:
+	// one item per batch of rows for tables and per file for volumes.
+	Reporter sdk.ProgressReporter
+	// Checkpoint, if set, records completed work so that an interrupted
+	// copy resumes instead of starting over.
+	Checkpoint Checkpoint
+	// CallOptions are applied to every underlying request, on both sides.
+	CallOptions []sdk.CallOption
+
+	memOnce sync.Once
+	mem     *memoryCheckpoint
+}
+
+// checkpoint returns the Checkpoint of m, or one kept in memory for the
+// lifetime of m if there is none.
+func (m *Migrator) checkpoint() Checkpoint {
+	if m.Checkpoint != nil {
+		return m.Checkpoint
+	}
+	m.memOnce.Do(func() { m.mem = &memoryCheckpoint{values: map[string]string{}} })
+	return m.mem
+}
+
+func (m *Migrator) progress(p Progress) {
+	if m.OnProgress != nil {
+		m.OnProgress(p)
+	}
+}
+
+func (m *Migrator) check() error {
+	if m.Source == nil || m.Target == nil {
+		return errors.New("migrate: Source and Target are required")
+	}
+	return nil
+}
+
+// CopyTableOptions configures CopyTable.
+type CopyTableOptions struct {
+	// Name is the name of the copy; the name of the source table if empty.
+	Name string
+	// BatchSize is the number of rows inserted per request. Defaults to
+	// 1000.
+	BatchSize int
+	// SchemaOnly creates the table without copying its rows.
+	SchemaOnly bool
+}
+
+// CopyTable creates a copy of a source table in a target database and
+// copies its rows.
+//
+// The copy has the columns and comment of the source table. Rows are
+// streamed from the source as JSON lines and inserted in batches; the
+// checkpoint records the number of rows inserted after every batch, and a
+// resumed copy skips that many rows of the source. The source must not
+// change between runs for a resumed copy to be exact.
+//
+// Example:
+//
+//	tableID, err := m.CopyTable(ctx, 123, prodDatabaseID, &migrate.CopyTableOptions{BatchSize: 5000})
+func (m *Migrator) CopyTable(ctx context.Context, tableID sdk.TableID, databaseID sdk.DatabaseID, opts *CopyTableOptions) (sdk.TableID, error) {
+	if err := m.check(); err != nil {
+		return 0, err
+	}
+	if tableID == 0 || databaseID == 0 {
+		return 0, errors.New("migrate: table and database IDs are required")
+	}
+	var cfg CopyTableOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	src, dst := m.Source.Raw(), m.Target.Raw()
+	cp := m.checkpoint()
+
+	info, err := src.GetTable(ctx, &sdk.TableInfoRequest{TableID: tableID}, m.CallOptions...)
+	if err != nil {
+		return 0, fmt.Errorf("get source table %d: %w", tableID, err)
+	}
+	if cfg.Name == "" {
+		cfg.Name = info.Name
+	}
+	key := fmt.Sprintf("table/%d", tableID)
+
+	var target sdk.TableID
+	if v, ok, err := cp.Get(key); err != nil {
+		return 0, err
+	} else if ok {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migrate: invalid checkpoint value %q for %s", v, key)
+		}
+		target = sdk.TableID(id)
+	} else {
+		resp, err := dst.CreateTable(ctx, &sdk.TableCreateRequest{
+			DatabaseID: databaseID,
+			Name:       cfg.Name,
+			Columns:    info.Columns,
+			Comment:    info.Comment,
+		}, m.CallOptions...)
+		if err != nil {
+			return 0, fmt.Errorf("create table %q: %w", cfg.Name, err)
+		}
+		target = resp.TableID
+		if err := cp.Set(key, strconv.FormatInt(int64(target), 10)); err != nil {
+			return target, err
+		}
+	}
+	if cfg.SchemaOnly {
+		return target, nil
+	}
+
+	if _, done, err := cp.Get(key + "/done"); err != nil || done {
+		return target, err
+	}
+	rowsKey := key + "/rows"
+	var copied int64
+	if v, ok, err := cp.Get(rowsKey); err != nil {
+		return target, err
+	} else if ok {
+		if copied, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return target, fmt.Errorf("migrate: invalid checkpoint value %q for %s", v, rowsKey)
+		}
+	}
+
+	if err := m.copyRows(ctx, tableID, target, info, copied, cfg.BatchSize, rowsKey); err != nil {
+		return target, err
+	}
+	return target, cp.Set(key+"/done", "true")
+}
+
+// copyRows streams the rows of the source table into target, skipping the
+// first skip rows.
+func (m *Migrator) copyRows(ctx context.Context, source, target sdk.TableID, info *sdk.TableInfoResponse, skip int64, batchSize int, rowsKey string) error {
+	stream, err := m.Source.Raw().DownloadTableData(ctx, &sdk.TableDownloadDataRequest{ID: int64(source), Format: sdk.TableExportFormatJSONL}, m.CallOptions...)
+	if err != nil {
+		return fmt.Errorf("export table %q: %w", info.Name, err)
+	}
+	defer stream.Body.Close()
+
+	// Each batch is one item of the reported progress.
+	progress := sdk.NewProgressTracker(m.Reporter, sdk.OperationMigrateTable)
+	var batches int64
+	if info.Lines > skip {
+		batches = (info.Lines - skip + int64(batchSize) - 1) / int64(batchSize)
+	}
+	progress.Start(batches, 0)
+	defer progress.Finish()
+
+	dec := json.NewDecoder(stream.Body)
+	dec.UseNumber()
+	var (
+		read  int64
+		done  = skip
+		batch = make([]map[string]any, 0, batchSize)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		item := fmt.Sprintf("%s rows %d-%d", info.Name, done+1, done+int64(len(batch)))
+		progress.ItemStarted(item, 0)
+		if _, err := m.Target.Raw().InsertTableRows(ctx, target, batch, m.CallOptions...); err != nil {
+			progress.ItemFailed(item, 0, err)
+			return fmt.Errorf("insert rows %d-%d into table %q: %w", done, done+int64(len(batch)), info.Name, err)
+		}
+		progress.ItemFinished(item, 0)
+		done += int64(len(batch))
+		batch = batch[:0]
+		if err := m.checkpoint().Set(rowsKey, strconv.FormatInt(done, 10)); err != nil {
+			return err
+		}
+		m.progress(Progress{Kind: KindTable, Name: info.Name, Done: done, Total: info.Lines})
+		return nil
+	}
+	for {
+		var row map[string]any
+		if err := dec.Decode(&row); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("read rows of table %q: %w", info.Name, err)
+		}
+		read++
+		if read <= skip {
+			continue
+		}
+		batch = append(batch, row)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,231 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// newEnv starts a server serving routes in the response envelope of the
+// service and returns an SDKClient for it.
+func newEnv(t *testing.T, routes map[string]any) *sdk.SDKClient {
+	t.Helper()
+	mux := http.NewServeMux()
+	for path, h := range routes {
+		switch h := h.(type) {
+		case http.HandlerFunc:
+			mux.HandleFunc(path, h)
+		default:
+			mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) { writeData(w, h) })
+		}
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	raw, err := sdk.NewRawClient(server.URL, "test-key")
+	require.NoError(t, err)
+	return sdk.NewSDKClient(raw)
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	payload, _ := json.Marshal(data)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"code": "OK", "data": json.RawMessage(payload)})
+}
+
+func writeError(w http.ResponseWriter, code, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"code": code, "msg": msg})
+}
+
+func TestCopyTableResumes(t *testing.T) {
+	t.Parallel()
+	source := newEnv(t, map[string]any{
+		"/catalog/table/info": sdk.TableInfoResponse{
+			Name: "orders", Lines: 5, Comment: "all orders",
+			Columns: []sdk.Column{{Name: "id", Type: "bigint", IsPk: true}},
+		},
+		"/catalog/table/download_data": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i := 1; i <= 5; i++ {
+				fmt.Fprintf(w, "{\"id\":%d}\n", i)
+			}
+		}),
+	})
+
+	var (
+		mu       sync.Mutex
+		creates  int
+		inserted []string
+		fail     = true
+	)
+	target := newEnv(t, map[string]any{
+		"/catalog/table/create": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req sdk.TableCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "orders", req.Name)
+			require.Equal(t, sdk.DatabaseID(9), req.DatabaseID)
+			require.Len(t, req.Columns, 1)
+			creates++
+			writeData(w, sdk.TableCreateResponse{TableID: 900})
+		}),
+		"/catalog/table/insert": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req sdk.TableInsertRowsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			mu.Lock()
+			defer mu.Unlock()
+			if fail && len(inserted) == 2 {
+				writeError(w, "ErrInternal", "connection reset")
+				return
+			}
+			for _, row := range req.Rows {
+				inserted = append(inserted, fmt.Sprint(row["id"]))
+			}
+			writeData(w, sdk.TableInsertRowsResponse{})
+		}),
+	})
+
+	cpPath := filepath.Join(t.TempDir(), "cp.json")
+	cp, err := NewFileCheckpoint(cpPath)
+	require.NoError(t, err)
+	var progress []Progress
+	m := &Migrator{Source: source, Target: target, Checkpoint: cp, OnProgress: func(p Progress) { progress = append(progress, p) }}
+
+	_, err = m.CopyTable(context.Background(), 100, 9, &CopyTableOptions{BatchSize: 2})
+	require.ErrorContains(t, err, "insert rows 2-4")
+
+	// Resume with a checkpoint reloaded from disk.
+	fail = false
+	cp, err = NewFileCheckpoint(cpPath)
+	require.NoError(t, err)
+	m.Checkpoint = cp
+	tableID, err := m.CopyTable(context.Background(), 100, 9, &CopyTableOptions{BatchSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, sdk.TableID(900), tableID)
+	require.Equal(t, 1, creates)
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, inserted)
+	require.Equal(t, Progress{Kind: KindTable, Name: "orders", Done: 5, Total: 5}, progress[len(progress)-1])
+
+	// A finished copy is not repeated.
+	_, err = m.CopyTable(context.Background(), 100, 9, nil)
+	require.NoError(t, err)
+	require.Len(t, inserted, 5)
+}
+
+func TestCopyVolumeAndRole(t *testing.T) {
+	t.Parallel()
+	source := newEnv(t, map[string]any{
+		"/catalog/volume/info": sdk.VolumeInfoResponse{VolumeID: "v1", VolumeName: "docs"},
+		"/catalog/file/list": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req sdk.FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Filters[1].Values[0] == "" {
+				writeData(w, sdk.FileListResponse{Total: 2, List: []sdk.VolumeChildrenResponse{
+					{ID: "d1", Name: "2024", FileType: "folder"},
+					{ID: "f1", Name: "a.txt", FileType: "txt"},
+				}})
+				return
+			}
+			writeData(w, sdk.FileListResponse{Total: 1, List: []sdk.VolumeChildrenResponse{{ID: "f2", Name: "b.txt", FileType: "txt"}}})
+		}),
+		"/catalog/file/download_stream": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			fmt.Fprintf(w, "content of %v", req["file_id"])
+		}),
+		"/role/info": sdk.RoleInfoResponse{
+			RoleID: 3, RoleName: "reader",
+			AuthorityList: []*sdk.PrivResponse{{PrivCode: "U2"}},
+			ObjAuthorityList: []*sdk.ObjPrivResponse{
+				{ObjID: "v1", ObjType: "volume", AuthorityCodeList: []*sdk.AuthorityCodeAndRule{{Code: "DV5"}}},
+				{ObjID: "77", ObjType: "table", AuthorityCodeList: []*sdk.AuthorityCodeAndRule{{Code: "DT8"}}},
+			},
+		},
+	})
+
+	var (
+		folders []sdk.FolderCreateRequest
+		uploads []string
+		role    sdk.RoleCreateRequest
+	)
+	target := newEnv(t, map[string]any{
+		"/catalog/volume/create": sdk.VolumeCreateResponse{VolumeID: "v9"},
+		"/catalog/folder/create": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req sdk.FolderCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			folders = append(folders, req)
+			writeData(w, sdk.FolderCreateResponse{FolderID: "d9"})
+		}),
+		"/connectors/upload": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			require.Equal(t, "v9", r.FormValue("VolumeID"))
+			var meta []sdk.FileMeta
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("meta")), &meta))
+			for _, fhs := range r.MultipartForm.File {
+				f, err := fhs[0].Open()
+				require.NoError(t, err)
+				body, _ := io.ReadAll(f)
+				uploads = append(uploads, meta[0].Path+"="+string(body))
+			}
+			writeData(w, sdk.UploadFileResponse{})
+		}),
+		"/role/create": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&role))
+			writeData(w, sdk.RoleCreateResponse{RoleID: 30})
+		}),
+	})
+
+	var (
+		progress []Progress
+		events   []sdk.ProgressEvent
+	)
+	m := &Migrator{
+		Source:     source,
+		Target:     target,
+		OnProgress: func(p Progress) { progress = append(progress, p) },
+		Reporter:   sdk.ProgressReporterFunc(func(e sdk.ProgressEvent) { events = append(events, e) }),
+	}
+	volumeID, err := m.CopyVolume(context.Background(), "v1", 9, nil)
+	require.NoError(t, err)
+	require.Equal(t, sdk.VolumeID("v9"), volumeID)
+	require.Equal(t, []sdk.FolderCreateRequest{{Name: "2024", VolumeID: "v9"}}, folders)
+	require.Equal(t, []string{"2024/b.txt=content of f2", "a.txt=content of f1"}, uploads)
+	require.Equal(t, Progress{Kind: KindVolume, Name: "docs", Item: "a.txt", Done: 2, Total: 2}, progress[len(progress)-1])
+	var types []sdk.ProgressEventType
+	for _, e := range events {
+		require.Equal(t, sdk.OperationMigrateVolume, e.Operation)
+		types = append(types, e.Type)
+	}
+	require.Equal(t, []sdk.ProgressEventType{
+		sdk.ProgressStarted,
+		sdk.ProgressItemStarted, sdk.ProgressItemFinished,
+		sdk.ProgressItemStarted, sdk.ProgressItemFinished,
+		sdk.ProgressFinished,
+	}, types)
+	require.Equal(t, "2024/b.txt", events[2].Item)
+	require.Equal(t, int64(2), events[5].ItemsDone)
+
+	res, err := m.CopyRole(context.Background(), 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, sdk.RoleID(30), res.RoleID)
+	require.Equal(t, []string{"table 77"}, res.DroppedGrants)
+	require.Equal(t, "reader", role.RoleName)
+	require.Equal(t, []string{"U2"}, role.PrivList)
+	require.Len(t, role.ObjPrivList, 1)
+	require.Equal(t, "v9", role.ObjPrivList[0].ObjID)
+}
+
+func TestMigratorRequiresClients(t *testing.T) {
+	t.Parallel()
+	m := &Migrator{}
+	_, err := m.CopyTable(context.Background(), 1, 1, nil)
+	require.ErrorContains(t, err, "Source and Target are required")
+}
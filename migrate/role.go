@@ -0,0 +1,130 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// CopyRoleOptions configures CopyRole.
+type CopyRoleOptions struct {
+	// Name is the name of the copy; the name of the source role if empty.
+	Name string
+}
+
+// RoleCopy is the outcome of CopyRole.
+type RoleCopy struct {
+	RoleID sdk.RoleID
+	// DroppedGrants describes the object privileges of the source role that
+	// were not copied because their object has not been copied.
+	DroppedGrants []string
+}
+
+// CopyRole creates a copy of a source role with the same global privileges
+// and, on the tables and volumes copied by this Migrator, the same object
+// privileges.
+//
+// Object privileges on anything else cannot be carried over and are
+// reported in the result. Copy tables and volumes first; calling CopyRole
+// again after copying more of them updates the role created the first time.
+//
+// Example:
+//
+//	res, err := m.CopyRole(ctx, analystRoleID, nil)
+//	if err != nil {
+//		return err
+//	}
+//	for _, g := range res.DroppedGrants {
+//		log.Printf("not copied: %s", g)
+//	}
+func (m *Migrator) CopyRole(ctx context.Context, roleID sdk.RoleID, opts *CopyRoleOptions) (*RoleCopy, error) {
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	if roleID == 0 {
+		return nil, errors.New("migrate: role ID is required")
+	}
+	var cfg CopyRoleOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	cp := m.checkpoint()
+
+	role, err := m.Source.Raw().GetRole(ctx, &sdk.RoleInfoRequest{RoleID: roleID}, m.CallOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("get source role %d: %w", roleID, err)
+	}
+	if cfg.Name == "" {
+		cfg.Name = role.RoleName
+	}
+	result := &RoleCopy{}
+	var privs []string
+	for _, p := range role.AuthorityList {
+		if p != nil {
+			privs = append(privs, p.PrivCode)
+		}
+	}
+	var objPrivs []sdk.ObjPrivResponse
+	for _, p := range role.ObjAuthorityList {
+		if p == nil {
+			continue
+		}
+		id, ok, err := m.copiedObjectID(p.ObjType, p.ObjID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			result.DroppedGrants = append(result.DroppedGrants, fmt.Sprintf("%s %s", p.ObjType, p.ObjID))
+			continue
+		}
+		grant := *p
+		grant.ObjID = id
+		objPrivs = append(objPrivs, grant)
+	}
+
+	key := fmt.Sprintf("role/%d", roleID)
+	v, ok, err := cp.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: invalid checkpoint value %q for %s", v, key)
+		}
+		result.RoleID = sdk.RoleID(id)
+		if _, err := m.Target.Raw().UpdateRoleInfo(ctx, &sdk.RoleUpdateInfoRequest{
+			RoleID: result.RoleID, PrivList: privs, ObjPrivList: objPrivs, Comment: role.Comment,
+		}, m.CallOptions...); err != nil {
+			return nil, fmt.Errorf("update role %q: %w", cfg.Name, err)
+		}
+	} else {
+		resp, err := m.Target.Raw().CreateRole(ctx, &sdk.RoleCreateRequest{
+			RoleName: cfg.Name, PrivList: privs, ObjPrivList: objPrivs, Comment: role.Comment,
+		}, m.CallOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("create role %q: %w", cfg.Name, err)
+		}
+		result.RoleID = resp.RoleID
+		if err := cp.Set(key, strconv.FormatUint(uint64(resp.RoleID), 10)); err != nil {
+			return result, err
+		}
+	}
+	m.progress(Progress{Kind: KindRole, Name: role.RoleName, Done: 1, Total: 1})
+	return result, nil
+}
+
+// copiedObjectID returns the ID of the copy of a source object, if this
+// Migrator copied it.
+func (m *Migrator) copiedObjectID(objType, objID string) (string, bool, error) {
+	switch objType {
+	case sdk.ObjTypeTable.String():
+		return m.checkpoint().Get("table/" + objID)
+	case sdk.ObjTypeVolume.String():
+		return m.checkpoint().Get("volume/" + objID)
+	}
+	return "", false, nil
+}
@@ -0,0 +1,189 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// listPageSize is the page size used to list the files of a volume.
+const listPageSize = 100
+
+// CopyVolumeOptions configures CopyVolume.
+type CopyVolumeOptions struct {
+	// Name is the name of the copy; the name of the source volume if empty.
+	Name string
+}
+
+// volumeEntry is a file or folder of a source volume.
+type volumeEntry struct {
+	id     sdk.FileID
+	path   string
+	size   int64
+	folder bool
+}
+
+// CopyVolume creates a copy of a source volume in a target database and
+// copies its folders and files.
+//
+// Each file is streamed from the source straight into an upload to the
+// target. The checkpoint records every folder created and every file
+// copied, so a resumed copy continues with the first file not yet copied.
+//
+// Example:
+//
+//	volumeID, err := m.CopyVolume(ctx, "vol-123", prodDatabaseID, nil)
+func (m *Migrator) CopyVolume(ctx context.Context, volumeID sdk.VolumeID, databaseID sdk.DatabaseID, opts *CopyVolumeOptions) (sdk.VolumeID, error) {
+	if err := m.check(); err != nil {
+		return "", err
+	}
+	if volumeID == "" || databaseID == 0 {
+		return "", errors.New("migrate: volume and database IDs are required")
+	}
+	var cfg CopyVolumeOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	src, dst := m.Source.Raw(), m.Target.Raw()
+	cp := m.checkpoint()
+
+	info, err := src.GetVolume(ctx, &sdk.VolumeInfoRequest{VolumeID: volumeID}, m.CallOptions...)
+	if err != nil {
+		return "", fmt.Errorf("get source volume %s: %w", volumeID, err)
+	}
+	if cfg.Name == "" {
+		cfg.Name = info.VolumeName
+	}
+	key := "volume/" + string(volumeID)
+
+	var target sdk.VolumeID
+	if v, ok, err := cp.Get(key); err != nil {
+		return "", err
+	} else if ok {
+		target = sdk.VolumeID(v)
+	} else {
+		resp, err := dst.CreateVolume(ctx, &sdk.VolumeCreateRequest{Name: cfg.Name, DatabaseID: databaseID, Comment: info.Comment}, m.CallOptions...)
+		if err != nil {
+			return "", fmt.Errorf("create volume %q: %w", cfg.Name, err)
+		}
+		target = resp.VolumeID
+		if err := cp.Set(key, string(target)); err != nil {
+			return target, err
+		}
+	}
+
+	var entries []volumeEntry
+	if err := m.listVolume(ctx, volumeID, "", "", &entries); err != nil {
+		return target, err
+	}
+	var total, totalBytes int64
+	for _, e := range entries {
+		if !e.folder {
+			total++
+			totalBytes += e.size
+		}
+	}
+	progress := sdk.NewProgressTracker(m.Reporter, sdk.OperationMigrateVolume)
+	progress.Start(total, totalBytes)
+	defer progress.Finish()
+
+	folders := map[string]sdk.FileID{"": ""}
+	var done int64
+	for _, e := range entries {
+		entryKey := key + "/" + string(e.id)
+		v, ok, err := cp.Get(entryKey)
+		if err != nil {
+			return target, err
+		}
+		if e.folder {
+			if !ok {
+				resp, err := dst.CreateFolder(ctx, &sdk.FolderCreateRequest{Name: path.Base(e.path), VolumeID: target, ParentID: folders[path.Dir(e.path)]}, m.CallOptions...)
+				if err != nil {
+					return target, fmt.Errorf("create folder %q: %w", e.path, err)
+				}
+				v = string(resp.FolderID)
+				if err := cp.Set(entryKey, v); err != nil {
+					return target, err
+				}
+			}
+			folders[e.path] = sdk.FileID(v)
+			continue
+		}
+		if ok {
+			progress.ItemSkipped(e.path, e.size)
+		} else {
+			progress.ItemStarted(e.path, e.size)
+			if err := m.copyFile(ctx, volumeID, target, e); err != nil {
+				progress.ItemFailed(e.path, e.size, err)
+				return target, err
+			}
+			if err := cp.Set(entryKey, "copied"); err != nil {
+				return target, err
+			}
+			progress.ItemFinished(e.path, e.size)
+		}
+		done++
+		m.progress(Progress{Kind: KindVolume, Name: info.VolumeName, Item: e.path, Done: done, Total: total})
+	}
+	return target, nil
+}
+
+// listVolume appends the entries below parentID to entries, folders before
+// their contents.
+func (m *Migrator) listVolume(ctx context.Context, volumeID sdk.VolumeID, parentID sdk.FileID, prefix string, entries *[]volumeEntry) error {
+	for page := 1; ; page++ {
+		list, err := m.Source.Raw().ListFiles(ctx, &sdk.FileListRequest{CommonCondition: sdk.CommonCondition{
+			Page:     page,
+			PageSize: listPageSize,
+			Filters: []sdk.CommonFilter{
+				{Name: "volume_id", Values: []string{string(volumeID)}},
+				{Name: "parent_id", Values: []string{string(parentID)}},
+			},
+		}}, m.CallOptions...)
+		if err != nil {
+			return fmt.Errorf("list folder %q: %w", prefix, err)
+		}
+		for _, item := range list.List {
+			e := volumeEntry{id: sdk.FileID(item.ID), path: path.Join(prefix, item.Name), size: item.Size, folder: isFolderType(item.FileType)}
+			*entries = append(*entries, e)
+			if e.folder {
+				if err := m.listVolume(ctx, volumeID, e.id, e.path, entries); err != nil {
+					return err
+				}
+			}
+		}
+		if len(list.List) < listPageSize || page*listPageSize >= list.Total {
+			return nil
+		}
+	}
+}
+
+// copyFile streams one file of the source volume into the target volume.
+func (m *Migrator) copyFile(ctx context.Context, source, target sdk.VolumeID, e volumeEntry) error {
+	stream, err := m.Source.Raw().DownloadFileStream(ctx, e.id, source, m.CallOptions...)
+	if err != nil {
+		return fmt.Errorf("download %q: %w", e.path, err)
+	}
+	defer stream.Body.Close()
+	name := path.Base(e.path)
+	if _, err := m.Target.Raw().UploadConnectorFile(ctx, &sdk.UploadFileRequest{
+		VolumeID: target,
+		Files:    []sdk.FileUploadItem{{File: stream.Body, FileName: name}},
+		Meta:     []sdk.FileMeta{{Filename: name, Path: e.path}},
+	}, m.CallOptions...); err != nil {
+		return fmt.Errorf("upload %q: %w", e.path, err)
+	}
+	return nil
+}
+
+func isFolderType(fileType string) bool {
+	switch strings.ToLower(fileType) {
+	case "dir", "folder", "directory":
+		return true
+	}
+	return false
+}
@@ -3,6 +3,9 @@ package sdk
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // This file contains all type definitions copied from catalog_service dependency.
@@ -31,6 +34,7 @@ type DatabaseID int64
 type TableID int64
 type CatalogID int64
 type VolumeID string
+type DatasetID int64
 type FileID string
 type UserID uint
 type RoleID uint
@@ -288,6 +292,13 @@ const (
 	PrivID_DeleteSubscription PrivID = 407 //删除订阅
 	PrivID_UseSubscription    PrivID = 408 //使用订阅
 
+	//数据集
+	PrivID_CreateDataSet PrivID = 500 //新建数据集
+	PrivID_QueryDataSet  PrivID = 501 //查询数据集的列表
+	PrivID_UpdateDataSet PrivID = 502 //更新数据集
+	PrivID_DeleteDataSet PrivID = 503 //删除数据集
+	PrivID_GetDataSet    PrivID = 504 //查看某个数据集的详情
+
 	//4.1之后如果有新的类别，那么PrivID从500开始，每个类别100个值
 )
 
@@ -425,6 +436,13 @@ const (
 	PrivCode_CreateSubscription PrivCode = "PS6"
 	PrivCode_UpdateSubscription PrivCode = "PS7"
 	PrivCode_DeleteSubscription PrivCode = "PS8"
+
+	//数据集
+	PrivCode_CreateDataSet PrivCode = "DS1"
+	PrivCode_QueryDataSet  PrivCode = "DS2"
+	PrivCode_UpdateDataSet PrivCode = "DS3"
+	PrivCode_DeleteDataSet PrivCode = "DS4"
+	PrivCode_GetDataSet    PrivCode = "DS5"
 )
 
 var ObjTypeToPrivIDMap = map[ObjType][]PrivID{
@@ -436,9 +454,153 @@ var ObjTypeToPrivIDMap = map[ObjType][]PrivID{
 	ObjTypeCatalog:    {PrivID_QueryCatalog, PrivID_UpdateCatalog, PrivID_DeleteCatalog, PrivID_CreateDatabase, PrivID_QueryDatabase},
 	ObjTypeDatabase:   {PrivID_UpdateDatabase, PrivID_DeleteDatabase, PrivID_CreateVolume, PrivID_CreateTable, PrivID_ShowTables, PrivID_CreateView},
 	ObjTypeVolume:     {PrivID_UpdateVolume, PrivID_DeleteVolume, PrivID_VolumeRead, PrivID_VolumeWrite},
+	ObjTypeDataSet:    {PrivID_UpdateDataSet, PrivID_DeleteDataSet, PrivID_GetDataSet},
 	ObjTypeTable:      {PrivID_AlterTable, PrivID_DropTable, PrivID_AlterView, PrivID_DropView, PrivID_TableSelect, PrivID_TableInsert, PrivID_TableUpdate, PrivID_TableDelete, PrivID_TableTruncate, PrivID_TableReference, PrivID_TableIndex},
 }
 
+// PrivCodeToPrivIDMap maps each privilege code to the ID of the same
+// privilege. Endpoints such as GetAuthorizedObjects take IDs.
+var PrivCodeToPrivIDMap = map[PrivCode]PrivID{
+	//用户
+	PrivCode_CreateUser:       PrivID_CreateUser,
+	PrivCode_QueryUser:        PrivID_QueryUser,
+	PrivCode_UpdatePassword:   PrivID_UpdatePassword,
+	PrivCode_UpdateUserRole:   PrivID_UpdateUserRole,
+	PrivCode_UpdateUserInfo:   PrivID_UpdateUserInfo,
+	PrivCode_UpdateUserStatus: PrivID_UpdateUserStatus,
+	PrivCode_DeleteUser:       PrivID_DeleteUser,
+	PrivCode_QueryUserLog:     PrivID_QueryUserLog,
+
+	//角色
+	PrivCode_CreateRole:       PrivID_CreateRole,
+	PrivCode_QueryRole:        PrivID_QueryRole,
+	PrivCode_UpdateRoleInfo:   PrivID_UpdateRoleInfo,
+	PrivCode_UpdateRoleStatus: PrivID_UpdateRoleStatus,
+	PrivCode_DeleteRole:       PrivID_DeleteRole,
+	PrivCode_QueryRoleLog:     PrivID_QueryRoleLog,
+
+	//连接器
+	PrivCode_CreateConnector: PrivID_CreateConnector,
+	PrivCode_QueryConnector:  PrivID_QueryConnector,
+	PrivCode_UpdateConnector: PrivID_UpdateConnector,
+	PrivCode_DeleteConnector: PrivID_DeleteConnector,
+
+	//数据载入任务
+	PrivCode_CreateLoadTask: PrivID_CreateLoadTask,
+	PrivCode_QueryLoadTask:  PrivID_QueryLoadTask,
+	PrivCode_UpdateLoadTask: PrivID_UpdateLoadTask,
+	PrivCode_DeleteLoadTask: PrivID_DeleteLoadTask,
+
+	//工作流
+	PrivCode_CreateWorkflow: PrivID_CreateWorkflow,
+	PrivCode_RunWorkflow:    PrivID_RunWorkflow,
+	PrivCode_QueryWorkflow:  PrivID_QueryWorkflow,
+	PrivCode_StopWorkflow:   PrivID_StopWorkflow,
+	PrivCode_UpdateWorkflow: PrivID_UpdateWorkflow,
+	PrivCode_DeleteWorkflow: PrivID_DeleteWorkflow,
+
+	//已废弃
+	PrivCode_CreateVolume_OLD: PrivID_CreateVolume_OLD,
+	PrivCode_QueryVolume_OLD:  PrivID_QueryVolume_OLD,
+	PrivCode_UpdateVolume_OLD: PrivID_UpdateVolume_OLD,
+	PrivCode_DeleteVolume_OLD: PrivID_DeleteVolume_OLD,
+	PrivCode_ExportVolume_OLD: PrivID_ExportVolume_OLD,
+
+	//目录
+	PrivCode_CreateCatalog: PrivID_CreateCatalog,
+	PrivCode_QueryCatalog:  PrivID_QueryCatalog,
+	PrivCode_UpdateCatalog: PrivID_UpdateCatalog,
+	PrivCode_DeleteCatalog: PrivID_DeleteCatalog,
+
+	//数据库
+	PrivCode_CreateDatabase: PrivID_CreateDatabase,
+	PrivCode_QueryDatabase:  PrivID_QueryDatabase,
+	PrivCode_UpdateDatabase: PrivID_UpdateDatabase,
+	PrivCode_DeleteDatabase: PrivID_DeleteDatabase,
+
+	//告警
+	PrivCode_CreateAlterRule:     PrivID_CreateAlterRule,
+	PrivCode_QueryAlterRule:      PrivID_QueryAlterRule,
+	PrivCode_UpdateAlterRule:     PrivID_UpdateAlterRule,
+	PrivCode_DeleteAlterRule:     PrivID_DeleteAlterRule,
+	PrivCode_CreateAlterReceiver: PrivID_CreateAlterReceiver,
+	PrivCode_QueryAlterReceiver:  PrivID_QueryAlterReceiver,
+	PrivCode_UpdateAlterReceiver: PrivID_UpdateAlterReceiver,
+	PrivCode_DeleteAlterReceiver: PrivID_DeleteAlterReceiver,
+	PrivCode_QueryAlterLog:       PrivID_QueryAlterLog,
+
+	//数据导出任务
+	PrivCode_CreateExportTask: PrivID_CreateExportTask,
+	PrivCode_QueryExportTask:  PrivID_QueryExportTask,
+	PrivCode_DeleteExportTask: PrivID_DeleteExportTask,
+
+	//数据卷
+	PrivCode_CreateVolume: PrivID_CreateVolume,
+	PrivCode_QueryVolume:  PrivID_QueryVolume,
+	PrivCode_UpdateVolume: PrivID_UpdateVolume,
+	PrivCode_DeleteVolume: PrivID_DeleteVolume,
+
+	//以下内容为4.1新增的权限码,其中部分权限码是从4.0迁移到4.1的（就是码被新的含义占了）
+
+	//连接器
+	PrivCode_GetConnector: PrivID_GetConnector,
+	PrivCode_UseConnector: PrivID_UseConnector,
+
+	//数据载入任务
+	PrivCode_GetLoadTask: PrivID_GetLoadTask,
+
+	//数据导出任务
+	PrivCode_GetExportTask:    PrivID_GetExportTask,
+	PrivCode_UpdateExportTask: PrivID_UpdateExportTask,
+
+	//工作流
+	PrivCode_GetWorkflow: PrivID_GetWorkflow,
+
+	//数据卷
+	PrivCode_VolumeRead:  PrivID_VolumeRead,
+	PrivCode_VolumeWrite: PrivID_VolumeWrite,
+
+	//表
+	PrivCode_CreateTable:    PrivID_CreateTable,
+	PrivCode_ShowTables:     PrivID_ShowTables,
+	PrivCode_AlterTable:     PrivID_AlterTable,
+	PrivCode_DropTable:      PrivID_DropTable,
+	PrivCode_CreateView:     PrivID_CreateView,
+	PrivCode_AlterView:      PrivID_AlterView,
+	PrivCode_DropView:       PrivID_DropView,
+	PrivCode_TableSelect:    PrivID_TableSelect,
+	PrivCode_TableInsert:    PrivID_TableInsert,
+	PrivCode_TableUpdate:    PrivID_TableUpdate,
+	PrivCode_TableDelete:    PrivID_TableDelete,
+	PrivCode_TableTruncate:  PrivID_TableTruncate,
+	PrivCode_TableReference: PrivID_TableReference,
+	PrivCode_TableIndex:     PrivID_TableIndex,
+
+	//知识库
+	PrivCode_CreateKnowledge: PrivID_CreateKnowledge,
+	PrivCode_QueryKnowledge:  PrivID_QueryKnowledge,
+	PrivCode_UpdateKnowledge: PrivID_UpdateKnowledge,
+	PrivCode_DeleteKnowledge: PrivID_DeleteKnowledge,
+	PrivCode_UseKnowledge:    PrivID_UseKnowledge,
+
+	//发布订阅
+	PrivCode_CreatePublication:  PrivID_CreatePublication,
+	PrivCode_QueryPublication:   PrivID_QueryPublication,
+	PrivCode_UpdatePublication:  PrivID_UpdatePublication,
+	PrivCode_DeletePublication:  PrivID_DeletePublication,
+	PrivCode_QuerySubscription:  PrivID_QuerySubscription,
+	PrivCode_CreateSubscription: PrivID_CreateSubscription,
+	PrivCode_UpdateSubscription: PrivID_UpdateSubscription,
+	PrivCode_DeleteSubscription: PrivID_DeleteSubscription,
+
+	//数据集
+	PrivCode_CreateDataSet: PrivID_CreateDataSet,
+	PrivCode_QueryDataSet:  PrivID_QueryDataSet,
+	PrivCode_UpdateDataSet: PrivID_UpdateDataSet,
+	PrivCode_DeleteDataSet: PrivID_DeleteDataSet,
+	PrivCode_GetDataSet:    PrivID_GetDataSet,
+}
+
 type CheckPriv struct {
 	PrivID   PrivID       `json:"priv_id"`
 	ObjectID PrivObjectID `json:"obj_id"`
@@ -581,6 +743,8 @@ type VolumeChildrenResponse struct {
 	CreatedAt      string `json:"created_at"`
 	CreatedBy      string `json:"created_by"`
 	UpdatedAt      string `json:"updated_at"`
+	// Tags are the key/value tags attached to the file.
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // ============ Models: Table types ============
@@ -594,19 +758,97 @@ type TableRefResp struct {
 
 // ============ Models: Role types ============
 
+// RoleStatus is whether a role is enabled. Values other than the constants
+// below are kept as returned by the service.
+type RoleStatus string
+
+const (
+	RoleStatusEnable  RoleStatus = "enable"  // Role is in effect
+	RoleStatusDisable RoleStatus = "disable" // Role is disabled
+)
+
+// String returns the status as returned by the service.
+func (s RoleStatus) String() string {
+	return string(s)
+}
+
+// IsEnabled reports whether the role is enabled.
+func (s RoleStatus) IsEnabled() bool {
+	return strings.EqualFold(string(s), string(RoleStatusEnable))
+}
+
+// IsDisabled reports whether the role is disabled.
+func (s RoleStatus) IsDisabled() bool {
+	return strings.EqualFold(string(s), string(RoleStatusDisable))
+}
+
+// UnmarshalJSON decodes a status string, or a numeric code as its text.
+// Values of other JSON types decode to the empty status rather than failing
+// the response.
+func (s *RoleStatus) UnmarshalJSON(data []byte) error {
+	*s = RoleStatus(decodeLenientString(data))
+	return nil
+}
+
 type RoleIDName struct {
-	ID     RoleID   `json:"id"`
-	Name   string   `json:"name"`
-	Status string   `json:"status"`
-	Codes  []string `json:"codes"`
+	ID     RoleID     `json:"id"`
+	Name   string     `json:"name"`
+	Status RoleStatus `json:"status"`
+	Codes  []string   `json:"codes"`
 }
 
 // ============ Models: User types ============
 
+// UserStatus is whether a user is enabled. Values other than the constants
+// below are kept as returned by the service.
+type UserStatus string
+
+const (
+	UserStatusEnable  UserStatus = "enable"  // User can sign in
+	UserStatusDisable UserStatus = "disable" // User is disabled
+)
+
+// String returns the status as returned by the service.
+func (s UserStatus) String() string {
+	return string(s)
+}
+
+// IsEnabled reports whether the user is enabled.
+func (s UserStatus) IsEnabled() bool {
+	return strings.EqualFold(string(s), string(UserStatusEnable))
+}
+
+// IsDisabled reports whether the user is disabled.
+func (s UserStatus) IsDisabled() bool {
+	return strings.EqualFold(string(s), string(UserStatusDisable))
+}
+
+// UnmarshalJSON decodes a status string, or a numeric code as its text.
+// Values of other JSON types decode to the empty status rather than failing
+// the response.
+func (s *UserStatus) UnmarshalJSON(data []byte) error {
+	*s = UserStatus(decodeLenientString(data))
+	return nil
+}
+
+// decodeLenientString returns the JSON string data, or the text of the JSON
+// number data so that numeric codes are kept, or "" for other JSON values.
+func decodeLenientString(data []byte) string {
+	var v string
+	if json.Unmarshal(data, &v) == nil {
+		return v
+	}
+	var n json.Number
+	if json.Unmarshal(data, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
+
 type UserResponse struct {
 	ID          UserID        `json:"id"`
 	Name        string        `json:"name"`
-	Status      string        `json:"status"`
+	Status      UserStatus    `json:"status"`
 	Phone       string        `json:"phone"`
 	Email       string        `json:"email"`
 	Reserved    bool          `json:"reserved"`
@@ -708,6 +950,7 @@ type Column struct {
 	Name    string `json:"name"`
 	Type    string `json:"type"`
 	IsPk    bool   `json:"is_pk"`
+	NotNull bool   `json:"not_null,omitempty"`
 	Default string `json:"default"`
 	Comment string `json:"comment"`
 }
@@ -760,6 +1003,17 @@ type CatalogInfoResponse struct {
 
 type CatalogTreeResponse struct {
 	Tree []*TreeNode `json:"tree"`
+	// ContinuationToken is set when the tree is returned in parts; pass it
+	// to WithTreeContinuation to get the next catalogs.
+	ContinuationToken string `json:"continuation_token,omitempty"`
+}
+
+// catalogTreeRequest is the body of GetCatalogTree, built from the tree
+// call options.
+type catalogTreeRequest struct {
+	MaxDepth          int      `json:"max_depth,omitempty"`
+	Types             []string `json:"types,omitempty"`
+	ContinuationToken string   `json:"continuation_token,omitempty"`
 }
 
 type CatalogListResponse struct {
@@ -796,7 +1050,9 @@ type DatabaseDeleteResponse struct {
 
 type DatabaseUpdateRequest struct {
 	DatabaseID DatabaseID `json:"id"`
-	Comment    string     `json:"description"`
+	// DatabaseName renames the database when set.
+	DatabaseName string `json:"name,omitempty"`
+	Comment      string `json:"description"`
 }
 
 type DatabaseUpdateResponse struct {
@@ -825,11 +1081,21 @@ type DatabaseListResponse struct {
 
 type DatabaseChildrenRequest struct {
 	DatabaseID DatabaseID `json:"id"`
+	// Types restricts the children to these types, NodeTypeTable or
+	// NodeTypeVolume; empty for all.
+	Types []string `json:"types,omitempty"`
+	// Page and PageSize select a page of children, counting pages from 1.
+	// A PageSize of 0 returns every child.
+	Page     int `json:"page,omitempty"`
+	PageSize int `json:"page_size,omitempty"`
 }
 
 // ChildrenResponse wraps the list of DatabaseChildrenResponse
 type DatabaseChildrenResponseData struct {
 	List []DatabaseChildrenResponse `json:"list"`
+	// Total is the number of children matching the types of the request,
+	// when the request is paginated.
+	Total int `json:"total,omitempty"`
 }
 
 type DatabaseRefListRequest struct {
@@ -872,6 +1138,15 @@ type TableInfoResponse struct {
 	Comment   string        `json:"comment"`
 }
 
+type TableRenameRequest struct {
+	TableID TableID `json:"id"`
+	Name    string  `json:"name"`
+}
+
+type TableRenameResponse struct {
+	TableID TableID `json:"id"`
+}
+
 type MultiTableInfoRequest struct {
 	TableList []TableInfoRequest `json:"table_list" binding:"required"`
 }
@@ -897,8 +1172,19 @@ type TableExistRequest struct {
 type TablePreviewRequest struct {
 	TableID TableID `json:"id"`
 	Lines   int     `json:"lines"`
-}
-
+	// Columns, if set, limits the preview to these columns, in this order.
+	Columns []string `json:"columns,omitempty"`
+	// Where, if set, is a SQL condition selecting the rows to preview, such
+	// as "amount > ?".
+	Where string `json:"where,omitempty"`
+	// WhereArgs holds the values of the "?" placeholders in Where.
+	WhereArgs []any `json:"-"`
+	// Offset is the number of rows to skip.
+	Offset int `json:"offset,omitempty"`
+}
+
+// TablePreviewResponse holds the previewed rows. Use Scan to decode them
+// into structs.
 type TablePreviewResponse struct {
 	Columns []Column        `json:"columns"`
 	Data    [][]interface{} `json:"data"`
@@ -954,6 +1240,15 @@ type TableLoadResponse struct {
 	Lines int64 `json:"lines"`
 }
 
+type TableInsertRowsRequest struct {
+	TableID TableID          `json:"id"`
+	Rows    []map[string]any `json:"rows"`
+}
+
+type TableInsertRowsResponse struct {
+	Inserted int64 `json:"inserted"`
+}
+
 type TableDownloadRequest struct {
 	TableID TableID `json:"id"`
 }
@@ -962,8 +1257,29 @@ type TableDownloadResponse struct {
 	Url string `json:"url"`
 }
 
+// TableExportFormat is the file format of exported table data.
+type TableExportFormat string
+
+const (
+	TableExportFormatCSV     TableExportFormat = "csv"
+	TableExportFormatJSONL   TableExportFormat = "jsonl"
+	TableExportFormatParquet TableExportFormat = "parquet"
+)
+
+// TableExportCompression is the compression applied to exported table data.
+type TableExportCompression string
+
+const (
+	TableExportCompressionNone TableExportCompression = ""
+	TableExportCompressionGzip TableExportCompression = "gzip"
+)
+
 type TableDownloadDataRequest struct {
 	ID int64 `json:"id"`
+	// Format is the file format; empty means CSV.
+	Format TableExportFormat `json:"format,omitempty"`
+	// Compression compresses the data; empty means uncompressed.
+	Compression TableExportCompression `json:"compression,omitempty"`
 }
 
 type TableTruncateRequest struct {
@@ -972,6 +1288,65 @@ type TableTruncateRequest struct {
 
 type TableTruncateResponse struct{}
 
+// TableAlterOp is the kind of change made by a TableAlteration.
+type TableAlterOp string
+
+const (
+	TableAlterOpAddColumn    TableAlterOp = "add_column"
+	TableAlterOpDropColumn   TableAlterOp = "drop_column"
+	TableAlterOpRenameColumn TableAlterOp = "rename_column"
+	TableAlterOpModifyColumn TableAlterOp = "modify_column"
+	TableAlterOpComment      TableAlterOp = "comment"
+)
+
+// TableAlteration is one change to a table schema. Use the Alter* helpers to
+// build one.
+type TableAlteration struct {
+	Op TableAlterOp `json:"op"`
+	// Column is the column to add, or the new definition of the column to
+	// modify.
+	Column *Column `json:"column,omitempty"`
+	// Name is the column to drop or rename.
+	Name string `json:"name,omitempty"`
+	// NewName is the new name of a renamed column.
+	NewName string `json:"new_name,omitempty"`
+	// Comment is the new table comment.
+	Comment string `json:"comment,omitempty"`
+}
+
+// AlterAddColumn adds col to the table.
+func AlterAddColumn(col Column) TableAlteration {
+	return TableAlteration{Op: TableAlterOpAddColumn, Column: &col}
+}
+
+// AlterDropColumn drops the named column.
+func AlterDropColumn(name string) TableAlteration {
+	return TableAlteration{Op: TableAlterOpDropColumn, Name: name}
+}
+
+// AlterRenameColumn renames a column.
+func AlterRenameColumn(name, newName string) TableAlteration {
+	return TableAlteration{Op: TableAlterOpRenameColumn, Name: name, NewName: newName}
+}
+
+// AlterModifyColumn replaces the definition of the column named col.Name,
+// e.g. to change its type or comment.
+func AlterModifyColumn(col Column) TableAlteration {
+	return TableAlteration{Op: TableAlterOpModifyColumn, Column: &col}
+}
+
+// AlterTableComment sets the table comment.
+func AlterTableComment(comment string) TableAlteration {
+	return TableAlteration{Op: TableAlterOpComment, Comment: comment}
+}
+
+type TableAlterRequest struct {
+	TableID     TableID           `json:"id"`
+	Alterations []TableAlteration `json:"alterations"`
+}
+
+type TableAlterResponse struct{}
+
 type TableDeleteRequest struct {
 	TableID TableID `json:"id"`
 }
@@ -1073,6 +1448,64 @@ type VolumeRemoveRefWorkflowResponse struct {
 	VolumeID VolumeID `json:"id"`
 }
 
+// ============ Handler: Dataset types ============
+
+type DatasetCreateRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	TableIDs    []TableID  `json:"table_id_list,omitempty"`
+	VolumeIDs   []VolumeID `json:"volume_id_list,omitempty"`
+}
+
+type DatasetCreateResponse struct {
+	DatasetID DatasetID `json:"id"`
+}
+
+type DatasetDeleteRequest struct {
+	DatasetID DatasetID `json:"id"`
+}
+
+type DatasetDeleteResponse struct {
+	DatasetID DatasetID `json:"id"`
+}
+
+type DatasetUpdateRequest struct {
+	DatasetID   DatasetID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	TableIDs    []TableID  `json:"table_id_list,omitempty"`
+	VolumeIDs   []VolumeID `json:"volume_id_list,omitempty"`
+}
+
+type DatasetUpdateResponse struct {
+	DatasetID DatasetID `json:"id"`
+}
+
+type DatasetInfoRequest struct {
+	DatasetID DatasetID `json:"id"`
+}
+
+type DatasetInfoResponse struct {
+	DatasetID   DatasetID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	TableIDs    []TableID  `json:"table_id_list"`
+	VolumeIDs   []VolumeID `json:"volume_id_list"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   string     `json:"created_at"`
+	UpdatedAt   string     `json:"updated_at"`
+}
+
+type DatasetListRequest struct {
+	CommonCondition
+	Keyword string `json:"keyword"`
+}
+
+type DatasetListResponse struct {
+	Total int                    `json:"total"`
+	List  []*DatasetInfoResponse `json:"list"`
+}
+
 // ============ Handler: File types ============
 
 type FileCreateRequest struct {
@@ -1133,13 +1566,52 @@ type FileInfoResponse struct {
 	Size          int64  `json:"size"`
 	ParentID      string `json:"parent_id"`
 	VolumeID      string `json:"volume_id"`
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
+	// Hash is the hex-encoded digest of the content recorded at upload:
+	// MD5, or SHA-256 when it is 64 characters long. Empty if unknown.
+	Hash      string `json:"hash,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	// Tags are the key/value tags attached to the file.
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 type FileListRequest struct {
 	CommonCondition
 	Keyword string `json:"keyword"`
+	// TagFilters restricts the result to files carrying every listed tag.
+	TagFilters []FileTagFilter `json:"tag_filters,omitempty"`
+}
+
+// FileTagFilter matches files by tag. A file matches when it has the tag
+// Key with one of Values, or with any value when Values is empty.
+type FileTagFilter struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values,omitempty"`
+}
+
+// FileTreeRequest asks for the folders and files below a folder of a volume.
+type FileTreeRequest struct {
+	VolumeID VolumeID `json:"volume_id"`
+	// FolderID is the folder to start from; empty means the volume root.
+	FolderID FileID `json:"folder_id,omitempty"`
+	// Depth is the number of levels returned, 1 for the direct children
+	// only. 0 returns the whole tree.
+	Depth int `json:"depth"`
+}
+
+// FileTreeNode is a file or folder with, for a folder, its content.
+type FileTreeNode struct {
+	VolumeChildrenResponse
+	// Children holds the content of a folder, folders first. It is empty
+	// for files and for folders at the depth limit.
+	Children []*FileTreeNode `json:"children,omitempty"`
+	// HasChildren reports whether a folder has content, including when
+	// that content was cut off by the depth limit.
+	HasChildren bool `json:"has_children"`
+}
+
+type FileTreeResponse struct {
+	Nodes []*FileTreeNode `json:"nodes"`
 }
 
 type FileListResponse struct {
@@ -1179,6 +1651,62 @@ type FilePreviewStreamRequest struct {
 	FileID FileID `json:"file_id"`
 }
 
+// FileMoveRequest moves a file into another folder or volume.
+type FileMoveRequest struct {
+	FileID FileID `json:"id"`
+	// VolumeID is the destination volume; empty keeps the file's volume.
+	VolumeID VolumeID `json:"volume_id,omitempty"`
+	// ParentID is the destination folder; empty means the volume root.
+	ParentID FileID `json:"parent_id"`
+	// Name renames the file on the way; empty keeps its name.
+	Name string `json:"name,omitempty"`
+}
+
+type FileMoveResponse struct {
+	FileID FileID `json:"id"`
+}
+
+// FileCopyRequest copies a file into another folder or volume.
+type FileCopyRequest struct {
+	FileID FileID `json:"id"`
+	// VolumeID is the destination volume; empty keeps the file's volume.
+	VolumeID VolumeID `json:"volume_id,omitempty"`
+	// ParentID is the destination folder; empty means the volume root.
+	ParentID FileID `json:"parent_id"`
+	// Name is the name of the copy; empty keeps the source name.
+	Name string `json:"name,omitempty"`
+}
+
+type FileCopyResponse struct {
+	FileID FileID `json:"id"`
+	Name   string `json:"name"`
+}
+
+// FileTagsSetRequest changes the tags of a file.
+type FileTagsSetRequest struct {
+	FileID FileID            `json:"id"`
+	Tags   map[string]string `json:"tags"`
+	// Replace makes Tags the complete set of tags of the file. Otherwise
+	// Tags are merged into the existing ones and keys given an empty value
+	// are removed.
+	Replace bool `json:"replace,omitempty"`
+}
+
+type FileTagsSetResponse struct {
+	FileID FileID `json:"id"`
+	// Tags are the tags of the file after the change.
+	Tags map[string]string `json:"tags"`
+}
+
+type FileTagsGetRequest struct {
+	FileID FileID `json:"id"`
+}
+
+type FileTagsGetResponse struct {
+	FileID FileID            `json:"id"`
+	Tags   map[string]string `json:"tags"`
+}
+
 // ============ Handler: Folder types ============
 
 type FolderCreateRequest struct {
@@ -1253,7 +1781,7 @@ type RoleInfoRequest struct {
 type RoleInfoResponse struct {
 	RoleID           RoleID             `json:"id"`
 	RoleName         string             `json:"name"`
-	Status           string             `json:"status"`
+	Status           RoleStatus         `json:"status"`
 	Reserved         bool               `json:"reserved"`
 	Comment          string             `json:"description"`
 	AuthorityList    []*PrivResponse    `json:"authority_list"`
@@ -1334,11 +1862,31 @@ type PrivAndRoleList struct {
 }
 
 type SimpleRoleResponse struct {
-	RoleID    RoleID `json:"id"`
-	RoleName  string `json:"name"`
-	Status    string `json:"status"`
-	Reserved  bool   `json:"reserved"`
-	IsObjPriv bool   `json:"is_obj_priv"`
+	RoleID    RoleID     `json:"id"`
+	RoleName  string     `json:"name"`
+	Status    RoleStatus `json:"status"`
+	Reserved  bool       `json:"reserved"`
+	IsObjPriv bool       `json:"is_obj_priv"`
+}
+
+// ============ Handler: Auth types ============
+
+type AuthLoginRequest struct {
+	UserName string `json:"name"`
+	Password string `json:"password"`
+}
+
+type AuthLoginResponse struct {
+	Token string `json:"token"`
+	// ExpiresIn is the lifetime of the token in seconds; 0 means the
+	// server did not say.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+type AuthAPIKeyExchangeRequest struct{}
+
+type AuthAPIKeyExchangeResponse struct {
+	APIKey string `json:"api_key"`
 }
 
 // ============ Handler: User types ============
@@ -1467,6 +2015,20 @@ type PrivGetAuthorizedObjectsResponse struct {
 	ObjectIDList  []PrivObjectID `json:"object_id_list"`
 }
 
+type PrivCheckRequest struct {
+	CheckList []CheckPriv `json:"check_list"`
+}
+
+type PrivCheckResponse struct {
+	List []CheckPrivResult `json:"list"`
+}
+
+// CheckPrivResult is the answer to one CheckPriv.
+type CheckPrivResult struct {
+	CheckPriv
+	Allowed bool `json:"allowed"`
+}
+
 type PrivListObjByCategoryRequest struct {
 	ObjType string `json:"category"`
 }
@@ -1510,6 +2072,38 @@ type GenAIWorkflowStep struct {
 	Parameters map[string]map[string]interface{} `json:"parameters,omitempty"`
 }
 
+// GenAIWorkflowNode describes a node type accepted in GenAIWorkflowStep.Node
+// and CatalogWorkflowNode.Type.
+type GenAIWorkflowNode struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	// Components are the keys of the outer map of the step parameters.
+	Components []GenAINodeComponent `json:"components"`
+}
+
+// GenAINodeComponent is a configurable part of a workflow node.
+type GenAINodeComponent struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Parameters  []GenAINodeParameter `json:"parameters"`
+}
+
+// GenAINodeParameter describes one parameter of a node component.
+type GenAINodeParameter struct {
+	Name string `json:"name"`
+	// Type is one of the GenAIParamType constants.
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Default     any    `json:"default,omitempty"`
+	// Enum, if not empty, lists the accepted values.
+	Enum []any `json:"enum,omitempty"`
+}
+
+type GenAIWorkflowNodeListResponse struct {
+	Nodes []GenAIWorkflowNode `json:"nodes"`
+}
+
 type GenAICreateWorkflowResponse struct {
 	ID string `json:"id"`
 }
@@ -1529,20 +2123,71 @@ type GenAIGetJobDetailRequest struct {
 }
 
 type GenAIWorkflowJobFileResponse struct {
-	FileID       string `json:"file_id"`
-	FileName     string `json:"file_name"`
-	FileType     int    `json:"file_type"`
-	FileStatus   string `json:"file_status"`
-	ErrorMessage string `json:"error_message"`
-	StartTime    string `json:"start_time"`
-	EndTime      string `json:"end_time"`
+	FileID       string     `json:"file_id"`
+	FileName     string     `json:"file_name"`
+	FileType     int        `json:"file_type"`
+	FileStatus   TaskStatus `json:"file_status"`
+	ErrorMessage string     `json:"error_message"`
+	StartTime    string     `json:"start_time"`
+	EndTime      string     `json:"end_time"`
+	// RawFileStatus is file_status exactly as returned by the service.
+	RawFileStatus json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the file, keeping its status as returned in
+// RawFileStatus.
+func (r *GenAIWorkflowJobFileResponse) UnmarshalJSON(data []byte) error {
+	type plain GenAIWorkflowJobFileResponse
+	aux := struct {
+		*plain
+		FileStatus json.RawMessage `json:"file_status"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.FileStatus, r.RawFileStatus = decodeRawTaskStatus(aux.FileStatus)
+	return nil
+}
+
+// MarshalJSON encodes the file, writing RawFileStatus back unless
+// FileStatus was changed since it was decoded.
+func (r GenAIWorkflowJobFileResponse) MarshalJSON() ([]byte, error) {
+	type plain GenAIWorkflowJobFileResponse
+	return json.Marshal(struct {
+		plain
+		FileStatus json.RawMessage `json:"file_status"`
+	}{plain(r), encodeRawTaskStatus(r.FileStatus, r.RawFileStatus)})
 }
 
 type GenAIGetJobDetailResponse struct {
+	// Status is the status of the job as returned by the service; use
+	// JobStatus for its TaskStatus.
 	Status string                         `json:"status"`
 	Files  []GenAIWorkflowJobFileResponse `json:"files"`
 }
 
+// JobStatus returns the status of the job parsed with ParseTaskStatus.
+func (r *GenAIGetJobDetailResponse) JobStatus() TaskStatus {
+	if r == nil {
+		return TaskStatusUnknown
+	}
+	return ParseTaskStatus(r.Status)
+}
+
+// FailedFiles returns the files of the job whose status is a failure.
+func (r *GenAIGetJobDetailResponse) FailedFiles() []GenAIWorkflowJobFileResponse {
+	if r == nil {
+		return nil
+	}
+	var failed []GenAIWorkflowJobFileResponse
+	for _, f := range r.Files {
+		if f.FileStatus == TaskStatusFailed {
+			failed = append(failed, f)
+		}
+	}
+	return failed
+}
+
 type GenAIDownloadFileResultRequest struct {
 	FileID string `uri:"file_id"`
 }
@@ -1582,6 +2227,24 @@ func (s WorkflowJobStatus) String() string {
 	}
 }
 
+// IsTerminal reports whether the job will no longer change status.
+func (s WorkflowJobStatus) IsTerminal() bool {
+	return s == WorkflowJobStatusCompleted || s == WorkflowJobStatusFailed
+}
+
+// ParseWorkflowJobStatus converts a status name returned by String, or its
+// number, into a WorkflowJobStatus. Matching is case-insensitive and
+// unrecognized values yield WorkflowJobStatusUnknown.
+func ParseWorkflowJobStatus(status string) WorkflowJobStatus {
+	status = strings.ToLower(strings.TrimSpace(status))
+	for _, s := range []WorkflowJobStatus{WorkflowJobStatusRunning, WorkflowJobStatusCompleted, WorkflowJobStatusFailed} {
+		if status == s.String() || status == strconv.Itoa(int(s)) {
+			return s
+		}
+	}
+	return WorkflowJobStatusUnknown
+}
+
 // WorkflowMetadata represents workflow metadata for creating a workflow.
 // This is used by the CreateWorkflow API endpoint.
 type WorkflowMetadata struct {
@@ -1617,8 +2280,9 @@ type CatalogWorkflowConnection struct {
 	ReceiverPort string `json:"receiver_port,omitempty"` // Optional port for receiver component
 }
 
-// WorkflowCreateResponse represents the response from creating a workflow.
-type WorkflowCreateResponse struct {
+// WorkflowResponse describes a stored workflow as returned by the workflow
+// create, get, update and list APIs.
+type WorkflowResponse struct {
 	CreatedAt         string `json:"created_at"`
 	Creator           string `json:"creator"`
 	Content           string `json:"content"`
@@ -1640,6 +2304,50 @@ type WorkflowCreateResponse struct {
 	Files             string `json:"files"`
 }
 
+// WorkflowCreateResponse represents the response from creating a workflow.
+type WorkflowCreateResponse = WorkflowResponse
+
+// WorkflowDeleteResponse represents the response from deleting a workflow.
+type WorkflowDeleteResponse struct {
+	ID string `json:"id"`
+}
+
+// WorkflowListRequest represents a request to list workflows.
+type WorkflowListRequest struct {
+	Name     string `json:"name,omitempty"`      // Filter by workflow name (fuzzy match)
+	Page     int    `json:"page,omitempty"`      // Page number (starts from 1, default 1)
+	PageSize int    `json:"page_size,omitempty"` // Page size (default 20)
+}
+
+// WorkflowListResponse represents the response from listing workflows.
+type WorkflowListResponse struct {
+	Workflows []WorkflowResponse `json:"workflows"`
+	Total     int                `json:"total"`
+}
+
+// WorkflowRunRequest represents a request to run a workflow on demand.
+type WorkflowRunRequest struct {
+	FileIDs []string `json:"file_ids,omitempty"` // Files to process; empty processes the whole source volume
+}
+
+// WorkflowRunResponse represents the response from starting a workflow run
+// or re-running files of a job.
+type WorkflowRunResponse struct {
+	JobID   string   `json:"job_id"`
+	FileIDs []string `json:"file_ids,omitempty"` // Files scheduled by the run, when reported
+}
+
+// WorkflowJobStopResponse represents the response from stopping a workflow job.
+type WorkflowJobStopResponse struct {
+	JobID  string `json:"job_id"`
+	Status string `json:"status"`
+}
+
+// WorkflowJobRerunRequest represents a request to re-run files of a workflow job.
+type WorkflowJobRerunRequest struct {
+	FileIDs []string `json:"file_ids"`
+}
+
 // WorkflowJobListRequest represents a request to list workflow jobs.
 type WorkflowJobListRequest struct {
 	WorkflowID   string `json:"workflow_id,omitempty"`    // Filter by workflow ID
@@ -1647,6 +2355,16 @@ type WorkflowJobListRequest struct {
 	Status       string `json:"status,omitempty"`         // Filter by job status
 	Page         int    `json:"page,omitempty"`           // Page number (starts from 1, default 1)
 	PageSize     int    `json:"page_size,omitempty"`      // Page size (default 20)
+
+	// Statuses filters by any of these statuses, in addition to Status.
+	Statuses []WorkflowJobStatus `json:"-"`
+	// SourceFileIDs filters by any of these source files, in addition to
+	// SourceFileID.
+	SourceFileIDs []string `json:"-"`
+	// StartedAfter and StartedBefore bound the start time of the jobs.
+	// StartedAfter is inclusive and StartedBefore exclusive.
+	StartedAfter  time.Time `json:"-"`
+	StartedBefore time.Time `json:"-"`
 }
 
 // WorkflowJob represents a workflow job in the list.
@@ -1672,6 +2390,13 @@ type workflowJobRaw struct {
 	Description map[string]interface{} `json:"description,omitempty"` // May contain triggerTaskID
 }
 
+// workflowJobListRaw is the raw API response of listing workflow jobs,
+// converted to WorkflowJobListResponse.
+type workflowJobListRaw struct {
+	Jobs  []workflowJobRaw `json:"jobs"`
+	Total int              `json:"total"`
+}
+
 // WorkflowJobListResponse represents the response from listing workflow jobs.
 // This matches the API response structure: {"code":"ok","msg":"ok","data":{"total":1,"jobs":[...]}}
 type WorkflowJobListResponse struct {
@@ -1938,6 +2663,25 @@ type LLMChatMessageCreateRequest struct {
 	Tags            []string         `json:"tags,omitempty"`             // Optional: Tag names list
 }
 
+// LLMChatMessageBatchCreateRequest represents a request to create many chat messages at once.
+type LLMChatMessageBatchCreateRequest struct {
+	Messages []LLMChatMessageCreateRequest `json:"messages"` // Required: Messages to create, in order
+}
+
+// LLMChatMessageBatchResult is the outcome of one message of a batch creation.
+type LLMChatMessageBatchResult struct {
+	Index   int             `json:"index"`             // Position of the message in the request
+	Message *LLMChatMessage `json:"message,omitempty"` // Created message, nil on failure
+	Error   string          `json:"error,omitempty"`   // Reason the message was not created
+}
+
+// LLMChatMessageBatchCreateResponse represents a response from creating chat messages in batch.
+type LLMChatMessageBatchCreateResponse struct {
+	Results      []LLMChatMessageBatchResult `json:"results"`       // One result per requested message
+	SuccessCount int                         `json:"success_count"` // Number of messages created
+	FailureCount int                         `json:"failure_count"` // Number of messages rejected
+}
+
 // LLMChatMessageListRequest represents a request to list chat messages.
 type LLMChatMessageListRequest struct {
 	UserID    string           `json:"user_id"`              // Required: User ID
@@ -1999,6 +2743,137 @@ type LLMLatestCompletedMessageResponse struct {
 	MessageID int64 `json:"message_id"`
 }
 
+// LLMUsageGroupBy is a dimension LLM usage statistics can be grouped by.
+type LLMUsageGroupBy string
+
+const (
+	LLMUsageGroupBySource LLMUsageGroupBy = "source" // Group by application name
+	LLMUsageGroupByUser   LLMUsageGroupBy = "user"   // Group by user ID
+	LLMUsageGroupByModel  LLMUsageGroupBy = "model"  // Group by model name
+)
+
+// LLMUsageBucket is the width of the time buckets of LLM usage statistics.
+type LLMUsageBucket string
+
+const (
+	LLMUsageBucketHour  LLMUsageBucket = "hour"  // One group per hour
+	LLMUsageBucketDay   LLMUsageBucket = "day"   // One group per day
+	LLMUsageBucketWeek  LLMUsageBucket = "week"  // One group per week
+	LLMUsageBucketMonth LLMUsageBucket = "month" // One group per month
+)
+
+// LLMUsageStatsFilter selects the chat messages LLM usage statistics are computed on.
+type LLMUsageStatsFilter struct {
+	UserID    string            // Optional: Only messages of this user
+	Source    string            // Optional: Only messages of this application
+	Model     string            // Optional: Only messages sent to this model
+	StartTime int64             // Optional: Messages created at or after this time (Unix timestamp in seconds)
+	EndTime   int64             // Optional: Messages created before this time (Unix timestamp in seconds)
+	GroupBy   []LLMUsageGroupBy // Optional: Dimensions to group the statistics by
+	Bucket    LLMUsageBucket    // Optional: Also group by time buckets of this width
+}
+
+// LLMUsageStats are the usage figures of a set of chat messages.
+type LLMUsageStats struct {
+	MessageCount     int64            `json:"message_count"`     // Number of messages
+	SuccessCount     int64            `json:"success_count"`     // Messages with the success status
+	FailedCount      int64            `json:"failed_count"`      // Messages with the failed status
+	AbortedCount     int64            `json:"aborted_count"`     // Messages with the aborted status
+	ErrorRate        float64          `json:"error_rate"`        // Failed messages over all messages, from 0 to 1
+	PromptTokens     int64            `json:"prompt_tokens"`     // Prompt tokens, for messages that recorded them
+	CompletionTokens int64            `json:"completion_tokens"` // Completion tokens, for messages that recorded them
+	TotalTokens      int64            `json:"total_tokens"`      // Total tokens, for messages that recorded them
+	Models           map[string]int64 `json:"models"`            // Number of messages per model
+}
+
+// LLMUsageStatsGroup holds the usage figures of one group. Only the fields of
+// the requested dimensions are set.
+type LLMUsageStatsGroup struct {
+	Source      string `json:"source,omitempty"`       // Application name
+	UserID      string `json:"user_id,omitempty"`      // User ID
+	Model       string `json:"model,omitempty"`        // Model name
+	BucketStart int64  `json:"bucket_start,omitempty"` // Start of the time bucket (Unix timestamp in seconds)
+	LLMUsageStats
+}
+
+// LLMUsageStatsResponse represents a response from getting LLM usage statistics.
+type LLMUsageStatsResponse struct {
+	Total  LLMUsageStats        `json:"total"`  // Figures of all the selected messages
+	Groups []LLMUsageStatsGroup `json:"groups"` // Figures per group, when grouping was requested
+}
+
+// LLMCompletionMessage is a message of an OpenAI-compatible chat completion.
+type LLMCompletionMessage struct {
+	Role    LLMMessageRole `json:"role,omitempty"` // Message role
+	Content string         `json:"content"`        // Message content
+	Name    string         `json:"name,omitempty"` // Optional: Name of the participant
+}
+
+// LLMCompletionRequest represents an OpenAI-compatible chat completion request.
+//
+// When SessionID is set, the exchange is recorded as an LLMChatMessage of that
+// session; UserID and Source are then required. The recording fields are not
+// sent to the model.
+type LLMCompletionRequest struct {
+	Model       string                 `json:"model"`                 // Required: Model name
+	Messages    []LLMCompletionMessage `json:"messages"`              // Required: Conversation so far
+	Temperature *float64               `json:"temperature,omitempty"` // Optional: Sampling temperature
+	TopP        *float64               `json:"top_p,omitempty"`       // Optional: Nucleus sampling
+	MaxTokens   *int                   `json:"max_tokens,omitempty"`  // Optional: Maximum tokens to generate
+	Stop        []string               `json:"stop,omitempty"`        // Optional: Stop sequences
+	Stream      bool                   `json:"stream,omitempty"`      // Set by the streaming variant
+	User        string                 `json:"user,omitempty"`        // Optional: End-user identifier
+
+	SessionID *int64   `json:"-"` // Optional: Session to record the exchange in
+	UserID    string   `json:"-"` // User ID of the recorded message
+	Source    string   `json:"-"` // Application name of the recorded message
+	Tags      []string `json:"-"` // Optional: Tags of the recorded message
+}
+
+// LLMCompletionUsage reports the tokens used by a chat completion.
+type LLMCompletionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// LLMCompletionChoice is a generated reply of a chat completion.
+type LLMCompletionChoice struct {
+	Index        int                  `json:"index"`
+	Message      LLMCompletionMessage `json:"message"`
+	FinishReason string               `json:"finish_reason"`
+}
+
+// LLMCompletionResponse represents an OpenAI-compatible chat completion response.
+type LLMCompletionResponse struct {
+	ID      string                `json:"id"`
+	Object  string                `json:"object"`
+	Created int64                 `json:"created"` // Creation time (Unix timestamp in seconds)
+	Model   string                `json:"model"`
+	Choices []LLMCompletionChoice `json:"choices"`
+	Usage   *LLMCompletionUsage   `json:"usage,omitempty"`
+
+	// Recorded is the message recorded in the session, if one was chosen.
+	Recorded *LLMChatMessage `json:"-"`
+}
+
+// LLMCompletionChunkChoice is the part of a reply carried by a streamed chunk.
+type LLMCompletionChunkChoice struct {
+	Index        int                  `json:"index"`
+	Delta        LLMCompletionMessage `json:"delta"`
+	FinishReason string               `json:"finish_reason"`
+}
+
+// LLMCompletionChunk is an event of a streamed chat completion.
+type LLMCompletionChunk struct {
+	ID      string                     `json:"id"`
+	Object  string                     `json:"object"`
+	Created int64                      `json:"created"`
+	Model   string                     `json:"model"`
+	Choices []LLMCompletionChunkChoice `json:"choices"`
+	Usage   *LLMCompletionUsage        `json:"usage,omitempty"`
+}
+
 // LLMModifySessionMessageResponseResponse represents a response from modifying a session message's modified response.
 type LLMModifySessionMessageResponseResponse struct {
 	Message          string `json:"message"`
@@ -2180,10 +3055,197 @@ type CancelAnalyzeResponse struct {
 	UserName  string `json:"user_name"`  // User name who cancelled the request
 }
 
+// AnalysisSessionListRequest represents a request to list past data analysis sessions.
+type AnalysisSessionListRequest struct {
+	Source   string `json:"source,omitempty"`    // Optional: Only sessions started from this source
+	Keyword  string `json:"keyword,omitempty"`   // Optional: Keyword search on session names
+	Page     int    `json:"page,omitempty"`      // Optional: Page number (starts from 1, default 1)
+	PageSize int    `json:"page_size,omitempty"` // Optional: Page size (default 20)
+}
+
+// AnalysisSession is a data analysis session of the current user.
+type AnalysisSession struct {
+	SessionID   string   `json:"session_id"`
+	SessionName string   `json:"session_name"`
+	Source      string   `json:"source"`
+	RequestIDs  []string `json:"request_ids"` // Analyses of the session, oldest first
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+// AnalysisSessionListResponse represents the response from listing data analysis sessions.
+type AnalysisSessionListResponse struct {
+	Total int               `json:"total"`
+	List  []AnalysisSession `json:"list"`
+}
+
+// AnalysisResultResponse is a previous data analysis, with the events it streamed.
+type AnalysisResultResponse struct {
+	RequestID      string                     `json:"request_id"`
+	SessionID      string                     `json:"session_id"`
+	SessionName    string                     `json:"session_name"`
+	Question       string                     `json:"question"`
+	Status         string                     `json:"status"` // "running", "completed", "failed" or "cancelled"
+	Classification *QuestionType              `json:"question_type,omitempty"`
+	Events         []*DataAnalysisStreamEvent `json:"events"` // Events in the order they were streamed
+	CreatedAt      string                     `json:"created_at"`
+	FinishedAt     string                     `json:"finished_at,omitempty"`
+}
+
+// AnalysisRating is the verdict of a user on a data analysis answer.
+type AnalysisRating string
+
+const (
+	AnalysisRatingPositive AnalysisRating = "positive" // The answer was right
+	AnalysisRatingNegative AnalysisRating = "negative" // The answer was wrong
+)
+
+// AnalysisFeedbackRequest represents feedback on a data analysis answer.
+type AnalysisFeedbackRequest struct {
+	RequestID    string         `json:"request_id"`              // Required: The analysis the feedback is about
+	Rating       AnalysisRating `json:"rating"`                  // Required: Whether the answer was right
+	CorrectedSQL string         `json:"corrected_sql,omitempty"` // Optional: The SQL that should have been generated
+	Comment      string         `json:"comment,omitempty"`       // Optional: Free-form remarks
+}
+
+// AnalysisFeedbackResponse represents the response from submitting analysis feedback.
+type AnalysisFeedbackResponse struct {
+	FeedbackID string `json:"feedback_id"`
+	RequestID  string `json:"request_id"`
+}
+
+// PassageSearchRequest represents a vector similarity search over the
+// files indexed for RAG. At least one of VolumeIDs, DatasetIDs and FileIDs
+// must be set.
+type PassageSearchRequest struct {
+	Query      string      `json:"query"`
+	TopK       int         `json:"top_k"`
+	VolumeIDs  []VolumeID  `json:"volume_ids,omitempty"`
+	DatasetIDs []DatasetID `json:"dataset_ids,omitempty"`
+	FileIDs    []FileID    `json:"file_ids,omitempty"`
+	MinScore   float64     `json:"min_score,omitempty"` // Drop passages scoring below this
+}
+
+// FilePassage is a passage of a file returned by a passage search.
+type FilePassage struct {
+	Score    float64        `json:"score"` // Similarity to the query, higher is closer
+	Content  string         `json:"content"`
+	ChunkID  string         `json:"chunk_id"`
+	FileID   FileID         `json:"file_id"`
+	FileName string         `json:"file_name"`
+	VolumeID VolumeID       `json:"volume_id"`
+	Page     int            `json:"page,omitempty"` // Page of the source document, if known
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+type PassageSearchResponse struct {
+	Passages []FilePassage `json:"passages"`
+}
+
 // ============ Handler: Task types ============
 
 type TaskID int64
 
+// TaskStatus represents the lifecycle state of a task.
+//
+// The task API reports the status as a free-form string; ParseTaskStatus
+// maps it onto this enum.
+type TaskStatus int
+
+const (
+	TaskStatusUnknown   TaskStatus = 0 // Status not recognized
+	TaskStatusPending   TaskStatus = 1 // Task is queued and has not started
+	TaskStatusRunning   TaskStatus = 2 // Task is running
+	TaskStatusSucceeded TaskStatus = 3 // Task finished successfully
+	TaskStatusFailed    TaskStatus = 4 // Task finished with an error
+	TaskStatusCancelled TaskStatus = 5 // Task was cancelled or stopped
+)
+
+// String returns the string representation of the task status.
+func (s TaskStatus) String() string {
+	switch s {
+	case TaskStatusPending:
+		return "pending"
+	case TaskStatusRunning:
+		return "running"
+	case TaskStatusSucceeded:
+		return "succeeded"
+	case TaskStatusFailed:
+		return "failed"
+	case TaskStatusCancelled:
+		return "cancelled"
+	case TaskStatusUnknown:
+		return "unknown"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// IsTerminal reports whether the task will no longer change state.
+func (s TaskStatus) IsTerminal() bool {
+	return s == TaskStatusSucceeded || s == TaskStatusFailed || s == TaskStatusCancelled
+}
+
+// MarshalJSON encodes the status as its string form.
+func (s TaskStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a status string, or a numeric code, with
+// ParseTaskStatus. Unrecognized values, and values of other JSON types,
+// decode to TaskStatusUnknown rather than failing the response; the
+// responses holding a TaskStatus keep the value as returned in a raw field.
+func (s *TaskStatus) UnmarshalJSON(data []byte) error {
+	*s = ParseTaskStatus(decodeLenientString(data))
+	return nil
+}
+
+// decodeRawTaskStatus decodes a status field, returning it both parsed and
+// as returned by the service.
+func decodeRawTaskStatus(data json.RawMessage) (TaskStatus, json.RawMessage) {
+	if len(data) == 0 {
+		return TaskStatusUnknown, nil
+	}
+	var s TaskStatus
+	_ = s.UnmarshalJSON(data)
+	return s, data
+}
+
+// encodeRawTaskStatus returns raw if it still decodes to s, so that a value
+// the SDK does not recognize, or a numeric code, is written back as it was
+// received, and the string form of s otherwise.
+func encodeRawTaskStatus(s TaskStatus, raw json.RawMessage) json.RawMessage {
+	if parsed, _ := decodeRawTaskStatus(raw); len(raw) > 0 && parsed == s {
+		return raw
+	}
+	data, _ := s.MarshalJSON()
+	return data
+}
+
+// ParseTaskStatus converts the status string returned by the task API into a
+// TaskStatus. Matching is case-insensitive and accepts the synonyms used by
+// the different task types, as well as the number of a status; unrecognized
+// values yield TaskStatusUnknown.
+func ParseTaskStatus(status string) TaskStatus {
+	status = strings.ToLower(strings.TrimSpace(status))
+	switch status {
+	case "pending", "waiting", "queued", "created", "init":
+		return TaskStatusPending
+	case "running", "processing", "loading", "in_progress":
+		return TaskStatusRunning
+	case "success", "succeeded", "successful", "completed", "complete", "finished", "done":
+		return TaskStatusSucceeded
+	case "failed", "failure", "fail", "error":
+		return TaskStatusFailed
+	case "cancelled", "canceled", "stopped", "aborted":
+		return TaskStatusCancelled
+	}
+	if n, err := strconv.Atoi(status); err == nil && n > int(TaskStatusUnknown) && n <= int(TaskStatusCancelled) {
+		return TaskStatus(n)
+	}
+	return TaskStatusUnknown
+}
+
 // TaskInfoRequest represents a request to get task information.
 type TaskInfoRequest struct {
 	TaskID TaskID `json:"task_id" form:"task_id"`
@@ -2199,7 +3261,7 @@ type TaskInfoResponse struct {
 	VolumePath          *FullPath              `json:"volume_path,omitempty"`
 	Name                string                 `json:"name"`
 	Creator             string                 `json:"creator"`
-	Status              string                 `json:"status"`
+	Status              TaskStatus             `json:"status"`
 	SourceConfig        map[string]interface{} `json:"source_config,omitempty"`
 	StartAt             string                 `json:"start_at,omitempty"`
 	EndAt               string                 `json:"end_at,omitempty"`
@@ -2209,6 +3271,34 @@ type TaskInfoResponse struct {
 	TablePath           *FullPath              `json:"table_path,omitempty"`
 	SourceFiles         [][]string             `json:"source_files,omitempty"`
 	LoadResults         []*LoadResult          `json:"load_results,omitempty"`
+	// RawStatus is the status exactly as returned by the service, which
+	// Status may not recognize.
+	RawStatus json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the task, keeping its status as returned in
+// RawStatus.
+func (r *TaskInfoResponse) UnmarshalJSON(data []byte) error {
+	type plain TaskInfoResponse
+	aux := struct {
+		*plain
+		Status json.RawMessage `json:"status"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	r.Status, r.RawStatus = decodeRawTaskStatus(aux.Status)
+	return nil
+}
+
+// MarshalJSON encodes the task, writing RawStatus back unless Status was
+// changed since it was decoded.
+func (r TaskInfoResponse) MarshalJSON() ([]byte, error) {
+	type plain TaskInfoResponse
+	return json.Marshal(struct {
+		plain
+		Status json.RawMessage `json:"status"`
+	}{plain(r), encodeRawTaskStatus(r.Status, r.RawStatus)})
 }
 
 // LoadResult represents a single file load result.
@@ -2216,3 +3306,159 @@ type LoadResult struct {
 	Lines  int64  `json:"lines"`
 	Reason string `json:"reason,omitempty"`
 }
+
+// LoadTaskSourceConfig describes where a load task reads its files from.
+type LoadTaskSourceConfig struct {
+	// ConnectorID is the connector to read from; 0 for files uploaded with
+	// UploadLocalFile, which are then listed in ConnFileIDs.
+	ConnectorID uint64 `json:"connector_id,omitempty"`
+	// URIs are the files or folders to load from the connector.
+	URIs        []string `json:"uris,omitempty"`
+	ConnFileIDs []string `json:"conn_file_ids,omitempty"`
+	// FileTypes and PathRegex filter the files found under URIs. Build
+	// FileTypes with FileTypeCodes.
+	FileTypes []int32             `json:"file_types,omitempty"`
+	PathRegex string              `json:"path_regex,omitempty"`
+	Csv       *ConnectorCsvConfig `json:"csv,omitempty"`
+}
+
+// LoadTaskTargetConfig describes where a load task writes to: either a
+// volume, for unstructured files, or a table.
+type LoadTaskTargetConfig struct {
+	VolumeID VolumeID     `json:"volume_id,omitempty"`
+	Table    *TableConfig `json:"table,omitempty"`
+	Dedup    *DedupConfig `json:"dedup,omitempty"`
+}
+
+type LoadTaskCreateRequest struct {
+	Name   string               `json:"name"`
+	Source LoadTaskSourceConfig `json:"source"`
+	Target LoadTaskTargetConfig `json:"target"`
+}
+
+type LoadTaskCreateResponse struct {
+	TaskID TaskID `json:"task_id"`
+}
+
+type LoadTaskListRequest struct {
+	Name     string `json:"name,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Page     int    `json:"page,omitempty"`
+	PageSize int    `json:"page_size,omitempty"`
+}
+
+type LoadTaskListResponse struct {
+	Tasks []TaskInfoResponse `json:"tasks"`
+	Total int                `json:"total"`
+}
+
+type LoadTaskCancelRequest struct {
+	TaskID TaskID `json:"task_id"`
+}
+
+type LoadTaskCancelResponse struct{}
+
+type LoadTaskDeleteRequest struct {
+	TaskID TaskID `json:"task_id"`
+}
+
+type LoadTaskDeleteResponse struct{}
+
+// ============ Handler: Trash types ============
+
+// TrashID identifies an item of the trash.
+type TrashID string
+
+// Types of the objects that can be moved to the trash.
+const (
+	TrashObjectFile   = "file"
+	TrashObjectFolder = "folder"
+	TrashObjectTable  = "table"
+)
+
+// TrashItem is an object moved to the trash, with the location it is
+// restored to.
+type TrashItem struct {
+	TrashID    TrashID    `json:"id"`
+	ObjectType string     `json:"object_type"`
+	ObjectID   string     `json:"object_id"`
+	Name       string     `json:"name"`
+	VolumeID   VolumeID   `json:"volume_id,omitempty"`
+	ParentID   FileID     `json:"parent_id,omitempty"`
+	DatabaseID DatabaseID `json:"database_id,omitempty"`
+	Size       int64      `json:"size"`
+	DeletedAt  string     `json:"deleted_at"`
+	DeletedBy  string     `json:"deleted_by"`
+	// ExpiresAt is when the item is purged automatically; empty if never.
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+type TrashMoveRequest struct {
+	FileIDs   []FileID  `json:"file_ids,omitempty"`
+	FolderIDs []FileID  `json:"folder_ids,omitempty"`
+	TableIDs  []TableID `json:"table_ids,omitempty"`
+}
+
+type TrashMoveResponse struct {
+	Items []TrashItem `json:"items"`
+}
+
+type TrashListRequest struct {
+	CommonCondition
+	Keyword string `json:"keyword"`
+	// ObjectTypes restricts the items to these TrashObject types; empty
+	// for all.
+	ObjectTypes []string `json:"object_types,omitempty"`
+}
+
+type TrashListResponse struct {
+	Total int         `json:"total"`
+	List  []TrashItem `json:"list"`
+}
+
+type TrashRestoreRequest struct {
+	TrashIDs []TrashID `json:"ids"`
+}
+
+type TrashRestoreResponse struct {
+	Items []TrashItem `json:"items"`
+}
+
+type TrashPurgeRequest struct {
+	TrashIDs []TrashID `json:"ids,omitempty"`
+	// All purges every item of the trash instead of TrashIDs.
+	All bool `json:"all,omitempty"`
+}
+
+type TrashPurgeResponse struct {
+	Purged int `json:"purged"`
+}
+
+// ============ Handler: Account types ============
+
+// ResourceLimit is a limit of the account together with its current usage.
+// A Max of 0 means that the resource is unlimited.
+type ResourceLimit struct {
+	Max  int64 `json:"max"`
+	Used int64 `json:"used"`
+}
+
+// APIRateLimit is a rate limit applied to the API requests of the account.
+type APIRateLimit struct {
+	// Scope is the group of endpoints the limit applies to, such as
+	// "default" or "upload".
+	Scope         string `json:"scope"`
+	Limit         int    `json:"limit"`
+	WindowSeconds int    `json:"window_seconds"`
+	Remaining     int    `json:"remaining"`
+	ResetAt       string `json:"reset_at,omitempty"`
+}
+
+type AccountLimitsResponse struct {
+	Databases ResourceLimit `json:"databases"`
+	Tables    ResourceLimit `json:"tables"`
+	Volumes   ResourceLimit `json:"volumes"`
+	// VolumeStorage is the storage of all volumes, in bytes.
+	VolumeStorage ResourceLimit  `json:"volume_storage"`
+	RateLimits    []APIRateLimit `json:"rate_limits"`
+}
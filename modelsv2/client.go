@@ -0,0 +1,185 @@
+package modelsv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// Client calls the APIs of a RawClient and returns v2 models. Requests and
+// errors are those of the RawClient.
+type Client struct {
+	raw sdk.RawClientAPI
+}
+
+// NewClient returns a Client sending its requests with raw, typically an
+// *sdk.RawClient.
+//
+// Example:
+//
+//	v2 := modelsv2.NewClient(rawClient)
+//	task, err := v2.GetLoadTask(ctx, taskID)
+func NewClient(raw sdk.RawClientAPI) *Client {
+	return &Client{raw: raw}
+}
+
+// Convert returns the v2 model T of v, a model of package sdk, for values
+// obtained from calls Client does not wrap.
+//
+// Example:
+//
+//	files, err := sdkClient.FindFilesByName(ctx, name, volumeID)
+//	if err != nil {
+//		return err
+//	}
+//	v2files, err := modelsv2.Convert[modelsv2.FileListResponse](files)
+func Convert[T any](v any) (*T, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encode %T: %w", v, err)
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("decode %T: %w", out, err)
+	}
+	return &out, nil
+}
+
+// convert converts the result of a RawClient call, keeping nil results nil.
+func convert[T any](v any, err error) (*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	if rv := reflect.ValueOf(v); !rv.IsValid() || rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, nil
+	}
+	return Convert[T](v)
+}
+
+// ListCatalogs is RawClient.ListCatalogs returning a v2 model.
+func (c *Client) ListCatalogs(ctx context.Context, opts ...sdk.CallOption) (*CatalogListResponse, error) {
+	return convert[CatalogListResponse](c.raw.ListCatalogs(ctx, opts...))
+}
+
+// GetDatabase is RawClient.GetDatabase returning a v2 model.
+func (c *Client) GetDatabase(ctx context.Context, req *sdk.DatabaseInfoRequest, opts ...sdk.CallOption) (*DatabaseInfoResponse, error) {
+	return convert[DatabaseInfoResponse](c.raw.GetDatabase(ctx, req, opts...))
+}
+
+// ListDatabases is RawClient.ListDatabases returning a v2 model.
+func (c *Client) ListDatabases(ctx context.Context, req *sdk.DatabaseListRequest, opts ...sdk.CallOption) (*DatabaseListResponse, error) {
+	return convert[DatabaseListResponse](c.raw.ListDatabases(ctx, req, opts...))
+}
+
+// GetDatabaseChildren is RawClient.GetDatabaseChildren returning a v2 model.
+func (c *Client) GetDatabaseChildren(ctx context.Context, req *sdk.DatabaseChildrenRequest, opts ...sdk.CallOption) (*DatabaseChildrenResponseData, error) {
+	return convert[DatabaseChildrenResponseData](c.raw.GetDatabaseChildren(ctx, req, opts...))
+}
+
+// GetTable is RawClient.GetTable returning a v2 model.
+func (c *Client) GetTable(ctx context.Context, req *sdk.TableInfoRequest, opts ...sdk.CallOption) (*TableInfoResponse, error) {
+	return convert[TableInfoResponse](c.raw.GetTable(ctx, req, opts...))
+}
+
+// GetVolume is RawClient.GetVolume returning a v2 model.
+func (c *Client) GetVolume(ctx context.Context, req *sdk.VolumeInfoRequest, opts ...sdk.CallOption) (*VolumeInfoResponse, error) {
+	return convert[VolumeInfoResponse](c.raw.GetVolume(ctx, req, opts...))
+}
+
+// GetDataset is RawClient.GetDataset returning a v2 model.
+func (c *Client) GetDataset(ctx context.Context, req *sdk.DatasetInfoRequest, opts ...sdk.CallOption) (*DatasetInfoResponse, error) {
+	return convert[DatasetInfoResponse](c.raw.GetDataset(ctx, req, opts...))
+}
+
+// GetFile is RawClient.GetFile returning a v2 model.
+func (c *Client) GetFile(ctx context.Context, req *sdk.FileInfoRequest, opts ...sdk.CallOption) (*FileInfoResponse, error) {
+	return convert[FileInfoResponse](c.raw.GetFile(ctx, req, opts...))
+}
+
+// ListFiles is RawClient.ListFiles returning a v2 model.
+func (c *Client) ListFiles(ctx context.Context, req *sdk.FileListRequest, opts ...sdk.CallOption) (*FileListResponse, error) {
+	return convert[FileListResponse](c.raw.ListFiles(ctx, req, opts...))
+}
+
+// GetTask is RawClient.GetTask returning a v2 model.
+func (c *Client) GetTask(ctx context.Context, req *sdk.TaskInfoRequest, opts ...sdk.CallOption) (*TaskInfoResponse, error) {
+	return convert[TaskInfoResponse](c.raw.GetTask(ctx, req, opts...))
+}
+
+// GetLoadTask is RawClient.GetLoadTask returning a v2 model.
+func (c *Client) GetLoadTask(ctx context.Context, taskID sdk.TaskID, opts ...sdk.CallOption) (*TaskInfoResponse, error) {
+	return convert[TaskInfoResponse](c.raw.GetLoadTask(ctx, taskID, opts...))
+}
+
+// ListLoadTasks is RawClient.ListLoadTasks returning a v2 model.
+func (c *Client) ListLoadTasks(ctx context.Context, req *sdk.LoadTaskListRequest, opts ...sdk.CallOption) (*LoadTaskListResponse, error) {
+	return convert[LoadTaskListResponse](c.raw.ListLoadTasks(ctx, req, opts...))
+}
+
+// GetUserDetail is RawClient.GetUserDetail returning a v2 model.
+func (c *Client) GetUserDetail(ctx context.Context, req *sdk.UserDetailInfoRequest, opts ...sdk.CallOption) (*UserDetailInfoResponse, error) {
+	return convert[UserDetailInfoResponse](c.raw.GetUserDetail(ctx, req, opts...))
+}
+
+// ListUsers is RawClient.ListUsers returning a v2 model.
+func (c *Client) ListUsers(ctx context.Context, req *sdk.UserListRequest, opts ...sdk.CallOption) (*UserListResponse, error) {
+	return convert[UserListResponse](c.raw.ListUsers(ctx, req, opts...))
+}
+
+// GetMyAPIKey is RawClient.GetMyAPIKey returning a v2 model.
+func (c *Client) GetMyAPIKey(ctx context.Context, opts ...sdk.CallOption) (*UserApiKeyResponse, error) {
+	return convert[UserApiKeyResponse](c.raw.GetMyAPIKey(ctx, opts...))
+}
+
+// GetRole is RawClient.GetRole returning a v2 model.
+func (c *Client) GetRole(ctx context.Context, req *sdk.RoleInfoRequest, opts ...sdk.CallOption) (*RoleInfoResponse, error) {
+	return convert[RoleInfoResponse](c.raw.GetRole(ctx, req, opts...))
+}
+
+// ListUserLogs is RawClient.ListUserLogs returning a v2 model.
+func (c *Client) ListUserLogs(ctx context.Context, req *sdk.LogLogListRequest, opts ...sdk.CallOption) (*LogLogListResponse, error) {
+	return convert[LogLogListResponse](c.raw.ListUserLogs(ctx, req, opts...))
+}
+
+// ListRoleLogs is RawClient.ListRoleLogs returning a v2 model.
+func (c *Client) ListRoleLogs(ctx context.Context, req *sdk.LogLogListRequest, opts ...sdk.CallOption) (*LogLogListResponse, error) {
+	return convert[LogLogListResponse](c.raw.ListRoleLogs(ctx, req, opts...))
+}
+
+// ListKnowledge is RawClient.ListKnowledge returning a v2 model.
+func (c *Client) ListKnowledge(ctx context.Context, req *sdk.NL2SQLKnowledgeListRequest, opts ...sdk.CallOption) (*NL2SQLKnowledgeListResponse, error) {
+	return convert[NL2SQLKnowledgeListResponse](c.raw.ListKnowledge(ctx, req, opts...))
+}
+
+// GetGenAIJob is RawClient.GetGenAIJob returning a v2 model.
+func (c *Client) GetGenAIJob(ctx context.Context, jobID string, opts ...sdk.CallOption) (*GenAIGetJobDetailResponse, error) {
+	return convert[GenAIGetJobDetailResponse](c.raw.GetGenAIJob(ctx, jobID, opts...))
+}
+
+// GetWorkflow is RawClient.GetWorkflow returning a v2 model.
+func (c *Client) GetWorkflow(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*WorkflowResponse, error) {
+	return convert[WorkflowResponse](c.raw.GetWorkflow(ctx, workflowID, opts...))
+}
+
+// ListWorkflows is RawClient.ListWorkflows returning a v2 model.
+func (c *Client) ListWorkflows(ctx context.Context, req *sdk.WorkflowListRequest, opts ...sdk.CallOption) (*WorkflowListResponse, error) {
+	return convert[WorkflowListResponse](c.raw.ListWorkflows(ctx, req, opts...))
+}
+
+// ListWorkflowJobs is RawClient.ListWorkflowJobs returning a v2 model.
+func (c *Client) ListWorkflowJobs(ctx context.Context, req *sdk.WorkflowJobListRequest, opts ...sdk.CallOption) (*WorkflowJobListResponse, error) {
+	return convert[WorkflowJobListResponse](c.raw.ListWorkflowJobs(ctx, req, opts...))
+}
+
+// ListAnalysisSessions is RawClient.ListAnalysisSessions returning a v2 model.
+func (c *Client) ListAnalysisSessions(ctx context.Context, req *sdk.AnalysisSessionListRequest, opts ...sdk.CallOption) (*AnalysisSessionListResponse, error) {
+	return convert[AnalysisSessionListResponse](c.raw.ListAnalysisSessions(ctx, req, opts...))
+}
+
+// GetAnalysisResult is RawClient.GetAnalysisResult returning a v2 model.
+func (c *Client) GetAnalysisResult(ctx context.Context, requestID string, opts ...sdk.CallOption) (*AnalysisResultResponse, error) {
+	return convert[AnalysisResultResponse](c.raw.GetAnalysisResult(ctx, requestID, opts...))
+}
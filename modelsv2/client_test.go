@@ -0,0 +1,54 @@
+package modelsv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+	"github.com/matrixorigin/moi-go-sdk/sdkmock"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fake := sdkmock.NewFake()
+	catalog, err := fake.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "sales"})
+	require.NoError(t, err)
+	db, err := fake.CreateDatabase(ctx, &sdk.DatabaseCreateRequest{DatabaseName: "orders", CatalogID: catalog.CatalogID})
+	require.NoError(t, err)
+
+	client := NewClient(fake)
+	got, err := client.GetDatabase(ctx, &sdk.DatabaseInfoRequest{DatabaseID: db.DatabaseID})
+	require.NoError(t, err)
+	require.Equal(t, "orders", got.DatabaseName)
+	require.False(t, got.CreatedAt.IsZero())
+	require.WithinDuration(t, time.Now(), got.CreatedAt.Time, 24*time.Hour)
+
+	catalogs, err := client.ListCatalogs(ctx)
+	require.NoError(t, err)
+	require.Len(t, catalogs.List, 1)
+	require.False(t, catalogs.List[0].CreatedAt.IsZero())
+
+	_, err = client.GetDatabase(ctx, nil)
+	require.ErrorIs(t, err, sdk.ErrNilRequest)
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+	job, err := Convert[WorkflowJob](&sdk.WorkflowJob{
+		JobID:     "j1",
+		Status:    sdk.WorkflowJobStatusRunning,
+		StartTime: "2024-05-01 08:30:00",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "j1", job.JobID)
+	require.Equal(t, sdk.WorkflowJobStatusRunning, job.Status)
+	require.True(t, time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC).Equal(job.StartTime.Time))
+	require.True(t, job.EndTime.IsZero())
+
+	_, err = Convert[WorkflowJob](&sdk.WorkflowJob{StartTime: "soon"})
+	require.Error(t, err)
+}
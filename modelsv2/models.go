@@ -0,0 +1,397 @@
+// Package modelsv2 provides the response models of the SDK with typed
+// timestamps. They mirror the models of package sdk, whose time fields are
+// strings in whatever format each API returns, with sdk.Time fields that
+// decode all of them.
+//
+// The package is opt-in: wrap a client with NewClient to get v2 models from
+// the same calls, or convert a model already at hand with Convert.
+//
+//	v2 := modelsv2.NewClient(rawClient)
+//	table, err := v2.GetTable(ctx, &sdk.TableInfoRequest{TableID: 1})
+//	if err != nil {
+//		return err
+//	}
+//	age := time.Since(table.CreatedAt.Time)
+package modelsv2
+
+import (
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// ============ Catalog and database ============
+
+// CatalogResponse is sdk.CatalogResponse with typed timestamps.
+type CatalogResponse struct {
+	CatalogID     sdk.CatalogID `json:"id"`
+	CatalogName   string        `json:"name"`
+	Comment       string        `json:"description"`
+	DatabaseCount int           `json:"database_count"`
+	TableCount    int           `json:"table_count"`
+	VolumeCount   int           `json:"volume_count"`
+	FileCount     int           `json:"file_count"`
+	Reserved      bool          `json:"reserved"`
+	CreatedAt     sdk.Time      `json:"created_at"`
+	CreatedBy     string        `json:"created_by"`
+	UpdatedAt     sdk.Time      `json:"updated_at"`
+	UpdatedBy     string        `json:"updated_by"`
+}
+
+// CatalogListResponse is sdk.CatalogListResponse with typed timestamps.
+type CatalogListResponse struct {
+	List []CatalogResponse `json:"list"`
+}
+
+// DatabaseResponse is sdk.DatabaseResponse with typed timestamps.
+type DatabaseResponse struct {
+	DatabaseID   sdk.DatabaseID `json:"id"`
+	DatabaseName string         `json:"name"`
+	Comment      string         `json:"description"`
+	TableCount   int            `json:"table_count"`
+	VolumeCount  int            `json:"volume_count"`
+	FileCount    int            `json:"file_count"`
+	Reserved     bool           `json:"reserved"`
+	CreatedAt    sdk.Time       `json:"created_at"`
+	CreatedBy    string         `json:"created_by"`
+	UpdatedAt    sdk.Time       `json:"updated_at"`
+	UpdatedBy    string         `json:"updated_by"`
+}
+
+// DatabaseListResponse is sdk.DatabaseListResponse with typed timestamps.
+type DatabaseListResponse struct {
+	List []DatabaseResponse `json:"list"`
+}
+
+// DatabaseInfoResponse is sdk.DatabaseInfoResponse with typed timestamps.
+type DatabaseInfoResponse struct {
+	DatabaseID   sdk.DatabaseID `json:"id"`
+	DatabaseName string         `json:"name"`
+	Comment      string         `json:"description"`
+	CreatedAt    sdk.Time       `json:"created_at"`
+	UpdatedAt    sdk.Time       `json:"updated_at"`
+}
+
+// DatabaseChildrenResponse is sdk.DatabaseChildrenResponse with typed
+// timestamps.
+type DatabaseChildrenResponse struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Typ           string   `json:"type"`
+	ChildrenCount int      `json:"children_count"`
+	Size          int64    `json:"size"`
+	Comment       string   `json:"description"`
+	Reserved      bool     `json:"reserved"`
+	CreatedAt     sdk.Time `json:"created_at"`
+	CreatedBy     string   `json:"created_by"`
+	UpdatedAt     sdk.Time `json:"updated_at"`
+	UpdatedBy     string   `json:"updated_by"`
+}
+
+// DatabaseChildrenResponseData is sdk.DatabaseChildrenResponseData with
+// typed timestamps.
+type DatabaseChildrenResponseData struct {
+	List []DatabaseChildrenResponse `json:"list"`
+}
+
+// TableInfoResponse is sdk.TableInfoResponse with typed timestamps.
+type TableInfoResponse struct {
+	Name      string            `json:"name"`
+	Lines     int64             `json:"lines"`
+	Size      int64             `json:"size"`
+	Columns   []sdk.Column      `json:"columns"`
+	Stats     []sdk.ColumnStats `json:"stats"`
+	CreateSql string            `json:"create_sql"`
+	CreatedAt sdk.Time          `json:"created_at"`
+	CreatedBy string            `json:"created_by"`
+	Comment   string            `json:"comment"`
+}
+
+// ============ Volume, file and dataset ============
+
+// VolumeInfoResponse is sdk.VolumeInfoResponse with typed timestamps.
+type VolumeInfoResponse struct {
+	VolumeID   sdk.VolumeID `json:"id"`
+	VolumeName string       `json:"name"`
+	Comment    string       `json:"description"`
+	Ref        bool         `json:"ref"`
+	CreatedAt  sdk.Time     `json:"created_at"`
+	UpdatedAt  sdk.Time     `json:"updated_at"`
+}
+
+// VolumeChildrenResponse is sdk.VolumeChildrenResponse with typed
+// timestamps.
+type VolumeChildrenResponse struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	FileType       string   `json:"file_type"`
+	ShowType       string   `json:"show_type"`
+	FileExt        string   `json:"file_ext"`
+	OriginFileExt  string   `json:"origin_file_ext"`
+	RefFileID      string   `json:"ref_file_id"`
+	Size           int64    `json:"size"`
+	VolumeID       string   `json:"volume_id"`
+	VolumeName     string   `json:"volume_name"`
+	VolumeReserved bool     `json:"volume_reserved"`
+	RefWorkFlowID  string   `json:"ref_workflow_id"`
+	ParentID       string   `json:"parent_id"`
+	ShowPath       string   `json:"show_path"`
+	SavePath       string   `json:"save_path"`
+	CreatedAt      sdk.Time `json:"created_at"`
+	CreatedBy      string   `json:"created_by"`
+	UpdatedAt      sdk.Time `json:"updated_at"`
+	// Tags are the key/value tags attached to the file.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// FileListResponse is sdk.FileListResponse with typed timestamps.
+type FileListResponse struct {
+	Total int                      `json:"total"`
+	List  []VolumeChildrenResponse `json:"list"`
+}
+
+// FileInfoResponse is sdk.FileInfoResponse with typed timestamps.
+type FileInfoResponse struct {
+	ID            sdk.FileID `json:"id"`
+	Name          string     `json:"name"`
+	FileType      string     `json:"file_type"`
+	ShowType      string     `json:"show_type"`
+	FileExt       string     `json:"file_ext"`
+	OriginFileExt string     `json:"origin_file_ext"`
+	RefFileID     string     `json:"ref_file_id"`
+	Size          int64      `json:"size"`
+	ParentID      string     `json:"parent_id"`
+	VolumeID      string     `json:"volume_id"`
+	Hash          string     `json:"hash,omitempty"`
+	CreatedAt     sdk.Time   `json:"created_at"`
+	UpdatedAt     sdk.Time   `json:"updated_at"`
+	// Tags are the key/value tags attached to the file.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// DatasetInfoResponse is sdk.DatasetInfoResponse with typed timestamps.
+type DatasetInfoResponse struct {
+	DatasetID   sdk.DatasetID  `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	TableIDs    []sdk.TableID  `json:"table_id_list"`
+	VolumeIDs   []sdk.VolumeID `json:"volume_id_list"`
+	CreatedBy   string         `json:"created_by"`
+	CreatedAt   sdk.Time       `json:"created_at"`
+	UpdatedAt   sdk.Time       `json:"updated_at"`
+}
+
+// ============ User and role ============
+
+// UserResponse is sdk.UserResponse with typed timestamps.
+type UserResponse struct {
+	ID          sdk.UserID        `json:"id"`
+	Name        string            `json:"name"`
+	Status      sdk.UserStatus    `json:"status"`
+	Phone       string            `json:"phone"`
+	Email       string            `json:"email"`
+	Reserved    bool              `json:"reserved"`
+	RoleList    []*sdk.RoleIDName `json:"role_list"`
+	LastLogin   sdk.Time          `json:"last_login"`
+	Description string            `json:"description"`
+	CreatedAt   sdk.Time          `json:"created_at"`
+	UpdatedAt   sdk.Time          `json:"updated_at"`
+}
+
+// UserDetailInfoResponse is sdk.UserDetailInfoResponse with typed
+// timestamps.
+type UserDetailInfoResponse struct {
+	UserResponse
+}
+
+// UserListResponse is sdk.UserListResponse with typed timestamps.
+type UserListResponse struct {
+	Total int            `json:"total"`
+	List  []UserResponse `json:"user_list"`
+}
+
+// UserApiKeyResponse is sdk.UserApiKeyResponse with typed timestamps.
+type UserApiKeyResponse struct {
+	Key       string   `json:"key"`
+	CreatedAt sdk.Time `json:"created_at"`
+}
+
+// RoleInfoResponse is sdk.RoleInfoResponse with typed timestamps.
+type RoleInfoResponse struct {
+	RoleID           sdk.RoleID             `json:"id"`
+	RoleName         string                 `json:"name"`
+	Status           sdk.RoleStatus         `json:"status"`
+	Reserved         bool                   `json:"reserved"`
+	Comment          string                 `json:"description"`
+	AuthorityList    []*sdk.PrivResponse    `json:"authority_list"`
+	ObjAuthorityList []*sdk.ObjPrivResponse `json:"obj_authority_list"`
+	CreatedAt        sdk.Time               `json:"created_at"`
+	UpdatedAt        sdk.Time               `json:"updated_at"`
+}
+
+// LogLogResponse is sdk.LogLogResponse with typed timestamps.
+type LogLogResponse struct {
+	LogActionType string   `json:"type"`
+	UserName      string   `json:"user_name"`
+	RoleName      string   `json:"role_name"`
+	CreatedAt     sdk.Time `json:"created_at"`
+	Status        string   `json:"status"`
+	Description   string   `json:"description"`
+}
+
+// LogLogListResponse is sdk.LogLogListResponse with typed timestamps.
+type LogLogListResponse struct {
+	Total int              `json:"total"`
+	List  []LogLogResponse `json:"role_list"`
+}
+
+// ============ Task ============
+
+// TaskInfoResponse is sdk.TaskInfoResponse with typed timestamps. It has
+// no RawStatus: statuses Status does not recognize decode to
+// sdk.TaskStatusUnknown.
+type TaskInfoResponse struct {
+	ID                  string                 `json:"id"`
+	SourceConnectorId   uint64                 `json:"source_connector_id"`
+	SourceConnectorType string                 `json:"source_connector_type"`
+	VolumeID            string                 `json:"volume_id"`
+	VolumeName          string                 `json:"volume_name"`
+	VolumePath          *sdk.FullPath          `json:"volume_path,omitempty"`
+	Name                string                 `json:"name"`
+	Creator             string                 `json:"creator"`
+	Status              sdk.TaskStatus         `json:"status"`
+	SourceConfig        map[string]interface{} `json:"source_config,omitempty"`
+	StartAt             sdk.Time               `json:"start_at"`
+	EndAt               sdk.Time               `json:"end_at"`
+	CreatedAt           sdk.Time               `json:"created_at"`
+	UpdatedAt           sdk.Time               `json:"updated_at"`
+	ConnectorName       string                 `json:"connector_name,omitempty"`
+	TablePath           *sdk.FullPath          `json:"table_path,omitempty"`
+	SourceFiles         [][]string             `json:"source_files,omitempty"`
+	LoadResults         []*sdk.LoadResult      `json:"load_results,omitempty"`
+}
+
+// LoadTaskListResponse is sdk.LoadTaskListResponse with typed timestamps.
+type LoadTaskListResponse struct {
+	Tasks []TaskInfoResponse `json:"tasks"`
+	Total int                `json:"total"`
+}
+
+// ============ GenAI and workflow ============
+
+// GenAIWorkflowJobFileResponse is sdk.GenAIWorkflowJobFileResponse with
+// typed timestamps.
+type GenAIWorkflowJobFileResponse struct {
+	FileID       string         `json:"file_id"`
+	FileName     string         `json:"file_name"`
+	FileType     int            `json:"file_type"`
+	FileStatus   sdk.TaskStatus `json:"file_status"`
+	ErrorMessage string         `json:"error_message"`
+	StartTime    sdk.Time       `json:"start_time"`
+	EndTime      sdk.Time       `json:"end_time"`
+}
+
+// GenAIGetJobDetailResponse is sdk.GenAIGetJobDetailResponse with typed
+// timestamps.
+type GenAIGetJobDetailResponse struct {
+	Status string                         `json:"status"`
+	Files  []GenAIWorkflowJobFileResponse `json:"files"`
+}
+
+// WorkflowResponse is sdk.WorkflowResponse with typed timestamps.
+type WorkflowResponse struct {
+	CreatedAt         sdk.Time `json:"created_at"`
+	Creator           string   `json:"creator"`
+	Content           string   `json:"content"`
+	UpdatedAt         sdk.Time `json:"updated_at"`
+	Modifier          string   `json:"modifier"`
+	ID                string   `json:"id"`
+	FileTypes         string   `json:"file_types"`
+	Name              string   `json:"name"`
+	SourceVolumeIDs   string   `json:"source_volume_ids"`
+	UserID            string   `json:"user_id"`
+	SourceVolumeNames string   `json:"source_volume_names"`
+	GroupID           string   `json:"group_id"`
+	TargetVolumeID    string   `json:"target_volume_id"`
+	Version           string   `json:"version"`
+	FlowInterval      int      `json:"flow_interval"`
+	TargetVolumeName  string   `json:"target_volume_name"`
+	Priority          int      `json:"priority"`
+	FlowOffset        int      `json:"flow_offset"`
+	Files             string   `json:"files"`
+}
+
+// WorkflowListResponse is sdk.WorkflowListResponse with typed timestamps.
+type WorkflowListResponse struct {
+	Workflows []WorkflowResponse `json:"workflows"`
+	Total     int                `json:"total"`
+}
+
+// WorkflowJob is sdk.WorkflowJob with typed timestamps. EndTime is zero
+// while the job runs.
+type WorkflowJob struct {
+	JobID        string                `json:"id"`
+	WorkflowID   string                `json:"workflow_id"`
+	SourceFileID string                `json:"source_file_id,omitempty"`
+	Status       sdk.WorkflowJobStatus `json:"status"`
+	StartTime    sdk.Time              `json:"start_time"`
+	EndTime      sdk.Time              `json:"end_time"`
+}
+
+// WorkflowJobListResponse is sdk.WorkflowJobListResponse with typed
+// timestamps.
+type WorkflowJobListResponse struct {
+	Jobs  []WorkflowJob `json:"jobs"`
+	Total int           `json:"total"`
+}
+
+// ============ NL2SQL and data asking ============
+
+// Nl2SqlKnowledgeResponse is sdk.Nl2SqlKnowledgeResponse with typed
+// timestamps.
+type Nl2SqlKnowledgeResponse struct {
+	ID        sdk.Nl2SqlKnowledgeID  `json:"id"`
+	Type      string                 `json:"type"`
+	Key       string                 `json:"key"`
+	Value     []string               `json:"value"`
+	Embedding []float64              `json:"embedding,omitempty"`
+	Meta      map[string]interface{} `json:"meta,omitempty"`
+	CreatedAt sdk.Time               `json:"created_at"`
+	UpdatedAt sdk.Time               `json:"updated_at"`
+}
+
+// NL2SQLKnowledgeListResponse is sdk.NL2SQLKnowledgeListResponse with typed
+// timestamps.
+type NL2SQLKnowledgeListResponse struct {
+	List  []*Nl2SqlKnowledgeResponse `json:"list"`
+	Total int64                      `json:"total"`
+}
+
+// AnalysisSession is sdk.AnalysisSession with typed timestamps.
+type AnalysisSession struct {
+	SessionID   string   `json:"session_id"`
+	SessionName string   `json:"session_name"`
+	Source      string   `json:"source"`
+	RequestIDs  []string `json:"request_ids"`
+	CreatedAt   sdk.Time `json:"created_at"`
+	UpdatedAt   sdk.Time `json:"updated_at"`
+}
+
+// AnalysisSessionListResponse is sdk.AnalysisSessionListResponse with typed
+// timestamps.
+type AnalysisSessionListResponse struct {
+	Total int               `json:"total"`
+	List  []AnalysisSession `json:"list"`
+}
+
+// AnalysisResultResponse is sdk.AnalysisResultResponse with typed
+// timestamps. FinishedAt is zero while the analysis runs.
+type AnalysisResultResponse struct {
+	RequestID      string                         `json:"request_id"`
+	SessionID      string                         `json:"session_id"`
+	SessionName    string                         `json:"session_name"`
+	Question       string                         `json:"question"`
+	Status         string                         `json:"status"`
+	Classification *sdk.QuestionType              `json:"question_type,omitempty"`
+	Events         []*sdk.DataAnalysisStreamEvent `json:"events"`
+	CreatedAt      sdk.Time                       `json:"created_at"`
+	FinishedAt     sdk.Time                       `json:"finished_at"`
+}
@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+// multipartBody collects a multipart request body written by a
+// multipart.Writer. The form fields and part headers are kept in memory,
+// while the content of seekable files is only recorded and read from the
+// file as the body is sent, so that uploading large files does not copy
+// them into memory. Since the sizes are known up front, the request still
+// carries a Content-Length, and the body can be produced again for
+// signing or retries.
+type multipartBody struct {
+	segments []bodySegment
+	pending  bytes.Buffer
+	size     int64
+}
+
+// bodySegment is either in-memory data or a range of a file.
+type bodySegment struct {
+	data   []byte
+	file   io.ReadSeeker
+	name   string
+	offset int64
+	size   int64
+}
+
+// Write appends p to the in-memory data of the body.
+func (b *multipartBody) Write(p []byte) (int, error) {
+	b.size += int64(len(p))
+	return b.pending.Write(p)
+}
+
+// addFile appends the rest of r, from its current position, to the body.
+// A file that cannot seek, or whose size cannot be determined, is copied
+// into memory instead.
+func (b *multipartBody) addFile(ctx context.Context, name string, r io.Reader) error {
+	if rs, ok := r.(io.ReadSeeker); ok {
+		if offset, size, err := remainingSize(rs); err == nil {
+			b.flush()
+			b.segments = append(b.segments, bodySegment{file: rs, name: name, offset: offset, size: size})
+			b.size += size
+			return nil
+		}
+	}
+	_, err := io.Copy(b, contextReader{ctx, r})
+	return err
+}
+
+func (b *multipartBody) flush() {
+	if b.pending.Len() > 0 {
+		b.segments = append(b.segments, bodySegment{data: bytes.Clone(b.pending.Bytes())})
+		b.pending.Reset()
+	}
+}
+
+// Len returns the total size of the body.
+func (b *multipartBody) Len() int64 {
+	return b.size
+}
+
+// Reader returns a new reader of the whole body. Readers share the files of
+// the body, so only one of them may be read at a time; each positions the
+// files itself.
+func (b *multipartBody) Reader() io.ReadCloser {
+	b.flush()
+	return &multipartBodyReader{segments: b.segments}
+}
+
+// remainingSize returns the current position of r and the number of bytes
+// after it, leaving r where it was.
+func remainingSize(r io.Seeker) (offset, size int64, err error) {
+	if offset, err = r.Seek(0, io.SeekCurrent); err != nil {
+		return 0, 0, err
+	}
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+	return offset, end - offset, nil
+}
+
+type multipartBodyReader struct {
+	segments []bodySegment
+	current  io.Reader
+	// left is the number of bytes of the current file still to be read.
+	left int64
+}
+
+func (r *multipartBodyReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.segments) == 0 {
+				return 0, io.EOF
+			}
+			seg := r.segments[0]
+			r.segments = r.segments[1:]
+			if seg.file == nil {
+				r.current, r.left = bytes.NewReader(seg.data), -1
+			} else {
+				if _, err := seg.file.Seek(seg.offset, io.SeekStart); err != nil {
+					return 0, fmt.Errorf("rewind file %s: %w", seg.name, err)
+				}
+				r.current, r.left = io.LimitReader(seg.file, seg.size), seg.size
+			}
+		}
+		n, err := r.current.Read(p)
+		if r.left >= 0 {
+			r.left -= int64(n)
+		}
+		if err == io.EOF {
+			if r.left > 0 {
+				return n, fmt.Errorf("file shrank while being uploaded: %w", io.ErrUnexpectedEOF)
+			}
+			r.current = nil
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+func (r *multipartBodyReader) Close() error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipartBody(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "data.bin")
+	require.NoError(t, os.WriteFile(path, []byte("skipped|file content"), 0o644))
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.Seek(int64(len("skipped|")), io.SeekStart)
+	require.NoError(t, err)
+
+	body := &multipartBody{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("meta", `{"a":1}`))
+	_, err = writer.CreateFormFile("file", "data.bin")
+	require.NoError(t, err)
+	require.NoError(t, body.addFile(context.Background(), "data.bin", file))
+	_, err = writer.CreateFormFile("file", "inline.txt")
+	require.NoError(t, err)
+	require.NoError(t, body.addFile(context.Background(), "inline.txt", io.NopCloser(strings.NewReader("inline"))))
+	require.NoError(t, writer.Close())
+
+	// The seekable file is read when the body is, the other one is buffered.
+	require.Len(t, body.segments, 2)
+	require.Equal(t, int64(len("file content")), body.segments[1].size)
+
+	// Every reader produces the whole body, as GetBody requires.
+	for range 2 {
+		data, err := io.ReadAll(body.Reader())
+		require.NoError(t, err)
+		require.Equal(t, body.Len(), int64(len(data)))
+
+		_, params, err := mime.ParseMediaType(writer.FormDataContentType())
+		require.NoError(t, err)
+		form, err := multipart.NewReader(bytes.NewReader(data), params["boundary"]).ReadForm(1 << 20)
+		require.NoError(t, err)
+		require.Equal(t, []string{`{"a":1}`}, form.Value["meta"])
+		for name, want := range map[string]string{"data.bin": "file content", "inline.txt": "inline"} {
+			var found bool
+			for _, fh := range form.File["file"] {
+				if fh.Filename == name {
+					f, err := fh.Open()
+					require.NoError(t, err)
+					got, _ := io.ReadAll(f)
+					require.Equal(t, want, string(got))
+					found = true
+				}
+			}
+			require.True(t, found, name)
+		}
+	}
+}
+
+func TestMultipartBodyFileShrinks(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "data.bin")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789"), 0o644))
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	body := &multipartBody{}
+	require.NoError(t, body.addFile(context.Background(), "data.bin", file))
+	require.NoError(t, os.Truncate(path, 4))
+	_, err = io.ReadAll(body.Reader())
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
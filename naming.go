@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ResourceKind is the kind of resource whose name a Namer checks.
+type ResourceKind string
+
+const (
+	ResourceCatalog  ResourceKind = "catalog"
+	ResourceDatabase ResourceKind = "database"
+	ResourceTable    ResourceKind = "table"
+	ResourceVolume   ResourceKind = "volume"
+	ResourceFolder   ResourceKind = "folder"
+	ResourceRole     ResourceKind = "role"
+	ResourceUser     ResourceKind = "user"
+)
+
+// Namer enforces a naming policy on the resources created through a
+// client; install one with WithNamer.
+//
+// NormalizeName returns the name to create the resource with, which may
+// differ from name, or an error if name is not acceptable.
+type Namer interface {
+	NormalizeName(kind ResourceKind, name string) (string, error)
+}
+
+// NamerFunc adapts a function to the Namer interface.
+type NamerFunc func(kind ResourceKind, name string) (string, error)
+
+// NormalizeName calls f(kind, name).
+func (f NamerFunc) NormalizeName(kind ResourceKind, name string) (string, error) {
+	return f(kind, name)
+}
+
+// NamingPolicy is a Namer for the common rules of naming policies.
+// Normalization happens first: surrounding spaces are trimmed, the name is
+// lowercased if Lowercase is set, and Prefix is added if AddPrefix is set.
+// The normalized name is then checked against the other rules.
+type NamingPolicy struct {
+	// Kinds limits the policy to these kinds of resources; empty applies
+	// it to all.
+	Kinds []ResourceKind
+	// MinLength and MaxLength bound the length of the name in characters.
+	// A MaxLength of 0 means no upper bound.
+	MinLength int
+	MaxLength int
+	// Pattern, if set, must match the whole name, for example
+	// `^[a-z][a-z0-9_]*$` to restrict the charset.
+	Pattern *regexp.Regexp
+	// Prefix is required at the start of every name.
+	Prefix string
+	// AddPrefix adds Prefix to names that lack it instead of rejecting them.
+	AddPrefix bool
+	// Lowercase lowercases names instead of leaving their case unchanged.
+	Lowercase bool
+}
+
+// NormalizeName normalizes name and checks it against the policy.
+func (p *NamingPolicy) NormalizeName(kind ResourceKind, name string) (string, error) {
+	if len(p.Kinds) > 0 && !slices.Contains(p.Kinds, kind) {
+		return name, nil
+	}
+	name = strings.TrimSpace(name)
+	if p.Lowercase {
+		name = strings.ToLower(name)
+	}
+	if p.AddPrefix && !strings.HasPrefix(name, p.Prefix) {
+		name = p.Prefix + name
+	}
+
+	var errs fieldErrors
+	n := utf8.RuneCountInString(name)
+	errs.check(n >= p.MinLength, "name", fmt.Sprintf("%s name %q is shorter than %d characters", kind, name, p.MinLength))
+	errs.check(p.MaxLength <= 0 || n <= p.MaxLength, "name", fmt.Sprintf("%s name %q is longer than %d characters", kind, name, p.MaxLength))
+	errs.check(strings.HasPrefix(name, p.Prefix), "name", fmt.Sprintf("%s name %q must start with %q", kind, name, p.Prefix))
+	errs.check(p.Pattern == nil || p.Pattern.MatchString(name), "name", fmt.Sprintf("%s name %q does not match %s", kind, name, p.Pattern))
+	if err := errs.err(); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// NormalizeName applies the naming policy of the client, if any, to the
+// name of a resource of the given kind. Helpers that look a resource up by
+// name before creating it use it so that they look for the name the
+// resource is created with.
+//
+// An error of the policy that is not a *ValidationError is wrapped in one,
+// so that every rejection matches ErrInvalidArgument.
+func (c *RawClient) NormalizeName(kind ResourceKind, name string) (string, error) {
+	if c.namer == nil {
+		return name, nil
+	}
+	normalized, err := c.namer.NormalizeName(kind, name)
+	if err != nil {
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			return "", err
+		}
+		return "", &ValidationError{Fields: []FieldError{{
+			Field:   "name",
+			Message: fmt.Sprintf("%s name %q: %v", kind, name, err),
+		}}}
+	}
+	return normalized, nil
+}
+
+// withNormalizedName returns req, or a copy of it if the naming policy
+// changes the name that field selects, so that the caller's request is
+// never modified.
+func withNormalizedName[T any](c *RawClient, kind ResourceKind, req *T, field func(*T) *string) (*T, error) {
+	name, err := c.NormalizeName(kind, *field(req))
+	if err != nil {
+		return nil, err
+	}
+	if name == *field(req) {
+		return req, nil
+	}
+	normalized := *req
+	*field(&normalized) = name
+	return &normalized, nil
+}
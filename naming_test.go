@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingPolicy(t *testing.T) {
+	t.Parallel()
+	policy := &NamingPolicy{
+		Kinds:     []ResourceKind{ResourceTable, ResourceVolume},
+		MaxLength: 12,
+		Pattern:   regexp.MustCompile(`^[a-z][a-z0-9_]*$`),
+		Prefix:    "bi_",
+		AddPrefix: true,
+		Lowercase: true,
+	}
+
+	name, err := policy.NormalizeName(ResourceTable, " Orders ")
+	require.NoError(t, err)
+	require.Equal(t, "bi_orders", name)
+
+	name, err = policy.NormalizeName(ResourceRole, "Any Name")
+	require.NoError(t, err)
+	require.Equal(t, "Any Name", name)
+
+	_, err = policy.NormalizeName(ResourceVolume, "order-files")
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 2)
+
+	strict := &NamingPolicy{Prefix: "bi_", MinLength: 4}
+	_, err = strict.NormalizeName(ResourceTable, "orders")
+	require.ErrorContains(t, err, `must start with "bi_"`)
+	_, err = strict.NormalizeName(ResourceTable, "bi_")
+	require.ErrorContains(t, err, "shorter than 4")
+}
+
+func TestWithNamer(t *testing.T) {
+	t.Parallel()
+	var created []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var req TableCreateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		created = append(created, req.Name)
+		writeEnvelope(w, TableCreateResponse{TableID: 10})
+	}
+	server := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/create": handler,
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+				{ID: "11", Name: "bi_customers", Typ: NodeTypeTable},
+			}})
+		},
+	})
+	client, err := NewRawClient(server.baseURL, "test-key", WithNamer(NamerFunc(func(kind ResourceKind, name string) (string, error) {
+		if name == "tmp" {
+			return "", errors.New("reserved name")
+		}
+		return "bi_" + name, nil
+	})))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	req := &TableCreateRequest{DatabaseID: 1, Name: "orders"}
+	_, err = client.CreateTable(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "orders", req.Name)
+	require.Equal(t, []string{"bi_orders"}, created)
+
+	_, err = client.CreateTable(ctx, &TableCreateRequest{DatabaseID: 1, Name: "tmp"})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	require.ErrorContains(t, err, `table name "tmp": reserved name`)
+	require.Len(t, created, 1)
+
+	id, ok, err := NewSDKClient(client).EnsureTable(ctx, 1, "customers", nil, "")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, TableID(11), id)
+}
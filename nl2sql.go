@@ -24,6 +24,9 @@ func (c *RawClient) RunNL2SQL(ctx context.Context, req *NL2SQLRunSQLRequest, opt
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp NL2SQLRunSQLResponse
 	if err := c.postJSON(ctx, "/catalog/nl2sql/run_sql", req, &resp, opts...); err != nil {
 		return nil, err
@@ -24,6 +24,9 @@ func (c *RawClient) CreateKnowledge(ctx context.Context, req *NL2SQLKnowledgeCre
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp NL2SQLKnowledgeCreateResponse
 	if err := c.postJSON(ctx, "/catalog/nl2sql_knowledge/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -46,6 +49,9 @@ func (c *RawClient) UpdateKnowledge(ctx context.Context, req *NL2SQLKnowledgeUpd
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp NL2SQLKnowledgeUpdateResponse
 	if err := c.postJSON(ctx, "/catalog/nl2sql_knowledge/update", req, &resp, opts...); err != nil {
 		return nil, err
@@ -66,6 +72,9 @@ func (c *RawClient) DeleteKnowledge(ctx context.Context, req *NL2SQLKnowledgeDel
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp NL2SQLKnowledgeDeleteResponse
 	if err := c.postJSON(ctx, "/catalog/nl2sql_knowledge/delete", req, &resp, opts...); err != nil {
 		return nil, err
@@ -91,6 +100,9 @@ func (c *RawClient) GetKnowledge(ctx context.Context, req *NL2SQLKnowledgeGetReq
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp NL2SQLKnowledgeGetResponse
 	if err := c.postJSON(ctx, "/catalog/nl2sql_knowledge/get", req, &resp, opts...); err != nil {
 		return nil, err
@@ -121,6 +133,9 @@ func (c *RawClient) ListKnowledge(ctx context.Context, req *NL2SQLKnowledgeListR
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp NL2SQLKnowledgeListResponse
 	if err := c.postJSON(ctx, "/catalog/nl2sql_knowledge/list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -150,6 +165,9 @@ func (c *RawClient) SearchKnowledge(ctx context.Context, req *NL2SQLKnowledgeSea
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp NL2SQLKnowledgeSearchResponse
 	if err := c.postJSON(ctx, "/catalog/nl2sql_knowledge/search", req, &resp, opts...); err != nil {
 		return nil, err
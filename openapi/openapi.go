@@ -0,0 +1,296 @@
+// Package openapi generates an OpenAPI 3 document from the endpoint table
+// of the SDK, so that clients in other languages and contract tests can be
+// derived from the request and response types of the Go SDK:
+//
+//	doc := openapi.Generate(sdk.Endpoints(), openapi.Info{Title: "MOI", Version: "1.0"})
+//	data, err := json.MarshalIndent(doc, "", "  ")
+//
+// Named Go types become schemas under components/schemas, referenced from
+// the operations. Every response is wrapped in the {code, msg, data,
+// request_id} envelope of the service.
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// Version is the OpenAPI version of the generated documents.
+const Version = "3.0.3"
+
+// envelopeSchema is the name of the schema of the response envelope.
+const envelopeSchema = "Envelope"
+
+// Info is the info object of the document.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Document is an OpenAPI document. Paths and schemas are plain maps so that
+// they marshal with sorted keys, which keeps generated files stable.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []map[string]string              `json:"servers,omitempty"`
+	Tags       []map[string]string              `json:"tags,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+	Security   []map[string][]string            `json:"security"`
+}
+
+// Components holds the reusable schemas and security schemes.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]map[string]any `json:"securitySchemes"`
+}
+
+// Operation is one method of a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a query or path parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is a JSON request body.
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is a JSON response.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is the subset of JSON Schema used for Go types.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// Generate builds the document of endpoints.
+func Generate(endpoints []sdk.Endpoint, info Info) *Document {
+	g := &generator{schemas: make(map[string]*Schema)}
+	g.schemas[envelopeSchema] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":       {Type: "string", Description: "OK on success, otherwise an error code"},
+			"msg":        {Type: "string"},
+			"request_id": {Type: "string"},
+			"data":       {},
+		},
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]map[string]*Operation),
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]map[string]any{
+				"apiKey": {"type": "apiKey", "in": "header", "name": "moi-key"},
+			},
+		},
+		Security: []map[string][]string{{"apiKey": {}}},
+	}
+	seenTags := make(map[string]bool)
+	for _, e := range endpoints {
+		if e.Path == "" {
+			// Methods built on other endpoints have no route of their own.
+			continue
+		}
+		if e.Tag != "" && !seenTags[e.Tag] {
+			seenTags[e.Tag] = true
+			doc.Tags = append(doc.Tags, map[string]string{"name": e.Tag})
+		}
+		op := &Operation{
+			OperationID: e.Name,
+			Responses: map[string]*Response{
+				"200": {
+					Description: "The response envelope",
+					Content:     jsonContent(g.envelope(e.Response)),
+				},
+			},
+		}
+		if e.Tag != "" {
+			op.Tags = []string{e.Tag}
+		}
+		for _, m := range pathParamRe.FindAllStringSubmatch(e.Path, -1) {
+			op.Parameters = append(op.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		for _, name := range e.Query {
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "query", Schema: &Schema{Type: "string"}})
+		}
+		if e.Request != nil {
+			op.RequestBody = &RequestBody{Required: true, Content: jsonContent(g.schema(e.Request))}
+		}
+		if doc.Paths[e.Path] == nil {
+			doc.Paths[e.Path] = make(map[string]*Operation)
+		}
+		doc.Paths[e.Path][strings.ToLower(e.Method)] = op
+	}
+	return doc
+}
+
+// pathParamRe matches the parameters of a path, such as {job_id}.
+var pathParamRe = regexp.MustCompile(`\{(\w+)\}`)
+
+func jsonContent(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: schema}}
+}
+
+type generator struct {
+	schemas map[string]*Schema
+}
+
+// envelope returns the schema of the envelope carrying data of type t.
+func (g *generator) envelope(t reflect.Type) *Schema {
+	ref := &Schema{Ref: "#/components/schemas/" + envelopeSchema}
+	if t == nil {
+		return ref
+	}
+	return &Schema{AllOf: []*Schema{ref, {
+		Type:       "object",
+		Properties: map[string]*Schema{"data": g.schema(t)},
+	}}}
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	sdkTimeType    = reflect.TypeFor[sdk.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+	marshalerType  = reflect.TypeFor[json.Marshaler]()
+)
+
+// schema returns the schema of t. Named struct types are added to the
+// components and referenced.
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType, sdkTimeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Struct && t.Implements(marshalerType) {
+		// The enums of the SDK with their own encoding, such as TaskStatus,
+		// are encoded as strings.
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schema(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := schemaName(t)
+		if _, ok := g.schemas[name]; !ok {
+			// Reserve the name first so that recursive types terminate.
+			g.schemas[name] = &Schema{}
+			*g.schemas[name] = *g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	// Interfaces and other kinds accept any value.
+	return &Schema{}
+}
+
+// structSchema returns the object schema of the JSON fields of t, with the
+// fields of embedded structs promoted as encoding/json does.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(s, t)
+	return s
+}
+
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.Contains(","+opts+",", ",string,") {
+			s.Properties[name] = &Schema{Type: "string"}
+			continue
+		}
+		s.Properties[name] = g.schema(ft)
+	}
+}
+
+// schemaName returns the component name of a named type, made safe for
+// generic instantiations.
+func schemaName(t reflect.Type) string {
+	return strings.NewReplacer("[", "_", "]", "", "/", "_", ".", "_", "*", "", ",", "_", " ", "").Replace(t.Name())
+}
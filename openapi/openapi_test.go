@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	doc := Generate(sdk.Endpoints(), Info{Title: "MOI", Version: "1.0"})
+	require.Equal(t, Version, doc.OpenAPI)
+
+	op := doc.Paths["/catalog/table/info"]["post"]
+	require.NotNil(t, op)
+	require.Equal(t, "GetTable", op.OperationID)
+	require.Equal(t, "#/components/schemas/TableInfoRequest", op.RequestBody.Content["application/json"].Schema.Ref)
+
+	req := doc.Components.Schemas["TableInfoRequest"]
+	require.NotNil(t, req)
+	require.Equal(t, "integer", req.Properties["id"].Type)
+	require.Equal(t, "int64", req.Properties["id"].Format)
+
+	data := op.Responses["200"].Content["application/json"].Schema.AllOf[1].Properties["data"]
+	require.Equal(t, "#/components/schemas/TableInfoResponse", data.Ref)
+
+	// Path parameters are declared, and methods without a route of their
+	// own are left out.
+	op = doc.Paths["/v1/genai/jobs/{job_id}"]["get"]
+	require.NotNil(t, op)
+	require.Equal(t, []Parameter{{Name: "job_id", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, op.Parameters)
+	require.Equal(t, "ListUsers", doc.Paths["/user/list"]["post"].OperationID)
+	require.NotContains(t, doc.Paths, "")
+
+	// Every reference resolves to a schema of the document.
+	raw, err := json.Marshal(doc)
+	require.NoError(t, err)
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				name := ref[len("#/components/schemas/"):]
+				require.Contains(t, doc.Components.Schemas, name)
+			}
+			for _, e := range v {
+				walk(e)
+			}
+		case []any:
+			for _, e := range v {
+				walk(e)
+			}
+		}
+	}
+	var tree any
+	require.NoError(t, json.Unmarshal(raw, &tree))
+	walk(tree)
+}
+
+type node struct {
+	Name     string  `json:"name"`
+	Children []*node `json:"children"`
+	Ignored  string  `json:"-"`
+	Count    int64   `json:"count,string"`
+}
+
+type wrapper struct {
+	node
+	Extra map[string]any `json:"extra,omitempty"`
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+	g := &generator{schemas: make(map[string]*Schema)}
+
+	s := g.schema(reflect.TypeFor[wrapper]())
+	require.Equal(t, "#/components/schemas/wrapper", s.Ref)
+	w := g.schemas["wrapper"]
+	require.Contains(t, w.Properties, "name")
+	require.Contains(t, w.Properties, "children")
+	require.NotContains(t, w.Properties, "Ignored")
+	require.Equal(t, "string", w.Properties["count"].Type)
+	require.Equal(t, "object", w.Properties["extra"].Type)
+
+	// Recursive types terminate.
+	n := g.schemas["node"]
+	require.Equal(t, "#/components/schemas/node", n.Properties["children"].Items.Ref)
+}
+
+// TestSpecUpToDate fails when openapi.json at the root of the module was
+// not regenerated after a change of the models or of the endpoint table.
+func TestSpecUpToDate(t *testing.T) {
+	t.Parallel()
+	want, err := os.ReadFile("../openapi.json")
+	require.NoError(t, err)
+	got, err := json.MarshalIndent(Generate(sdk.Endpoints(), Info{
+		Title:       "MOI API",
+		Description: "Generated from the MOI Go SDK. Do not edit.",
+		Version:     "1.0.0",
+	}), "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+	require.True(t, bytes.Equal(want, got), "openapi.json is stale, run go generate")
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -11,6 +12,7 @@ const (
 	defaultUserAgent        = "matrixflow-sdk-go/0.1.0"
 	defaultHTTPTimeout      = 30 * time.Second
 	defaultStreamReadTimeout = 30 * time.Second // Default timeout between messages in streaming responses
+	defaultLongRequestTimeout = time.Hour       // Default overall timeout of streams, uploads and downloads
 )
 
 type clientOptions struct {
@@ -18,6 +20,18 @@ type clientOptions struct {
 	userAgent       string
 	defaultHeaders  http.Header
 	llmProxyBaseURL string // Optional: direct LLM Proxy base URL for direct connection
+	interceptors    []Interceptor
+	logger          *slog.Logger
+	logRedactor     BodyRedactor
+	namer           Namer
+	exchangeAPIKey  bool // Used by NewRawClientWithPassword
+	transport       *TransportConfig
+
+	longRequestTimeout time.Duration // Overall timeout of long-running requests (0 means none)
+	deduplicateReads   bool          // Share responses of identical concurrent reads
+	resolverCacheTTL   time.Duration // Lifetime of cached path resolutions (0 disables the cache)
+	circuitBreaker     *CircuitBreakerConfig
+	signing            *SigningCredentials
 }
 
 // ClientOption customizes the SDK client during construction.
@@ -66,6 +80,139 @@ func WithHTTPTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithTransportConfig tunes the transport of the HTTP client: connection
+// pooling, custom CAs, client certificates for mutual TLS, proxying and
+// HTTP/2.
+//
+// The configuration is applied on top of the transport of the client given
+// with WithHTTPClient, which must then be an *http.Transport, or of
+// http.DefaultTransport. Invalid settings, such as an unreadable CA file,
+// make NewRawClient fail.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithTransportConfig(&sdk.TransportConfig{
+//			MaxIdleConnsPerHost: 32,
+//			RootCAFile:          "/etc/moi/ca.pem",
+//			ClientCertFile:      "/etc/moi/client.pem",
+//			ClientKeyFile:       "/etc/moi/client-key.pem",
+//		}))
+func WithTransportConfig(cfg *TransportConfig) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = cfg
+	}
+}
+
+// WithRequestDeduplication collapses identical concurrent read requests into
+// a single backend call whose response is shared by all callers, so that
+// bursts such as many goroutines resolving the same table cost one request.
+//
+// Requests are identical when they have the same endpoint, query, body,
+// request ID and per-call headers. Only GET requests and the POST info, list,
+// tree and similar lookups are deduplicated; use WithoutDeduplication to
+// force a fresh request for a single call.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithRequestDeduplication())
+func WithRequestDeduplication() ClientOption {
+	return func(o *clientOptions) {
+		o.deduplicateReads = true
+	}
+}
+
+// WithResolverCache makes SDKClient.ResolvePath, ResolveTablePath and
+// ResolveVolumePath remember the IDs that each path resolved to for ttl,
+// so that resolving the same paths in a hot loop does not fetch the catalog
+// tree every time.
+//
+// Paths through a catalog, database, table or volume that is renamed or
+// deleted with this client are forgotten at once. Changes made by other
+// clients are only seen once the entries expire, or after
+// ClearResolverCache. Failed resolutions are not cached.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithResolverCache(time.Minute))
+func WithResolverCache(ttl time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.resolverCacheTTL = ttl
+	}
+}
+
+// WithCircuitBreaker makes the client fail fast with ErrCircuitOpen while
+// the backend is down, instead of letting every call wait for its timeout.
+//
+// Transport errors and 5xx responses count as failures. Once their share of
+// the requests of a window reaches cfg.FailureRate, the circuit opens and
+// requests fail without being sent. After cfg.CoolDown, a single probe
+// request is let through: its success closes the circuit, its failure keeps
+// it open for another cool-down. A nil cfg uses the defaults.
+//
+// The breaker is shared by clients derived with WithSpecialUser.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithCircuitBreaker(&sdk.CircuitBreakerConfig{
+//			FailureRate: 0.5,
+//			MinRequests: 20,
+//			CoolDown:    10 * time.Second,
+//		}))
+func WithCircuitBreaker(cfg *CircuitBreakerConfig) ClientOption {
+	return func(o *clientOptions) {
+		if cfg == nil {
+			cfg = &CircuitBreakerConfig{}
+		}
+		o.circuitBreaker = cfg
+	}
+}
+
+// WithSigningCredentials signs every request with HMAC-SHA256, for
+// deployments that require stronger authentication than the plain API key
+// header. With signing, the apiKey of NewRawClient may be left empty.
+//
+// Each request carries the access key, a timestamp, a random nonce, the
+// SHA-256 of its body and a signature over the method, path, query,
+// timestamp, nonce and body hash, so that the server can reject tampered
+// and replayed requests. Streamed bodies, such as multipart uploads, are
+// signed with the body hash UNSIGNED-PAYLOAD.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, "",
+//		sdk.WithSigningCredentials(sdk.SigningCredentials{
+//			AccessKeyID: os.Getenv("MOI_ACCESS_KEY"),
+//			Secret:      os.Getenv("MOI_SECRET"),
+//		}))
+func WithSigningCredentials(creds SigningCredentials) ClientOption {
+	return func(o *clientOptions) {
+		if creds.AccessKeyID == "" || creds.Secret == "" {
+			return
+		}
+		o.signing = &creds
+	}
+}
+
+// WithLongRequestTimeout configures the overall timeout of long-running
+// requests: event streams, file uploads and downloads. These requests do not
+// use the timeout of the http.Client, which would cut them off while data is
+// still flowing.
+//
+// The default is one hour. A timeout of zero or less removes the limit, leaving
+// the requests bounded by their context only.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithLongRequestTimeout(4 * time.Hour))
+func WithLongRequestTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.longRequestTimeout = max(timeout, 0)
+	}
+}
+
 // WithUserAgent overrides the default User-Agent header that is sent with every request.
 //
 // The default User-Agent is "matrixflow-sdk-go/0.1.0".
@@ -156,6 +303,92 @@ func WithLLMProxyBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// WithInterceptor registers middleware that runs around every HTTP request
+// issued by the client.
+//
+// Interceptors run in registration order: the first one registered sees the
+// request first and the response last. They are shared by clients derived
+// with WithSpecialUser.
+//
+// Example:
+//
+//	audit := func(req *http.Request, next sdk.RequestHandler) (*http.Response, error) {
+//		resp, err := next(req)
+//		if err == nil {
+//			log.Printf("%s %s -> %d", req.Method, req.URL.Path, resp.StatusCode)
+//		}
+//		return resp, err
+//	}
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithInterceptor(audit))
+func WithInterceptor(interceptor Interceptor) ClientOption {
+	return func(o *clientOptions) {
+		if interceptor != nil {
+			o.interceptors = append(o.interceptors, interceptor)
+		}
+	}
+}
+
+// WithLogger logs every request issued by the client to logger.
+//
+// Each request produces one record with the method, URL, HTTP status,
+// latency and request ID. Successful requests are logged at debug level and
+// failed ones (transport errors and non-2xx statuses) at warn level. The API
+// key is never logged. Use WithDebugLogging to also capture bodies for
+// individual calls.
+//
+// Example:
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithLogger(logger))
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithLogRedactor replaces the function used to scrub request and response
+// bodies captured by WithDebugLogging.
+//
+// The default redactor masks JSON fields whose names look like credentials,
+// such as "password", "api_key" and "token".
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithLogger(logger),
+//		sdk.WithLogRedactor(func(contentType string, body []byte) []byte {
+//			return emailPattern.ReplaceAll(body, []byte("<email>"))
+//		}))
+func WithLogRedactor(redactor BodyRedactor) ClientOption {
+	return func(o *clientOptions) {
+		o.logRedactor = redactor
+	}
+}
+
+// WithNamer installs a naming policy that every resource name goes through
+// before the client creates the resource. The namer may return a
+// normalized name, which is the one sent to the server, or an error, which
+// fails the call with an error matching ErrInvalidArgument before any
+// request is made.
+//
+// The policy applies to CreateCatalog, CreateDatabase, CreateTable,
+// CreateVolume, CreateFolder, CreateRole and CreateUser, and to the
+// SDKClient helpers built on them.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithNamer(&sdk.NamingPolicy{
+//		MaxLength: 64,
+//		Pattern:   regexp.MustCompile(`^[a-z][a-z0-9_]*$`),
+//		Prefix:    "analytics_",
+//		AddPrefix: true,
+//		Lowercase: true,
+//	}))
+func WithNamer(namer Namer) ClientOption {
+	return func(o *clientOptions) {
+		o.namer = namer
+	}
+}
+
 // CallOption customizes individual SDK operations.
 //
 // CallOption functions are used with individual API method calls to customize
@@ -175,6 +408,24 @@ type callOptions struct {
 	useDirectLLMProxy  bool          // Whether to use direct LLM Proxy connection
 	streamBufferSize   int           // Buffer size for stream scanner (in bytes)
 	streamReadTimeout  time.Duration // Timeout between messages in streaming responses (0 means use default)
+	streamReconnects   int           // Reconnection attempts for streams (0 means disabled or stream default)
+	streamRetryDelay   time.Duration // Delay before a stream reconnection attempt
+	streamResume       StreamResumeFunc
+	chunkedUpload      *ChunkedUploadOptions
+	uploadLimits       *UploadLimits
+	bandwidth          *bandwidthLimiter
+	progress           ProgressFunc
+	uploadProgress     ProgressFunc
+	callTimeout        time.Duration // Overall timeout of each request of the call (0 means the client timeouts)
+	noDedup            bool          // Send even if an identical request is in flight
+	batchConcurrency   int           // Calls of a Batch in flight (0 means the default)
+	decoder            ResponseDecoder
+	debugLogging       bool
+	skipSession        bool // Send without the session token, for logging in
+	apiKeyOverride     string
+	treeDepth          int           // Levels of the catalog tree to return (0 means all)
+	treeTypes          []string      // Types of the children of databases in the catalog tree (empty means all)
+	treeToken          string        // Continuation token of the catalog tree
 }
 
 func newCallOptions(opts ...CallOption) callOptions {
@@ -206,6 +457,23 @@ func WithRequestID(id string) CallOption {
 	}
 }
 
+// WithAPIKeyOverride sends the request with apiKey instead of the
+// credentials of the client, so that a shared client can make individual
+// calls on behalf of another user.
+//
+// Unlike WithSpecialUser, no client is created; this suits services that
+// act for many users. An empty apiKey leaves the client credentials in
+// place.
+//
+// Example:
+//
+//	resp, err := client.ListCatalogs(ctx, sdk.WithAPIKeyOverride(user.APIKey))
+func WithAPIKeyOverride(apiKey string) CallOption {
+	return func(co *callOptions) {
+		co.apiKeyOverride = strings.TrimSpace(apiKey)
+	}
+}
+
 // WithHeader sets or overrides a header on the outgoing request.
 //
 // Headers set via WithHeader will override default headers and any headers
@@ -335,6 +603,114 @@ func WithStreamBufferSize(size int) CallOption {
 	}
 }
 
+// WithCallTimeout bounds a single call, in place of the timeout of the
+// http.Client or the long request timeout.
+//
+// The deadline covers the whole request, including reading the response
+// body, so for a stream or a download it must leave room for the data to
+// arrive. When a call makes several requests, each gets its own deadline; use
+// a context deadline to bound them together.
+//
+// Example:
+//
+//	resp, err := client.GetCatalog(ctx, req,
+//		sdk.WithCallTimeout(5*time.Second))
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(co *callOptions) {
+		if timeout > 0 {
+			co.callTimeout = timeout
+		}
+	}
+}
+
+// WithoutDeduplication sends the request even if an identical one is in
+// flight, instead of sharing its response. It has no effect unless the
+// client was created with WithRequestDeduplication.
+//
+// Example:
+//
+//	table, err := client.GetTable(ctx, req, sdk.WithoutDeduplication())
+func WithoutDeduplication() CallOption {
+	return func(co *callOptions) {
+		co.noDedup = true
+	}
+}
+
+// WithTreeDepth limits GetCatalogTree to depth levels of nodes: 1 returns
+// the catalogs only, 2 the catalogs and their databases and 3 or more the
+// whole tree. Nodes at the last level are returned without their children;
+// load them with a LazyTree, ListDatabases or GetDatabaseChildren.
+//
+// Example:
+//
+//	tree, err := client.GetCatalogTree(ctx, sdk.WithTreeDepth(2))
+func WithTreeDepth(depth int) CallOption {
+	return func(co *callOptions) {
+		co.treeDepth = depth
+	}
+}
+
+// WithTreeNodeTypes limits the children of databases returned by
+// GetCatalogTree to the given types, NodeTypeTable or NodeTypeVolume.
+// Catalogs and databases are always returned.
+//
+// Example:
+//
+//	tree, err := client.GetCatalogTree(ctx, sdk.WithTreeNodeTypes(sdk.NodeTypeVolume))
+func WithTreeNodeTypes(types ...string) CallOption {
+	return func(co *callOptions) {
+		co.treeTypes = append([]string(nil), types...)
+	}
+}
+
+// WithTreeContinuation continues a catalog tree that GetCatalogTree
+// returned in parts, from the ContinuationToken of the previous part.
+//
+// Example:
+//
+//	tree, err := client.GetCatalogTree(ctx)
+//	for err == nil && tree.ContinuationToken != "" {
+//		var next *sdk.CatalogTreeResponse
+//		next, err = client.GetCatalogTree(ctx, sdk.WithTreeContinuation(tree.ContinuationToken))
+//		if err == nil {
+//			tree.Tree = append(tree.Tree, next.Tree...)
+//			tree.ContinuationToken = next.ContinuationToken
+//		}
+//	}
+func WithTreeContinuation(token string) CallOption {
+	return func(co *callOptions) {
+		co.treeToken = token
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of calls of a Batch sent at
+// the same time. The default is 8.
+//
+// Example:
+//
+//	results, err := client.Batch(ctx, calls, sdk.WithBatchConcurrency(16))
+func WithBatchConcurrency(n int) CallOption {
+	return func(co *callOptions) {
+		co.batchConcurrency = n
+	}
+}
+
+// WithResponseDecoder replaces the decoding of the response body for a
+// single call, for endpoints that do not answer with the usual format. It
+// applies to the JSON APIs: the enveloped catalog APIs, whose default is
+// EnvelopeDecoder, and the LLM Proxy APIs, whose default is JSONDecoder.
+//
+// Example:
+//
+//	var stats map[string]int
+//	err := client.Do(ctx, http.MethodGet, "/catalog/new/stats", nil, &stats,
+//		sdk.WithResponseDecoder(sdk.JSONDecoder))
+func WithResponseDecoder(decoder ResponseDecoder) CallOption {
+	return func(co *callOptions) {
+		co.decoder = decoder
+	}
+}
+
 // WithStreamReadTimeout sets the timeout between messages in streaming responses.
 //
 // This timeout is reset each time data is successfully read from the stream.
@@ -358,6 +734,141 @@ func WithStreamReadTimeout(timeout time.Duration) CallOption {
 	}
 }
 
+// StreamResumeFunc is called before a dropped stream is reopened. attempt
+// counts the consecutive attempts starting at 1, lastEventID is the ID the
+// stream resumes after, and cause is the read error that ended the previous
+// connection.
+type StreamResumeFunc func(attempt int, lastEventID string, cause error)
+
+// WithStreamAutoReconnect reopens a server-sent event stream when the
+// connection drops mid-stream, for example when a proxy closes an idle
+// connection during a long-running analysis.
+//
+// The stream is reopened by sending the original request again with the
+// Last-Event-ID header set to the ID of the last event received, so that the
+// server resumes after it instead of restarting the analysis. maxAttempts
+// bounds the consecutive attempts made without receiving an event, and delay
+// is the wait before each attempt (1s if <= 0, or the server's "retry" value
+// when it sends one).
+//
+// Example:
+//
+//	stream, err := client.AnalyzeDataStream(ctx, req,
+//		sdk.WithStreamAutoReconnect(5, 2*time.Second))
+func WithStreamAutoReconnect(maxAttempts int, delay time.Duration) CallOption {
+	return func(co *callOptions) {
+		if maxAttempts > 0 {
+			co.streamReconnects = maxAttempts
+		}
+		if delay > 0 {
+			co.streamRetryDelay = delay
+		}
+	}
+}
+
+// WithStreamResumeCallback registers fn to be called before each attempt to
+// reopen a dropped stream. It has no effect unless the stream reconnects,
+// see WithStreamAutoReconnect.
+//
+// Example:
+//
+//	stream, err := client.AnalyzeDataStream(ctx, req,
+//		sdk.WithStreamAutoReconnect(3, time.Second),
+//		sdk.WithStreamResumeCallback(func(attempt int, lastEventID string, cause error) {
+//			log.Printf("resuming analysis after %q (attempt %d): %v", lastEventID, attempt, cause)
+//		}))
+func WithStreamResumeCallback(fn StreamResumeFunc) CallOption {
+	return func(co *callOptions) {
+		co.streamResume = fn
+	}
+}
+
+// WithChunkedUpload switches UploadConnectorFile (and the SDKClient import
+// helpers built on it) to the resumable chunked upload mode.
+//
+// Instead of a single multipart POST, each file is uploaded in parts of
+// ChunkSize bytes, Parallelism parts at a time, and a failed part is retried
+// on its own. Files must implement io.ReaderAt with a known size, which
+// *os.File, *bytes.Reader and *strings.Reader all do. If the upload is
+// interrupted, the returned *ResumableUploadError carries a resume token.
+//
+// Example:
+//
+//	resp, err := client.UploadConnectorFile(ctx, req,
+//		sdk.WithChunkedUpload(sdk.ChunkedUploadOptions{
+//			ChunkSize:   16 << 20,
+//			Parallelism: 8,
+//		}))
+//	var resumable *sdk.ResumableUploadError
+//	if errors.As(err, &resumable) {
+//		// Retry later with ResumeTokens: map[string]string{resumable.FileName: resumable.ResumeToken}
+//	}
+func WithChunkedUpload(opts ChunkedUploadOptions) CallOption {
+	return func(co *callOptions) {
+		o := opts
+		co.chunkedUpload = &o
+	}
+}
+
+// WithProgress registers a callback that reports bytes transferred while a
+// download is read.
+//
+// It applies to the methods that return a FileStream, such as
+// DownloadFileStream, DownloadTableData and DownloadGenAIResult. The
+// callback runs on the goroutine reading FileStream.Body.
+//
+// Example:
+//
+//	stream, err := client.DownloadFileStream(ctx, fileID, volumeID,
+//		sdk.WithProgress(func(transferred, total int64) {
+//			fmt.Printf("\r%d / %d bytes", transferred, total)
+//		}))
+func WithProgress(fn ProgressFunc) CallOption {
+	return func(co *callOptions) {
+		co.progress = fn
+	}
+}
+
+// WithUploadProgress registers a callback that reports bytes sent while
+// files are uploaded.
+//
+// It applies to UploadLocalFiles and UploadConnectorFile, and so to
+// ImportLocalFileToVolume and ImportLocalFilesToVolume. For a multipart
+// request total is the size of the whole request body, form fields
+// included. When an upload is split into several requests or into chunks
+// with WithChunkedUpload, the callback receives one running total for the
+// whole call. Calls to it never overlap. Cancelling ctx aborts the upload
+// and the call returns the context error.
+//
+// Example:
+//
+//	resp, err := sdkClient.ImportLocalFileToVolume(ctx, "/data/video.mp4", volumeID, meta, nil,
+//		sdk.WithUploadProgress(func(sent, total int64) {
+//			fmt.Printf("\r%d / %d bytes", sent, total)
+//		}))
+func WithUploadProgress(fn ProgressFunc) CallOption {
+	return func(co *callOptions) {
+		co.uploadProgress = fn
+	}
+}
+
+// WithDebugLogging logs this call at info level together with its redacted
+// request and response bodies.
+//
+// JSON and text bodies are captured up to a size limit; multipart uploads,
+// downloads and event streams are logged without their bodies. The call is
+// logged to the client's logger, or to slog.Default if WithLogger was not
+// used.
+//
+// Example:
+//
+//	resp, err := client.CreateTable(ctx, req, sdk.WithDebugLogging())
+func WithDebugLogging() CallOption {
+	return func(co *callOptions) {
+		co.debugLogging = true
+	}
+}
+
 func cloneHeader(src http.Header) http.Header {
 	if len(src) == 0 {
 		return make(http.Header)
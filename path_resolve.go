@@ -0,0 +1,287 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Node types reported in TreeNode.Typ and DatabaseChildrenResponse.Typ.
+const (
+	NodeTypeCatalog  = "catalog"
+	NodeTypeDatabase = "database"
+	NodeTypeTable    = "table"
+	NodeTypeVolume   = "volume"
+)
+
+// ResolvedPath is the result of resolving a "catalog/database/object" path.
+//
+// Kind is one of the NodeType constants. IDs of the path's ancestors are
+// always filled in; TableID or VolumeID is set only when Kind is table or
+// volume respectively.
+type ResolvedPath struct {
+	Kind       string
+	Name       string
+	CatalogID  CatalogID
+	DatabaseID DatabaseID
+	TableID    TableID
+	VolumeID   VolumeID
+}
+
+// FindByPath looks up path in a catalog tree without making any requests.
+//
+// path has one to three "/"-separated segments: a catalog, a database in
+// that catalog, and a table or volume in that database. Names are matched
+// exactly. kinds optionally restricts the object types accepted for the last
+// segment of a three-segment path.
+//
+// The error wraps ErrPathNotFound when any segment is missing and
+// ErrAmbiguousPath when the last segment names both a table and a volume.
+func FindByPath(tree *CatalogTreeResponse, path string, kinds ...string) (*ResolvedPath, error) {
+	segments, err := splitCatalogPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if tree == nil {
+		return nil, fmt.Errorf("%w: %q", ErrPathNotFound, path)
+	}
+
+	catalogNode := findTreeNode(tree.Tree, segments[0], NodeTypeCatalog)
+	if catalogNode == nil {
+		return nil, fmt.Errorf("%w: catalog %q", ErrPathNotFound, segments[0])
+	}
+	resolved := &ResolvedPath{Kind: NodeTypeCatalog, Name: catalogNode.Name}
+	if resolved.CatalogID, err = parseCatalogID(catalogNode.ID); err != nil {
+		return nil, err
+	}
+	if len(segments) == 1 {
+		return resolved, nil
+	}
+
+	dbNode := findTreeNode(catalogNode.NodeList, segments[1], NodeTypeDatabase)
+	if dbNode == nil {
+		return nil, fmt.Errorf("%w: database %q in catalog %q", ErrPathNotFound, segments[1], segments[0])
+	}
+	resolved.Kind, resolved.Name = NodeTypeDatabase, dbNode.Name
+	if resolved.DatabaseID, err = parseDatabaseID(dbNode.ID); err != nil {
+		return nil, err
+	}
+	if len(segments) == 2 {
+		return resolved, nil
+	}
+
+	children := make([]DatabaseChildrenResponse, 0, len(dbNode.NodeList))
+	for _, n := range dbNode.NodeList {
+		if n != nil {
+			children = append(children, DatabaseChildrenResponse{ID: n.ID, Name: n.Name, Typ: n.Typ})
+		}
+	}
+	if err := resolveDatabaseChild(resolved, children, segments[2], kinds); err != nil {
+		return nil, fmt.Errorf("%w in %q", err, path)
+	}
+	return resolved, nil
+}
+
+// ResolvePath turns a human-readable "catalog/database/object" path into
+// typed IDs.
+//
+// The catalog tree is fetched once and searched with FindByPath. If the tree
+// does not list the children of the database, they are fetched with
+// GetDatabaseChildren.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - path: "catalog", "catalog/database" or "catalog/database/object" (required)
+//
+// Returns:
+//   - *ResolvedPath: the kind and IDs of the object the path refers to
+//   - error: wraps ErrPathNotFound or ErrAmbiguousPath when the path cannot
+//     be resolved to exactly one object
+//
+// Example:
+//
+//	p, err := sdkClient.ResolvePath(ctx, "sales/analytics/orders")
+//	if err != nil {
+//		return err
+//	}
+//	if p.Kind == sdk.NodeTypeTable {
+//		fmt.Println("table", p.TableID)
+//	}
+func (c *SDKClient) ResolvePath(ctx context.Context, path string, opts ...CallOption) (*ResolvedPath, error) {
+	return c.resolvePath(ctx, path, nil, opts)
+}
+
+// ResolveTablePath resolves a "catalog/database/table" path to a table ID.
+//
+// Example:
+//
+//	tableID, err := sdkClient.ResolveTablePath(ctx, "sales/analytics/orders")
+func (c *SDKClient) ResolveTablePath(ctx context.Context, path string, opts ...CallOption) (TableID, error) {
+	resolved, err := c.resolvePath(ctx, path, []string{NodeTypeTable}, opts)
+	if err != nil {
+		return 0, err
+	}
+	if resolved.Kind != NodeTypeTable {
+		return 0, fmt.Errorf("sdk: path %q refers to a %s, not a table", path, resolved.Kind)
+	}
+	return resolved.TableID, nil
+}
+
+// ResolveVolumePath resolves a "catalog/database/volume" path to a volume ID.
+//
+// Example:
+//
+//	volumeID, err := sdkClient.ResolveVolumePath(ctx, "sales/analytics/raw-docs")
+func (c *SDKClient) ResolveVolumePath(ctx context.Context, path string, opts ...CallOption) (VolumeID, error) {
+	resolved, err := c.resolvePath(ctx, path, []string{NodeTypeVolume}, opts)
+	if err != nil {
+		return "", err
+	}
+	if resolved.Kind != NodeTypeVolume {
+		return "", fmt.Errorf("sdk: path %q refers to a %s, not a volume", path, resolved.Kind)
+	}
+	return resolved.VolumeID, nil
+}
+
+func (c *SDKClient) resolvePath(ctx context.Context, path string, kinds []string, opts []CallOption) (*ResolvedPath, error) {
+	segments, err := splitCatalogPath(path)
+	if err != nil {
+		return nil, err
+	}
+	cache := c.raw.resolver
+	if cache == nil {
+		return c.lookupPath(ctx, path, segments, kinds, opts)
+	}
+	key := resolverKey(segments, kinds)
+	if resolved, ok := cache.get(key); ok {
+		return resolved, nil
+	}
+	resolved, err := c.lookupPath(ctx, path, segments, kinds, opts)
+	if err != nil {
+		return nil, err
+	}
+	cache.put(key, resolved)
+	return resolved, nil
+}
+
+// lookupPath resolves the segments of path with the catalog tree, and the
+// children of the database if the tree omits them.
+func (c *SDKClient) lookupPath(ctx context.Context, path string, segments, kinds []string, opts []CallOption) (*ResolvedPath, error) {
+	tree, err := c.raw.GetCatalogTree(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) < 3 {
+		return FindByPath(tree, path, kinds...)
+	}
+
+	// Resolve the database first so that its children can be fetched when
+	// the tree response omits them.
+	dbPath := segments[0] + "/" + segments[1]
+	resolved, err := FindByPath(tree, dbPath)
+	if err != nil {
+		return nil, err
+	}
+	dbNode := findTreeNode(findTreeNode(tree.Tree, segments[0], NodeTypeCatalog).NodeList, segments[1], NodeTypeDatabase)
+	if len(dbNode.NodeList) > 0 {
+		return FindByPath(tree, path, kinds...)
+	}
+	children, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: resolved.DatabaseID}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveDatabaseChild(resolved, children.List, segments[2], kinds); err != nil {
+		return nil, fmt.Errorf("%w in %q", err, path)
+	}
+	return resolved, nil
+}
+
+// resolveDatabaseChild finds name among children and records it in resolved.
+func resolveDatabaseChild(resolved *ResolvedPath, children []DatabaseChildrenResponse, name string, kinds []string) error {
+	var match *DatabaseChildrenResponse
+	for i := range children {
+		child := &children[i]
+		if child.Name != name || !acceptsKind(kinds, child.Typ) {
+			continue
+		}
+		if child.Typ != NodeTypeTable && child.Typ != NodeTypeVolume {
+			continue
+		}
+		if match != nil && match.Typ != child.Typ {
+			return fmt.Errorf("%w: %q is both a table and a volume", ErrAmbiguousPath, name)
+		}
+		match = child
+	}
+	if match == nil {
+		return fmt.Errorf("%w: %q", ErrPathNotFound, name)
+	}
+
+	resolved.Kind, resolved.Name = match.Typ, match.Name
+	switch match.Typ {
+	case NodeTypeTable:
+		id, err := strconv.ParseInt(match.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("sdk: invalid table id %q: %w", match.ID, err)
+		}
+		resolved.TableID = TableID(id)
+	case NodeTypeVolume:
+		resolved.VolumeID = VolumeID(match.ID)
+	}
+	return nil
+}
+
+func splitCatalogPath(path string) ([]string, error) {
+	trimmed := strings.Trim(strings.TrimSpace(path), "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	segments := strings.Split(trimmed, "/")
+	if len(segments) > 3 {
+		return nil, fmt.Errorf("path %q has more than 3 segments", path)
+	}
+	for i, s := range segments {
+		segments[i] = strings.TrimSpace(s)
+		if segments[i] == "" {
+			return nil, fmt.Errorf("path %q contains an empty segment", path)
+		}
+	}
+	return segments, nil
+}
+
+func findTreeNode(nodes []*TreeNode, name, typ string) *TreeNode {
+	for _, n := range nodes {
+		if n != nil && n.Name == name && (n.Typ == "" || n.Typ == typ) {
+			return n
+		}
+	}
+	return nil
+}
+
+func acceptsKind(kinds []string, typ string) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if k == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCatalogID(s string) (CatalogID, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("sdk: invalid catalog id %q: %w", s, err)
+	}
+	return CatalogID(id), nil
+}
+
+func parseDatabaseID(s string) (DatabaseID, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("sdk: invalid database id %q: %w", s, err)
+	}
+	return DatabaseID(id), nil
+}
@@ -0,0 +1,102 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCatalogTree() *CatalogTreeResponse {
+	return &CatalogTreeResponse{Tree: []*TreeNode{
+		{Typ: NodeTypeCatalog, ID: "1", Name: "sales", NodeList: []*TreeNode{
+			{Typ: NodeTypeDatabase, ID: "10", Name: "analytics", NodeList: []*TreeNode{
+				{Typ: NodeTypeTable, ID: "100", Name: "orders"},
+				{Typ: NodeTypeVolume, ID: "vol-1", Name: "docs"},
+				{Typ: NodeTypeTable, ID: "101", Name: "shared"},
+				{Typ: NodeTypeVolume, ID: "vol-2", Name: "shared"},
+			}},
+			{Typ: NodeTypeDatabase, ID: "11", Name: "empty"},
+		}},
+	}}
+}
+
+func TestFindByPath(t *testing.T) {
+	t.Parallel()
+	tree := testCatalogTree()
+
+	p, err := FindByPath(tree, "sales")
+	require.NoError(t, err)
+	require.Equal(t, &ResolvedPath{Kind: NodeTypeCatalog, Name: "sales", CatalogID: 1}, p)
+
+	p, err = FindByPath(tree, "/sales/analytics/")
+	require.NoError(t, err)
+	require.Equal(t, NodeTypeDatabase, p.Kind)
+	require.Equal(t, DatabaseID(10), p.DatabaseID)
+
+	p, err = FindByPath(tree, "sales/analytics/orders")
+	require.NoError(t, err)
+	require.Equal(t, NodeTypeTable, p.Kind)
+	require.Equal(t, CatalogID(1), p.CatalogID)
+	require.Equal(t, DatabaseID(10), p.DatabaseID)
+	require.Equal(t, TableID(100), p.TableID)
+
+	p, err = FindByPath(tree, "sales/analytics/docs")
+	require.NoError(t, err)
+	require.Equal(t, VolumeID("vol-1"), p.VolumeID)
+
+	_, err = FindByPath(tree, "sales/analytics/shared")
+	require.True(t, errors.Is(err, ErrAmbiguousPath))
+	p, err = FindByPath(tree, "sales/analytics/shared", NodeTypeVolume)
+	require.NoError(t, err)
+	require.Equal(t, VolumeID("vol-2"), p.VolumeID)
+
+	for _, missing := range []string{"nope", "sales/nope", "sales/analytics/nope"} {
+		_, err = FindByPath(tree, missing)
+		require.True(t, errors.Is(err, ErrPathNotFound), missing)
+	}
+	for _, bad := range []string{"", "a//b", "a/b/c/d"} {
+		_, err = FindByPath(tree, bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestSDKClientResolvePath_FetchesChildren(t *testing.T) {
+	t.Parallel()
+	var childrenCalls int32
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/tree": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, testCatalogTree())
+		},
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&childrenCalls, 1)
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+				{ID: "200", Name: "events", Typ: NodeTypeTable},
+			}})
+		},
+	})
+	client := NewSDKClient(raw)
+	ctx := context.Background()
+
+	tableID, err := client.ResolveTablePath(ctx, "sales/empty/events")
+	require.NoError(t, err)
+	require.Equal(t, TableID(200), tableID)
+	require.Equal(t, int32(1), atomic.LoadInt32(&childrenCalls))
+
+	tableID, err = client.ResolveTablePath(ctx, "sales/analytics/shared")
+	require.NoError(t, err)
+	require.Equal(t, TableID(101), tableID)
+	require.Equal(t, int32(1), atomic.LoadInt32(&childrenCalls))
+
+	volumeID, err := client.ResolveVolumePath(ctx, "sales/analytics/docs")
+	require.NoError(t, err)
+	require.Equal(t, VolumeID("vol-1"), volumeID)
+
+	_, err = client.ResolveVolumePath(ctx, "sales/analytics/orders")
+	require.True(t, errors.Is(err, ErrPathNotFound))
+	_, err = client.ResolveTablePath(ctx, "sales/analytics")
+	require.ErrorContains(t, err, "not a table")
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"fmt"
 )
 
 // ListObjectsByCategory lists objects by category for privilege management.
@@ -25,9 +26,76 @@ func (c *RawClient) ListObjectsByCategory(ctx context.Context, req *PrivListObjB
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp PrivListObjByCategoryResponse
 	if err := c.postJSON(ctx, "/rbac/priv/list_obj_by_category", req, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
+
+// GetAuthorizedObjects returns the objects on which the caller holds a
+// privilege.
+//
+// ObjPrivIDList lists further privileges that also qualify an object; an
+// object is returned if any of them is held. AllAuthorized is true when
+// the privilege is held globally, in which case ObjectIDList is not
+// meaningful.
+//
+// Example:
+//
+//	resp, err := client.GetAuthorizedObjects(ctx, &sdk.PrivGetAuthorizedObjectsRequest{
+//		PrivID: sdk.PrivID_TableSelect,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if !resp.AllAuthorized {
+//		fmt.Printf("Readable tables: %v\n", resp.ObjectIDList)
+//	}
+func (c *RawClient) GetAuthorizedObjects(ctx context.Context, req *PrivGetAuthorizedObjectsRequest, opts ...CallOption) (*PrivGetAuthorizedObjectsResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp PrivGetAuthorizedObjectsResponse
+	if err := c.postJSON(ctx, "/rbac/priv/get_authorized_objects", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CheckPrivs checks several privileges of the caller in one request.
+//
+// The results are in the order of privs. Use PrivCodeToPrivIDMap to check
+// a privilege known by its code.
+//
+// Example:
+//
+//	results, err := client.CheckPrivs(ctx, []sdk.CheckPriv{
+//		{PrivID: sdk.PrivID_TableSelect, ObjectID: sdk.IntToPrivObjectID(123)},
+//		{PrivID: sdk.PrivID_TableInsert, ObjectID: sdk.IntToPrivObjectID(123)},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, r := range results {
+//		fmt.Printf("priv %d on %s: %v\n", r.PrivID, r.ObjectID, r.Allowed)
+//	}
+func (c *RawClient) CheckPrivs(ctx context.Context, privs []CheckPriv, opts ...CallOption) ([]CheckPrivResult, error) {
+	if len(privs) == 0 {
+		return nil, nil
+	}
+	var resp PrivCheckResponse
+	if err := c.postJSON(ctx, "/rbac/priv/check", &PrivCheckRequest{CheckList: privs}, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if len(resp.List) != len(privs) {
+		return nil, fmt.Errorf("check privs: got %d results for %d checks", len(resp.List), len(privs))
+	}
+	return resp.List, nil
+}
@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanUser(t *testing.T) {
+	t.Parallel()
+	roles := map[RoleID]RoleInfoResponse{
+		1: {RoleID: 1, RoleName: "reader", Status: "enable", ObjAuthorityList: []*ObjPrivResponse{
+			{ObjID: "123", ObjType: "table", AuthorityCodeList: []*AuthorityCodeAndRule{
+				{Code: "DT8", BlackColumnList: []string{"ssn"}},
+			}},
+			// Same ID, other category.
+			{ObjID: "123", ObjType: "database", AuthorityCodeList: []*AuthorityCodeAndRule{{Code: "DT8"}}},
+		}},
+		2: {RoleID: 2, RoleName: "disabled-admin", Status: "disable", AuthorityList: []*PrivResponse{{PrivCode: "DT8"}}},
+		3: {RoleID: 3, RoleName: "user-admin", Status: "enable", AuthorityList: []*PrivResponse{{PrivCode: "U1"}}},
+	}
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/user/detail_info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, UserDetailInfoResponse{UserResponse: UserResponse{ID: 7, RoleList: []*RoleIDName{{ID: 1}, {ID: 2}, {ID: 3}}}})
+		},
+		"/role/info": func(w http.ResponseWriter, r *http.Request) {
+			var req RoleInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			writeEnvelope(w, roles[req.RoleID])
+		},
+	}))
+	ctx := context.Background()
+
+	check, err := client.CanUser(ctx, 7, PrivCode_TableSelect, IntToPrivObjectID(123))
+	require.NoError(t, err)
+	require.True(t, check.Allowed)
+	require.False(t, check.Global)
+	require.Equal(t, []string{"reader"}, check.Roles)
+	require.Len(t, check.Grants, 1)
+	require.Equal(t, []string{"ssn"}, check.Grants[0].BlackColumnList)
+
+	check, err = client.CanUser(ctx, 7, PrivCode_TableSelect, IntToPrivObjectID(456))
+	require.NoError(t, err)
+	require.False(t, check.Allowed)
+
+	check, err = client.CanUser(ctx, 7, PrivCode_CreateUser, "")
+	require.NoError(t, err)
+	require.True(t, check.Allowed)
+	require.True(t, check.Global)
+	require.Equal(t, []string{"user-admin"}, check.Roles)
+}
+
+func TestCanUser_Caller(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/user/me/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, UserMeInfoResponse{AuthorityCodeList: []string{"U2"}})
+		},
+		"/rbac/priv/get_authorized_objects": func(w http.ResponseWriter, r *http.Request) {
+			var req PrivGetAuthorizedObjectsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, PrivID_TableSelect, req.PrivID)
+			writeEnvelope(w, PrivGetAuthorizedObjectsResponse{ObjectIDList: []PrivObjectID{"123"}})
+		},
+	}))
+	ctx := context.Background()
+
+	check, err := client.CanUser(ctx, 0, PrivCode_QueryUser, "")
+	require.NoError(t, err)
+	require.True(t, check.Allowed)
+	require.True(t, check.Global)
+
+	check, err = client.CanUser(ctx, 0, PrivCode_TableSelect, "123")
+	require.NoError(t, err)
+	require.True(t, check.Allowed)
+	require.False(t, check.Global)
+
+	check, err = client.CanUser(ctx, 0, PrivCode_TableSelect, "456")
+	require.NoError(t, err)
+	require.False(t, check.Allowed)
+
+	_, err = client.CanUser(ctx, 0, "", "123")
+	require.Error(t, err)
+}
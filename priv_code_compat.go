@@ -0,0 +1,192 @@
+package sdk
+
+import "fmt"
+
+// legacyPrivCodes maps the 4.0 privilege codes that were renumbered in 4.1
+// to their current codes. Several 4.0 codes, such as C3, were reused in 4.1
+// for new privileges, so a code alone does not tell which scheme it is in.
+var legacyPrivCodes = map[PrivCode]PrivCode{
+	//连接器
+	"C3": PrivCode_UpdateConnector,
+	"C4": PrivCode_DeleteConnector,
+
+	//数据载入任务
+	"L3": PrivCode_UpdateLoadTask,
+	"L4": PrivCode_DeleteLoadTask,
+
+	//工作流
+	"W4": PrivCode_StopWorkflow,
+	"W5": PrivCode_UpdateWorkflow,
+	"W6": PrivCode_DeleteWorkflow,
+
+	//目录
+	"D1": PrivCode_CreateCatalog,
+	"D2": PrivCode_QueryCatalog,
+	"D3": PrivCode_UpdateCatalog,
+	"D4": PrivCode_DeleteCatalog,
+
+	//数据库
+	"D5": PrivCode_CreateDatabase,
+	"D6": PrivCode_QueryDatabase,
+	"D7": PrivCode_UpdateDatabase,
+	"D8": PrivCode_DeleteDatabase,
+
+	//数据导出任务
+	"E3": PrivCode_DeleteExportTask,
+
+	//数据卷
+	"D9":  PrivCode_CreateVolume,
+	"D10": PrivCode_QueryVolume,
+	"D11": PrivCode_UpdateVolume,
+	"D12": PrivCode_DeleteVolume,
+}
+
+// privCodesAddedIn41 are the privileges that do not exist on 4.0 servers.
+var privCodesAddedIn41 = []PrivCode{
+	PrivCode_GetConnector, PrivCode_UseConnector,
+	PrivCode_GetLoadTask,
+	PrivCode_GetExportTask, PrivCode_UpdateExportTask,
+	PrivCode_GetWorkflow,
+	PrivCode_VolumeRead, PrivCode_VolumeWrite,
+	PrivCode_CreateTable, PrivCode_ShowTables, PrivCode_AlterTable, PrivCode_DropTable,
+	PrivCode_CreateView, PrivCode_AlterView, PrivCode_DropView,
+	PrivCode_TableSelect, PrivCode_TableInsert, PrivCode_TableUpdate, PrivCode_TableDelete,
+	PrivCode_TableTruncate, PrivCode_TableReference, PrivCode_TableIndex,
+	PrivCode_CreateKnowledge, PrivCode_QueryKnowledge, PrivCode_UpdateKnowledge,
+	PrivCode_DeleteKnowledge, PrivCode_UseKnowledge,
+	PrivCode_CreatePublication, PrivCode_QueryPublication, PrivCode_UpdatePublication,
+	PrivCode_DeletePublication, PrivCode_QuerySubscription, PrivCode_CreateSubscription,
+	PrivCode_UpdateSubscription, PrivCode_DeleteSubscription,
+	PrivCode_CreateDataSet, PrivCode_QueryDataSet, PrivCode_UpdateDataSet,
+	PrivCode_DeleteDataSet, PrivCode_GetDataSet,
+}
+
+// PrivCodeTranslator translates privilege codes between the 4.0 scheme and
+// the current one, so that role definitions written for 4.0 servers can be
+// applied to current servers and the other way round.
+//
+// Because 4.1 reuses some 4.0 codes for other privileges (C3 was "update
+// connector" and is now "get connector"), the scheme of the input must be
+// known: ToCurrent takes 4.0 codes and ToLegacy takes current ones.
+//
+// Example:
+//
+//	t := sdk.NewPrivCodeTranslator()
+//	req := &sdk.RoleCreateRequest{
+//		RoleName:    "loader",
+//		PrivList:    t.ToCurrentCodes([]string{"L1", "L3", "D2"}), // L1, L4, DC2
+//		ObjPrivList: t.ToCurrentObjPrivs(legacyObjPrivs),
+//	}
+type PrivCodeTranslator struct {
+	toCurrent map[PrivCode]PrivCode
+	toLegacy  map[PrivCode]PrivCode
+	current   map[PrivCode]bool // Codes without a 4.0 equivalent
+}
+
+// NewPrivCodeTranslator returns a translator for the 4.0 to 4.1 code
+// migrations.
+func NewPrivCodeTranslator() *PrivCodeTranslator {
+	t := &PrivCodeTranslator{
+		toCurrent: make(map[PrivCode]PrivCode, len(legacyPrivCodes)),
+		toLegacy:  make(map[PrivCode]PrivCode, len(legacyPrivCodes)),
+		current:   make(map[PrivCode]bool, len(privCodesAddedIn41)),
+	}
+	for legacy, current := range legacyPrivCodes {
+		t.toCurrent[legacy] = current
+		t.toLegacy[current] = legacy
+	}
+	for _, code := range privCodesAddedIn41 {
+		t.current[code] = true
+	}
+	return t
+}
+
+// ToCurrent returns the current code of a 4.0 code. Codes that did not
+// change are returned as is.
+func (t *PrivCodeTranslator) ToCurrent(code PrivCode) PrivCode {
+	if current, ok := t.toCurrent[code]; ok {
+		return current
+	}
+	return code
+}
+
+// ToLegacy returns the 4.0 code of a current code. Codes that did not change
+// are returned as is; codes of privileges added in 4.1 are an error.
+func (t *PrivCodeTranslator) ToLegacy(code PrivCode) (PrivCode, error) {
+	if legacy, ok := t.toLegacy[code]; ok {
+		return legacy, nil
+	}
+	if t.current[code] {
+		return "", fmt.Errorf("privilege %s does not exist before 4.1", code)
+	}
+	return code, nil
+}
+
+// ToCurrentCodes translates a list of 4.0 codes, such as
+// RoleCreateRequest.PrivList.
+func (t *PrivCodeTranslator) ToCurrentCodes(codes []string) []string {
+	if codes == nil {
+		return nil
+	}
+	out := make([]string, len(codes))
+	for i, code := range codes {
+		out[i] = string(t.ToCurrent(PrivCode(code)))
+	}
+	return out
+}
+
+// ToLegacyCodes translates a list of current codes to 4.0 codes.
+func (t *PrivCodeTranslator) ToLegacyCodes(codes []string) ([]string, error) {
+	if codes == nil {
+		return nil, nil
+	}
+	out := make([]string, len(codes))
+	for i, code := range codes {
+		legacy, err := t.ToLegacy(PrivCode(code))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = string(legacy)
+	}
+	return out, nil
+}
+
+// ToCurrentObjPrivs translates the codes of object privileges written with
+// 4.0 codes. The input is not modified.
+func (t *PrivCodeTranslator) ToCurrentObjPrivs(privs []ObjPrivResponse) []ObjPrivResponse {
+	out, _ := t.translateObjPrivs(privs, func(code PrivCode) (PrivCode, error) {
+		return t.ToCurrent(code), nil
+	})
+	return out
+}
+
+// ToLegacyObjPrivs translates the codes of object privileges to 4.0 codes.
+// The input is not modified.
+func (t *PrivCodeTranslator) ToLegacyObjPrivs(privs []ObjPrivResponse) ([]ObjPrivResponse, error) {
+	return t.translateObjPrivs(privs, t.ToLegacy)
+}
+
+func (t *PrivCodeTranslator) translateObjPrivs(privs []ObjPrivResponse, translate func(PrivCode) (PrivCode, error)) ([]ObjPrivResponse, error) {
+	if privs == nil {
+		return nil, nil
+	}
+	out := make([]ObjPrivResponse, len(privs))
+	for i, priv := range privs {
+		codes := make([]*AuthorityCodeAndRule, len(priv.AuthorityCodeList))
+		for j, code := range priv.AuthorityCodeList {
+			if code == nil {
+				continue
+			}
+			translated, err := translate(PrivCode(code.Code))
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", priv.ObjType, priv.ObjID, err)
+			}
+			copied := *code
+			copied.Code = string(translated)
+			codes[j] = &copied
+		}
+		priv.AuthorityCodeList = codes
+		out[i] = priv
+	}
+	return out, nil
+}
@@ -0,0 +1,55 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivCodeTranslator(t *testing.T) {
+	t.Parallel()
+	tr := NewPrivCodeTranslator()
+
+	require.Equal(t, []string{"L1", "L4", "DC2", "C4", "DV4", "U1"}, tr.ToCurrentCodes([]string{"L1", "L3", "D2", "C3", "D12", "U1"}))
+	legacy, err := tr.ToLegacyCodes([]string{"L4", "DC2", "C5", "U1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"L3", "D2", "C4", "U1"}, legacy)
+
+	_, err = tr.ToLegacy(PrivCode_GetConnector)
+	require.Error(t, err)
+	_, err = tr.ToLegacyCodes([]string{"DT8"})
+	require.Error(t, err)
+
+	// Every migrated code maps back to itself.
+	for old := range legacyPrivCodes {
+		back, err := tr.ToLegacy(tr.ToCurrent(old))
+		require.NoError(t, err)
+		require.Equal(t, old, back)
+	}
+}
+
+func TestPrivCodeTranslatorObjPrivs(t *testing.T) {
+	t.Parallel()
+	tr := NewPrivCodeTranslator()
+	in := []ObjPrivResponse{{
+		ObjID:   "7",
+		ObjType: ObjTypeConnector.String(),
+		AuthorityCodeList: []*AuthorityCodeAndRule{
+			{Code: "C3"},
+			{Code: "C4"},
+		},
+	}}
+
+	out := tr.ToCurrentObjPrivs(in)
+	require.Equal(t, "C4", out[0].AuthorityCodeList[0].Code)
+	require.Equal(t, "C5", out[0].AuthorityCodeList[1].Code)
+	require.Equal(t, "C3", in[0].AuthorityCodeList[0].Code)
+
+	back, err := tr.ToLegacyObjPrivs(out)
+	require.NoError(t, err)
+	require.Equal(t, in, back)
+
+	out[0].AuthorityCodeList[0].Code = string(PrivCode_UseConnector)
+	_, err = tr.ToLegacyObjPrivs(out)
+	require.ErrorContains(t, err, "connector 7")
+}
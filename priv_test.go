@@ -3,6 +3,7 @@ package sdk
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -74,6 +75,7 @@ func TestPrivNilRequestErrors(t *testing.T) {
 		call func() error
 	}{
 		{"ListByCategory", func() error { _, err := client.ListObjectsByCategory(ctx, nil); return err }},
+		{"GetAuthorizedObjects", func() error { _, err := client.GetAuthorizedObjects(ctx, nil); return err }},
 	}
 
 	for _, tc := range tests {
@@ -83,6 +85,47 @@ func TestPrivNilRequestErrors(t *testing.T) {
 	}
 }
 
+func TestCheckPrivs(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/rbac/priv/check": func(w http.ResponseWriter, r *http.Request) {
+			var req PrivCheckRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			resp := PrivCheckResponse{}
+			for _, c := range req.CheckList {
+				resp.List = append(resp.List, CheckPrivResult{CheckPriv: c, Allowed: c.PrivID == PrivID_TableSelect})
+			}
+			writeEnvelope(w, resp)
+		},
+	})
+	ctx := context.Background()
+
+	results, err := client.CheckPrivs(ctx, []CheckPriv{
+		{PrivID: PrivID_TableSelect, ObjectID: "123"},
+		{PrivID: PrivID_TableInsert, ObjectID: "123"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.True(t, results[0].Allowed)
+	require.False(t, results[1].Allowed)
+	require.Equal(t, PrivID_TableInsert, results[1].PrivID)
+
+	results, err = client.CheckPrivs(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestCheckPrivs_ResultCountMismatch(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/rbac/priv/check": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, PrivCheckResponse{})
+		},
+	})
+	_, err := client.CheckPrivs(context.Background(), []CheckPriv{{PrivID: PrivID_TableSelect, ObjectID: "123"}})
+	require.ErrorContains(t, err, "0 results for 1 checks")
+}
+
 // TestTableRowColExpression_JSON 测试 TableRowColExpression 的 JSON 序列化和反序列化
 func TestTableRowColExpression_JSON(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,199 @@
+package sdk
+
+import (
+	"sync"
+	"time"
+)
+
+// ProgressEventType is the kind of a ProgressEvent.
+type ProgressEventType string
+
+const (
+	// ProgressStarted is reported once, when the items of the operation are
+	// known and before the first of them starts.
+	ProgressStarted ProgressEventType = "started"
+	// ProgressItemStarted is reported when work on an item begins.
+	ProgressItemStarted ProgressEventType = "item_started"
+	// ProgressItemFinished is reported when an item completed.
+	ProgressItemFinished ProgressEventType = "item_finished"
+	// ProgressItemSkipped is reported for an item left alone because it was
+	// already done, such as an existing file with SkipExisting.
+	ProgressItemSkipped ProgressEventType = "item_skipped"
+	// ProgressItemFailed is reported when an item failed; Err is set.
+	ProgressItemFailed ProgressEventType = "item_failed"
+	// ProgressFinished is reported once, when the operation is over, even if
+	// some items failed.
+	ProgressFinished ProgressEventType = "finished"
+)
+
+// Operations reported in ProgressEvent.Operation.
+const (
+	OperationImportDirectory = "import_directory"
+	OperationExportVolume    = "export_volume"
+	OperationCascadeDelete   = "cascade_delete"
+	OperationMigrateTable    = "migrate_table"
+	OperationMigrateVolume   = "migrate_volume"
+)
+
+// ProgressEvent is one step of a long-running operation, as passed to a
+// ProgressReporter. The counters are the totals of the operation at the
+// time of the event, so a reporter can render each event on its own.
+type ProgressEvent struct {
+	Type ProgressEventType
+	// Operation is the operation reporting progress, one of the Operation
+	// constants.
+	Operation string
+	// Item names the item the event is about, such as a file path or
+	// `table "orders"`; empty for ProgressStarted and ProgressFinished.
+	Item string
+	// Bytes is the size of the item, when known.
+	Bytes int64
+	// Err is the error of a ProgressItemFailed event.
+	Err error
+
+	// ItemsDone counts the items finished, skipped or failed so far, out of
+	// ItemsTotal.
+	ItemsDone  int64
+	ItemsTotal int64
+	// BytesDone counts the bytes of the items done so far, out of
+	// BytesTotal. Both are 0 for operations that do not move data.
+	BytesDone  int64
+	BytesTotal int64
+	// Elapsed is the time since the operation started.
+	Elapsed time.Duration
+	// ETA estimates the time left from the rate so far, by bytes when the
+	// operation moves data and by items otherwise. It is 0 until the first
+	// item is done.
+	ETA time.Duration
+}
+
+// ProgressReporter receives the progress of long-running SDKClient
+// operations, such as directory imports and exports, cascade deletes and
+// migrations, so that CLIs and UIs can render them all the same way.
+//
+// Events of one operation are delivered one at a time, even when the
+// operation works on several items concurrently, so Report needs no
+// locking of its own. It should return quickly, since the operation waits
+// for it.
+type ProgressReporter interface {
+	Report(event ProgressEvent)
+}
+
+// ProgressReporterFunc adapts a function to the ProgressReporter interface.
+type ProgressReporterFunc func(event ProgressEvent)
+
+// Report calls f(event).
+func (f ProgressReporterFunc) Report(event ProgressEvent) {
+	f(event)
+}
+
+// ProgressTracker keeps the counters of one operation and reports its
+// events to a ProgressReporter. Operations outside this package, such as
+// migrations, use it to report progress like the SDKClient helpers do.
+//
+// All methods are safe for concurrent use and do nothing on a nil
+// *ProgressTracker, which NewProgressTracker returns for a nil reporter.
+type ProgressTracker struct {
+	reporter  ProgressReporter
+	operation string
+	now       func() time.Time
+
+	mu         sync.Mutex
+	start      time.Time
+	itemsDone  int64
+	itemsTotal int64
+	bytesDone  int64
+	bytesTotal int64
+}
+
+// NewProgressTracker returns a tracker reporting the events of operation to
+// r, or nil if r is nil.
+func NewProgressTracker(r ProgressReporter, operation string) *ProgressTracker {
+	if r == nil {
+		return nil
+	}
+	return &ProgressTracker{reporter: r, operation: operation, now: time.Now}
+}
+
+// Start reports ProgressStarted with the number of items and bytes the
+// operation will go through; bytes is 0 if it moves no data. The ETA is
+// measured from this call.
+func (t *ProgressTracker) Start(items, bytes int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.start = t.now()
+	t.itemsTotal, t.bytesTotal = items, bytes
+	t.report(ProgressEvent{Type: ProgressStarted})
+}
+
+// ItemStarted reports that work on item begins.
+func (t *ProgressTracker) ItemStarted(item string, bytes int64) {
+	t.item(ProgressEvent{Type: ProgressItemStarted, Item: item, Bytes: bytes})
+}
+
+// ItemFinished reports that item completed.
+func (t *ProgressTracker) ItemFinished(item string, bytes int64) {
+	t.item(ProgressEvent{Type: ProgressItemFinished, Item: item, Bytes: bytes})
+}
+
+// ItemSkipped reports that item was left alone.
+func (t *ProgressTracker) ItemSkipped(item string, bytes int64) {
+	t.item(ProgressEvent{Type: ProgressItemSkipped, Item: item, Bytes: bytes})
+}
+
+// ItemFailed reports that item failed with err.
+func (t *ProgressTracker) ItemFailed(item string, bytes int64, err error) {
+	t.item(ProgressEvent{Type: ProgressItemFailed, Item: item, Bytes: bytes, Err: err})
+}
+
+// Finish reports ProgressFinished.
+func (t *ProgressTracker) Finish() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.report(ProgressEvent{Type: ProgressFinished})
+}
+
+func (t *ProgressTracker) item(e ProgressEvent) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e.Type != ProgressItemStarted {
+		t.itemsDone++
+		t.bytesDone += e.Bytes
+	}
+	t.report(e)
+}
+
+// report fills in the counters of e and hands it to the reporter. t.mu is
+// held, which serializes the calls to Report.
+func (t *ProgressTracker) report(e ProgressEvent) {
+	e.Operation = t.operation
+	e.ItemsDone, e.ItemsTotal = t.itemsDone, t.itemsTotal
+	e.BytesDone, e.BytesTotal = t.bytesDone, t.bytesTotal
+	if !t.start.IsZero() {
+		e.Elapsed = t.now().Sub(t.start)
+	}
+	e.ETA = estimateRemaining(e.Elapsed, t.bytesDone, t.bytesTotal, t.itemsDone, t.itemsTotal)
+	t.reporter.Report(e)
+}
+
+// estimateRemaining extrapolates the time left from the time elapsed and
+// the share of the work done, by bytes when known and by items otherwise.
+func estimateRemaining(elapsed time.Duration, bytesDone, bytesTotal, itemsDone, itemsTotal int64) time.Duration {
+	done, total := itemsDone, itemsTotal
+	if bytesTotal > 0 {
+		done, total = bytesDone, bytesTotal
+	}
+	if done <= 0 || total <= done {
+		return 0
+	}
+	return time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+}
@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressTracker(t *testing.T) {
+	t.Parallel()
+	var events []ProgressEvent
+	tracker := NewProgressTracker(ProgressReporterFunc(func(e ProgressEvent) { events = append(events, e) }), OperationExportVolume)
+	now := time.Unix(1000, 0)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Start(3, 400)
+	tracker.ItemStarted("a", 100)
+	now = now.Add(10 * time.Second)
+	tracker.ItemFinished("a", 100)
+	tracker.ItemSkipped("b", 100)
+	boom := errors.New("boom")
+	tracker.ItemFailed("c", 200, boom)
+	tracker.Finish()
+
+	require.Len(t, events, 6)
+	for _, e := range events {
+		require.Equal(t, OperationExportVolume, e.Operation)
+		require.Equal(t, int64(3), e.ItemsTotal)
+		require.Equal(t, int64(400), e.BytesTotal)
+	}
+	require.Equal(t, ProgressStarted, events[0].Type)
+	require.Equal(t, ProgressEvent{Type: ProgressItemStarted, Operation: OperationExportVolume, Item: "a", Bytes: 100, ItemsTotal: 3, BytesTotal: 400}, events[1])
+
+	finished := events[2]
+	require.Equal(t, ProgressItemFinished, finished.Type)
+	require.Equal(t, int64(1), finished.ItemsDone)
+	require.Equal(t, int64(100), finished.BytesDone)
+	require.Equal(t, 10*time.Second, finished.Elapsed)
+	require.Equal(t, 30*time.Second, finished.ETA)
+
+	require.Equal(t, ProgressItemSkipped, events[3].Type)
+	require.Equal(t, 10*time.Second, events[3].ETA)
+	require.ErrorIs(t, events[4].Err, boom)
+	require.Equal(t, ProgressFinished, events[5].Type)
+	require.Equal(t, int64(3), events[5].ItemsDone)
+	require.Equal(t, int64(400), events[5].BytesDone)
+	require.Zero(t, events[5].ETA)
+}
+
+func TestProgressTrackerNil(t *testing.T) {
+	t.Parallel()
+	tracker := NewProgressTracker(nil, OperationImportDirectory)
+	require.Nil(t, tracker)
+	tracker.Start(1, 0)
+	tracker.ItemStarted("a", 0)
+	tracker.ItemFinished("a", 0)
+	tracker.ItemFailed("a", 0, errors.New("boom"))
+	tracker.Finish()
+}
+
+func TestEstimateRemainingByItems(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, 6*time.Second, estimateRemaining(2*time.Second, 0, 0, 1, 4))
+	require.Zero(t, estimateRemaining(2*time.Second, 0, 0, 0, 4))
+	require.Zero(t, estimateRemaining(2*time.Second, 0, 0, 4, 4))
+}
+
+func TestImportDirectoryToVolumeProgress(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	for name, content := range map[string]string{"a.txt": "hello", "b.txt": "hi", "c.txt": "fail"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o644))
+	}
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			if r.MultipartForm.File["file"][0].Filename == "c.txt" {
+				writeEnvelopeError(w, "ErrPermissionDenied", "denied")
+				return
+			}
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	})
+
+	// The reporter appends without locking: events must not overlap even
+	// with several workers.
+	var events []ProgressEvent
+	res, err := NewSDKClient(raw).ImportDirectoryToVolume(context.Background(), root, "vol-1", &DirectoryImportOptions{
+		Concurrency: 3,
+		Progress:    ProgressReporterFunc(func(e ProgressEvent) { events = append(events, e) }),
+	})
+	require.Error(t, err)
+	require.Len(t, res.Failed(), 1)
+
+	require.Len(t, events, 8)
+	require.Equal(t, ProgressStarted, events[0].Type)
+	require.Equal(t, int64(3), events[0].ItemsTotal)
+	require.Equal(t, int64(11), events[0].BytesTotal)
+	last := events[len(events)-1]
+	require.Equal(t, ProgressFinished, last.Type)
+	require.Equal(t, int64(3), last.ItemsDone)
+	require.Equal(t, int64(11), last.BytesDone)
+
+	counts := map[ProgressEventType]int{}
+	for _, e := range events {
+		counts[e.Type]++
+		if e.Type == ProgressItemFailed {
+			require.Equal(t, "c.txt", e.Item)
+			require.Error(t, e.Err)
+		}
+	}
+	require.Equal(t, map[ProgressEventType]int{
+		ProgressStarted: 1, ProgressItemStarted: 3, ProgressItemFinished: 2, ProgressItemFailed: 1, ProgressFinished: 1,
+	}, counts)
+}
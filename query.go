@@ -0,0 +1,168 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// FilterOp is how a filter of a Query compares a field with its values.
+type FilterOp int
+
+const (
+	// Eq matches the field equal to the single value.
+	Eq FilterOp = iota
+	// In matches the field equal to any of the values.
+	In
+	// Between matches the field within the two values, from and to. An
+	// empty or nil bound leaves the range open on that side.
+	Between
+)
+
+func (op FilterOp) String() string {
+	switch op {
+	case Eq:
+		return "Eq"
+	case In:
+		return "In"
+	case Between:
+		return "Between"
+	}
+	return fmt.Sprintf("FilterOp(%d)", int(op))
+}
+
+// SortOrder is the direction of the ordering of a Query.
+type SortOrder string
+
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// Query builds the CommonCondition of list requests.
+//
+// Filter values are converted to strings: time.Time and Time values use the
+// "2006-01-02 15:04:05" layout of the service, slices are expanded into
+// their elements and other values are formatted with fmt.Sprint. Errors
+// found while building are reported by Build.
+//
+// Example:
+//
+//	cond, err := sdk.NewQuery().
+//		Filter("volume_id", sdk.Eq, volumeID).
+//		Fuzzy("description", "invoice").
+//		OrderBy("created_at", sdk.Desc).
+//		Page(1, 50).
+//		Build()
+//	if err != nil {
+//		return err
+//	}
+//	resp, err := client.ListFiles(ctx, &sdk.FileListRequest{CommonCondition: cond})
+type Query struct {
+	cond CommonCondition
+	errs fieldErrors
+}
+
+// NewQuery returns an empty query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Filter adds a filter on the field name. Eq takes one value, In at least
+// one and Between two.
+func (q *Query) Filter(name string, op FilterOp, values ...any) *Query {
+	field := fmt.Sprintf("filters[%d]", len(q.cond.Filters))
+	values = flattenFilterValues(values)
+	switch op {
+	case Eq:
+		q.errs.check(len(values) == 1, field, fmt.Sprintf("%s: Eq on %q needs 1 value, got %d", field, name, len(values)))
+	case In:
+		q.errs.check(len(values) > 0, field, fmt.Sprintf("%s: In on %q needs at least 1 value", field, name))
+	case Between:
+		q.errs.check(len(values) == 2, field, fmt.Sprintf("%s: Between on %q needs 2 values, got %d", field, name, len(values)))
+	default:
+		q.errs.check(false, field, fmt.Sprintf("%s: unknown operator %v", field, op))
+	}
+	return q.add(field, CommonFilter{Name: name, FilterValues: values})
+}
+
+// Fuzzy adds a filter matching the field name containing keyword.
+func (q *Query) Fuzzy(name, keyword string) *Query {
+	field := fmt.Sprintf("filters[%d]", len(q.cond.Filters))
+	return q.add(field, CommonFilter{Name: name, Fuzzy: true, FilterValues: []any{keyword}})
+}
+
+func (q *Query) add(field string, filter CommonFilter) *Query {
+	q.errs.check(!blank(filter.Name), field, field+": filter name is required")
+	filter.Values = make([]string, len(filter.FilterValues))
+	for i, v := range filter.FilterValues {
+		filter.Values[i] = formatFilterValue(v)
+	}
+	q.cond.Filters = append(q.cond.Filters, filter)
+	return q
+}
+
+// OrderBy sorts the results by field. A later call replaces the ordering.
+func (q *Query) OrderBy(field string, order SortOrder) *Query {
+	q.errs.check(order == Asc || order == Desc, "order", fmt.Sprintf("order must be %q or %q, got %q", Asc, Desc, order))
+	q.cond.OrderBy = field
+	q.cond.Order = string(order)
+	return q
+}
+
+// Page selects the page of results, counting from 1, and the number of
+// results per page.
+func (q *Query) Page(page, pageSize int) *Query {
+	q.errs.check(page >= 1, "page", fmt.Sprintf("page must be at least 1, got %d", page))
+	q.errs.check(pageSize >= 1, "page_size", fmt.Sprintf("page_size must be at least 1, got %d", pageSize))
+	q.cond.Page = page
+	q.cond.PageSize = pageSize
+	return q
+}
+
+// Build returns the condition. It fails with a *ValidationError listing
+// every invalid call.
+func (q *Query) Build() (CommonCondition, error) {
+	if err := q.errs.err(); err != nil {
+		return CommonCondition{}, err
+	}
+	cond := q.cond
+	cond.Filters = append([]CommonFilter(nil), q.cond.Filters...)
+	return cond, nil
+}
+
+// flattenFilterValues expands the slices among values, except []byte.
+func flattenFilterValues(values []any) []any {
+	var out []any
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < rv.Len(); i++ {
+				out = append(out, rv.Index(i).Interface())
+			}
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func formatFilterValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(auditLogTimeLayout)
+	case Time:
+		return formatFilterValue(v.Time)
+	case []byte:
+		return string(v)
+	}
+	return strings.TrimSpace(fmt.Sprint(v))
+}
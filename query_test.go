@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuild(t *testing.T) {
+	t.Parallel()
+	since := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	cond, err := NewQuery().
+		Filter("volume_id", Eq, VolumeID("v1")).
+		Filter("role_id", In, []RoleID{3, 5}).
+		Filter("created_at", Between, since, nil).
+		Fuzzy("description", "invoice").
+		OrderBy("created_at", Desc).
+		Page(2, 50).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, 2, cond.Page)
+	require.Equal(t, 50, cond.PageSize)
+	require.Equal(t, "desc", cond.Order)
+	require.Equal(t, "created_at", cond.OrderBy)
+	require.Len(t, cond.Filters, 4)
+	require.Equal(t, []string{"v1"}, cond.Filters[0].Values)
+	require.Equal(t, []string{"3", "5"}, cond.Filters[1].Values)
+	require.Equal(t, []string{"2024-05-01 08:30:00", ""}, cond.Filters[2].Values)
+	require.Equal(t, CommonFilter{
+		Name:         "description",
+		Values:       []string{"invoice"},
+		Fuzzy:        true,
+		FilterValues: []any{"invoice"},
+	}, cond.Filters[3])
+	require.False(t, cond.Filters[0].Fuzzy)
+}
+
+func TestQueryBuildErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewQuery().
+		Filter("status", Eq, 1, 2).
+		Filter("", In, "a").
+		Filter("created_at", Between, "2024-05-01").
+		OrderBy("created_at", "newest").
+		Page(0, 50).
+		Build()
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	fields := make([]string, len(verr.Fields))
+	for i, f := range verr.Fields {
+		fields[i] = f.Field
+	}
+	require.Equal(t, []string{"filters[0]", "filters[1]", "filters[2]", "order", "page"}, fields)
+}
@@ -0,0 +1,505 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// listPageSize is the page size used to list the existing roles and users.
+const listPageSize = 100
+
+// Action is the kind of change ApplyPolicy makes to a resource.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Resource kinds reported in Change.Kind.
+const (
+	KindRole = "role"
+	KindUser = "user"
+)
+
+// Change is a change ApplyPolicy made, or would make in a dry run.
+type Change struct {
+	Action Action
+	Kind   string
+	Name   string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %q", c.Action, c.Kind, c.Name)
+}
+
+// ApplyOptions configures ApplyPolicy.
+type ApplyOptions struct {
+	// DryRun computes the changes without making them.
+	DryRun bool
+	// CallOptions are applied to every underlying request.
+	CallOptions []sdk.CallOption
+}
+
+// Result lists the changes made by ApplyPolicy, in the order they were
+// made.
+type Result struct {
+	Changes []Change
+}
+
+// ApplyPolicy converges the roles and users of the service to policy.
+//
+// Declared roles that do not exist are created and existing ones whose
+// description or privileges differ are updated. Declared users are created,
+// or get their roles and details updated. With Policy.Prune, roles and
+// users that are not declared are deleted afterwards. Resources are matched
+// by name.
+//
+// All changes are planned before the first one is made, so an invalid
+// policy or a reference to an unknown role changes nothing. If a change
+// fails, the changes made before it are reported along with the error;
+// applying the policy again resumes the work.
+//
+// Example:
+//
+//	result, err := rbac.ApplyPolicy(ctx, client, &rbac.Policy{
+//		Roles: []rbac.Role{{
+//			Name:   "analyst",
+//			Tables: []rbac.TableGrant{{TableID: 123, Privileges: []sdk.PrivCode{sdk.PrivCode_TableSelect}}},
+//		}},
+//		Users: []rbac.User{{Name: "alice", Password: initialPassword, Roles: []string{"analyst"}}},
+//	}, nil)
+//	if err != nil {
+//		return err
+//	}
+//	for _, c := range result.Changes {
+//		log.Println(c)
+//	}
+func ApplyPolicy(ctx context.Context, client sdk.RawClientAPI, policy *Policy, opts *ApplyOptions) (*Result, error) {
+	if policy == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &ApplyOptions{}
+	}
+
+	a := &applier{client: client, callOpts: opts.CallOptions, roleIDs: make(map[string]sdk.RoleID)}
+	if err := a.load(ctx); err != nil {
+		return nil, err
+	}
+	steps, err := a.plan(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, s := range steps {
+		if !opts.DryRun {
+			if err := s.apply(ctx); err != nil {
+				return result, fmt.Errorf("%s: %w", s.change, err)
+			}
+		}
+		result.Changes = append(result.Changes, s.change)
+	}
+	return result, nil
+}
+
+type step struct {
+	change Change
+	apply  func(ctx context.Context) error
+}
+
+type applier struct {
+	client   sdk.RawClientAPI
+	callOpts []sdk.CallOption
+
+	roles []sdk.RoleInfoResponse
+	users []sdk.UserResponse
+	// roleIDs maps role names to IDs, including the roles created while
+	// applying.
+	roleIDs map[string]sdk.RoleID
+}
+
+// load lists the existing roles and users.
+func (a *applier) load(ctx context.Context) error {
+	for page := 1; ; page++ {
+		resp, err := a.client.ListRoles(ctx, &sdk.RoleListRequest{
+			CommonCondition: sdk.CommonCondition{Page: page, PageSize: listPageSize},
+		}, a.callOpts...)
+		if err != nil {
+			return fmt.Errorf("list roles: %w", err)
+		}
+		a.roles = append(a.roles, resp.List...)
+		if len(resp.List) < listPageSize || len(a.roles) >= resp.Total {
+			break
+		}
+	}
+	for _, r := range a.roles {
+		a.roleIDs[r.RoleName] = r.RoleID
+	}
+
+	for page := 1; ; page++ {
+		resp, err := a.client.ListUsers(ctx, &sdk.UserListRequest{
+			CommonCondition: sdk.CommonCondition{Page: page, PageSize: listPageSize},
+		}, a.callOpts...)
+		if err != nil {
+			return fmt.Errorf("list users: %w", err)
+		}
+		a.users = append(a.users, resp.List...)
+		if len(resp.List) < listPageSize || len(a.users) >= resp.Total {
+			break
+		}
+	}
+	return nil
+}
+
+func (a *applier) plan(policy *Policy) ([]step, error) {
+	var steps []step
+
+	declaredRoles := make(map[string]bool, len(policy.Roles))
+	for _, r := range policy.Roles {
+		name := strings.TrimSpace(r.Name)
+		declaredRoles[name] = true
+		s, err := a.planRole(name, r)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			steps = append(steps, *s)
+		}
+	}
+
+	declaredUsers := make(map[string]bool, len(policy.Users))
+	for _, u := range policy.Users {
+		name := strings.TrimSpace(u.Name)
+		declaredUsers[name] = true
+		for _, role := range u.Roles {
+			role = strings.TrimSpace(role)
+			if declaredRoles[role] {
+				continue
+			}
+			existing := a.role(role)
+			if existing == nil {
+				return nil, fmt.Errorf("user %q: role %q does not exist", name, role)
+			}
+			if policy.Prune && !existing.Reserved {
+				return nil, fmt.Errorf("user %q: role %q is not declared and would be pruned", name, role)
+			}
+		}
+		s, err := a.planUser(name, u)
+		if err != nil {
+			return nil, err
+		}
+		if s != nil {
+			steps = append(steps, *s)
+		}
+	}
+
+	if policy.Prune {
+		// Users go first, so that no user refers to a deleted role.
+		for _, u := range a.users {
+			if declaredUsers[u.Name] || u.Reserved {
+				continue
+			}
+			userID := u.ID
+			steps = append(steps, step{
+				change: Change{Action: ActionDelete, Kind: KindUser, Name: u.Name},
+				apply: func(ctx context.Context) error {
+					_, err := a.client.DeleteUser(ctx, &sdk.UserDeleteUserRequest{UserID: userID}, a.callOpts...)
+					return err
+				},
+			})
+		}
+		for _, r := range a.roles {
+			if declaredRoles[r.RoleName] || r.Reserved {
+				continue
+			}
+			roleID := r.RoleID
+			steps = append(steps, step{
+				change: Change{Action: ActionDelete, Kind: KindRole, Name: r.RoleName},
+				apply: func(ctx context.Context) error {
+					_, err := a.client.DeleteRole(ctx, &sdk.RoleDeleteRequest{RoleID: roleID}, a.callOpts...)
+					return err
+				},
+			})
+		}
+	}
+	return steps, nil
+}
+
+// planRole returns the step converging the role name to r, or nil if it
+// is up to date.
+func (a *applier) planRole(name string, r Role) (*step, error) {
+	privList := privCodes(r.Privileges)
+	objPrivList := objPrivs(r)
+
+	existing := a.role(name)
+	if existing == nil {
+		return &step{
+			change: Change{Action: ActionCreate, Kind: KindRole, Name: name},
+			apply: func(ctx context.Context) error {
+				resp, err := a.client.CreateRole(ctx, &sdk.RoleCreateRequest{
+					RoleName:    name,
+					Comment:     r.Description,
+					PrivList:    privList,
+					ObjPrivList: objPrivList,
+				}, a.callOpts...)
+				if err != nil {
+					return err
+				}
+				a.roleIDs[name] = resp.RoleID
+				return nil
+			},
+		}, nil
+	}
+
+	var current []string
+	for _, p := range existing.AuthorityList {
+		if p != nil {
+			current = append(current, p.PrivCode)
+		}
+	}
+	var currentObj []sdk.ObjPrivResponse
+	for _, p := range existing.ObjAuthorityList {
+		if p != nil {
+			currentObj = append(currentObj, *p)
+		}
+	}
+	if existing.Comment == r.Description &&
+		slices.Equal(normalizeCodes(current), privList) &&
+		sameObjPrivs(currentObj, objPrivList) {
+		return nil, nil
+	}
+	if existing.Reserved {
+		return nil, fmt.Errorf("role %q is reserved and cannot be changed", name)
+	}
+
+	roleID := existing.RoleID
+	return &step{
+		change: Change{Action: ActionUpdate, Kind: KindRole, Name: name},
+		apply: func(ctx context.Context) error {
+			_, err := a.client.UpdateRoleInfo(ctx, &sdk.RoleUpdateInfoRequest{
+				RoleID:      roleID,
+				Comment:     r.Description,
+				PrivList:    privList,
+				ObjPrivList: objPrivList,
+			}, a.callOpts...)
+			return err
+		},
+	}, nil
+}
+
+// planUser returns the step converging the user name to u, or nil if it is
+// up to date.
+func (a *applier) planUser(name string, u User) (*step, error) {
+	roles := normalizeCodes(u.Roles)
+
+	existing := a.user(name)
+	if existing == nil {
+		if u.Password == "" {
+			return nil, fmt.Errorf("user %q: password is required to create the user", name)
+		}
+		return &step{
+			change: Change{Action: ActionCreate, Kind: KindUser, Name: name},
+			apply: func(ctx context.Context) error {
+				roleIDs, err := a.resolveRoles(roles)
+				if err != nil {
+					return err
+				}
+				_, err = a.client.CreateUser(ctx, &sdk.UserCreateRequest{
+					UserName:    name,
+					Password:    u.Password,
+					RoleIDList:  roleIDs,
+					Description: u.Description,
+					Email:       u.Email,
+					Phone:       u.Phone,
+				}, a.callOpts...)
+				return err
+			},
+		}, nil
+	}
+
+	var currentRoles []string
+	for _, r := range existing.RoleList {
+		if r != nil {
+			currentRoles = append(currentRoles, r.Name)
+		}
+	}
+	updateRoles := !slices.Equal(normalizeCodes(currentRoles), roles)
+
+	info := sdk.UserUpdateInfoRequest{
+		UserID:      existing.ID,
+		Phone:       orDefault(u.Phone, existing.Phone),
+		Email:       orDefault(u.Email, existing.Email),
+		Description: orDefault(u.Description, existing.Description),
+	}
+	updateInfo := info.Phone != existing.Phone || info.Email != existing.Email || info.Description != existing.Description
+
+	if !updateRoles && !updateInfo {
+		return nil, nil
+	}
+	return &step{
+		change: Change{Action: ActionUpdate, Kind: KindUser, Name: name},
+		apply: func(ctx context.Context) error {
+			if updateRoles {
+				roleIDs, err := a.resolveRoles(roles)
+				if err != nil {
+					return err
+				}
+				if _, err := a.client.UpdateUserRoles(ctx, &sdk.UserUpdateRoleListRequest{
+					UserID:     info.UserID,
+					RoleIDList: roleIDs,
+				}, a.callOpts...); err != nil {
+					return err
+				}
+			}
+			if updateInfo {
+				if _, err := a.client.UpdateUserInfo(ctx, &info, a.callOpts...); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, nil
+}
+
+func (a *applier) resolveRoles(names []string) ([]sdk.RoleID, error) {
+	ids := make([]sdk.RoleID, 0, len(names))
+	for _, name := range names {
+		id, ok := a.roleIDs[name]
+		if !ok {
+			return nil, fmt.Errorf("role %q does not exist", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (a *applier) role(name string) *sdk.RoleInfoResponse {
+	for i := range a.roles {
+		if a.roles[i].RoleName == name {
+			return &a.roles[i]
+		}
+	}
+	return nil
+}
+
+func (a *applier) user(name string) *sdk.UserResponse {
+	for i := range a.users {
+		if a.users[i].Name == name {
+			return &a.users[i]
+		}
+	}
+	return nil
+}
+
+// objPrivs returns the object privileges of r in a canonical order, with
+// the grants of the same object merged.
+func objPrivs(r Role) []sdk.ObjPrivResponse {
+	byObject := make(map[string]*sdk.ObjPrivResponse)
+	add := func(objType, objID string, codes []sdk.PrivCode, hidden []string, rules []*sdk.TableRowColRule) {
+		key := objType + "/" + objID
+		priv, ok := byObject[key]
+		if !ok {
+			priv = &sdk.ObjPrivResponse{ObjID: objID, ObjType: objType}
+			byObject[key] = priv
+		}
+		for _, code := range codes {
+			priv.AuthorityCodeList = append(priv.AuthorityCodeList, &sdk.AuthorityCodeAndRule{
+				Code:            code.String(),
+				BlackColumnList: hidden,
+				RuleList:        rules,
+			})
+		}
+	}
+	for _, g := range r.Tables {
+		add(sdk.ObjTypeTable.String(), sdk.IntToPrivObjectID(int64(g.TableID)).String(), g.Privileges, g.HiddenColumns, g.Rules)
+	}
+	for _, g := range r.Objects {
+		add(strings.TrimSpace(g.Type), strings.TrimSpace(g.ID), g.Privileges, nil, nil)
+	}
+
+	result := make([]sdk.ObjPrivResponse, 0, len(byObject))
+	for _, priv := range byObject {
+		result = append(result, *priv)
+	}
+	return normalizeObjPrivs(result)
+}
+
+// sameObjPrivs reports whether two object privilege lists grant the same
+// privileges. Object names are ignored.
+func sameObjPrivs(a, b []sdk.ObjPrivResponse) bool {
+	ja, errA := json.Marshal(normalizeObjPrivs(a))
+	jb, errB := json.Marshal(normalizeObjPrivs(b))
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+// normalizeObjPrivs returns a copy of privs sorted by object and code,
+// without object names and with empty lists set to nil.
+func normalizeObjPrivs(privs []sdk.ObjPrivResponse) []sdk.ObjPrivResponse {
+	result := make([]sdk.ObjPrivResponse, 0, len(privs))
+	for _, p := range privs {
+		n := sdk.ObjPrivResponse{ObjID: p.ObjID, ObjType: p.ObjType}
+		for _, c := range p.AuthorityCodeList {
+			if c == nil {
+				continue
+			}
+			code := *c
+			if len(code.BlackColumnList) == 0 {
+				code.BlackColumnList = nil
+			}
+			if len(code.RuleList) == 0 {
+				code.RuleList = nil
+			}
+			n.AuthorityCodeList = append(n.AuthorityCodeList, &code)
+		}
+		sort.SliceStable(n.AuthorityCodeList, func(i, j int) bool {
+			return n.AuthorityCodeList[i].Code < n.AuthorityCodeList[j].Code
+		})
+		result = append(result, n)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ObjType != result[j].ObjType {
+			return result[i].ObjType < result[j].ObjType
+		}
+		return result[i].ObjID < result[j].ObjID
+	})
+	return result
+}
+
+func privCodes(codes []sdk.PrivCode) []string {
+	s := make([]string, len(codes))
+	for i, c := range codes {
+		s[i] = c.String()
+	}
+	return normalizeCodes(s)
+}
+
+// normalizeCodes returns the trimmed, sorted and deduplicated values of s.
+func normalizeCodes(s []string) []string {
+	result := make([]string, 0, len(s))
+	for _, v := range s {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return slices.Compact(result)
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,130 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+	"github.com/matrixorigin/moi-go-sdk/sdkmock"
+)
+
+func testPolicy() *Policy {
+	return &Policy{
+		Roles: []Role{
+			{
+				Name:       "analyst",
+				Privileges: []sdk.PrivCode{sdk.PrivCode_QueryUser},
+				Tables: []TableGrant{
+					{TableID: 123, Privileges: []sdk.PrivCode{sdk.PrivCode_TableSelect}, HiddenColumns: []string{"ssn"}},
+				},
+				Objects: []ObjectGrant{
+					{Type: sdk.ObjTypeVolume.String(), ID: "v-1", Privileges: []sdk.PrivCode{sdk.PrivCode_VolumeRead}},
+				},
+			},
+			{Name: "loader", Description: "loads data"},
+		},
+		Users: []User{
+			{Name: "alice", Password: "secret", Email: "alice@example.com", Roles: []string{"analyst", "loader"}},
+		},
+	}
+}
+
+func TestApplyPolicy(t *testing.T) {
+	ctx := context.Background()
+	fake := sdkmock.NewFake()
+
+	result, err := ApplyPolicy(ctx, fake, testPolicy(), nil)
+	require.NoError(t, err)
+	require.Equal(t, []Change{
+		{Action: ActionCreate, Kind: KindRole, Name: "analyst"},
+		{Action: ActionCreate, Kind: KindRole, Name: "loader"},
+		{Action: ActionCreate, Kind: KindUser, Name: "alice"},
+	}, result.Changes)
+
+	users, err := fake.ListUsers(ctx, &sdk.UserListRequest{})
+	require.NoError(t, err)
+	require.Len(t, users.List, 1)
+	require.Len(t, users.List[0].RoleList, 2)
+
+	// Applying the same policy again changes nothing.
+	result, err = ApplyPolicy(ctx, fake, testPolicy(), nil)
+	require.NoError(t, err)
+	require.Empty(t, result.Changes)
+}
+
+func TestApplyPolicy_UpdatesAndPrunes(t *testing.T) {
+	ctx := context.Background()
+	fake := sdkmock.NewFake()
+	_, err := ApplyPolicy(ctx, fake, testPolicy(), nil)
+	require.NoError(t, err)
+	_, err = fake.CreateUser(ctx, &sdk.UserCreateRequest{UserName: "bob"})
+	require.NoError(t, err)
+
+	policy := testPolicy()
+	policy.Prune = true
+	policy.Roles = policy.Roles[:1]
+	policy.Roles[0].Tables[0].Privileges = append(policy.Roles[0].Tables[0].Privileges, sdk.PrivCode_TableInsert)
+	policy.Users[0].Roles = []string{"analyst"}
+
+	dryRun, err := ApplyPolicy(ctx, fake, policy, &ApplyOptions{DryRun: true})
+	require.NoError(t, err)
+	want := []Change{
+		{Action: ActionUpdate, Kind: KindRole, Name: "analyst"},
+		{Action: ActionUpdate, Kind: KindUser, Name: "alice"},
+		{Action: ActionDelete, Kind: KindUser, Name: "bob"},
+		{Action: ActionDelete, Kind: KindRole, Name: "loader"},
+	}
+	require.Equal(t, want, dryRun.Changes)
+	roles, err := fake.ListRoles(ctx, &sdk.RoleListRequest{})
+	require.NoError(t, err)
+	require.Len(t, roles.List, 2, "a dry run changes nothing")
+
+	result, err := ApplyPolicy(ctx, fake, policy, nil)
+	require.NoError(t, err)
+	require.Equal(t, want, result.Changes)
+
+	roles, err = fake.ListRoles(ctx, &sdk.RoleListRequest{})
+	require.NoError(t, err)
+	require.Len(t, roles.List, 1)
+	require.Len(t, roles.List[0].ObjAuthorityList, 2)
+	users, err := fake.ListUsers(ctx, &sdk.UserListRequest{})
+	require.NoError(t, err)
+	require.Len(t, users.List, 1)
+	require.Len(t, users.List[0].RoleList, 1)
+	require.Equal(t, "alice@example.com", users.List[0].Email)
+
+	result, err = ApplyPolicy(ctx, fake, policy, nil)
+	require.NoError(t, err)
+	require.Empty(t, result.Changes)
+}
+
+func TestApplyPolicy_PlanErrorsChangeNothing(t *testing.T) {
+	ctx := context.Background()
+	fake := sdkmock.NewFake()
+
+	policy := testPolicy()
+	policy.Users[0].Roles = []string{"analyst", "missing"}
+	_, err := ApplyPolicy(ctx, fake, policy, nil)
+	require.ErrorContains(t, err, `role "missing" does not exist`)
+
+	policy = testPolicy()
+	policy.Users[0].Password = ""
+	_, err = ApplyPolicy(ctx, fake, policy, nil)
+	require.ErrorContains(t, err, "password is required")
+
+	roles, err := fake.ListRoles(ctx, &sdk.RoleListRequest{})
+	require.NoError(t, err)
+	require.Empty(t, roles.List)
+}
+
+func TestPolicyValidate(t *testing.T) {
+	require.ErrorContains(t, (&Policy{Roles: []Role{{Name: "a"}, {Name: "a"}}}).Validate(), "declared twice")
+	require.ErrorContains(t, (&Policy{Roles: []Role{{Name: "a", Tables: []TableGrant{{Privileges: []sdk.PrivCode{"DT8"}}}}}}).Validate(), "table_id is required")
+	require.ErrorContains(t, (&Policy{Users: []User{{}}}).Validate(), "name is required")
+	require.NoError(t, testPolicy().Validate())
+
+	_, err := ApplyPolicy(context.Background(), sdkmock.NewFake(), nil, nil)
+	require.ErrorIs(t, err, sdk.ErrNilRequest)
+}
@@ -0,0 +1,130 @@
+// Package rbac applies declarative role-based access control policies to
+// the MOI catalog service.
+//
+// A Policy describes the roles, their privileges and the users bound to
+// them. ApplyPolicy compares it with the roles and users that exist and
+// creates, updates and, when the policy asks for it, deletes them until the
+// service matches the policy. The types carry json and yaml tags, so a
+// policy can be kept in a configuration file:
+//
+//	roles:
+//	  - name: analyst
+//	    privileges: [U2]
+//	    tables:
+//	      - table_id: 123
+//	        privileges: [DT8]
+//	users:
+//	  - name: alice
+//	    password: change-me
+//	    roles: [analyst]
+package rbac
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// Policy is the desired state of roles and users.
+type Policy struct {
+	Roles []Role `json:"roles" yaml:"roles"`
+	Users []User `json:"users" yaml:"users"`
+	// Prune deletes the roles and users that the policy does not declare.
+	// Reserved roles and users are never deleted.
+	Prune bool `json:"prune" yaml:"prune"`
+}
+
+// Role is a role and the privileges it grants.
+type Role struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	// Privileges are the global privilege codes of the role, such as
+	// sdk.PrivCode_QueryUser.
+	Privileges []sdk.PrivCode `json:"privileges" yaml:"privileges"`
+	// Tables are the privileges of the role on individual tables.
+	Tables []TableGrant `json:"tables" yaml:"tables"`
+	// Objects are the privileges of the role on other objects, such as
+	// volumes and datasets.
+	Objects []ObjectGrant `json:"objects" yaml:"objects"`
+}
+
+// TableGrant grants privileges on a table, optionally restricted to some
+// columns and rows. The restrictions apply to every privilege of the grant.
+type TableGrant struct {
+	TableID    sdk.TableID    `json:"table_id" yaml:"table_id"`
+	Privileges []sdk.PrivCode `json:"privileges" yaml:"privileges"`
+	// HiddenColumns are the columns the privileges do not cover.
+	HiddenColumns []string `json:"hidden_columns" yaml:"hidden_columns"`
+	// Rules restrict the rows the privileges cover.
+	Rules []*sdk.TableRowColRule `json:"rules" yaml:"rules"`
+}
+
+// ObjectGrant grants privileges on an object other than a table.
+type ObjectGrant struct {
+	// Type is the object category, as returned by sdk.ObjType.String,
+	// for example "volume".
+	Type       string         `json:"type" yaml:"type"`
+	ID         string         `json:"id" yaml:"id"`
+	Privileges []sdk.PrivCode `json:"privileges" yaml:"privileges"`
+}
+
+// User is a user and the roles bound to it.
+//
+// Description, Email and Phone are only changed on existing users when
+// they are set. Password is only used to create the user.
+type User struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Email       string `json:"email" yaml:"email"`
+	Phone       string `json:"phone" yaml:"phone"`
+	Password    string `json:"password" yaml:"password"`
+	// Roles are the names of the roles of the user. They must be declared
+	// in the policy or exist already.
+	Roles []string `json:"roles" yaml:"roles"`
+}
+
+// Validate checks that the policy is well formed: names are present and
+// unique, and every grant names its object and privileges.
+func (p *Policy) Validate() error {
+	roles := make(map[string]bool, len(p.Roles))
+	for i, r := range p.Roles {
+		name := strings.TrimSpace(r.Name)
+		if name == "" {
+			return fmt.Errorf("roles[%d]: name is required", i)
+		}
+		if roles[name] {
+			return fmt.Errorf("role %q is declared twice", name)
+		}
+		roles[name] = true
+		for j, g := range r.Tables {
+			if g.TableID == 0 {
+				return fmt.Errorf("role %q: tables[%d]: table_id is required", name, j)
+			}
+			if len(g.Privileges) == 0 {
+				return fmt.Errorf("role %q: tables[%d]: privileges are required", name, j)
+			}
+		}
+		for j, g := range r.Objects {
+			if strings.TrimSpace(g.Type) == "" || strings.TrimSpace(g.ID) == "" {
+				return fmt.Errorf("role %q: objects[%d]: type and id are required", name, j)
+			}
+			if len(g.Privileges) == 0 {
+				return fmt.Errorf("role %q: objects[%d]: privileges are required", name, j)
+			}
+		}
+	}
+
+	users := make(map[string]bool, len(p.Users))
+	for i, u := range p.Users {
+		name := strings.TrimSpace(u.Name)
+		if name == "" {
+			return fmt.Errorf("users[%d]: name is required", i)
+		}
+		if users[name] {
+			return fmt.Errorf("user %q is declared twice", name)
+		}
+		users[name] = true
+	}
+	return nil
+}
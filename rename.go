@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// RenameDatabase renames the database databaseID of the catalog catalogID
+// to newName, keeping its comment.
+//
+// It checks first that the database belongs to the catalog and that no
+// other database of the catalog is named newName; the latter fails with an
+// error matching ErrConflict. Renaming a database to its current name does
+// nothing.
+//
+// Example:
+//
+//	err := sdkClient.RenameDatabase(ctx, catalogID, dbID, "orders_2024")
+//	if errors.Is(err, sdk.ErrConflict) {
+//		// pick another name
+//	}
+func (c *SDKClient) RenameDatabase(ctx context.Context, catalogID CatalogID, databaseID DatabaseID, newName string, opts ...CallOption) error {
+	if newName == "" {
+		return fmt.Errorf("new database name is required")
+	}
+	dbs, err := c.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: catalogID}, opts...)
+	if err != nil {
+		return err
+	}
+	var current *DatabaseResponse
+	for i := range dbs.List {
+		db := &dbs.List[i]
+		if db.DatabaseID == databaseID {
+			current = db
+		} else if db.DatabaseName == newName {
+			return &classifiedError{msg: fmt.Sprintf("sdk: database %q already exists in catalog %d", newName, catalogID), class: ErrConflict}
+		}
+	}
+	if current == nil {
+		return &classifiedError{msg: fmt.Sprintf("sdk: database %d not found in catalog %d", databaseID, catalogID), class: ErrNotFound}
+	}
+	if current.DatabaseName == newName {
+		return nil
+	}
+	_, err = c.raw.UpdateDatabase(ctx, &DatabaseUpdateRequest{
+		DatabaseID:   databaseID,
+		DatabaseName: newName,
+		Comment:      current.Comment,
+	}, opts...)
+	return err
+}
+
+// RenameTable renames the table tableID of the database databaseID to
+// newName.
+//
+// It checks first that the table belongs to the database and that no
+// other table of the database is named newName; the latter fails with an
+// error matching ErrConflict. Renaming a table to its current name does
+// nothing.
+//
+// Example:
+//
+//	if err := sdkClient.RenameTable(ctx, dbID, tableID, "customers_v2"); err != nil {
+//		return err
+//	}
+func (c *SDKClient) RenameTable(ctx context.Context, databaseID DatabaseID, tableID TableID, newName string, opts ...CallOption) error {
+	if newName == "" {
+		return fmt.Errorf("new table name is required")
+	}
+	children, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID, Types: []string{NodeTypeTable}}, opts...)
+	if err != nil {
+		return err
+	}
+	id := strconv.FormatInt(int64(tableID), 10)
+	var current *DatabaseChildrenResponse
+	for i := range children.List {
+		child := &children.List[i]
+		if child.Typ != NodeTypeTable {
+			continue
+		}
+		if child.ID == id {
+			current = child
+		} else if child.Name == newName {
+			return &classifiedError{msg: fmt.Sprintf("sdk: table %q already exists in database %d", newName, databaseID), class: ErrConflict}
+		}
+	}
+	if current == nil {
+		return &classifiedError{msg: fmt.Sprintf("sdk: table %d not found in database %d", tableID, databaseID), class: ErrNotFound}
+	}
+	if current.Name == newName {
+		return nil
+	}
+	_, err = c.raw.RenameTable(ctx, &TableRenameRequest{TableID: tableID, Name: newName}, opts...)
+	return err
+}
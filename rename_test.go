@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameDatabase(t *testing.T) {
+	t.Parallel()
+	var updates []DatabaseUpdateRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseListResponse{List: []DatabaseResponse{
+				{DatabaseID: 1, DatabaseName: "orders", Comment: "order data"},
+				{DatabaseID: 2, DatabaseName: "archive"},
+			}})
+		},
+		"/catalog/database/update": func(w http.ResponseWriter, r *http.Request) {
+			var req DatabaseUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			updates = append(updates, req)
+			writeEnvelope(w, DatabaseUpdateResponse{DatabaseID: req.DatabaseID})
+		},
+	}))
+	ctx := context.Background()
+
+	require.NoError(t, client.RenameDatabase(ctx, 7, 1, "orders_2024"))
+	require.ErrorIs(t, client.RenameDatabase(ctx, 7, 1, "archive"), ErrConflict)
+	require.ErrorIs(t, client.RenameDatabase(ctx, 7, 3, "other"), ErrNotFound)
+	require.NoError(t, client.RenameDatabase(ctx, 7, 1, "orders"))
+	require.Equal(t, []DatabaseUpdateRequest{
+		{DatabaseID: 1, DatabaseName: "orders_2024", Comment: "order data"},
+	}, updates)
+}
+
+func TestRenameTable(t *testing.T) {
+	t.Parallel()
+	var renames []TableRenameRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+				{ID: "10", Name: "customers", Typ: NodeTypeTable},
+				{ID: "11", Name: "orders", Typ: NodeTypeTable},
+				{ID: "vol-1", Name: "docs", Typ: NodeTypeVolume},
+			}})
+		},
+		"/catalog/table/rename": func(w http.ResponseWriter, r *http.Request) {
+			var req TableRenameRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			renames = append(renames, req)
+			writeEnvelope(w, TableRenameResponse{TableID: req.TableID})
+		},
+	}))
+	ctx := context.Background()
+
+	require.NoError(t, client.RenameTable(ctx, 2, 10, "docs"))
+	require.ErrorIs(t, client.RenameTable(ctx, 2, 10, "orders"), ErrConflict)
+	require.ErrorIs(t, client.RenameTable(ctx, 2, 12, "x"), ErrNotFound)
+	require.Equal(t, []TableRenameRequest{{TableID: 10, Name: "docs"}}, renames)
+
+	_, err := client.Raw().RenameTable(ctx, &TableRenameRequest{TableID: 10})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
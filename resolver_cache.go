@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// resolverCacheSweepSize is the number of cached paths above which expired
+// entries are removed when a new path is stored.
+const resolverCacheSweepSize = 1024
+
+// resolverCache remembers the IDs that "catalog/database/object" paths
+// resolved to, for the time-to-live given to WithResolverCache. Only
+// successful resolutions are cached.
+type resolverCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]resolverEntry
+}
+
+type resolverEntry struct {
+	resolved ResolvedPath
+	expires  time.Time
+}
+
+func newResolverCache(ttl time.Duration) *resolverCache {
+	return &resolverCache{ttl: ttl, now: time.Now, entries: make(map[string]resolverEntry)}
+}
+
+// resolverKey identifies a resolution by its path segments and the kinds
+// of object accepted for the last one.
+func resolverKey(segments, kinds []string) string {
+	return strings.Join(segments, "/") + "|" + strings.Join(kinds, ",")
+}
+
+func (r *resolverCache) get(key string) (*ResolvedPath, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !r.now().Before(e.expires) {
+		delete(r.entries, key)
+		return nil, false
+	}
+	resolved := e.resolved
+	return &resolved, true
+}
+
+func (r *resolverCache) put(key string, resolved *ResolvedPath) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if len(r.entries) >= resolverCacheSweepSize {
+		for k, e := range r.entries {
+			if !now.Before(e.expires) {
+				delete(r.entries, k)
+			}
+		}
+	}
+	r.entries[key] = resolverEntry{resolved: *resolved, expires: now.Add(r.ttl)}
+}
+
+// forget removes the paths that resolved to, or through, an object whose
+// ID is set in ids. The zero IDs of ids are ignored.
+func (r *resolverCache) forget(ids ResolvedPath) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, e := range r.entries {
+		p := e.resolved
+		if (ids.CatalogID != 0 && p.CatalogID == ids.CatalogID) ||
+			(ids.DatabaseID != 0 && p.DatabaseID == ids.DatabaseID) ||
+			(ids.TableID != 0 && p.TableID == ids.TableID) ||
+			(ids.VolumeID != "" && p.VolumeID == ids.VolumeID) {
+			delete(r.entries, k)
+		}
+	}
+}
+
+func (r *resolverCache) clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = make(map[string]resolverEntry)
+}
+
+// forgetResolved drops the cached paths of an object that was renamed or
+// deleted through the client. It does nothing without a resolver cache.
+func (c *RawClient) forgetResolved(ids ResolvedPath) {
+	if c.resolver != nil {
+		c.resolver.forget(ids)
+	}
+}
+
+// ClearResolverCache forgets every path cached by WithResolverCache, for
+// example after catalogs were renamed or deleted by another client. It
+// does nothing if the client has no resolver cache.
+func (c *RawClient) ClearResolverCache() {
+	if c.resolver != nil {
+		c.resolver.clear()
+	}
+}
@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolverCache(t *testing.T) {
+	t.Parallel()
+	var treeCalls atomic.Int32
+	server := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/tree": func(w http.ResponseWriter, r *http.Request) {
+			treeCalls.Add(1)
+			writeEnvelope(w, fullCatalogTree())
+		},
+		"/catalog/table/rename": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TableRenameResponse{TableID: 3})
+		},
+	})
+	raw, err := NewRawClient(server.baseURL, "test-key", WithResolverCache(time.Minute))
+	require.NoError(t, err)
+	now := time.Now()
+	raw.resolver.now = func() time.Time { return now }
+	client := NewSDKClient(raw)
+	ctx := context.Background()
+
+	for range 3 {
+		id, err := client.ResolveTablePath(ctx, "sales/orders/items")
+		require.NoError(t, err)
+		require.Equal(t, TableID(3), id)
+	}
+	require.EqualValues(t, 1, treeCalls.Load())
+
+	// Failures are not cached, and the kinds are part of the key.
+	for range 2 {
+		_, err = client.ResolveTablePath(ctx, "sales/orders/missing")
+		require.ErrorIs(t, err, ErrPathNotFound)
+	}
+	_, err = client.ResolvePath(ctx, "sales/orders/items")
+	require.NoError(t, err)
+	require.EqualValues(t, 4, treeCalls.Load())
+
+	_, err = raw.RenameTable(ctx, &TableRenameRequest{TableID: 3, Name: "lines"})
+	require.NoError(t, err)
+	_, err = client.ResolveTablePath(ctx, "sales/orders/items")
+	require.NoError(t, err)
+	require.EqualValues(t, 5, treeCalls.Load())
+
+	now = now.Add(time.Minute)
+	_, err = client.ResolveTablePath(ctx, "sales/orders/items")
+	require.NoError(t, err)
+	require.EqualValues(t, 6, treeCalls.Load())
+
+	raw.ClearResolverCache()
+	_, err = client.ResolveVolumePath(ctx, "sales/orders/docs")
+	require.NoError(t, err)
+	_, err = client.ResolveTablePath(ctx, "sales/orders/items")
+	require.NoError(t, err)
+	require.EqualValues(t, 8, treeCalls.Load())
+}
@@ -1,6 +1,11 @@
 package sdk
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
 
 type apiEnvelope struct {
 	Code      string          `json:"code"`
@@ -8,3 +13,37 @@ type apiEnvelope struct {
 	Data      json.RawMessage `json:"data"`
 	RequestID string          `json:"request_id"`
 }
+
+// ResponseDecoder decodes the body of a successful response into respBody,
+// which may be nil. It may also turn the response into an error, as
+// EnvelopeDecoder does for envelopes with an error code. Non-2xx responses
+// are reported as errors before any decoder runs.
+//
+// Use WithResponseDecoder to call endpoints whose responses use another
+// format, for example with RawClient.Do.
+type ResponseDecoder func(resp *http.Response, respBody any) error
+
+// EnvelopeDecoder decodes the standard {code, msg, data} envelope of the
+// catalog APIs: a non-OK code is returned as an *APIError, and the data
+// field is decoded into respBody. It is the default decoder of the catalog
+// APIs.
+func EnvelopeDecoder(resp *http.Response, respBody any) error {
+	return decodeEnvelope(resp, respBody)
+}
+
+// JSONDecoder decodes the whole body into respBody, for endpoints that
+// return plain JSON without an envelope, as LLM Proxy does. It is the
+// default decoder of the LLM Proxy APIs. An empty or null body leaves
+// respBody unchanged.
+func JSONDecoder(resp *http.Response, respBody any) error {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+	if respBody != nil && len(data) > 0 && string(data) != "null" {
+		if err := json.Unmarshal(data, respBody); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return nil
+}
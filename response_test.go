@@ -0,0 +1,55 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithResponseDecoder(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/raw": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerContentType, mimeJSON)
+			_, _ = w.Write([]byte(`{"items":["a"]}`))
+		},
+		"/catalog/wrapped": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerContentType, mimeJSON)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"busy"}`))
+		},
+		"/llm-proxy/api/sessions/1": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, LLMSession{ID: 1, Title: "s"})
+		},
+	})
+	ctx := context.Background()
+
+	var raw struct {
+		Items []string `json:"items"`
+	}
+	require.NoError(t, client.Do(ctx, http.MethodGet, "/catalog/raw", nil, &raw, WithResponseDecoder(JSONDecoder)))
+	require.Equal(t, []string{"a"}, raw.Items)
+
+	custom := func(resp *http.Response, respBody any) error {
+		var body struct {
+			OK    bool   `json:"ok"`
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return err
+		}
+		if !body.OK {
+			return fmt.Errorf("backend: %s", body.Error)
+		}
+		return nil
+	}
+	require.EqualError(t, client.Do(ctx, http.MethodGet, "/catalog/wrapped", nil, nil, WithResponseDecoder(custom)), "backend: busy")
+
+	// An LLM Proxy endpoint answering with an envelope.
+	session, err := client.GetLLMSession(ctx, 1, WithResponseDecoder(EnvelopeDecoder))
+	require.NoError(t, err)
+	require.Equal(t, "s", session.Title)
+}
@@ -2,8 +2,14 @@ package sdk
 
 import (
 	"context"
+	"fmt"
+	"strings"
 )
 
+// nameLookupPageSize is the page size of the exact name lookups of
+// GetRoleByName and GetUserByName. Names are unique, so one page is enough.
+const nameLookupPageSize = 10
+
 // CreateRole creates a new role with specified privileges.
 //
 // Roles are used to manage permissions. You can assign global privileges
@@ -33,6 +39,13 @@ func (c *RawClient) CreateRole(ctx context.Context, req *RoleCreateRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := withNormalizedName(c, ResourceRole, req, func(r *RoleCreateRequest) *string { return &r.RoleName })
+	if err != nil {
+		return nil, err
+	}
 	var resp RoleCreateResponse
 	if err := c.postJSON(ctx, "/role/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -53,6 +66,9 @@ func (c *RawClient) DeleteRole(ctx context.Context, req *RoleDeleteRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp RoleDeleteResponse
 	if err := c.postJSON(ctx, "/role/delete", req, &resp, opts...); err != nil {
 		return nil, err
@@ -77,6 +93,9 @@ func (c *RawClient) GetRole(ctx context.Context, req *RoleInfoRequest, opts ...C
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp RoleInfoResponse
 	if err := c.postJSON(ctx, "/role/info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -107,6 +126,9 @@ func (c *RawClient) ListRoles(ctx context.Context, req *RoleListRequest, opts ..
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp RoleListResponse
 	if err := c.postJSON(ctx, "/role/list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -114,6 +136,40 @@ func (c *RawClient) ListRoles(ctx context.Context, req *RoleListRequest, opts ..
 	return &resp, nil
 }
 
+// GetRoleByName retrieves the role named name with a single request,
+// filtering the role list on the exact name. The comparison is
+// case-sensitive. It returns an error matching ErrNotFound if there is no
+// such role.
+//
+// Example:
+//
+//	role, err := client.GetRoleByName(ctx, "analyst")
+//	if errors.Is(err, sdk.ErrNotFound) {
+//		// create the role
+//	} else if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Role %s has ID %d\n", role.RoleName, role.RoleID)
+func (c *RawClient) GetRoleByName(ctx context.Context, name string, opts ...CallOption) (*RoleInfoResponse, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, invalidArgument("name", "role name is required")
+	}
+	cond, err := NewQuery().Filter("name", Eq, name).Page(1, nameLookupPageSize).Build()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.ListRoles(ctx, &RoleListRequest{CommonCondition: cond}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.List {
+		if resp.List[i].RoleName == name {
+			return &resp.List[i], nil
+		}
+	}
+	return nil, &classifiedError{msg: fmt.Sprintf("sdk: role %q not found", name), class: ErrNotFound}
+}
+
 // ListRolesByCategoryAndObject lists roles filtered by category and object.
 //
 // This is useful for finding roles that have privileges on specific objects.
@@ -128,6 +184,9 @@ func (c *RawClient) ListRolesByCategoryAndObject(ctx context.Context, req *RoleL
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp RoleListByCategoryAndObjectResponse
 	if err := c.postJSON(ctx, "/role/list_by_category_and_obj", req, &resp, opts...); err != nil {
 		return nil, err
@@ -149,6 +208,9 @@ func (c *RawClient) UpdateRoleCodeList(ctx context.Context, req *RoleUpdateCodeL
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp RoleUpdateCodeListResponse
 	if err := c.postJSON(ctx, "/role/update_code_list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -196,6 +258,9 @@ func (c *RawClient) UpdateRoleInfo(ctx context.Context, req *RoleUpdateInfoReque
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp RoleUpdateInfoResponse
 	if err := c.postJSON(ctx, "/role/update_info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -218,6 +283,9 @@ func (c *RawClient) UpdateRolesByObject(ctx context.Context, req *RoleUpdateRole
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp RoleUpdateRolesByObjectResponse
 	if err := c.postJSON(ctx, "/role/update_roles_by_obj", req, &resp, opts...); err != nil {
 		return nil, err
@@ -239,6 +307,9 @@ func (c *RawClient) UpdateRoleStatus(ctx context.Context, req *RoleUpdateStatusR
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp RoleUpdateStatusResponse
 	if err := c.postJSON(ctx, "/role/update_status", req, &resp, opts...); err != nil {
 		return nil, err
@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -89,3 +91,32 @@ func TestRoleNilRequestErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRoleByName(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/role/list": func(w http.ResponseWriter, r *http.Request) {
+			var req RoleListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Len(t, req.Filters, 1)
+			require.Equal(t, "name", req.Filters[0].Name)
+			require.False(t, req.Filters[0].Fuzzy)
+			list := []RoleInfoResponse{{RoleID: 4, RoleName: "Analyst"}}
+			if req.Filters[0].Values[0] == "analyst" {
+				list = append(list, RoleInfoResponse{RoleID: 5, RoleName: "analyst"})
+			}
+			writeEnvelope(w, RoleListResponse{Total: len(list), List: list})
+		},
+	})
+
+	role, err := client.GetRoleByName(ctx, "analyst")
+	require.NoError(t, err)
+	require.Equal(t, RoleID(5), role.RoleID)
+
+	_, err = client.GetRoleByName(ctx, "auditor")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.GetRoleByName(ctx, " ")
+	require.Error(t, err)
+}
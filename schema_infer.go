@@ -0,0 +1,240 @@
+package sdk
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts recognized as dates and datetimes by InferTableConfig.
+var (
+	inferDateLayouts     = []string{"2006-01-02", "2006/01/02", "2006.01.02"}
+	inferDatetimeLayouts = []string{
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04:05.999999",
+		"2006/01/02 15:04:05",
+		"2006-01-02T15:04:05",
+		time.RFC3339,
+		time.RFC3339Nano,
+	}
+)
+
+// InferTableOptions configures InferTableConfig.
+type InferTableOptions struct {
+	// TableName and Description are copied into the result.
+	TableName   string
+	Description string
+	// NoHeader indicates that the preview has no header row. Columns are
+	// then named col_1, col_2, ...
+	NoHeader bool
+	// ColumnTypes overrides the inferred data type of the named columns.
+	ColumnTypes map[string]string
+	// PrimaryKey lists the columns that form the primary key. When empty
+	// and AutoPrimaryKey is set, the column suggested by SuggestPrimaryKey
+	// is used.
+	PrimaryKey     []string
+	AutoPrimaryKey bool
+}
+
+// InferTableConfig builds the column definitions of a new table from a file
+// preview.
+//
+// Each column is typed from its sampled values as the narrowest of int,
+// bigint, double, date, datetime and varchar; empty and NULL values are
+// ignored, and a column without values becomes varchar. Because only a
+// sample of the file is inspected, review the result for columns whose
+// later rows may not fit.
+//
+// Example:
+//
+//	preview, err := client.FilePreview(ctx, &sdk.FilePreviewRequest{
+//		ConnFileId:    connFileID,
+//		IsColumnName:  true,
+//		ColumnNameRow: 1,
+//		RowStart:      2,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	createTable, err := sdk.InferTableConfig(preview, &sdk.InferTableOptions{
+//		TableName:      "orders",
+//		AutoPrimaryKey: true,
+//	})
+func InferTableConfig(preview *FilePreviewResponse, opts *InferTableOptions) (*CreateTableConfig, error) {
+	if preview == nil || len(preview.Rows) == 0 {
+		return nil, fmt.Errorf("preview has no columns")
+	}
+	if opts == nil {
+		opts = &InferTableOptions{}
+	}
+
+	columns := make([]TableColumn, len(preview.Rows))
+	seen := make(map[string]bool, len(preview.Rows))
+	for i, row := range preview.Rows {
+		if row == nil {
+			return nil, fmt.Errorf("preview column %d is nil", i+1)
+		}
+		name := strings.TrimSpace(row.ColumnName)
+		if opts.NoHeader || name == "" {
+			name = fmt.Sprintf("col_%d", i+1)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column name %q", name)
+		}
+		seen[name] = true
+		dataType := opts.ColumnTypes[name]
+		if dataType == "" {
+			dataType = inferColumnType(row.ColumnValues)
+		}
+		columns[i] = TableColumn{
+			Number:         i + 1,
+			ColumnName:     name,
+			ColumnValues:   row.ColumnValues,
+			CharNumber:     row.CharNumber,
+			CharColumnName: row.CharColumnName,
+			DataType:       dataType,
+			ColNumInFile:   i + 1,
+		}
+	}
+	config := &CreateTableConfig{
+		Name:        opts.TableName,
+		Description: opts.Description,
+		TableColumn: columns,
+	}
+
+	keys := opts.PrimaryKey
+	if len(keys) == 0 && opts.AutoPrimaryKey {
+		if key := SuggestPrimaryKey(config); key != "" {
+			keys = []string{key}
+		}
+	}
+	for _, key := range keys {
+		if !seen[key] {
+			return nil, fmt.Errorf("primary key column %q is not in the file", key)
+		}
+		for i := range columns {
+			if columns[i].ColumnName == key {
+				columns[i].IsKey = true
+			}
+		}
+	}
+	return config, nil
+}
+
+// SuggestPrimaryKey returns the name of a column that looks like a primary
+// key, or "" if there is none.
+//
+// A candidate must have a value in every sampled row, no duplicates and an
+// integer or varchar type. Among the candidates, a column named "id" is
+// preferred, then one ending in "_id", "Id" or "ID", then the first integer
+// column.
+func SuggestPrimaryKey(config *CreateTableConfig) string {
+	if config == nil {
+		return ""
+	}
+	best, bestRank := "", 0
+	for _, col := range config.TableColumn {
+		if !isKeyCandidate(col) {
+			continue
+		}
+		name := strings.ToLower(col.ColumnName)
+		rank := 1
+		switch {
+		case name == "id":
+			rank = 4
+		case strings.HasSuffix(name, "_id") || strings.HasSuffix(col.ColumnName, "Id") || strings.HasSuffix(col.ColumnName, "ID"):
+			rank = 3
+		case col.DataType == "int" || col.DataType == "bigint":
+			rank = 2
+		}
+		if rank > bestRank {
+			best, bestRank = col.ColumnName, rank
+		}
+	}
+	if bestRank < 2 {
+		// A unique varchar sample alone is too weak a signal.
+		return ""
+	}
+	return best
+}
+
+func isKeyCandidate(col TableColumn) bool {
+	switch col.DataType {
+	case "int", "bigint", "varchar":
+	default:
+		return false
+	}
+	if len(col.ColumnValues) == 0 {
+		return false
+	}
+	values := make(map[string]bool, len(col.ColumnValues))
+	for _, v := range col.ColumnValues {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.EqualFold(v, "null") || values[v] {
+			return false
+		}
+		values[v] = true
+	}
+	return true
+}
+
+// inferColumnType picks the narrowest data type that holds every sampled
+// value. Empty and NULL values are ignored.
+func inferColumnType(values []string) string {
+	isInt, isFloat, isDate, isDatetime := true, true, true, true
+	wide, sampled := false, false
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.EqualFold(v, "null") {
+			continue
+		}
+		sampled = true
+		if isInt {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				isInt = false
+			} else if n < math.MinInt32 || n > math.MaxInt32 {
+				wide = true
+			}
+		}
+		if isFloat && !isInt {
+			f, err := strconv.ParseFloat(v, 64)
+			isFloat = err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+		}
+		if isDate {
+			isDate = matchesLayout(v, inferDateLayouts)
+		}
+		if isDatetime {
+			isDatetime = matchesLayout(v, inferDatetimeLayouts) || matchesLayout(v, inferDateLayouts)
+		}
+		if !isInt && !isFloat && !isDate && !isDatetime {
+			return "varchar"
+		}
+	}
+	switch {
+	case !sampled:
+		return "varchar"
+	case isInt && wide:
+		return "bigint"
+	case isInt:
+		return "int"
+	case isFloat:
+		return "double"
+	case isDate:
+		return "date"
+	case isDatetime:
+		return "datetime"
+	}
+	return "varchar"
+}
+
+func matchesLayout(v string, layouts []string) bool {
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, v); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferColumnType(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"1", "-2", ""}, "int"},
+		{[]string{"1", "3000000000"}, "bigint"},
+		{[]string{"1", "2.5"}, "double"},
+		{[]string{"2024-01-02", "2024/02/29"}, "date"},
+		{[]string{"2024-01-02", "2024-01-02 10:30:00"}, "datetime"},
+		{[]string{"2024-01-02T10:30:00Z"}, "datetime"},
+		{[]string{"1", "2024-01-02"}, "varchar"},
+		{[]string{"1", "abc"}, "varchar"},
+		{[]string{"NaN"}, "varchar"},
+		{[]string{"", "NULL"}, "varchar"},
+		{nil, "varchar"},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, inferColumnType(tc.values), "%v", tc.values)
+	}
+}
+
+func TestInferTableConfig(t *testing.T) {
+	t.Parallel()
+	preview := &FilePreviewResponse{Rows: []*PreviewRow{
+		{ColumnName: "name", ColumnValues: []string{"a", "b", "c"}},
+		{ColumnName: "order_id", ColumnValues: []string{"10", "11", "12"}},
+		{ColumnName: "created", ColumnValues: []string{"2024-01-01", "2024-01-02", ""}},
+		{ColumnName: "", ColumnValues: []string{"1.5", "2", "3"}},
+	}}
+
+	cfg, err := InferTableConfig(preview, &InferTableOptions{TableName: "orders", AutoPrimaryKey: true})
+	require.NoError(t, err)
+	require.Equal(t, "orders", cfg.Name)
+	require.Len(t, cfg.TableColumn, 4)
+	require.Equal(t, "varchar", cfg.TableColumn[0].DataType)
+	require.Equal(t, "int", cfg.TableColumn[1].DataType)
+	require.True(t, cfg.TableColumn[1].IsKey)
+	require.False(t, cfg.TableColumn[0].IsKey)
+	require.Equal(t, "date", cfg.TableColumn[2].DataType)
+	require.Equal(t, "col_4", cfg.TableColumn[3].ColumnName)
+	require.Equal(t, "double", cfg.TableColumn[3].DataType)
+	require.Equal(t, 4, cfg.TableColumn[3].ColNumInFile)
+
+	cfg, err = InferTableConfig(preview, &InferTableOptions{
+		PrimaryKey:  []string{"name"},
+		ColumnTypes: map[string]string{"order_id": "bigint"},
+	})
+	require.NoError(t, err)
+	require.True(t, cfg.TableColumn[0].IsKey)
+	require.False(t, cfg.TableColumn[1].IsKey)
+	require.Equal(t, "bigint", cfg.TableColumn[1].DataType)
+
+	_, err = InferTableConfig(preview, &InferTableOptions{PrimaryKey: []string{"missing"}})
+	require.Error(t, err)
+	_, err = InferTableConfig(&FilePreviewResponse{}, nil)
+	require.Error(t, err)
+}
+
+func TestSuggestPrimaryKey(t *testing.T) {
+	t.Parallel()
+	cols := func(c ...TableColumn) *CreateTableConfig { return &CreateTableConfig{TableColumn: c} }
+
+	require.Equal(t, "id", SuggestPrimaryKey(cols(
+		TableColumn{ColumnName: "userId", DataType: "int", ColumnValues: []string{"1", "2"}},
+		TableColumn{ColumnName: "id", DataType: "varchar", ColumnValues: []string{"a", "b"}},
+	)))
+	require.Equal(t, "seq", SuggestPrimaryKey(cols(
+		TableColumn{ColumnName: "name", DataType: "varchar", ColumnValues: []string{"a", "b"}},
+		TableColumn{ColumnName: "seq", DataType: "int", ColumnValues: []string{"1", "2"}},
+	)))
+	// Duplicates and missing values rule a column out.
+	require.Equal(t, "", SuggestPrimaryKey(cols(
+		TableColumn{ColumnName: "id", DataType: "int", ColumnValues: []string{"1", "1"}},
+		TableColumn{ColumnName: "paid", DataType: "int", ColumnValues: []string{"1", ""}},
+	)))
+	require.Equal(t, "", SuggestPrimaryKey(cols(
+		TableColumn{ColumnName: "name", DataType: "varchar", ColumnValues: []string{"a", "b"}},
+	)))
+}
@@ -26,9 +26,18 @@ func NewSDKClient(raw *RawClient) *SDKClient {
 	}
 }
 
+// Raw returns the RawClient the SDKClient sends its requests with, for the
+// operations that have no high-level counterpart.
+func (c *SDKClient) Raw() *RawClient {
+	return c.raw
+}
+
 // WithSpecialUser creates a new SDKClient with the same configuration but a different API key.
 // The cloned client uses a cloned RawClient with the new API key.
 // Panics if the client is nil or if the API key is empty.
+//
+// Methods that accept CallOptions can instead act for another user with
+// WithAPIKeyOverride.
 func (c *SDKClient) WithSpecialUser(apiKey string) *SDKClient {
 	if c == nil {
 		panic("cannot clone nil client")
@@ -91,79 +100,51 @@ func (c *SDKClient) CreateTableRole(ctx context.Context, roleName string, commen
 		return 0, false, fmt.Errorf("role name is required")
 	}
 
-	// Step 1: Query for existing role by name using filters (as per frontend example)
-	// Use server-side filter with fuzzy search, then verify exact match client-side
-	var existingRole *RoleInfoResponse
-	page := 1
-	pageSize := 100
-	maxPages := 1000 // Safety limit to avoid infinite loops
-
-	for page <= maxPages {
-		// Use filters to search by role name (matching frontend example format)
-		roleListReq := &RoleListRequest{
-			Keyword: "",
-			CommonCondition: CommonCondition{
-				Page:     page,
-				PageSize: pageSize,
-				Order:    "desc",
-				OrderBy:  "created_at",
-				Filters: []CommonFilter{
-					{
-						Name:   "name_description",
-						Values: []string{roleName},
-						Fuzzy:  true,
-					},
-				},
-			},
-		}
-
-		roleListResp, err := c.raw.ListRoles(ctx, roleListReq)
-		if err != nil {
-			return 0, false, err
-		}
-
-		if roleListResp == nil || len(roleListResp.List) == 0 {
-			// No more roles to check
-			break
-		}
+	return c.findOrCreateRole(ctx, roleName, comment, tablePrivsToObjPrivs(tablePrivs))
+}
 
-		// Check if role with exact name exists in current page
-		for i := range roleListResp.List {
-			if roleListResp.List[i].RoleName == roleName {
-				existingRole = &roleListResp.List[i]
-				break
-			}
-		}
+// findOrCreateRole returns the ID of the role named roleName, creating it
+// with objPrivList and no global privileges if it does not exist.
+func (c *SDKClient) findOrCreateRole(ctx context.Context, roleName string, comment string, objPrivList []ObjPrivResponse) (roleID RoleID, created bool, err error) {
+	roleName, err = c.raw.NormalizeName(ResourceRole, roleName)
+	if err != nil {
+		return 0, false, err
+	}
 
-		if existingRole != nil {
-			// Found the role
-			break
-		}
+	// Step 1: Look up the role by its exact name
+	existingRole, err := c.raw.GetRoleByName(ctx, roleName)
+	if err == nil {
+		return existingRole.RoleID, false, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return 0, false, err
+	}
 
-		// Check if there are more pages
-		// Stop if current page has fewer results than pageSize (indicates last page)
-		if len(roleListResp.List) < pageSize {
-			// No more pages (last page returned fewer items than pageSize)
-			break
-		}
+	// Step 2: Create new role
+	createReq := &RoleCreateRequest{
+		RoleName:    roleName,
+		Comment:     comment,
+		PrivList:    []string{}, // No global privileges, only object-level privileges
+		ObjPrivList: objPrivList,
+	}
 
-		// Also check Total to avoid infinite loops
-		// If we've processed all items according to Total, stop
-		if roleListResp.Total > 0 && page*pageSize >= roleListResp.Total {
-			// Reached the total number of roles
-			break
+	createResp, err := c.raw.CreateRole(ctx, createReq)
+	if err != nil {
+		// The role may have been created concurrently since the lookup
+		if errors.Is(err, ErrConflict) {
+			if existingRole, getErr := c.raw.GetRoleByName(ctx, roleName); getErr == nil {
+				return existingRole.RoleID, false, nil
+			}
+			return 0, false, fmt.Errorf("role '%s' already exists but could not be retrieved", roleName)
 		}
-
-		// Continue to next page
-		page++
+		return 0, false, fmt.Errorf("failed to create role: %w", err)
 	}
 
-	// Step 2: If role exists, return its ID
-	if existingRole != nil {
-		return existingRole.RoleID, false, nil
-	}
+	return createResp.RoleID, true, nil
+}
 
-	// Step 3: Convert table privilege info to ObjPrivResponse
+// tablePrivsToObjPrivs converts table privilege info to object privileges.
+func tablePrivsToObjPrivs(tablePrivs []TablePrivInfo) []ObjPrivResponse {
 	objPrivList := make([]ObjPrivResponse, 0, len(tablePrivs))
 	for _, tablePriv := range tablePrivs {
 		var authorityCodeList []*AuthorityCodeAndRule
@@ -193,94 +174,7 @@ func (c *SDKClient) CreateTableRole(ctx context.Context, roleName string, commen
 			AuthorityCodeList: authorityCodeList,
 		})
 	}
-
-	// Step 4: Create new role
-	createReq := &RoleCreateRequest{
-		RoleName:    roleName,
-		Comment:     comment,
-		PrivList:    []string{}, // No global privileges, only object-level privileges
-		ObjPrivList: objPrivList,
-	}
-
-	createResp, err := c.raw.CreateRole(ctx, createReq)
-	if err != nil {
-		// If creation fails due to role already existing, try to find it again
-		// This handles the case where ListRoles failed but the role exists
-		var apiErr *APIError
-		if errors.As(err, &apiErr) && apiErr != nil {
-			// Check if error indicates role already exists
-			errMsg := strings.ToLower(apiErr.Message)
-			if strings.Contains(errMsg, "already exists") || strings.Contains(errMsg, "duplicate") {
-				// Try to list roles one more time to find the existing role with pagination
-				// Use the same pagination logic as initial search
-				retryPage := 1
-				retryPageSize := 100
-				retryMaxPages := 1000 // Safety limit
-				for retryPage <= retryMaxPages {
-					retryListReq := &RoleListRequest{
-						Keyword: "",
-						CommonCondition: CommonCondition{
-							Page:     retryPage,
-							PageSize: retryPageSize,
-							Order:    "desc",
-							OrderBy:  "created_at",
-							Filters: []CommonFilter{
-								{
-									Name:   "name_description",
-									Values: []string{roleName},
-									Fuzzy:  true,
-								},
-							},
-						},
-					}
-					retryListResp, retryErr := c.raw.ListRoles(ctx, retryListReq)
-					if retryErr != nil {
-						// If listing fails for this page, try next page (might be a transient error)
-						// But if it's the first page, break
-						if retryPage == 1 {
-							break
-						}
-						// For subsequent pages, if error occurs, assume we've reached the end
-						break
-					}
-
-					if retryListResp == nil || len(retryListResp.List) == 0 {
-						// No more results
-						break
-					}
-
-					// Search for the role by name in current page
-					for i := range retryListResp.List {
-						if retryListResp.List[i].RoleName == roleName {
-							return retryListResp.List[i].RoleID, false, nil
-						}
-					}
-
-					// Check if there are more pages
-					// Stop if current page has fewer results than pageSize
-					if len(retryListResp.List) < retryPageSize {
-						// No more pages
-						break
-					}
-
-					// Also check Total to avoid infinite loops
-					if retryListResp.Total > 0 && retryPage*retryPageSize >= retryListResp.Total {
-						// Reached the total number of roles
-						break
-					}
-
-					// Continue to next page
-					retryPage++
-				}
-				// If ListRoles still fails, we can't find the role, but we know it exists
-				// Return a more user-friendly error message
-				return 0, false, fmt.Errorf("role '%s' already exists but could not be retrieved", roleName)
-			}
-		}
-		return 0, false, fmt.Errorf("failed to create role: %w", err)
-	}
-
-	return createResp.RoleID, true, nil
+	return objPrivList
 }
 
 // UpdateTableRole updates an existing role with table privileges.
@@ -322,6 +216,12 @@ func (c *SDKClient) UpdateTableRole(ctx context.Context, roleID RoleID, comment
 		return fmt.Errorf("role_id is required")
 	}
 
+	return c.updateObjRole(ctx, roleID, comment, tablePrivsToObjPrivs(tablePrivs), globalPrivs)
+}
+
+// updateObjRole replaces the object privileges of a role. An empty comment
+// and nil globalPrivs keep the current values, as in UpdateTableRole.
+func (c *SDKClient) updateObjRole(ctx context.Context, roleID RoleID, comment string, objPrivList []ObjPrivResponse, globalPrivs []string) error {
 	// Step 1: Get current role info if needed (to preserve comment or global privileges)
 	var currentComment string
 	var privList []string
@@ -355,38 +255,7 @@ func (c *SDKClient) UpdateTableRole(ctx context.Context, roleID RoleID, comment
 		privList = globalPrivs
 	}
 
-	// Step 3: Convert table privilege info to ObjPrivResponse
-	objPrivList := make([]ObjPrivResponse, 0, len(tablePrivs))
-	for _, tablePriv := range tablePrivs {
-		var authorityCodeList []*AuthorityCodeAndRule
-
-		// Use AuthorityCodeList if provided, otherwise fall back to PrivCodes for backward compatibility
-		if len(tablePriv.AuthorityCodeList) > 0 {
-			// Use the provided AuthorityCodeList with rules
-			authorityCodeList = tablePriv.AuthorityCodeList
-		} else if len(tablePriv.PrivCodes) > 0 {
-			// Convert PrivCode slice to AuthorityCodeAndRule slice (backward compatibility)
-			authorityCodeList = make([]*AuthorityCodeAndRule, 0, len(tablePriv.PrivCodes))
-			for _, privCode := range tablePriv.PrivCodes {
-				authorityCodeList = append(authorityCodeList, &AuthorityCodeAndRule{
-					Code:     string(privCode),
-					RuleList: nil, // No rules by default
-				})
-			}
-		} else {
-			// Skip if neither is provided
-			continue
-		}
-
-		objPrivList = append(objPrivList, ObjPrivResponse{
-			ObjID:             fmt.Sprintf("%d", tablePriv.TableID),
-			ObjType:           ObjTypeTable.String(), // "table"
-			ObjName:           "",                    // Table name is optional, can be left empty
-			AuthorityCodeList: authorityCodeList,
-		})
-	}
-
-	// Step 4: Update role
+	// Step 2: Update role
 	updateReq := &RoleUpdateInfoRequest{
 		RoleID:      roleID,
 		PrivList:    privList,
@@ -422,6 +291,10 @@ func (c *SDKClient) UpdateTableRole(ctx context.Context, roleID RoleID, comment
 // Note: This method uses magic values for VolumeID ("123456") and constructs Meta from the first conn_file_id.
 // The Files field in UploadFileRequest is set to empty, as the file is already uploaded and referenced by conn_file_id.
 func (c *SDKClient) ImportLocalFileToTable(ctx context.Context, tableConfig *TableConfig) (*UploadFileResponse, error) {
+	return c.importLocalFileToTable(ctx, tableConfig)
+}
+
+func (c *SDKClient) importLocalFileToTable(ctx context.Context, tableConfig *TableConfig, opts ...CallOption) (*UploadFileResponse, error) {
 	if tableConfig == nil {
 		return nil, fmt.Errorf("table_config is required")
 	}
@@ -471,7 +344,7 @@ func (c *SDKClient) ImportLocalFileToTable(ctx context.Context, tableConfig *Tab
 	}
 
 	// Call the raw client's UploadConnectorFile method
-	return c.raw.UploadConnectorFile(ctx, uploadReq)
+	return c.raw.UploadConnectorFile(ctx, uploadReq, opts...)
 }
 
 // ImportLocalFileToVolume uploads a local unstructured file to a target volume.
@@ -490,6 +363,12 @@ func (c *SDKClient) ImportLocalFileToTable(ctx context.Context, tableConfig *Tab
 //   - *UploadFileResponse: the response from the upload operation
 //   - error: any error that occurred
 //
+// Large files can be uploaded in resumable parts by passing
+// sdk.WithChunkedUpload in opts, and sdk.WithUploadProgress reports the
+// bytes sent as the upload goes. When meta.Hash is empty, the MD5 of the
+// file is computed and sent with it, so DedupByMD5 works without hashing
+// by hand.
+//
 // Example:
 //
 //	resp, err := sdkClient.ImportLocalFileToVolume(ctx, "/path/to/file.docx", "123456", sdk.FileMeta{
@@ -521,6 +400,12 @@ func (c *SDKClient) ImportLocalFileToVolume(ctx context.Context, filePath string
 	}
 	defer file.Close()
 
+	if meta.Hash == "" {
+		if meta.Hash, err = localMD5(filePath); err != nil {
+			return nil, fmt.Errorf("hash file %s: %w", filePath, err)
+		}
+	}
+
 	// Extract filename from path
 	fileName := filepath.Base(filePath)
 
@@ -560,6 +445,13 @@ func (c *SDKClient) ImportLocalFileToVolume(ctx context.Context, filePath string
 //   - *UploadFileResponse: the response from the upload operation
 //   - error: any error that occurred
 //
+// The files are sent in a single multipart request, streamed from disk so
+// that memory use does not grow with the number or size of the files. Pass
+// sdk.WithUploadLimits in opts to split them into several requests, sent
+// concurrently, or to cap the upload bandwidth; the response then merges
+// theirs, and each request has its own load task, listed in
+// UploadFileResponse.Batches.
+//
 // Example:
 //
 //	resp, err := sdkClient.ImportLocalFilesToVolume(ctx, []string{
@@ -589,82 +481,44 @@ func (c *SDKClient) ImportLocalFilesToVolume(ctx context.Context, filePaths []st
 		return nil, fmt.Errorf("metas array length (%d) must match filePaths length (%d)", len(metas), len(filePaths))
 	}
 
-	// Open all files and build file upload items
-	files := make([]FileUploadItem, 0, len(filePaths))
-	fileMetas := make([]FileMeta, 0, len(filePaths))
-	fileHandles := make([]*os.File, 0, len(filePaths))
-
-	// Cleanup function to close all opened files
-	cleanup := func() {
-		for _, f := range fileHandles {
-			if f != nil {
-				f.Close()
-			}
-		}
-	}
-	defer cleanup()
-
+	uploads := make([]localUpload, 0, len(filePaths))
 	for i, filePath := range filePaths {
 		if strings.TrimSpace(filePath) == "" {
 			return nil, fmt.Errorf("file_path[%d] is empty", i)
 		}
-
-		// Open the local file
-		file, err := os.Open(filePath)
+		info, err := os.Stat(filePath)
 		if err != nil {
-			// Close already opened files before returning error
-			cleanup()
 			return nil, fmt.Errorf("open file %s: %w", filePath, err)
 		}
-		fileHandles = append(fileHandles, file)
 
-		// Extract filename from path
+		// Use the provided meta, whose filename may differ from the local
+		// file name, or generate one from the file path
 		fileName := filepath.Base(filePath)
-
-		// If meta provides a filename, use it for the upload item
-		// This allows callers to specify a different display name than the local file name
-		uploadFileName := fileName
+		meta := FileMeta{Filename: fileName, Path: fileName}
 		if i < len(metas) && strings.TrimSpace(metas[i].Filename) != "" {
-			uploadFileName = metas[i].Filename
+			meta = metas[i]
 		}
-
-		// Build file upload item
-		files = append(files, FileUploadItem{
-			File:     file,
-			FileName: uploadFileName,
-		})
-
-		// Build meta - use provided meta or auto-generate from file path
-		if i < len(metas) && strings.TrimSpace(metas[i].Filename) != "" {
-			// Use provided meta
-			fileMetas = append(fileMetas, metas[i])
-		} else {
-			// Auto-generate meta from file path
-			fileMetas = append(fileMetas, FileMeta{
-				Filename: fileName,
-				Path:     fileName,
-			})
+		if meta.Hash == "" {
+			if meta.Hash, err = localMD5(filePath); err != nil {
+				return nil, fmt.Errorf("hash file %s: %w", filePath, err)
+			}
 		}
+		uploads = append(uploads, localUpload{path: filePath, meta: meta, size: info.Size()})
 	}
 
-	// Build UploadFileRequest
-	uploadReq := &UploadFileRequest{
-		VolumeID:    volumeID,
-		Files:       files,
-		Meta:        fileMetas,
-		DedupConfig: dedup,
+	limits := newCallOptions(opts...).uploadLimits
+	batches := batchLocalUploads(uploads, limits)
+	if len(batches) == 1 {
+		return c.uploadLocalBatch(ctx, volumeID, batches[0], dedup, opts)
 	}
-
-	// Call the raw client's UploadConnectorFile method
-	// Note: We need to keep files open until the request completes, so we don't defer close here
-	// The files will be closed by the defer function above after the method returns
-	return c.raw.UploadConnectorFile(ctx, uploadReq, opts...)
+	return c.uploadLocalBatches(ctx, volumeID, batches, dedup, limits.MaxParallel, opts)
 }
 
 // RunSQL executes a SQL statement using the NL2SQL RunSQL operation.
 //
 // The statement must reference tables using fully qualified names (database.table).
 // This requirement allows the catalog service to route the query to the correct database.
+// The statement is sent unchanged; use RunSQLArgs to bind "?" placeholders.
 func (c *SDKClient) RunSQL(ctx context.Context, statement string, opts ...CallOption) (*NL2SQLRunSQLResponse, error) {
 	if strings.TrimSpace(statement) == "" {
 		return nil, fmt.Errorf("statement is required")
@@ -675,6 +529,26 @@ func (c *SDKClient) RunSQL(ctx context.Context, statement string, opts ...CallOp
 	}, opts...)
 }
 
+// RunSQLArgs executes a SQL statement like RunSQL after binding args to its
+// "?" placeholders with BindSQL, so that values never need to be
+// concatenated into the statement by hand. A statement without arguments is
+// sent unchanged.
+//
+// Example:
+//
+//	resp, err := sdkClient.RunSQLArgs(ctx, "SELECT * FROM shop.orders WHERE customer = ? AND created_at >= ?",
+//		[]any{customerName, time.Now().AddDate(0, -1, 0)}, sdk.WithRequestID("orders-report"))
+func (c *SDKClient) RunSQLArgs(ctx context.Context, statement string, args []any, opts ...CallOption) (*NL2SQLRunSQLResponse, error) {
+	if len(args) > 0 {
+		bound, err := BindSQL(statement, args...)
+		if err != nil {
+			return nil, err
+		}
+		statement = bound
+	}
+	return c.RunSQL(ctx, statement, opts...)
+}
+
 // CreateDocumentProcessingWorkflow creates a workflow for processing documents from a source volume to a target volume.
 //
 // This is a high-level convenience method that creates a complete document processing pipeline
@@ -960,6 +834,145 @@ func (c *SDKClient) WaitForWorkflowJob(ctx context.Context, workflowID string, s
 	}
 }
 
+// RerunFailedWorkflowFiles re-runs every file of a workflow job that failed.
+//
+// The job detail is fetched with GetGenAIJob, the files whose status is a
+// failure are collected, and they are submitted together with
+// RerunWorkflowJobFiles.
+//
+// Parameters:
+//   - jobID: the workflow job ID (required)
+//
+// Returns:
+//   - *WorkflowRunResponse: the rerun that was started; when no file failed,
+//     no request is made and the response has no FileIDs
+//   - error: any error that occurred
+//
+// Example:
+//
+//	run, err := sdkClient.RerunFailedWorkflowFiles(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Re-running %d files in job %s\n", len(run.FileIDs), run.JobID)
+func (c *SDKClient) RerunFailedWorkflowFiles(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowRunResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("job_id is required")
+	}
+
+	detail, err := c.raw.GetGenAIJob(ctx, jobID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow job: %w", err)
+	}
+
+	var fileIDs []string
+	for _, f := range detail.FailedFiles() {
+		if f.FileID != "" {
+			fileIDs = append(fileIDs, f.FileID)
+		}
+	}
+	if len(fileIDs) == 0 {
+		return &WorkflowRunResponse{JobID: jobID}, nil
+	}
+
+	resp, err := c.raw.RerunWorkflowJobFiles(ctx, jobID, &WorkflowJobRerunRequest{FileIDs: fileIDs}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rerun failed files: %w", err)
+	}
+	if resp.JobID == "" {
+		resp.JobID = jobID
+	}
+	if len(resp.FileIDs) == 0 {
+		resp.FileIDs = fileIDs
+	}
+	return resp, nil
+}
+
+// WaitForTask polls a task until it reaches a terminal state or the context is done.
+//
+// The task is queried immediately and then every pollInterval. Transient
+// request errors do not stop the polling; the last one is reported if the
+// context expires first. Errors that polling again cannot fix, such as
+// ErrNotFound or ErrPermissionDenied, are returned at once. Unlike WaitForWorkflowJob no default deadline is
+// applied, because load tasks can legitimately run for a long time: bound the
+// wait with the context.
+//
+// Parameters:
+//   - ctx: context controlling how long to wait
+//   - taskID: the task ID (required)
+//   - pollInterval: the interval between polling attempts (default: 2 seconds if <= 0)
+//
+// Returns:
+//   - *TaskInfoResponse: the last task information received
+//   - TaskStatus: the terminal status (TaskStatusSucceeded, TaskStatusFailed or TaskStatusCancelled)
+//   - error: any error that occurred, including context cancellation
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+//	defer cancel()
+//	task, status, err := sdkClient.WaitForTask(ctx, sdk.TaskID(resp.TaskId), 2*time.Second)
+//	if err != nil {
+//		return err
+//	}
+//	if status != sdk.TaskStatusSucceeded {
+//		return fmt.Errorf("task %s ended as %s", task.ID, status)
+//	}
+func (c *SDKClient) WaitForTask(ctx context.Context, taskID TaskID, pollInterval time.Duration) (*TaskInfoResponse, TaskStatus, error) {
+	return pollTask(ctx, taskID, pollInterval, func(ctx context.Context) (*TaskInfoResponse, error) {
+		return c.raw.GetTask(ctx, &TaskInfoRequest{TaskID: taskID})
+	})
+}
+
+// pollTask calls get until the task reaches a terminal state, as described
+// for WaitForTask.
+func pollTask(ctx context.Context, taskID TaskID, pollInterval time.Duration, get func(context.Context) (*TaskInfoResponse, error)) (*TaskInfoResponse, TaskStatus, error) {
+	if taskID == 0 {
+		return nil, TaskStatusUnknown, invalidArgument("task_id", "task_id is required")
+	}
+	if pollInterval <= 0 {
+		pollInterval = 2 * time.Second
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var (
+		last    *TaskInfoResponse
+		lastErr error
+	)
+	for {
+		task, err := get(ctx)
+		if err == nil {
+			last, lastErr = task, nil
+			if task.Status.IsTerminal() {
+				return task, task.Status, nil
+			}
+		} else if isPermanentError(err) {
+			status := TaskStatusUnknown
+			if last != nil {
+				status = last.Status
+			}
+			return last, status, fmt.Errorf("get task %d: %w", taskID, err)
+		} else {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			status := TaskStatusUnknown
+			if last != nil {
+				status = last.Status
+			}
+			if lastErr != nil {
+				return last, status, fmt.Errorf("task %d did not reach a terminal state (last error: %v): %w", taskID, lastErr, ctx.Err())
+			}
+			return last, status, fmt.Errorf("task %d did not reach a terminal state, last status %s: %w", taskID, status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 // FindFilesByName searches for files by name within a specific volume.
 //
 // This is a high-level convenience method that uses ListFiles with filters
@@ -2,9 +2,12 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -467,8 +470,11 @@ func TestCreateDocumentProcessingWorkflow_Success(t *testing.T) {
 	t.Logf("Created workflow with ID: %s", workflowID)
 
 	// Verify the workflow was created by checking its details
-	// Note: We can't easily verify the workflow details without a GetWorkflow API,
-	// but we can at least verify the ID is not empty and the creation succeeded
+	workflow, err := rawClient.GetWorkflow(ctx, workflowID)
+	require.NoError(t, err)
+	require.Equal(t, workflowID, workflow.ID)
+	require.Equal(t, workflowName, workflow.Name)
+	require.Equal(t, string(targetVolumeResp.VolumeID), workflow.TargetVolumeID)
 }
 
 func TestCreateDocumentProcessingWorkflow_EmptyWorkflowName(t *testing.T) {
@@ -936,3 +942,104 @@ func TestImportLocalFileToTable_ExistedTableOption(t *testing.T) {
 		t.Logf("Successfully imported with nil ExistedTable (initialized), response: %+v", resp3)
 	}
 }
+
+func TestWaitForTask(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "42", r.URL.Query().Get("task_id"))
+			status := TaskStatusRunning
+			if atomic.AddInt32(&calls, 1) >= 3 {
+				status = TaskStatusSucceeded
+			}
+			writeEnvelope(w, TaskInfoResponse{ID: "42", Status: status})
+		},
+	})
+
+	task, status, err := NewSDKClient(raw).WaitForTask(context.Background(), 42, time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, TaskStatusSucceeded, status)
+	require.Equal(t, "42", task.ID)
+	require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestWaitForTask_ContextExpires(t *testing.T) {
+	t.Parallel()
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TaskInfoResponse{ID: "7", Status: TaskStatusRunning})
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	task, status, err := NewSDKClient(raw).WaitForTask(ctx, 7, 5*time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, TaskStatusRunning, status)
+	require.NotNil(t, task)
+}
+
+func TestWaitForTask_StopsOnPermissionDenied(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			writeEnvelopeError(w, "ErrPermissionDenied", "no privilege on task 7")
+		},
+	})
+
+	_, status, err := NewSDKClient(raw).WaitForTask(context.Background(), 7, time.Millisecond)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.Equal(t, TaskStatusUnknown, status)
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestWaitForTask_RequiresTaskID(t *testing.T) {
+	t.Parallel()
+	_, _, err := NewSDKClient(&RawClient{}).WaitForTask(context.Background(), 0, 0)
+	require.Error(t, err)
+}
+
+func TestRerunFailedWorkflowFiles_Mock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var rerun WorkflowJobRerunRequest
+	rawClient := newMockClient(t, map[string]http.HandlerFunc{
+		"/v1/genai/jobs/job-1": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, GenAIGetJobDetailResponse{
+				Status: TaskStatusSucceeded.String(),
+				Files: []GenAIWorkflowJobFileResponse{
+					{FileID: "f1", FileStatus: TaskStatusSucceeded},
+					{FileID: "f2", FileStatus: TaskStatusFailed, ErrorMessage: "parse error"},
+					{FileID: "f3", FileStatus: TaskStatusFailed},
+				},
+			})
+		},
+		"/v1/genai/jobs/job-1/rerun": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&rerun))
+			writeEnvelope(w, WorkflowRunResponse{JobID: "job-2"})
+		},
+		"/v1/genai/jobs/job-ok": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, GenAIGetJobDetailResponse{
+				Status: TaskStatusSucceeded.String(),
+				Files:  []GenAIWorkflowJobFileResponse{{FileID: "f1", FileStatus: TaskStatusSucceeded}},
+			})
+		},
+	})
+	client := NewSDKClient(rawClient)
+
+	resp, err := client.RerunFailedWorkflowFiles(ctx, "job-1")
+	require.NoError(t, err)
+	require.Equal(t, "job-2", resp.JobID)
+	require.Equal(t, []string{"f2", "f3"}, resp.FileIDs)
+	require.Equal(t, []string{"f2", "f3"}, rerun.FileIDs)
+
+	resp, err = client.RerunFailedWorkflowFiles(ctx, "job-ok")
+	require.NoError(t, err)
+	require.Equal(t, "job-ok", resp.JobID)
+	require.Empty(t, resp.FileIDs)
+}
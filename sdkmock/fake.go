@@ -0,0 +1,1222 @@
+// Package sdkmock provides an in-memory fake of the MOI catalog service for
+// unit tests that should not depend on a live backend.
+//
+// Fake implements sdk.RawClientAPI. Catalogs, databases, tables, volumes,
+// folders, files, roles and users are modeled in memory; every other method
+// is delegated to the embedded RawClientAPI, which is nil by default and
+// therefore panics when an unmodeled method is called. Tests that need one of
+// those methods can either assign a stub to Fake.RawClientAPI or embed Fake in
+// their own type and override the method.
+//
+// Example:
+//
+//	fake := sdkmock.NewFake()
+//	svc := NewService(fake) // NewService accepts sdk.RawClientAPI
+//	catalog, _ := fake.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "demo"})
+package sdkmock
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// Error codes returned by the fake inside *sdk.APIError.
+const (
+	CodeNotFound      = "ErrNotFound"
+	CodeAlreadyExists = "ErrAlreadyExists"
+	CodeInvalidParam  = "ErrInvalidParam"
+)
+
+type catalogEntry struct {
+	resp sdk.CatalogResponse
+}
+
+type databaseEntry struct {
+	catalogID sdk.CatalogID
+	resp      sdk.DatabaseResponse
+}
+
+type tableEntry struct {
+	databaseID sdk.DatabaseID
+	info       sdk.TableInfoResponse
+}
+
+type volumeEntry struct {
+	databaseID sdk.DatabaseID
+	info       sdk.VolumeInfoResponse
+}
+
+type fileEntry struct {
+	isFolder bool
+	info     sdk.FileInfoResponse
+}
+
+// Fake is an in-memory implementation of sdk.RawClientAPI.
+//
+// A Fake is safe for concurrent use. The zero value is not usable; create
+// one with NewFake.
+type Fake struct {
+	// RawClientAPI receives calls for methods the fake does not model.
+	sdk.RawClientAPI
+
+	mu sync.Mutex
+
+	nextID    int64
+	catalogs  map[sdk.CatalogID]*catalogEntry
+	databases map[sdk.DatabaseID]*databaseEntry
+	tables    map[sdk.TableID]*tableEntry
+	volumes   map[sdk.VolumeID]*volumeEntry
+	files     map[sdk.FileID]*fileEntry
+	roles     map[sdk.RoleID]*sdk.RoleInfoResponse
+	users     map[sdk.UserID]*sdk.UserResponse
+
+	now func() time.Time
+}
+
+var _ sdk.RawClientAPI = (*Fake)(nil)
+
+// NewFake returns an empty Fake.
+func NewFake() *Fake {
+	return &Fake{
+		catalogs:  make(map[sdk.CatalogID]*catalogEntry),
+		databases: make(map[sdk.DatabaseID]*databaseEntry),
+		tables:    make(map[sdk.TableID]*tableEntry),
+		volumes:   make(map[sdk.VolumeID]*volumeEntry),
+		files:     make(map[sdk.FileID]*fileEntry),
+		roles:     make(map[sdk.RoleID]*sdk.RoleInfoResponse),
+		users:     make(map[sdk.UserID]*sdk.UserResponse),
+		now:       time.Now,
+	}
+}
+
+func (f *Fake) newID() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *Fake) timestamp() string {
+	return f.now().Format("2006-01-02 15:04:05")
+}
+
+func notFound(kind string, id interface{}) error {
+	return &sdk.APIError{Code: CodeNotFound, Message: fmt.Sprintf("%s %v not found", kind, id), HTTPStatus: 200}
+}
+
+func alreadyExists(kind, name string) error {
+	return &sdk.APIError{Code: CodeAlreadyExists, Message: fmt.Sprintf("%s %q already exists", kind, name), HTTPStatus: 200}
+}
+
+func invalidParam(msg string) error {
+	return &sdk.APIError{Code: CodeInvalidParam, Message: msg, HTTPStatus: 200}
+}
+
+// paginate returns the page of n items selected by cond, as [start, end).
+func paginate(n int, cond sdk.CommonCondition) (int, int) {
+	if cond.PageSize <= 0 {
+		return 0, n
+	}
+	page := cond.Page
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * cond.PageSize
+	if start > n {
+		start = n
+	}
+	end := start + cond.PageSize
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// matchKeyword reports whether name matches the keyword and any name-like
+// filters of the condition.
+func matchKeyword(name, keyword string, filters []sdk.CommonFilter) bool {
+	if keyword != "" && !strings.Contains(name, keyword) {
+		return false
+	}
+	for _, filter := range filters {
+		if filter.Name != "name" && filter.Name != "name_description" {
+			continue
+		}
+		matched := len(filter.Values) == 0
+		for _, v := range filter.Values {
+			if (filter.Fuzzy && strings.Contains(name, v)) || name == v {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// ============ Catalog ============
+
+// CreateCatalog creates a catalog in memory.
+func (f *Fake) CreateCatalog(ctx context.Context, req *sdk.CatalogCreateRequest, opts ...sdk.CallOption) (*sdk.CatalogCreateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.catalogs {
+		if c.resp.CatalogName == req.CatalogName {
+			return nil, alreadyExists("catalog", req.CatalogName)
+		}
+	}
+	id := sdk.CatalogID(f.newID())
+	ts := f.timestamp()
+	f.catalogs[id] = &catalogEntry{resp: sdk.CatalogResponse{
+		CatalogID:   id,
+		CatalogName: req.CatalogName,
+		Comment:     req.Comment,
+		CreatedAt:   ts,
+		UpdatedAt:   ts,
+	}}
+	return &sdk.CatalogCreateResponse{CatalogID: id}, nil
+}
+
+// DeleteCatalog deletes a catalog and everything it contains.
+func (f *Fake) DeleteCatalog(ctx context.Context, req *sdk.CatalogDeleteRequest, opts ...sdk.CallOption) (*sdk.CatalogDeleteResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.catalogs[req.CatalogID]; !ok {
+		return nil, notFound("catalog", req.CatalogID)
+	}
+	for id, db := range f.databases {
+		if db.catalogID == req.CatalogID {
+			f.deleteDatabaseLocked(id)
+		}
+	}
+	delete(f.catalogs, req.CatalogID)
+	return &sdk.CatalogDeleteResponse{CatalogID: req.CatalogID}, nil
+}
+
+// UpdateCatalog updates the name and description of a catalog.
+func (f *Fake) UpdateCatalog(ctx context.Context, req *sdk.CatalogUpdateRequest, opts ...sdk.CallOption) (*sdk.CatalogUpdateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, ok := f.catalogs[req.CatalogID]
+	if !ok {
+		return nil, notFound("catalog", req.CatalogID)
+	}
+	if req.CatalogName != "" {
+		c.resp.CatalogName = req.CatalogName
+	}
+	c.resp.Comment = req.Comment
+	c.resp.UpdatedAt = f.timestamp()
+	return &sdk.CatalogUpdateResponse{CatalogID: req.CatalogID}, nil
+}
+
+// GetCatalog returns a catalog by ID.
+func (f *Fake) GetCatalog(ctx context.Context, req *sdk.CatalogInfoRequest, opts ...sdk.CallOption) (*sdk.CatalogInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, ok := f.catalogs[req.CatalogID]
+	if !ok {
+		return nil, notFound("catalog", req.CatalogID)
+	}
+	return &sdk.CatalogInfoResponse{
+		CatalogID:   c.resp.CatalogID,
+		CatalogName: c.resp.CatalogName,
+		Comment:     c.resp.Comment,
+	}, nil
+}
+
+// ListCatalogs returns all catalogs ordered by ID.
+func (f *Fake) ListCatalogs(ctx context.Context, opts ...sdk.CallOption) (*sdk.CatalogListResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &sdk.CatalogListResponse{}
+	for _, id := range f.sortedCatalogIDs() {
+		c := f.catalogs[id].resp
+		c.DatabaseCount, c.TableCount, c.VolumeCount = f.countCatalogLocked(id)
+		resp.List = append(resp.List, c)
+	}
+	return resp, nil
+}
+
+// GetCatalogTree returns the catalog/database/table/volume hierarchy.
+func (f *Fake) GetCatalogTree(ctx context.Context, opts ...sdk.CallOption) (*sdk.CatalogTreeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &sdk.CatalogTreeResponse{}
+	for _, cid := range f.sortedCatalogIDs() {
+		c := f.catalogs[cid].resp
+		cnode := &sdk.TreeNode{Typ: "catalog", ID: strconv.FormatInt(int64(cid), 10), Name: c.CatalogName, Description: c.Comment}
+		for _, did := range f.sortedDatabaseIDs(cid) {
+			d := f.databases[did].resp
+			dnode := &sdk.TreeNode{Typ: "database", ID: strconv.FormatInt(int64(did), 10), Name: d.DatabaseName, Description: d.Comment}
+			for _, child := range f.databaseChildrenLocked(did) {
+				dnode.NodeList = append(dnode.NodeList, &sdk.TreeNode{Typ: child.Typ, ID: child.ID, Name: child.Name, Description: child.Comment})
+			}
+			cnode.NodeList = append(cnode.NodeList, dnode)
+		}
+		resp.Tree = append(resp.Tree, cnode)
+	}
+	return resp, nil
+}
+
+func (f *Fake) sortedCatalogIDs() []sdk.CatalogID {
+	ids := make([]sdk.CatalogID, 0, len(f.catalogs))
+	for id := range f.catalogs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (f *Fake) countCatalogLocked(id sdk.CatalogID) (databases, tables, volumes int) {
+	for did, db := range f.databases {
+		if db.catalogID != id {
+			continue
+		}
+		databases++
+		for _, t := range f.tables {
+			if t.databaseID == did {
+				tables++
+			}
+		}
+		for _, v := range f.volumes {
+			if v.databaseID == did {
+				volumes++
+			}
+		}
+	}
+	return databases, tables, volumes
+}
+
+// ============ Database ============
+
+// CreateDatabase creates a database in an existing catalog.
+func (f *Fake) CreateDatabase(ctx context.Context, req *sdk.DatabaseCreateRequest, opts ...sdk.CallOption) (*sdk.DatabaseCreateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.catalogs[req.CatalogID]; !ok {
+		return nil, notFound("catalog", req.CatalogID)
+	}
+	for _, db := range f.databases {
+		if db.resp.DatabaseName == req.DatabaseName {
+			return nil, alreadyExists("database", req.DatabaseName)
+		}
+	}
+	id := sdk.DatabaseID(f.newID())
+	ts := f.timestamp()
+	f.databases[id] = &databaseEntry{catalogID: req.CatalogID, resp: sdk.DatabaseResponse{
+		DatabaseID:   id,
+		DatabaseName: req.DatabaseName,
+		Comment:      req.Comment,
+		CreatedAt:    ts,
+		UpdatedAt:    ts,
+	}}
+	return &sdk.DatabaseCreateResponse{DatabaseID: id}, nil
+}
+
+// DeleteDatabase deletes a database together with its tables and volumes.
+func (f *Fake) DeleteDatabase(ctx context.Context, req *sdk.DatabaseDeleteRequest, opts ...sdk.CallOption) (*sdk.DatabaseDeleteResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.databases[req.DatabaseID]; !ok {
+		return nil, notFound("database", req.DatabaseID)
+	}
+	f.deleteDatabaseLocked(req.DatabaseID)
+	return &sdk.DatabaseDeleteResponse{DatabaseID: req.DatabaseID}, nil
+}
+
+func (f *Fake) deleteDatabaseLocked(id sdk.DatabaseID) {
+	for tid, t := range f.tables {
+		if t.databaseID == id {
+			delete(f.tables, tid)
+		}
+	}
+	for vid, v := range f.volumes {
+		if v.databaseID == id {
+			f.deleteVolumeLocked(vid)
+		}
+	}
+	delete(f.databases, id)
+}
+
+// UpdateDatabase updates the description of a database.
+func (f *Fake) UpdateDatabase(ctx context.Context, req *sdk.DatabaseUpdateRequest, opts ...sdk.CallOption) (*sdk.DatabaseUpdateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	db, ok := f.databases[req.DatabaseID]
+	if !ok {
+		return nil, notFound("database", req.DatabaseID)
+	}
+	if req.DatabaseName != "" && req.DatabaseName != db.resp.DatabaseName {
+		for _, other := range f.databases {
+			if other.resp.DatabaseName == req.DatabaseName {
+				return nil, alreadyExists("database", req.DatabaseName)
+			}
+		}
+		db.resp.DatabaseName = req.DatabaseName
+	}
+	db.resp.Comment = req.Comment
+	db.resp.UpdatedAt = f.timestamp()
+	return &sdk.DatabaseUpdateResponse{DatabaseID: req.DatabaseID}, nil
+}
+
+// GetDatabase returns a database by ID.
+func (f *Fake) GetDatabase(ctx context.Context, req *sdk.DatabaseInfoRequest, opts ...sdk.CallOption) (*sdk.DatabaseInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	db, ok := f.databases[req.DatabaseID]
+	if !ok {
+		return nil, notFound("database", req.DatabaseID)
+	}
+	return &sdk.DatabaseInfoResponse{
+		DatabaseID:   db.resp.DatabaseID,
+		DatabaseName: db.resp.DatabaseName,
+		Comment:      db.resp.Comment,
+		CreatedAt:    db.resp.CreatedAt,
+		UpdatedAt:    db.resp.UpdatedAt,
+	}, nil
+}
+
+// ListDatabases returns the databases of a catalog ordered by ID.
+func (f *Fake) ListDatabases(ctx context.Context, req *sdk.DatabaseListRequest, opts ...sdk.CallOption) (*sdk.DatabaseListResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.catalogs[req.CatalogID]; !ok {
+		return nil, notFound("catalog", req.CatalogID)
+	}
+	resp := &sdk.DatabaseListResponse{}
+	for _, id := range f.sortedDatabaseIDs(req.CatalogID) {
+		db := f.databases[id].resp
+		for _, t := range f.tables {
+			if t.databaseID == id {
+				db.TableCount++
+			}
+		}
+		for _, v := range f.volumes {
+			if v.databaseID == id {
+				db.VolumeCount++
+			}
+		}
+		resp.List = append(resp.List, db)
+	}
+	return resp, nil
+}
+
+// GetDatabaseChildren returns the tables and volumes of a database.
+func (f *Fake) GetDatabaseChildren(ctx context.Context, req *sdk.DatabaseChildrenRequest, opts ...sdk.CallOption) (*sdk.DatabaseChildrenResponseData, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.databases[req.DatabaseID]; !ok {
+		return nil, notFound("database", req.DatabaseID)
+	}
+	var children []sdk.DatabaseChildrenResponse
+	for _, child := range f.databaseChildrenLocked(req.DatabaseID) {
+		if len(req.Types) == 0 || slices.Contains(req.Types, child.Typ) {
+			children = append(children, child)
+		}
+	}
+	if req.PageSize <= 0 {
+		return &sdk.DatabaseChildrenResponseData{List: children}, nil
+	}
+	start, end := paginate(len(children), sdk.CommonCondition{Page: req.Page, PageSize: req.PageSize})
+	return &sdk.DatabaseChildrenResponseData{List: children[start:end], Total: len(children)}, nil
+}
+
+func (f *Fake) sortedDatabaseIDs(catalogID sdk.CatalogID) []sdk.DatabaseID {
+	var ids []sdk.DatabaseID
+	for id, db := range f.databases {
+		if db.catalogID == catalogID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func (f *Fake) databaseChildrenLocked(id sdk.DatabaseID) []sdk.DatabaseChildrenResponse {
+	var children []sdk.DatabaseChildrenResponse
+	for tid, t := range f.tables {
+		if t.databaseID != id {
+			continue
+		}
+		children = append(children, sdk.DatabaseChildrenResponse{
+			ID:        strconv.FormatInt(int64(tid), 10),
+			Name:      t.info.Name,
+			Typ:       "table",
+			Size:      t.info.Size,
+			Comment:   t.info.Comment,
+			CreatedAt: t.info.CreatedAt,
+			UpdatedAt: t.info.CreatedAt,
+		})
+	}
+	for vid, v := range f.volumes {
+		if v.databaseID != id {
+			continue
+		}
+		children = append(children, sdk.DatabaseChildrenResponse{
+			ID:        string(vid),
+			Name:      v.info.VolumeName,
+			Typ:       "volume",
+			Comment:   v.info.Comment,
+			CreatedAt: v.info.CreatedAt,
+			UpdatedAt: v.info.UpdatedAt,
+		})
+	}
+	sort.Slice(children, func(i, j int) bool {
+		if children[i].Typ != children[j].Typ {
+			return children[i].Typ < children[j].Typ
+		}
+		return children[i].Name < children[j].Name
+	})
+	return children
+}
+
+// ============ Table ============
+
+// CreateTable creates a table in an existing database.
+func (f *Fake) CreateTable(ctx context.Context, req *sdk.TableCreateRequest, opts ...sdk.CallOption) (*sdk.TableCreateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	if req.Name == "" {
+		return nil, invalidParam("table name is required")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.databases[req.DatabaseID]; !ok {
+		return nil, notFound("database", req.DatabaseID)
+	}
+	if f.findTableLocked(req.DatabaseID, req.Name) != nil {
+		return nil, alreadyExists("table", req.Name)
+	}
+	id := sdk.TableID(f.newID())
+	columns := append([]sdk.Column(nil), req.Columns...)
+	f.tables[id] = &tableEntry{databaseID: req.DatabaseID, info: sdk.TableInfoResponse{
+		Name:      req.Name,
+		Columns:   columns,
+		Comment:   req.Comment,
+		CreatedAt: f.timestamp(),
+	}}
+	return &sdk.TableCreateResponse{TableID: id}, nil
+}
+
+// GetTable returns a table by ID, or by database ID and name when the
+// table ID is sdk.TableIDInSubDatabase.
+func (f *Fake) GetTable(ctx context.Context, req *sdk.TableInfoRequest, opts ...sdk.CallOption) (*sdk.TableInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var t *tableEntry
+	if req.TableID == sdk.TableIDInSubDatabase {
+		t = f.findTableLocked(req.DatabaseID, req.TableName)
+	} else {
+		t = f.tables[req.TableID]
+	}
+	if t == nil {
+		return nil, notFound("table", req.TableID)
+	}
+	info := t.info
+	info.Columns = append([]sdk.Column(nil), t.info.Columns...)
+	return &info, nil
+}
+
+// CheckTableExists reports whether a table with the given name exists.
+func (f *Fake) CheckTableExists(ctx context.Context, req *sdk.TableExistRequest, opts ...sdk.CallOption) (bool, error) {
+	if req == nil {
+		return false, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.findTableLocked(req.DatabaseID, req.Name) != nil, nil
+}
+
+// TruncateTable resets the row count of a table.
+func (f *Fake) TruncateTable(ctx context.Context, req *sdk.TableTruncateRequest, opts ...sdk.CallOption) (*sdk.TableTruncateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.tables[req.TableID]
+	if !ok {
+		return nil, notFound("table", req.TableID)
+	}
+	t.info.Lines = 0
+	t.info.Size = 0
+	return &sdk.TableTruncateResponse{}, nil
+}
+
+// RenameTable renames a table.
+func (f *Fake) RenameTable(ctx context.Context, req *sdk.TableRenameRequest, opts ...sdk.CallOption) (*sdk.TableRenameResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.tables[req.TableID]
+	if !ok {
+		return nil, notFound("table", req.TableID)
+	}
+	if other := f.findTableLocked(t.databaseID, req.Name); other != nil && other != t {
+		return nil, alreadyExists("table", req.Name)
+	}
+	t.info.Name = req.Name
+	return &sdk.TableRenameResponse{TableID: req.TableID}, nil
+}
+
+// DeleteTable deletes a table.
+func (f *Fake) DeleteTable(ctx context.Context, req *sdk.TableDeleteRequest, opts ...sdk.CallOption) (*sdk.TableDeleteResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.tables[req.TableID]; !ok {
+		return nil, notFound("table", req.TableID)
+	}
+	delete(f.tables, req.TableID)
+	return &sdk.TableDeleteResponse{}, nil
+}
+
+func (f *Fake) findTableLocked(databaseID sdk.DatabaseID, name string) *tableEntry {
+	for _, t := range f.tables {
+		if t.databaseID == databaseID && t.info.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// ============ Volume ============
+
+// CreateVolume creates a volume in an existing database.
+func (f *Fake) CreateVolume(ctx context.Context, req *sdk.VolumeCreateRequest, opts ...sdk.CallOption) (*sdk.VolumeCreateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.databases[req.DatabaseID]; !ok {
+		return nil, notFound("database", req.DatabaseID)
+	}
+	for _, v := range f.volumes {
+		if v.databaseID == req.DatabaseID && v.info.VolumeName == req.Name {
+			return nil, alreadyExists("volume", req.Name)
+		}
+	}
+	id := sdk.VolumeID(strconv.FormatInt(f.newID(), 10))
+	ts := f.timestamp()
+	f.volumes[id] = &volumeEntry{databaseID: req.DatabaseID, info: sdk.VolumeInfoResponse{
+		VolumeID:   id,
+		VolumeName: req.Name,
+		Comment:    req.Comment,
+		CreatedAt:  ts,
+		UpdatedAt:  ts,
+	}}
+	return &sdk.VolumeCreateResponse{VolumeID: id}, nil
+}
+
+// DeleteVolume deletes a volume together with its files and folders.
+func (f *Fake) DeleteVolume(ctx context.Context, req *sdk.VolumeDeleteRequest, opts ...sdk.CallOption) (*sdk.VolumeDeleteResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.volumes[req.VolumeID]; !ok {
+		return nil, notFound("volume", req.VolumeID)
+	}
+	f.deleteVolumeLocked(req.VolumeID)
+	return &sdk.VolumeDeleteResponse{VolumeID: req.VolumeID}, nil
+}
+
+func (f *Fake) deleteVolumeLocked(id sdk.VolumeID) {
+	for fid, file := range f.files {
+		if file.info.VolumeID == string(id) {
+			delete(f.files, fid)
+		}
+	}
+	delete(f.volumes, id)
+}
+
+// UpdateVolume updates the name and description of a volume.
+func (f *Fake) UpdateVolume(ctx context.Context, req *sdk.VolumeUpdateRequest, opts ...sdk.CallOption) (*sdk.VolumeUpdateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.volumes[req.VolumeID]
+	if !ok {
+		return nil, notFound("volume", req.VolumeID)
+	}
+	if req.Name != "" {
+		v.info.VolumeName = req.Name
+	}
+	v.info.Comment = req.Comment
+	v.info.UpdatedAt = f.timestamp()
+	return &sdk.VolumeUpdateResponse{VolumeID: req.VolumeID}, nil
+}
+
+// GetVolume returns a volume by ID.
+func (f *Fake) GetVolume(ctx context.Context, req *sdk.VolumeInfoRequest, opts ...sdk.CallOption) (*sdk.VolumeInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.volumes[req.VolumeID]
+	if !ok {
+		return nil, notFound("volume", req.VolumeID)
+	}
+	info := v.info
+	return &info, nil
+}
+
+// ============ Folder ============
+
+// CreateFolder creates a folder in a volume.
+func (f *Fake) CreateFolder(ctx context.Context, req *sdk.FolderCreateRequest, opts ...sdk.CallOption) (*sdk.FolderCreateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id, err := f.createFileLocked(req.VolumeID, req.ParentID, req.Name, 0, "", true)
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.FolderCreateResponse{FolderID: id, Name: req.Name}, nil
+}
+
+// UpdateFolder renames a folder.
+func (f *Fake) UpdateFolder(ctx context.Context, req *sdk.FolderUpdateRequest, opts ...sdk.CallOption) (*sdk.FolderUpdateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	folder, ok := f.files[req.FolderID]
+	if !ok || !folder.isFolder {
+		return nil, notFound("folder", req.FolderID)
+	}
+	folder.info.Name = req.Name
+	folder.info.UpdatedAt = f.timestamp()
+	return &sdk.FolderUpdateResponse{FolderID: req.FolderID}, nil
+}
+
+// DeleteFolder deletes a folder and everything below it.
+func (f *Fake) DeleteFolder(ctx context.Context, req *sdk.FolderDeleteRequest, opts ...sdk.CallOption) (*sdk.FolderDeleteResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	folder, ok := f.files[req.FolderID]
+	if !ok || !folder.isFolder {
+		return nil, notFound("folder", req.FolderID)
+	}
+	f.deleteChildrenLocked(req.FolderID)
+	delete(f.files, req.FolderID)
+	return &sdk.FolderDeleteResponse{FolderID: req.FolderID}, nil
+}
+
+// CleanFolder deletes everything below a folder but keeps the folder.
+func (f *Fake) CleanFolder(ctx context.Context, req *sdk.FolderCleanRequest, opts ...sdk.CallOption) (*sdk.FolderCleanResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	folder, ok := f.files[req.FolderID]
+	if !ok || !folder.isFolder {
+		return nil, notFound("folder", req.FolderID)
+	}
+	f.deleteChildrenLocked(req.FolderID)
+	return &sdk.FolderCleanResponse{FolderID: req.FolderID}, nil
+}
+
+func (f *Fake) deleteChildrenLocked(parent sdk.FileID) {
+	for id, file := range f.files {
+		if file.info.ParentID != string(parent) {
+			continue
+		}
+		if file.isFolder {
+			f.deleteChildrenLocked(id)
+		}
+		delete(f.files, id)
+	}
+}
+
+// ============ File ============
+
+// CreateFile records file metadata in a volume.
+func (f *Fake) CreateFile(ctx context.Context, req *sdk.FileCreateRequest, opts ...sdk.CallOption) (*sdk.FileCreateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id, err := f.createFileLocked(req.VolumeID, req.ParentID, req.Name, req.Size, req.OriginFileExt, false)
+	if err != nil {
+		return nil, err
+	}
+	file := f.files[id]
+	file.info.ShowType = req.ShowType
+	file.info.RefFileID = req.RefFileID
+	return &sdk.FileCreateResponse{FileID: id, Name: req.Name}, nil
+}
+
+// UpdateFile renames a file.
+func (f *Fake) UpdateFile(ctx context.Context, req *sdk.FileUpdateRequest, opts ...sdk.CallOption) (*sdk.FileUpdateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, ok := f.files[req.FileID]
+	if !ok || file.isFolder {
+		return nil, notFound("file", req.FileID)
+	}
+	file.info.Name = req.Name
+	file.info.UpdatedAt = f.timestamp()
+	return &sdk.FileUpdateResponse{FileID: req.FileID}, nil
+}
+
+// DeleteFile deletes a file.
+func (f *Fake) DeleteFile(ctx context.Context, req *sdk.FileDeleteRequest, opts ...sdk.CallOption) (*sdk.FileDeleteResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, ok := f.files[req.FileID]
+	if !ok || file.isFolder {
+		return nil, notFound("file", req.FileID)
+	}
+	delete(f.files, req.FileID)
+	return &sdk.FileDeleteResponse{FileID: req.FileID}, nil
+}
+
+// GetFile returns a file or folder by ID.
+func (f *Fake) GetFile(ctx context.Context, req *sdk.FileInfoRequest, opts ...sdk.CallOption) (*sdk.FileInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, ok := f.files[req.FileID]
+	if !ok {
+		return nil, notFound("file", req.FileID)
+	}
+	info := file.info
+	return &info, nil
+}
+
+// ListFiles lists files and folders. The "volume_id" and "parent_id" filters
+// restrict the result to one location; "name" and the keyword match names.
+func (f *Fake) ListFiles(ctx context.Context, req *sdk.FileListRequest, opts ...sdk.CallOption) (*sdk.FileListResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var volumeIDs, parentIDs []string
+	for _, filter := range req.Filters {
+		switch filter.Name {
+		case "volume_id":
+			volumeIDs = filter.Values
+		case "parent_id":
+			parentIDs = filter.Values
+		}
+	}
+	var matched []sdk.VolumeChildrenResponse
+	for _, file := range f.files {
+		if len(volumeIDs) > 0 && !contains(volumeIDs, file.info.VolumeID) {
+			continue
+		}
+		if len(parentIDs) > 0 && !contains(parentIDs, file.info.ParentID) {
+			continue
+		}
+		if !matchKeyword(file.info.Name, req.Keyword, req.Filters) {
+			continue
+		}
+		matched = append(matched, f.volumeChildLocked(file))
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if len(matched[i].ID) != len(matched[j].ID) {
+			return len(matched[i].ID) < len(matched[j].ID)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	start, end := paginate(len(matched), req.CommonCondition)
+	return &sdk.FileListResponse{Total: len(matched), List: matched[start:end]}, nil
+}
+
+func (f *Fake) volumeChildLocked(file *fileEntry) sdk.VolumeChildrenResponse {
+	var volumeName string
+	if v, ok := f.volumes[sdk.VolumeID(file.info.VolumeID)]; ok {
+		volumeName = v.info.VolumeName
+	}
+	return sdk.VolumeChildrenResponse{
+		ID:            string(file.info.ID),
+		Name:          file.info.Name,
+		FileType:      file.info.FileType,
+		ShowType:      file.info.ShowType,
+		FileExt:       file.info.FileExt,
+		OriginFileExt: file.info.OriginFileExt,
+		RefFileID:     file.info.RefFileID,
+		Size:          file.info.Size,
+		VolumeID:      file.info.VolumeID,
+		VolumeName:    volumeName,
+		ParentID:      file.info.ParentID,
+	}
+}
+
+func (f *Fake) createFileLocked(volumeID sdk.VolumeID, parentID sdk.FileID, name string, size int64, ext string, isFolder bool) (sdk.FileID, error) {
+	if name == "" {
+		return "", invalidParam("name is required")
+	}
+	if _, ok := f.volumes[volumeID]; !ok {
+		return "", notFound("volume", volumeID)
+	}
+	if parentID != "" {
+		parent, ok := f.files[parentID]
+		if !ok || !parent.isFolder {
+			return "", notFound("folder", parentID)
+		}
+	}
+	for _, file := range f.files {
+		if file.info.VolumeID == string(volumeID) && file.info.ParentID == string(parentID) &&
+			file.info.Name == name && file.isFolder == isFolder {
+			return "", alreadyExists("file", name)
+		}
+	}
+	id := sdk.FileID(strconv.FormatInt(f.newID(), 10))
+	ts := f.timestamp()
+	fileType := "file"
+	if isFolder {
+		fileType = "dir"
+	}
+	f.files[id] = &fileEntry{isFolder: isFolder, info: sdk.FileInfoResponse{
+		ID:            id,
+		Name:          name,
+		FileType:      fileType,
+		FileExt:       ext,
+		OriginFileExt: ext,
+		Size:          size,
+		ParentID:      string(parentID),
+		VolumeID:      string(volumeID),
+		CreatedAt:     ts,
+		UpdatedAt:     ts,
+	}}
+	return id, nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ============ Role ============
+
+// CreateRole creates a role.
+func (f *Fake) CreateRole(ctx context.Context, req *sdk.RoleCreateRequest, opts ...sdk.CallOption) (*sdk.RoleCreateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.roles {
+		if r.RoleName == req.RoleName {
+			return nil, alreadyExists("role", req.RoleName)
+		}
+	}
+	id := sdk.RoleID(f.newID())
+	ts := f.timestamp()
+	role := &sdk.RoleInfoResponse{
+		RoleID:    id,
+		RoleName:  req.RoleName,
+		Status:    sdk.RoleStatusEnable,
+		Comment:   req.Comment,
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	}
+	for _, code := range req.PrivList {
+		role.AuthorityList = append(role.AuthorityList, &sdk.PrivResponse{PrivCode: code})
+	}
+	for i := range req.ObjPrivList {
+		objPriv := req.ObjPrivList[i]
+		role.ObjAuthorityList = append(role.ObjAuthorityList, &objPriv)
+	}
+	f.roles[id] = role
+	return &sdk.RoleCreateResponse{RoleID: id}, nil
+}
+
+// DeleteRole deletes a role.
+func (f *Fake) DeleteRole(ctx context.Context, req *sdk.RoleDeleteRequest, opts ...sdk.CallOption) (*sdk.RoleDeleteResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.roles[req.RoleID]; !ok {
+		return nil, notFound("role", req.RoleID)
+	}
+	delete(f.roles, req.RoleID)
+	return &sdk.RoleDeleteResponse{RoleID: req.RoleID}, nil
+}
+
+// GetRole returns a role by ID.
+func (f *Fake) GetRole(ctx context.Context, req *sdk.RoleInfoRequest, opts ...sdk.CallOption) (*sdk.RoleInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	role, ok := f.roles[req.RoleID]
+	if !ok {
+		return nil, notFound("role", req.RoleID)
+	}
+	resp := *role
+	return &resp, nil
+}
+
+// ListRoles lists roles matching the keyword and name filters.
+func (f *Fake) ListRoles(ctx context.Context, req *sdk.RoleListRequest, opts ...sdk.CallOption) (*sdk.RoleListResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []sdk.RoleInfoResponse
+	for _, role := range f.roles {
+		if matchKeyword(role.RoleName, req.Keyword, req.Filters) {
+			matched = append(matched, *role)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].RoleID < matched[j].RoleID })
+	start, end := paginate(len(matched), req.CommonCondition)
+	return &sdk.RoleListResponse{Total: len(matched), List: matched[start:end]}, nil
+}
+
+// GetRoleByName returns the role named name.
+func (f *Fake) GetRoleByName(ctx context.Context, name string, opts ...sdk.CallOption) (*sdk.RoleInfoResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, role := range f.roles {
+		if role.RoleName == name {
+			resp := *role
+			return &resp, nil
+		}
+	}
+	return nil, notFound("role", name)
+}
+
+// UpdateRoleInfo replaces the description and privileges of a role.
+func (f *Fake) UpdateRoleInfo(ctx context.Context, req *sdk.RoleUpdateInfoRequest, opts ...sdk.CallOption) (*sdk.RoleUpdateInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	role, ok := f.roles[req.RoleID]
+	if !ok {
+		return nil, notFound("role", req.RoleID)
+	}
+	role.Comment = req.Comment
+	role.AuthorityList = nil
+	for _, code := range req.PrivList {
+		role.AuthorityList = append(role.AuthorityList, &sdk.PrivResponse{PrivCode: code})
+	}
+	role.ObjAuthorityList = nil
+	for i := range req.ObjPrivList {
+		objPriv := req.ObjPrivList[i]
+		role.ObjAuthorityList = append(role.ObjAuthorityList, &objPriv)
+	}
+	role.UpdatedAt = f.timestamp()
+	return &sdk.RoleUpdateInfoResponse{RoleID: req.RoleID}, nil
+}
+
+// ============ User ============
+
+// CreateUser creates a user with the given roles.
+func (f *Fake) CreateUser(ctx context.Context, req *sdk.UserCreateRequest, opts ...sdk.CallOption) (*sdk.UserCreateResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.users {
+		if u.Name == req.UserName {
+			return nil, alreadyExists("user", req.UserName)
+		}
+	}
+	user := &sdk.UserResponse{
+		Name:        req.UserName,
+		Status:      sdk.UserStatusEnable,
+		Phone:       req.Phone,
+		Email:       req.Email,
+		Description: req.Description,
+	}
+	for _, roleID := range req.RoleIDList {
+		role, ok := f.roles[roleID]
+		if !ok {
+			return nil, notFound("role", roleID)
+		}
+		user.RoleList = append(user.RoleList, &sdk.RoleIDName{ID: roleID, Name: role.RoleName, Status: role.Status})
+	}
+	user.ID = sdk.UserID(f.newID())
+	user.CreatedAt = f.timestamp()
+	user.UpdatedAt = user.CreatedAt
+	f.users[user.ID] = user
+	resp := &sdk.UserCreateResponse{UserID: user.ID}
+	if req.GetApiKey {
+		resp.ApiKey = fmt.Sprintf("fake-api-key-%d", user.ID)
+	}
+	return resp, nil
+}
+
+// DeleteUser deletes a user.
+func (f *Fake) DeleteUser(ctx context.Context, req *sdk.UserDeleteUserRequest, opts ...sdk.CallOption) (*sdk.UserDeleteUserResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.users[req.UserID]; !ok {
+		return nil, notFound("user", req.UserID)
+	}
+	delete(f.users, req.UserID)
+	return &sdk.UserDeleteUserResponse{UserID: req.UserID}, nil
+}
+
+// GetUserDetail returns a user by ID.
+func (f *Fake) GetUserDetail(ctx context.Context, req *sdk.UserDetailInfoRequest, opts ...sdk.CallOption) (*sdk.UserDetailInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, ok := f.users[req.UserID]
+	if !ok {
+		return nil, notFound("user", req.UserID)
+	}
+	return &sdk.UserDetailInfoResponse{UserResponse: *user}, nil
+}
+
+// ListUsers lists users matching the keyword and name filters.
+func (f *Fake) ListUsers(ctx context.Context, req *sdk.UserListRequest, opts ...sdk.CallOption) (*sdk.UserListResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var matched []sdk.UserResponse
+	for _, user := range f.users {
+		if matchKeyword(user.Name, req.Keyword, req.Filters) {
+			matched = append(matched, *user)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+	start, end := paginate(len(matched), req.CommonCondition)
+	return &sdk.UserListResponse{Total: len(matched), List: matched[start:end]}, nil
+}
+
+// GetUserByName returns the user named name.
+func (f *Fake) GetUserByName(ctx context.Context, name string, opts ...sdk.CallOption) (*sdk.UserResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, user := range f.users {
+		if user.Name == name {
+			resp := *user
+			return &resp, nil
+		}
+	}
+	return nil, notFound("user", name)
+}
+
+// UpdateUserInfo replaces the contact details and description of a user.
+func (f *Fake) UpdateUserInfo(ctx context.Context, req *sdk.UserUpdateInfoRequest, opts ...sdk.CallOption) (*sdk.UserUpdateInfoResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, ok := f.users[req.UserID]
+	if !ok {
+		return nil, notFound("user", req.UserID)
+	}
+	user.Phone = req.Phone
+	user.Email = req.Email
+	user.Description = req.Description
+	user.UpdatedAt = f.timestamp()
+	return &sdk.UserUpdateInfoResponse{UserID: req.UserID}, nil
+}
+
+// UpdateUserRoles replaces the roles of a user.
+func (f *Fake) UpdateUserRoles(ctx context.Context, req *sdk.UserUpdateRoleListRequest, opts ...sdk.CallOption) (*sdk.UserUpdateRoleListResponse, error) {
+	if req == nil {
+		return nil, sdk.ErrNilRequest
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	user, ok := f.users[req.UserID]
+	if !ok {
+		return nil, notFound("user", req.UserID)
+	}
+	roles := make([]*sdk.RoleIDName, 0, len(req.RoleIDList))
+	for _, roleID := range req.RoleIDList {
+		role, ok := f.roles[roleID]
+		if !ok {
+			return nil, notFound("role", roleID)
+		}
+		roles = append(roles, &sdk.RoleIDName{ID: roleID, Name: role.RoleName, Status: role.Status})
+	}
+	user.RoleList = roles
+	user.UpdatedAt = f.timestamp()
+	return &sdk.UserUpdateRoleListResponse{UserID: req.UserID}, nil
+}
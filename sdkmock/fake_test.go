@@ -0,0 +1,186 @@
+package sdkmock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func TestFakeCatalogHierarchy(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	catalog, err := fake.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "c1"})
+	require.NoError(t, err)
+	db, err := fake.CreateDatabase(ctx, &sdk.DatabaseCreateRequest{DatabaseName: "d1", CatalogID: catalog.CatalogID})
+	require.NoError(t, err)
+	table, err := fake.CreateTable(ctx, &sdk.TableCreateRequest{
+		DatabaseID: db.DatabaseID,
+		Name:       "t1",
+		Columns:    []sdk.Column{{Name: "id", Type: "int", IsPk: true}},
+	})
+	require.NoError(t, err)
+	volume, err := fake.CreateVolume(ctx, &sdk.VolumeCreateRequest{Name: "v1", DatabaseID: db.DatabaseID})
+	require.NoError(t, err)
+
+	exists, err := fake.CheckTableExists(ctx, &sdk.TableExistRequest{DatabaseID: db.DatabaseID, Name: "t1"})
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	info, err := fake.GetTable(ctx, &sdk.TableInfoRequest{TableID: table.TableID})
+	require.NoError(t, err)
+	require.Equal(t, "t1", info.Name)
+	require.Len(t, info.Columns, 1)
+
+	children, err := fake.GetDatabaseChildren(ctx, &sdk.DatabaseChildrenRequest{DatabaseID: db.DatabaseID})
+	require.NoError(t, err)
+	require.Len(t, children.List, 2)
+
+	tree, err := fake.GetCatalogTree(ctx)
+	require.NoError(t, err)
+	require.Len(t, tree.Tree, 1)
+	require.Len(t, tree.Tree[0].NodeList, 1)
+	require.Len(t, tree.Tree[0].NodeList[0].NodeList, 2)
+
+	_, err = fake.DeleteCatalog(ctx, &sdk.CatalogDeleteRequest{CatalogID: catalog.CatalogID})
+	require.NoError(t, err)
+	_, err = fake.GetVolume(ctx, &sdk.VolumeInfoRequest{VolumeID: volume.VolumeID})
+	requireAPICode(t, err, CodeNotFound)
+	_, err = fake.GetTable(ctx, &sdk.TableInfoRequest{TableID: table.TableID})
+	requireAPICode(t, err, CodeNotFound)
+}
+
+func TestFakeDuplicateNames(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	_, err := fake.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "dup"})
+	require.NoError(t, err)
+	_, err = fake.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "dup"})
+	requireAPICode(t, err, CodeAlreadyExists)
+
+	_, err = fake.CreateRole(ctx, &sdk.RoleCreateRequest{RoleName: "r"})
+	require.NoError(t, err)
+	_, err = fake.CreateRole(ctx, &sdk.RoleCreateRequest{RoleName: "r"})
+	requireAPICode(t, err, CodeAlreadyExists)
+}
+
+func TestFakeRename(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	catalog, err := fake.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "c1"})
+	require.NoError(t, err)
+	db, err := fake.CreateDatabase(ctx, &sdk.DatabaseCreateRequest{DatabaseName: "d1", CatalogID: catalog.CatalogID})
+	require.NoError(t, err)
+	_, err = fake.CreateDatabase(ctx, &sdk.DatabaseCreateRequest{DatabaseName: "d2", CatalogID: catalog.CatalogID})
+	require.NoError(t, err)
+	_, err = fake.UpdateDatabase(ctx, &sdk.DatabaseUpdateRequest{DatabaseID: db.DatabaseID, DatabaseName: "d2"})
+	requireAPICode(t, err, CodeAlreadyExists)
+	_, err = fake.UpdateDatabase(ctx, &sdk.DatabaseUpdateRequest{DatabaseID: db.DatabaseID, DatabaseName: "d3"})
+	require.NoError(t, err)
+	info, err := fake.GetDatabase(ctx, &sdk.DatabaseInfoRequest{DatabaseID: db.DatabaseID})
+	require.NoError(t, err)
+	require.Equal(t, "d3", info.DatabaseName)
+
+	t1, err := fake.CreateTable(ctx, &sdk.TableCreateRequest{DatabaseID: db.DatabaseID, Name: "t1"})
+	require.NoError(t, err)
+	_, err = fake.CreateTable(ctx, &sdk.TableCreateRequest{DatabaseID: db.DatabaseID, Name: "t2"})
+	require.NoError(t, err)
+	_, err = fake.RenameTable(ctx, &sdk.TableRenameRequest{TableID: t1.TableID, Name: "t2"})
+	requireAPICode(t, err, CodeAlreadyExists)
+	_, err = fake.RenameTable(ctx, &sdk.TableRenameRequest{TableID: t1.TableID, Name: "t3"})
+	require.NoError(t, err)
+	exists, err := fake.CheckTableExists(ctx, &sdk.TableExistRequest{DatabaseID: db.DatabaseID, Name: "t3"})
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestFakeFilesAndFolders(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	catalog, err := fake.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "c"})
+	require.NoError(t, err)
+	db, err := fake.CreateDatabase(ctx, &sdk.DatabaseCreateRequest{DatabaseName: "d", CatalogID: catalog.CatalogID})
+	require.NoError(t, err)
+	volume, err := fake.CreateVolume(ctx, &sdk.VolumeCreateRequest{Name: "v", DatabaseID: db.DatabaseID})
+	require.NoError(t, err)
+
+	folder, err := fake.CreateFolder(ctx, &sdk.FolderCreateRequest{Name: "docs", VolumeID: volume.VolumeID})
+	require.NoError(t, err)
+	_, err = fake.CreateFile(ctx, &sdk.FileCreateRequest{Name: "a.txt", VolumeID: volume.VolumeID, ParentID: folder.FolderID, Size: 3})
+	require.NoError(t, err)
+	_, err = fake.CreateFile(ctx, &sdk.FileCreateRequest{Name: "b.txt", VolumeID: volume.VolumeID, ParentID: folder.FolderID, Size: 4})
+	require.NoError(t, err)
+
+	list, err := fake.ListFiles(ctx, &sdk.FileListRequest{
+		CommonCondition: sdk.CommonCondition{
+			Filters: []sdk.CommonFilter{{Name: "parent_id", Values: []string{string(folder.FolderID)}}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, list.Total)
+
+	list, err = fake.ListFiles(ctx, &sdk.FileListRequest{Keyword: "b."})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Total)
+	require.Equal(t, "b.txt", list.List[0].Name)
+
+	_, err = fake.CleanFolder(ctx, &sdk.FolderCleanRequest{FolderID: folder.FolderID})
+	require.NoError(t, err)
+	list, err = fake.ListFiles(ctx, &sdk.FileListRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Total)
+	require.Equal(t, "docs", list.List[0].Name)
+}
+
+func TestFakeUsersReferenceRoles(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFake()
+
+	_, err := fake.CreateUser(ctx, &sdk.UserCreateRequest{UserName: "u", RoleIDList: []sdk.RoleID{42}})
+	requireAPICode(t, err, CodeNotFound)
+
+	role, err := fake.CreateRole(ctx, &sdk.RoleCreateRequest{RoleName: "reader", PrivList: []string{"DT8"}})
+	require.NoError(t, err)
+	user, err := fake.CreateUser(ctx, &sdk.UserCreateRequest{UserName: "u", RoleIDList: []sdk.RoleID{role.RoleID}, GetApiKey: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, user.ApiKey)
+
+	detail, err := fake.GetUserDetail(ctx, &sdk.UserDetailInfoRequest{UserID: user.UserID})
+	require.NoError(t, err)
+	require.Len(t, detail.RoleList, 1)
+	require.Equal(t, "reader", detail.RoleList[0].Name)
+
+	_, err = fake.UpdateUserRoles(ctx, &sdk.UserUpdateRoleListRequest{UserID: user.UserID, RoleIDList: []sdk.RoleID{42}})
+	requireAPICode(t, err, CodeNotFound)
+	_, err = fake.UpdateUserRoles(ctx, &sdk.UserUpdateRoleListRequest{UserID: user.UserID})
+	require.NoError(t, err)
+	detail, err = fake.GetUserDetail(ctx, &sdk.UserDetailInfoRequest{UserID: user.UserID})
+	require.NoError(t, err)
+	require.Empty(t, detail.RoleList)
+
+	_, err = fake.UpdateRoleInfo(ctx, &sdk.RoleUpdateInfoRequest{RoleID: role.RoleID, PrivList: []string{"DT8", "DT9"}, Comment: "rw"})
+	require.NoError(t, err)
+	info, err := fake.GetRole(ctx, &sdk.RoleInfoRequest{RoleID: role.RoleID})
+	require.NoError(t, err)
+	require.Equal(t, "rw", info.Comment)
+	require.Len(t, info.AuthorityList, 2)
+}
+
+func TestFakeNilRequest(t *testing.T) {
+	_, err := NewFake().CreateCatalog(context.Background(), nil)
+	require.ErrorIs(t, err, sdk.ErrNilRequest)
+}
+
+func requireAPICode(t *testing.T, err error, code string) {
+	t.Helper()
+	var apiErr *sdk.APIError
+	require.True(t, errors.As(err, &apiErr), "expected *sdk.APIError, got %v", err)
+	require.Equal(t, code, apiErr.Code)
+}
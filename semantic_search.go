@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultSemanticSearchTopK is the number of passages SemanticSearch returns
+// when no topK is given.
+const defaultSemanticSearchTopK = 10
+
+// SearchSource selects the knowledge searched by SemanticSearch: the files
+// of a volume or of a dataset. Exactly one of the fields must be set.
+type SearchSource struct {
+	VolumeID  VolumeID
+	DatasetID DatasetID
+}
+
+// SemanticSearch finds the passages of the files of a volume or dataset
+// closest in meaning to query.
+//
+// The query is embedded and matched against the passages indexed for RAG on
+// the server, through RawClient.SearchFilePassages. Each passage carries
+// its score and the file it comes from, so callers can cite sources or
+// fetch the full document.
+//
+// Parameters:
+//   - ctx: context for the request
+//   - source: the volume or dataset to search (required)
+//   - query: the text to search for (required)
+//   - topK: the maximum number of passages returned; 10 if <= 0
+//
+// Returns:
+//   - []FilePassage: the passages found, highest score first
+//   - error: any error that occurred
+//
+// Example:
+//
+//	passages, err := sdkClient.SemanticSearch(ctx, sdk.SearchSource{VolumeID: volumeID},
+//		"how do I rotate the API key?", 5)
+//	if err != nil {
+//		return err
+//	}
+//	for _, p := range passages {
+//		fmt.Printf("[%.2f] %s (page %d)\n%s\n", p.Score, p.FileName, p.Page, p.Content)
+//	}
+func (c *SDKClient) SemanticSearch(ctx context.Context, source SearchSource, query string, topK int, opts ...CallOption) ([]FilePassage, error) {
+	if (source.VolumeID == "") == (source.DatasetID == 0) {
+		return nil, fmt.Errorf("exactly one of volume_id and dataset_id is required")
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	if topK <= 0 {
+		topK = defaultSemanticSearchTopK
+	}
+	req := &PassageSearchRequest{Query: query, TopK: topK}
+	if source.VolumeID != "" {
+		req.VolumeIDs = []VolumeID{source.VolumeID}
+	} else {
+		req.DatasetIDs = []DatasetID{source.DatasetID}
+	}
+	resp, err := c.raw.SearchFilePassages(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	passages := resp.Passages
+	sort.SliceStable(passages, func(i, j int) bool { return passages[i].Score > passages[j].Score })
+	if len(passages) > topK {
+		passages = passages[:topK]
+	}
+	return passages, nil
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemanticSearch(t *testing.T) {
+	t.Parallel()
+	var got PassageSearchRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/search": func(w http.ResponseWriter, r *http.Request) {
+			got = PassageSearchRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			writeEnvelope(w, PassageSearchResponse{Passages: []FilePassage{
+				{Score: 0.4, Content: "b", FileID: "f2", FileName: "b.pdf"},
+				{Score: 0.9, Content: "a", FileID: "f1", FileName: "a.pdf", Page: 3},
+				{Score: 0.6, Content: "c", FileID: "f3", FileName: "c.pdf"},
+			}})
+		},
+	}))
+	ctx := context.Background()
+
+	passages, err := client.SemanticSearch(ctx, SearchSource{VolumeID: "v1"}, "refunds", 2)
+	require.NoError(t, err)
+	require.Equal(t, PassageSearchRequest{Query: "refunds", TopK: 2, VolumeIDs: []VolumeID{"v1"}}, got)
+	require.Len(t, passages, 2)
+	require.Equal(t, FileID("f1"), passages[0].FileID)
+	require.Equal(t, 3, passages[0].Page)
+	require.Equal(t, FileID("f3"), passages[1].FileID)
+
+	_, err = client.SemanticSearch(ctx, SearchSource{DatasetID: 7}, "refunds", 0)
+	require.NoError(t, err)
+	require.Equal(t, []DatasetID{7}, got.DatasetIDs)
+	require.Equal(t, defaultSemanticSearchTopK, got.TopK)
+
+	_, err = client.SemanticSearch(ctx, SearchSource{VolumeID: "v1", DatasetID: 7}, "refunds", 1)
+	require.Error(t, err)
+	_, err = client.SemanticSearch(ctx, SearchSource{}, "refunds", 1)
+	require.Error(t, err)
+	_, err = client.SemanticSearch(ctx, SearchSource{VolumeID: "v1"}, " ", 1)
+	require.Error(t, err)
+	_, err = client.Raw().SearchFilePassages(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+}
@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// verifySignature checks req the way a server would.
+func verifySignature(t *testing.T, req *http.Request, secret string) {
+	t.Helper()
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	bodyHash := req.Header.Get(headerSignatureBodyHash)
+	if bodyHash != unsignedPayload {
+		sum := sha256.Sum256(body)
+		require.Equal(t, hex.EncodeToString(sum[:]), bodyHash)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		req.Header.Get(headerSignatureTimestamp),
+		req.Header.Get(headerSignatureNonce),
+		bodyHash,
+	}, "\n")))
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), req.Header.Get(headerSignature))
+}
+
+func TestWithSigningCredentials(t *testing.T) {
+	t.Parallel()
+	var nonces []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get(headerAPIKey))
+		require.Equal(t, "ak", r.Header.Get(headerSignatureKeyID))
+		verifySignature(t, r, "secret")
+		nonces = append(nonces, r.Header.Get(headerSignatureNonce))
+		writeEnvelope(w, map[string]any{})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewRawClient(server.URL, "", WithSigningCredentials(SigningCredentials{AccessKeyID: "ak", Secret: "secret"}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1})
+	require.NoError(t, err)
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1}, WithQueryParam("b", "2"), WithQueryParam("a", "1"))
+	require.NoError(t, err)
+	_, err = client.GetAnalysisResult(ctx, "r1")
+	require.NoError(t, err)
+	require.Len(t, nonces, 3)
+	require.NotEqual(t, nonces[0], nonces[1])
+
+	_, err = NewRawClient(server.URL, "")
+	require.ErrorIs(t, err, ErrAPIKeyRequired)
+}
+
+func TestSignStreamedBody(t *testing.T) {
+	t.Parallel()
+	s := &requestSigner{creds: SigningCredentials{AccessKeyID: "ak", Secret: "secret"}}
+	s.now = func() time.Time { return time.Unix(1700000000, 0) }
+	pr, pw := io.Pipe()
+	go func() { _ = pw.Close() }()
+	req := httptest.NewRequest(http.MethodPost, "/connectors/upload", pr)
+	require.NoError(t, s.sign(req))
+	require.Equal(t, unsignedPayload, req.Header.Get(headerSignatureBodyHash))
+	require.Equal(t, "1700000000", req.Header.Get(headerSignatureTimestamp))
+	verifySignature(t, req, "secret")
+}
+
+func TestSignMultipartUpload(t *testing.T) {
+	t.Parallel()
+	var uploads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/connectors/file/upload", r.URL.Path)
+		require.Equal(t, unsignedPayload, r.Header.Get(headerSignatureBodyHash))
+		verifySignature(t, r, "secret")
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		require.Len(t, r.MultipartForm.File["file"], 1)
+		uploads++
+		writeEnvelope(w, LocalFileUploadResponse{ConnFileIds: []string{"cf-1"}})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewRawClient(server.URL, "", WithSigningCredentials(SigningCredentials{AccessKeyID: "ak", Secret: "secret"}))
+	require.NoError(t, err)
+	resp, err := client.UploadLocalFile(context.Background(), strings.NewReader("a,b\n1,2\n"), "data.csv",
+		[]FileMeta{{Filename: "data.csv", Path: "/"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"cf-1"}, resp.ConnFileIds)
+	require.Equal(t, 1, uploads)
+}
@@ -0,0 +1,316 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// defaultSQLCursorBatchSize is the number of rows fetched per request when
+// no batch size is given.
+const defaultSQLCursorBatchSize = 1000
+
+// cursorKeyNullColumn is the column added to keyset batches to tell a NULL
+// key from the text "NULL", which the rows render alike.
+const cursorKeyNullColumn = "sdk_cursor_key_null"
+
+// SQLCursor iterates over the rows of a query in batches, so that large
+// results are never held in memory at once. Create one with RunSQLCursor.
+//
+// Batches are only consistent if the rows have a stable total order: two
+// rows must never compare equal, otherwise the server may return them in a
+// different order for each batch and rows are skipped or repeated. With
+// SQLCursorOptions.KeyColumn the cursor orders by that column itself;
+// otherwise the statement's own ORDER BY must provide such an order.
+type SQLCursor struct {
+	client    *SDKClient
+	ctx       context.Context
+	statement string
+	batchSize int
+	key       string
+	opts      []CallOption
+
+	columns []string
+	batch   []NL2SQLRow
+	pos     int
+	offset  int
+	// keyIndex is the position of key in columns, and lastKey the key of
+	// the last row received, used to select the next batch.
+	keyIndex int
+	lastKey  *string
+	done     bool
+	err      error
+}
+
+// SQLCursorOptions configures RunSQLCursor.
+type SQLCursorOptions struct {
+	// BatchSize is the number of rows fetched per request. Defaults to
+	// 1000.
+	BatchSize int
+	// KeyColumn names a column of the result whose values are unique and
+	// never NULL, such as a single-column primary key. Batches are then
+	// fetched by keyset, as
+	// "SELECT * FROM (statement) WHERE key > last ORDER BY key LIMIT n",
+	// so every batch costs the same however deep the iteration goes. The
+	// batches also select whether the key is NULL, so that a NULL key is
+	// reported as an error while a key holding the text "NULL" is not.
+	//
+	// Without it the statement must end with an ORDER BY clause giving a
+	// total order, to which "LIMIT n OFFSET m" is appended. The server then
+	// reads and discards all the rows before each batch, which makes deep
+	// iterations over large results slow.
+	KeyColumn string
+}
+
+// RunSQLCursor opens a cursor over the rows of a SELECT statement.
+//
+// args holds the values of the statement's "?" placeholders and optional
+// CallOptions, as for QueryRows. opts may be nil, in which case the
+// statement must end with an ORDER BY clause; see SQLCursorOptions. No
+// request is made until the first call to Next.
+//
+// Example:
+//
+//	cur, err := sdkClient.RunSQLCursor(ctx, "SELECT id, amount FROM shop.orders WHERE amount > ?",
+//		&sdk.SQLCursorOptions{BatchSize: 5000, KeyColumn: "id"}, 100)
+//	if err != nil {
+//		return err
+//	}
+//	defer cur.Close()
+//	for cur.Next() {
+//		var o struct {
+//			ID     int64   `db:"id"`
+//			Amount float64 `db:"amount"`
+//		}
+//		if err := cur.Scan(&o); err != nil {
+//			return err
+//		}
+//		process(o)
+//	}
+//	if err := cur.Err(); err != nil {
+//		return err
+//	}
+func (c *SDKClient) RunSQLCursor(ctx context.Context, statement string, opts *SQLCursorOptions, args ...any) (*SQLCursor, error) {
+	statement = strings.TrimRight(strings.TrimSpace(statement), "; \t\n")
+	if statement == "" {
+		return nil, fmt.Errorf("statement is required")
+	}
+	var cfg SQLCursorOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	cfg.KeyColumn = strings.TrimSpace(cfg.KeyColumn)
+	params, callOpts := splitSQLArgs(args)
+	if len(params) > 0 {
+		bound, err := BindSQL(statement, params...)
+		if err != nil {
+			return nil, err
+		}
+		statement = bound
+	}
+	if cfg.KeyColumn == "" && !endsWithOrderBy(statement) {
+		return nil, fmt.Errorf("statement must end with an ORDER BY clause without LIMIT, or a key column must be given")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultSQLCursorBatchSize
+	}
+	return &SQLCursor{
+		client:    c,
+		ctx:       ctx,
+		statement: statement,
+		batchSize: cfg.BatchSize,
+		key:       cfg.KeyColumn,
+		opts:      callOpts,
+	}, nil
+}
+
+// endsWithOrderBy reports whether the top level of statement has an ORDER
+// BY clause that is not followed by a LIMIT clause. Quoted sections,
+// comments and parenthesized subqueries are ignored.
+func endsWithOrderBy(statement string) bool {
+	masked := []byte(statement)
+	for i := 0; i < len(masked); i++ {
+		switch ch := masked[i]; {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := skipQuoted(statement, i)
+			for j := i; j < end; j++ {
+				masked[j] = ' '
+			}
+			i = end - 1
+		case ch == '-' && strings.HasPrefix(statement[i:], "--"), ch == '#':
+			for ; i < len(masked) && masked[i] != '\n'; i++ {
+				masked[i] = ' '
+			}
+		case ch == '/' && strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				end = len(statement)
+			} else {
+				end += i + 4
+			}
+			for j := i; j < end; j++ {
+				masked[j] = ' '
+			}
+			i = end - 1
+		}
+	}
+	depth, orderBy := 0, false
+	for _, m := range cursorClauseRe.FindAllString(string(masked), -1) {
+		switch m = strings.ToUpper(m); {
+		case m == "(":
+			depth++
+		case m == ")":
+			depth--
+		case depth != 0:
+		case strings.HasPrefix(m, "ORDER"):
+			orderBy = true
+		default:
+			// LIMIT, or a UNION that the ORDER BY seen so far belonged to
+			// a part of.
+			orderBy = false
+		}
+	}
+	return orderBy
+}
+
+var cursorClauseRe = regexp.MustCompile(`(?i)[()]|\border\s+by\b|\blimit\b|\bunion\b`)
+
+// Next advances the cursor to the next row, fetching the next batch when
+// the current one is exhausted. It returns false when there are no more
+// rows or an error occurred; check Err to tell them apart.
+func (cur *SQLCursor) Next() bool {
+	if cur.err != nil {
+		return false
+	}
+	cur.pos++
+	if cur.pos < len(cur.batch) {
+		return true
+	}
+	if cur.done {
+		return false
+	}
+	if err := cur.fetch(); err != nil {
+		cur.err = err
+		return false
+	}
+	return cur.pos < len(cur.batch)
+}
+
+func (cur *SQLCursor) fetch() error {
+	if err := cur.ctx.Err(); err != nil {
+		return err
+	}
+	var stmt string
+	if cur.key == "" {
+		stmt = fmt.Sprintf("%s LIMIT %d OFFSET %d", cur.statement, cur.batchSize, cur.offset)
+	} else {
+		key := quoteSQLIdent(cur.key)
+		stmt = fmt.Sprintf("SELECT sdk_cursor.*, sdk_cursor.%s IS NULL AS %s FROM (%s) AS sdk_cursor", key, cursorKeyNullColumn, cur.statement)
+		if cur.lastKey != nil {
+			stmt += fmt.Sprintf(" WHERE %s > %s", key, quoteSQLString(*cur.lastKey))
+		}
+		stmt += fmt.Sprintf(" ORDER BY %s LIMIT %d", key, cur.batchSize)
+	}
+	resp, err := cur.client.RunSQL(cur.ctx, stmt, cur.opts...)
+	if err != nil {
+		return err
+	}
+	cur.batch, cur.pos = nil, 0
+	lastKeyNull := false
+	if len(resp.Results) > 0 {
+		result := resp.Results[0]
+		columns, rows := result.Columns, result.Rows
+		if cur.key != "" {
+			// Strip the NULL flag of the key, the last column.
+			n := len(columns) - 1
+			if n < 0 || !strings.EqualFold(columns[n], cursorKeyNullColumn) {
+				return fmt.Errorf("result columns %v do not end with %s", columns, cursorKeyNullColumn)
+			}
+			columns = columns[:n]
+			stripped := make([]NL2SQLRow, len(rows))
+			for i, row := range rows {
+				stripped[i] = row[:min(n, len(row))]
+			}
+			if len(rows) > 0 {
+				last := rows[len(rows)-1]
+				lastKeyNull = len(last) <= n || last[n] == "1" || strings.EqualFold(last[n], "true")
+			}
+			rows = stripped
+		}
+		if cur.columns == nil {
+			cur.columns = columns
+			if cur.key != "" {
+				if cur.keyIndex = slices.IndexFunc(cur.columns, func(col string) bool { return strings.EqualFold(col, cur.key) }); cur.keyIndex < 0 {
+					return fmt.Errorf("key column %q is not in the result columns %v", cur.key, cur.columns)
+				}
+			}
+		}
+		cur.batch = rows
+	}
+	cur.offset += len(cur.batch)
+	if len(cur.batch) < cur.batchSize {
+		cur.done = true
+	} else if cur.key != "" {
+		last := cur.batch[len(cur.batch)-1]
+		if cur.keyIndex >= len(last) || lastKeyNull {
+			return fmt.Errorf("key column %q is NULL in row %d", cur.key, cur.offset)
+		}
+		cur.lastKey = &last[cur.keyIndex]
+	}
+	return nil
+}
+
+// Columns returns the column names of the result. It is nil until the
+// first batch has been fetched.
+func (cur *SQLCursor) Columns() []string {
+	return cur.columns
+}
+
+// Row returns the current row. It is valid only after Next returned true.
+func (cur *SQLCursor) Row() NL2SQLRow {
+	if cur.pos >= len(cur.batch) {
+		return nil
+	}
+	return cur.batch[cur.pos]
+}
+
+// Scan converts the current row into dest, which must be a pointer to a
+// struct. Columns are matched to fields as in NL2SQLResult.Scan.
+func (cur *SQLCursor) Scan(dest any) error {
+	row := cur.Row()
+	if row == nil {
+		return fmt.Errorf("sdk: Scan called without a current row")
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sdk: scan destination must be a non-nil pointer to a struct, got %T", dest)
+	}
+	item := rv.Elem()
+	fields := structFieldIndex(item.Type())
+	for i, col := range cur.columns {
+		idx := fields[strings.ToLower(col)]
+		if idx == nil || i >= len(row) {
+			continue
+		}
+		if err := setSQLValue(item.FieldByIndex(idx), row[i]); err != nil {
+			return fmt.Errorf("sdk: scan column %q: %w", col, err)
+		}
+	}
+	return nil
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (cur *SQLCursor) Err() error {
+	return cur.err
+}
+
+// Close stops the iteration and releases the buffered rows.
+func (cur *SQLCursor) Close() error {
+	cur.done = true
+	cur.batch = nil
+	cur.pos = 0
+	return nil
+}
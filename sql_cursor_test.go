@@ -0,0 +1,218 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLCursor(t *testing.T) {
+	t.Parallel()
+
+	const total = 7
+	pageRe := regexp.MustCompile(`^SELECT id, name FROM db.t WHERE id > 0 ORDER BY id LIMIT (\d+) OFFSET (\d+)$`)
+	var statements []string
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			var req NL2SQLRunSQLRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			statements = append(statements, req.Statement)
+			m := pageRe.FindStringSubmatch(req.Statement)
+			require.NotNil(t, m, req.Statement)
+			limit, _ := strconv.Atoi(m[1])
+			offset, _ := strconv.Atoi(m[2])
+			result := NL2SQLResult{Columns: []string{"id", "name"}, Rows: []NL2SQLRow{}}
+			for i := offset; i < total && i < offset+limit; i++ {
+				result.Rows = append(result.Rows, NL2SQLRow{strconv.Itoa(i + 1), fmt.Sprintf("n%d", i+1)})
+			}
+			writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{result}})
+		},
+	})
+	client := NewSDKClient(raw)
+
+	cur, err := client.RunSQLCursor(context.Background(), "SELECT id, name FROM db.t WHERE id > ? ORDER BY id;", &SQLCursorOptions{BatchSize: 3}, 0)
+	require.NoError(t, err)
+	defer cur.Close()
+
+	type row struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+	var rows []row
+	for cur.Next() {
+		var r row
+		require.NoError(t, cur.Scan(&r))
+		rows = append(rows, r)
+	}
+	require.NoError(t, cur.Err())
+	require.Len(t, rows, total)
+	require.Equal(t, row{ID: 7, Name: "n7"}, rows[6])
+	require.Equal(t, []string{"id", "name"}, cur.Columns())
+	// Batches of 3, 3 and a final short batch of 1.
+	require.Len(t, statements, 3)
+	require.False(t, cur.Next())
+}
+
+func TestSQLCursor_KeysetIgnoresUnstableOrder(t *testing.T) {
+	t.Parallel()
+
+	const total = 10
+	pageRe := regexp.MustCompile("^SELECT sdk_cursor\\.\\*, sdk_cursor\\.`id` IS NULL AS sdk_cursor_key_null FROM \\((.*)\\) AS sdk_cursor(?: WHERE `id` > '(\\d+)')? ORDER BY `id` LIMIT (\\d+)$")
+	rng := rand.New(rand.NewSource(1))
+	var statements []string
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			var req NL2SQLRunSQLRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			statements = append(statements, req.Statement)
+			m := pageRe.FindStringSubmatch(req.Statement)
+			require.NotNil(t, m, req.Statement)
+			require.Equal(t, "SELECT id, name FROM db.t", m[1])
+
+			// The statement itself has no order, so every request sees
+			// the rows in a different order; only the outer ORDER BY
+			// makes batches consistent.
+			ids := rng.Perm(total)
+			last := -1
+			if m[2] != "" {
+				last, _ = strconv.Atoi(m[2])
+			}
+			limit, _ := strconv.Atoi(m[3])
+			var selected []int
+			for _, id := range ids {
+				if id > last {
+					selected = append(selected, id)
+				}
+			}
+			slices.Sort(selected)
+			result := NL2SQLResult{Columns: []string{"ID", "name", "sdk_cursor_key_null"}, Rows: []NL2SQLRow{}}
+			for _, id := range selected[:min(limit, len(selected))] {
+				result.Rows = append(result.Rows, NL2SQLRow{strconv.Itoa(id), fmt.Sprintf("n%d", id), "0"})
+			}
+			writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{result}})
+		},
+	})
+	client := NewSDKClient(raw)
+
+	cur, err := client.RunSQLCursor(context.Background(), "SELECT id, name FROM db.t", &SQLCursorOptions{BatchSize: 4, KeyColumn: "id"})
+	require.NoError(t, err)
+	var ids []string
+	for cur.Next() {
+		ids = append(ids, cur.Row()[0])
+	}
+	require.NoError(t, cur.Err())
+	require.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, ids)
+	require.Equal(t, []string{"ID", "name"}, cur.Columns())
+	require.Len(t, statements, 3)
+	require.Equal(t, "SELECT sdk_cursor.*, sdk_cursor.`id` IS NULL AS sdk_cursor_key_null FROM (SELECT id, name FROM db.t) AS sdk_cursor WHERE `id` > '7' ORDER BY `id` LIMIT 4", statements[2])
+}
+
+func TestSQLCursor_KeyColumnMissing(t *testing.T) {
+	t.Parallel()
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{{Columns: []string{"name", "sdk_cursor_key_null"}, Rows: []NL2SQLRow{{"a", "1"}}}}})
+		},
+	})
+	cur, err := NewSDKClient(raw).RunSQLCursor(context.Background(), "SELECT name FROM db.t", &SQLCursorOptions{KeyColumn: "id"})
+	require.NoError(t, err)
+	require.False(t, cur.Next())
+	require.ErrorContains(t, cur.Err(), `key column "id" is not in the result columns`)
+}
+
+func TestSQLCursor_ExactMultipleAndErrors(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			switch calls {
+			case 1:
+				writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{{Columns: []string{"id"}, Rows: []NL2SQLRow{{"1"}, {"2"}}}}})
+			default:
+				writeEnvelopeError(w, "ErrInternal", "boom")
+			}
+		},
+	})
+	client := NewSDKClient(raw)
+
+	cur, err := client.RunSQLCursor(context.Background(), "SELECT id FROM db.t ORDER BY id", &SQLCursorOptions{BatchSize: 2})
+	require.NoError(t, err)
+	require.True(t, cur.Next())
+	require.Equal(t, NL2SQLRow{"1"}, cur.Row())
+	require.True(t, cur.Next())
+	// A full batch requires another fetch, which fails.
+	require.False(t, cur.Next())
+	require.Error(t, cur.Err())
+
+	_, err = client.RunSQLCursor(context.Background(), " ; ", nil)
+	require.Error(t, err)
+}
+
+func TestSQLCursor_RequiresOrder(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, nil))
+	for stmt, ok := range map[string]bool{
+		"SELECT id FROM db.t":                                           false,
+		"SELECT id FROM db.t ORDER BY id LIMIT 10":                      false,
+		"SELECT id FROM (SELECT id FROM db.t ORDER BY id) AS s":         false,
+		"SELECT id FROM db.t WHERE note = 'ORDER BY id'":                false,
+		"SELECT id FROM db.t ORDER BY id -- LIMIT 5":                    true,
+		"SELECT id FROM db.t ORDER BY FIELD(id, 3, 1), id":              true,
+		"(SELECT id FROM db.a) UNION (SELECT id FROM db.b) ORDER BY id": true,
+	} {
+		_, err := client.RunSQLCursor(context.Background(), stmt, nil)
+		if ok {
+			require.NoError(t, err, stmt)
+		} else {
+			require.ErrorContains(t, err, "ORDER BY", stmt)
+		}
+	}
+}
+
+func TestSQLCursor_KeyNull(t *testing.T) {
+	t.Parallel()
+	var statements []string
+	nullFlag := "0"
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			var req NL2SQLRunSQLRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			statements = append(statements, req.Statement)
+			result := NL2SQLResult{Columns: []string{"code", "sdk_cursor_key_null"}}
+			if len(statements) == 1 {
+				result.Rows = []NL2SQLRow{{"MULL", "0"}, {"NULL", nullFlag}}
+			}
+			writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{result}})
+		},
+	})
+	client := NewSDKClient(raw)
+
+	// A key holding the text "NULL" is an ordinary value.
+	cur, err := client.RunSQLCursor(context.Background(), "SELECT code FROM db.t", &SQLCursorOptions{BatchSize: 2, KeyColumn: "code"})
+	require.NoError(t, err)
+	var codes []string
+	for cur.Next() {
+		codes = append(codes, cur.Row()[0])
+	}
+	require.NoError(t, cur.Err())
+	require.Equal(t, []string{"MULL", "NULL"}, codes)
+	require.Len(t, statements, 2)
+	require.Contains(t, statements[1], "WHERE `code` > 'NULL'")
+
+	// A NULL key stops the iteration.
+	statements, nullFlag = nil, "1"
+	cur, err = client.RunSQLCursor(context.Background(), "SELECT code FROM db.t", &SQLCursorOptions{BatchSize: 2, KeyColumn: "code"})
+	require.NoError(t, err)
+	require.False(t, cur.Next())
+	require.ErrorContains(t, cur.Err(), `key column "code" is NULL in row 2`)
+}
@@ -0,0 +1,223 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlTimeFormat is the literal format used for time.Time parameters.
+const sqlTimeFormat = "2006-01-02 15:04:05.999999"
+
+// BindSQL replaces each "?" placeholder in statement with the corresponding
+// argument rendered as a SQL literal.
+//
+// Placeholders inside quoted strings, quoted identifiers and comments are
+// left alone. Arguments are rendered as follows:
+//   - nil and nil pointers: NULL
+//   - strings: single-quoted, with quotes, backslashes and control
+//     characters escaped
+//   - []byte: a hexadecimal literal (X'...')
+//   - bools: TRUE or FALSE
+//   - integers and floats, including named types such as TableID; NaN and
+//     infinities are rejected
+//   - time.Time: a quoted 'YYYY-MM-DD hh:mm:ss.ffffff' literal in the
+//     time's own location
+//   - driver.Valuer: the rendering of its value
+//   - other slices and arrays: their elements, separated by commas, for use
+//     in IN (...) lists
+//
+// Example:
+//
+//	stmt, err := sdk.BindSQL("SELECT * FROM shop.orders WHERE customer = ? AND id IN (?)",
+//		"O'Brien", []int64{1, 2, 3})
+//	// SELECT * FROM shop.orders WHERE customer = 'O\'Brien' AND id IN (1, 2, 3)
+func BindSQL(statement string, args ...any) (string, error) {
+	var b strings.Builder
+	b.Grow(len(statement) + 16*len(args))
+	next := 0
+
+	for i := 0; i < len(statement); i++ {
+		ch := statement[i]
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := skipQuoted(statement, i)
+			b.WriteString(statement[i:end])
+			i = end - 1
+		case ch == '-' && strings.HasPrefix(statement[i:], "--"), ch == '#':
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				end = len(statement) - i
+			}
+			b.WriteString(statement[i : i+end])
+			i += end - 1
+		case ch == '/' && strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				end = len(statement) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(statement[i : i+end])
+			i += end - 1
+		case ch == '?':
+			if next >= len(args) {
+				return "", fmt.Errorf("sdk: statement has more placeholders than the %d arguments given", len(args))
+			}
+			lit, err := sqlLiteral(args[next])
+			if err != nil {
+				return "", fmt.Errorf("sdk: argument %d: %w", next+1, err)
+			}
+			b.WriteString(lit)
+			next++
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	if next != len(args) {
+		return "", fmt.Errorf("sdk: statement has %d placeholders but %d arguments were given", next, len(args))
+	}
+	return b.String(), nil
+}
+
+// skipQuoted returns the index just past the quoted section starting at
+// start. Backslash escapes and doubled quotes are honoured.
+func skipQuoted(s string, start int) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// sqlLiteral renders v as a SQL literal.
+func sqlLiteral(v any) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return quoteSQLString(t), nil
+	case []byte:
+		if t == nil {
+			return "NULL", nil
+		}
+		return "X'" + hex.EncodeToString(t) + "'", nil
+	case time.Time:
+		return "'" + t.Format(sqlTimeFormat) + "'", nil
+	case driver.Valuer:
+		rv := reflect.ValueOf(t)
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "NULL", nil
+		}
+		value, err := t.Value()
+		if err != nil {
+			return "", err
+		}
+		return sqlLiteral(value)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return sqlLiteral(rv.Elem().Interface())
+	case reflect.String:
+		return quoteSQLString(rv.String()), nil
+	case reflect.Bool:
+		if rv.Bool() {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("cannot use %v as a SQL value", f)
+		}
+		return strconv.FormatFloat(f, 'g', -1, rv.Type().Bits()), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return sqlLiteral(rv.Bytes())
+		}
+		if rv.Len() == 0 {
+			return "", fmt.Errorf("cannot bind an empty %s", rv.Type())
+		}
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			lit, err := sqlLiteral(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			parts[i] = lit
+		}
+		return strings.Join(parts, ", "), nil
+	}
+	return "", fmt.Errorf("unsupported argument type %T", v)
+}
+
+// quoteSQLString quotes s as a single-quoted string literal, escaping the
+// characters MySQL-compatible servers treat specially.
+func quoteSQLString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case 0:
+			b.WriteString(`\0`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '"':
+			b.WriteString(`\"`)
+		case 0x1a:
+			b.WriteString(`\Z`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// splitSQLArgs separates the CallOptions mixed into the arguments of
+// QueryRows and RunSQLCursor from the statement parameters.
+func splitSQLArgs(args []any) (params []any, opts []CallOption) {
+	for _, a := range args {
+		switch t := a.(type) {
+		case CallOption:
+			opts = append(opts, t)
+		case []CallOption:
+			opts = append(opts, t...)
+		default:
+			params = append(params, a)
+		}
+	}
+	return params, opts
+}
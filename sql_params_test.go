@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testValuer struct{ v any }
+
+func (t testValuer) Value() (driver.Value, error) { return t.v, nil }
+
+func TestBindSQL(t *testing.T) {
+	t.Parallel()
+
+	name := "bob"
+	var nilPtr *string
+	ts := time.Date(2024, 3, 5, 7, 8, 9, 120000000, time.UTC)
+
+	tests := []struct {
+		name string
+		stmt string
+		args []any
+		want string
+	}{
+		{"string escaping", "SELECT * FROM db.t WHERE name = ?", []any{"O'Brien \\ \"x\"\n"}, `SELECT * FROM db.t WHERE name = 'O\'Brien \\ \"x\"\n'`},
+		{"numbers", "SELECT ?, ?, ?, ?", []any{42, int8(-3), uint(7), 1.5}, "SELECT 42, -3, 7, 1.5"},
+		{"named numeric type", "SELECT * FROM db.t WHERE id = ?", []any{TableID(9)}, "SELECT * FROM db.t WHERE id = 9"},
+		{"bool and nil", "SELECT ?, ?, ?", []any{true, nil, nilPtr}, "SELECT TRUE, NULL, NULL"},
+		{"pointer", "SELECT ?", []any{&name}, "SELECT 'bob'"},
+		{"time", "SELECT ?", []any{ts}, "SELECT '2024-03-05 07:08:09.12'"},
+		{"bytes", "SELECT ?", []any{[]byte{0xde, 0xad}}, "SELECT X'dead'"},
+		{"slice for IN", "SELECT * FROM db.t WHERE id IN (?)", []any{[]int64{1, 2, 3}}, "SELECT * FROM db.t WHERE id IN (1, 2, 3)"},
+		{"valuer", "SELECT ?", []any{testValuer{"v"}}, "SELECT 'v'"},
+		{"placeholders in literals and comments", "SELECT '?', \"a?\", `c?`, 'it''s ?' -- ?\n, ? /* ? */ # ?", []any{1}, "SELECT '?', \"a?\", `c?`, 'it''s ?' -- ?\n, 1 /* ? */ # ?"},
+		{"escaped quote in literal", `SELECT 'a\'?', ?`, []any{2}, `SELECT 'a\'?', 2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BindSQL(tt.stmt, tt.args...)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBindSQL_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := BindSQL("SELECT ?, ?", 1)
+	require.ErrorContains(t, err, "more placeholders")
+	_, err = BindSQL("SELECT ?", 1, 2)
+	require.ErrorContains(t, err, "1 placeholders but 2 arguments")
+	_, err = BindSQL("SELECT ?", math.NaN())
+	require.Error(t, err)
+	_, err = BindSQL("SELECT ?", []int{})
+	require.Error(t, err)
+	_, err = BindSQL("SELECT ?", map[string]int{"a": 1})
+	require.ErrorContains(t, err, "unsupported argument type")
+}
+
+func TestSDKClientRunSQL_BindsArgs(t *testing.T) {
+	t.Parallel()
+
+	var got NL2SQLRunSQLRequest
+	var requestID string
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			requestID = r.Header.Get(headerRequestID)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{{Columns: []string{"id"}, Rows: []NL2SQLRow{{"1"}}}}})
+		},
+	})
+	client := NewSDKClient(raw)
+
+	_, err := client.RunSQLArgs(context.Background(), "SELECT id FROM db.t WHERE name = ? AND id > ?",
+		[]any{"x' OR '1'='1", 10}, WithRequestID("req-sql"))
+	require.NoError(t, err)
+	require.Equal(t, `SELECT id FROM db.t WHERE name = 'x\' OR \'1\'=\'1' AND id > 10`, got.Statement)
+	require.Equal(t, "req-sql", requestID)
+
+	// Without arguments the statement is sent unchanged.
+	_, err = client.RunSQLArgs(context.Background(), "SELECT '?' FROM db.t WHERE a = ?", nil)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT '?' FROM db.t WHERE a = ?", got.Statement)
+
+	// RunSQL never binds: the '?' and the ? are sent as they are.
+	got = NL2SQLRunSQLRequest{}
+	_, err = client.RunSQL(context.Background(), "SELECT '?' FROM db.t WHERE a = ?", WithRequestID("req-plain"))
+	require.NoError(t, err)
+	require.Equal(t, "req-plain", requestID)
+	require.Equal(t, RunSQL, got.Operation)
+	require.Equal(t, "SELECT '?' FROM db.t WHERE a = ?", got.Statement)
+
+	var rows []struct {
+		ID int `db:"id"`
+	}
+	require.NoError(t, client.QueryRows(context.Background(), "SELECT id FROM db.t WHERE id = ?", &rows, 1))
+	require.Equal(t, "SELECT id FROM db.t WHERE id = 1", got.Statement)
+	require.Len(t, rows, 1)
+}
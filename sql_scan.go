@@ -0,0 +1,266 @@
+package sdk
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sqlTimeLayouts are the formats tried, in order, when scanning a value into
+// a time.Time field.
+var sqlTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// QueryRows runs statement with RunSQL and scans the rows of the first
+// result set into dest.
+//
+// dest must be a pointer to a slice of structs or of struct pointers. Columns
+// are matched to fields by the `db` struct tag, falling back to a
+// case-insensitive match on the field name; a tag of "-" skips the field.
+// Columns without a matching field are ignored. See NL2SQLResult.Scan for the
+// supported field types.
+//
+// Parameters:
+//   - ctx: context for the request
+//   - statement: the SQL statement to execute (required)
+//   - dest: pointer to the slice to fill (required)
+//   - args: values for the "?" placeholders of statement, see RunSQLArgs;
+//     CallOptions among them apply to the request
+//
+// Returns:
+//   - error: any error from running the statement or converting a value
+//
+// Example:
+//
+//	type order struct {
+//		ID      int64     `db:"id"`
+//		Amount  float64   `db:"amount"`
+//		Created time.Time `db:"created_at"`
+//		Note    *string   `db:"note"`
+//	}
+//	var orders []order
+//	err := sdkClient.QueryRows(ctx, "SELECT id, amount, created_at, note FROM shop.orders WHERE amount > ?", &orders, 100)
+func (c *SDKClient) QueryRows(ctx context.Context, statement string, dest any, args ...any) error {
+	params, opts := splitSQLArgs(args)
+	resp, err := c.RunSQLArgs(ctx, statement, params, opts...)
+	if err != nil {
+		return err
+	}
+	if len(resp.Results) == 0 {
+		return (&NL2SQLResult{}).Scan(dest)
+	}
+	return resp.Results[0].Scan(dest)
+}
+
+// Scan converts the rows of r into dest, which must be a pointer to a slice
+// of structs or of struct pointers. The slice is replaced with one element per
+// row.
+//
+// Because the service returns every value as a string, values are converted
+// to the field type: strings, []byte, bools, signed and unsigned integers,
+// floats, time.Time and types implementing encoding.TextUnmarshaler are
+// supported. Pointer fields are left nil when the value is empty or "NULL".
+func (r *NL2SQLResult) Scan(dest any) error {
+	return scanSQLRows(dest, r.Columns, len(r.Rows), func(row, col int) (string, bool, bool) {
+		if col >= len(r.Rows[row]) {
+			return "", false, false
+		}
+		s := r.Rows[row][col]
+		return s, s == "" || strings.EqualFold(s, "NULL"), true
+	})
+}
+
+// scanSQLRows converts rows values into dest, a pointer to a slice of structs
+// or of struct pointers. cell returns the text of a value, whether it is
+// NULL, and false when the row has no such column.
+func scanSQLRows(dest any, columns []string, rows int, cell func(row, col int) (s string, null, ok bool)) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("sdk: scan destination must be a non-nil pointer to a slice, got %T", dest)
+	}
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	isPtr := elemType.Kind() == reflect.Pointer
+	if isPtr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("sdk: scan destination element must be a struct, got %s", elemType)
+	}
+
+	fields := structFieldIndex(structType)
+	targets := make([][]int, len(columns))
+	for i, col := range columns {
+		targets[i] = fields[strings.ToLower(col)]
+	}
+
+	out := reflect.MakeSlice(slice.Type(), 0, rows)
+	for rowIdx := 0; rowIdx < rows; rowIdx++ {
+		item := reflect.New(structType).Elem()
+		for i, idx := range targets {
+			if idx == nil {
+				continue
+			}
+			s, null, ok := cell(rowIdx, i)
+			if !ok {
+				continue
+			}
+			if err := setSQLText(item.FieldByIndex(idx), s, null); err != nil {
+				return fmt.Errorf("sdk: scan row %d column %q: %w", rowIdx, columns[i], err)
+			}
+		}
+		if isPtr {
+			item = item.Addr()
+		}
+		out = reflect.Append(out, item)
+	}
+	slice.Set(out)
+	return nil
+}
+
+var structFieldCache sync.Map // map[reflect.Type]map[string][]int
+
+// structFieldIndex maps lower-cased column names to field indexes of t,
+// descending into embedded structs.
+func structFieldIndex(t reflect.Type) map[string][]int {
+	if cached, ok := structFieldCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	var walk func(t reflect.Type, prefix []int)
+	walk = func(t reflect.Type, prefix []int) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("db")
+			if tag == "-" {
+				continue
+			}
+			index := append(append([]int{}, prefix...), i)
+			if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+				walk(f.Type, index)
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			name := tag
+			if name == "" {
+				name = f.Name
+			}
+			name = strings.ToLower(name)
+			// Outer fields take precedence over promoted ones.
+			if existing, ok := fields[name]; !ok || len(existing) > len(index) {
+				fields[name] = index
+			}
+		}
+	}
+	walk(t, nil)
+	structFieldCache.Store(t, fields)
+	return fields
+}
+
+// setSQLValue converts the string s and stores it in v. Pointer fields are
+// set to nil when s is empty or "NULL".
+func setSQLValue(v reflect.Value, s string) error {
+	return setSQLText(v, s, s == "" || strings.EqualFold(s, "NULL"))
+}
+
+// setSQLText is setSQLValue for callers that know whether the value is NULL.
+func setSQLText(v reflect.Value, s string, null bool) error {
+	if v.Kind() == reflect.Pointer {
+		if null {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		ptr := reflect.New(v.Type().Elem())
+		if err := setSQLText(ptr.Elem(), s, false); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	if v.Type() == timeType {
+		if s == "" {
+			v.Set(reflect.Zero(timeType))
+			return nil
+		}
+		for _, layout := range sqlTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				v.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot parse %q as time", s)
+	}
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		switch strings.ToLower(s) {
+		case "1", "true", "t", "yes", "y":
+			v.SetBool(true)
+		case "", "0", "false", "f", "no", "n":
+			v.SetBool(false)
+		default:
+			return fmt.Errorf("cannot parse %q as bool", s)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if s == "" {
+			v.SetInt(0)
+			return nil
+		}
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if s == "" {
+			v.SetUint(0)
+			return nil
+		}
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if s == "" {
+			v.SetFloat(0)
+			return nil
+		}
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported field type %s", v.Type())
+		}
+		v.SetBytes([]byte(s))
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
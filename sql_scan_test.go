@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type scanBase struct {
+	ID int64 `db:"id"`
+}
+
+type scanRow struct {
+	scanBase
+	Name     string
+	Amount   float64   `db:"amount"`
+	Active   bool      `db:"is_active"`
+	Created  time.Time `db:"created_at"`
+	Note     *string   `db:"note"`
+	Count    *uint16   `db:"cnt"`
+	Ignored  string    `db:"-"`
+	internal string
+}
+
+func TestNL2SQLResultScan(t *testing.T) {
+	t.Parallel()
+	result := &NL2SQLResult{
+		Columns: []string{"id", "NAME", "amount", "is_active", "created_at", "note", "cnt", "extra"},
+		Rows: []NL2SQLRow{
+			{"1", "alice", "12.5", "1", "2024-03-01 10:20:30", "hello", "7", "x"},
+			{"2", "bob", "0", "false", "2024-03-02", "NULL", "", "y"},
+		},
+	}
+
+	var rows []scanRow
+	require.NoError(t, result.Scan(&rows))
+	require.Len(t, rows, 2)
+
+	require.Equal(t, int64(1), rows[0].ID)
+	require.Equal(t, "alice", rows[0].Name)
+	require.Equal(t, 12.5, rows[0].Amount)
+	require.True(t, rows[0].Active)
+	require.Equal(t, time.Date(2024, 3, 1, 10, 20, 30, 0, time.UTC), rows[0].Created)
+	require.NotNil(t, rows[0].Note)
+	require.Equal(t, "hello", *rows[0].Note)
+	require.Equal(t, uint16(7), *rows[0].Count)
+
+	require.False(t, rows[1].Active)
+	require.Nil(t, rows[1].Note)
+	require.Nil(t, rows[1].Count)
+
+	var ptrs []*scanRow
+	require.NoError(t, result.Scan(&ptrs))
+	require.Len(t, ptrs, 2)
+	require.Equal(t, "bob", ptrs[1].Name)
+}
+
+func TestNL2SQLResultScan_Errors(t *testing.T) {
+	t.Parallel()
+	result := &NL2SQLResult{Columns: []string{"id"}, Rows: []NL2SQLRow{{"abc"}}}
+
+	var rows []scanRow
+	err := result.Scan(&rows)
+	require.ErrorContains(t, err, `column "id"`)
+
+	require.Error(t, result.Scan(rows))
+	var ints []int
+	require.Error(t, result.Scan(&ints))
+}
+
+func TestSDKClientQueryRows(t *testing.T) {
+	t.Parallel()
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{{
+				Columns: []string{"id", "name"},
+				Rows:    []NL2SQLRow{{"3", "carol"}},
+			}}})
+		},
+	})
+
+	var rows []scanRow
+	err := NewSDKClient(raw).QueryRows(context.Background(), "SELECT id, name FROM db.t", &rows)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(3), rows[0].ID)
+	require.Equal(t, "carol", rows[0].Name)
+
+	err = NewSDKClient(raw).QueryRows(context.Background(), " ", &rows)
+	require.ErrorContains(t, err, "statement is required")
+}
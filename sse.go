@@ -0,0 +1,450 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSSEBufferSize     = 4096
+	defaultSSEMaxReconnects  = 3
+	defaultSSEReconnectDelay = time.Second
+	headerLastEventID        = "Last-Event-ID"
+)
+
+// ErrSkipEvent may be returned by an SSEDecoder to drop an event instead of
+// handing it to the caller.
+var ErrSkipEvent = errors.New("sdk: skip event")
+
+// SSEEvent is a single server-sent event as read from the wire.
+type SSEEvent struct {
+	// ID is the last event ID seen on the stream, which the event inherits
+	// when it carries no "id" field of its own.
+	ID string
+	// Event is the value of the "event" field; empty for unnamed events.
+	Event string
+	// Data holds the "data" lines of the event joined with "\n".
+	Data []byte
+}
+
+// SSEDecoder converts a raw event into a value of type T.
+type SSEDecoder[T any] func(event *SSEEvent) (T, error)
+
+// SSEReconnectFunc reopens a stream after a transient read failure.
+// lastEventID is the ID of the last event received, or empty if the server
+// has not sent any; it should be sent in the Last-Event-ID request header so
+// that the server can resume after it.
+type SSEReconnectFunc func(ctx context.Context, lastEventID string) (*http.Response, error)
+
+// SSEOptions configures an SSEStream. The zero value reads the stream with
+// default buffering, no read timeout and no reconnection.
+type SSEOptions struct {
+	// InitialBufferSize is the initial size of the line buffer. Lines longer
+	// than the buffer are still read in full. Defaults to 4KB.
+	InitialBufferSize int
+	// ReadTimeout fails a read when no data arrives within the interval.
+	// Zero disables the timeout.
+	ReadTimeout time.Duration
+	// HeartbeatEvents lists event names that are dropped without being
+	// decoded, such as "ping". Comment lines are always dropped.
+	HeartbeatEvents []string
+	// Reconnect, when set, is called to reopen the stream after a read
+	// error other than io.EOF.
+	Reconnect SSEReconnectFunc
+	// MaxReconnects bounds the consecutive reconnection attempts made
+	// without receiving an event. Defaults to 3.
+	MaxReconnects int
+	// ReconnectDelay is the wait before each reconnection attempt, unless
+	// the server sets one with a "retry" field. Defaults to 1s.
+	ReconnectDelay time.Duration
+	// OnReconnect, when set, is called before each reconnection attempt.
+	OnReconnect StreamResumeFunc
+}
+
+// SSEStream reads server-sent events from a streaming HTTP response and
+// decodes them into values of type T.
+//
+// Example:
+//
+//	stream := sdk.NewSSEStream(ctx, resp, func(ev *sdk.SSEEvent) (MyEvent, error) {
+//		var v MyEvent
+//		err := json.Unmarshal(ev.Data, &v)
+//		return v, err
+//	}, &sdk.SSEOptions{HeartbeatEvents: []string{"ping"}})
+//	defer stream.Close()
+//	for {
+//		v, err := stream.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(v)
+//	}
+type SSEStream[T any] struct {
+	// Header contains the HTTP response headers of the current connection.
+	Header http.Header
+	// StatusCode is the HTTP status code of the current connection.
+	StatusCode int
+
+	ctx     context.Context
+	decode  SSEDecoder[T]
+	opts    SSEOptions
+	reader  *bufio.Reader
+	lastID  string
+	retry   time.Duration
+	attempt int
+
+	mu     sync.Mutex
+	body   io.ReadCloser
+	closed bool
+}
+
+// NewSSEStream wraps resp, whose body must be an event stream, in an
+// SSEStream. opts may be nil. ctx bounds reconnection attempts.
+func NewSSEStream[T any](ctx context.Context, resp *http.Response, decode SSEDecoder[T], opts *SSEOptions) *SSEStream[T] {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	s := &SSEStream[T]{ctx: ctx, decode: decode}
+	if opts != nil {
+		s.opts = *opts
+	}
+	if s.opts.MaxReconnects <= 0 {
+		s.opts.MaxReconnects = defaultSSEMaxReconnects
+	}
+	if s.opts.ReconnectDelay <= 0 {
+		s.opts.ReconnectDelay = defaultSSEReconnectDelay
+	}
+	s.setResponse(resp)
+	return s
+}
+
+// Next returns the next decoded event. It returns io.EOF once the server
+// ends the stream.
+func (s *SSEStream[T]) Next() (T, error) {
+	var zero T
+	for {
+		event, err := s.readEvent()
+		if err != nil {
+			if err == io.EOF {
+				return zero, io.EOF
+			}
+			if s.reconnect(err) {
+				continue
+			}
+			return zero, fmt.Errorf("read stream: %w", err)
+		}
+		s.attempt = 0
+		if s.isHeartbeat(event.Event) {
+			continue
+		}
+		v, err := s.decode(event)
+		if errors.Is(err, ErrSkipEvent) {
+			continue
+		}
+		if err != nil {
+			return zero, err
+		}
+		return v, nil
+	}
+}
+
+// LastEventID returns the ID of the last event received, or empty if the
+// server has not sent event IDs.
+func (s *SSEStream[T]) LastEventID() string {
+	return s.lastID
+}
+
+// Close releases the underlying HTTP response body. It is safe to call
+// from another goroutine to abort a blocked Next.
+func (s *SSEStream[T]) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.body == nil {
+		return nil
+	}
+	return s.body.Close()
+}
+
+func (s *SSEStream[T]) setResponse(resp *http.Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if resp == nil {
+		s.body = nil
+		return
+	}
+	s.body = resp.Body
+	s.Header = resp.Header
+	s.StatusCode = resp.StatusCode
+	s.reader = nil
+}
+
+func (s *SSEStream[T]) isHeartbeat(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, h := range s.opts.HeartbeatEvents {
+		if h == name {
+			return true
+		}
+	}
+	return false
+}
+
+// reconnect reopens the stream after the read error cause, reporting
+// whether reading can continue.
+func (s *SSEStream[T]) reconnect(cause error) bool {
+	if s.opts.Reconnect == nil {
+		return false
+	}
+	for s.attempt < s.opts.MaxReconnects {
+		s.mu.Lock()
+		closed := s.closed
+		if s.body != nil {
+			s.body.Close()
+		}
+		s.mu.Unlock()
+		if closed || s.ctx.Err() != nil {
+			return false
+		}
+		s.attempt++
+
+		delay := s.opts.ReconnectDelay
+		if s.retry > 0 {
+			delay = s.retry
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+
+		if s.opts.OnReconnect != nil {
+			s.opts.OnReconnect(s.attempt, s.lastID, cause)
+		}
+		resp, err := s.opts.Reconnect(s.ctx, s.lastID)
+		if err != nil {
+			cause = err
+			continue
+		}
+		s.setResponse(resp)
+		return true
+	}
+	return false
+}
+
+// readEvent reads lines up to the end of the next event that carries data.
+// A final event that is not followed by a blank line is still returned.
+func (s *SSEStream[T]) readEvent() (*SSEEvent, error) {
+	var data []string
+	var name string
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			if err == io.EOF && len(data) > 0 {
+				return &SSEEvent{ID: s.lastID, Event: name, Data: []byte(strings.Join(data, "\n"))}, nil
+			}
+			return nil, err
+		}
+
+		if line == "" {
+			// An empty line dispatches the event; events without data are
+			// dropped.
+			if len(data) > 0 {
+				return &SSEEvent{ID: s.lastID, Event: name, Data: []byte(strings.Join(data, "\n"))}, nil
+			}
+			name = ""
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // comment, commonly used as a keep-alive
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "data":
+			data = append(data, value)
+		case "event":
+			name = value
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
+
+// readLine reads a line from the body, growing the buffer as needed so that
+// lines of any length are returned in full. The read timeout, if any, is
+// reset each time data is successfully read.
+func (s *SSEStream[T]) readLine() (string, error) {
+	if s.reader == nil {
+		s.mu.Lock()
+		body := s.body
+		s.mu.Unlock()
+		if body == nil {
+			return "", io.EOF
+		}
+		bufferSize := s.opts.InitialBufferSize
+		if bufferSize <= 0 {
+			bufferSize = defaultSSEBufferSize
+		}
+		var r io.Reader = body
+		if s.opts.ReadTimeout > 0 {
+			r = newTimeoutReader(body, s.opts.ReadTimeout)
+		}
+		s.reader = bufio.NewReaderSize(r, bufferSize)
+	}
+
+	var line []byte
+	for {
+		part, isPrefix, err := s.reader.ReadLine()
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				return string(line), nil
+			}
+			return "", err
+		}
+		line = append(line, part...)
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+// timeoutReader wraps an io.ReadCloser and provides timeout control that resets on each successful read.
+// The timeout is applied to the interval between reads, not the total read time.
+type timeoutReader struct {
+	reader    io.ReadCloser
+	timeout   time.Duration
+	readMutex chan struct{} // Serializes read operations
+}
+
+func newTimeoutReader(reader io.ReadCloser, timeout time.Duration) *timeoutReader {
+	return &timeoutReader{
+		reader:    reader,
+		timeout:   timeout,
+		readMutex: make(chan struct{}, 1),
+	}
+}
+
+func (r *timeoutReader) Read(p []byte) (n int, err error) {
+	// Serialize reads to ensure timeout is properly reset
+	r.readMutex <- struct{}{}
+	defer func() { <-r.readMutex }()
+
+	if r.timeout <= 0 {
+		// No timeout, read directly
+		return r.reader.Read(p)
+	}
+
+	// Create a context with timeout for this read operation
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	// Use a channel to receive the read result
+	type result struct {
+		n   int
+		err error
+	}
+	resultCh := make(chan result, 1)
+
+	// Perform the read in a goroutine
+	// Note: The read operation itself is thread-safe, as io.ReadCloser implementations
+	// should handle concurrent reads appropriately, or we serialize them via readMutex
+	go func() {
+		// Create a local buffer to avoid potential race conditions
+		// We'll read into a buffer and then copy to p
+		buf := make([]byte, len(p))
+		n, err := r.reader.Read(buf)
+		if n > 0 {
+			copy(p, buf[:n])
+		}
+		resultCh <- result{n: n, err: err}
+	}()
+
+	// Wait for either the read to complete or the timeout
+	select {
+	case res := <-resultCh:
+		// Read completed successfully - timeout is effectively reset for the next read
+		return res.n, res.err
+	case <-ctx.Done():
+		// Timeout - no data received within the timeout period
+		return 0, fmt.Errorf("read timeout: no data received within %v", r.timeout)
+	}
+}
+
+func (r *timeoutReader) Close() error {
+	if r.reader != nil {
+		return r.reader.Close()
+	}
+	return nil
+}
+
+// sseOptions returns the SSEOptions for a stream opened with these call
+// options. reconnect is used only if auto-reconnect is enabled.
+func (co callOptions) sseOptions(reconnect SSEReconnectFunc) *SSEOptions {
+	opts := &SSEOptions{
+		InitialBufferSize: co.streamBufferSize,
+		ReadTimeout:       co.streamReadTimeout,
+		MaxReconnects:     co.streamReconnects,
+		ReconnectDelay:    co.streamRetryDelay,
+		OnReconnect:       co.streamResume,
+	}
+	if co.streamReconnects > 0 {
+		opts.Reconnect = reconnect
+	}
+	return opts
+}
+
+// openEventStream sends a request that expects a server-sent event stream
+// and checks that the response is one. lastEventID, when not empty, is sent
+// in the Last-Event-ID header so that the server can resume the stream.
+func (c *RawClient) openEventStream(ctx context.Context, method, path string, payload []byte, opts callOptions, lastEventID string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	resp, err := c.doStream(ctx, method, path, body, opts, func(r *http.Request) {
+		if payload != nil {
+			r.Header.Set(headerContentType, mimeJSON)
+		}
+		r.Header.Set(headerAccept, "text/event-stream")
+		if lastEventID != "" {
+			r.Header.Set(headerLastEventID, lastEventID)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	contentType := resp.Header.Get(headerContentType)
+	if !strings.Contains(contentType, "text/event-stream") && !strings.Contains(contentType, "text/plain") {
+		// Not a streaming response, try to parse as error
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected content type: %s, body: %s", contentType, string(data))
+	}
+	return resp, nil
+}
@@ -0,0 +1,255 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSSEResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{headerContentType: []string{"text/event-stream"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func decodeSSEString(ev *SSEEvent) (string, error) {
+	return ev.Event + "=" + string(ev.Data), nil
+}
+
+func TestSSEStream_Parsing(t *testing.T) {
+	t.Parallel()
+
+	body := ": keep-alive\n" +
+		"event: ping\ndata: {}\n\n" +
+		"id: 1\nretry: 250\nevent: a\ndata:first\n\n" +
+		"event: b\ndata: line1\ndata: line2\n\n" +
+		"event: empty\n\n" +
+		"data: last"
+	stream := NewSSEStream(context.Background(), newTestSSEResponse(body), decodeSSEString,
+		&SSEOptions{HeartbeatEvents: []string{"ping"}})
+	defer stream.Close()
+
+	v, err := stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, "a=first", v)
+	require.Equal(t, "1", stream.LastEventID())
+	require.Equal(t, 250*time.Millisecond, stream.retry)
+
+	v, err = stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, "b=line1\nline2", v)
+
+	// The event without data is dropped and the final event is returned
+	// even without a trailing blank line.
+	v, err = stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, "=last", v)
+
+	_, err = stream.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestSSEStream_DecoderSkipAndError(t *testing.T) {
+	t.Parallel()
+
+	body := "event: skip\ndata: x\n\nevent: keep\ndata: y\n\nevent: bad\ndata: z\n\n"
+	decode := func(ev *SSEEvent) (string, error) {
+		switch ev.Event {
+		case "skip":
+			return "", ErrSkipEvent
+		case "bad":
+			return "", errors.New("bad event")
+		}
+		return string(ev.Data), nil
+	}
+	stream := NewSSEStream(context.Background(), newTestSSEResponse(body), decode, nil)
+	defer stream.Close()
+
+	v, err := stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, "y", v)
+
+	_, err = stream.Next()
+	require.EqualError(t, err, "bad event")
+}
+
+// failingReader returns data and then a non-EOF error, simulating a dropped
+// connection.
+type failingReader struct {
+	r io.Reader
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset")
+	}
+	return n, err
+}
+
+func (f *failingReader) Close() error { return nil }
+
+func TestSSEStream_ReconnectWithLastEventID(t *testing.T) {
+	t.Parallel()
+
+	first := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       &failingReader{r: strings.NewReader("id: 7\ndata: one\n\ndata: partial")},
+	}
+	var gotLastID string
+	stream := NewSSEStream(context.Background(), first, decodeSSEString, &SSEOptions{
+		ReconnectDelay: time.Millisecond,
+		Reconnect: func(ctx context.Context, lastEventID string) (*http.Response, error) {
+			gotLastID = lastEventID
+			return newTestSSEResponse("id: 8\ndata: two\n\n"), nil
+		},
+	})
+	defer stream.Close()
+
+	v, err := stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, "=one", v)
+
+	// The partial event is discarded and reading resumes on the new
+	// connection.
+	v, err = stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, "=two", v)
+	require.Equal(t, "7", gotLastID)
+	require.Equal(t, "8", stream.LastEventID())
+
+	_, err = stream.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestSSEStream_ReconnectGivesUp(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	first := &http.Response{StatusCode: http.StatusOK, Body: &failingReader{r: strings.NewReader("")}}
+	stream := NewSSEStream(context.Background(), first, decodeSSEString, &SSEOptions{
+		MaxReconnects:  2,
+		ReconnectDelay: time.Millisecond,
+		Reconnect: func(ctx context.Context, lastEventID string) (*http.Response, error) {
+			atomic.AddInt32(&attempts, 1)
+			return nil, errors.New("unavailable")
+		},
+	})
+
+	_, err := stream.Next()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "connection reset")
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestStreamWorkflowJobEvents_ResumesAfterDisconnect(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/workflow_job/events": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerContentType, "text/event-stream")
+			if atomic.AddInt32(&calls, 1) == 1 {
+				require.Empty(t, r.Header.Get(headerLastEventID))
+				w.Header().Set("Content-Length", "1000")
+				fmt.Fprint(w, "retry: 1\nid: e1\ndata: {\"job_id\":\"job-1\",\"status\":1}\n\n")
+				return // short body: the client sees an unexpected EOF
+			}
+			require.Equal(t, "e1", r.Header.Get(headerLastEventID))
+			payload, _ := json.Marshal(WorkflowJobEvent{JobID: "job-1", Status: WorkflowJobStatusCompleted})
+			fmt.Fprintf(w, "id: e2\ndata: %s\n\n", payload)
+		},
+	})
+
+	stream, err := client.StreamWorkflowJobEvents(context.Background(), "wf-1", "file-1")
+	require.NoError(t, err)
+	defer stream.Close()
+
+	event, err := stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, WorkflowJobStatusRunning, event.Status)
+
+	event, err = stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, WorkflowJobStatusCompleted, event.Status)
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestAnalyzeDataStream_AutoReconnect(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/analyze": func(w http.ResponseWriter, r *http.Request) {
+			var req DataAnalysisRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "why?", req.Question)
+			w.Header().Set(headerContentType, "text/event-stream")
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("Content-Length", "1000")
+				fmt.Fprint(w, "id: 1\nevent: classification\ndata: {\"type\":\"classification\"}\n\n")
+				return // connection drops mid-stream
+			}
+			require.Equal(t, "1", r.Header.Get(headerLastEventID))
+			fmt.Fprint(w, "id: 2\nevent: complete\ndata: {\"type\":\"complete\"}\n\n")
+		},
+	})
+
+	var resumed []string
+	stream, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "why?"},
+		WithStreamAutoReconnect(2, time.Millisecond),
+		WithStreamResumeCallback(func(attempt int, lastEventID string, cause error) {
+			require.Error(t, cause)
+			resumed = append(resumed, fmt.Sprintf("%d:%s", attempt, lastEventID))
+		}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	event, err := stream.ReadEvent()
+	require.NoError(t, err)
+	require.Equal(t, "classification", event.Type)
+
+	event, err = stream.ReadEvent()
+	require.NoError(t, err)
+	require.Equal(t, "complete", event.Type)
+	require.Equal(t, []string{"1:1"}, resumed)
+
+	_, err = stream.ReadEvent()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestAnalyzeDataStream_NoReconnectByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/data_asking/analyze": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set(headerContentType, "text/event-stream")
+			w.Header().Set("Content-Length", "1000")
+			fmt.Fprint(w, "id: 1\ndata: {\"type\":\"classification\"}\n\n")
+		},
+	})
+
+	stream, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "why?"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	_, err = stream.ReadEvent()
+	require.NoError(t, err)
+	_, err = stream.ReadEvent()
+	require.Error(t, err)
+	require.NotErrorIs(t, err, io.EOF)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
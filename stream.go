@@ -30,6 +30,47 @@ type FileStream struct {
 	StatusCode int
 }
 
+// ProgressFunc reports the progress of a transfer.
+//
+// transferred is the number of bytes moved so far and total is the expected
+// size in bytes, or -1 when the server did not announce a Content-Length.
+type ProgressFunc func(transferred, total int64)
+
+// newFileStream wraps resp in a FileStream, reporting read progress to the
+// callback registered with WithProgress, if any.
+func newFileStream(resp *http.Response, opts callOptions) *FileStream {
+	body := resp.Body
+	if opts.progress != nil {
+		body = &progressReader{rc: body, total: resp.ContentLength, fn: opts.progress}
+	}
+	return &FileStream{
+		Body:       body,
+		Header:     resp.Header.Clone(),
+		StatusCode: resp.StatusCode,
+	}
+}
+
+// progressReader counts the bytes read through it.
+type progressReader struct {
+	rc          io.ReadCloser
+	transferred int64
+	total       int64
+	fn          ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	if n > 0 {
+		r.transferred += int64(n)
+		r.fn(r.transferred, r.total)
+	}
+	return n, err
+}
+
+func (r *progressReader) Close() error {
+	return r.rc.Close()
+}
+
 // Close releases the underlying HTTP response body.
 //
 // This should always be called when done with the FileStream to prevent
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"fmt"
 )
 
 // CreateTable creates a new table in the specified database.
@@ -23,6 +24,13 @@ func (c *RawClient) CreateTable(ctx context.Context, req *TableCreateRequest, op
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := withNormalizedName(c, ResourceTable, req, func(r *TableCreateRequest) *string { return &r.Name })
+	if err != nil {
+		return nil, err
+	}
 	var resp TableCreateResponse
 	if err := c.postJSON(ctx, "/catalog/table/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -47,6 +55,9 @@ func (c *RawClient) GetTable(ctx context.Context, req *TableInfoRequest, opts ..
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp TableInfoResponse
 	if err := c.postJSON(ctx, "/catalog/table/info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -80,6 +91,9 @@ func (c *RawClient) GetMultiTable(ctx context.Context, req *MultiTableInfoReques
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp MultiTableInfoResponse
 	if err := c.postJSON(ctx, "/catalog/table/multi_info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -128,6 +142,9 @@ func (c *RawClient) CheckTableExists(ctx context.Context, req *TableExistRequest
 	if req == nil {
 		return false, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return false, err
+	}
 	var exists bool
 	if err := c.postJSON(ctx, "/catalog/table/exist", req, &exists, opts...); err != nil {
 		return false, err
@@ -140,22 +157,49 @@ func (c *RawClient) CheckTableExists(ctx context.Context, req *TableExistRequest
 // Returns a preview of the table data with limited rows.
 // This method internally uses GetTableData to fetch the data.
 //
+// When Columns, Where or Offset is set, the preview is read with a SQL
+// query instead, "SELECT columns FROM db.table WHERE ... LIMIT lines OFFSET
+// offset", ordered by the primary key when the table has one, so that only
+// the selected columns and rows are transferred.
+//
+// Either way the values are decoded according to the column types: integers
+// as int64 (uint64 when unsigned), floating-point numbers as float64,
+// booleans as bool, NULL as nil and everything else as string. A SQL query
+// returns NULL as the text "NULL", so in the second form a text column holding
+// NULL reads as "NULL" rather than nil.
+//
 // Example:
 //
 //	resp, err := client.PreviewTable(ctx, &sdk.TablePreviewRequest{
 //		TableID: 456,
 //		Lines:   10,
 //	})
+//
+// Example - Preview two columns of the large orders only:
+//
+//	resp, err := client.PreviewTable(ctx, &sdk.TablePreviewRequest{
+//		TableID:   456,
+//		Lines:     20,
+//		Columns:   []string{"id", "amount"},
+//		Where:     "amount > ?",
+//		WhereArgs: []any{1000},
+//	})
 func (c *RawClient) PreviewTable(ctx context.Context, req *TablePreviewRequest, opts ...CallOption) (*TablePreviewResponse, error) {
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 
 	// Convert TablePreviewRequest to GetTableDataRequest
 	pageSize := req.Lines
 	if pageSize <= 0 {
 		pageSize = 10 // Default preview size
 	}
+	if len(req.Columns) > 0 || !blank(req.Where) || req.Offset > 0 {
+		return c.previewTableSQL(ctx, req, pageSize, opts)
+	}
 
 	dataReq := &GetTableDataRequest{
 		TableID:  req.TableID,
@@ -175,6 +219,7 @@ func (c *RawClient) PreviewTable(ctx context.Context, req *TablePreviewRequest,
 	if len(previewData) > pageSize {
 		previewData = previewData[:pageSize]
 	}
+	decodePreviewData(dataResp.Columns, previewData)
 
 	return &TablePreviewResponse{
 		Columns: dataResp.Columns,
@@ -202,6 +247,9 @@ func (c *RawClient) GetTableData(ctx context.Context, req *GetTableDataRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp GetTableDataResponse
 	if err := c.postJSON(ctx, "/catalog/table/data", req, &resp, opts...); err != nil {
 		return nil, err
@@ -222,6 +270,9 @@ func (c *RawClient) LoadTable(ctx context.Context, req *TableLoadRequest, opts .
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp TableLoadResponse
 	if err := c.postJSON(ctx, "/catalog/table/load", req, &resp, opts...); err != nil {
 		return nil, err
@@ -229,6 +280,33 @@ func (c *RawClient) LoadTable(ctx context.Context, req *TableLoadRequest, opts .
 	return &resp, nil
 }
 
+// InsertTableRows writes rows directly into the table.
+//
+// Each row maps column names to values; columns missing from a row get their
+// default value. Unlike LoadTable, no data file has to be uploaded first.
+//
+// Example:
+//
+//	resp, err := client.InsertTableRows(ctx, 456, []map[string]any{
+//		{"id": 1, "name": "alice"},
+//		{"id": 2, "name": "bob"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Inserted %d rows\n", resp.Inserted)
+func (c *RawClient) InsertTableRows(ctx context.Context, tableID TableID, rows []map[string]any, opts ...CallOption) (*TableInsertRowsResponse, error) {
+	req := &TableInsertRowsRequest{TableID: tableID, Rows: rows}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp TableInsertRowsResponse
+	if err := c.postJSON(ctx, "/catalog/table/insert", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetTableDownloadLink retrieves a download link for the table data.
 //
 // The link is a signed URL that can be used to download the table data.
@@ -246,6 +324,9 @@ func (c *RawClient) GetTableDownloadLink(ctx context.Context, req *TableDownload
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp TableDownloadResponse
 	if err := c.postJSON(ctx, "/catalog/table/download", req, &resp, opts...); err != nil {
 		return nil, err
@@ -266,6 +347,9 @@ func (c *RawClient) TruncateTable(ctx context.Context, req *TableTruncateRequest
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp TableTruncateResponse
 	if err := c.postJSON(ctx, "/catalog/table/truncate", req, &resp, opts...); err != nil {
 		return nil, err
@@ -273,6 +357,84 @@ func (c *RawClient) TruncateTable(ctx context.Context, req *TableTruncateRequest
 	return &resp, nil
 }
 
+// AlterTable changes the schema of the table.
+//
+// The alterations are applied in order and require the alter table
+// privilege (PrivID_AlterTable). Columns can be added, dropped, renamed or
+// redefined, and the table comment can be changed.
+//
+// Example:
+//
+//	_, err := client.AlterTable(ctx, &sdk.TableAlterRequest{
+//		TableID: 456,
+//		Alterations: []sdk.TableAlteration{
+//			sdk.AlterAddColumn(sdk.Column{Name: "email", Type: "varchar(255)"}),
+//			sdk.AlterRenameColumn("name", "full_name"),
+//			sdk.AlterModifyColumn(sdk.Column{Name: "age", Type: "bigint"}),
+//			sdk.AlterDropColumn("legacy"),
+//			sdk.AlterTableComment("customer master data"),
+//		},
+//	})
+func (c *RawClient) AlterTable(ctx context.Context, req *TableAlterRequest, opts ...CallOption) (*TableAlterResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp TableAlterResponse
+	if err := c.postJSON(ctx, "/catalog/table/alter", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (a TableAlteration) validate() error {
+	switch a.Op {
+	case TableAlterOpAddColumn, TableAlterOpModifyColumn:
+		if a.Column == nil || a.Column.Name == "" || a.Column.Type == "" {
+			return fmt.Errorf("%s needs a column name and type", a.Op)
+		}
+	case TableAlterOpDropColumn:
+		if a.Name == "" {
+			return fmt.Errorf("%s needs a column name", a.Op)
+		}
+	case TableAlterOpRenameColumn:
+		if a.Name == "" || a.NewName == "" {
+			return fmt.Errorf("%s needs the old and new column names", a.Op)
+		}
+	case TableAlterOpComment:
+	default:
+		return fmt.Errorf("unknown operation %q", a.Op)
+	}
+	return nil
+}
+
+// RenameTable renames the specified table. The columns and data of the
+// table are not changed. SDKClient.RenameTable also checks that the new
+// name is free first.
+//
+// Example:
+//
+//	_, err := client.RenameTable(ctx, &sdk.TableRenameRequest{
+//		TableID: 456,
+//		Name:    "customers_v2",
+//	})
+func (c *RawClient) RenameTable(ctx context.Context, req *TableRenameRequest, opts ...CallOption) (*TableRenameResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp TableRenameResponse
+	if err := c.postJSON(ctx, "/catalog/table/rename", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	c.forgetResolved(ResolvedPath{TableID: req.TableID})
+	return &resp, nil
+}
+
 // DeleteTable deletes the specified table.
 //
 // This operation will permanently delete the table and all its data.
@@ -286,10 +448,14 @@ func (c *RawClient) DeleteTable(ctx context.Context, req *TableDeleteRequest, op
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp TableDeleteResponse
 	if err := c.postJSON(ctx, "/catalog/table/delete", req, &resp, opts...); err != nil {
 		return nil, err
 	}
+	c.forgetResolved(ResolvedPath{TableID: req.TableID})
 	return &resp, nil
 }
 
@@ -310,6 +476,9 @@ func (c *RawClient) GetTableFullPath(ctx context.Context, req *TableFullPathRequ
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp TableFullPathResponse
 	if err := c.postJSON(ctx, "/catalog/table/full_path", req, &resp, opts...); err != nil {
 		return nil, err
@@ -330,6 +499,9 @@ func (c *RawClient) GetTableRefList(ctx context.Context, req *TableRefListReques
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp TableRefListResponse
 	if err := c.postJSON(ctx, "/catalog/table/ref_list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -0,0 +1,179 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const defaultCreateTablesConcurrency = 8
+
+// TableCreateSpec describes one table to create with CreateTables.
+type TableCreateSpec struct {
+	Name    string
+	Columns []Column
+	Comment string
+}
+
+// CreateTablesOptions configures CreateTables.
+type CreateTablesOptions struct {
+	// Concurrency is the maximum number of tables created at the same time.
+	// Defaults to 8.
+	Concurrency int
+	// RollbackOnError deletes the tables that were created successfully when
+	// at least one table fails, so the database is left unchanged.
+	RollbackOnError bool
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// TableCreateResult is the outcome of creating one table in CreateTables.
+type TableCreateResult struct {
+	Name    string
+	TableID TableID
+	// Err is the creation error, or nil if the table was created.
+	Err error
+	// RolledBack is true if the table was created and then deleted again
+	// because RollbackOnError was set and another table failed.
+	RolledBack bool
+	// RollbackErr is the error returned while deleting the table during
+	// rollback, if any.
+	RollbackErr error
+}
+
+// CreateTablesError is returned by CreateTables when at least one table could
+// not be created. Results holds the per-table outcome.
+type CreateTablesError struct {
+	Failed  int
+	Total   int
+	Results []TableCreateResult
+}
+
+func (e *CreateTablesError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "sdk: %d of %d tables failed to create", e.Failed, e.Total)
+	for _, r := range e.Results {
+		if r.Err != nil {
+			fmt.Fprintf(&b, "; %s: %v", r.Name, r.Err)
+		}
+	}
+	return b.String()
+}
+
+// CreateTables creates many tables in one database concurrently.
+//
+// Tables are created by a bounded pool of workers. The returned results are
+// in the same order as specs and report the table ID or the error of every
+// table. If any table fails, the error is a *CreateTablesError; with
+// RollbackOnError set, the tables that were created are deleted again.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - databaseID: the database to create the tables in (required)
+//   - specs: the tables to create (required, names must be unique)
+//   - opts: optional settings; nil uses the defaults
+//
+// Returns:
+//   - []TableCreateResult: the outcome for each spec, in order
+//   - error: nil if every table was created, otherwise a *CreateTablesError
+//
+// Example:
+//
+//	results, err := sdkClient.CreateTables(ctx, dbID, []sdk.TableCreateSpec{
+//		{Name: "orders", Columns: []sdk.Column{{Name: "id", Type: "int", IsPk: true}}},
+//		{Name: "customers", Columns: []sdk.Column{{Name: "id", Type: "int", IsPk: true}}},
+//	}, &sdk.CreateTablesOptions{Concurrency: 16, RollbackOnError: true})
+//	var bulkErr *sdk.CreateTablesError
+//	if errors.As(err, &bulkErr) {
+//		for _, r := range bulkErr.Results {
+//			if r.Err != nil {
+//				log.Printf("%s: %v", r.Name, r.Err)
+//			}
+//		}
+//	}
+func (c *SDKClient) CreateTables(ctx context.Context, databaseID DatabaseID, specs []TableCreateSpec, opts *CreateTablesOptions) ([]TableCreateResult, error) {
+	if databaseID == 0 {
+		return nil, fmt.Errorf("database_id is required")
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("at least one table spec is required")
+	}
+	seen := make(map[string]bool, len(specs))
+	for i, spec := range specs {
+		name := strings.TrimSpace(spec.Name)
+		if name == "" {
+			return nil, fmt.Errorf("specs[%d]: table name is required", i)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("specs[%d]: duplicate table name %q", i, name)
+		}
+		seen[name] = true
+	}
+
+	var cfg CreateTablesOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultCreateTablesConcurrency
+	}
+
+	results := make([]TableCreateResult, len(specs))
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i, spec := range specs {
+		results[i].Name = spec.Name
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int, spec TableCreateSpec) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := c.raw.CreateTable(ctx, &TableCreateRequest{
+				DatabaseID: databaseID,
+				Name:       spec.Name,
+				Columns:    spec.Columns,
+				Comment:    spec.Comment,
+			}, cfg.CallOptions...)
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].TableID = resp.TableID
+		}(i, spec)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return results, nil
+	}
+
+	if cfg.RollbackOnError {
+		// Use a context that outlives a cancelled ctx so that cleanup still
+		// runs when the failure was caused by cancellation.
+		rollbackCtx := context.WithoutCancel(ctx)
+		for i := range results {
+			if results[i].Err != nil {
+				continue
+			}
+			_, err := c.raw.DeleteTable(rollbackCtx, &TableDeleteRequest{TableID: results[i].TableID}, cfg.CallOptions...)
+			if err != nil {
+				results[i].RollbackErr = err
+				continue
+			}
+			results[i].RolledBack = true
+		}
+	}
+	return results, &CreateTablesError{Failed: failed, Total: len(specs), Results: results}
+}
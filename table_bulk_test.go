@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTables(t *testing.T) {
+	t.Parallel()
+	var (
+		nextID      int64
+		inFlight    int32
+		maxInFlight int32
+	)
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/create": func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+			writeEnvelope(w, TableCreateResponse{TableID: TableID(atomic.AddInt64(&nextID, 1))})
+		},
+	})
+
+	specs := make([]TableCreateSpec, 20)
+	for i := range specs {
+		specs[i] = TableCreateSpec{Name: fmt.Sprintf("t%d", i), Columns: []Column{{Name: "id", Type: "int"}}}
+	}
+	results, err := NewSDKClient(raw).CreateTables(context.Background(), 1, specs, &CreateTablesOptions{Concurrency: 3})
+	require.NoError(t, err)
+	require.Len(t, results, len(specs))
+	for i, r := range results {
+		require.Equal(t, specs[i].Name, r.Name)
+		require.NotZero(t, r.TableID)
+		require.NoError(t, r.Err)
+	}
+	require.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3))
+}
+
+func TestCreateTables_RollbackOnError(t *testing.T) {
+	t.Parallel()
+	var (
+		mu      sync.Mutex
+		deleted []TableID
+	)
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/create": func(w http.ResponseWriter, r *http.Request) {
+			var req TableCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			switch req.Name {
+			case "bad":
+				writeEnvelopeError(w, "ErrInvalidParam", "bad column type")
+			case "a":
+				writeEnvelope(w, TableCreateResponse{TableID: 1})
+			default:
+				writeEnvelope(w, TableCreateResponse{TableID: 2})
+			}
+		},
+		"/catalog/table/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req TableDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			mu.Lock()
+			deleted = append(deleted, req.TableID)
+			mu.Unlock()
+			writeEnvelope(w, struct{}{})
+		},
+	})
+
+	results, err := NewSDKClient(raw).CreateTables(context.Background(), 1, []TableCreateSpec{
+		{Name: "a"}, {Name: "bad"}, {Name: "c"},
+	}, &CreateTablesOptions{RollbackOnError: true})
+
+	var bulkErr *CreateTablesError
+	require.True(t, errors.As(err, &bulkErr))
+	require.Equal(t, 1, bulkErr.Failed)
+	require.Contains(t, err.Error(), "bad column type")
+	require.Error(t, results[1].Err)
+	require.True(t, results[0].RolledBack)
+	require.True(t, results[2].RolledBack)
+	require.ElementsMatch(t, []TableID{1, 2}, deleted)
+}
+
+func TestCreateTables_Validation(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(&RawClient{})
+	ctx := context.Background()
+
+	_, err := client.CreateTables(ctx, 0, []TableCreateSpec{{Name: "a"}}, nil)
+	require.Error(t, err)
+	_, err = client.CreateTables(ctx, 1, nil, nil)
+	require.Error(t, err)
+	_, err = client.CreateTables(ctx, 1, []TableCreateSpec{{Name: "a"}, {Name: "a"}}, nil)
+	require.ErrorContains(t, err, "duplicate")
+	_, err = client.CreateTables(ctx, 1, []TableCreateSpec{{Name: " "}}, nil)
+	require.ErrorContains(t, err, "name is required")
+}
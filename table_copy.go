@@ -0,0 +1,251 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CopyTableOptions configures CopyTable.
+type CopyTableOptions struct {
+	// Where, if set, is a SQL condition selecting the rows to copy, such as
+	// "created_at >= ?". Without it every row is copied.
+	Where string
+	// WhereArgs holds the values of the "?" placeholders in Where.
+	WhereArgs []any
+	// BatchSize is the number of rows inserted per request. Defaults to
+	// 1000.
+	BatchSize int
+	// SchemaOnly creates the destination table without copying any rows.
+	SchemaOnly bool
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// CopyTableResult describes a table copied with CopyTable.
+type CopyTableResult struct {
+	// TableID is the ID of the destination table.
+	TableID TableID
+	// Rows is the number of rows inserted into it.
+	Rows int64
+}
+
+// CopyTable creates a copy of a table in another database.
+//
+// The destination table gets the columns and comment of the source table.
+// Without a Where condition the rows are streamed from DownloadTableData as
+// JSON lines and inserted in batches, so the table is never held in memory
+// at once. With one, the rows are read through a SQLCursor over
+// "SELECT * FROM db.table WHERE ..." instead, paged by keyset on a
+// single-column primary key, or ordered by the whole primary key or every
+// column otherwise; these rows arrive as text and are converted by the
+// server on insert.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - srcTableID: the table to copy (required)
+//   - dstDatabaseID: the database to create the copy in (required)
+//   - newName: the name of the copy; empty keeps the source name
+//   - opts: optional settings; nil copies every row
+//
+// The copy runs in a UnitOfWork: if copying the rows fails, the destination
+// table is deleted again.
+//
+// Returns:
+//   - *CopyTableResult: the destination table and the rows copied; it is
+//     also returned with an error wrapping a *RollbackError when a failed
+//     copy left the table behind
+//   - error: any error that occurred
+//
+// Example:
+//
+//	res, err := sdkClient.CopyTable(ctx, ordersID, archiveDBID, "orders_2023", &sdk.CopyTableOptions{
+//		Where:     "created_at < ?",
+//		WhereArgs: []any{"2024-01-01"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("copied %d rows into table %d\n", res.Rows, res.TableID)
+func (c *SDKClient) CopyTable(ctx context.Context, srcTableID TableID, dstDatabaseID DatabaseID, newName string, opts *CopyTableOptions) (*CopyTableResult, error) {
+	if srcTableID == 0 {
+		return nil, fmt.Errorf("source table_id is required")
+	}
+	if dstDatabaseID == 0 {
+		return nil, fmt.Errorf("destination database_id is required")
+	}
+	var cfg CopyTableOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultInsertRowsBatchSize
+	}
+	if strings.TrimSpace(cfg.Where) == "" && len(cfg.WhereArgs) > 0 {
+		return nil, fmt.Errorf("WhereArgs given without a Where condition")
+	}
+
+	info, err := c.raw.GetTable(ctx, &TableInfoRequest{TableID: srcTableID}, cfg.CallOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("get table %d: %w", srcTableID, err)
+	}
+	if newName == "" {
+		newName = info.Name
+	}
+	var result *CopyTableResult
+	err = c.RunUnitOfWork(ctx, func(ctx context.Context, uow *UnitOfWork) error {
+		tableID, err := uow.CreateTable(ctx, &TableCreateRequest{
+			DatabaseID: dstDatabaseID,
+			Name:       newName,
+			Columns:    info.Columns,
+			Comment:    info.Comment,
+		}, cfg.CallOptions...)
+		if err != nil {
+			return fmt.Errorf("create table %q: %w", newName, err)
+		}
+		result = &CopyTableResult{TableID: tableID}
+		if cfg.SchemaOnly {
+			return nil
+		}
+
+		ins := &tableCopyInserter{client: c, result: result, batchSize: cfg.BatchSize, opts: cfg.CallOptions}
+		if strings.TrimSpace(cfg.Where) == "" {
+			err = c.copyTableStream(ctx, srcTableID, ins, cfg.CallOptions)
+		} else {
+			err = c.copyTableWhere(ctx, srcTableID, info, &cfg, ins)
+		}
+		if err == nil {
+			err = ins.flush(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("copy rows of table %q: %w", info.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		var rollbackErr *RollbackError
+		if errors.As(err, &rollbackErr) {
+			// The destination table could not be deleted.
+			return result, err
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// copyTableStream feeds every row of the table to ins.
+func (c *SDKClient) copyTableStream(ctx context.Context, tableID TableID, ins *tableCopyInserter, opts []CallOption) error {
+	stream, err := c.raw.DownloadTableData(ctx, &TableDownloadDataRequest{ID: int64(tableID), Format: TableExportFormatJSONL}, opts...)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	dec := json.NewDecoder(stream.Body)
+	dec.UseNumber()
+	for {
+		var row map[string]any
+		if err := dec.Decode(&row); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := ins.add(ctx, row); err != nil {
+			return err
+		}
+	}
+}
+
+// copyTableWhere feeds the rows of the table matching cfg.Where to ins.
+func (c *SDKClient) copyTableWhere(ctx context.Context, tableID TableID, info *TableInfoResponse, cfg *CopyTableOptions, ins *tableCopyInserter) error {
+	paths, err := c.raw.GetTableFullPath(ctx, &TableFullPathRequest{TableIDList: []TableID{tableID}}, cfg.CallOptions...)
+	if err != nil {
+		return err
+	}
+	if len(paths.TableFullPath) == 0 || len(paths.TableFullPath[0].NameList) < 2 {
+		return fmt.Errorf("full path of table %d is unknown", tableID)
+	}
+	names := paths.TableFullPath[0].NameList
+	statement := fmt.Sprintf("SELECT * FROM %s.%s WHERE %s",
+		quoteSQLIdent(names[len(names)-2]), quoteSQLIdent(names[len(names)-1]), cfg.Where)
+	// A single-column primary key allows keyset paging; otherwise the rows
+	// are ordered by the whole key, or by every column without one.
+	cursorOpts := &SQLCursorOptions{BatchSize: cfg.BatchSize}
+	var keys, all []string
+	for _, col := range info.Columns {
+		if col.IsPk {
+			keys = append(keys, quoteSQLIdent(col.Name))
+			cursorOpts.KeyColumn = col.Name
+		}
+		all = append(all, quoteSQLIdent(col.Name))
+	}
+	switch {
+	case len(keys) > 1:
+		cursorOpts.KeyColumn = ""
+		statement += " ORDER BY " + strings.Join(keys, ", ")
+	case len(keys) == 0:
+		statement += " ORDER BY " + strings.Join(all, ", ")
+	}
+
+	args := append(append([]any{}, cfg.WhereArgs...), cfg.CallOptions)
+	cur, err := c.RunSQLCursor(ctx, statement, cursorOpts, args...)
+	if err != nil {
+		return err
+	}
+	defer cur.Close()
+	for cur.Next() {
+		values := cur.Row()
+		row := make(map[string]any, len(values))
+		for i, col := range cur.Columns() {
+			if i < len(values) {
+				row[col] = values[i]
+			}
+		}
+		if err := ins.add(ctx, row); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// tableCopyInserter collects the rows of a copy and inserts them into the
+// destination table in batches.
+type tableCopyInserter struct {
+	client    *SDKClient
+	result    *CopyTableResult
+	batchSize int
+	opts      []CallOption
+	batch     []map[string]any
+	// sent is the number of rows handed to the server so far.
+	sent int
+}
+
+func (ins *tableCopyInserter) add(ctx context.Context, row map[string]any) error {
+	ins.batch = append(ins.batch, row)
+	if len(ins.batch) < ins.batchSize {
+		return nil
+	}
+	return ins.flush(ctx)
+}
+
+func (ins *tableCopyInserter) flush(ctx context.Context) error {
+	if len(ins.batch) == 0 {
+		return nil
+	}
+	resp, err := ins.client.raw.InsertTableRows(ctx, ins.result.TableID, ins.batch, ins.opts...)
+	if err != nil {
+		return &InsertRowsError{Offset: ins.sent, Inserted: ins.result.Rows, Err: err}
+	}
+	ins.result.Rows += resp.Inserted
+	ins.sent += len(ins.batch)
+	ins.batch = nil
+	return nil
+}
+
+// quoteSQLIdent quotes name as a backquoted identifier.
+func quoteSQLIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,145 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func copyTableRoutes(t *testing.T, inserted *[][]map[string]any) map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"/catalog/table/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TableInfoResponse{
+				Name:    "orders",
+				Comment: "all orders",
+				Columns: []Column{{Name: "id", Type: "bigint", IsPk: true}, {Name: "amount", Type: "double"}},
+			})
+		},
+		"/catalog/table/create": func(w http.ResponseWriter, r *http.Request) {
+			var req TableCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, DatabaseID(20), req.DatabaseID)
+			require.Equal(t, "orders_copy", req.Name)
+			require.Equal(t, "all orders", req.Comment)
+			require.Len(t, req.Columns, 2)
+			writeEnvelope(w, TableCreateResponse{TableID: 200})
+		},
+		"/catalog/table/insert": func(w http.ResponseWriter, r *http.Request) {
+			var req TableInsertRowsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, TableID(200), req.TableID)
+			*inserted = append(*inserted, req.Rows)
+			writeEnvelope(w, TableInsertRowsResponse{Inserted: int64(len(req.Rows))})
+		},
+	}
+}
+
+func TestCopyTableStreamsAllRows(t *testing.T) {
+	t.Parallel()
+	var batches [][]map[string]any
+	routes := copyTableRoutes(t, &batches)
+	routes["/catalog/table/download_data"] = func(w http.ResponseWriter, r *http.Request) {
+		var req TableDownloadDataRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, int64(100), req.ID)
+		require.Equal(t, TableExportFormatJSONL, req.Format)
+		_, _ = w.Write([]byte("{\"id\":1,\"amount\":9.5}\n{\"id\":2,\"amount\":3}\n{\"id\":3,\"amount\":1}\n"))
+	}
+	client := NewSDKClient(newMockClient(t, routes))
+
+	res, err := client.CopyTable(context.Background(), 100, 20, "orders_copy", &CopyTableOptions{BatchSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, &CopyTableResult{TableID: 200, Rows: 3}, res)
+	require.Len(t, batches, 2)
+	require.Equal(t, map[string]any{"id": 1.0, "amount": 9.5}, batches[0][0])
+	require.Len(t, batches[1], 1)
+}
+
+func TestCopyTableWhere(t *testing.T) {
+	t.Parallel()
+	var batches [][]map[string]any
+	routes := copyTableRoutes(t, &batches)
+	routes["/catalog/table/full_path"] = func(w http.ResponseWriter, r *http.Request) {
+		writeEnvelope(w, TableFullPathResponse{TableFullPath: []FullPath{{IDList: []string{"1", "10", "100"}, NameList: []string{"main", "shop", "orders"}}}})
+	}
+	routes["/catalog/nl2sql/run_sql"] = func(w http.ResponseWriter, r *http.Request) {
+		var req NL2SQLRunSQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		// The single-column primary key is used for keyset paging.
+		require.Equal(t, "SELECT sdk_cursor.*, sdk_cursor.`id` IS NULL AS sdk_cursor_key_null FROM (SELECT * FROM `shop`.`orders` WHERE amount > 5) AS sdk_cursor ORDER BY `id` LIMIT 1000", req.Statement)
+		result := NL2SQLResult{Columns: []string{"id", "amount", "sdk_cursor_key_null"}, Rows: []NL2SQLRow{{"1", "9.5", "0"}, {"4", "7", "0"}}}
+		writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{result}})
+	}
+	client := NewSDKClient(newMockClient(t, routes))
+
+	res, err := client.CopyTable(context.Background(), 100, 20, "orders_copy", &CopyTableOptions{Where: "amount > ?", WhereArgs: []any{5}})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.Rows)
+	require.Equal(t, [][]map[string]any{{{"id": "1", "amount": "9.5"}, {"id": "4", "amount": "7"}}}, batches)
+}
+
+func TestCopyTableInsertFailure(t *testing.T) {
+	t.Parallel()
+	var batches [][]map[string]any
+	routes := copyTableRoutes(t, &batches)
+	routes["/catalog/table/download_data"] = func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{\"id\":1}\n{\"id\":2}\n"))
+	}
+	routes["/catalog/table/insert"] = func(w http.ResponseWriter, r *http.Request) {
+		writeEnvelopeError(w, "ErrInternal", "disk full")
+	}
+	var deleted []TableID
+	routes["/catalog/table/delete"] = func(w http.ResponseWriter, r *http.Request) {
+		var req TableDeleteRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		deleted = append(deleted, req.TableID)
+		writeEnvelope(w, TableDeleteResponse{})
+	}
+	client := NewSDKClient(newMockClient(t, routes))
+
+	// The half-filled copy is deleted again.
+	res, err := client.CopyTable(context.Background(), 100, 20, "orders_copy", nil)
+	require.Error(t, err)
+	var insertErr *InsertRowsError
+	require.True(t, errors.As(err, &insertErr))
+	require.Nil(t, res)
+	require.Equal(t, []TableID{200}, deleted)
+}
+
+func TestCopyTableRollbackFailure(t *testing.T) {
+	t.Parallel()
+	var batches [][]map[string]any
+	routes := copyTableRoutes(t, &batches)
+	routes["/catalog/table/download_data"] = func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{\"id\":1}\n"))
+	}
+	routes["/catalog/table/insert"] = func(w http.ResponseWriter, r *http.Request) {
+		writeEnvelopeError(w, "ErrInternal", "disk full")
+	}
+	routes["/catalog/table/delete"] = func(w http.ResponseWriter, r *http.Request) {
+		writeEnvelopeError(w, "ErrPermissionDenied", "cannot delete")
+	}
+	client := NewSDKClient(newMockClient(t, routes))
+
+	// The table that could not be deleted is reported.
+	res, err := client.CopyTable(context.Background(), 100, 20, "orders_copy", nil)
+	var rollbackErr *RollbackError
+	require.ErrorAs(t, err, &rollbackErr)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.Equal(t, TableID(200), res.TableID)
+}
+
+func TestCopyTableValidation(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, nil))
+	_, err := client.CopyTable(context.Background(), 0, 20, "", nil)
+	require.Error(t, err)
+	_, err = client.CopyTable(context.Background(), 100, 0, "", nil)
+	require.Error(t, err)
+	_, err = client.CopyTable(context.Background(), 100, 20, "", &CopyTableOptions{WhereArgs: []any{1}})
+	require.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTableToFile(t *testing.T) {
+	t.Parallel()
+	data := []byte("{\"id\":1}\n{\"id\":2}\n")
+	sum := sha256.Sum256(data)
+	var got TableDownloadDataRequest
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/download_data": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.Header().Set(headerContentSHA256, hex.EncodeToString(sum[:]))
+			_, _ = w.Write(data)
+		},
+	})
+
+	var progressed int64
+	path := filepath.Join(t.TempDir(), "out", "orders.jsonl.gz")
+	res, err := NewSDKClient(raw).ExportTableToFile(context.Background(), 5, path, TableExportFormatJSONL,
+		WithProgress(func(transferred, total int64) { progressed = transferred }))
+	require.NoError(t, err)
+	require.Equal(t, int64(5), got.ID)
+	require.Equal(t, TableExportFormatJSONL, got.Format)
+	require.Equal(t, TableExportCompressionGzip, got.Compression)
+	require.True(t, res.Verified)
+	require.Equal(t, int64(len(data)), res.Bytes)
+	require.Equal(t, int64(len(data)), progressed)
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, data, written)
+}
+
+func TestExportTableToFile_ChecksumMismatch(t *testing.T) {
+	t.Parallel()
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/download_data": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerContentMD5, "AAAAAAAAAAAAAAAAAAAAAA==")
+			_, _ = w.Write([]byte("id\n1\n"))
+		},
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orders.csv")
+	_, err := NewSDKClient(raw).ExportTableToFile(context.Background(), 5, path, TableExportFormatCSV)
+	require.True(t, errors.Is(err, ErrChecksumMismatch))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries, "no partial file should be left behind")
+
+	_, err = NewSDKClient(raw).ExportTableToFile(context.Background(), 5, path, "xml")
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+const defaultInsertRowsBatchSize = 1000
+
+// InsertRowsOptions configures InsertRows.
+type InsertRowsOptions struct {
+	// BatchSize is the maximum number of rows sent per request. Defaults to
+	// 1000.
+	BatchSize int
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// InsertRowsError is returned by InsertRows when a batch fails. The batches
+// before it have already been written.
+type InsertRowsError struct {
+	// Offset is the index of the first row of the failed batch.
+	Offset int
+	// Inserted is the number of rows written before the failure.
+	Inserted int64
+	Err      error
+}
+
+func (e *InsertRowsError) Error() string {
+	return fmt.Sprintf("sdk: insert rows at offset %d failed after %d rows were inserted: %v", e.Offset, e.Inserted, e.Err)
+}
+
+func (e *InsertRowsError) Unwrap() error { return e.Err }
+
+// InsertRows writes rows into a table in batches.
+//
+// Rows are split into chunks of at most BatchSize rows, which are sent one
+// after another with RawClient.InsertTableRows so that large row sets do not
+// produce oversized requests. Batches are not atomic as a whole: when one
+// fails, the earlier batches stay written and the error reports how far the
+// insert got.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - tableID: the table to insert into (required)
+//   - rows: the rows to insert, as column name to value maps (required)
+//   - opts: optional settings; nil uses the defaults
+//
+// Returns:
+//   - int64: the number of rows inserted
+//   - error: nil on success, otherwise a *InsertRowsError
+//
+// Example:
+//
+//	inserted, err := sdkClient.InsertRows(ctx, tableID, rows, &sdk.InsertRowsOptions{BatchSize: 500})
+//	var insertErr *sdk.InsertRowsError
+//	if errors.As(err, &insertErr) {
+//		log.Printf("resume from row %d: %v", insertErr.Offset, insertErr.Err)
+//	}
+func (c *SDKClient) InsertRows(ctx context.Context, tableID TableID, rows []map[string]any, opts *InsertRowsOptions) (int64, error) {
+	if tableID == 0 {
+		return 0, fmt.Errorf("table_id is required")
+	}
+	if len(rows) == 0 {
+		return 0, fmt.Errorf("at least one row is required")
+	}
+	var cfg InsertRowsOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultInsertRowsBatchSize
+	}
+
+	var inserted int64
+	for offset := 0; offset < len(rows); offset += cfg.BatchSize {
+		end := min(offset+cfg.BatchSize, len(rows))
+		if err := ctx.Err(); err != nil {
+			return inserted, &InsertRowsError{Offset: offset, Inserted: inserted, Err: err}
+		}
+		resp, err := c.raw.InsertTableRows(ctx, tableID, rows[offset:end], cfg.CallOptions...)
+		if err != nil {
+			return inserted, &InsertRowsError{Offset: offset, Inserted: inserted, Err: err}
+		}
+		inserted += resp.Inserted
+	}
+	return inserted, nil
+}
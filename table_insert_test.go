@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertTableRows(t *testing.T) {
+	t.Parallel()
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/insert": func(w http.ResponseWriter, r *http.Request) {
+			var req TableInsertRowsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, TableID(7), req.TableID)
+			require.Len(t, req.Rows, 2)
+			require.Equal(t, "alice", req.Rows[0]["name"])
+			writeEnvelope(w, TableInsertRowsResponse{Inserted: int64(len(req.Rows))})
+		},
+	})
+
+	resp, err := raw.InsertTableRows(context.Background(), 7, []map[string]any{
+		{"id": 1, "name": "alice"},
+		{"id": 2, "name": "bob"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Inserted)
+
+	_, err = raw.InsertTableRows(context.Background(), 0, []map[string]any{{"id": 1}})
+	require.Error(t, err)
+	_, err = raw.InsertTableRows(context.Background(), 7, nil)
+	require.Error(t, err)
+}
+
+func TestInsertRows_Batches(t *testing.T) {
+	t.Parallel()
+	var batches []int
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/insert": func(w http.ResponseWriter, r *http.Request) {
+			var req TableInsertRowsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			batches = append(batches, len(req.Rows))
+			writeEnvelope(w, TableInsertRowsResponse{Inserted: int64(len(req.Rows))})
+		},
+	})
+
+	rows := make([]map[string]any, 25)
+	for i := range rows {
+		rows[i] = map[string]any{"id": i}
+	}
+	inserted, err := NewSDKClient(raw).InsertRows(context.Background(), 7, rows, &InsertRowsOptions{BatchSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(25), inserted)
+	require.Equal(t, []int{10, 10, 5}, batches)
+}
+
+func TestInsertRows_FailedBatch(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/insert": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 2 {
+				writeEnvelopeError(w, "ErrInternal", "column type mismatch")
+				return
+			}
+			var req TableInsertRowsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			writeEnvelope(w, TableInsertRowsResponse{Inserted: int64(len(req.Rows))})
+		},
+	})
+
+	rows := make([]map[string]any, 5)
+	for i := range rows {
+		rows[i] = map[string]any{"id": i}
+	}
+	inserted, err := NewSDKClient(raw).InsertRows(context.Background(), 7, rows, &InsertRowsOptions{BatchSize: 2})
+	require.Equal(t, int64(2), inserted)
+	var insertErr *InsertRowsError
+	require.True(t, errors.As(err, &insertErr))
+	require.Equal(t, 2, insertErr.Offset)
+	require.Equal(t, int64(2), insertErr.Inserted)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, 2, calls)
+}
@@ -0,0 +1,240 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// previewTableSQL reads a projected or filtered preview with a SQL query.
+func (c *RawClient) previewTableSQL(ctx context.Context, req *TablePreviewRequest, lines int, opts []CallOption) (*TablePreviewResponse, error) {
+	info, err := c.GetTable(ctx, &TableInfoRequest{TableID: req.TableID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get table %d: %w", req.TableID, err)
+	}
+	columns := info.Columns
+	if len(req.Columns) > 0 {
+		if columns, err = selectPreviewColumns(info.Columns, req.Columns); err != nil {
+			return nil, err
+		}
+	}
+
+	paths, err := c.GetTableFullPath(ctx, &TableFullPathRequest{TableIDList: []TableID{req.TableID}}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths.TableFullPath) == 0 || len(paths.TableFullPath[0].NameList) < 2 {
+		return nil, fmt.Errorf("full path of table %d is unknown", req.TableID)
+	}
+	names := paths.TableFullPath[0].NameList
+
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	for i, col := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(quoteSQLIdent(col.Name))
+	}
+	fmt.Fprintf(&b, " FROM %s.%s", quoteSQLIdent(names[len(names)-2]), quoteSQLIdent(names[len(names)-1]))
+	if !blank(req.Where) {
+		where, err := BindSQL(req.Where, req.WhereArgs...)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, " WHERE %s", where)
+	}
+	var keys []string
+	for _, col := range info.Columns {
+		if col.IsPk {
+			keys = append(keys, quoteSQLIdent(col.Name))
+		}
+	}
+	if len(keys) > 0 {
+		b.WriteString(" ORDER BY " + strings.Join(keys, ", "))
+	}
+	fmt.Fprintf(&b, " LIMIT %d OFFSET %d", lines, req.Offset)
+
+	resp, err := c.RunNL2SQL(ctx, &NL2SQLRunSQLRequest{Operation: RunSQL, Statement: b.String()}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	preview := &TablePreviewResponse{Columns: columns, Data: [][]interface{}{}}
+	if len(resp.Results) == 0 {
+		return preview, nil
+	}
+	result := resp.Results[0]
+	// Type the values by the columns actually returned, which should be
+	// the ones selected.
+	types := make([]string, len(result.Columns))
+	for i, name := range result.Columns {
+		for _, col := range columns {
+			if strings.EqualFold(col.Name, name) {
+				types[i] = col.Type
+				break
+			}
+		}
+	}
+	for _, row := range result.Rows {
+		values := make([]interface{}, len(row))
+		for i, s := range row {
+			colType := ""
+			if i < len(types) {
+				colType = types[i]
+			}
+			if isPreviewNull(colType, s) {
+				continue
+			}
+			values[i] = decodePreviewValue(colType, s)
+		}
+		preview.Data = append(preview.Data, values)
+	}
+	return preview, nil
+}
+
+// selectPreviewColumns returns the columns of the table named by names, in
+// that order. Names are matched case-insensitively.
+func selectPreviewColumns(all []Column, names []string) ([]Column, error) {
+	var errs fieldErrors
+	selected := make([]Column, 0, len(names))
+	for i, name := range names {
+		found := false
+		for _, col := range all {
+			if strings.EqualFold(col.Name, strings.TrimSpace(name)) {
+				selected = append(selected, col)
+				found = true
+				break
+			}
+		}
+		errs.check(found, fmt.Sprintf("columns[%d]", i), fmt.Sprintf("table has no column %q", name))
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// isPreviewNull reports whether s, returned as text by a SQL query, stands
+// for NULL. The query results cannot tell NULL from the text "NULL", so text
+// columns keep it as a string.
+func isPreviewNull(colType, s string) bool {
+	if !strings.EqualFold(s, "NULL") {
+		return false
+	}
+	switch previewBaseType(colType) {
+	case "", "char", "varchar", "text", "tinytext", "mediumtext", "longtext", "enum", "set", "json":
+		return false
+	}
+	return true
+}
+
+// previewBaseType returns colType lower-cased and without its length,
+// precision or attributes, e.g. "int" for "INT(11) UNSIGNED".
+func previewBaseType(colType string) string {
+	base := strings.ToLower(strings.TrimSpace(colType))
+	if i := strings.IndexAny(base, "( "); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
+
+// decodePreviewValue converts a value returned as text by a SQL query into
+// the Go type matching colType. Values that do not parse are kept as text.
+func decodePreviewValue(colType, s string) interface{} {
+	unsigned := strings.Contains(strings.ToLower(colType), "unsigned")
+	switch previewBaseType(colType) {
+	case "tinyint", "smallint", "mediumint", "int", "integer", "bigint":
+		if unsigned {
+			if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+				return n
+			}
+		} else if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	case "float", "double", "real":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case "bool", "boolean":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return s
+}
+
+// decodePreviewData converts the JSON-decoded values of data in place to the
+// Go types decodePreviewValue uses, by the type of the matching column:
+// integers become int64 or uint64 rather than float64, and text is parsed
+// for numeric and boolean columns. JSON null stays nil.
+func decodePreviewData(columns []Column, data [][]interface{}) {
+	for _, row := range data {
+		for i, v := range row {
+			if i >= len(columns) {
+				break
+			}
+			colType := columns[i].Type
+			switch v := v.(type) {
+			case string:
+				row[i] = decodePreviewValue(colType, v)
+			case float64:
+				row[i] = decodePreviewValue(colType, strconv.FormatFloat(v, 'f', -1, 64))
+				if _, ok := row[i].(string); ok {
+					row[i] = v
+				}
+			case json.Number:
+				row[i] = decodePreviewValue(colType, v.String())
+				if _, ok := row[i].(string); ok {
+					row[i] = v
+				}
+			}
+		}
+	}
+}
+
+// Scan converts the previewed rows into dest, which must be a pointer to a
+// slice of structs or of struct pointers, matching columns to fields as
+// QueryRows does. The slice is replaced with one element per row.
+//
+// Example:
+//
+//	var orders []struct {
+//		ID     int64   `db:"id"`
+//		Amount float64 `db:"amount"`
+//		Note   *string `db:"note"`
+//	}
+//	if err := resp.Scan(&orders); err != nil {
+//		return err
+//	}
+func (r *TablePreviewResponse) Scan(dest any) error {
+	columns := make([]string, len(r.Columns))
+	for i, col := range r.Columns {
+		columns[i] = col.Name
+	}
+	// Only nil values are NULL; the text "NULL" is kept as it is.
+	return scanSQLRows(dest, columns, len(r.Data), func(row, col int) (string, bool, bool) {
+		if col >= len(r.Data[row]) {
+			return "", false, false
+		}
+		v := r.Data[row][col]
+		return previewValueText(v), v == nil, true
+	})
+}
+
+// previewValueText formats a preview value as the text Scan converts.
+func previewValueText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
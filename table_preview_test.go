@@ -0,0 +1,155 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewTableProjection(t *testing.T) {
+	t.Parallel()
+	var statement string
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TableInfoResponse{Name: "orders", Columns: []Column{
+				{Name: "id", Type: "bigint", IsPk: true},
+				{Name: "amount", Type: "double"},
+				{Name: "paid", Type: "bool"},
+				{Name: "note", Type: "varchar(255)"},
+				{Name: "total", Type: "decimal(10,2)"},
+			}})
+		},
+		"/catalog/table/full_path": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TableFullPathResponse{TableFullPath: []FullPath{{NameList: []string{"main", "shop", "orders"}}}})
+		},
+		"/catalog/nl2sql/run_sql": func(w http.ResponseWriter, r *http.Request) {
+			var req NL2SQLRunSQLRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			statement = req.Statement
+			writeEnvelope(w, NL2SQLRunSQLResponse{Results: []NL2SQLResult{{
+				Columns: []string{"note", "id", "amount", "paid", "total"},
+				Rows: []NL2SQLRow{
+					{"first", "7", "9.5", "true", "12.30"},
+					{"NULL", "8", "NULL", "false", "0.10"},
+				},
+			}}})
+		},
+	})
+
+	resp, err := client.PreviewTable(context.Background(), &TablePreviewRequest{
+		TableID:   100,
+		Lines:     2,
+		Columns:   []string{"note", "ID", "amount", "paid", "total"},
+		Where:     "note <> ?",
+		WhereArgs: []any{"it's"},
+		Offset:    5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "SELECT `note`, `id`, `amount`, `paid`, `total` FROM `shop`.`orders` WHERE note <> 'it\\'s' ORDER BY `id` LIMIT 2 OFFSET 5", statement)
+	require.Equal(t, []string{"note", "id", "amount", "paid", "total"}, []string{resp.Columns[0].Name, resp.Columns[1].Name, resp.Columns[2].Name, resp.Columns[3].Name, resp.Columns[4].Name})
+	// NULL is nil except in text columns, where it cannot be told from the
+	// text "NULL".
+	require.Equal(t, [][]interface{}{
+		{"first", int64(7), 9.5, true, "12.30"},
+		{"NULL", int64(8), nil, false, "0.10"},
+	}, resp.Data)
+
+	var rows []struct {
+		ID     int64    `db:"id"`
+		Amount *float64 `db:"amount"`
+		Note   *string  `db:"note"`
+		Paid   bool     `db:"paid"`
+	}
+	require.NoError(t, resp.Scan(&rows))
+	require.Len(t, rows, 2)
+	require.Equal(t, int64(7), rows[0].ID)
+	require.Equal(t, 9.5, *rows[0].Amount)
+	require.Equal(t, "first", *rows[0].Note)
+	require.True(t, rows[0].Paid)
+	require.Nil(t, rows[1].Amount)
+	require.Equal(t, "NULL", *rows[1].Note)
+}
+
+func TestPreviewTableDecodesJSONValues(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/data": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, GetTableDataResponse{
+				Columns: []Column{
+					{Name: "id", Type: "bigint"},
+					{Name: "hits", Type: "int unsigned"},
+					{Name: "amount", Type: "double"},
+					{Name: "paid", Type: "bool"},
+					{Name: "note", Type: "varchar(20)"},
+				},
+				Data: [][]interface{}{
+					{7, 3, 9.5, true, "NULL"},
+					{"8", "4", 2, "false", nil},
+				},
+			})
+		},
+	})
+
+	resp, err := client.PreviewTable(context.Background(), &TablePreviewRequest{TableID: 100, Lines: 2})
+	require.NoError(t, err)
+	require.Equal(t, [][]interface{}{
+		{int64(7), uint64(3), 9.5, true, "NULL"},
+		{int64(8), uint64(4), float64(2), false, nil},
+	}, resp.Data)
+
+	var rows []struct {
+		ID   int64   `db:"id"`
+		Note *string `db:"note"`
+	}
+	require.NoError(t, resp.Scan(&rows))
+	require.Equal(t, "NULL", *rows[0].Note)
+	require.Nil(t, rows[1].Note)
+}
+
+func TestPreviewTableUnknownColumn(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TableInfoResponse{Columns: []Column{{Name: "id", Type: "int"}}})
+		},
+	})
+	_, err := client.PreviewTable(context.Background(), &TablePreviewRequest{TableID: 100, Columns: []string{"id", "missing"}})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, "columns[1]", verr.Fields[0].Field)
+}
+
+func TestPreviewTableValidation(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, nil)
+	for _, req := range []*TablePreviewRequest{
+		{},
+		{TableID: 1, Offset: -1},
+		{TableID: 1, WhereArgs: []any{1}},
+		{TableID: 1, Columns: []string{" "}},
+	} {
+		_, err := client.PreviewTable(context.Background(), req)
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+}
+
+func TestTablePreviewResponseScanJSONValues(t *testing.T) {
+	t.Parallel()
+	// Previews read with GetTableData hold values decoded from JSON.
+	resp := &TablePreviewResponse{
+		Columns: []Column{{Name: "id"}, {Name: "name"}},
+		Data:    [][]interface{}{{float64(42), "alice"}, {float64(1e6), nil}},
+	}
+	var rows []struct {
+		ID   int64
+		Name *string
+	}
+	require.NoError(t, resp.Scan(&rows))
+	require.Equal(t, int64(42), rows[0].ID)
+	require.Equal(t, "alice", *rows[0].Name)
+	require.Equal(t, int64(1000000), rows[1].ID)
+	require.Nil(t, rows[1].Name)
+}
@@ -2,8 +2,10 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
@@ -116,6 +118,7 @@ func TestTableNilRequestErrors(t *testing.T) {
 		{"Load", func() error { _, err := client.LoadTable(ctx, nil); return err }},
 		{"Download", func() error { _, err := client.GetTableDownloadLink(ctx, nil); return err }},
 		{"DownloadData", func() error { _, err := client.DownloadTableData(ctx, nil); return err }},
+		{"Alter", func() error { _, err := client.AlterTable(ctx, nil); return err }},
 		{"Truncate", func() error { _, err := client.TruncateTable(ctx, nil); return err }},
 		{"Delete", func() error { _, err := client.DeleteTable(ctx, nil); return err }},
 		{"FullPath", func() error { _, err := client.GetTableFullPath(ctx, nil); return err }},
@@ -129,6 +132,56 @@ func TestTableNilRequestErrors(t *testing.T) {
 	}
 }
 
+func TestAlterTable(t *testing.T) {
+	t.Parallel()
+	var got TableAlterRequest
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/table/alter": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			writeEnvelope(w, TableAlterResponse{})
+		},
+	})
+
+	_, err := client.AlterTable(context.Background(), &TableAlterRequest{
+		TableID: 9,
+		Alterations: []TableAlteration{
+			AlterAddColumn(Column{Name: "email", Type: "varchar(255)"}),
+			AlterRenameColumn("name", "full_name"),
+			AlterModifyColumn(Column{Name: "age", Type: "bigint"}),
+			AlterDropColumn("legacy"),
+			AlterTableComment("customers"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TableID(9), got.TableID)
+	require.Len(t, got.Alterations, 5)
+	require.Equal(t, TableAlterOpAddColumn, got.Alterations[0].Op)
+	require.Equal(t, "email", got.Alterations[0].Column.Name)
+	require.Equal(t, "full_name", got.Alterations[1].NewName)
+	require.Equal(t, "bigint", got.Alterations[2].Column.Type)
+	require.Equal(t, "legacy", got.Alterations[3].Name)
+	require.Equal(t, "customers", got.Alterations[4].Comment)
+}
+
+func TestAlterTable_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	tests := []*TableAlterRequest{
+		{Alterations: []TableAlteration{AlterDropColumn("a")}},
+		{TableID: 1},
+		{TableID: 1, Alterations: []TableAlteration{AlterAddColumn(Column{Name: "a"})}},
+		{TableID: 1, Alterations: []TableAlteration{AlterRenameColumn("a", "")}},
+		{TableID: 1, Alterations: []TableAlteration{AlterDropColumn("")}},
+		{TableID: 1, Alterations: []TableAlteration{{Op: "truncate"}}},
+	}
+	for _, req := range tests {
+		_, err := client.AlterTable(ctx, req)
+		require.Error(t, err)
+	}
+}
+
 func TestTableDatabaseIDNotExists(t *testing.T) {
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -23,8 +23,8 @@ func (c *RawClient) GetTask(ctx context.Context, req *TaskInfoRequest, opts ...C
 	if req == nil {
 		return nil, ErrNilRequest
 	}
-	if req.TaskID == 0 {
-		return nil, fmt.Errorf("task_id is required")
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
 
 	// Add task_id as query parameter
@@ -36,3 +36,132 @@ func (c *RawClient) GetTask(ctx context.Context, req *TaskInfoRequest, opts ...C
 	}
 	return &resp, nil
 }
+
+// CreateLoadTask creates a task that loads files into a volume or a table.
+//
+// The source names the connector files (or uploaded files) to read and the
+// target says where the data goes. The task runs asynchronously; poll it
+// with GetLoadTask or use SDKClient.RunLoadTaskAndWait.
+//
+// Example:
+//
+//	resp, err := client.CreateLoadTask(ctx, &sdk.LoadTaskCreateRequest{
+//		Name: "daily-orders",
+//		Source: sdk.LoadTaskSourceConfig{
+//			ConnectorID: 12,
+//			URIs:        []string{"s3://bucket/exports/orders.csv"},
+//		},
+//		Target: sdk.LoadTaskTargetConfig{
+//			Table: &sdk.TableConfig{DatabaseID: 123, TableID: 456},
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Created load task %d\n", resp.TaskID)
+func (c *RawClient) CreateLoadTask(ctx context.Context, req *LoadTaskCreateRequest, opts ...CallOption) (*LoadTaskCreateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var resp LoadTaskCreateResponse
+	if err := c.postJSON(ctx, "/task/load/create", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetLoadTask retrieves a load task, including its per-file load results.
+//
+// Example:
+//
+//	task, err := client.GetLoadTask(ctx, 123)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Task %s: %s\n", task.Name, task.Status)
+func (c *RawClient) GetLoadTask(ctx context.Context, taskID TaskID, opts ...CallOption) (*TaskInfoResponse, error) {
+	if taskID == 0 {
+		return nil, invalidArgument("task_id", "task_id is required")
+	}
+
+	opts = append(opts, WithQueryParam("task_id", fmt.Sprintf("%d", taskID)))
+
+	var resp TaskInfoResponse
+	if err := c.getJSON(ctx, "/task/load/get", &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListLoadTasks lists load tasks, optionally filtered by name and status.
+//
+// Example:
+//
+//	resp, err := client.ListLoadTasks(ctx, &sdk.LoadTaskListRequest{
+//		Status:   "running",
+//		Page:     1,
+//		PageSize: 20,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, task := range resp.Tasks {
+//		fmt.Printf("%s: %s\n", task.ID, task.Status)
+//	}
+func (c *RawClient) ListLoadTasks(ctx context.Context, req *LoadTaskListRequest, opts ...CallOption) (*LoadTaskListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp LoadTaskListResponse
+	if err := c.postJSON(ctx, "/task/load/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CancelLoadTask stops a pending or running load task. Files already
+// loaded are kept.
+//
+// Example:
+//
+//	_, err := client.CancelLoadTask(ctx, &sdk.LoadTaskCancelRequest{TaskID: 123})
+func (c *RawClient) CancelLoadTask(ctx context.Context, req *LoadTaskCancelRequest, opts ...CallOption) (*LoadTaskCancelResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp LoadTaskCancelResponse
+	if err := c.postJSON(ctx, "/task/load/cancel", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteLoadTask deletes a load task and its history. The loaded data is
+// not affected.
+//
+// Example:
+//
+//	_, err := client.DeleteLoadTask(ctx, &sdk.LoadTaskDeleteRequest{TaskID: 123})
+func (c *RawClient) DeleteLoadTask(ctx context.Context, req *LoadTaskDeleteRequest, opts ...CallOption) (*LoadTaskDeleteResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp LoadTaskDeleteResponse
+	if err := c.postJSON(ctx, "/task/load/delete", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
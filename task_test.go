@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -199,3 +200,87 @@ func TestImportLocalFilesToVolumeErrors(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "file_path[0] is empty")
 }
+
+func TestParseTaskStatus(t *testing.T) {
+	t.Parallel()
+	tests := map[string]TaskStatus{
+		"pending":   TaskStatusPending,
+		"Running":   TaskStatusRunning,
+		" SUCCESS ": TaskStatusSucceeded,
+		"completed": TaskStatusSucceeded,
+		"failed":    TaskStatusFailed,
+		"canceled":  TaskStatusCancelled,
+		"mystery":   TaskStatusUnknown,
+		"":          TaskStatusUnknown,
+	}
+	for in, want := range tests {
+		require.Equal(t, want, ParseTaskStatus(in), in)
+	}
+	require.True(t, TaskStatusFailed.IsTerminal())
+	require.False(t, TaskStatusRunning.IsTerminal())
+	require.Equal(t, "succeeded", TaskStatusSucceeded.String())
+	require.Equal(t, "unknown(42)", TaskStatus(42).String())
+}
+
+func TestTaskStatusJSON(t *testing.T) {
+	t.Parallel()
+	var task TaskInfoResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"1","status":"Success"}`), &task))
+	require.Equal(t, TaskStatusSucceeded, task.Status)
+
+	// Unknown and malformed values do not fail the response.
+	require.NoError(t, json.Unmarshal([]byte(`{"status":"mystery"}`), &task))
+	require.Equal(t, TaskStatusUnknown, task.Status)
+	require.NoError(t, json.Unmarshal([]byte(`{"status":["x"]}`), &task))
+	require.Equal(t, TaskStatusUnknown, task.Status)
+
+	// Numeric codes decode like their text form.
+	require.NoError(t, json.Unmarshal([]byte(`{"status":3}`), &task))
+	require.Equal(t, TaskStatusSucceeded, task.Status)
+
+	var detail GenAIGetJobDetailResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"status":"completed","files":[{"file_id":"f1","file_status":"Error"}]}`), &detail))
+	require.Equal(t, "completed", detail.Status)
+	require.Equal(t, TaskStatusSucceeded, detail.JobStatus())
+	require.Len(t, detail.FailedFiles(), 1)
+
+	data, err := json.Marshal(TaskInfoResponse{Status: TaskStatusRunning})
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"status":"running"`)
+}
+
+func TestTaskStatusRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, in := range []string{
+		`{"id":"1","status":"archived"}`,
+		`{"id":"1","status":3}`,
+		`{"id":"1","status":"Success"}`,
+	} {
+		var task TaskInfoResponse
+		require.NoError(t, json.Unmarshal([]byte(in), &task))
+		out, err := json.Marshal(task)
+		require.NoError(t, err)
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(out, &got))
+		var want map[string]any
+		require.NoError(t, json.Unmarshal([]byte(in), &want))
+		require.Equal(t, want["status"], got["status"], in)
+	}
+
+	// A status changed after decoding is written in its canonical form.
+	var task TaskInfoResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"status":"archived"}`), &task))
+	task.Status = TaskStatusFailed
+	out, err := json.Marshal(task)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"status":"failed"`)
+
+	in := `{"job_id":"j1","status":"queued","files":[{"file_id":"f1","file_status":7}]}`
+	var detail GenAIGetJobDetailResponse
+	require.NoError(t, json.Unmarshal([]byte(in), &detail))
+	require.Equal(t, TaskStatusUnknown, detail.Files[0].FileStatus)
+	out, err = json.Marshal(detail)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"status":"queued"`)
+	require.Contains(t, string(out), `"file_status":7`)
+}
@@ -2,7 +2,10 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -21,6 +24,38 @@ func newTestClient(t *testing.T) *RawClient {
 	return client
 }
 
+// newMockClient starts an httptest server serving the given routes (keyed
+// by URL path) and returns a RawClient pointed at it. Unknown paths get 404.
+func newMockClient(t *testing.T, routes map[string]http.HandlerFunc) *RawClient {
+	t.Helper()
+	mux := http.NewServeMux()
+	for path, handler := range routes {
+		mux.HandleFunc(path, handler)
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client, err := NewRawClient(server.URL, "test-key")
+	require.NoError(t, err)
+	return client
+}
+
+// writeEnvelope writes data wrapped in the standard OK response envelope.
+func writeEnvelope(w http.ResponseWriter, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(headerContentType, mimeJSON)
+	_ = json.NewEncoder(w).Encode(apiEnvelope{Code: "OK", Data: payload})
+}
+
+// writeEnvelopeError writes an error envelope with the given code.
+func writeEnvelopeError(w http.ResponseWriter, code, msg string) {
+	w.Header().Set(headerContentType, mimeJSON)
+	_ = json.NewEncoder(w).Encode(apiEnvelope{Code: code, Msg: msg})
+}
+
 func randomName(prefix string) string {
 	return fmt.Sprintf("%s%d", prefix, time.Now().UnixNano())
 }
@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unixMillisThreshold separates Unix timestamps in seconds from those in
+// milliseconds: 1e11 seconds is in the year 5138.
+const unixMillisThreshold = 1e11
+
+// Time is a timestamp of the service. The APIs format times inconsistently,
+// so Time decodes any of them:
+//
+//   - RFC 3339 strings, such as "2024-05-01T08:30:00Z"
+//   - "2024-05-01 08:30:00" and "2024-05-01T08:30:00", with optional
+//     fractional seconds, and "2024-05-01"; these carry no zone and are
+//     taken as UTC
+//   - Unix timestamps in seconds or milliseconds, as numbers or strings
+//
+// null and "" decode to the zero Time. Time encodes as an RFC 3339 string,
+// or null when zero.
+type Time struct {
+	time.Time
+}
+
+// ParseTime parses a timestamp in any of the formats accepted by Time.
+// The empty string gives the zero Time.
+//
+// Example:
+//
+//	created, err := sdk.ParseTime(resp.CreatedAt)
+func ParseTime(s string) (Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Time{}, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return unixTime(n), nil
+	}
+	for _, layout := range sqlTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Time{t}, nil
+		}
+	}
+	return Time{}, fmt.Errorf("parse time %q: unsupported format", s)
+}
+
+func unixTime(n int64) Time {
+	if n >= unixMillisThreshold || n <= -unixMillisThreshold {
+		return Time{time.UnixMilli(n).UTC()}
+	}
+	return Time{time.Unix(n, 0).UTC()}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		*t = Time{}
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		parsed, err := ParseTime(s)
+		if err != nil {
+			return err
+		}
+		*t = parsed
+		return nil
+	}
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse time %s: unsupported format", data)
+	}
+	*t = unixTime(n)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
+// String returns the time in RFC 3339 format, or "" when zero.
+func (t Time) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	want := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`"2024-05-01T08:30:00Z"`, want},
+		{`"2024-05-01T16:30:00+08:00"`, want},
+		{`"2024-05-01 08:30:00"`, want},
+		{`"2024-05-01T08:30:00"`, want},
+		{`"2024-05-01 08:30:00.250"`, want.Add(250 * time.Millisecond)},
+		{`"2024-05-01"`, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{`1714552200`, want},
+		{`1714552200000`, want},
+		{`"1714552200"`, want},
+		{`null`, time.Time{}},
+		{`""`, time.Time{}},
+	}
+	for _, tt := range tests {
+		var got Time
+		require.NoError(t, json.Unmarshal([]byte(tt.in), &got), tt.in)
+		require.True(t, tt.want.Equal(got.Time), "%s: got %v", tt.in, got)
+	}
+
+	var got Time
+	require.Error(t, json.Unmarshal([]byte(`"yesterday"`), &got))
+	require.Error(t, json.Unmarshal([]byte(`true`), &got))
+}
+
+func TestTimeMarshalJSON(t *testing.T) {
+	t.Parallel()
+	v := struct {
+		CreatedAt Time `json:"created_at"`
+		UpdatedAt Time `json:"updated_at"`
+	}{CreatedAt: Time{time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)}}
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"created_at":"2024-05-01T08:30:00Z","updated_at":null}`, string(data))
+
+	var back struct {
+		CreatedAt Time `json:"created_at"`
+	}
+	require.NoError(t, json.Unmarshal(data, &back))
+	require.True(t, v.CreatedAt.Equal(back.CreatedAt.Time))
+	require.Equal(t, "2024-05-01T08:30:00Z", back.CreatedAt.String())
+	require.Equal(t, "", Time{}.String())
+}
+
+func TestParseTime(t *testing.T) {
+	t.Parallel()
+	got, err := ParseTime(" 2024-05-01 08:30:00 ")
+	require.NoError(t, err)
+	require.Equal(t, 2024, got.Year())
+
+	got, err = ParseTime("")
+	require.NoError(t, err)
+	require.True(t, got.IsZero())
+
+	_, err = ParseTime("01/05/2024")
+	require.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// TransportConfig tunes the HTTP transport of the client: connection
+// pooling, TLS and proxying. Zero fields keep the settings of
+// http.DefaultTransport.
+type TransportConfig struct {
+	// MaxIdleConns bounds the idle connections kept across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost bounds the idle connections kept per host. The
+	// Go default of 2 is low for clients issuing many concurrent requests.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost bounds the connections per host, in any state.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes connections idle for longer.
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout bounds the TLS handshake.
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout bounds the wait for response headers once the
+	// request is sent. Unlike the client timeout, it does not cut off
+	// responses whose bodies take long to read.
+	ResponseHeaderTimeout time.Duration
+
+	// TLSConfig is the base TLS configuration. It is cloned, not modified.
+	TLSConfig *tls.Config
+	// RootCAFile is a PEM file of certificate authorities trusted in
+	// addition to the system ones, for servers with a private CA.
+	RootCAFile string
+	// ClientCertFile and ClientKeyFile are the PEM certificate and key
+	// presented to servers requiring mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// ProxyURL is the proxy requests are sent through. Empty uses the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// DisableProxy sends requests directly, ignoring the environment.
+	DisableProxy bool
+
+	// DisableHTTP2 restricts the client to HTTP/1.1.
+	DisableHTTP2 bool
+	// HTTP2ReadIdleTimeout sends a ping on HTTP/2 connections that have
+	// received nothing for this long, to detect broken connections.
+	HTTP2ReadIdleTimeout time.Duration
+	// HTTP2PingTimeout closes HTTP/2 connections whose ping is not answered
+	// in time. Defaults to 15 seconds.
+	HTTP2PingTimeout time.Duration
+}
+
+// newTransport builds an http.Transport from base, or from
+// http.DefaultTransport if base is nil, with cfg applied.
+func newTransport(base *http.Transport, cfg *TransportConfig) (*http.Transport, error) {
+	if base == nil {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+	t := base.Clone()
+
+	if cfg.MaxIdleConns > 0 {
+		t.MaxIdleConns = cfg.MaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+	if cfg.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	if cfg.TLSHandshakeTimeout > 0 {
+		t.TLSHandshakeTimeout = cfg.TLSHandshakeTimeout
+	}
+	if cfg.ResponseHeaderTimeout > 0 {
+		t.ResponseHeaderTimeout = cfg.ResponseHeaderTimeout
+	}
+
+	tlsConfig, err := cfg.tlsConfig(t.TLSClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	t.TLSClientConfig = tlsConfig
+
+	switch {
+	case cfg.DisableProxy:
+		t.Proxy = nil
+	case strings.TrimSpace(cfg.ProxyURL) != "":
+		proxy, err := url.Parse(strings.TrimSpace(cfg.ProxyURL))
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("proxy URL must include scheme and host")
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	}
+
+	if cfg.DisableHTTP2 {
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetHTTP1(true)
+	} else {
+		// A custom TLS configuration otherwise turns HTTP/2 off.
+		t.ForceAttemptHTTP2 = true
+	}
+	if cfg.HTTP2ReadIdleTimeout > 0 || cfg.HTTP2PingTimeout > 0 {
+		t.HTTP2 = &http.HTTP2Config{
+			SendPingTimeout: cfg.HTTP2ReadIdleTimeout,
+			PingTimeout:     cfg.HTTP2PingTimeout,
+		}
+	}
+	return t, nil
+}
+
+// tlsConfig returns base, or a copy of it with the configured CA and client
+// certificate, or nil if none is configured.
+func (cfg *TransportConfig) tlsConfig(base *tls.Config) (*tls.Config, error) {
+	if cfg.TLSConfig != nil {
+		base = cfg.TLSConfig
+	}
+	if cfg.RootCAFile == "" && cfg.ClientCertFile == "" && cfg.ClientKeyFile == "" {
+		if base == nil {
+			return nil, nil
+		}
+		return base.Clone(), nil
+	}
+
+	var tlsConfig *tls.Config
+	if base != nil {
+		tlsConfig = base.Clone()
+	} else {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.RootCAFile != "" {
+		pem, err := os.ReadFile(cfg.RootCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read root CA file: %w", err)
+		}
+		pool := tlsConfig.RootCAs
+		if pool == nil {
+			if pool, err = x509.SystemCertPool(); err != nil {
+				pool = x509.NewCertPool()
+			}
+		} else {
+			pool = pool.Clone()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.RootCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.ClientCertFile != "" || cfg.ClientKeyFile != "" {
+		if cfg.ClientCertFile == "" || cfg.ClientKeyFile == "" {
+			return nil, fmt.Errorf("client certificate and key files are both required")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTransportConfig(t *testing.T) {
+	t.Parallel()
+	var clientCerts int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientCerts = len(r.TLS.PeerCertificates)
+		w.Header().Set(headerContentType, mimeJSON)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	// The test server certificate serves as CA and as client certificate.
+	dir := t.TempDir()
+	cert := server.TLS.Certificates[0]
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600))
+	ctx := context.Background()
+
+	client, err := NewRawClient(server.URL, "test-key")
+	require.NoError(t, err)
+	_, err = client.HealthCheck(ctx)
+	require.Error(t, err)
+
+	client, err = NewRawClient(server.URL, "test-key", WithHTTPTimeout(5*time.Second), WithTransportConfig(&TransportConfig{
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     time.Minute,
+		RootCAFile:          certFile,
+		ClientCertFile:      certFile,
+		ClientKeyFile:       keyFile,
+		DisableProxy:        true,
+	}))
+	require.NoError(t, err)
+	status, err := client.HealthCheck(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "ok", status.Status)
+	require.Equal(t, 1, clientCerts)
+
+	transport := client.httpClient.Transport.(*http.Transport)
+	require.Equal(t, 16, transport.MaxIdleConnsPerHost)
+	require.Equal(t, time.Minute, transport.IdleConnTimeout)
+	require.Equal(t, 5*time.Second, client.httpClient.Timeout)
+	require.Nil(t, transport.Proxy)
+
+	_, err = NewRawClient(server.URL, "test-key", WithTransportConfig(&TransportConfig{RootCAFile: filepath.Join(dir, "missing.pem")}))
+	require.Error(t, err)
+	_, err = NewRawClient(server.URL, "test-key", WithTransportConfig(&TransportConfig{ClientCertFile: certFile}))
+	require.Error(t, err)
+	_, err = NewRawClient(server.URL, "test-key", WithTransportConfig(&TransportConfig{ProxyURL: "proxy:8080"}))
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package sdk
+
+import "context"
+
+// MoveToTrash moves the specified files, folders and tables to the trash,
+// instead of deleting them permanently as DeleteFile, DeleteFolder and
+// DeleteTable do. A folder is moved with its files and subfolders.
+//
+// The returned items identify the objects in the trash; pass their TrashID
+// to RestoreFromTrash to bring them back, or to PurgeTrash to delete them.
+//
+// Example:
+//
+//	resp, err := client.MoveToTrash(ctx, &sdk.TrashMoveRequest{
+//		FileIDs:  []sdk.FileID{"file-id-123"},
+//		TableIDs: []sdk.TableID{456},
+//	})
+func (c *RawClient) MoveToTrash(ctx context.Context, req *TrashMoveRequest, opts ...CallOption) (*TrashMoveResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp TrashMoveResponse
+	if err := c.postJSON(ctx, "/catalog/trash/move", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	for _, id := range req.TableIDs {
+		c.forgetResolved(ResolvedPath{TableID: id})
+	}
+	return &resp, nil
+}
+
+// ListTrash lists the items of the trash, most recently deleted first.
+//
+// Example:
+//
+//	resp, err := client.ListTrash(ctx, &sdk.TrashListRequest{
+//		ObjectTypes: []string{sdk.TrashObjectTable},
+//	})
+//	for _, item := range resp.List {
+//		fmt.Println(item.Name, item.DeletedAt)
+//	}
+func (c *RawClient) ListTrash(ctx context.Context, req *TrashListRequest, opts ...CallOption) (*TrashListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp TrashListResponse
+	if err := c.postJSON(ctx, "/catalog/trash/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RestoreFromTrash restores the specified items of the trash to where they
+// were deleted from. Restoring an object whose name has been taken in the
+// meantime fails with an error matching ErrConflict.
+//
+// Example:
+//
+//	resp, err := client.RestoreFromTrash(ctx, &sdk.TrashRestoreRequest{
+//		TrashIDs: []sdk.TrashID{item.TrashID},
+//	})
+func (c *RawClient) RestoreFromTrash(ctx context.Context, req *TrashRestoreRequest, opts ...CallOption) (*TrashRestoreResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp TrashRestoreResponse
+	if err := c.postJSON(ctx, "/catalog/trash/restore", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PurgeTrash permanently deletes the specified items of the trash, or the
+// whole trash if All is set. Purged items cannot be restored.
+//
+// Example:
+//
+//	resp, err := client.PurgeTrash(ctx, &sdk.TrashPurgeRequest{All: true})
+//	fmt.Println("purged", resp.Purged)
+func (c *RawClient) PurgeTrash(ctx context.Context, req *TrashPurgeRequest, opts ...CallOption) (*TrashPurgeResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp TrashPurgeResponse
+	if err := c.postJSON(ctx, "/catalog/trash/purge", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrash(t *testing.T) {
+	t.Parallel()
+	item := TrashItem{TrashID: "tr-1", ObjectType: TrashObjectTable, ObjectID: "456", Name: "orders", DatabaseID: 2}
+	var restored TrashRestoreRequest
+	var purged TrashPurgeRequest
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/trash/move": func(w http.ResponseWriter, r *http.Request) {
+			var req TrashMoveRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, []TableID{456}, req.TableIDs)
+			writeEnvelope(w, TrashMoveResponse{Items: []TrashItem{item}})
+		},
+		"/catalog/trash/list": func(w http.ResponseWriter, r *http.Request) {
+			var req TrashListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, []string{TrashObjectTable}, req.ObjectTypes)
+			writeEnvelope(w, TrashListResponse{Total: 1, List: []TrashItem{item}})
+		},
+		"/catalog/trash/restore": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&restored))
+			writeEnvelope(w, TrashRestoreResponse{Items: []TrashItem{item}})
+		},
+		"/catalog/trash/purge": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&purged))
+			writeEnvelope(w, TrashPurgeResponse{Purged: 1})
+		},
+	})
+	ctx := context.Background()
+
+	moved, err := client.MoveToTrash(ctx, &TrashMoveRequest{TableIDs: []TableID{456}})
+	require.NoError(t, err)
+	require.Equal(t, []TrashItem{item}, moved.Items)
+
+	list, err := client.ListTrash(ctx, &TrashListRequest{ObjectTypes: []string{TrashObjectTable}})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Total)
+
+	_, err = client.RestoreFromTrash(ctx, &TrashRestoreRequest{TrashIDs: []TrashID{"tr-1"}})
+	require.NoError(t, err)
+	require.Equal(t, []TrashID{"tr-1"}, restored.TrashIDs)
+
+	resp, err := client.PurgeTrash(ctx, &TrashPurgeRequest{All: true})
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Purged)
+	require.True(t, purged.All)
+}
+
+func TestTrashValidation(t *testing.T) {
+	t.Parallel()
+	client := newMockClient(t, nil)
+	ctx := context.Background()
+
+	_, err := client.MoveToTrash(ctx, &TrashMoveRequest{})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.RestoreFromTrash(ctx, &TrashRestoreRequest{})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.PurgeTrash(ctx, &TrashPurgeRequest{})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.PurgeTrash(ctx, &TrashPurgeRequest{TrashIDs: []TrashID{"tr-1"}, All: true})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	_, err = client.ListTrash(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+}
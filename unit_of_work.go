@@ -0,0 +1,236 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// UnitOfWork groups the steps of a composite operation so that the
+// resources created by the completed steps are removed again when a later
+// step fails. Each step registers a compensation, such as deleting the
+// table it created, with OnRollback; Rollback runs the compensations in
+// reverse order and Commit discards them.
+//
+// The Create methods create a resource and register its deletion in one
+// call. Create a UnitOfWork with NewUnitOfWork, or use RunUnitOfWork to
+// commit or roll back automatically. A UnitOfWork is safe for concurrent
+// use.
+type UnitOfWork struct {
+	client *SDKClient
+
+	mu    sync.Mutex
+	steps []compensation
+	done  bool
+}
+
+type compensation struct {
+	name string
+	undo func(ctx context.Context) error
+}
+
+// CompensationError is the failure of one compensation during Rollback.
+type CompensationError struct {
+	// Name describes the compensation, such as `delete table "orders"`.
+	Name string
+	Err  error
+}
+
+func (e *CompensationError) Error() string { return e.Name + ": " + e.Err.Error() }
+
+func (e *CompensationError) Unwrap() error { return e.Err }
+
+// RollbackError is returned by Rollback when some compensations failed,
+// leaving the resources they should have removed in place. The other
+// compensations have still run.
+type RollbackError struct {
+	Failed []*CompensationError
+}
+
+func (e *RollbackError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "sdk: %d compensations failed during rollback", len(e.Failed))
+	for _, f := range e.Failed {
+		fmt.Fprintf(&b, "; %v", f)
+	}
+	return b.String()
+}
+
+func (e *RollbackError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, f := range e.Failed {
+		errs[i] = f
+	}
+	return errs
+}
+
+// NewUnitOfWork returns an empty unit of work bound to the client.
+//
+// Example:
+//
+//	uow := sdkClient.NewUnitOfWork()
+//	dbID, err := uow.CreateDatabase(ctx, &sdk.DatabaseCreateRequest{CatalogID: catalogID, DatabaseName: "sales"})
+//	if err != nil {
+//		return err
+//	}
+//	if _, err := uow.CreateTable(ctx, &sdk.TableCreateRequest{DatabaseID: dbID, Name: "orders", Columns: cols}); err != nil {
+//		return errors.Join(err, uow.Rollback(ctx))
+//	}
+//	uow.Commit()
+func (c *SDKClient) NewUnitOfWork() *UnitOfWork {
+	return &UnitOfWork{client: c}
+}
+
+// RunUnitOfWork runs fn with a new unit of work. If fn returns an error or
+// panics, the compensations registered so far are run and the error of fn
+// is returned, joined with the *RollbackError if the rollback failed;
+// otherwise the unit of work is committed.
+//
+// Example:
+//
+//	err := sdkClient.RunUnitOfWork(ctx, func(ctx context.Context, uow *sdk.UnitOfWork) error {
+//		dbID, err := uow.CreateDatabase(ctx, &sdk.DatabaseCreateRequest{CatalogID: catalogID, DatabaseName: "sales"})
+//		if err != nil {
+//			return err
+//		}
+//		_, err = uow.CreateVolume(ctx, &sdk.VolumeCreateRequest{DatabaseID: dbID, Name: "docs"})
+//		return err
+//	})
+func (c *SDKClient) RunUnitOfWork(ctx context.Context, fn func(ctx context.Context, uow *UnitOfWork) error) (err error) {
+	uow := c.NewUnitOfWork()
+	defer func() {
+		if r := recover(); r != nil {
+			_ = uow.Rollback(ctx)
+			panic(r)
+		}
+	}()
+	if err := fn(ctx, uow); err != nil {
+		return errors.Join(err, uow.Rollback(ctx))
+	}
+	uow.Commit()
+	return nil
+}
+
+// OnRollback registers a compensation that undoes a completed step. name
+// describes it in errors. Registering after Commit or Rollback does
+// nothing.
+func (u *UnitOfWork) OnRollback(name string, undo func(ctx context.Context) error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if !u.done {
+		u.steps = append(u.steps, compensation{name: name, undo: undo})
+	}
+}
+
+// Commit discards the registered compensations, keeping every resource
+// created by the unit of work.
+func (u *UnitOfWork) Commit() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.steps, u.done = nil, true
+}
+
+// Rollback runs the registered compensations, last registered first. A
+// failing compensation does not stop the others; their errors are
+// returned as a *RollbackError. The compensations run even if ctx is
+// cancelled, since a cancellation is often what made the work fail.
+// Calling Rollback again, or after Commit, does nothing.
+func (u *UnitOfWork) Rollback(ctx context.Context) error {
+	u.mu.Lock()
+	steps := u.steps
+	u.steps, u.done = nil, true
+	u.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	var failed []*CompensationError
+	for i := len(steps) - 1; i >= 0; i-- {
+		if err := steps[i].undo(ctx); err != nil {
+			failed = append(failed, &CompensationError{Name: steps[i].name, Err: err})
+		}
+	}
+	if len(failed) > 0 {
+		return &RollbackError{Failed: failed}
+	}
+	return nil
+}
+
+// CreateCatalog creates a catalog that is deleted on rollback.
+func (u *UnitOfWork) CreateCatalog(ctx context.Context, req *CatalogCreateRequest, opts ...CallOption) (CatalogID, error) {
+	resp, err := u.client.raw.CreateCatalog(ctx, req, opts...)
+	if err != nil {
+		return 0, err
+	}
+	u.OnRollback(fmt.Sprintf("delete catalog %q", req.CatalogName), func(ctx context.Context) error {
+		_, err := u.client.raw.DeleteCatalog(ctx, &CatalogDeleteRequest{CatalogID: resp.CatalogID}, opts...)
+		return err
+	})
+	return resp.CatalogID, nil
+}
+
+// CreateDatabase creates a database that is deleted on rollback.
+func (u *UnitOfWork) CreateDatabase(ctx context.Context, req *DatabaseCreateRequest, opts ...CallOption) (DatabaseID, error) {
+	resp, err := u.client.raw.CreateDatabase(ctx, req, opts...)
+	if err != nil {
+		return 0, err
+	}
+	u.OnRollback(fmt.Sprintf("delete database %q", req.DatabaseName), func(ctx context.Context) error {
+		_, err := u.client.raw.DeleteDatabase(ctx, &DatabaseDeleteRequest{DatabaseID: resp.DatabaseID}, opts...)
+		return err
+	})
+	return resp.DatabaseID, nil
+}
+
+// CreateTable creates a table that is deleted on rollback.
+func (u *UnitOfWork) CreateTable(ctx context.Context, req *TableCreateRequest, opts ...CallOption) (TableID, error) {
+	resp, err := u.client.raw.CreateTable(ctx, req, opts...)
+	if err != nil {
+		return 0, err
+	}
+	u.OnRollback(fmt.Sprintf("delete table %q", req.Name), func(ctx context.Context) error {
+		_, err := u.client.raw.DeleteTable(ctx, &TableDeleteRequest{TableID: resp.TableID}, opts...)
+		return err
+	})
+	return resp.TableID, nil
+}
+
+// CreateVolume creates a volume that is deleted on rollback.
+func (u *UnitOfWork) CreateVolume(ctx context.Context, req *VolumeCreateRequest, opts ...CallOption) (VolumeID, error) {
+	resp, err := u.client.raw.CreateVolume(ctx, req, opts...)
+	if err != nil {
+		return "", err
+	}
+	u.OnRollback(fmt.Sprintf("delete volume %q", req.Name), func(ctx context.Context) error {
+		_, err := u.client.raw.DeleteVolume(ctx, &VolumeDeleteRequest{VolumeID: resp.VolumeID}, opts...)
+		return err
+	})
+	return resp.VolumeID, nil
+}
+
+// CreateFolder creates a folder that is deleted, with its content, on
+// rollback.
+func (u *UnitOfWork) CreateFolder(ctx context.Context, req *FolderCreateRequest, opts ...CallOption) (FileID, error) {
+	resp, err := u.client.raw.CreateFolder(ctx, req, opts...)
+	if err != nil {
+		return "", err
+	}
+	u.OnRollback(fmt.Sprintf("delete folder %q", req.Name), func(ctx context.Context) error {
+		_, err := u.client.raw.DeleteFolder(ctx, &FolderDeleteRequest{FolderID: resp.FolderID}, opts...)
+		return err
+	})
+	return resp.FolderID, nil
+}
+
+// CreateRole creates a role that is deleted on rollback.
+func (u *UnitOfWork) CreateRole(ctx context.Context, req *RoleCreateRequest, opts ...CallOption) (RoleID, error) {
+	resp, err := u.client.raw.CreateRole(ctx, req, opts...)
+	if err != nil {
+		return 0, err
+	}
+	u.OnRollback(fmt.Sprintf("delete role %q", req.RoleName), func(ctx context.Context) error {
+		_, err := u.client.raw.DeleteRole(ctx, &RoleDeleteRequest{RoleID: resp.RoleID}, opts...)
+		return err
+	})
+	return resp.RoleID, nil
+}
@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunUnitOfWorkRollback(t *testing.T) {
+	t.Parallel()
+	var deleted []string
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/database/create": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseCreateResponse{DatabaseID: 7})
+		},
+		"/catalog/table/create": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, TableCreateResponse{TableID: 10})
+		},
+		"/catalog/volume/create": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelopeError(w, "ErrAlreadyExists", "volume exists")
+		},
+		"/catalog/table/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req TableDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			deleted = append(deleted, "table")
+			writeEnvelope(w, TableDeleteResponse{})
+		},
+		"/catalog/database/delete": func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, "database")
+			writeEnvelopeError(w, "ErrPermissionDenied", "read-only")
+		},
+	}))
+
+	err := client.RunUnitOfWork(context.Background(), func(ctx context.Context, uow *UnitOfWork) error {
+		dbID, err := uow.CreateDatabase(ctx, &DatabaseCreateRequest{CatalogID: 1, DatabaseName: "sales"})
+		if err != nil {
+			return err
+		}
+		if _, err := uow.CreateTable(ctx, &TableCreateRequest{DatabaseID: dbID, Name: "orders"}); err != nil {
+			return err
+		}
+		_, err = uow.CreateVolume(ctx, &VolumeCreateRequest{DatabaseID: dbID, Name: "docs"})
+		return err
+	})
+	require.ErrorIs(t, err, ErrConflict)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	var rbErr *RollbackError
+	require.ErrorAs(t, err, &rbErr)
+	require.Len(t, rbErr.Failed, 1)
+	require.Equal(t, `delete database "sales"`, rbErr.Failed[0].Name)
+	require.Equal(t, []string{"table", "database"}, deleted)
+}
+
+func TestUnitOfWorkCommit(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, nil))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var undone []string
+	uow := client.NewUnitOfWork()
+	uow.OnRollback("first", func(ctx context.Context) error { undone = append(undone, "first"); return nil })
+	uow.OnRollback("second", func(ctx context.Context) error {
+		require.NoError(t, ctx.Err())
+		undone = append(undone, "second")
+		return nil
+	})
+	cancel()
+	require.NoError(t, uow.Rollback(ctx))
+	require.Equal(t, []string{"second", "first"}, undone)
+	require.NoError(t, uow.Rollback(ctx))
+
+	undone = nil
+	err := client.RunUnitOfWork(context.Background(), func(ctx context.Context, uow *UnitOfWork) error {
+		uow.OnRollback("step", func(ctx context.Context) error { return errors.New("unexpected") })
+		return nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, undone)
+}
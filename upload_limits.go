@@ -0,0 +1,261 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// throttleChunkSize is the largest read a throttled body passes through at
+// once, which keeps the rate smooth for large reads.
+const throttleChunkSize = 32 << 10
+
+// UploadLimits bounds the uploads made by one call. See WithUploadLimits.
+type UploadLimits struct {
+	// MaxParallel is the maximum number of upload requests in flight.
+	// Defaults to 1.
+	MaxParallel int
+	// MaxFilesPerRequest is the maximum number of files sent in one
+	// multipart request. 0 means no limit.
+	MaxFilesPerRequest int
+	// MaxBytesPerRequest is the maximum total size of the files sent in one
+	// multipart request. A file larger than it is sent on its own. 0 means
+	// no limit.
+	MaxBytesPerRequest int64
+	// BytesPerSecond caps the combined rate at which request bodies are
+	// sent, across all requests of the call. 0 means no cap.
+	BytesPerSecond int64
+}
+
+// WithUploadLimits splits and throttles the uploads of a call.
+//
+// ImportLocalFilesToVolume, which otherwise sends every file in a single
+// request, sends its files in several multipart requests that respect
+// MaxFilesPerRequest and MaxBytesPerRequest, with up to MaxParallel of them
+// in flight. Each request starts its own load task: the TaskId of the
+// merged response is the first one, and UploadFileResponse.Batches has
+// them all. BytesPerSecond applies to any call that sends a request body,
+// including UploadConnectorFile and chunked uploads.
+//
+// Example:
+//
+//	resp, err := sdkClient.ImportLocalFilesToVolume(ctx, paths, volumeID, nil, nil,
+//		sdk.WithUploadLimits(sdk.UploadLimits{
+//			MaxParallel:        4,
+//			MaxBytesPerRequest: 64 << 20,
+//			BytesPerSecond:     10 << 20,
+//		}))
+func WithUploadLimits(limits UploadLimits) CallOption {
+	var limiter *bandwidthLimiter
+	if limits.BytesPerSecond > 0 {
+		limiter = &bandwidthLimiter{rate: limits.BytesPerSecond}
+	}
+	return func(co *callOptions) {
+		l := limits
+		co.uploadLimits = &l
+		co.bandwidth = limiter
+	}
+}
+
+// bandwidthLimiter spaces out the bytes sent through it so that they
+// average at most rate bytes per second.
+type bandwidthLimiter struct {
+	rate int64
+
+	mu sync.Mutex
+	// next is when the bytes reserved so far will have been sent at rate.
+	next time.Time
+}
+
+// wait accounts for n bytes just read, blocking for as long as the bytes
+// reserved before them need at the configured rate.
+func (l *bandwidthLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(n) * time.Second / time.Duration(l.rate))
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// throttledBody is a request body read no faster than its limiter allows.
+type throttledBody struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *bandwidthLimiter
+}
+
+func (b *throttledBody) Read(p []byte) (int, error) {
+	if len(p) > throttleChunkSize {
+		p = p[:throttleChunkSize]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		if werr := b.limiter.wait(b.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// throttleRequest makes the body of req respect the bandwidth limit of
+// opts, if any.
+func throttleRequest(req *http.Request, opts callOptions) {
+	if opts.bandwidth == nil || req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+	req.Body = &throttledBody{ReadCloser: req.Body, ctx: req.Context(), limiter: opts.bandwidth}
+}
+
+// localUpload is a local file queued by ImportLocalFilesToVolume.
+type localUpload struct {
+	path string
+	meta FileMeta
+	size int64
+}
+
+// batchLocalUploads groups files into requests that respect limits,
+// keeping their order.
+func batchLocalUploads(files []localUpload, limits *UploadLimits) [][]localUpload {
+	if limits == nil || (limits.MaxFilesPerRequest <= 0 && limits.MaxBytesPerRequest <= 0) {
+		return [][]localUpload{files}
+	}
+	var (
+		batches [][]localUpload
+		current []localUpload
+		size    int64
+	)
+	for _, f := range files {
+		full := limits.MaxFilesPerRequest > 0 && len(current) >= limits.MaxFilesPerRequest
+		tooBig := limits.MaxBytesPerRequest > 0 && size+f.size > limits.MaxBytesPerRequest
+		if len(current) > 0 && (full || tooBig) {
+			batches = append(batches, current)
+			current, size = nil, 0
+		}
+		current = append(current, f)
+		size += f.size
+	}
+	return append(batches, current)
+}
+
+// uploadLocalBatch uploads one batch of local files in a single request.
+func (c *SDKClient) uploadLocalBatch(ctx context.Context, volumeID VolumeID, batch []localUpload, dedup *DedupConfig, opts []CallOption) (*UploadFileResponse, error) {
+	files := make([]FileUploadItem, 0, len(batch))
+	metas := make([]FileMeta, 0, len(batch))
+	for _, f := range batch {
+		file, err := os.Open(f.path)
+		if err != nil {
+			return nil, fmt.Errorf("open file %s: %w", f.path, err)
+		}
+		defer file.Close()
+		files = append(files, FileUploadItem{File: file, FileName: f.meta.Filename})
+		metas = append(metas, f.meta)
+	}
+	return c.raw.UploadConnectorFile(ctx, &UploadFileRequest{
+		VolumeID:    volumeID,
+		Files:       files,
+		Meta:        metas,
+		DedupConfig: dedup,
+	}, opts...)
+}
+
+// uploadLocalBatches uploads batches with up to parallel requests in flight
+// and merges their responses. The first failure cancels the batches not yet
+// sent. Upload progress is reported for all batches together.
+func (c *SDKClient) uploadLocalBatches(ctx context.Context, volumeID VolumeID, batches [][]localUpload, dedup *DedupConfig, parallel int, opts []CallOption) (*UploadFileResponse, error) {
+	if parallel <= 0 {
+		parallel = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sizes := make([]int64, len(batches))
+	for i, batch := range batches {
+		for _, f := range batch {
+			sizes[i] += f.size
+		}
+	}
+	progress := newUploadProgress(newCallOptions(opts...).uploadProgress, sizes)
+
+	responses := make([]*UploadFileResponse, len(batches))
+	var (
+		mu       sync.Mutex
+		failed   = -1
+		firstErr error
+	)
+	fail := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failed < 0 {
+			failed, firstErr = i, err
+		}
+		cancel()
+	}
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(i, ctx.Err())
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := c.uploadLocalBatch(ctx, volumeID, batch, dedup, progress.partOptions(i, opts))
+			if err != nil {
+				fail(i, err)
+				return
+			}
+			responses[i] = resp
+		}()
+	}
+	wg.Wait()
+
+	merged := &UploadFileResponse{Success: true}
+	var messages []string
+	for i, resp := range responses {
+		if resp == nil {
+			merged.Success = false
+			continue
+		}
+		merged.Batches = append(merged.Batches, resp)
+		if merged.FileID == "" {
+			merged.FileID = resp.FileID
+		}
+		if merged.TaskId == 0 {
+			merged.TaskId = resp.TaskId
+		}
+		merged.Results = append(merged.Results, resp.Results...)
+		merged.Success = merged.Success && resp.Success
+		if resp.Message != "" {
+			messages = append(messages, fmt.Sprintf("batch %d: %s", i+1, resp.Message))
+		}
+	}
+	merged.Message = strings.Join(messages, "; ")
+	if firstErr != nil {
+		return merged, fmt.Errorf("upload batch %d of %d (%s): %w", failed+1, len(batches), filepath.Base(batches[failed][0].path), firstErr)
+	}
+	return merged, nil
+}
@@ -0,0 +1,187 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchLocalUploads(t *testing.T) {
+	t.Parallel()
+	files := []localUpload{{path: "a", size: 40}, {path: "b", size: 40}, {path: "c", size: 100}, {path: "d", size: 10}, {path: "e", size: 10}}
+	names := func(batches [][]localUpload) [][]string {
+		var out [][]string
+		for _, b := range batches {
+			var names []string
+			for _, f := range b {
+				names = append(names, f.path)
+			}
+			out = append(out, names)
+		}
+		return out
+	}
+	require.Equal(t, [][]string{{"a", "b", "c", "d", "e"}}, names(batchLocalUploads(files, nil)))
+	require.Equal(t, [][]string{{"a", "b"}, {"c"}, {"d", "e"}}, names(batchLocalUploads(files, &UploadLimits{MaxBytesPerRequest: 90})))
+	require.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, names(batchLocalUploads(files, &UploadLimits{MaxFilesPerRequest: 2})))
+}
+
+func TestImportLocalFilesToVolumeBatches(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	var paths []string
+	for i := range 5 {
+		p := filepath.Join(dir, fmt.Sprintf("f%d.txt", i))
+		require.NoError(t, os.WriteFile(p, []byte("0123456789"), 0o644))
+		paths = append(paths, p)
+	}
+
+	var (
+		mu       sync.Mutex
+		requests [][]string
+		inFlight int
+		maxSeen  int
+	)
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			maxSeen = max(maxSeen, inFlight)
+			mu.Unlock()
+			time.Sleep(20 * time.Millisecond)
+
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			var meta []FileMeta
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("meta")), &meta))
+			var names []string
+			var results []*FileUploadResult
+			for _, m := range meta {
+				names = append(names, m.Filename)
+				results = append(results, &FileUploadResult{FileID: "id-" + m.Filename, Success: true})
+			}
+			mu.Lock()
+			requests = append(requests, names)
+			inFlight--
+			mu.Unlock()
+			writeEnvelope(w, UploadFileResponse{Success: true, TaskId: 7, Results: results})
+		},
+	}))
+
+	resp, err := client.ImportLocalFilesToVolume(context.Background(), paths, "v1", nil, nil,
+		WithUploadLimits(UploadLimits{MaxParallel: 2, MaxFilesPerRequest: 2}))
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	require.Len(t, resp.Batches, 3)
+	require.Len(t, resp.Results, 5)
+	require.Equal(t, "id-f0.txt", resp.Results[0].FileID)
+	require.Equal(t, int64(7), resp.TaskId)
+	require.Equal(t, 2, maxSeen)
+	sort.Slice(requests, func(i, j int) bool { return requests[i][0] < requests[j][0] })
+	require.Equal(t, [][]string{{"f0.txt", "f1.txt"}, {"f2.txt", "f3.txt"}, {"f4.txt"}}, requests)
+}
+
+func TestImportLocalFilesToVolumeBatchFailure(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	var paths []string
+	for i := range 3 {
+		p := filepath.Join(dir, fmt.Sprintf("f%d.txt", i))
+		require.NoError(t, os.WriteFile(p, []byte("x"), 0o644))
+		paths = append(paths, p)
+	}
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			var meta []FileMeta
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("meta")), &meta))
+			if meta[0].Filename == "f1.txt" {
+				writeEnvelopeError(w, "ErrPermissionDenied", "quota exceeded")
+				return
+			}
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	}))
+
+	resp, err := client.ImportLocalFilesToVolume(context.Background(), paths, "v1", nil, nil,
+		WithUploadLimits(UploadLimits{MaxFilesPerRequest: 1}))
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.ErrorContains(t, err, "batch 2 of 3 (f1.txt)")
+	require.False(t, resp.Success)
+	require.Len(t, resp.Batches, 1)
+}
+
+func TestUploadBandwidthLimit(t *testing.T) {
+	t.Parallel()
+	payload := make([]byte, 64<<10)
+	var received int
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received = len(body)
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	})
+
+	start := time.Now()
+	_, err := client.UploadConnectorFile(context.Background(), &UploadFileRequest{
+		VolumeID: "v1",
+		Files:    []FileUploadItem{{File: bytes.NewReader(payload), FileName: "big.bin"}},
+	}, WithUploadLimits(UploadLimits{BytesPerSecond: 256 << 10}))
+	require.NoError(t, err)
+	require.Greater(t, received, len(payload))
+	// 64 KiB at 256 KiB/s: the second 32 KiB chunk waits about 125ms.
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestImportLocalFilesToVolumeSingleRequest(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	var paths []string
+	for i := range 40 {
+		p := filepath.Join(dir, fmt.Sprintf("f%02d.txt", i))
+		require.NoError(t, os.WriteFile(p, []byte(fmt.Sprintf("content %d", i)), 0o644))
+		paths = append(paths, p)
+	}
+
+	var (
+		mu       sync.Mutex
+		sizes    []int
+		contents = map[string]string{}
+	)
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.Positive(t, r.ContentLength)
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			mu.Lock()
+			defer mu.Unlock()
+			sizes = append(sizes, len(r.MultipartForm.File["file"]))
+			for _, fh := range r.MultipartForm.File["file"] {
+				f, err := fh.Open()
+				require.NoError(t, err)
+				data, _ := io.ReadAll(f)
+				contents[fh.Filename] = string(data)
+			}
+			writeEnvelope(w, UploadFileResponse{Success: true, TaskId: 7})
+		},
+	}))
+
+	// Without WithUploadLimits every file goes in one request, so TaskId
+	// covers them all.
+	resp, err := client.ImportLocalFilesToVolume(context.Background(), paths, "v1", nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, resp.Batches)
+	require.EqualValues(t, 7, resp.TaskId)
+	require.Equal(t, []int{40}, sizes)
+	require.Len(t, contents, 40)
+	require.Equal(t, "content 39", contents["f39.txt"])
+}
@@ -0,0 +1,102 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// contextReader stops reading once its context is done, so that building a
+// large multipart body aborts promptly when the call is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// trackUploadProgress reports the bytes of the body of req read by the
+// transport to the callback registered with WithUploadProgress, if any.
+func trackUploadProgress(req *http.Request, opts callOptions) {
+	if opts.uploadProgress == nil || req.Body == nil || req.Body == http.NoBody {
+		return
+	}
+	total := req.ContentLength
+	if total <= 0 {
+		total = -1
+	}
+	req.Body = &progressReader{rc: req.Body, total: total, fn: opts.uploadProgress}
+}
+
+// uploadProgress combines the progress of the parts of one upload, such as
+// the requests of a batched upload or the chunks of a chunked one, into a
+// single running total. Each part has a slot holding its bytes sent and its
+// expected size. The callback is never called concurrently.
+type uploadProgress struct {
+	fn ProgressFunc
+
+	mu    sync.Mutex
+	sent  []int64
+	total []int64
+}
+
+// newUploadProgress returns an aggregator for parts of the given expected
+// sizes, or nil if fn is nil. The methods of a nil aggregator do nothing.
+func newUploadProgress(fn ProgressFunc, totals []int64) *uploadProgress {
+	if fn == nil {
+		return nil
+	}
+	return &uploadProgress{fn: fn, sent: make([]int64, len(totals)), total: append([]int64(nil), totals...)}
+}
+
+// set records the progress of part i; a non-negative total replaces its
+// expected size.
+func (p *uploadProgress) set(i int, sent, total int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent[i] = sent
+	if total >= 0 {
+		p.total[i] = total
+	}
+	p.report()
+}
+
+// add records n more bytes sent for part i.
+func (p *uploadProgress) add(i int, n int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent[i] += n
+	p.report()
+}
+
+func (p *uploadProgress) report() {
+	var sent, total int64
+	for i := range p.sent {
+		sent += p.sent[i]
+		total += p.total[i]
+	}
+	p.fn(sent, total)
+}
+
+// partOptions returns opts with the progress of their requests reported to
+// part i instead of to the callback of the call.
+func (p *uploadProgress) partOptions(i int, opts []CallOption) []CallOption {
+	if p == nil {
+		return opts
+	}
+	return append(opts[:len(opts):len(opts)], WithUploadProgress(func(sent, total int64) {
+		p.set(i, sent, total)
+	}))
+}
@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// progressRecorder collects the calls of an upload progress callback.
+type progressRecorder struct {
+	mu    sync.Mutex
+	calls [][2]int64
+}
+
+func (p *progressRecorder) record(sent, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, [2]int64{sent, total})
+}
+
+// requireComplete checks that sent never decreased and ended at total.
+func (p *progressRecorder) requireComplete(t *testing.T, total int64) {
+	t.Helper()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	require.NotEmpty(t, p.calls)
+	for i := 1; i < len(p.calls); i++ {
+		require.GreaterOrEqual(t, p.calls[i][0], p.calls[i-1][0])
+	}
+	require.Equal(t, [2]int64{total, total}, p.calls[len(p.calls)-1])
+}
+
+func TestUploadConnectorFileProgress(t *testing.T) {
+	t.Parallel()
+	var bodySize int64
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			n, err := io.Copy(io.Discard, r.Body)
+			require.NoError(t, err)
+			bodySize = n
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	})
+
+	var progress progressRecorder
+	content := strings.Repeat("x", 200<<10)
+	_, err := client.UploadConnectorFile(context.Background(), &UploadFileRequest{
+		VolumeID: "v1",
+		Files:    []FileUploadItem{{File: strings.NewReader(content), FileName: "a.bin"}},
+		Meta:     []FileMeta{{Filename: "a.bin", Path: "a.bin"}},
+	}, WithUploadProgress(progress.record))
+	require.NoError(t, err)
+	require.Greater(t, bodySize, int64(len(content)))
+	progress.requireComplete(t, bodySize)
+}
+
+func TestUploadLocalFilesProgress(t *testing.T) {
+	t.Parallel()
+	var bodySize int64
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/file/upload": func(w http.ResponseWriter, r *http.Request) {
+			bodySize, _ = io.Copy(io.Discard, r.Body)
+			writeEnvelope(w, LocalFileUploadResponse{ConnFileIds: []string{"c1"}})
+		},
+	})
+
+	var progress progressRecorder
+	_, err := client.UploadLocalFiles(context.Background(),
+		[]FileUploadItem{{File: strings.NewReader("a,b\n1,2\n"), FileName: "a.csv"}},
+		[]FileMeta{{Filename: "a.csv", Path: "/"}},
+		WithUploadProgress(progress.record))
+	require.NoError(t, err)
+	progress.requireComplete(t, bodySize)
+}
+
+func TestUploadProgressCancelled(t *testing.T) {
+	t.Parallel()
+	called := false
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.UploadConnectorFile(ctx, &UploadFileRequest{
+		VolumeID: "v1",
+		Files:    []FileUploadItem{{File: strings.NewReader("data"), FileName: "a.txt"}},
+	}, WithUploadProgress(func(int64, int64) {}))
+	require.True(t, errors.Is(err, context.Canceled), "got %v", err)
+	require.False(t, called)
+}
+
+func TestUploadConnectorFileChunkedProgress(t *testing.T) {
+	_, client := newFakeChunkServer(t)
+	a, b := strings.Repeat("a", 50), strings.Repeat("b", 30)
+
+	var progress progressRecorder
+	_, err := client.UploadConnectorFile(context.Background(), &UploadFileRequest{
+		VolumeID: "v1",
+		Files: []FileUploadItem{
+			{File: strings.NewReader(a), FileName: "a.txt"},
+			{File: strings.NewReader(b), FileName: "b.txt"},
+		},
+	}, WithChunkedUpload(ChunkedUploadOptions{ChunkSize: 16, Parallelism: 2}), WithUploadProgress(progress.record))
+	require.NoError(t, err)
+	progress.requireComplete(t, int64(len(a)+len(b)))
+	// One report per part: 4 parts of a.txt and 2 of b.txt.
+	require.Len(t, progress.calls, 6)
+}
+
+func TestImportLocalFilesToVolumeBatchProgress(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	var paths []string
+	for i := range 3 {
+		p := filepath.Join(dir, fmt.Sprintf("f%d.txt", i))
+		require.NoError(t, os.WriteFile(p, []byte(strings.Repeat("z", 1000)), 0o644))
+		paths = append(paths, p)
+	}
+
+	var (
+		mu       sync.Mutex
+		bodySize int64
+	)
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			n, _ := io.Copy(io.Discard, r.Body)
+			mu.Lock()
+			bodySize += n
+			mu.Unlock()
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	}))
+
+	var progress progressRecorder
+	_, err := client.ImportLocalFilesToVolume(context.Background(), paths, "v1", nil, nil,
+		WithUploadLimits(UploadLimits{MaxParallel: 3, MaxFilesPerRequest: 1}),
+		WithUploadProgress(progress.record))
+	require.NoError(t, err)
+	progress.requireComplete(t, bodySize)
+}
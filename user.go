@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"context"
+	"fmt"
+	"strings"
 )
 
 // CreateUser creates a new user account.
@@ -23,6 +25,13 @@ func (c *RawClient) CreateUser(ctx context.Context, req *UserCreateRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := withNormalizedName(c, ResourceUser, req, func(r *UserCreateRequest) *string { return &r.UserName })
+	if err != nil {
+		return nil, err
+	}
 	var resp UserCreateResponse
 	if err := c.postJSON(ctx, "/user/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -43,6 +52,9 @@ func (c *RawClient) DeleteUser(ctx context.Context, req *UserDeleteUserRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserDeleteUserResponse
 	if err := c.postJSON(ctx, "/user/delete", req, &resp, opts...); err != nil {
 		return nil, err
@@ -67,6 +79,9 @@ func (c *RawClient) GetUserDetail(ctx context.Context, req *UserDetailInfoReques
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserDetailInfoResponse
 	if err := c.postJSON(ctx, "/user/detail_info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -97,6 +112,9 @@ func (c *RawClient) ListUsers(ctx context.Context, req *UserListRequest, opts ..
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserListResponse
 	if err := c.postJSON(ctx, "/user/list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -104,6 +122,38 @@ func (c *RawClient) ListUsers(ctx context.Context, req *UserListRequest, opts ..
 	return &resp, nil
 }
 
+// GetUserByName retrieves the user named name with a single request,
+// filtering the user list on the exact name. The comparison is
+// case-sensitive. It returns an error matching ErrNotFound if there is no
+// such user.
+//
+// Example:
+//
+//	user, err := client.GetUserByName(ctx, "alice")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("User %s has ID %d\n", user.Name, user.ID)
+func (c *RawClient) GetUserByName(ctx context.Context, name string, opts ...CallOption) (*UserResponse, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, invalidArgument("name", "user name is required")
+	}
+	cond, err := NewQuery().Filter("name", Eq, name).Page(1, nameLookupPageSize).Build()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.ListUsers(ctx, &UserListRequest{CommonCondition: cond}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.List {
+		if resp.List[i].Name == name {
+			return &resp.List[i], nil
+		}
+	}
+	return nil, &classifiedError{msg: fmt.Sprintf("sdk: user %q not found", name), class: ErrNotFound}
+}
+
 // UpdateUserPassword updates the password for the specified user.
 //
 // This operation requires appropriate permissions to change another user's password.
@@ -118,6 +168,9 @@ func (c *RawClient) UpdateUserPassword(ctx context.Context, req *UserUpdatePassw
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserUpdatePasswordResponse
 	if err := c.postJSON(ctx, "/user/update_password", req, &resp, opts...); err != nil {
 		return nil, err
@@ -140,6 +193,9 @@ func (c *RawClient) UpdateUserInfo(ctx context.Context, req *UserUpdateInfoReque
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserUpdateInfoResponse
 	if err := c.postJSON(ctx, "/user/update_info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -161,6 +217,9 @@ func (c *RawClient) UpdateUserRoles(ctx context.Context, req *UserUpdateRoleList
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserUpdateRoleListResponse
 	if err := c.postJSON(ctx, "/user/update_role_list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -182,6 +241,9 @@ func (c *RawClient) UpdateUserStatus(ctx context.Context, req *UserUpdateStatusR
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserUpdateStatusResponse
 	if err := c.postJSON(ctx, "/user/update_status", req, &resp, opts...); err != nil {
 		return nil, err
@@ -260,6 +322,9 @@ func (c *RawClient) UpdateMyInfo(ctx context.Context, req *UserMeUpdateInfoReque
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserMeUpdateInfoResponse
 	if err := c.postJSON(ctx, "/user/me/update_info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -281,6 +346,9 @@ func (c *RawClient) UpdateMyPassword(ctx context.Context, req *UserMeUpdatePassw
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp UserMeUpdatePasswordResponse
 	if err := c.postJSON(ctx, "/user/me/update_password", req, &resp, opts...); err != nil {
 		return nil, err
@@ -0,0 +1,180 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// UserSpec describes the desired state of a user for EnsureUser.
+type UserSpec struct {
+	// Name identifies the user (required).
+	Name string
+	// Password is used to create the user, and to reset the password of an
+	// existing user when ResetPassword is set.
+	Password      string
+	ResetPassword bool
+	// RoleIDs are the roles of the user. Nil leaves the roles of an
+	// existing user unchanged; an empty slice removes them all.
+	RoleIDs []RoleID
+	// Phone, Email and Description are updated on an existing user when
+	// they are set.
+	Phone       string
+	Email       string
+	Description string
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// EnsureUserResult reports what EnsureUser did.
+type EnsureUserResult struct {
+	UserID  UserID
+	Created bool
+	// RolesUpdated, InfoUpdated and PasswordReset report the changes made
+	// to an existing user.
+	RolesUpdated  bool
+	InfoUpdated   bool
+	PasswordReset bool
+}
+
+// EnsureUser creates a user if it does not exist, or brings an existing
+// user in line with spec.
+//
+// The user is looked up by name. A missing user is created with the
+// password, roles and details of spec. For an existing user, the roles and
+// details that differ from spec are updated, and the password is reset if
+// spec.ResetPassword is set. Calling EnsureUser again with the same spec
+// changes nothing, which makes it suitable for periodic synchronization.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - spec: the desired state of the user (required)
+//
+// Returns:
+//   - *EnsureUserResult: the user ID and the changes made
+//   - error: any error that occurred
+//
+// Example:
+//
+//	res, err := sdkClient.EnsureUser(ctx, &sdk.UserSpec{
+//		Name:     "alice",
+//		Password: initialPassword,
+//		RoleIDs:  []sdk.RoleID{analystRoleID},
+//		Email:    "alice@example.com",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if res.Created {
+//		fmt.Printf("Created user %d\n", res.UserID)
+//	}
+func (c *SDKClient) EnsureUser(ctx context.Context, spec *UserSpec) (*EnsureUserResult, error) {
+	if spec == nil {
+		return nil, ErrNilRequest
+	}
+	if spec.Name == "" {
+		return nil, fmt.Errorf("user name is required")
+	}
+	name, err := c.raw.NormalizeName(ResourceUser, spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if name != spec.Name {
+		normalized := *spec
+		normalized.Name = name
+		spec = &normalized
+	}
+	opts := spec.CallOptions
+
+	existing, err := c.findUserByName(ctx, spec.Name, opts)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		if spec.Password == "" {
+			return nil, fmt.Errorf("password is required to create user %q", spec.Name)
+		}
+		resp, err := c.raw.CreateUser(ctx, &UserCreateRequest{
+			UserName:    spec.Name,
+			Password:    spec.Password,
+			RoleIDList:  spec.RoleIDs,
+			Description: spec.Description,
+			Phone:       spec.Phone,
+			Email:       spec.Email,
+		}, opts...)
+		if err == nil {
+			return &EnsureUserResult{UserID: resp.UserID, Created: true}, nil
+		}
+		if !errors.Is(err, ErrConflict) {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		// Created concurrently; converge the user that now exists.
+		existing, err = c.findUserByName(ctx, spec.Name, opts)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("user '%s' already exists but could not be retrieved", spec.Name)
+		}
+	}
+
+	result := &EnsureUserResult{UserID: existing.ID}
+	if spec.RoleIDs != nil {
+		current := make([]RoleID, 0, len(existing.RoleList))
+		for _, r := range existing.RoleList {
+			if r != nil {
+				current = append(current, r.ID)
+			}
+		}
+		desired := slices.Clone(spec.RoleIDs)
+		slices.Sort(current)
+		slices.Sort(desired)
+		if !slices.Equal(current, slices.Compact(desired)) {
+			if _, err := c.raw.UpdateUserRoles(ctx, &UserUpdateRoleListRequest{UserID: existing.ID, RoleIDList: spec.RoleIDs}, opts...); err != nil {
+				return result, fmt.Errorf("failed to update user roles: %w", err)
+			}
+			result.RolesUpdated = true
+		}
+	}
+
+	info := &UserUpdateInfoRequest{
+		UserID:      existing.ID,
+		Phone:       valueOr(spec.Phone, existing.Phone),
+		Email:       valueOr(spec.Email, existing.Email),
+		Description: valueOr(spec.Description, existing.Description),
+	}
+	if info.Phone != existing.Phone || info.Email != existing.Email || info.Description != existing.Description {
+		if _, err := c.raw.UpdateUserInfo(ctx, info, opts...); err != nil {
+			return result, fmt.Errorf("failed to update user info: %w", err)
+		}
+		result.InfoUpdated = true
+	}
+
+	if spec.ResetPassword {
+		if spec.Password == "" {
+			return result, fmt.Errorf("password is required to reset the password of user %q", spec.Name)
+		}
+		if _, err := c.raw.UpdateUserPassword(ctx, &UserUpdatePasswordRequest{UserID: existing.ID, Password: spec.Password}, opts...); err != nil {
+			return result, fmt.Errorf("failed to reset user password: %w", err)
+		}
+		result.PasswordReset = true
+	}
+	return result, nil
+}
+
+// findUserByName returns the user named name, or nil if there is none.
+func (c *SDKClient) findUserByName(ctx context.Context, name string, opts []CallOption) (*UserResponse, error) {
+	user, err := c.raw.GetUserByName(ctx, name, opts...)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func valueOr(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,126 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureUserCreatesMissingUser(t *testing.T) {
+	t.Parallel()
+	var created UserCreateRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/user/list": func(w http.ResponseWriter, r *http.Request) {
+			var req UserListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, []string{"alice"}, req.Filters[0].Values)
+			// A fuzzy match on another user must not count.
+			writeEnvelope(w, UserListResponse{Total: 1, List: []UserResponse{{ID: 3, Name: "alice2"}}})
+		},
+		"/user/create": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			writeEnvelope(w, UserCreateResponse{UserID: 7})
+		},
+	}))
+
+	res, err := client.EnsureUser(context.Background(), &UserSpec{
+		Name: "alice", Password: "secret", RoleIDs: []RoleID{1, 2}, Email: "alice@example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &EnsureUserResult{UserID: 7, Created: true}, res)
+	require.Equal(t, UserCreateRequest{
+		UserName: "alice", Password: "secret", RoleIDList: []RoleID{1, 2}, Email: "alice@example.com",
+	}, created)
+}
+
+func TestEnsureUserUpdatesDrift(t *testing.T) {
+	t.Parallel()
+	calls := map[string]any{}
+	record := func(path string, v any) { calls[path] = v }
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/user/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, UserListResponse{Total: 1, List: []UserResponse{{
+				ID: 9, Name: "bob", Phone: "123", Email: "old@example.com", Description: "ops",
+				RoleList: []*RoleIDName{{ID: 2}, {ID: 1}},
+			}}})
+		},
+		"/user/update_role_list": func(w http.ResponseWriter, r *http.Request) {
+			var req UserUpdateRoleListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			record(r.URL.Path, req)
+			writeEnvelope(w, UserUpdateRoleListResponse{UserID: req.UserID})
+		},
+		"/user/update_info": func(w http.ResponseWriter, r *http.Request) {
+			var req UserUpdateInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			record(r.URL.Path, req)
+			writeEnvelope(w, UserUpdateInfoResponse{UserID: req.UserID})
+		},
+		"/user/update_password": func(w http.ResponseWriter, r *http.Request) {
+			var req UserUpdatePasswordRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			record(r.URL.Path, req)
+			writeEnvelope(w, UserUpdatePasswordResponse{UserID: req.UserID})
+		},
+	}))
+	ctx := context.Background()
+
+	// Same roles in another order, same details: nothing to do.
+	res, err := client.EnsureUser(ctx, &UserSpec{Name: "bob", RoleIDs: []RoleID{1, 2}, Phone: "123"})
+	require.NoError(t, err)
+	require.Equal(t, &EnsureUserResult{UserID: 9}, res)
+	require.Empty(t, calls)
+
+	res, err = client.EnsureUser(ctx, &UserSpec{
+		Name: "bob", Password: "new", ResetPassword: true, RoleIDs: []RoleID{3}, Email: "bob@example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &EnsureUserResult{UserID: 9, RolesUpdated: true, InfoUpdated: true, PasswordReset: true}, res)
+	require.Equal(t, map[string]any{
+		"/user/update_role_list": UserUpdateRoleListRequest{UserID: 9, RoleIDList: []RoleID{3}},
+		"/user/update_info":      UserUpdateInfoRequest{UserID: 9, Phone: "123", Email: "bob@example.com", Description: "ops"},
+		"/user/update_password":  UserUpdatePasswordRequest{UserID: 9, Password: "new"},
+	}, calls)
+}
+
+func TestEnsureUserConvergesAfterConflict(t *testing.T) {
+	t.Parallel()
+	lists := 0
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/user/list": func(w http.ResponseWriter, r *http.Request) {
+			lists++
+			if lists == 1 {
+				writeEnvelope(w, UserListResponse{})
+				return
+			}
+			writeEnvelope(w, UserListResponse{Total: 1, List: []UserResponse{{ID: 4, Name: "carol"}}})
+		},
+		"/user/create": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelopeError(w, "ErrAlreadyExists", "user carol already exists")
+		},
+	}))
+
+	res, err := client.EnsureUser(context.Background(), &UserSpec{Name: "carol", Password: "secret"})
+	require.NoError(t, err)
+	require.Equal(t, &EnsureUserResult{UserID: 4}, res)
+}
+
+func TestEnsureUserValidation(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/user/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, UserListResponse{})
+		},
+	}))
+	ctx := context.Background()
+
+	_, err := client.EnsureUser(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.EnsureUser(ctx, &UserSpec{})
+	require.ErrorContains(t, err, "name is required")
+	_, err = client.EnsureUser(ctx, &UserSpec{Name: "dave"})
+	require.ErrorContains(t, err, "password is required")
+}
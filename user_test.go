@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -174,3 +176,42 @@ func TestCreateUserWithGetApiKey(t *testing.T) {
 	require.NoError(t, err)
 	markRoleDeleted()
 }
+
+func TestUserAndRoleStatus(t *testing.T) {
+	t.Parallel()
+	var user UserResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"status":"enable","role_list":[{"id":1,"status":"Disable"}]}`), &user))
+	require.Equal(t, UserStatusEnable, user.Status)
+	require.True(t, user.Status.IsEnabled())
+	require.True(t, user.RoleList[0].Status.IsDisabled())
+	require.False(t, user.RoleList[0].Status.IsEnabled())
+
+	// Unknown strings are kept; numeric codes keep their text; other JSON
+	// types decode to the empty status.
+	require.NoError(t, json.Unmarshal([]byte(`{"status":"locked","role_list":[{"status":1},{"status":true}]}`), &user))
+	require.Equal(t, "locked", user.Status.String())
+	require.False(t, user.Status.IsEnabled())
+	require.False(t, user.Status.IsDisabled())
+	require.Equal(t, RoleStatus("1"), user.RoleList[0].Status)
+	require.Equal(t, RoleStatus(""), user.RoleList[1].Status)
+}
+
+func TestGetUserByName(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var requests int
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/user/list": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			var req UserListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, []CommonFilter{{Name: "name", Values: []string{"alice"}}}, req.Filters)
+			writeEnvelope(w, UserListResponse{Total: 1, List: []UserResponse{{ID: 7, Name: "alice"}}})
+		},
+	})
+
+	user, err := client.GetUserByName(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, UserID(7), user.ID)
+	require.Equal(t, 1, requests)
+}
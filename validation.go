@@ -0,0 +1,1190 @@
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldError is an invalid field of a request.
+type FieldError struct {
+	// Field is the snake-case name of the field, with the index of the
+	// element for lists, for example "alterations[2]". IDs are named after
+	// what they identify, "table_id" or "volume_id", even where the JSON
+	// name is just "id".
+	Field string
+	// Message describes the problem, for example "task_id is required".
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationError lists every invalid field of a request. RawClient methods
+// return it, before sending anything, when the Validate method of their
+// request fails. It matches ErrInvalidArgument with errors.Is.
+//
+// Example:
+//
+//	_, err := client.CreateLoadTask(ctx, req)
+//	var verr *sdk.ValidationError
+//	if errors.As(err, &verr) {
+//		for _, f := range verr.Fields {
+//			fmt.Printf("%s: %s\n", f.Field, f.Message)
+//		}
+//	}
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	if e == nil || len(e.Fields) == 0 {
+		return "invalid request"
+	}
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrInvalidArgument.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// Validator is implemented by the requests that can be checked before they
+// are sent. RawClient.Do validates the requests implementing it.
+type Validator interface {
+	Validate() error
+}
+
+// validate validates v if it implements Validator and is not a nil pointer.
+func validate(v any) error {
+	val, ok := v.(Validator)
+	if !ok {
+		return nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	return val.Validate()
+}
+
+// fieldErrors collects the invalid fields of a request.
+type fieldErrors []FieldError
+
+// check records message for field unless ok.
+func (f *fieldErrors) check(ok bool, field, message string) {
+	if !ok {
+		*f = append(*f, FieldError{Field: field, Message: message})
+	}
+}
+
+func (f fieldErrors) err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: f}
+}
+
+func blank(s string) bool {
+	return strings.TrimSpace(s) == ""
+}
+
+// Validate checks that the request has a volume and files to upload, or the
+// connector files of its table configuration, and that PathRegex compiles.
+func (r *UploadFileRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	errs.check(len(r.Files) > 0 || (r.TableConfig != nil && len(r.TableConfig.ConnFileIDs) > 0),
+		"files", "at least one file is required, or TableConfig.ConnFileIDs must be provided")
+	errs.checkPathRegex("path_regex", r.PathRegex)
+	return errs.err()
+}
+
+// Validate checks that the request names a connector file.
+func (r *ConnectorFileDownloadRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.ConnFileId), "conn_file_id", "conn_file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a connector file.
+func (r *ConnectorFileDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.ConnFileId), "conn_file_id", "conn_file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a question.
+func (r *DataAnalysisRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Question), "question", "question cannot be empty")
+	return errs.err()
+}
+
+// Validate checks that the request has a query and something to search.
+func (r *PassageSearchRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Query), "query", "query cannot be empty")
+	errs.check(len(r.VolumeIDs) > 0 || len(r.DatasetIDs) > 0 || len(r.FileIDs) > 0,
+		"volume_ids", "at least one volume, dataset or file is required")
+	return errs.err()
+}
+
+// Validate checks that the request names an analysis.
+func (r *CancelAnalyzeRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.RequestID), "request_id", "request_id cannot be empty")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *FileMoveRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *FileCopyRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file and that no tag key is
+// empty.
+func (r *FileTagsSetRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	for key := range r.Tags {
+		if blank(key) {
+			errs.check(false, "tags", "tag keys cannot be empty")
+			break
+		}
+	}
+	return errs.err()
+}
+
+// Validate checks that the request has files to rerun.
+func (r *WorkflowJobRerunRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.FileIDs) > 0, "file_ids", "file_ids is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a model and messages, and the user
+// and source of the recorded message when it has a session.
+func (r *LLMCompletionRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Model), "model", "model is required")
+	errs.check(len(r.Messages) > 0, "messages", "at least one message is required")
+	if r.SessionID != nil {
+		errs.check(r.UserID != "", "user_id", "user_id is required to record the completion")
+		errs.check(r.Source != "", "source", "source is required to record the completion")
+	}
+	return errs.err()
+}
+
+// Validate checks that the request has messages.
+func (r *LLMChatMessageBatchCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.Messages) > 0, "messages", "at least one message is required")
+	return errs.err()
+}
+
+// Validate checks that the time range, when bounded on both sides, is not
+// empty.
+func (r *LLMUsageStatsFilter) Validate() error {
+	var errs fieldErrors
+	errs.check(r.StartTime <= 0 || r.EndTime <= 0 || r.EndTime > r.StartTime,
+		"end_time", "end_time must be after start_time")
+	return errs.err()
+}
+
+// Validate checks that the request names a table and that its projection
+// and filter are well formed.
+func (r *TablePreviewRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	errs.check(r.Offset >= 0, "offset", "offset must not be negative")
+	errs.check(!blank(r.Where) || len(r.WhereArgs) == 0, "where_args", "where_args given without a where condition")
+	for i, col := range r.Columns {
+		errs.check(!blank(col), fmt.Sprintf("columns[%d]", i), "column name must not be empty")
+	}
+	return errs.err()
+}
+
+// Validate checks that the request names a table and that every alteration
+// has the fields its operation needs.
+func (r *TableAlterRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	errs.check(len(r.Alterations) > 0, "alterations", "alterations cannot be empty")
+	for i, alt := range r.Alterations {
+		if err := alt.validate(); err != nil {
+			field := fmt.Sprintf("alterations[%d]", i)
+			errs.check(false, field, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+	return errs.err()
+}
+
+// Validate checks that the request names a table and its new name.
+func (r *TableRenameRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	errs.check(!blank(r.Name), "name", "name is required")
+	return errs.err()
+}
+
+// Validate checks that the request names at least one object.
+func (r *TrashMoveRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.FileIDs)+len(r.FolderIDs)+len(r.TableIDs) > 0,
+		"ids", "file_ids, folder_ids or table_ids are required")
+	return errs.err()
+}
+
+// Validate checks that the request names the items to restore.
+func (r *TrashRestoreRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.TrashIDs) > 0, "ids", "ids are required")
+	return errs.err()
+}
+
+// Validate checks that the request either names the items to purge or
+// purges the whole trash, but not both.
+func (r *TrashPurgeRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.TrashIDs) > 0 || r.All, "ids", "ids or all is required")
+	errs.check(len(r.TrashIDs) == 0 || !r.All, "all", "ids and all are mutually exclusive")
+	return errs.err()
+}
+
+// Validate checks that the request names a task.
+func (r *TaskInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TaskID != 0, "task_id", "task_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a source and exactly one target.
+func (r *LoadTaskCreateRequest) Validate() error {
+	var errs fieldErrors
+	src := r.Source
+	errs.check(len(src.URIs) > 0 || len(src.ConnFileIDs) > 0,
+		"source", "source uris or conn_file_ids are required")
+	errs.check(len(src.URIs) == 0 || src.ConnectorID != 0,
+		"source.connector_id", "source connector_id is required with uris")
+	errs.check((r.Target.VolumeID == "") != (r.Target.Table == nil),
+		"target", "exactly one of target volume_id and table is required")
+	errs.checkPathRegex("source.path_regex", src.PathRegex)
+	return errs.err()
+}
+
+// Validate checks that the request names a task.
+func (r *LoadTaskCancelRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TaskID != 0, "task_id", "task_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a task.
+func (r *LoadTaskDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TaskID != 0, "task_id", "task_id is required")
+	return errs.err()
+}
+
+// checkPage records negative page numbers and sizes.
+func (f *fieldErrors) checkPage(pageField string, page int, sizeField string, size int) {
+	f.check(page >= 0, pageField, pageField+" must not be negative")
+	f.check(size >= 0, sizeField, sizeField+" must not be negative")
+}
+
+// invalidArgument returns the *ValidationError of a method argument that is
+// not part of a request.
+func invalidArgument(field, message string) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: message}}}
+}
+
+// Validate checks that no resource is asked for in negative amounts.
+func (r *CapacityRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.Databases >= 0, "databases", "databases must not be negative")
+	errs.check(r.Tables >= 0, "tables", "tables must not be negative")
+	errs.check(r.Volumes >= 0, "volumes", "volumes must not be negative")
+	errs.check(r.StorageBytes >= 0, "storage_bytes", "storage_bytes must not be negative")
+	return errs.err()
+}
+
+// Validate checks that the request has a name and a password.
+func (r *AuthLoginRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.UserName), "name", "name is required")
+	errs.check(r.Password != "", "password", "password is required")
+	return errs.err()
+}
+
+// Validate accepts every request; the key to exchange is the one the client
+// is configured with.
+func (r *AuthAPIKeyExchangeRequest) Validate() error {
+	return nil
+}
+
+// Validate checks that the request has files and their meta.
+func (r *LocalFileUploadRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.Files) > 0, "files", "at least one file is required")
+	errs.check(len(r.Meta) > 0, "meta", "meta is required")
+	return errs.err()
+}
+
+// Validate checks that the request names the file to preview: a URI or
+// connector file with a connector, a connector file without.
+func (r *FilePreviewRequest) Validate() error {
+	var errs fieldErrors
+	if r.ConnectorId > 0 {
+		errs.check(!blank(r.Uri) || !blank(r.ConnFileId),
+			"uri", "file preview needs uri or conn_file_id when connector_id is provided")
+	} else {
+		errs.check(!blank(r.ConnFileId),
+			"conn_file_id", "file preview needs conn_file_id for local upload file")
+	}
+	return errs.err()
+}
+
+// Validate checks that the request names a connector.
+func (r *ConnectorFileListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.ConnectorId != 0, "connector_id", "connector_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a name.
+func (r *CatalogCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.CatalogName), "name", "name is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a catalog.
+func (r *CatalogDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.CatalogID != 0, "catalog_id", "catalog_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a catalog.
+func (r *CatalogUpdateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.CatalogID != 0, "catalog_id", "catalog_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a catalog.
+func (r *CatalogInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.CatalogID != 0, "catalog_id", "catalog_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a catalog.
+func (r *CatalogRefListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.CatalogID != 0, "catalog_id", "catalog_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a name and a catalog.
+func (r *DatabaseCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.DatabaseName), "name", "name is required")
+	errs.check(r.CatalogID != 0, "catalog_id", "catalog_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a database.
+func (r *DatabaseDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatabaseID != 0, "database_id", "database_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a database.
+func (r *DatabaseUpdateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatabaseID != 0, "database_id", "database_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a database.
+func (r *DatabaseInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatabaseID != 0, "database_id", "database_id is required")
+	return errs.err()
+}
+
+// Validate accepts every request; a zero CatalogID lists the databases of
+// every catalog.
+func (r *DatabaseListRequest) Validate() error {
+	return nil
+}
+
+// Validate checks that the request names a database and that its page is
+// not negative.
+func (r *DatabaseChildrenRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatabaseID != 0, "database_id", "database_id is required")
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the request names a database.
+func (r *DatabaseRefListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatabaseID != 0, "database_id", "database_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a database, a name and named
+// columns.
+func (r *TableCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatabaseID != 0, "database_id", "database_id is required")
+	errs.check(!blank(r.Name), "name", "name is required")
+	for i, col := range r.Columns {
+		errs.check(!blank(col.Name), fmt.Sprintf("columns[%d]", i), "column name must not be empty")
+	}
+	return errs.err()
+}
+
+// Validate checks that the request names a table by ID, or by name and
+// database.
+func (r *TableInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0 || (!blank(r.TableName) && r.DatabaseID != 0),
+		"table_id", "table_id, or table_name and database_id, is required")
+	return errs.err()
+}
+
+// Validate checks that the request names at least one table, and every
+// table it names.
+func (r *MultiTableInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.TableList) > 0, "table_list", "table_list cannot be empty")
+	for i := range r.TableList {
+		if err := r.TableList[i].Validate(); err != nil {
+			field := fmt.Sprintf("table_list[%d]", i)
+			errs.check(false, field, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+	return errs.err()
+}
+
+// Validate checks that the request has a database and a name.
+func (r *TableExistRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatabaseID != 0, "database_id", "database_id is required")
+	errs.check(!blank(r.Name), "name", "name is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a table by ID, or by name and
+// database, and that its page is not negative.
+func (r *GetTableDataRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0 || (!blank(r.TableName) && r.DatabaseID != 0),
+		"table_id", "table_id, or name and database_id, is required")
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the request names a table.
+func (r *TableLoadRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a table and has rows.
+func (r *TableInsertRowsRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	errs.check(len(r.Rows) > 0, "rows", "rows cannot be empty")
+	return errs.err()
+}
+
+// Validate checks that the request names a table.
+func (r *TableDownloadRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a table.
+func (r *TableDownloadDataRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.ID != 0, "table_id", "table_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a table.
+func (r *TableTruncateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a table.
+func (r *TableDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names at least one table.
+func (r *TableFullPathRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.TableIDList) > 0, "table_id_list", "table_id_list cannot be empty")
+	return errs.err()
+}
+
+// Validate checks that the request names a table.
+func (r *TableRefListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.TableID != 0, "table_id", "table_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a name and a database.
+func (r *VolumeCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Name), "name", "name is required")
+	errs.check(r.DatabaseID != 0, "database_id", "database_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a volume.
+func (r *VolumeDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a volume.
+func (r *VolumeUpdateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a volume.
+func (r *VolumeInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a volume.
+func (r *VolumeRefListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names at least one database, volume or
+// folder.
+func (r *VolumeFullPathRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.DatabaseIDList)+len(r.VolumeIDList)+len(r.FolderIDList) > 0,
+		"volume_id_list", "database_id_list, volume_id_list or folder_id_list is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a volume.
+func (r *VolumeAddRefWorkflowRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a volume.
+func (r *VolumeRemoveRefWorkflowRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a name.
+func (r *DatasetCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Name), "name", "name is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a dataset.
+func (r *DatasetDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatasetID != 0, "dataset_id", "dataset_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a dataset.
+func (r *DatasetUpdateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatasetID != 0, "dataset_id", "dataset_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a dataset.
+func (r *DatasetInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.DatasetID != 0, "dataset_id", "dataset_id is required")
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *DatasetListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the request has a name and a volume.
+func (r *FileCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Name), "name", "name is required")
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file and its new name.
+func (r *FileUpdateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	errs.check(!blank(r.Name), "name", "name is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *FileDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file reference.
+func (r *FileDeleteRefRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.RefFileID), "ref_file_id", "ref_file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *FileInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the page is not negative and that every tag filter
+// has a key.
+func (r *FileListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	for i, f := range r.TagFilters {
+		errs.check(!blank(f.Key), fmt.Sprintf("tag_filters[%d]", i), "tag filter key cannot be empty")
+	}
+	return errs.err()
+}
+
+// Validate checks that the request names a volume and that the depth is
+// not negative.
+func (r *FileTreeRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	errs.check(r.Depth >= 0, "depth", "depth must not be negative")
+	return errs.err()
+}
+
+// Validate checks that the request has a name and a volume.
+func (r *FileUploadRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Name), "name", "name is required")
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *FileDownloadRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(string(r.FileID)), "file_id", "file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *FilePreviewLinkRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *FilePreviewStreamRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *FileTagsGetRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FileID != "", "file_id", "file_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a name and a volume.
+func (r *FolderCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Name), "name", "name is required")
+	errs.check(r.VolumeID != "", "volume_id", "volume_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a folder and its new name.
+func (r *FolderUpdateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FolderID != "", "folder_id", "folder_id is required")
+	errs.check(!blank(r.Name), "name", "name is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a folder.
+func (r *FolderDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FolderID != "", "folder_id", "folder_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a folder.
+func (r *FolderCleanRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FolderID != "", "folder_id", "folder_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a folder.
+func (r *FolderRefListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.FolderID != "", "folder_id", "folder_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a name.
+func (r *RoleCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.RoleName), "name", "name is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a role.
+func (r *RoleDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.RoleID != 0, "role_id", "role_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a role.
+func (r *RoleInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.RoleID != 0, "role_id", "role_id is required")
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *RoleListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the request names a role.
+func (r *RoleUpdateInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.RoleID != 0, "role_id", "role_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a role and an action.
+func (r *RoleUpdateStatusRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.RoleID != 0, "role_id", "role_id is required")
+	errs.check(!blank(r.Action), "action", "action is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a role and the category of the
+// object.
+func (r *RoleUpdateCodeListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.RoleID != 0, "role_id", "role_id is required")
+	errs.check(!blank(r.ObjType), "category", "category is required")
+	return errs.err()
+}
+
+// Validate checks that the request names an object and a privilege code.
+func (r *RoleUpdateRolesByObjectRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.ObjID), "id", "id is required")
+	errs.check(!blank(r.Code), "code", "code is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a category.
+func (r *RoleListByCategoryAndObjectRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.ObjType), "category", "category is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a name and a password.
+func (r *UserCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.UserName), "name", "name is required")
+	errs.check(r.Password != "", "password", "password is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a user.
+func (r *UserDeleteUserRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.UserID != 0, "user_id", "user_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a user.
+func (r *UserDetailInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.UserID != 0, "user_id", "user_id is required")
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *UserListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the request names a user and a password.
+func (r *UserUpdatePasswordRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.UserID != 0, "user_id", "user_id is required")
+	errs.check(r.Password != "", "password", "password is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a user.
+func (r *UserUpdateInfoRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.UserID != 0, "user_id", "user_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a user.
+func (r *UserUpdateRoleListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.UserID != 0, "user_id", "user_id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a user and an action.
+func (r *UserUpdateStatusRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.UserID != 0, "user_id", "user_id is required")
+	errs.check(!blank(r.Action), "action", "action is required")
+	return errs.err()
+}
+
+// Validate accepts every request; the user is the caller.
+func (r *UserMeInfoRequest) Validate() error {
+	return nil
+}
+
+// Validate accepts every request; empty fields are cleared.
+func (r *UserMeUpdateInfoRequest) Validate() error {
+	return nil
+}
+
+// Validate checks that the request has a password.
+func (r *UserMeUpdatePasswordRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.Password != "", "password", "password is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a privilege.
+func (r *PrivGetAuthorizedObjectsRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.PrivID != 0 || len(r.ObjPrivIDList) > 0, "priv_id", "priv_id or obj_priv_id_list is required")
+	return errs.err()
+}
+
+// Validate checks that the request has privileges to check.
+func (r *PrivCheckRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(len(r.CheckList) > 0, "check_list", "check_list cannot be empty")
+	return errs.err()
+}
+
+// Validate checks that the request has a category.
+func (r *PrivListObjByCategoryRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.ObjType), "category", "category is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a node.
+func (r *GenAIGenerateNodeRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Node), "node", "node is required")
+	return errs.err()
+}
+
+// Validate checks that the request has steps and that every step names a
+// node.
+func (r *GenAICreateWorkflowRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkSteps(r.Steps)
+	return errs.err()
+}
+
+// Validate checks that the request has steps and that every step names a
+// node.
+func (r *GenAICreatePipelineRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkSteps(r.Steps)
+	return errs.err()
+}
+
+// checkSteps records an empty step list and the steps without a node.
+func (f *fieldErrors) checkSteps(steps []GenAIWorkflowStep) {
+	f.check(len(steps) > 0, "steps", "steps cannot be empty")
+	for i, step := range steps {
+		f.check(!blank(step.Node), fmt.Sprintf("steps[%d]", i), "step node is required")
+	}
+}
+
+// Validate checks that the request names a job.
+func (r *GenAIGetJobDetailRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.JobID), "job_id", "jobID cannot be empty")
+	return errs.err()
+}
+
+// Validate checks that the request names a file.
+func (r *GenAIDownloadFileResultRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.FileID), "file_id", "fileID cannot be empty")
+	return errs.err()
+}
+
+// Validate checks that every node of the workflow has an ID and a type, and
+// that every connection has both ends.
+func (r *WorkflowMetadata) Validate() error {
+	var errs fieldErrors
+	if r.Workflow == nil {
+		return nil
+	}
+	for i, node := range r.Workflow.Nodes {
+		field := fmt.Sprintf("workflow.node[%d]", i)
+		errs.check(!blank(node.ID), field, field+": id is required")
+		errs.check(!blank(node.Type), field, field+": type is required")
+	}
+	for i, conn := range r.Workflow.Connections {
+		field := fmt.Sprintf("workflow.connections[%d]", i)
+		errs.check(!blank(conn.Sender) && !blank(conn.Receiver), field, field+": sender and receiver are required")
+	}
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *WorkflowListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that no file ID is empty.
+func (r *WorkflowRunRequest) Validate() error {
+	var errs fieldErrors
+	for i, id := range r.FileIDs {
+		errs.check(!blank(id), fmt.Sprintf("file_ids[%d]", i), "file ID cannot be empty")
+	}
+	return errs.err()
+}
+
+// Validate checks that the page is not negative and that the time range,
+// when bounded on both sides, is not empty.
+func (r *WorkflowJobListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	errs.check(r.StartedAfter.IsZero() || r.StartedBefore.IsZero() || r.StartedBefore.After(r.StartedAfter),
+		"started_before", "started_before must be after started_after")
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *LogLogListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the request has a title, a source and a user.
+func (r *LLMSessionCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Title), "title", "title is required")
+	errs.check(!blank(r.Source), "source", "source is required")
+	errs.check(!blank(r.UserID), "user_id", "user_id is required")
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *LLMSessionListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate accepts every request; nil fields are left unchanged.
+func (r *LLMSessionUpdateRequest) Validate() error {
+	return nil
+}
+
+// Validate checks that the request has a user, a source, a role, content
+// and a model.
+func (r *LLMChatMessageCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.UserID), "user_id", "user_id is required")
+	errs.check(!blank(r.Source), "source", "source is required")
+	errs.check(r.Role != "", "role", "role is required")
+	errs.check(r.Content != "", "content", "content is required")
+	errs.check(!blank(r.Model), "model", "model is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a user and that the page is not
+// negative.
+func (r *LLMChatMessageListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.UserID), "user_id", "user_id is required")
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate accepts every request; nil fields are left unchanged.
+func (r *LLMChatMessageUpdateRequest) Validate() error {
+	return nil
+}
+
+// Validate checks that no tag is empty. An empty list removes every tag.
+func (r *LLMChatMessageTagsUpdateRequest) Validate() error {
+	var errs fieldErrors
+	for i, tag := range r.Tags {
+		errs.check(!blank(tag), fmt.Sprintf("tags[%d]", i), "tag cannot be empty")
+	}
+	return errs.err()
+}
+
+// Validate checks that the limit, when set, is positive.
+func (r *LLMSessionMessagesListRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.Limit == nil || *r.Limit > 0, "limit", "limit must be positive")
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *AnalysisSessionListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the request names an analysis and rates it.
+func (r *AnalysisFeedbackRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.RequestID), "request_id", "request_id cannot be empty")
+	errs.check(r.Rating == AnalysisRatingPositive || r.Rating == AnalysisRatingNegative,
+		"rating", fmt.Sprintf("invalid rating %q", r.Rating))
+	return errs.err()
+}
+
+// Validate checks that the request has a statement.
+func (r *NL2SQLRunSQLRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Statement), "statement", "statement is required")
+	return errs.err()
+}
+
+// Validate checks that the request has a type and a key.
+func (r *NL2SQLKnowledgeCreateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(!blank(r.Type), "knowledge_type", "knowledge_type is required")
+	errs.check(!blank(r.Key), "knowledge_key", "knowledge_key is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a knowledge entry.
+func (r *NL2SQLKnowledgeUpdateRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.ID != 0, "id", "id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a knowledge entry.
+func (r *NL2SQLKnowledgeDeleteRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.ID != 0, "id", "id is required")
+	return errs.err()
+}
+
+// Validate checks that the request names a knowledge entry.
+func (r *NL2SQLKnowledgeGetRequest) Validate() error {
+	var errs fieldErrors
+	errs.check(r.ID != 0, "id", "id is required")
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *NL2SQLKnowledgeListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page_number", r.PageNumber, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *NL2SQLKnowledgeSearchRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page_number", r.PageNumber, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *LoadTaskListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
+
+// Validate checks that the page is not negative.
+func (r *TrashListRequest) Validate() error {
+	var errs fieldErrors
+	errs.checkPage("page", r.Page, "page_size", r.PageSize)
+	return errs.err()
+}
@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"go/token"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError(t *testing.T) {
+	t.Parallel()
+	err := (&LoadTaskCreateRequest{
+		Source: LoadTaskSourceConfig{URIs: []string{"s3://bucket/a.csv"}},
+	}).Validate()
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, []FieldError{
+		{Field: "source.connector_id", Message: "source connector_id is required with uris"},
+		{Field: "target", Message: "exactly one of target volume_id and table is required"},
+	}, verr.Fields)
+	require.Equal(t, "source connector_id is required with uris; exactly one of target volume_id and table is required", err.Error())
+
+	err = (&TableAlterRequest{
+		TableID:     1,
+		Alterations: []TableAlteration{AlterDropColumn("a"), {Op: "truncate"}, AlterDropColumn("")},
+	}).Validate()
+	require.True(t, errors.As(err, &verr))
+	require.Len(t, verr.Fields, 2)
+	require.Equal(t, "alterations[1]", verr.Fields[0].Field)
+	require.Equal(t, "alterations[2]", verr.Fields[1].Field)
+
+	require.NoError(t, (&TaskInfoRequest{TaskID: 1}).Validate())
+	require.NoError(t, (&LLMUsageStatsFilter{StartTime: 1}).Validate())
+}
+
+func TestValidateBeforeSending(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var calls atomic.Int32
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeEnvelope(w, TaskInfoResponse{})
+		},
+	})
+
+	_, err := client.GetTask(ctx, &TaskInfoRequest{})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	err = client.Do(ctx, http.MethodPost, "/task/get", &TaskInfoRequest{}, nil)
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, "task_id", verr.Fields[0].Field)
+	require.Zero(t, calls.Load())
+
+	var nilReq *TaskInfoRequest
+	require.NoError(t, client.Do(ctx, http.MethodPost, "/task/get", nilReq, nil))
+	require.EqualValues(t, 1, calls.Load())
+}
+
+// TestRequestsImplementValidator fails when a RawClientAPI method takes a
+// request that cannot be validated.
+func TestRequestsImplementValidator(t *testing.T) {
+	t.Parallel()
+	validator := reflect.TypeFor[Validator]()
+	api := reflect.TypeFor[RawClientAPI]()
+	for i := 0; i < api.NumMethod(); i++ {
+		m := api.Method(i)
+		for j := 0; j < m.Type.NumIn(); j++ {
+			in := m.Type.In(j)
+			if in.Kind() == reflect.Pointer && in.Elem().Kind() == reflect.Struct {
+				require.True(t, in.Implements(validator), "%s takes %s, which has no Validate method", m.Name, in)
+			}
+		}
+	}
+	for _, e := range Endpoints() {
+		// Unexported requests are built by the SDK, not by callers.
+		if e.Request != nil && token.IsExported(e.Request.Name()) {
+			require.True(t, reflect.PointerTo(e.Request).Implements(validator), "%s request has no Validate method", e.Name)
+		}
+	}
+}
+
+func TestValidateArguments(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := newMockClient(t, map[string]http.HandlerFunc{
+		"/": func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		},
+	})
+
+	requireInvalid := func(err error, field, message string) {
+		t.Helper()
+		var verr *ValidationError
+		require.True(t, errors.As(err, &verr), "got %v", err)
+		require.Equal(t, field, verr.Fields[0].Field)
+		require.Equal(t, message, verr.Fields[0].Message)
+	}
+
+	_, err := client.GetLoadTask(ctx, 0)
+	requireInvalid(err, "task_id", "task_id is required")
+	_, err = client.InsertTableRows(ctx, 0, nil)
+	requireInvalid(err, "table_id", "table_id is required")
+	_, err = client.InsertTableRows(ctx, 1, nil)
+	requireInvalid(err, "rows", "rows cannot be empty")
+	_, err = client.DownloadFileStream(ctx, " ", "")
+	requireInvalid(err, "file_id", "file_id is required")
+	_, err = client.ListFolderTree(ctx, "v1", "", -1)
+	requireInvalid(err, "depth", "depth must not be negative")
+	_, err = client.ListConnectorFiles(ctx, 0, "", false)
+	requireInvalid(err, "connector_id", "connector_id is required")
+	_, err = client.GetGenAIJob(ctx, "")
+	requireInvalid(err, "job_id", "jobID cannot be empty")
+	_, err = client.GetRoleByName(ctx, "")
+	requireInvalid(err, "name", "role name is required")
+	_, err = client.SubmitAnalysisFeedback(ctx, "req-1", "meh", "", "")
+	requireInvalid(err, "rating", `invalid rating "meh"`)
+	_, err = client.UploadLocalFiles(ctx, nil, nil)
+	requireInvalid(err, "files", "at least one file is required")
+	_, err = client.Batch(ctx, []BatchCall{{Path: "/catalog/list"}, {}})
+	requireInvalid(err, "calls[1].path", "call 1: path is required")
+	_, err = client.CreateCatalog(ctx, &CatalogCreateRequest{})
+	requireInvalid(err, "name", "name is required")
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableName: "orders"})
+	requireInvalid(err, "table_id", "table_id, or table_name and database_id, is required")
+	_, err = client.ListUsers(ctx, &UserListRequest{CommonCondition: CommonCondition{PageSize: -1}})
+	requireInvalid(err, "page_size", "page_size must not be negative")
+
+	sdk := NewSDKClient(client)
+	_, err = sdk.NewTaskJob(0, time.Millisecond).Wait(ctx)
+	requireInvalid(err, "task_id", "task_id is required")
+	_, _, err = sdk.WaitForTask(ctx, 0, time.Millisecond)
+	requireInvalid(err, "task_id", "task_id is required")
+}
+
+func TestValidationFieldNames(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		req   Validator
+		field string
+	}{
+		{&CatalogInfoRequest{}, "catalog_id"},
+		{&DatabaseDeleteRequest{}, "database_id"},
+		{&DatabaseChildrenRequest{}, "database_id"},
+		{&TableInfoRequest{}, "table_id"},
+		{&TableDeleteRequest{}, "table_id"},
+		{&TableDownloadDataRequest{}, "table_id"},
+		{&TablePreviewRequest{}, "table_id"},
+		{&TableRenameRequest{Name: "t"}, "table_id"},
+		{&TableInsertRowsRequest{Rows: []map[string]any{{"a": 1}}}, "table_id"},
+		{&GetTableDataRequest{}, "table_id"},
+		{&VolumeInfoRequest{}, "volume_id"},
+		{&DatasetDeleteRequest{}, "dataset_id"},
+		{&FileInfoRequest{}, "file_id"},
+		{&FileDeleteRefRequest{}, "ref_file_id"},
+		{&FolderCleanRequest{}, "folder_id"},
+		{&RoleInfoRequest{}, "role_id"},
+		{&UserDetailInfoRequest{}, "user_id"},
+	} {
+		var verr *ValidationError
+		require.True(t, errors.As(tc.req.Validate(), &verr), "%T", tc.req)
+		require.Equal(t, tc.field, verr.Fields[0].Field, "%T", tc.req)
+		require.True(t, strings.HasPrefix(verr.Fields[0].Message, tc.field), "%T: %s", tc.req, verr.Fields[0].Message)
+	}
+}
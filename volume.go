@@ -23,6 +23,13 @@ func (c *RawClient) CreateVolume(ctx context.Context, req *VolumeCreateRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req, err := withNormalizedName(c, ResourceVolume, req, func(r *VolumeCreateRequest) *string { return &r.Name })
+	if err != nil {
+		return nil, err
+	}
 	var resp VolumeCreateResponse
 	if err := c.postJSON(ctx, "/catalog/volume/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -43,10 +50,14 @@ func (c *RawClient) DeleteVolume(ctx context.Context, req *VolumeDeleteRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp VolumeDeleteResponse
 	if err := c.postJSON(ctx, "/catalog/volume/delete", req, &resp, opts...); err != nil {
 		return nil, err
 	}
+	c.forgetResolved(ResolvedPath{VolumeID: req.VolumeID})
 	return &resp, nil
 }
 
@@ -65,10 +76,14 @@ func (c *RawClient) UpdateVolume(ctx context.Context, req *VolumeUpdateRequest,
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp VolumeUpdateResponse
 	if err := c.postJSON(ctx, "/catalog/volume/update", req, &resp, opts...); err != nil {
 		return nil, err
 	}
+	c.forgetResolved(ResolvedPath{VolumeID: req.VolumeID})
 	return &resp, nil
 }
 
@@ -89,6 +104,9 @@ func (c *RawClient) GetVolume(ctx context.Context, req *VolumeInfoRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp VolumeInfoResponse
 	if err := c.postJSON(ctx, "/catalog/volume/info", req, &resp, opts...); err != nil {
 		return nil, err
@@ -109,6 +127,9 @@ func (c *RawClient) GetVolumeRefList(ctx context.Context, req *VolumeRefListRequ
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp VolumeRefListResponse
 	if err := c.postJSON(ctx, "/catalog/volume/ref_list", req, &resp, opts...); err != nil {
 		return nil, err
@@ -135,6 +156,9 @@ func (c *RawClient) GetVolumeFullPath(ctx context.Context, req *VolumeFullPathRe
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp VolumeFullPathResponse
 	if err := c.postJSON(ctx, "/catalog/volume/full_path", req, &resp, opts...); err != nil {
 		return nil, err
@@ -156,6 +180,9 @@ func (c *RawClient) AddVolumeWorkflowRef(ctx context.Context, req *VolumeAddRefW
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp VolumeAddRefWorkflowResponse
 	if err := c.postJSON(ctx, "/catalog/volume/add_ref_workflow", req, &resp, opts...); err != nil {
 		return nil, err
@@ -177,6 +204,9 @@ func (c *RawClient) RemoveVolumeWorkflowRef(ctx context.Context, req *VolumeRemo
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	var resp VolumeRemoveRefWorkflowResponse
 	if err := c.postJSON(ctx, "/catalog/volume/remove_ref_workflow", req, &resp, opts...); err != nil {
 		return nil, err
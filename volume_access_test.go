@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantAndRevokeVolumeAccess(t *testing.T) {
+	t.Parallel()
+	role := RoleInfoResponse{RoleID: 5, ObjAuthorityList: []*ObjPrivResponse{
+		{ObjType: "volume", ObjID: "v-1", AuthorityCodeList: []*AuthorityCodeAndRule{{Code: "DV3"}}},
+		{ObjType: "volume", ObjID: "v-2", AuthorityCodeList: []*AuthorityCodeAndRule{{Code: "DV5"}}},
+	}}
+	var updates []RoleUpdateCodeListRequest
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/role/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, role)
+		},
+		"/role/update_code_list": func(w http.ResponseWriter, r *http.Request) {
+			var req RoleUpdateCodeListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			updates = append(updates, req)
+			writeEnvelope(w, RoleUpdateCodeListResponse{RoleID: req.RoleID})
+		},
+	}))
+	ctx := context.Background()
+
+	require.NoError(t, client.GrantVolumeAccess(ctx, 5, "v-1", VolumeAccessReadWrite))
+	require.NoError(t, client.RevokeVolumeAccess(ctx, 5, "v-2", VolumeAccessRead))
+	require.Equal(t, []RoleUpdateCodeListRequest{
+		{RoleID: 5, ObjType: "volume", ObjID: "v-1", CodeList: []string{"DV3", "DV5", "DV6"}},
+		{RoleID: 5, ObjType: "volume", ObjID: "v-2", CodeList: []string{}},
+	}, updates)
+
+	require.ErrorContains(t, client.GrantVolumeAccess(ctx, 5, "v-1", 0), "read or write")
+	require.ErrorContains(t, client.GrantVolumeAccess(ctx, 0, "v-1", VolumeAccessRead), "role_id")
+}
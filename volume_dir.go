@@ -0,0 +1,547 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDirectoryImportConcurrency = 4
+	defaultDirectoryExportConcurrency = 4
+	defaultDirectoryExportRetries     = 3
+	defaultDirectoryExportRetryDelay  = 500 * time.Millisecond
+	volumeListPageSize                = 100
+)
+
+// DirectoryImportOptions configures ImportDirectoryToVolume.
+type DirectoryImportOptions struct {
+	// Concurrency is the maximum number of files uploaded at the same time.
+	// Defaults to 4.
+	Concurrency int
+	// Include limits the upload to files whose slash-separated path relative
+	// to the directory, or whose base name, matches one of these path.Match
+	// patterns. An empty list includes every file.
+	Include []string
+	// Exclude skips files and whole sub-directories whose relative path or
+	// base name matches one of these path.Match patterns. Exclude wins over
+	// Include.
+	Exclude []string
+	// Dedup is passed to every upload.
+	Dedup *DedupConfig
+	// Progress, if set, receives an event as each file starts and ends.
+	Progress ProgressReporter
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// DirectoryImportFile is the outcome of uploading one file.
+type DirectoryImportFile struct {
+	// LocalPath is the path of the file on disk.
+	LocalPath string
+	// Path is the slash-separated path of the file inside the volume.
+	Path     string
+	Size     int64
+	Response *UploadFileResponse
+	Err      error
+}
+
+// DirectoryImportResult summarizes an ImportDirectoryToVolume call.
+type DirectoryImportResult struct {
+	// Folders maps the slash-separated path of every folder created or
+	// reused in the volume to its ID.
+	Folders map[string]FileID
+	// Files holds the outcome of every upload, in walk order.
+	Files []DirectoryImportFile
+}
+
+// Failed returns the files that could not be uploaded.
+func (r *DirectoryImportResult) Failed() []DirectoryImportFile {
+	var failed []DirectoryImportFile
+	for _, f := range r.Files {
+		if f.Err != nil {
+			failed = append(failed, f)
+		}
+	}
+	return failed
+}
+
+// ImportDirectoryToVolume uploads a local directory tree to a volume,
+// recreating its folder structure.
+//
+// The directory is walked first and a volume folder is created for every
+// sub-directory, parents before children; folders that already exist are
+// reused. The files are then uploaded by a bounded pool of workers with
+// FileMeta.Path set to their path relative to localDir. Symbolic links are
+// not followed.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - localDir: the local directory to upload (required)
+//   - volumeID: the target volume ID (required)
+//   - opts: optional filters, concurrency and dedup settings; nil uses the defaults
+//
+// Returns:
+//   - *DirectoryImportResult: the folders created and the per-file outcomes
+//   - error: an error if the walk or a folder creation failed, or if any file
+//     failed to upload; the result is returned in the latter case too
+//
+// Example:
+//
+//	res, err := sdkClient.ImportDirectoryToVolume(ctx, "./docs", volumeID, &sdk.DirectoryImportOptions{
+//		Include: []string{"*.pdf", "*.docx"},
+//		Exclude: []string{".git", "drafts"},
+//		Dedup:   sdk.NewDedupConfig([]sdk.DedupBy{sdk.DedupByMD5}, sdk.DedupStrategySkip),
+//	})
+//	if err != nil {
+//		for _, f := range res.Failed() {
+//			log.Printf("%s: %v", f.Path, f.Err)
+//		}
+//	}
+func (c *SDKClient) ImportDirectoryToVolume(ctx context.Context, localDir string, volumeID VolumeID, opts *DirectoryImportOptions) (*DirectoryImportResult, error) {
+	if strings.TrimSpace(localDir) == "" {
+		return nil, fmt.Errorf("local_dir is required")
+	}
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	var cfg DirectoryImportOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultDirectoryImportConcurrency
+	}
+	for _, pattern := range append(append([]string{}, cfg.Include...), cfg.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+
+	info, err := os.Stat(localDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", localDir)
+	}
+
+	dirs, files, err := walkLocalDir(localDir, cfg.Include, cfg.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	result := &DirectoryImportResult{Folders: make(map[string]FileID)}
+	var totalBytes int64
+	for _, rel := range files {
+		f := DirectoryImportFile{LocalPath: filepath.Join(localDir, filepath.FromSlash(rel)), Path: rel}
+		if info, err := os.Stat(f.LocalPath); err == nil {
+			f.Size = info.Size()
+		}
+		totalBytes += f.Size
+		result.Files = append(result.Files, f)
+	}
+	progress := NewProgressTracker(cfg.Progress, OperationImportDirectory)
+	progress.Start(int64(len(result.Files)), totalBytes)
+	defer progress.Finish()
+
+	// WalkDir visits parents before their children, so each parent ID is
+	// known by the time its sub-folders are created.
+	for _, dir := range dirs {
+		parentID := result.Folders[path.Dir(dir)]
+		id, err := c.ensureFolder(ctx, volumeID, parentID, path.Base(dir), cfg.CallOptions)
+		if err != nil {
+			return result, fmt.Errorf("create folder %s: %w", dir, err)
+		}
+		result.Folders[dir] = id
+	}
+
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i := range result.Files {
+		f := &result.Files[i]
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			f.Err = ctx.Err()
+			progress.ItemFailed(f.Path, f.Size, f.Err)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			progress.ItemStarted(f.Path, f.Size)
+			f.Response, f.Err = c.ImportLocalFileToVolume(ctx, f.LocalPath, volumeID, FileMeta{
+				Filename: path.Base(f.Path),
+				Path:     f.Path,
+			}, cfg.Dedup, cfg.CallOptions...)
+			reportFileOutcome(progress, f.Path, f.Size, f.Err)
+		}()
+	}
+	wg.Wait()
+
+	if failed := len(result.Failed()); failed > 0 {
+		return result, fmt.Errorf("%d of %d files failed to upload, first error: %w", failed, len(result.Files), result.Failed()[0].Err)
+	}
+	return result, nil
+}
+
+// walkLocalDir lists the sub-directories and regular files below localDir as
+// slash-separated relative paths, in lexical walk order.
+func walkLocalDir(localDir string, include, exclude []string) (dirs, files []string, err error) {
+	err = filepath.WalkDir(localDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if matchAnyGlob(exclude, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case d.IsDir():
+			dirs = append(dirs, rel)
+		case d.Type().IsRegular():
+			if len(include) == 0 || matchAnyGlob(include, rel) {
+				files = append(files, rel)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("walk %s: %w", localDir, err)
+	}
+	return dirs, files, nil
+}
+
+// ensureFolder creates the named folder under parentID, or returns the ID
+// of the folder that already exists there.
+func (c *SDKClient) ensureFolder(ctx context.Context, volumeID VolumeID, parentID FileID, name string, opts []CallOption) (FileID, error) {
+	resp, createErr := c.raw.CreateFolder(ctx, &FolderCreateRequest{Name: name, VolumeID: volumeID, ParentID: parentID}, opts...)
+	if createErr == nil {
+		return resp.FolderID, nil
+	}
+	list, err := c.raw.ListFiles(ctx, &FileListRequest{CommonCondition: CommonCondition{
+		Page:     1,
+		PageSize: volumeListPageSize,
+		Filters: []CommonFilter{
+			{Name: "volume_id", Values: []string{string(volumeID)}},
+			{Name: "parent_id", Values: []string{string(parentID)}},
+			{Name: "file_name", Values: []string{name}},
+		},
+	}}, opts...)
+	if err != nil {
+		return "", createErr
+	}
+	for _, item := range list.List {
+		if item.Name == name && isFolderType(item.FileType) {
+			return FileID(item.ID), nil
+		}
+	}
+	return "", createErr
+}
+
+func isFolderType(fileType string) bool {
+	switch strings.ToLower(fileType) {
+	case "dir", "folder", "directory":
+		return true
+	}
+	return false
+}
+
+// reportFileOutcome reports the end of a file transfer to progress.
+func reportFileOutcome(progress *ProgressTracker, item string, size int64, err error) {
+	if err != nil {
+		progress.ItemFailed(item, size, err)
+	} else {
+		progress.ItemFinished(item, size)
+	}
+}
+
+// matchAnyGlob reports whether rel or its base name matches any pattern.
+func matchAnyGlob(patterns []string, rel string) bool {
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DirectoryExportOptions configures ExportVolumeToDirectory.
+type DirectoryExportOptions struct {
+	// Concurrency is the maximum number of files downloaded at the same time.
+	// Defaults to 4.
+	Concurrency int
+	// MaxRetries is how many times a failed download is retried. Defaults to
+	// 3; a negative value disables retries. Errors returned by the service
+	// are not retried.
+	MaxRetries int
+	// SkipExisting leaves local files that already exist with the same size
+	// untouched instead of downloading them again.
+	SkipExisting bool
+	// Progress, if set, receives an event as each file starts and ends.
+	Progress ProgressReporter
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// DirectoryExportFile is the outcome of downloading one file.
+type DirectoryExportFile struct {
+	FileID FileID
+	// Path is the slash-separated path of the file inside the volume.
+	Path string
+	// LocalPath is where the file was written.
+	LocalPath string
+	Size      int64
+	// Skipped is true if SkipExisting left an existing local file in place.
+	Skipped bool
+	Err     error
+}
+
+// DirectoryExportResult summarizes an ExportVolumeToDirectory call.
+type DirectoryExportResult struct {
+	// Folders holds the slash-separated path of every folder recreated locally.
+	Folders []string
+	// Files holds the outcome of every download, in listing order.
+	Files []DirectoryExportFile
+}
+
+// Failed returns the files that could not be downloaded.
+func (r *DirectoryExportResult) Failed() []DirectoryExportFile {
+	var failed []DirectoryExportFile
+	for _, f := range r.Files {
+		if f.Err != nil {
+			failed = append(failed, f)
+		}
+	}
+	return failed
+}
+
+// ExportVolumeToDirectory downloads every file of a volume into localDir,
+// recreating the volume's folder hierarchy. It is the inverse of
+// ImportDirectoryToVolume.
+//
+// The volume is listed folder by folder, then files are downloaded by a
+// bounded pool of workers. Transient download failures are retried. Each
+// file is written to a temporary file first and renamed into place, so an
+// interrupted export never leaves truncated files behind. Entries whose
+// names would escape localDir are reported as failures.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - volumeID: the volume to export (required)
+//   - localDir: the local directory to write to; created if missing (required)
+//   - opts: optional concurrency and retry settings; nil uses the defaults
+//
+// Returns:
+//   - *DirectoryExportResult: the folders created and the per-file outcomes
+//   - error: an error if listing the volume failed, or if any file failed to
+//     download; the result is returned in the latter case too
+//
+// Example:
+//
+//	res, err := sdkClient.ExportVolumeToDirectory(ctx, volumeID, "./backup", &sdk.DirectoryExportOptions{
+//		Concurrency:  8,
+//		SkipExisting: true,
+//	})
+func (c *SDKClient) ExportVolumeToDirectory(ctx context.Context, volumeID VolumeID, localDir string, opts *DirectoryExportOptions) (*DirectoryExportResult, error) {
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	if strings.TrimSpace(localDir) == "" {
+		return nil, fmt.Errorf("local_dir is required")
+	}
+	var cfg DirectoryExportOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultDirectoryExportConcurrency
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultDirectoryExportRetries
+	} else if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+
+	result := &DirectoryExportResult{}
+	if err := c.listVolumeTree(ctx, volumeID, "", "", result, cfg.CallOptions); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(localDir, 0o755); err != nil {
+		return result, err
+	}
+	for _, dir := range result.Folders {
+		if err := os.MkdirAll(filepath.Join(localDir, filepath.FromSlash(dir)), 0o755); err != nil {
+			return result, err
+		}
+	}
+
+	var totalBytes int64
+	for _, f := range result.Files {
+		totalBytes += f.Size
+	}
+	progress := NewProgressTracker(cfg.Progress, OperationExportVolume)
+	progress.Start(int64(len(result.Files)), totalBytes)
+	defer progress.Finish()
+
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i := range result.Files {
+		f := &result.Files[i]
+		if f.Err != nil {
+			progress.ItemFailed(f.Path, f.Size, f.Err)
+			continue
+		}
+		f.LocalPath = filepath.Join(localDir, filepath.FromSlash(f.Path))
+		if cfg.SkipExisting {
+			if info, err := os.Stat(f.LocalPath); err == nil && info.Mode().IsRegular() && info.Size() == f.Size {
+				f.Skipped = true
+				progress.ItemSkipped(f.Path, f.Size)
+				continue
+			}
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			f.Err = ctx.Err()
+			progress.ItemFailed(f.Path, f.Size, f.Err)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			progress.ItemStarted(f.Path, f.Size)
+			f.Err = c.downloadFileWithRetry(ctx, f.FileID, volumeID, f.LocalPath, cfg.MaxRetries, cfg.CallOptions)
+			reportFileOutcome(progress, f.Path, f.Size, f.Err)
+		}()
+	}
+	wg.Wait()
+
+	if failed := result.Failed(); len(failed) > 0 {
+		return result, fmt.Errorf("%d of %d files failed to download, first error: %w", len(failed), len(result.Files), failed[0].Err)
+	}
+	return result, nil
+}
+
+// listVolumeTree appends the folders and files below parentID to result,
+// descending into sub-folders depth first.
+func (c *SDKClient) listVolumeTree(ctx context.Context, volumeID VolumeID, parentID FileID, prefix string, result *DirectoryExportResult, opts []CallOption) error {
+	for page := 1; ; page++ {
+		list, err := c.raw.ListFiles(ctx, &FileListRequest{CommonCondition: CommonCondition{
+			Page:     page,
+			PageSize: volumeListPageSize,
+			Filters: []CommonFilter{
+				{Name: "volume_id", Values: []string{string(volumeID)}},
+				{Name: "parent_id", Values: []string{string(parentID)}},
+			},
+		}}, opts...)
+		if err != nil {
+			return fmt.Errorf("list folder %q: %w", prefix, err)
+		}
+		for _, item := range list.List {
+			rel := path.Join(prefix, item.Name)
+			if !isSafeEntryName(item.Name) {
+				result.Files = append(result.Files, DirectoryExportFile{
+					FileID: FileID(item.ID),
+					Path:   rel,
+					Err:    fmt.Errorf("unsafe file name %q", item.Name),
+				})
+				continue
+			}
+			if isFolderType(item.FileType) {
+				result.Folders = append(result.Folders, rel)
+				if err := c.listVolumeTree(ctx, volumeID, FileID(item.ID), rel, result, opts); err != nil {
+					return err
+				}
+				continue
+			}
+			result.Files = append(result.Files, DirectoryExportFile{FileID: FileID(item.ID), Path: rel, Size: item.Size})
+		}
+		if len(list.List) < volumeListPageSize || page*volumeListPageSize >= list.Total {
+			return nil
+		}
+	}
+}
+
+func (c *SDKClient) downloadFileWithRetry(ctx context.Context, fileID FileID, volumeID VolumeID, localPath string, retries int, opts []CallOption) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * defaultDirectoryExportRetryDelay):
+			}
+		}
+		err = c.downloadFileTo(ctx, fileID, volumeID, localPath, opts)
+		if err == nil {
+			return nil
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("download after %d attempts: %w", retries+1, err)
+}
+
+func (c *SDKClient) downloadFileTo(ctx context.Context, fileID FileID, volumeID VolumeID, localPath string, opts []CallOption) error {
+	stream, err := c.raw.DownloadFileStream(ctx, fileID, volumeID, opts...)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(localPath), "."+filepath.Base(localPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, stream.Body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), localPath); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// isSafeEntryName reports whether a volume entry name can be used as a single
+// local path element.
+func isSafeEntryName(name string) bool {
+	if name == "" || name == "." || name == ".." {
+		return false
+	}
+	return !strings.ContainsAny(name, `/\`) && !strings.ContainsRune(name, 0)
+}
@@ -0,0 +1,164 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDKClientImportDirectoryToVolume(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	for _, p := range []string{"root.txt", "a/y.md", "a/b/x.txt", "skip/z.txt", ".git/config.txt"} {
+		full := filepath.Join(root, filepath.FromSlash(p))
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(p), 0o644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "empty"), 0o755))
+
+	var (
+		mu       sync.Mutex
+		folders  []FolderCreateRequest
+		uploaded []string
+		nextID   int
+	)
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/folder/create": func(w http.ResponseWriter, r *http.Request) {
+			var req FolderCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			mu.Lock()
+			defer mu.Unlock()
+			folders = append(folders, req)
+			if req.Name == "a" {
+				writeEnvelopeError(w, "ErrAlreadyExists", "folder exists")
+				return
+			}
+			nextID++
+			writeEnvelope(w, FolderCreateResponse{FolderID: FileID(fmt.Sprintf("f%d", nextID)), Name: req.Name})
+		},
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, FileListResponse{Total: 1, List: []VolumeChildrenResponse{
+				{ID: "existing-a", Name: "a", FileType: "dir"},
+			}})
+		},
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			var metas []FileMeta
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("meta")), &metas))
+			require.Len(t, metas, 1)
+			mu.Lock()
+			uploaded = append(uploaded, metas[0].Path)
+			mu.Unlock()
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	})
+
+	res, err := NewSDKClient(raw).ImportDirectoryToVolume(context.Background(), root, "vol-1", &DirectoryImportOptions{
+		Concurrency: 2,
+		Include:     []string{"*.txt"},
+		Exclude:     []string{".git", "skip"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]FileID{"a": "existing-a", "a/b": "f1", "empty": "f2"}, res.Folders)
+	require.Len(t, folders, 3)
+	require.Equal(t, FileID("existing-a"), folders[1].ParentID)
+	require.Equal(t, FileID(""), folders[2].ParentID)
+
+	sort.Strings(uploaded)
+	require.Equal(t, []string{"a/b/x.txt", "root.txt"}, uploaded)
+	require.Empty(t, res.Failed())
+}
+
+func TestSDKClientImportDirectoryToVolume_Validation(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(&RawClient{})
+	ctx := context.Background()
+
+	_, err := client.ImportDirectoryToVolume(ctx, "", "vol", nil)
+	require.Error(t, err)
+	_, err = client.ImportDirectoryToVolume(ctx, t.TempDir(), "", nil)
+	require.Error(t, err)
+	_, err = client.ImportDirectoryToVolume(ctx, t.TempDir(), "vol", &DirectoryImportOptions{Include: []string{"["}})
+	require.ErrorContains(t, err, "invalid glob")
+
+	file := filepath.Join(t.TempDir(), "f.txt")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+	_, err = client.ImportDirectoryToVolume(ctx, file, "vol", nil)
+	require.ErrorContains(t, err, "not a directory")
+}
+
+func TestSDKClientExportVolumeToDirectory(t *testing.T) {
+	t.Parallel()
+	children := map[string][]VolumeChildrenResponse{
+		"": {
+			{ID: "d1", Name: "docs", FileType: "dir"},
+			{ID: "f1", Name: "readme.txt", FileType: "file", Size: 6},
+			{ID: "bad", Name: "../evil", FileType: "file"},
+		},
+		"d1": {
+			{ID: "f2", Name: "a.txt", FileType: "file", Size: 1},
+			{ID: "d2", Name: "empty", FileType: "dir"},
+		},
+	}
+	var (
+		mu       sync.Mutex
+		attempts = map[FileID]int{}
+	)
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var parent string
+			for _, f := range req.Filters {
+				if f.Name == "parent_id" {
+					parent = f.Values[0]
+				}
+			}
+			writeEnvelope(w, FileListResponse{Total: len(children[parent]), List: children[parent]})
+		},
+		"/catalog/file/download_stream": func(w http.ResponseWriter, r *http.Request) {
+			var req FileDownloadRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			mu.Lock()
+			attempts[req.FileID]++
+			n := attempts[req.FileID]
+			mu.Unlock()
+			if req.FileID == "f2" && n == 1 {
+				// Drop the connection to simulate a transient failure.
+				hj, ok := w.(http.Hijacker)
+				require.True(t, ok)
+				conn, _, err := hj.Hijack()
+				require.NoError(t, err)
+				conn.Close()
+				return
+			}
+			_, _ = w.Write([]byte("data-" + string(req.FileID)))
+		},
+	})
+
+	dir := t.TempDir()
+	res, err := NewSDKClient(raw).ExportVolumeToDirectory(context.Background(), "vol-1", dir, nil)
+	require.Error(t, err)
+	require.Len(t, res.Failed(), 1)
+	require.Equal(t, FileID("bad"), res.Failed()[0].FileID)
+	require.Equal(t, []string{"docs", "docs/empty"}, res.Folders)
+
+	got, err := os.ReadFile(filepath.Join(dir, "readme.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "data-f1", string(got))
+	got, err = os.ReadFile(filepath.Join(dir, "docs", "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "data-f2", string(got))
+	require.DirExists(t, filepath.Join(dir, "docs", "empty"))
+	require.Equal(t, 2, attempts["f2"])
+	require.NoFileExists(t, filepath.Join(filepath.Dir(dir), "evil"))
+}
@@ -0,0 +1,415 @@
+package sdk
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SyncDirection selects which side of a VolumeSyncer is the source of truth.
+type SyncDirection int
+
+const (
+	// SyncToVolume makes the volume match the local directory.
+	SyncToVolume SyncDirection = iota
+	// SyncToLocal makes the local directory match the volume.
+	SyncToLocal
+)
+
+// SyncAction is the change a VolumeSyncer makes to one file.
+type SyncAction string
+
+const (
+	// SyncActionCreate copies a file that only exists on the source side.
+	SyncActionCreate SyncAction = "create"
+	// SyncActionUpdate overwrites a file whose content differs.
+	SyncActionUpdate SyncAction = "update"
+	// SyncActionDelete removes a file that no longer exists on the source side.
+	SyncActionDelete SyncAction = "delete"
+)
+
+// SyncChange describes one difference found by a VolumeSyncer.
+type SyncChange struct {
+	Action SyncAction
+	// Path is the slash-separated path relative to the directory and volume root.
+	Path string
+	// FileID is the volume file, if it exists remotely.
+	FileID FileID
+	// Reason explains why an update was planned: "size", or the hash
+	// algorithm whose digests differ, "md5" or "sha256".
+	Reason string
+	// Err is the error applying the change, if any. Always nil in dry runs.
+	Err error
+}
+
+// SyncReport is the result of VolumeSyncer.Diff or VolumeSyncer.Sync.
+type SyncReport struct {
+	Direction SyncDirection
+	DryRun    bool
+	Changes   []SyncChange
+	// Unchanged is the number of files found identical on both sides.
+	Unchanged int
+}
+
+// Failed returns the changes that could not be applied.
+func (r *SyncReport) Failed() []SyncChange {
+	var failed []SyncChange
+	for _, c := range r.Changes {
+		if c.Err != nil {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// VolumeSyncer keeps a local directory and a volume in step.
+//
+// Files are paired by their path relative to the directory and the volume
+// root. CompareBy uses the DedupBy semantics of uploads: with DedupByName
+// paired files are considered equal when their sizes match; adding
+// DedupByMD5 additionally compares content hashes, using the hash the server
+// recorded for the remote file and downloading only files that have none.
+// Only new and changed files are transferred, and files missing
+// from the source side are deleted when DeleteRemoved is set.
+//
+// Configure the exported fields before calling Diff or Sync; a VolumeSyncer
+// must not be modified while a call is in progress.
+type VolumeSyncer struct {
+	client   *SDKClient
+	volumeID VolumeID
+	localDir string
+
+	// Direction selects the source of truth. Defaults to SyncToVolume.
+	Direction SyncDirection
+	// CompareBy lists the criteria used to decide whether paired files are
+	// equal. Defaults to DedupByName.
+	CompareBy []DedupBy
+	// DeleteRemoved deletes files on the destination side that do not exist
+	// on the source side.
+	DeleteRemoved bool
+	// DryRun makes Sync report the planned changes without applying them.
+	DryRun bool
+	// Include and Exclude filter local files like DirectoryImportOptions;
+	// the same patterns are applied to volume paths.
+	Include []string
+	Exclude []string
+	// Concurrency is the maximum number of transfers at the same time.
+	// Defaults to 4.
+	Concurrency int
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// NewVolumeSyncer returns a VolumeSyncer between localDir and volumeID.
+//
+// Example:
+//
+//	syncer := sdk.NewVolumeSyncer(sdkClient, "./docs", volumeID)
+//	syncer.CompareBy = []sdk.DedupBy{sdk.DedupByName, sdk.DedupByMD5}
+//	syncer.DeleteRemoved = true
+//	syncer.DryRun = true
+//	report, err := syncer.Sync(ctx)
+//	for _, c := range report.Changes {
+//		fmt.Println(c.Action, c.Path, c.Reason)
+//	}
+func NewVolumeSyncer(client *SDKClient, localDir string, volumeID VolumeID) *VolumeSyncer {
+	return &VolumeSyncer{client: client, localDir: localDir, volumeID: volumeID}
+}
+
+// Diff compares both sides and returns the changes Sync would apply.
+func (s *VolumeSyncer) Diff(ctx context.Context) (*SyncReport, error) {
+	report, err := s.diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = true
+	return report, nil
+}
+
+// Sync applies the changes found by Diff, or only reports them when DryRun
+// is set. The returned error summarizes failed changes; the report is
+// returned in that case too.
+func (s *VolumeSyncer) Sync(ctx context.Context) (*SyncReport, error) {
+	report, err := s.diff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.DryRun = s.DryRun
+	if s.DryRun || len(report.Changes) == 0 {
+		return report, nil
+	}
+
+	switch s.Direction {
+	case SyncToVolume:
+		if err := s.applyToVolume(ctx, report); err != nil {
+			return report, err
+		}
+	case SyncToLocal:
+		s.applyToLocal(ctx, report)
+	}
+	if failed := report.Failed(); len(failed) > 0 {
+		return report, fmt.Errorf("%d of %d sync changes failed, first error: %w", len(failed), len(report.Changes), failed[0].Err)
+	}
+	return report, nil
+}
+
+func (s *VolumeSyncer) diff(ctx context.Context) (*SyncReport, error) {
+	if s.client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if s.volumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	if strings.TrimSpace(s.localDir) == "" {
+		return nil, fmt.Errorf("local_dir is required")
+	}
+	for _, pattern := range append(append([]string{}, s.Include...), s.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+
+	var localFiles []string
+	if info, err := os.Stat(s.localDir); err == nil {
+		if !info.IsDir() {
+			return nil, fmt.Errorf("%s is not a directory", s.localDir)
+		}
+		if _, localFiles, err = walkLocalDir(s.localDir, s.Include, s.Exclude); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) || s.Direction == SyncToVolume {
+		return nil, err
+	}
+
+	remote := &DirectoryExportResult{}
+	if err := s.client.listVolumeTree(ctx, s.volumeID, "", "", remote, s.CallOptions); err != nil {
+		return nil, err
+	}
+	remoteFiles := make(map[string]DirectoryExportFile, len(remote.Files))
+	for _, f := range remote.Files {
+		if f.Err != nil || !s.included(f.Path) {
+			continue
+		}
+		remoteFiles[f.Path] = f
+	}
+
+	report := &SyncReport{Direction: s.Direction}
+	localSet := make(map[string]bool, len(localFiles))
+	for _, rel := range localFiles {
+		localSet[rel] = true
+		rf, ok := remoteFiles[rel]
+		if !ok {
+			if s.Direction == SyncToVolume {
+				report.Changes = append(report.Changes, SyncChange{Action: SyncActionCreate, Path: rel})
+			} else if s.DeleteRemoved {
+				report.Changes = append(report.Changes, SyncChange{Action: SyncActionDelete, Path: rel})
+			}
+			continue
+		}
+		reason, err := s.compare(ctx, rel, rf)
+		if err != nil {
+			return nil, fmt.Errorf("compare %s: %w", rel, err)
+		}
+		if reason == "" {
+			report.Unchanged++
+			continue
+		}
+		report.Changes = append(report.Changes, SyncChange{Action: SyncActionUpdate, Path: rel, FileID: rf.FileID, Reason: reason})
+	}
+	for rel, rf := range remoteFiles {
+		if localSet[rel] {
+			continue
+		}
+		if s.Direction == SyncToLocal {
+			report.Changes = append(report.Changes, SyncChange{Action: SyncActionCreate, Path: rel, FileID: rf.FileID})
+		} else if s.DeleteRemoved {
+			report.Changes = append(report.Changes, SyncChange{Action: SyncActionDelete, Path: rel, FileID: rf.FileID})
+		}
+	}
+	sort.Slice(report.Changes, func(i, j int) bool { return report.Changes[i].Path < report.Changes[j].Path })
+	return report, nil
+}
+
+// included applies the Include and Exclude patterns to a volume path the
+// same way walkLocalDir applies them to local paths.
+func (s *VolumeSyncer) included(rel string) bool {
+	if matchAnyGlob(s.Exclude, rel) || s.underExcludedDir(rel) {
+		return false
+	}
+	return len(s.Include) == 0 || matchAnyGlob(s.Include, rel)
+}
+
+func (s *VolumeSyncer) underExcludedDir(rel string) bool {
+	for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+		if matchAnyGlob(s.Exclude, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// compare returns why the local and remote copies of rel differ, or "" if
+// they are equal under CompareBy.
+func (s *VolumeSyncer) compare(ctx context.Context, rel string, remote DirectoryExportFile) (string, error) {
+	info, err := os.Stat(filepath.Join(s.localDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return "", err
+	}
+	if info.Size() != remote.Size {
+		return "size", nil
+	}
+	byMD5 := false
+	for _, by := range s.CompareBy {
+		if by == DedupByMD5 {
+			byMD5 = true
+		}
+	}
+	if !byMD5 {
+		return "", nil
+	}
+	algorithm, remoteSum, err := s.remoteHash(ctx, remote.FileID)
+	if err != nil {
+		return "", err
+	}
+	localSum, err := localFileHash(filepath.Join(s.localDir, filepath.FromSlash(rel)), algorithm)
+	if err != nil {
+		return "", err
+	}
+	if localSum != remoteSum {
+		return algorithm, nil
+	}
+	return "", nil
+}
+
+// remoteHash returns the hash the server recorded for fileID, or the MD5 of
+// its downloaded content if none was recorded.
+func (s *VolumeSyncer) remoteHash(ctx context.Context, fileID FileID) (algorithm, digest string, err error) {
+	info, err := s.client.raw.GetFile(ctx, &FileInfoRequest{FileID: fileID}, s.CallOptions...)
+	if err != nil {
+		return "", "", err
+	}
+	if algorithm, digest = splitFileHash(info.Hash); algorithm != "" {
+		return algorithm, digest, nil
+	}
+
+	stream, err := s.client.raw.DownloadFileStream(ctx, fileID, s.volumeID, s.CallOptions...)
+	if err != nil {
+		return "", "", err
+	}
+	defer stream.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, stream.Body); err != nil {
+		return "", "", err
+	}
+	return HashMD5, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func localMD5(filePath string) (string, error) {
+	return localFileHash(filePath, HashMD5)
+}
+
+// localFileHash returns the hex digest of a local file with algorithm,
+// HashMD5 or HashSHA256.
+func localFileHash(filePath, algorithm string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	var h hash.Hash = md5.New()
+	if algorithm == HashSHA256 {
+		h = sha256.New()
+	}
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (s *VolumeSyncer) applyToVolume(ctx context.Context, report *SyncReport) error {
+	// Make sure the folders of new files exist, parents first.
+	needed := make(map[string]bool)
+	for _, c := range report.Changes {
+		if c.Action == SyncActionCreate {
+			for dir := path.Dir(c.Path); dir != "."; dir = path.Dir(dir) {
+				needed[dir] = true
+			}
+		}
+	}
+	dirs := make([]string, 0, len(needed))
+	for dir := range needed {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	folders := map[string]FileID{".": ""}
+	for _, dir := range dirs {
+		id, err := s.client.ensureFolder(ctx, s.volumeID, folders[path.Dir(dir)], path.Base(dir), s.CallOptions)
+		if err != nil {
+			return fmt.Errorf("create folder %s: %w", dir, err)
+		}
+		folders[dir] = id
+	}
+	replace := NewDedupConfig([]DedupBy{DedupByName}, DedupStrategyReplace)
+	s.forEachChange(ctx, report, func(c *SyncChange) error {
+		switch c.Action {
+		case SyncActionDelete:
+			_, err := s.client.raw.DeleteFile(ctx, &FileDeleteRequest{FileID: c.FileID}, s.CallOptions...)
+			return err
+		default:
+			_, err := s.client.ImportLocalFileToVolume(ctx, filepath.Join(s.localDir, filepath.FromSlash(c.Path)), s.volumeID,
+				FileMeta{Filename: path.Base(c.Path), Path: c.Path}, replace, s.CallOptions...)
+			return err
+		}
+	})
+	return nil
+}
+
+func (s *VolumeSyncer) applyToLocal(ctx context.Context, report *SyncReport) {
+	s.forEachChange(ctx, report, func(c *SyncChange) error {
+		localPath := filepath.Join(s.localDir, filepath.FromSlash(c.Path))
+		if c.Action == SyncActionDelete {
+			return os.Remove(localPath)
+		}
+		if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+			return err
+		}
+		return s.client.downloadFileWithRetry(ctx, c.FileID, s.volumeID, localPath, defaultDirectoryExportRetries, s.CallOptions)
+	})
+}
+
+// forEachChange runs apply for every change with bounded concurrency and
+// records the errors in the report.
+func (s *VolumeSyncer) forEachChange(ctx context.Context, report *SyncReport, apply func(*SyncChange) error) {
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultDirectoryImportConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range report.Changes {
+		c := &report.Changes[i]
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			c.Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			c.Err = apply(c)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,182 @@
+package sdk
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSyncMockClient(t *testing.T, remote map[string][]VolumeChildrenResponse, content, hashes map[FileID]string) (*RawClient, *[]string, *[]FileID, *[]FileID) {
+	var (
+		mu         sync.Mutex
+		uploaded   []string
+		deleted    []FileID
+		downloaded []FileID
+	)
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			var parent string
+			for _, f := range req.Filters {
+				if f.Name == "parent_id" {
+					parent = f.Values[0]
+				}
+			}
+			writeEnvelope(w, FileListResponse{Total: len(remote[parent]), List: remote[parent]})
+		},
+		"/catalog/file/info": func(w http.ResponseWriter, r *http.Request) {
+			var req FileInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			writeEnvelope(w, FileInfoResponse{ID: req.FileID, Hash: hashes[req.FileID]})
+		},
+		"/catalog/file/download_stream": func(w http.ResponseWriter, r *http.Request) {
+			var req FileDownloadRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			mu.Lock()
+			downloaded = append(downloaded, req.FileID)
+			mu.Unlock()
+			_, _ = w.Write([]byte(content[req.FileID]))
+		},
+		"/catalog/file/delete": func(w http.ResponseWriter, r *http.Request) {
+			var req FileDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			mu.Lock()
+			deleted = append(deleted, req.FileID)
+			mu.Unlock()
+			writeEnvelope(w, FileDeleteResponse{FileID: req.FileID})
+		},
+		"/catalog/folder/create": func(w http.ResponseWriter, r *http.Request) {
+			var req FolderCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			writeEnvelope(w, FolderCreateResponse{FolderID: FileID("new-" + req.Name), Name: req.Name})
+		},
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			var metas []FileMeta
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("meta")), &metas))
+			mu.Lock()
+			uploaded = append(uploaded, metas[0].Path)
+			mu.Unlock()
+			writeEnvelope(w, UploadFileResponse{Success: true})
+		},
+	})
+	return raw, &uploaded, &deleted, &downloaded
+}
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for p, data := range files {
+		full := filepath.Join(root, filepath.FromSlash(p))
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(data), 0o644))
+	}
+}
+
+func TestVolumeSyncer_ToVolume(t *testing.T) {
+	t.Parallel()
+	remote := map[string][]VolumeChildrenResponse{
+		"": {
+			{ID: "same", Name: "same.txt", FileType: "file", Size: 4},
+			{ID: "md5", Name: "md5.txt", FileType: "file", Size: 4},
+			{ID: "size", Name: "size.txt", FileType: "file", Size: 1},
+			{ID: "gone", Name: "gone.txt", FileType: "file", Size: 1},
+		},
+	}
+	content := map[FileID]string{"same": "same", "md5": "old!", "size": "x", "gone": "g"}
+	sameSum := md5.Sum([]byte("same"))
+	oldSum := sha256.Sum256([]byte("old!"))
+	hashes := map[FileID]string{
+		"same": hex.EncodeToString(sameSum[:]),
+		"md5":  "sha256:" + hex.EncodeToString(oldSum[:]),
+	}
+	raw, uploaded, deleted, downloaded := newSyncMockClient(t, remote, content, hashes)
+
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"same.txt":    "same",
+		"md5.txt":     "new!",
+		"size.txt":    "bigger",
+		"sub/new.txt": "n",
+	})
+
+	syncer := NewVolumeSyncer(NewSDKClient(raw), dir, "vol-1")
+	syncer.CompareBy = []DedupBy{DedupByName, DedupByMD5}
+	syncer.DeleteRemoved = true
+
+	diff, err := syncer.Diff(context.Background())
+	require.NoError(t, err)
+	require.True(t, diff.DryRun)
+	require.Equal(t, 1, diff.Unchanged)
+	require.Equal(t, []SyncChange{
+		{Action: SyncActionDelete, Path: "gone.txt", FileID: "gone"},
+		{Action: SyncActionUpdate, Path: "md5.txt", FileID: "md5", Reason: "sha256"},
+		{Action: SyncActionUpdate, Path: "size.txt", FileID: "size", Reason: "size"},
+		{Action: SyncActionCreate, Path: "sub/new.txt"},
+	}, diff.Changes)
+	require.Empty(t, *uploaded)
+	require.Empty(t, *downloaded, "recorded hashes are compared without downloading")
+
+	report, err := syncer.Sync(context.Background())
+	require.NoError(t, err)
+	require.False(t, report.DryRun)
+	sort.Strings(*uploaded)
+	require.Equal(t, []string{"md5.txt", "size.txt", "sub/new.txt"}, *uploaded)
+	require.Equal(t, []FileID{"gone"}, *deleted)
+}
+
+func TestVolumeSyncer_DownloadsFilesWithoutHash(t *testing.T) {
+	t.Parallel()
+	remote := map[string][]VolumeChildrenResponse{
+		"": {
+			{ID: "a", Name: "a.txt", FileType: "file", Size: 4},
+			{ID: "b", Name: "b.txt", FileType: "file", Size: 4},
+		},
+	}
+	raw, _, _, downloaded := newSyncMockClient(t, remote, map[FileID]string{"a": "same", "b": "old!"}, nil)
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a.txt": "same", "b.txt": "new!"})
+
+	syncer := NewVolumeSyncer(NewSDKClient(raw), dir, "vol-1")
+	syncer.CompareBy = []DedupBy{DedupByName, DedupByMD5}
+	diff, err := syncer.Diff(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, diff.Unchanged)
+	require.Equal(t, []SyncChange{{Action: SyncActionUpdate, Path: "b.txt", FileID: "b", Reason: "md5"}}, diff.Changes)
+	sort.Slice(*downloaded, func(i, j int) bool { return (*downloaded)[i] < (*downloaded)[j] })
+	require.Equal(t, []FileID{"a", "b"}, *downloaded)
+}
+
+func TestVolumeSyncer_ToLocal(t *testing.T) {
+	t.Parallel()
+	remote := map[string][]VolumeChildrenResponse{
+		"":  {{ID: "d", Name: "docs", FileType: "dir"}},
+		"d": {{ID: "a", Name: "a.txt", FileType: "file", Size: 5}},
+	}
+	raw, _, _, _ := newSyncMockClient(t, remote, map[FileID]string{"a": "hello"}, nil)
+
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"stale.txt": "x"})
+
+	syncer := NewVolumeSyncer(NewSDKClient(raw), dir, "vol-1")
+	syncer.Direction = SyncToLocal
+	syncer.DeleteRemoved = true
+	report, err := syncer.Sync(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 2)
+
+	got, err := os.ReadFile(filepath.Join(dir, "docs", "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(got))
+	require.NoFileExists(t, filepath.Join(dir, "stale.txt"))
+}
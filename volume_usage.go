@@ -0,0 +1,231 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// volumeUsageLargestFiles is the number of files listed in
+// VolumeUsage.LargestFiles.
+const volumeUsageLargestFiles = 10
+
+// ExtensionUsage is the storage taken by the files of one extension.
+type ExtensionUsage struct {
+	Files int
+	Size  int64
+}
+
+// UsageFile is a file listed in a usage report.
+type UsageFile struct {
+	FileID FileID
+	// Path is the slash-separated path of the file below the volume root.
+	Path string
+	Size int64
+}
+
+// VolumeUsage reports the storage used by a volume.
+type VolumeUsage struct {
+	VolumeID    VolumeID
+	TotalSize   int64
+	FileCount   int
+	FolderCount int
+	// ByExtension maps lower-case file extensions without the dot, or ""
+	// for files without one, to the storage they take.
+	ByExtension map[string]ExtensionUsage
+	// LargestFiles holds the largest files of the volume, largest first.
+	LargestFiles []UsageFile
+}
+
+// DatabaseUsage reports the storage used by the tables and volumes of a
+// database.
+type DatabaseUsage struct {
+	DatabaseID DatabaseID
+	Name       string
+	TableCount int
+	// TableSize is the sum of the table sizes reported by the catalog.
+	TableSize int64
+	// VolumeSize and FileCount sum the usage of the volumes.
+	VolumeSize int64
+	FileCount  int
+	Volumes    []*VolumeUsage
+}
+
+// CatalogUsage reports the storage used by the databases of a catalog.
+type CatalogUsage struct {
+	CatalogID  CatalogID
+	Name       string
+	TableCount int
+	TableSize  int64
+	VolumeSize int64
+	FileCount  int
+	Databases  []*DatabaseUsage
+}
+
+// GetVolumeUsage reports the total size, file count, breakdown by file
+// extension and largest files of a volume.
+//
+// The figures are computed from a listing of every folder of the volume, so
+// the call takes one request per folder and page.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - volumeID: the volume to report on (required)
+//
+// Returns:
+//   - *VolumeUsage: the usage of the volume
+//   - error: any error that occurred
+//
+// Example:
+//
+//	usage, err := sdkClient.GetVolumeUsage(ctx, volumeID)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%d files, %d bytes, %d bytes of PDF\n",
+//		usage.FileCount, usage.TotalSize, usage.ByExtension["pdf"].Size)
+func (c *SDKClient) GetVolumeUsage(ctx context.Context, volumeID VolumeID, opts ...CallOption) (*VolumeUsage, error) {
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	var entries []folderTreeEntry
+	if err := c.listFolderTree(ctx, volumeID, "", "", &entries, opts); err != nil {
+		return nil, err
+	}
+
+	usage := &VolumeUsage{VolumeID: volumeID, ByExtension: map[string]ExtensionUsage{}}
+	var files []UsageFile
+	for _, e := range entries {
+		if e.folder {
+			usage.FolderCount++
+			continue
+		}
+		usage.FileCount++
+		usage.TotalSize += e.item.Size
+		ext := fileExtension(e.item)
+		byExt := usage.ByExtension[ext]
+		byExt.Files++
+		byExt.Size += e.item.Size
+		usage.ByExtension[ext] = byExt
+		files = append(files, UsageFile{FileID: e.id, Path: e.path, Size: e.item.Size})
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Size > files[j].Size })
+	if len(files) > volumeUsageLargestFiles {
+		files = files[:volumeUsageLargestFiles]
+	}
+	usage.LargestFiles = files
+	return usage, nil
+}
+
+// GetDatabaseUsage reports the storage used by a database: the sizes of its
+// tables as reported by the catalog, and the usage of each of its volumes as
+// computed by GetVolumeUsage.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - databaseID: the database to report on (required)
+//
+// Returns:
+//   - *DatabaseUsage: the usage of the database and of each volume
+//   - error: any error that occurred
+//
+// Example:
+//
+//	usage, err := sdkClient.GetDatabaseUsage(ctx, databaseID)
+//	if err != nil {
+//		return err
+//	}
+//	for _, v := range usage.Volumes {
+//		fmt.Printf("volume %s: %d bytes\n", v.VolumeID, v.TotalSize)
+//	}
+func (c *SDKClient) GetDatabaseUsage(ctx context.Context, databaseID DatabaseID, opts ...CallOption) (*DatabaseUsage, error) {
+	if databaseID == 0 {
+		return nil, fmt.Errorf("database_id is required")
+	}
+	info, err := c.raw.GetDatabase(ctx, &DatabaseInfoRequest{DatabaseID: databaseID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get database %d: %w", databaseID, err)
+	}
+	return c.databaseUsage(ctx, databaseID, info.DatabaseName, opts)
+}
+
+// GetCatalogUsage reports the storage used by every database of a catalog,
+// as GetDatabaseUsage does, together with their totals.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - catalogID: the catalog to report on (required)
+//
+// Returns:
+//   - *CatalogUsage: the usage of the catalog and of each database
+//   - error: any error that occurred
+//
+// Example:
+//
+//	usage, err := sdkClient.GetCatalogUsage(ctx, catalogID)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("tables: %d bytes, volumes: %d bytes\n", usage.TableSize, usage.VolumeSize)
+func (c *SDKClient) GetCatalogUsage(ctx context.Context, catalogID CatalogID, opts ...CallOption) (*CatalogUsage, error) {
+	if catalogID == 0 {
+		return nil, fmt.Errorf("catalog_id is required")
+	}
+	info, err := c.raw.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: catalogID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get catalog %d: %w", catalogID, err)
+	}
+	dbs, err := c.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: catalogID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list databases of catalog %d: %w", catalogID, err)
+	}
+
+	usage := &CatalogUsage{CatalogID: catalogID, Name: info.CatalogName}
+	for _, db := range dbs.List {
+		dbUsage, err := c.databaseUsage(ctx, db.DatabaseID, db.DatabaseName, opts)
+		if err != nil {
+			return nil, err
+		}
+		usage.Databases = append(usage.Databases, dbUsage)
+		usage.TableCount += dbUsage.TableCount
+		usage.TableSize += dbUsage.TableSize
+		usage.VolumeSize += dbUsage.VolumeSize
+		usage.FileCount += dbUsage.FileCount
+	}
+	return usage, nil
+}
+
+func (c *SDKClient) databaseUsage(ctx context.Context, databaseID DatabaseID, name string, opts []CallOption) (*DatabaseUsage, error) {
+	children, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list children of database %d: %w", databaseID, err)
+	}
+	usage := &DatabaseUsage{DatabaseID: databaseID, Name: name}
+	for _, child := range children.List {
+		switch child.Typ {
+		case NodeTypeTable:
+			usage.TableCount++
+			usage.TableSize += child.Size
+		case NodeTypeVolume:
+			volUsage, err := c.GetVolumeUsage(ctx, VolumeID(child.ID), opts...)
+			if err != nil {
+				return nil, fmt.Errorf("usage of volume %q: %w", child.Name, err)
+			}
+			usage.Volumes = append(usage.Volumes, volUsage)
+			usage.VolumeSize += volUsage.TotalSize
+			usage.FileCount += volUsage.FileCount
+		}
+	}
+	return usage, nil
+}
+
+// fileExtension returns the lower-case extension of a file without the dot.
+func fileExtension(file VolumeChildrenResponse) string {
+	ext := path.Ext(file.Name)
+	if ext == "" {
+		ext = file.FileExt
+	}
+	return strings.ToLower(strings.TrimPrefix(ext, "."))
+}
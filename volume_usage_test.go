@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// volumeUsageRoutes serves catalog 1 with database 10, which holds table t1
+// of 500 bytes and volume v1. v1 has a root folder with a.PDF, b.pdf and
+// folder "logs", which holds twelve .log files of 1 to 12 bytes.
+func volumeUsageRoutes(t *testing.T) map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"/catalog/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, CatalogInfoResponse{CatalogID: 1, CatalogName: "main"})
+		},
+		"/catalog/database/list": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseListResponse{List: []DatabaseResponse{{DatabaseID: 10, DatabaseName: "docs"}}})
+		},
+		"/catalog/database/info": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseInfoResponse{DatabaseID: 10, DatabaseName: "docs"})
+		},
+		"/catalog/database/children": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, DatabaseChildrenResponseData{List: []DatabaseChildrenResponse{
+				{ID: "t1", Name: "orders", Typ: NodeTypeTable, Size: 500},
+				{ID: "v1", Name: "files", Typ: NodeTypeVolume},
+			}})
+		},
+		"/catalog/file/list": func(w http.ResponseWriter, r *http.Request) {
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "v1", req.Filters[0].Values[0])
+			switch req.Filters[1].Values[0] {
+			case "":
+				writeEnvelope(w, FileListResponse{Total: 3, List: []VolumeChildrenResponse{
+					{ID: "a", Name: "a.PDF", FileType: "pdf", Size: 100},
+					{ID: "logs", Name: "logs", FileType: "folder"},
+					{ID: "b", Name: "b.pdf", FileType: "pdf", Size: 50},
+				}})
+			case "logs":
+				var list []VolumeChildrenResponse
+				for i := 1; i <= 12; i++ {
+					list = append(list, VolumeChildrenResponse{ID: fmt.Sprintf("l%d", i), Name: fmt.Sprintf("%d.log", i), FileType: "log", Size: int64(i)})
+				}
+				writeEnvelope(w, FileListResponse{Total: len(list), List: list})
+			default:
+				writeEnvelope(w, FileListResponse{})
+			}
+		},
+	}
+}
+
+func TestGetVolumeUsage(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, volumeUsageRoutes(t)))
+
+	usage, err := client.GetVolumeUsage(context.Background(), "v1")
+	require.NoError(t, err)
+	require.Equal(t, 14, usage.FileCount)
+	require.Equal(t, 1, usage.FolderCount)
+	require.Equal(t, int64(150+78), usage.TotalSize)
+	require.Equal(t, map[string]ExtensionUsage{"pdf": {Files: 2, Size: 150}, "log": {Files: 12, Size: 78}}, usage.ByExtension)
+	require.Len(t, usage.LargestFiles, 10)
+	require.Equal(t, UsageFile{FileID: "a", Path: "a.PDF", Size: 100}, usage.LargestFiles[0])
+	require.Equal(t, UsageFile{FileID: "l12", Path: "logs/12.log", Size: 12}, usage.LargestFiles[2])
+	require.Equal(t, int64(5), usage.LargestFiles[9].Size)
+
+	_, err = client.GetVolumeUsage(context.Background(), "")
+	require.Error(t, err)
+}
+
+func TestGetCatalogUsage(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, volumeUsageRoutes(t)))
+
+	dbUsage, err := client.GetDatabaseUsage(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, "docs", dbUsage.Name)
+	require.Equal(t, 1, dbUsage.TableCount)
+	require.Equal(t, int64(500), dbUsage.TableSize)
+	require.Equal(t, int64(228), dbUsage.VolumeSize)
+	require.Equal(t, 14, dbUsage.FileCount)
+	require.Len(t, dbUsage.Volumes, 1)
+
+	usage, err := client.GetCatalogUsage(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "main", usage.Name)
+	require.Len(t, usage.Databases, 1)
+	require.Equal(t, int64(500), usage.TableSize)
+	require.Equal(t, int64(228), usage.VolumeSize)
+	require.Equal(t, 14, usage.FileCount)
+}
@@ -0,0 +1,144 @@
+package sdk
+
+import (
+	"context"
+	"slices"
+)
+
+// defaultWorkflowJobPageSize is the number of jobs fetched per request when
+// the request gives no page size.
+const defaultWorkflowJobPageSize = 50
+
+// matches reports whether job satisfies the status and start time filters
+// of r. Jobs whose start time cannot be parsed are kept.
+func (r *WorkflowJobListRequest) matches(job *WorkflowJob) bool {
+	if r.Status != "" || len(r.Statuses) > 0 {
+		ok := slices.Contains(r.Statuses, job.Status)
+		if !ok && r.Status != "" {
+			ok = ParseWorkflowJobStatus(r.Status) == job.Status
+		}
+		if !ok {
+			return false
+		}
+	}
+	if r.StartedAfter.IsZero() && r.StartedBefore.IsZero() {
+		return true
+	}
+	loc := r.StartedAfter.Location()
+	if r.StartedAfter.IsZero() {
+		loc = r.StartedBefore.Location()
+	}
+	started, ok := parseServerTime(job.StartTime, loc)
+	if !ok {
+		return true
+	}
+	if !r.StartedAfter.IsZero() && started.Before(r.StartedAfter) {
+		return false
+	}
+	return r.StartedBefore.IsZero() || started.Before(r.StartedBefore)
+}
+
+// WorkflowJobPager iterates over the workflow jobs of a list request page
+// by page. Create one with PageWorkflowJobs.
+type WorkflowJobPager struct {
+	client *SDKClient
+	ctx    context.Context
+	req    WorkflowJobListRequest
+	opts   []CallOption
+
+	batch []WorkflowJob
+	pos   int
+	done  bool
+	err   error
+}
+
+// PageWorkflowJobs opens a pager over the workflow jobs selected by req.
+//
+// The filters of req are sent to the server; the statuses and the start
+// time range are also checked on the returned jobs. req.Page is the first
+// page read, and req.PageSize the number of jobs per request, 50 if unset.
+// A nil request lists every job. No request is made until the first call
+// to Next.
+//
+// Example:
+//
+//	pager := sdkClient.PageWorkflowJobs(ctx, &sdk.WorkflowJobListRequest{
+//		WorkflowID:   workflowID,
+//		Statuses:     []sdk.WorkflowJobStatus{sdk.WorkflowJobStatusFailed},
+//		StartedAfter: time.Now().Add(-24 * time.Hour),
+//	})
+//	for pager.Next() {
+//		job := pager.Job()
+//		fmt.Printf("%s failed at %s\n", job.JobID, job.EndTime)
+//	}
+//	if err := pager.Err(); err != nil {
+//		return err
+//	}
+func (c *SDKClient) PageWorkflowJobs(ctx context.Context, req *WorkflowJobListRequest, opts ...CallOption) *WorkflowJobPager {
+	var r WorkflowJobListRequest
+	if req != nil {
+		r = *req
+	}
+	if r.Page <= 0 {
+		r.Page = 1
+	}
+	if r.PageSize <= 0 {
+		r.PageSize = defaultWorkflowJobPageSize
+	}
+	// The first fetch moves to r.Page.
+	r.Page--
+	return &WorkflowJobPager{client: c, ctx: ctx, req: r, opts: opts}
+}
+
+// Next advances the pager to the next job, fetching the next page when the
+// current one is exhausted. It returns false when there are no more jobs or
+// an error occurred; check Err to tell them apart.
+func (p *WorkflowJobPager) Next() bool {
+	for p.err == nil {
+		p.pos++
+		for p.pos < len(p.batch) {
+			if p.req.matches(&p.batch[p.pos]) {
+				return true
+			}
+			p.pos++
+		}
+		if p.done {
+			return false
+		}
+		if err := p.fetch(); err != nil {
+			p.err = err
+			return false
+		}
+		p.pos = -1
+	}
+	return false
+}
+
+func (p *WorkflowJobPager) fetch() error {
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	p.req.Page++
+	resp, err := p.client.raw.ListWorkflowJobs(p.ctx, &p.req, p.opts...)
+	if err != nil {
+		return err
+	}
+	p.batch = resp.Jobs
+	if len(p.batch) < p.req.PageSize || (resp.Total > 0 && p.req.Page*p.req.PageSize >= resp.Total) {
+		p.done = true
+	}
+	return nil
+}
+
+// Job returns the current job. It is valid only after Next returned true.
+func (p *WorkflowJobPager) Job() *WorkflowJob {
+	if p.pos < 0 || p.pos >= len(p.batch) {
+		return nil
+	}
+	return &p.batch[p.pos]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *WorkflowJobPager) Err() error {
+	return p.err
+}
@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorkflowJobStatus(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, WorkflowJobStatusRunning, ParseWorkflowJobStatus("Running"))
+	require.Equal(t, WorkflowJobStatusCompleted, ParseWorkflowJobStatus("2"))
+	require.Equal(t, WorkflowJobStatusFailed, ParseWorkflowJobStatus(" failed "))
+	require.Equal(t, WorkflowJobStatusUnknown, ParseWorkflowJobStatus("paused"))
+	require.True(t, WorkflowJobStatusFailed.IsTerminal())
+	require.False(t, WorkflowJobStatusRunning.IsTerminal())
+}
+
+func TestPageWorkflowJobs(t *testing.T) {
+	t.Parallel()
+	// Seven jobs started one hour apart; every third one failed. The server
+	// applies no filter, so the pager has to.
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	var queries []url.Values
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/workflow_job": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			queries = append(queries, q)
+			page, _ := strconv.Atoi(q.Get("page"))
+			size, _ := strconv.Atoi(q.Get("page_size"))
+			var jobs []map[string]any
+			for i := (page - 1) * size; i < page*size && i < 7; i++ {
+				status := WorkflowJobStatusCompleted
+				if i%3 == 0 {
+					status = WorkflowJobStatusFailed
+				}
+				jobs = append(jobs, map[string]any{
+					"id":          fmt.Sprintf("job-%d", i),
+					"workflow_id": "wf-1",
+					"status":      int(status),
+					"start_time":  base.Add(time.Duration(i) * time.Hour).Format("2006-01-02 15:04:05"),
+				})
+			}
+			writeEnvelope(w, map[string]any{"jobs": jobs, "total": 7})
+		},
+	}))
+
+	pager := client.PageWorkflowJobs(context.Background(), &WorkflowJobListRequest{
+		WorkflowID:    "wf-1",
+		SourceFileIDs: []string{"f1", "f2"},
+		Statuses:      []WorkflowJobStatus{WorkflowJobStatusFailed},
+		StartedAfter:  base.Add(time.Hour),
+		StartedBefore: base.Add(6 * time.Hour),
+		PageSize:      3,
+	})
+	var ids []string
+	for pager.Next() {
+		ids = append(ids, pager.Job().JobID)
+	}
+	require.NoError(t, pager.Err())
+	require.Equal(t, []string{"job-3"}, ids)
+
+	require.Len(t, queries, 3)
+	q := queries[0]
+	require.Equal(t, "wf-1", q.Get("workflow_id"))
+	require.Equal(t, []string{"f1", "f2"}, q["source_file_id"])
+	require.Equal(t, []string{"failed"}, q["status"])
+	require.Equal(t, "2024-05-01T01:00:00Z", q.Get("start_time_after"))
+	require.Equal(t, "2024-05-01T06:00:00Z", q.Get("start_time_before"))
+	require.Equal(t, "3", queries[2].Get("page"))
+}
+
+func TestPageWorkflowJobsError(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/workflow_job": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelopeError(w, "ErrInternal", "boom")
+		},
+	}))
+	pager := client.PageWorkflowJobs(context.Background(), nil)
+	require.False(t, pager.Next())
+	require.ErrorContains(t, pager.Err(), "boom")
+	require.Nil(t, pager.Job())
+}
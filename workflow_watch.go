@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// WorkflowJobEvent is a status change of a workflow job, as delivered by
+// WatchWorkflowJob.
+type WorkflowJobEvent struct {
+	JobID          string            `json:"job_id"`
+	WorkflowID     string            `json:"workflow_id"`
+	SourceFileID   string            `json:"source_file_id"`
+	Status         WorkflowJobStatus `json:"status"`
+	PreviousStatus WorkflowJobStatus `json:"previous_status"`
+	Message        string            `json:"message,omitempty"` // Error or progress message, if any
+	Time           string            `json:"time"`              // Time of the status change
+}
+
+// IsTerminal reports whether the job will no longer change status after
+// this event.
+func (e *WorkflowJobEvent) IsTerminal() bool {
+	return e.Status.IsTerminal()
+}
+
+// WorkflowJobWatcher reads workflow job events from a server-sent event
+// stream. It must be closed by the caller.
+type WorkflowJobWatcher struct {
+	stream *SSEStream[*WorkflowJobEvent]
+	done   bool
+}
+
+// Next returns the next status change of the job.
+//
+// Next returns io.EOF after the event that moves the job to a terminal
+// status, or when the server ends the stream.
+func (w *WorkflowJobWatcher) Next() (*WorkflowJobEvent, error) {
+	if w.done {
+		return nil, io.EOF
+	}
+	event, err := w.stream.Next()
+	if err != nil {
+		if err == io.EOF {
+			w.done = true
+		}
+		return nil, err
+	}
+	if event.IsTerminal() {
+		w.done = true
+	}
+	return event, nil
+}
+
+// Close releases the underlying stream.
+func (w *WorkflowJobWatcher) Close() error {
+	if w == nil {
+		return nil
+	}
+	return w.stream.Close()
+}
+
+// decodeWorkflowJobEvent decodes a job status event; "error" events are
+// returned as errors.
+func decodeWorkflowJobEvent(raw *SSEEvent) (*WorkflowJobEvent, error) {
+	if raw.Event == "error" {
+		return nil, fmt.Errorf("workflow job event stream: %s", sseErrorMessage(raw))
+	}
+	var event WorkflowJobEvent
+	if err := json.Unmarshal(raw.Data, &event); err != nil {
+		return nil, fmt.Errorf("decode workflow job event: %w", err)
+	}
+	return &event, nil
+}
+
+// sseErrorMessage extracts the message of an "error" event, falling back to
+// the raw data.
+func sseErrorMessage(event *SSEEvent) string {
+	var payload struct {
+		Message string `json:"message"`
+		Msg     string `json:"msg"`
+	}
+	if json.Unmarshal(event.Data, &payload) == nil {
+		if payload.Message != "" {
+			return payload.Message
+		}
+		if payload.Msg != "" {
+			return payload.Msg
+		}
+	}
+	return string(event.Data)
+}
+
+// StreamWorkflowJobEvents opens a server-sent event stream of status changes
+// for the jobs of a workflow that process the given source file.
+//
+// Heartbeat events are dropped. If the connection fails mid-stream, it is
+// reopened with the Last-Event-ID of the last event received, so that no
+// status change is lost. WithStreamBufferSize, WithStreamReadTimeout,
+// WithStreamAutoReconnect and WithStreamResumeCallback apply.
+func (c *RawClient) StreamWorkflowJobEvents(ctx context.Context, workflowID, sourceFileID string, opts ...CallOption) (*SSEStream[*WorkflowJobEvent], error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, invalidArgument("workflow_id", "workflowID cannot be empty")
+	}
+	if strings.TrimSpace(sourceFileID) == "" {
+		return nil, invalidArgument("source_file_id", "sourceFileID cannot be empty")
+	}
+
+	callOpts := newCallOptions(opts...)
+	query := url.Values{}
+	query.Set("workflow_id", workflowID)
+	query.Set("source_file_id", sourceFileID)
+	path := "/byoa/api/v1/workflow_job/events?" + query.Encode()
+
+	resp, err := c.openEventStream(ctx, http.MethodGet, path, nil, callOpts, "")
+	if err != nil {
+		return nil, err
+	}
+	sseOpts := callOpts.sseOptions(nil)
+	sseOpts.HeartbeatEvents = []string{"ping", "heartbeat"}
+	// Watching is always resumable: the server replays the status changes
+	// after Last-Event-ID.
+	sseOpts.Reconnect = func(ctx context.Context, lastEventID string) (*http.Response, error) {
+		return c.openEventStream(ctx, http.MethodGet, path, nil, callOpts, lastEventID)
+	}
+	return NewSSEStream(ctx, resp, decodeWorkflowJobEvent, sseOpts), nil
+}
+
+// WatchWorkflowJob subscribes to status changes of the workflow job that
+// processes a source file, instead of polling GetWorkflowJob.
+//
+// Parameters:
+//   - ctx: context for the stream; cancelling it ends the subscription
+//   - workflowID: the workflow ID (required)
+//   - sourceFileID: the source file ID (required)
+//
+// Returns:
+//   - *WorkflowJobWatcher: the event reader; the caller must Close it
+//   - error: any error that occurred while opening the stream
+//
+// Example:
+//
+//	watcher, err := sdkClient.WatchWorkflowJob(ctx, "workflow-123", "file-456")
+//	if err != nil {
+//		return err
+//	}
+//	defer watcher.Close()
+//	for {
+//		event, err := watcher.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Printf("Job %s is %s\n", event.JobID, event.Status)
+//	}
+func (c *SDKClient) WatchWorkflowJob(ctx context.Context, workflowID, sourceFileID string, opts ...CallOption) (*WorkflowJobWatcher, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, fmt.Errorf("workflow_id is required")
+	}
+	if strings.TrimSpace(sourceFileID) == "" {
+		return nil, fmt.Errorf("source_file_id is required")
+	}
+	stream, err := c.raw.StreamWorkflowJobEvents(ctx, workflowID, sourceFileID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &WorkflowJobWatcher{stream: stream}, nil
+}
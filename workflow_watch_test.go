@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchWorkflowJob(t *testing.T) {
+	t.Parallel()
+
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/workflow_job/events": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "wf-1", r.URL.Query().Get("workflow_id"))
+			require.Equal(t, "file-1", r.URL.Query().Get("source_file_id"))
+			require.Equal(t, "text/event-stream", r.Header.Get(headerAccept))
+			w.Header().Set(headerContentType, "text/event-stream")
+			fmt.Fprint(w, "event: ping\ndata: {}\n\n")
+			fmt.Fprint(w, `data: {"job_id":"job-1","workflow_id":"wf-1","source_file_id":"file-1","status":1,"previous_status":0}`+"\n\n")
+			fmt.Fprint(w, `data: {"job_id":"job-1","workflow_id":"wf-1","source_file_id":"file-1","status":2,"previous_status":1}`+"\n\n")
+			fmt.Fprint(w, `data: {"job_id":"job-1","status":3}`+"\n\n")
+		},
+	}))
+
+	watcher, err := client.WatchWorkflowJob(context.Background(), "wf-1", "file-1")
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	event, err := watcher.Next()
+	require.NoError(t, err)
+	require.Equal(t, "job-1", event.JobID)
+	require.Equal(t, WorkflowJobStatusRunning, event.Status)
+	require.False(t, event.IsTerminal())
+
+	event, err = watcher.Next()
+	require.NoError(t, err)
+	require.Equal(t, WorkflowJobStatusCompleted, event.Status)
+	require.Equal(t, WorkflowJobStatusRunning, event.PreviousStatus)
+	require.True(t, event.IsTerminal())
+
+	// Events after the terminal one are not delivered.
+	_, err = watcher.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestWatchWorkflowJob_ErrorEvent(t *testing.T) {
+	t.Parallel()
+
+	client := NewSDKClient(newMockClient(t, map[string]http.HandlerFunc{
+		"/byoa/api/v1/workflow_job/events": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerContentType, "text/event-stream")
+			fmt.Fprint(w, "event: error\ndata: {\"message\":\"job not found\"}\n\n")
+		},
+	}))
+
+	watcher, err := client.WatchWorkflowJob(context.Background(), "wf-1", "file-1")
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	_, err = watcher.Next()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "job not found")
+}
+
+func TestWatchWorkflowJob_RequiresIDs(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(&RawClient{})
+
+	_, err := client.WatchWorkflowJob(context.Background(), "", "file-1")
+	require.Error(t, err)
+	_, err = client.WatchWorkflowJob(context.Background(), "wf-1", " ")
+	require.Error(t, err)
+}
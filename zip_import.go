@@ -0,0 +1,246 @@
+package sdk
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ZipImportOptions configures ImportZipToVolume.
+type ZipImportOptions struct {
+	// KeepStructure recreates the folders of the archive in the volume;
+	// otherwise every extracted file lands at the volume root.
+	KeepStructure bool
+	// PathRegex, if set, limits the extraction to the entries whose path
+	// inside the archive matches it.
+	PathRegex string
+	// FileTypes, if set, limits the extraction to files of these types, as
+	// told by DetectFileType for the local check.
+	FileTypes []FileType
+	// Dedup is passed to the upload.
+	Dedup *DedupConfig
+	// NoWait returns once the archive is uploaded, without waiting for the
+	// extraction task; the entries then carry no task results.
+	NoWait bool
+	// PollInterval is the interval between polls of the extraction task.
+	// Defaults to 2 seconds.
+	PollInterval time.Duration
+	// CallOptions are applied to every underlying request.
+	CallOptions []CallOption
+}
+
+// ZipEntryResult is the outcome of one file of the archive.
+type ZipEntryResult struct {
+	// Path is the slash-separated path of the entry inside the archive.
+	Path string
+	// Size is the uncompressed size of the entry.
+	Size int64
+	// Selected is false for entries that PathRegex or FileTypes leave out;
+	// the server does not extract them.
+	Selected bool
+	// Err is the failure reported by the extraction task for the entry.
+	Err error
+}
+
+// ZipImportResult summarizes an ImportZipToVolume call.
+type ZipImportResult struct {
+	Upload *UploadFileResponse
+	// Task is the extraction task as it ended, or nil with NoWait or when
+	// the upload started no task.
+	Task *TaskInfoResponse
+	// Entries lists the files of the archive in archive order, followed by
+	// any failure of the task that matches none of them.
+	Entries []ZipEntryResult
+}
+
+// Failed returns the entries that the extraction task reported as failed.
+func (r *ZipImportResult) Failed() []ZipEntryResult {
+	var failed []ZipEntryResult
+	for _, e := range r.Entries {
+		if e.Err != nil {
+			failed = append(failed, e)
+		}
+	}
+	return failed
+}
+
+// ImportZipToVolume uploads a zip archive to a volume and lets the server
+// extract it.
+//
+// The archive is checked locally before anything is sent: it must be a
+// readable zip file whose entries are neither encrypted nor escape the
+// archive root, and PathRegex must compile and, with FileTypes, select at
+// least one of its files. The upload then sets UnzipKeepStructure,
+// PathRegex and FileTypes from opts, and the extraction task is followed
+// until it ends, so that failures can be reported per entry.
+//
+// Parameters:
+//   - ctx: context for the requests
+//   - zipPath: the local zip archive (required)
+//   - volumeID: the target volume ID (required)
+//   - opts: optional extraction filters and settings; nil extracts every
+//     file at the volume root
+//
+// Returns:
+//   - *ZipImportResult: the upload response, the task and the per-entry outcomes
+//   - error: an error if the archive is invalid, the upload failed, or the
+//     task did not succeed; the result is returned in the latter case too
+//
+// Example:
+//
+//	res, err := sdkClient.ImportZipToVolume(ctx, "./reports.zip", volumeID, &sdk.ZipImportOptions{
+//		KeepStructure: true,
+//		PathRegex:     `^2024/.*\.pdf$`,
+//		FileTypes:     []sdk.FileType{sdk.FileTypePDF},
+//	})
+//	if err != nil {
+//		for _, e := range res.Failed() {
+//			log.Printf("%s: %v", e.Path, e.Err)
+//		}
+//	}
+func (c *SDKClient) ImportZipToVolume(ctx context.Context, zipPath string, volumeID VolumeID, opts *ZipImportOptions) (*ZipImportResult, error) {
+	if strings.TrimSpace(zipPath) == "" {
+		return nil, fmt.Errorf("zip_path is required")
+	}
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	var cfg ZipImportOptions
+	if opts != nil {
+		cfg = *opts
+	}
+	entries, err := checkZipArchive(zipPath, cfg.PathRegex, cfg.FileTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(zipPath)
+	if err != nil {
+		return nil, fmt.Errorf("open file %s: %w", zipPath, err)
+	}
+	defer file.Close()
+	name := filepath.Base(zipPath)
+	meta := FileMeta{Filename: name, Path: name}
+	if meta.Hash, err = localMD5(zipPath); err != nil {
+		return nil, fmt.Errorf("hash file %s: %w", zipPath, err)
+	}
+	upload, err := c.raw.UploadConnectorFile(ctx, &UploadFileRequest{
+		VolumeID:           volumeID,
+		Files:              []FileUploadItem{{File: file, FileName: name}},
+		Meta:               []FileMeta{meta},
+		FileTypes:          FileTypeCodes(cfg.FileTypes...),
+		PathRegex:          cfg.PathRegex,
+		UnzipKeepStructure: cfg.KeepStructure,
+		DedupConfig:        cfg.Dedup,
+	}, cfg.CallOptions...)
+	if err != nil {
+		return nil, err
+	}
+	result := &ZipImportResult{Upload: upload, Entries: entries}
+	if cfg.NoWait || upload.TaskId == 0 {
+		return result, nil
+	}
+
+	task, err := c.NewTaskJob(TaskID(upload.TaskId), cfg.PollInterval, cfg.CallOptions...).Wait(ctx)
+	result.Task = task
+	if task != nil {
+		result.Entries = matchZipFailures(result.Entries, loadFailures(task))
+	}
+	return result, err
+}
+
+// checkZipArchive lists the files of the archive at zipPath, marking those
+// that pathRegex and fileTypes select, and rejects archives the server
+// could not safely extract.
+func checkZipArchive(zipPath, pathRegex string, fileTypes []FileType) ([]ZipEntryResult, error) {
+	if err := ValidatePathRegex(pathRegex); err != nil {
+		return nil, err
+	}
+	var re *regexp.Regexp
+	if pathRegex != "" {
+		re = regexp.MustCompile(pathRegex)
+	}
+
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		if errors.Is(err, zip.ErrFormat) {
+			return nil, &ValidationError{Fields: []FieldError{{Field: "zip_path", Message: fmt.Sprintf("%s is not a zip archive", zipPath)}}}
+		}
+		return nil, fmt.Errorf("open archive %s: %w", zipPath, err)
+	}
+	defer r.Close()
+
+	var (
+		errs     fieldErrors
+		entries  []ZipEntryResult
+		selected int
+	)
+	for _, f := range r.File {
+		errs.check(isSafeArchivePath(f.Name), "zip_path", fmt.Sprintf("entry %q escapes the archive root", f.Name))
+		// Bit 0 of the general purpose flags marks an encrypted entry.
+		errs.check(f.Flags&0x1 == 0, "zip_path", fmt.Sprintf("entry %q is encrypted", f.Name))
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		e := ZipEntryResult{
+			Path: f.Name,
+			Size: int64(f.UncompressedSize64),
+			Selected: (re == nil || re.MatchString(f.Name)) &&
+				(len(fileTypes) == 0 || slices.Contains(fileTypes, DetectFileType(f.Name))),
+		}
+		if e.Selected {
+			selected++
+		}
+		entries = append(entries, e)
+	}
+	errs.check(len(entries) > 0, "zip_path", fmt.Sprintf("%s contains no files", zipPath))
+	errs.check(len(entries) == 0 || selected > 0, "path_regex", fmt.Sprintf("path_regex %q and file_types %v select none of the %d files of the archive", pathRegex, fileTypes, len(entries)))
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// isSafeArchivePath reports whether an entry path stays inside the
+// directory the archive is extracted to.
+func isSafeArchivePath(name string) bool {
+	if name == "" || strings.ContainsRune(name, 0) || strings.Contains(name, `\`) || path.IsAbs(name) {
+		return false
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// matchZipFailures attaches the failures of the extraction task to the
+// entries they name. The task may report a file under the archive name or
+// the target folder, so a failure matches the entry whose path ends its
+// own. Failures that match no entry are appended.
+func matchZipFailures(entries []ZipEntryResult, failures []LoadFileFailure) []ZipEntryResult {
+	for _, f := range failures {
+		err := errors.New(f.Reason)
+		matched := false
+		for i := range entries {
+			if f.File == entries[i].Path || strings.HasSuffix(f.File, "/"+entries[i].Path) {
+				entries[i].Err = err
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			entries = append(entries, ZipEntryResult{Path: f.File, Selected: true, Err: err})
+		}
+	}
+	return entries
+}
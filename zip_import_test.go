@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"archive/zip"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestZip writes an archive holding the given files to a temporary
+// directory and returns its path.
+func writeTestZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "archive.zip")
+	f, err := os.Create(p)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+	return p
+}
+
+func TestImportZipToVolume(t *testing.T) {
+	t.Parallel()
+	zipPath := writeTestZip(t, map[string]string{
+		"2024/a.pdf":  "a",
+		"2024/b.pdf":  "bb",
+		"notes.txt":   "notes",
+		"2024/empty/": "",
+	})
+
+	var form map[string][]string
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			form = r.MultipartForm.Value
+			require.Equal(t, "archive.zip", r.MultipartForm.File["file"][0].Filename)
+			writeEnvelope(w, UploadFileResponse{Success: true, TaskId: 42})
+		},
+		"/task/get": func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "42", r.URL.Query().Get("task_id"))
+			writeEnvelope(w, TaskInfoResponse{
+				Status:      TaskStatusFailed,
+				SourceFiles: [][]string{{"archive.zip", "2024", "a.pdf"}, {"archive.zip", "2024", "b.pdf"}},
+				LoadResults: []*LoadResult{{Lines: 1}, {Reason: "corrupt pdf"}},
+			})
+		},
+	})
+
+	res, err := NewSDKClient(raw).ImportZipToVolume(context.Background(), zipPath, "vol-1", &ZipImportOptions{
+		KeepStructure: true,
+		PathRegex:     `\.pdf$`,
+		FileTypes:     []FileType{FileTypePDF},
+		PollInterval:  time.Millisecond,
+	})
+	var jobErr *JobError
+	require.ErrorAs(t, err, &jobErr)
+	require.Equal(t, TaskStatusFailed, jobErr.Status)
+
+	require.Equal(t, []string{"true"}, form["unzip_keep_structure"])
+	require.Equal(t, []string{`\.pdf$`}, form["path_regex"])
+	require.Equal(t, []string{"[2]"}, form["file_types"])
+
+	require.Equal(t, int64(42), res.Upload.TaskId)
+	require.NotNil(t, res.Task)
+	require.Len(t, res.Entries, 3)
+	failed := res.Failed()
+	require.Len(t, failed, 1)
+	require.Equal(t, "2024/b.pdf", failed[0].Path)
+	require.Equal(t, int64(2), failed[0].Size)
+	require.EqualError(t, failed[0].Err, "corrupt pdf")
+	for _, e := range res.Entries {
+		require.Equal(t, e.Path != "notes.txt", e.Selected, e.Path)
+	}
+}
+
+func TestImportZipToVolumeNoWait(t *testing.T) {
+	t.Parallel()
+	zipPath := writeTestZip(t, map[string]string{"a.txt": "a"})
+	raw := newMockClient(t, map[string]http.HandlerFunc{
+		"/connectors/upload": func(w http.ResponseWriter, r *http.Request) {
+			writeEnvelope(w, UploadFileResponse{Success: true, TaskId: 42})
+		},
+	})
+	res, err := NewSDKClient(raw).ImportZipToVolume(context.Background(), zipPath, "vol-1", &ZipImportOptions{NoWait: true})
+	require.NoError(t, err)
+	require.Nil(t, res.Task)
+	require.Equal(t, []ZipEntryResult{{Path: "a.txt", Size: 1, Selected: true}}, res.Entries)
+}
+
+func TestImportZipToVolumeValidation(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newMockClient(t, nil))
+	ctx := context.Background()
+
+	_, err := client.ImportZipToVolume(ctx, "", "vol-1", nil)
+	require.ErrorContains(t, err, "zip_path is required")
+
+	notZip := filepath.Join(t.TempDir(), "plain.zip")
+	require.NoError(t, os.WriteFile(notZip, []byte("not an archive"), 0o644))
+	_, err = client.ImportZipToVolume(ctx, notZip, "vol-1", nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	require.ErrorContains(t, err, "is not a zip archive")
+
+	_, err = client.ImportZipToVolume(ctx, writeTestZip(t, map[string]string{"../evil.txt": "x"}), "vol-1", nil)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	require.ErrorContains(t, err, "escapes the archive root")
+
+	zipPath := writeTestZip(t, map[string]string{"a.txt": "a"})
+	_, err = client.ImportZipToVolume(ctx, zipPath, "vol-1", &ZipImportOptions{PathRegex: "("})
+	require.ErrorContains(t, err, "invalid path_regex")
+	_, err = client.ImportZipToVolume(ctx, zipPath, "vol-1", &ZipImportOptions{PathRegex: `\.pdf$`})
+	require.ErrorContains(t, err, "select none of the 1 files")
+	_, err = client.ImportZipToVolume(ctx, zipPath, "vol-1", &ZipImportOptions{FileTypes: []FileType{FileTypePDF}})
+	require.ErrorContains(t, err, "file_types [pdf] select none of the 1 files")
+
+	_, err = client.ImportZipToVolume(ctx, writeTestZip(t, map[string]string{"empty/": ""}), "vol-1", nil)
+	require.ErrorContains(t, err, "contains no files")
+}